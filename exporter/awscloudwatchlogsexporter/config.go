@@ -19,6 +19,8 @@ import (
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
 )
 
 // Config represent a configuration for the CloudWatch logs exporter.
@@ -45,6 +47,21 @@ type Config struct {
 	// Optional.
 	Endpoint string `mapstructure:"endpoint"`
 
+	// SigningRegion is the region requests sent to Endpoint are signed for.
+	// It's derived from the hostname of Endpoint, or Region, by default.
+	// Optional.
+	SigningRegion string `mapstructure:"signing_region"`
+
+	// UseFIPSEndpoint sends the logs to the FIPS endpoint of CloudWatch Logs
+	// in Region.
+	// Optional.
+	UseFIPSEndpoint bool `mapstructure:"use_fips_endpoint"`
+
+	// UseDualStackEndpoint sends the logs to the dual-stack (IPv4 and IPv6)
+	// endpoint of CloudWatch Logs in Region.
+	// Optional.
+	UseDualStackEndpoint bool `mapstructure:"use_dualstack_endpoint"`
+
 	// QueueSettings is a subset of exporterhelper.QueueSettings,
 	// because only QueueSize is user-settable due to how AWS CloudWatch API works
 	QueueSettings QueueSettings `mapstructure:"sending_queue"`
@@ -68,7 +85,16 @@ func (config *Config) Validate() error {
 	if config.QueueSettings.QueueSize < 1 {
 		return errors.New("'sending_queue.queue_size' must be 1 or greater")
 	}
-	return nil
+	return config.endpointSettings().Validate()
+}
+
+func (config *Config) endpointSettings() awsutil.EndpointSettings {
+	return awsutil.EndpointSettings{
+		Endpoint:             config.Endpoint,
+		UseFIPSEndpoint:      config.UseFIPSEndpoint,
+		UseDualStackEndpoint: config.UseDualStackEndpoint,
+		SigningRegion:        config.SigningRegion,
+	}
 }
 
 func (config *Config) enforcedQueueSettings() exporterhelper.QueueSettings {
@@ -37,7 +37,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Exporters), 2)
+	assert.Equal(t, len(cfg.Exporters), 3)
 
 	defaultRetrySettings := exporterhelper.DefaultRetrySettings()
 
@@ -77,6 +77,40 @@ func TestLoadConfig(t *testing.T) {
 		},
 		e2,
 	)
+
+	e3 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "e3-vpc-endpoint")].(*Config)
+
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "e3-vpc-endpoint")),
+			RetrySettings:    defaultRetrySettings,
+			LogGroupName:     "test-3",
+			LogStreamName:    "testing",
+			Endpoint:         "https://vpce-0123-abcd.logs.us-gov-west-1.vpce.amazonaws.com",
+			QueueSettings: QueueSettings{
+				QueueSize: exporterhelper.DefaultQueueSettings().QueueSize,
+			},
+		},
+		e3,
+	)
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "test"
+	cfg.LogStreamName = "testing"
+	cfg.UseFIPSEndpoint = true
+	cfg.UseDualStackEndpoint = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Endpoint = "logs.us-east-1.amazonaws.com"
+	assert.EqualError(t, cfg.Validate(), "'endpoint' can't be combined with 'use_fips_endpoint' or 'use_dualstack_endpoint'")
+
+	cfg.UseFIPSEndpoint = false
+	cfg.UseDualStackEndpoint = false
+	cfg.Endpoint = ""
+	cfg.SigningRegion = "us-east-1"
+	assert.EqualError(t, cfg.Validate(), "'signing_region' requires 'endpoint'")
 }
 
 func TestFailedLoadConfig(t *testing.T) {
@@ -28,6 +28,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
 )
 
 type exporter struct {
@@ -48,8 +50,9 @@ func (e *exporter) Start(ctx context.Context, host component.Host) error {
 		if e.config.Region != "" {
 			awsConfig.Region = aws.String(e.config.Region)
 		}
-		if e.config.Endpoint != "" {
-			awsConfig.Endpoint = aws.String(e.config.Endpoint)
+		if err := awsutil.ConfigureEndpoint(awsConfig, e.config.endpointSettings()); err != nil {
+			startErr = err
+			return
 		}
 		awsConfig.MaxRetries = aws.Int(1) // retry will be handled by the collector queue
 		sess, err := session.NewSession(awsConfig)
@@ -15,12 +15,18 @@
 package awscloudwatchlogsexporter
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
 )
 
 func TestLogToCWLog(t *testing.T) {
@@ -200,3 +206,27 @@ func TestAttrValue(t *testing.T) {
 		})
 	}
 }
+
+func TestStartSignsForEndpointRegion(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "")
+
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"logStreams":[{"logStreamName":"testing","uploadSequenceToken":"token"}]}`))
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogGroupName = "test"
+	cfg.LogStreamName = "testing"
+	cfg.Endpoint = srv.URL
+	cfg.SigningRegion = "us-gov-west-1"
+
+	exp := &exporter{config: cfg, logger: zap.NewNop()}
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, "token", exp.seqToken)
+	assert.Contains(t, authorization, "/us-gov-west-1/logs/aws4_request")
+}
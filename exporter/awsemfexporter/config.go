@@ -88,8 +88,12 @@ type MetricDescriptor struct {
 	overwrite bool `mapstructure:"overwrite"`
 }
 
-// Validate filters out invalid metricDeclarations and metricDescriptors
+// Validate checks the endpoint settings and filters out invalid metricDeclarations and metricDescriptors
 func (config *Config) Validate() error {
+	if err := config.EndpointSettings().Validate(); err != nil {
+		return err
+	}
+
 	validDeclarations := []*MetricDeclaration{}
 	for _, declaration := range config.MetricDeclarations {
 		err := declaration.init(config.logger)
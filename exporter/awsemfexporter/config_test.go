@@ -40,7 +40,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, 3, len(cfg.Exporters))
+	assert.Equal(t, 4, len(cfg.Exporters))
 
 	r0 := cfg.Exporters[config.NewComponentID(typeStr)]
 	assert.Equal(t, factory.CreateDefaultConfig(), r0)
@@ -69,9 +69,35 @@ func TestLoadConfig(t *testing.T) {
 			MetricDescriptors:               []MetricDescriptor{},
 		}, r1)
 
-	r2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "resource_attr_to_label")].(*Config)
+	r2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "fips")].(*Config)
 	assert.NoError(t, r2.Validate())
-	assert.Equal(t, r2,
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "fips")),
+			AWSSessionSettings: awsutil.AWSSessionSettings{
+				NumberOfWorkers:       8,
+				Endpoint:              "",
+				UseFIPSEndpoint:       true,
+				UseDualStackEndpoint:  true,
+				RequestTimeoutSeconds: 30,
+				MaxRetries:            2,
+				NoVerifySSL:           false,
+				ProxyAddress:          "",
+				Region:                "us-gov-west-1",
+				RoleARN:               "",
+			},
+			LogGroupName:                    "",
+			LogStreamName:                   "",
+			DimensionRollupOption:           "ZeroAndSingleDimensionRollup",
+			OutputDestination:               "cloudwatch",
+			ParseJSONEncodedAttributeValues: make([]string, 0),
+			MetricDeclarations:              []*MetricDeclaration{},
+			MetricDescriptors:               []MetricDescriptor{},
+		}, r2)
+
+	r3 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "resource_attr_to_label")].(*Config)
+	assert.NoError(t, r3.Validate())
+	assert.Equal(t, r3,
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "resource_attr_to_label")),
 			AWSSessionSettings: awsutil.AWSSessionSettings{
@@ -121,3 +147,12 @@ func TestConfigValidate(t *testing.T) {
 		{unit: "Megabytes", metricName: "memory_usage"},
 	}, cfg.MetricDescriptors)
 }
+
+func TestConfigValidateEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "https://vpce-0123-abcd.logs.us-east-1.vpce.amazonaws.com"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.UseFIPSEndpoint = true
+	assert.EqualError(t, cfg.Validate(), "'endpoint' can't be combined with 'use_fips_endpoint' or 'use_dualstack_endpoint'")
+}
@@ -16,6 +16,7 @@ package awsxrayexporter
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/xray"
@@ -26,8 +27,8 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter/internal/translator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray/translator"
 )
 
 const (
@@ -38,57 +39,71 @@ const (
 // request and then posts the request to the configured region's X-Ray endpoint.
 func newTracesExporter(
 	config config.Exporter, set component.ExporterCreateSettings, cn awsutil.ConnAttr) (component.TracesExporter, error) {
-	typeLog := zap.String("type", string(config.ID().Type()))
-	nameLog := zap.String("name", config.ID().String())
 	logger := set.Logger
-	awsConfig, session, err := awsutil.GetAWSConfigSession(logger, cn, &config.(*Config).AWSSessionSettings)
+	eCfg := config.(*Config)
+	awsConfig, session, err := awsutil.GetAWSConfigSession(logger, cn, &eCfg.AWSSessionSettings)
 	if err != nil {
 		return nil, err
 	}
 	xrayClient := newXRay(logger, awsConfig, set.BuildInfo, session)
+	router := newRoleRouter(eCfg.RoleRouting, &xrayClient, func(roleARN string) (segmentsClient, error) {
+		settings := eCfg.AWSSessionSettings
+		settings.RoleARN = roleARN
+		roleConfig, roleSession, err := awsutil.GetAWSConfigSession(logger, cn, &settings)
+		if err != nil {
+			return nil, err
+		}
+		roleClient := newXRay(logger, roleConfig, set.BuildInfo, roleSession)
+		return &roleClient, nil
+	})
+	return newRoutingTracesExporter(eCfg, set, router)
+}
+
+// newRoutingTracesExporter creates the exporter sending the segments of each resource with the
+// client selected by the router.
+func newRoutingTracesExporter(eCfg *Config, set component.ExporterCreateSettings, router *roleRouter) (component.TracesExporter, error) {
+	typeLog := zap.String("type", string(eCfg.ID().Type()))
+	nameLog := zap.String("name", eCfg.ID().String())
+	logger := set.Logger
 	return exporterhelper.NewTracesExporter(
-		config,
+		eCfg,
 		set,
 		func(ctx context.Context, td pdata.Traces) error {
-			var err error
 			logger.Debug("TracesExporter", typeLog, nameLog, zap.Int("#spans", td.SpanCount()))
-			documents := make([]*string, 0, td.SpanCount())
-			for i := 0; i < td.ResourceSpans().Len(); i++ {
-				rspans := td.ResourceSpans().At(i)
-				resource := rspans.Resource()
-				for j := 0; j < rspans.InstrumentationLibrarySpans().Len(); j++ {
-					spans := rspans.InstrumentationLibrarySpans().At(j).Spans()
-					for k := 0; k < spans.Len(); k++ {
-						document, localErr := translator.MakeSegmentDocumentString(spans.At(k), resource,
-							config.(*Config).IndexedAttributes, config.(*Config).IndexAllAttributes)
-						if localErr != nil {
-							logger.Debug("Error translating span.", zap.Error(localErr))
-							continue
-						}
-						documents = append(documents, &document)
-					}
+			batches := router.route(td)
+			var errs, permanentErrs []error
+			failed := pdata.NewTraces()
+			for _, batch := range batches {
+				err := batch.err
+				if err == nil {
+					err = putSegments(logger, batch.client, translateResources(logger, eCfg, batch.resources))
 				}
-			}
-			for offset := 0; offset < len(documents); offset += maxSegmentsPerPut {
-				nextOffset := offset + maxSegmentsPerPut
-				if nextOffset > td.SpanCount() {
-					nextOffset = td.SpanCount()
+				if err == nil {
+					continue
 				}
-				input := xray.PutTraceSegmentsInput{TraceSegmentDocuments: documents[offset:nextOffset]}
-				logger.Debug("request: " + input.String())
-				output, localErr := xrayClient.PutTraceSegments(&input)
-				if localErr != nil {
-					logger.Debug("response error", zap.Error(localErr))
-					err = wrapErrorIfBadRequest(&localErr) // record error
+				if len(batches) == 1 {
+					return err
 				}
-				if output != nil {
-					logger.Debug("response: " + output.String())
+				if batch.roleARN != "" {
+					err = fmt.Errorf("failed to send the segments of role %s: %w", batch.roleARN, err)
 				}
-				if err != nil {
-					break
+				if consumererror.IsPermanent(err) {
+					permanentErrs = append(permanentErrs, err)
+					continue
 				}
+				errs = append(errs, err)
+				for _, rs := range batch.resources {
+					rs.CopyTo(failed.ResourceSpans().AppendEmpty())
+				}
+			}
+			if len(errs) == 0 {
+				return consumererror.Combine(permanentErrs)
 			}
-			return err
+			// Only the segments of the failed roles are retried, the rejected ones are dropped.
+			for _, err := range permanentErrs {
+				logger.Error("Dropping the segments rejected by X-Ray", zap.Error(err))
+			}
+			return consumererror.NewTraces(consumererror.Combine(errs), failed)
 		},
 		exporterhelper.WithShutdown(func(context.Context) error {
 			_ = logger.Sync()
@@ -97,6 +112,51 @@ func newTracesExporter(
 	)
 }
 
+func translateResources(logger *zap.Logger, eCfg *Config, resources []pdata.ResourceSpans) []*string {
+	var documents []*string
+	for _, rspans := range resources {
+		resource := rspans.Resource()
+		for j := 0; j < rspans.InstrumentationLibrarySpans().Len(); j++ {
+			spans := rspans.InstrumentationLibrarySpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				document, localErr := translator.TranslateSpanDocument(spans.At(k), translator.TranslateOptions{
+					Resource:           resource,
+					IndexedAttributes:  eCfg.IndexedAttributes,
+					IndexAllAttributes: eCfg.IndexAllAttributes,
+					Events:             eCfg.SpanEvents,
+				})
+				if localErr != nil {
+					logger.Debug("Error translating span.", zap.Error(localErr))
+					continue
+				}
+				documents = append(documents, &document)
+			}
+		}
+	}
+	return documents
+}
+
+// putSegments sends the documents in batches of the maximum size of PutTraceSegments.
+func putSegments(logger *zap.Logger, client segmentsClient, documents []*string) error {
+	for offset := 0; offset < len(documents); offset += maxSegmentsPerPut {
+		nextOffset := offset + maxSegmentsPerPut
+		if nextOffset > len(documents) {
+			nextOffset = len(documents)
+		}
+		input := xray.PutTraceSegmentsInput{TraceSegmentDocuments: documents[offset:nextOffset]}
+		logger.Debug("request: " + input.String())
+		output, localErr := client.PutTraceSegments(&input)
+		if localErr != nil {
+			logger.Debug("response error", zap.Error(localErr))
+			return wrapErrorIfBadRequest(&localErr)
+		}
+		if output != nil {
+			logger.Debug("response: " + output.String())
+		}
+	}
+	return nil
+}
+
 func wrapErrorIfBadRequest(err *error) error {
 	_, ok := (*err).(awserr.RequestFailure)
 	if ok && (*err).(awserr.RequestFailure).StatusCode() < 500 {
@@ -15,9 +15,15 @@
 package awsxrayexporter
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"go.opentelemetry.io/collector/config"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray/translator"
 )
 
 // Config defines configuration for AWS X-Ray exporter.
@@ -33,4 +39,53 @@ type Config struct {
 	// Set to true to convert all OpenTelemetry attributes to X-Ray annotation (indexed) ignoring the IndexedAttributes option.
 	// Default value: false
 	IndexAllAttributes bool `mapstructure:"index_all_attributes"`
+	// SpanEvents controls how span events other than exceptions are converted: "drop" discards them,
+	// "subsegments" converts them to zero-duration subsegments, and "metadata" records them in the
+	// segment metadata. Exception events are always converted to the cause of the segment.
+	// Default value: drop
+	SpanEvents translator.EventsMode `mapstructure:"span_events"`
+	// RoleRouting sends the segments of each resource to the account of the IAM role selected by one of
+	// its attributes, so that a central collector can send the traces of each team to their own account.
+	RoleRouting RoleRoutingSettings `mapstructure:"role_routing"`
+}
+
+// RoleRoutingSettings selects the IAM role assumed to send the segments of a resource.
+type RoleRoutingSettings struct {
+	// AttributeKey is the resource attribute selecting the role, such as cloud.account.id.
+	AttributeKey string `mapstructure:"attribute_key"`
+	// Roles maps the values of the attribute to the ARN of the role to assume. The segments of the
+	// resources without a role are sent with the credentials of role_arn, or the default credentials.
+	Roles map[string]string `mapstructure:"roles"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.SpanEvents {
+	case translator.EventsModeDrop, translator.EventsModeSubsegments, translator.EventsModeMetadata:
+	default:
+		return fmt.Errorf("invalid span_events %q, must be one of %q, %q or %q", cfg.SpanEvents,
+			translator.EventsModeDrop, translator.EventsModeSubsegments, translator.EventsModeMetadata)
+	}
+	return cfg.RoleRouting.validate()
+}
+
+func (s *RoleRoutingSettings) validate() error {
+	switch {
+	case s.AttributeKey == "" && len(s.Roles) == 0:
+		return nil
+	case s.AttributeKey == "":
+		return errors.New("role_routing::attribute_key must be specified with roles")
+	case len(s.Roles) == 0:
+		return errors.New("role_routing::roles must be specified with attribute_key")
+	}
+	for value, roleARN := range s.Roles {
+		parsed, err := arn.Parse(roleARN)
+		if err != nil {
+			return fmt.Errorf("invalid role ARN %q for %q: %w", roleARN, value, err)
+		}
+		if parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+			return fmt.Errorf("invalid role ARN %q for %q: not an IAM role", roleARN, value)
+		}
+	}
+	return nil
 }
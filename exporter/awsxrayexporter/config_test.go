@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray/translator"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -61,5 +62,79 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IndexedAttributes:  []string{"indexed_attr_0", "indexed_attr_1"},
 			IndexAllAttributes: false,
+			SpanEvents:         translator.EventsModeSubsegments,
+			RoleRouting: RoleRoutingSettings{
+				AttributeKey: "cloud.account.id",
+				Roles: map[string]string{
+					"111111111111": "arn:aws:iam::111111111111:role/xray-writer",
+					"222222222222": "arn:aws:iam::222222222222:role/xray-writer",
+				},
+			},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SpanEvents = translator.EventsModeMetadata
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SpanEvents = "annotations"
+	assert.EqualError(t, cfg.Validate(), `invalid span_events "annotations", must be one of "drop", "subsegments" or "metadata"`)
+}
+
+func TestValidateRoleRouting(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings RoleRoutingSettings
+		err      string
+	}{
+		{name: "disabled"},
+		{
+			name: "roles",
+			settings: RoleRoutingSettings{
+				AttributeKey: "cloud.account.id",
+				Roles:        map[string]string{"111111111111": "arn:aws:iam::111111111111:role/teams/xray-writer"},
+			},
+		},
+		{
+			name:     "missing attribute key",
+			settings: RoleRoutingSettings{Roles: map[string]string{"111111111111": "arn:aws:iam::111111111111:role/xray-writer"}},
+			err:      "role_routing::attribute_key must be specified with roles",
+		},
+		{
+			name:     "missing roles",
+			settings: RoleRoutingSettings{AttributeKey: "cloud.account.id"},
+			err:      "role_routing::roles must be specified with attribute_key",
+		},
+		{
+			name: "invalid ARN",
+			settings: RoleRoutingSettings{
+				AttributeKey: "cloud.account.id",
+				Roles:        map[string]string{"111111111111": "xray-writer"},
+			},
+			err: `invalid role ARN "xray-writer" for "111111111111": arn: invalid prefix`,
+		},
+		{
+			name: "not a role",
+			settings: RoleRoutingSettings{
+				AttributeKey: "cloud.account.id",
+				Roles:        map[string]string{"111111111111": "arn:aws:iam::111111111111:user/xray-writer"},
+			},
+			err: `invalid role ARN "arn:aws:iam::111111111111:user/xray-writer" for "111111111111": not an IAM role`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.RoleRouting = tt.settings
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
 		})
+	}
 }
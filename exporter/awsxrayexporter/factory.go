@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray/translator"
 )
 
 const (
@@ -41,6 +42,7 @@ func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings:   config.NewExporterSettings(config.NewComponentID(typeStr)),
 		AWSSessionSettings: awsutil.CreateDefaultSessionConfig(),
+		SpanEvents:         translator.EventsModeDrop,
 	}
 }
 
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/awsutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray/translator"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -45,6 +46,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 			ResourceARN:           "",
 			RoleARN:               "",
 		},
+		SpanEvents: translator.EventsModeDrop,
 	}, "failed to create default config")
 	assert.NoError(t, configtest.CheckConfigStruct(cfg))
 }
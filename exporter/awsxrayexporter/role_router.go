@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsxrayexporter
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/xray"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// segmentsClient sends segment documents to X-Ray.
+type segmentsClient interface {
+	PutTraceSegments(input *xray.PutTraceSegmentsInput) (*xray.PutTraceSegmentsOutput, error)
+}
+
+// segmentsBatch holds the resources whose segments are sent with the same
+// client, or the error creating it.
+type segmentsBatch struct {
+	roleARN   string
+	client    segmentsClient
+	err       error
+	resources []pdata.ResourceSpans
+}
+
+// roleRouter selects the client sending the segments of each resource from
+// the role mapped to one of its attributes. The clients of the roles are
+// created on first use, and kept along with the credentials of their role.
+type roleRouter struct {
+	attributeKey  string
+	roles         map[string]string
+	defaultClient segmentsClient
+	newClient     func(roleARN string) (segmentsClient, error)
+
+	mu      sync.Mutex
+	clients map[string]segmentsClient
+}
+
+func newRoleRouter(settings RoleRoutingSettings, defaultClient segmentsClient, newClient func(roleARN string) (segmentsClient, error)) *roleRouter {
+	return &roleRouter{
+		attributeKey:  settings.AttributeKey,
+		roles:         settings.Roles,
+		defaultClient: defaultClient,
+		newClient:     newClient,
+		clients:       map[string]segmentsClient{},
+	}
+}
+
+// route groups the resources of the traces by client, in the order they
+// first appear.
+func (r *roleRouter) route(td pdata.Traces) []*segmentsBatch {
+	rss := td.ResourceSpans()
+	if len(r.roles) == 0 {
+		batch := &segmentsBatch{client: r.defaultClient}
+		for i := 0; i < rss.Len(); i++ {
+			batch.resources = append(batch.resources, rss.At(i))
+		}
+		return []*segmentsBatch{batch}
+	}
+
+	var batches []*segmentsBatch
+	byRole := map[string]*segmentsBatch{}
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		roleARN := r.roleARN(rs.Resource())
+		batch, ok := byRole[roleARN]
+		if !ok {
+			batch = &segmentsBatch{roleARN: roleARN}
+			batch.client, batch.err = r.client(roleARN)
+			byRole[roleARN] = batch
+			batches = append(batches, batch)
+		}
+		batch.resources = append(batch.resources, rs)
+	}
+	return batches
+}
+
+// roleARN returns the role mapped to the attribute of the resource, or ""
+// for the default client.
+func (r *roleRouter) roleARN(resource pdata.Resource) string {
+	value, ok := resource.Attributes().Get(r.attributeKey)
+	if !ok {
+		return ""
+	}
+	return r.roles[value.AsString()]
+}
+
+func (r *roleRouter) client(roleARN string) (segmentsClient, error) {
+	if roleARN == "" {
+		return r.defaultClient, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if client, ok := r.clients[roleARN]; ok {
+		return client, nil
+	}
+	// Failures aren't cached, the client is created again with the next
+	// traces of the role.
+	client, err := r.newClient(roleARN)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[roleARN] = client
+	return client, nil
+}
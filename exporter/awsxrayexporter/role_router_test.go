@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsxrayexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	roleA = "arn:aws:iam::111111111111:role/xray-writer"
+	roleB = "arn:aws:iam::222222222222:role/xray-writer"
+)
+
+// fakeSegmentsClient records the documents it's sent, or fails with err.
+type fakeSegmentsClient struct {
+	mu        sync.Mutex
+	documents int
+	err       error
+}
+
+func (c *fakeSegmentsClient) PutTraceSegments(input *xray.PutTraceSegmentsInput) (*xray.PutTraceSegmentsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.documents += len(input.TraceSegmentDocuments)
+	return &xray.PutTraceSegmentsOutput{}, nil
+}
+
+// newTestRouter routes the cloud.account.id 111111111111 and 222222222222
+// to the clients of roleA and roleB.
+func newTestRouter(defaultClient segmentsClient, clients map[string]*fakeSegmentsClient) (*roleRouter, *int) {
+	created := 0
+	router := newRoleRouter(RoleRoutingSettings{
+		AttributeKey: conventions.AttributeCloudAccountID,
+		Roles: map[string]string{
+			"111111111111": roleA,
+			"222222222222": roleB,
+		},
+	}, defaultClient, func(roleARN string) (segmentsClient, error) {
+		client, ok := clients[roleARN]
+		if !ok {
+			return nil, errors.New("AccessDenied: not authorized to perform sts:AssumeRole")
+		}
+		created++
+		return client, nil
+	})
+	return router, &created
+}
+
+func tracesOfAccounts(accounts ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, account := range accounts {
+		rs := td.ResourceSpans().AppendEmpty()
+		if account != "" {
+			rs.Resource().Attributes().InsertString(conventions.AttributeCloudAccountID, account)
+		}
+		constructHTTPServerSpan().CopyTo(rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty())
+	}
+	return td
+}
+
+func TestRoleRouterRoute(t *testing.T) {
+	defaultClient := &fakeSegmentsClient{}
+	clientA, clientB := &fakeSegmentsClient{}, &fakeSegmentsClient{}
+	router, created := newTestRouter(defaultClient, map[string]*fakeSegmentsClient{roleA: clientA, roleB: clientB})
+
+	batches := router.route(tracesOfAccounts("111111111111", "", "222222222222", "111111111111", "333333333333"))
+	require.Len(t, batches, 3)
+	assert.Equal(t, roleA, batches[0].roleARN)
+	assert.Same(t, clientA, batches[0].client)
+	assert.Len(t, batches[0].resources, 2)
+	// The resources without attribute or role are sent with the default client.
+	assert.Equal(t, "", batches[1].roleARN)
+	assert.Same(t, defaultClient, batches[1].client)
+	assert.Len(t, batches[1].resources, 2)
+	assert.Same(t, clientB, batches[2].client)
+
+	// The clients are created once per role.
+	router.route(tracesOfAccounts("111111111111", "222222222222"))
+	assert.Equal(t, 2, *created)
+}
+
+func TestRoleRouterRouteDisabled(t *testing.T) {
+	defaultClient := &fakeSegmentsClient{}
+	router := newRoleRouter(RoleRoutingSettings{}, defaultClient, nil)
+	batches := router.route(tracesOfAccounts("111111111111", "222222222222"))
+	require.Len(t, batches, 1)
+	assert.Same(t, defaultClient, batches[0].client)
+	assert.Len(t, batches[0].resources, 2)
+}
+
+func TestRoleRouterClientError(t *testing.T) {
+	router, created := newTestRouter(&fakeSegmentsClient{}, map[string]*fakeSegmentsClient{roleA: {}})
+	batches := router.route(tracesOfAccounts("222222222222"))
+	require.Len(t, batches, 1)
+	assert.EqualError(t, batches[0].err, "AccessDenied: not authorized to perform sts:AssumeRole")
+	assert.Equal(t, 0, *created)
+}
+
+func newTestRoutingExporter(t *testing.T, router *roleRouter) func(pdata.Traces) error {
+	cfg := createDefaultConfig().(*Config)
+	exp, err := newRoutingTracesExporter(cfg, componenttest.NewNopExporterCreateSettings(), router)
+	require.NoError(t, err)
+	return func(td pdata.Traces) error {
+		return exp.ConsumeTraces(context.Background(), td)
+	}
+}
+
+func TestRoutingExport(t *testing.T) {
+	defaultClient, clientA, clientB := &fakeSegmentsClient{}, &fakeSegmentsClient{}, &fakeSegmentsClient{}
+	router, _ := newTestRouter(defaultClient, map[string]*fakeSegmentsClient{roleA: clientA, roleB: clientB})
+	consume := newTestRoutingExporter(t, router)
+
+	require.NoError(t, consume(tracesOfAccounts("111111111111", "", "222222222222", "111111111111")))
+	assert.Equal(t, 2, clientA.documents)
+	assert.Equal(t, 1, clientB.documents)
+	assert.Equal(t, 1, defaultClient.documents)
+}
+
+func TestRoutingExportPartialFailure(t *testing.T) {
+	defaultClient := &fakeSegmentsClient{}
+	clientA := &fakeSegmentsClient{err: errors.New("RequestError: send request failed")}
+	router, _ := newTestRouter(defaultClient, map[string]*fakeSegmentsClient{roleA: clientA})
+	consume := newTestRoutingExporter(t, router)
+
+	// The role of 222222222222 can't be assumed.
+	err := consume(tracesOfAccounts("111111111111", "", "222222222222"))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "failed to send the segments of role "+roleA+": RequestError: send request failed")
+	assert.Contains(t, err.Error(), "failed to send the segments of role "+roleB+": AccessDenied")
+	assert.Equal(t, 1, defaultClient.documents)
+
+	// Only the resources of the failed roles are retried.
+	var failed consumererror.Traces
+	require.True(t, consumererror.AsTraces(err, &failed))
+	rss := failed.GetTraces().ResourceSpans()
+	require.Equal(t, 2, rss.Len())
+	account, _ := rss.At(0).Resource().Attributes().Get(conventions.AttributeCloudAccountID)
+	assert.Equal(t, "111111111111", account.StringVal())
+	account, _ = rss.At(1).Resource().Attributes().Get(conventions.AttributeCloudAccountID)
+	assert.Equal(t, "222222222222", account.StringVal())
+}
+
+func TestRoutingExportRejected(t *testing.T) {
+	defaultClient := &fakeSegmentsClient{}
+	clientA := &fakeSegmentsClient{err: awserr.NewRequestFailure(awserr.New("InvalidRequestException", "Invalid segment", nil), 400, "1")}
+	router, _ := newTestRouter(defaultClient, map[string]*fakeSegmentsClient{roleA: clientA})
+	consume := newTestRoutingExporter(t, router)
+
+	// The segments rejected by X-Ray aren't retried.
+	err := consume(tracesOfAccounts("111111111111", ""))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, defaultClient.documents)
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/azure/managedidentity"
+)
+
+const (
+	// storageVersion is the version of the Blob service REST API.
+	storageVersion = "2020-10-02"
+
+	// maxBlockSize is the maximum size of a block staged with Put Block.
+	maxBlockSize = 100 * 1024 * 1024
+
+	// storageResource is the resource of the tokens requested to the managed
+	// identity endpoint.
+	storageResource = "https://storage.azure.com/"
+)
+
+// statusError is an unexpected HTTP status returned by the Blob service.
+type statusError struct {
+	op     string
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s failed with HTTP %d: %s", e.op, e.status, e.body)
+}
+
+// retryable returns whether the request may succeed if retried.
+func (e *statusError) retryable() bool {
+	return e.status == http.StatusRequestTimeout || e.status == http.StatusTooManyRequests || e.status >= http.StatusInternalServerError
+}
+
+// authorizer authorizes the requests sent to the Blob service.
+type authorizer interface {
+	authorize(ctx context.Context, req *http.Request) error
+}
+
+// blobClient stages and commits the blocks of block blobs with the Put Block
+// and Put Block List operations of the Blob service REST API.
+type blobClient struct {
+	client    *http.Client
+	endpoint  *url.URL
+	container string
+	auth      authorizer
+}
+
+// putBlock stages a block of a blob.
+func (c *blobClient) putBlock(ctx context.Context, blob, blockID string, data []byte) error {
+	query := url.Values{"comp": {"block"}, "blockid": {blockID}}
+	return c.do(ctx, "Put Block", blob, query, data, nil)
+}
+
+// putBlockList commits the blocks of a blob, in order. The content type is
+// set on the blob.
+func (c *blobClient) putBlockList(ctx context.Context, blob string, blockIDs []string, contentType string) error {
+	list := struct {
+		XMLName xml.Name `xml:"BlockList"`
+		Latest  []string `xml:"Latest"`
+	}{Latest: blockIDs}
+	body, err := xml.Marshal(list)
+	if err != nil {
+		return err
+	}
+	body = append([]byte(xml.Header), body...)
+	header := http.Header{"X-Ms-Blob-Content-Type": {contentType}}
+	return c.do(ctx, "Put Block List", blob, url.Values{"comp": {"blocklist"}}, body, header)
+}
+
+func (c *blobClient) do(ctx context.Context, op, blob string, query url.Values, body []byte, header http.Header) error {
+	u := *c.endpoint
+	u.Path = path.Join("/", u.Path, c.container, blob)
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("X-Ms-Version", storageVersion)
+	req.Header.Set("X-Ms-Date", time.Now().UTC().Format(http.TimeFormat))
+	if err = c.auth.authorize(ctx, req); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &statusError{op: op, status: resp.StatusCode, body: string(msg)}
+}
+
+// sasAuthorizer appends a shared access signature to the requests.
+type sasAuthorizer struct {
+	token url.Values
+}
+
+func newSASAuthorizer(token string) (*sasAuthorizer, error) {
+	values, err := url.ParseQuery(token)
+	if err != nil {
+		return nil, err
+	}
+	return &sasAuthorizer{token: values}, nil
+}
+
+func (a *sasAuthorizer) authorize(_ context.Context, req *http.Request) error {
+	query := req.URL.Query()
+	for k, v := range a.token {
+		query[k] = v
+	}
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// managedIdentityAuthorizer authorizes requests with the tokens of a managed
+// identity.
+type managedIdentityAuthorizer struct {
+	tokens *managedidentity.TokenSource
+}
+
+func newManagedIdentityAuthorizer(endpoint, clientID string) *managedIdentityAuthorizer {
+	return &managedIdentityAuthorizer{tokens: managedidentity.NewTokenSource(endpoint, storageResource, clientID)}
+}
+
+func (a *managedIdentityAuthorizer) authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.tokens.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedIdentityAuthorizer(t *testing.T) {
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, storageResource, r.URL.Query().Get("resource"))
+		assert.Equal(t, "client", r.URL.Query().Get("client_id"))
+		fmt.Fprintf(w, `{"access_token":"token","expires_on":"%d"}`, time.Now().Add(time.Hour).Unix())
+	}))
+	defer imds.Close()
+
+	a := newManagedIdentityAuthorizer(imds.URL, "client")
+	req := httptest.NewRequest(http.MethodPut, "https://account.blob.core.windows.net/c/b", nil)
+	require.NoError(t, a.authorize(context.Background(), req))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+}
+
+func TestManagedIdentityAuthorizerError(t *testing.T) {
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no identity", http.StatusBadRequest)
+	}))
+	defer imds.Close()
+
+	a := newManagedIdentityAuthorizer(imds.URL, "")
+	req := httptest.NewRequest(http.MethodPut, "https://account.blob.core.windows.net/c/b", nil)
+	err := a.authorize(context.Background(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 400")
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestSASAuthorizer(t *testing.T) {
+	a, err := newSASAuthorizer("sv=2020-10-02&sig=a%2Bb")
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "https://account.blob.core.windows.net/c/b?comp=block", nil)
+	require.NoError(t, a.authorize(context.Background(), req))
+	assert.Equal(t, "block", req.URL.Query().Get("comp"))
+	assert.Equal(t, "a+b", req.URL.Query().Get("sig"))
+}
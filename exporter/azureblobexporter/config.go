@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	partitionHour   = "hour"
+	partitionMinute = "minute"
+
+	compressionGzip = "gzip"
+	compressionNone = "none"
+
+	authManagedIdentity = "managed_identity"
+	authSASToken        = "sas_token"
+)
+
+// Config defines configuration for the Azure Blob exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Container is the name of the container blobs are written to.
+	Container string `mapstructure:"container"`
+
+	// Prefix is prepended to the names of the blobs.
+	Prefix string `mapstructure:"prefix"`
+
+	// Partition is the duration covered by a virtual folder, "hour" or "minute".
+	Partition string `mapstructure:"partition"`
+
+	// Compression of the blobs, "gzip" or "none".
+	Compression string `mapstructure:"compression"`
+
+	// BlockSize is the maximum size of the blocks staged for a batch. Larger
+	// batches are staged as several blocks.
+	BlockSize int `mapstructure:"block_size"`
+
+	// Credentials defines how requests to the storage account are authorized.
+	Credentials CredentialsConfig `mapstructure:"credentials"`
+}
+
+// CredentialsConfig defines how requests to the storage account are authorized.
+type CredentialsConfig struct {
+	// Type is "managed_identity" or "sas_token".
+	Type string `mapstructure:"type"`
+
+	// ClientID of the user-assigned managed identity to use. The
+	// system-assigned identity is used if empty.
+	ClientID string `mapstructure:"client_id"`
+
+	// SASToken is the shared access signature appended to the requests.
+	SASToken string `mapstructure:"sas_token"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	u, err := url.Parse(cfg.Endpoint)
+	if cfg.Endpoint == "" || err != nil || u.Host == "" {
+		return errors.New("endpoint must be the URL of the blob service of the storage account")
+	}
+	if cfg.Container == "" {
+		return errors.New("container must be specified")
+	}
+	if cfg.Partition != partitionHour && cfg.Partition != partitionMinute {
+		return fmt.Errorf("partition must be %q or %q", partitionHour, partitionMinute)
+	}
+	if cfg.Compression != compressionGzip && cfg.Compression != compressionNone {
+		return fmt.Errorf("compression must be %q or %q", compressionGzip, compressionNone)
+	}
+	if cfg.BlockSize <= 0 || cfg.BlockSize > maxBlockSize {
+		return fmt.Errorf("block_size must be positive and at most %d", maxBlockSize)
+	}
+	switch cfg.Credentials.Type {
+	case authManagedIdentity:
+	case authSASToken:
+		if cfg.Credentials.SASToken == "" {
+			return errors.New("credentials.sas_token must be specified with the sas_token auth type")
+		}
+		if _, err := url.ParseQuery(cfg.Credentials.SASToken); err != nil {
+			return fmt.Errorf("credentials.sas_token is invalid: %w", err)
+		}
+	default:
+		return fmt.Errorf("credentials.type must be %q or %q", authManagedIdentity, authSASToken)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "https://account.blob.core.windows.net"
+	defaultCfg.Container = "telemetry"
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewComponentID(typeStr)])
+
+	sasCfg := factory.CreateDefaultConfig().(*Config)
+	sasCfg.ExporterSettings = config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "sas"))
+	sasCfg.Endpoint = "https://account.blob.core.windows.net"
+	sasCfg.Container = "archive"
+	sasCfg.Prefix = "collector"
+	sasCfg.Partition = partitionMinute
+	sasCfg.Compression = compressionNone
+	sasCfg.BlockSize = 1048576
+	sasCfg.Credentials = CredentialsConfig{Type: authSASToken, SASToken: "sv=2020-10-02&sig=abc"}
+	sasCfg.QueueSettings.Enabled = false
+	assert.Equal(t, sasCfg, cfg.Exporters[config.NewComponentIDWithName(typeStr, "sas")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "account" },
+			err:    "endpoint must be the URL of the blob service of the storage account",
+		},
+		{
+			name:   "container",
+			modify: func(cfg *Config) { cfg.Container = "" },
+			err:    "container must be specified",
+		},
+		{
+			name:   "partition",
+			modify: func(cfg *Config) { cfg.Partition = "day" },
+			err:    `partition must be "hour" or "minute"`,
+		},
+		{
+			name:   "compression",
+			modify: func(cfg *Config) { cfg.Compression = "zstd" },
+			err:    `compression must be "gzip" or "none"`,
+		},
+		{
+			name:   "block_size",
+			modify: func(cfg *Config) { cfg.BlockSize = 0 },
+			err:    "block_size must be positive and at most 104857600",
+		},
+		{
+			name:   "auth type",
+			modify: func(cfg *Config) { cfg.Credentials.Type = "shared_key" },
+			err:    `credentials.type must be "managed_identity" or "sas_token"`,
+		},
+		{
+			name:   "sas_token",
+			modify: func(cfg *Config) { cfg.Credentials.Type = authSASToken },
+			err:    "credentials.sas_token must be specified with the sas_token auth type",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "https://account.blob.core.windows.net"
+			cfg.Container = "telemetry"
+			require.NoError(t, cfg.Validate())
+			test.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), test.err)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/azure/managedidentity"
+)
+
+const (
+	signalTraces = "traces"
+	signalLogs   = "logs"
+
+	// maxBlocksPerBlob is the number of blocks after which a new blob is
+	// started. Every commit lists all the blocks of the blob, so blobs are
+	// kept well below the limit of 50,000 blocks of the Blob service.
+	maxBlocksPerBlob = 10000
+)
+
+type blobExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	signal string
+	// instance identifies the blobs written by this exporter, so that several
+	// collectors can write to the same virtual folders.
+	instance string
+	now      func() time.Time
+
+	tracesMarshaler pdata.TracesMarshaler
+	logsMarshaler   pdata.LogsMarshaler
+
+	client *blobClient
+
+	mu       sync.Mutex
+	window   time.Time
+	sequence int
+	blob     string
+	blocks   []string
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, signal string) (*blobExporter, error) {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	return &blobExporter{
+		cfg:             cfg,
+		logger:          logger,
+		signal:          signal,
+		instance:        hex.EncodeToString(id[:]),
+		now:             time.Now,
+		tracesMarshaler: otlp.NewJSONTracesMarshaler(),
+		logsMarshaler:   otlp.NewJSONLogsMarshaler(),
+	}, nil
+}
+
+func (e *blobExporter) start(_ context.Context, host component.Host) error {
+	client, err := e.cfg.HTTPClientSettings.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	endpoint, err := url.Parse(e.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	var auth authorizer
+	if e.cfg.Credentials.Type == authSASToken {
+		if auth, err = newSASAuthorizer(e.cfg.Credentials.SASToken); err != nil {
+			return err
+		}
+	} else {
+		auth = newManagedIdentityAuthorizer(managedidentity.IMDSTokenEndpoint, e.cfg.Credentials.ClientID)
+	}
+	e.client = &blobClient{
+		client:    client,
+		endpoint:  endpoint,
+		container: e.cfg.Container,
+		auth:      auth,
+	}
+	return nil
+}
+
+func (e *blobExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.write(ctx, buf)
+}
+
+func (e *blobExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.write(ctx, buf)
+}
+
+// write appends an OTLP/JSON batch as a line of the blob of the current
+// partition. The batch is staged as new blocks, then committed after the
+// blocks already committed to the blob, so that readers only see complete
+// batches. With gzip compression every batch is a gzip member, and the blob
+// is a valid gzip stream.
+func (e *blobExporter) write(ctx context.Context, batch []byte) error {
+	data, err := e.encode(batch)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	numBlocks := (len(data) + e.cfg.BlockSize - 1) / e.cfg.BlockSize
+	window := e.now().UTC().Truncate(e.partitionDuration())
+	switch {
+	case !window.Equal(e.window):
+		e.window = window
+		e.sequence = 0
+		e.rotate()
+	case len(e.blocks)+numBlocks > maxBlocksPerBlob:
+		e.sequence++
+		e.rotate()
+	}
+
+	blocks := e.blocks
+	for offset := 0; offset < len(data); offset += e.cfg.BlockSize {
+		end := offset + e.cfg.BlockSize
+		if end > len(data) {
+			end = len(data)
+		}
+		id := blockID(len(blocks))
+		if err = e.client.putBlock(ctx, e.blob, id, data[offset:end]); err != nil {
+			return e.wrapError(err)
+		}
+		blocks = append(blocks, id)
+	}
+	if err = e.client.putBlockList(ctx, e.blob, blocks, e.contentType()); err != nil {
+		return e.wrapError(err)
+	}
+	e.blocks = blocks
+	return nil
+}
+
+// rotate starts a new blob for the current window and sequence.
+func (e *blobExporter) rotate() {
+	e.blocks = nil
+	e.blob = e.blobName()
+}
+
+// blobName returns the name of the blob of the current window and sequence,
+// in virtual folders partitioned by time, e.g.
+// prefix/logs/year=2021/month=10/day=04/hour=15/logs_<instance>_0.json.gz.
+func (e *blobExporter) blobName() string {
+	w := e.window
+	folder := fmt.Sprintf("year=%04d/month=%02d/day=%02d/hour=%02d", w.Year(), w.Month(), w.Day(), w.Hour())
+	if e.cfg.Partition == partitionMinute {
+		folder += fmt.Sprintf("/minute=%02d", w.Minute())
+	}
+	name := fmt.Sprintf("%s_%s_%d.json", e.signal, e.instance, e.sequence)
+	if e.cfg.Compression == compressionGzip {
+		name += ".gz"
+	}
+	return path.Join(e.cfg.Prefix, e.signal, folder, name)
+}
+
+func (e *blobExporter) partitionDuration() time.Duration {
+	if e.cfg.Partition == partitionMinute {
+		return time.Minute
+	}
+	return time.Hour
+}
+
+func (e *blobExporter) contentType() string {
+	if e.cfg.Compression == compressionGzip {
+		return "application/gzip"
+	}
+	return "application/x-ndjson"
+}
+
+// encode terminates the batch with a newline and compresses it.
+func (e *blobExporter) encode(batch []byte) ([]byte, error) {
+	if e.cfg.Compression != compressionGzip {
+		return append(batch, '\n'), nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(batch); err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write([]byte{'\n'}); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// wrapError marks the errors that won't succeed if retried as permanent.
+func (e *blobExporter) wrapError(err error) error {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && !statusErr.retryable() {
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
+
+// blockID returns the ID of the n-th block of a blob. The IDs of the blocks
+// of a blob must all have the same length.
+func blockID(n int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%010d", n)))
+}
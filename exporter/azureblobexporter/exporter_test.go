@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureblobexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// fakeBlobService implements the Put Block and Put Block List operations.
+type fakeBlobService struct {
+	mu           sync.Mutex
+	staged       map[string][]byte
+	blobs        map[string][]byte
+	contentTypes map[string]string
+	queries      []string
+	failStatus   int
+}
+
+func newFakeBlobService() *fakeBlobService {
+	return &fakeBlobService{
+		staged:       map[string][]byte{},
+		blobs:        map[string][]byte{},
+		contentTypes: map[string]string{},
+	}
+}
+
+func (s *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, r.URL.RawQuery)
+	if s.failStatus != 0 {
+		w.WriteHeader(s.failStatus)
+		return
+	}
+	if r.Method != http.MethodPut || r.Header.Get("X-Ms-Version") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	blob := r.URL.Path
+	switch r.URL.Query().Get("comp") {
+	case "block":
+		s.staged[blob+"#"+r.URL.Query().Get("blockid")] = body
+	case "blocklist":
+		var list struct {
+			Latest []string `xml:"Latest"`
+		}
+		if err := xml.Unmarshal(body, &list); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var content []byte
+		for _, id := range list.Latest {
+			block, ok := s.staged[blob+"#"+id]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content = append(content, block...)
+		}
+		s.blobs[blob] = content
+		s.contentTypes[blob] = r.Header.Get("X-Ms-Blob-Content-Type")
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *fakeBlobService) blobNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for name := range s.blobs {
+		names = append(names, name)
+	}
+	return names
+}
+
+func newTestExporter(t *testing.T, url string, modify func(*Config)) *blobExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = url
+	cfg.Container = "telemetry"
+	cfg.Credentials = CredentialsConfig{Type: authSASToken, SASToken: "sv=2020-10-02&sig=abc"}
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	exp, err := newExporter(cfg, zap.NewNop(), signalLogs)
+	require.NoError(t, err)
+	exp.instance = "instance"
+	exp.now = func() time.Time { return time.Date(2021, 10, 4, 15, 5, 0, 0, time.UTC) }
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func testLogs(body string) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().Body().SetStringVal(body)
+	return ld
+}
+
+func TestAppendBatchesToBlob(t *testing.T) {
+	service := newFakeBlobService()
+	server := httptest.NewServer(service)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.Prefix = "collector"
+		cfg.BlockSize = 64
+	})
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("first")))
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("second")))
+
+	name := "/telemetry/collector/logs/year=2021/month=10/day=04/hour=15/logs_instance_0.json.gz"
+	require.Equal(t, []string{name}, service.blobNames())
+	assert.Equal(t, "application/gzip", service.contentTypes[name])
+	for _, query := range service.queries {
+		assert.Contains(t, query, "sig=abc")
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(service.blobs[name]))
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for i, body := range []string{"first", "second"} {
+		ld, err := otlp.NewJSONLogsUnmarshaler().UnmarshalLogs([]byte(lines[i]))
+		require.NoError(t, err)
+		assert.Equal(t, body, ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body().StringVal())
+	}
+}
+
+func TestPartitionRotation(t *testing.T) {
+	service := newFakeBlobService()
+	server := httptest.NewServer(service)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.Partition = partitionMinute
+		cfg.Compression = compressionNone
+	})
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("first")))
+	exp.now = func() time.Time { return time.Date(2021, 10, 4, 15, 6, 30, 0, time.UTC) }
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("second")))
+
+	assert.ElementsMatch(t, []string{
+		"/telemetry/logs/year=2021/month=10/day=04/hour=15/minute=05/logs_instance_0.json",
+		"/telemetry/logs/year=2021/month=10/day=04/hour=15/minute=06/logs_instance_0.json",
+	}, service.blobNames())
+	blob := service.blobs["/telemetry/logs/year=2021/month=10/day=04/hour=15/minute=06/logs_instance_0.json"]
+	assert.Equal(t, 1, bytes.Count(blob, []byte("\n")))
+	assert.Equal(t, "application/x-ndjson", service.contentTypes["/telemetry/logs/year=2021/month=10/day=04/hour=15/minute=06/logs_instance_0.json"])
+}
+
+func TestBlockLimitRotation(t *testing.T) {
+	service := newFakeBlobService()
+	server := httptest.NewServer(service)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL, nil)
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("first")))
+	exp.blocks = make([]string, maxBlocksPerBlob)
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("second")))
+
+	assert.ElementsMatch(t, []string{
+		"/telemetry/logs/year=2021/month=10/day=04/hour=15/logs_instance_0.json.gz",
+		"/telemetry/logs/year=2021/month=10/day=04/hour=15/logs_instance_1.json.gz",
+	}, service.blobNames())
+}
+
+func TestPushTraces(t *testing.T) {
+	service := newFakeBlobService()
+	server := httptest.NewServer(service)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.Compression = compressionNone
+	})
+	exp.signal = signalTraces
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	name := "/telemetry/traces/year=2021/month=10/day=04/hour=15/traces_instance_0.json"
+	got, err := otlp.NewJSONTracesUnmarshaler().UnmarshalTraces(service.blobs[name])
+	require.NoError(t, err)
+	assert.Equal(t, "span", got.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+}
+
+func TestPushErrors(t *testing.T) {
+	service := newFakeBlobService()
+	server := httptest.NewServer(service)
+	defer server.Close()
+	exp := newTestExporter(t, server.URL, nil)
+
+	service.failStatus = http.StatusForbidden
+	err := exp.pushLogs(context.Background(), testLogs("first"))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	service.failStatus = http.StatusServiceUnavailable
+	err = exp.pushLogs(context.Background(), testLogs("first"))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// Blocks staged by failed writes aren't committed.
+	service.failStatus = 0
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("second")))
+	assert.Len(t, exp.blocks, 1)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/azure/managedidentity"
+)
+
+const (
+	// eventHubsResource is the resource of the Azure AD tokens authorizing
+	// requests to Event Hubs.
+	eventHubsResource = "https://eventhubs.azure.net/"
+
+	// azureADEndpoint is the endpoint of Azure AD issuing the tokens of
+	// applications.
+	azureADEndpoint = "https://login.microsoftonline.com"
+
+	// sasTokenValidity is how long the generated shared access signatures
+	// are valid.
+	sasTokenValidity = time.Hour
+
+	// tokenRefreshMargin is how long before their expiration shared access
+	// signatures are renewed.
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// authorizer authorizes the requests sent to Event Hubs.
+type authorizer interface {
+	authorize(ctx context.Context, req *http.Request) error
+}
+
+func newAuthorizer(cfg *CredentialsConfig, resourceURI string) (authorizer, error) {
+	switch cfg.Type {
+	case authConnectionString:
+		cs, err := parseConnectionString(cfg.ConnectionString)
+		if err != nil {
+			return nil, err
+		}
+		return newSASAuthorizer(cs.keyName, cs.key, resourceURI), nil
+	case authManagedIdentity:
+		return newManagedIdentityAuthorizer(managedidentity.IMDSTokenEndpoint, cfg.ClientID), nil
+	case authClientSecret:
+		return newClientSecretAuthorizer(cfg.TenantID, cfg.ClientID, cfg.ClientSecret), nil
+	}
+	return nil, fmt.Errorf("unsupported credentials type %q", cfg.Type)
+}
+
+// sasAuthorizer authorizes requests with shared access signatures generated
+// from a shared access key.
+type sasAuthorizer struct {
+	keyName     string
+	key         string
+	resourceURI string
+	now         func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newSASAuthorizer(keyName, key, resourceURI string) *sasAuthorizer {
+	return &sasAuthorizer{
+		keyName:     keyName,
+		key:         key,
+		resourceURI: resourceURI,
+		now:         time.Now,
+	}
+}
+
+func (a *sasAuthorizer) authorize(_ context.Context, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	if a.token == "" || !now.Add(tokenRefreshMargin).Before(a.expires) {
+		a.expires = now.Add(sasTokenValidity)
+		a.token = a.sign(a.expires)
+	}
+	req.Header.Set("Authorization", a.token)
+	return nil
+}
+
+// sign returns a shared access signature of the resource valid until expires.
+func (a *sasAuthorizer) sign(expires time.Time) string {
+	resource := url.QueryEscape(strings.ToLower(a.resourceURI))
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(a.key))
+	_, _ = mac.Write([]byte(resource + "\n" + expiry))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=%s&skn=%s",
+		resource, url.QueryEscape(signature), expiry, url.QueryEscape(a.keyName))
+}
+
+// managedIdentityAuthorizer authorizes requests with the tokens of a managed
+// identity.
+type managedIdentityAuthorizer struct {
+	tokens *managedidentity.TokenSource
+}
+
+func newManagedIdentityAuthorizer(endpoint, clientID string) *managedIdentityAuthorizer {
+	return &managedIdentityAuthorizer{tokens: managedidentity.NewTokenSource(endpoint, eventHubsResource, clientID)}
+}
+
+func (a *managedIdentityAuthorizer) authorize(ctx context.Context, req *http.Request) error {
+	token, err := a.tokens.Token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// clientSecretAuthorizer authorizes requests with the tokens of an Azure AD
+// application, requested with the client credentials flow.
+type clientSecretAuthorizer struct {
+	tokens oauth2.TokenSource
+}
+
+func newClientSecretAuthorizer(tenantID, clientID, clientSecret string) *clientSecretAuthorizer {
+	cfg := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureADEndpoint, url.PathEscape(tenantID)),
+		Scopes:       []string{eventHubsResource + ".default"},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	// The token source caches tokens until they expire.
+	return &clientSecretAuthorizer{tokens: cfg.TokenSource(context.Background())}
+}
+
+func (a *clientSecretAuthorizer) authorize(_ context.Context, req *http.Request) error {
+	token, err := a.tokens.Token()
+	if err != nil {
+		return fmt.Errorf("failed to request Azure AD token: %w", err)
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
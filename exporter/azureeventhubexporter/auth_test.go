@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConnectionString(t *testing.T) {
+	cs, err := parseConnectionString(testConnectionString)
+	require.NoError(t, err)
+	assert.Equal(t, &connectionString{
+		endpoint:   "https://telemetry.servicebus.windows.net",
+		keyName:    "send",
+		key:        "c2VjcmV0",
+		entityPath: "otlp",
+	}, cs)
+}
+
+func TestSASAuthorizer(t *testing.T) {
+	now := time.Unix(1633420800, 0)
+	a := newSASAuthorizer("send", "key", "https://Telemetry.servicebus.windows.net/otlp")
+	a.now = func() time.Time { return now }
+
+	req := httptest.NewRequest(http.MethodPost, "https://telemetry.servicebus.windows.net/otlp/messages", nil)
+	require.NoError(t, a.authorize(context.Background(), req))
+
+	resource := url.QueryEscape("https://telemetry.servicebus.windows.net/otlp")
+	mac := hmac.New(sha256.New, []byte("key"))
+	_, _ = mac.Write([]byte(resource + "\n1633424400"))
+	signature := url.QueryEscape(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	token := fmt.Sprintf("SharedAccessSignature sr=%s&sig=%s&se=1633424400&skn=send", resource, signature)
+	assert.Equal(t, token, req.Header.Get("Authorization"))
+
+	// The signature is reused until it's about to expire.
+	now = now.Add(50 * time.Minute)
+	require.NoError(t, a.authorize(context.Background(), req))
+	assert.Equal(t, token, req.Header.Get("Authorization"))
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, a.authorize(context.Background(), req))
+	assert.NotEqual(t, token, req.Header.Get("Authorization"))
+}
+
+func TestManagedIdentityAuthorizer(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, eventHubsResource, r.URL.Query().Get("resource"))
+		assert.Equal(t, "identity", r.URL.Query().Get("client_id"))
+		fmt.Fprintf(w, `{"access_token": "token", "expires_on": "%d"}`, time.Now().Add(time.Hour).Unix())
+	}))
+	defer srv.Close()
+
+	a := newManagedIdentityAuthorizer(srv.URL, "identity")
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "https://telemetry.servicebus.windows.net/otlp/messages", nil)
+		require.NoError(t, a.authorize(context.Background(), req))
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// apiVersion is the version of the Event Hubs REST API.
+const apiVersion = "2014-01"
+
+// statusError is an unexpected HTTP status returned by Event Hubs.
+type statusError struct {
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("sending event failed with HTTP %d: %s", e.status, e.body)
+}
+
+// retryable returns whether the request may succeed if retried.
+func (e *statusError) retryable() bool {
+	return e.status == http.StatusRequestTimeout || e.status == http.StatusTooManyRequests || e.status >= http.StatusInternalServerError
+}
+
+// eventHubClient sends events with the Send Event operation of the Event
+// Hubs REST API.
+type eventHubClient struct {
+	client   *http.Client
+	endpoint *url.URL
+	eventHub string
+	auth     authorizer
+}
+
+// resourceURI returns the URI of the event hub, which shared access
+// signatures are generated for.
+func resourceURI(endpoint *url.URL, eventHub string) string {
+	return fmt.Sprintf("%s://%s/%s", endpoint.Scheme, endpoint.Host, eventHub)
+}
+
+// send sends an event with the body, content type and partition key, which
+// may be empty.
+func (c *eventHubClient) send(ctx context.Context, body []byte, contentType, partitionKey string) error {
+	u := *c.endpoint
+	u.Path = path.Join("/", u.Path, c.eventHub, "messages")
+	u.RawQuery = url.Values{"api-version": {apiVersion}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if partitionKey != "" {
+		properties, err := json.Marshal(map[string]string{"PartitionKey": partitionKey})
+		if err != nil {
+			return err
+		}
+		req.Header.Set("BrokerProperties", string(properties))
+	}
+	if err = c.auth.authorize(ctx, req); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &statusError{status: resp.StatusCode, body: string(msg)}
+}
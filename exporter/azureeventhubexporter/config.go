@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	encodingProto = "otlp_proto"
+	encodingJSON  = "otlp_json"
+
+	authConnectionString = "connection_string"
+	authManagedIdentity  = "managed_identity"
+	authClientSecret     = "client_secret"
+)
+
+// Config defines configuration for the Azure Event Hubs exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// EventHub is the name of the event hub events are sent to. It defaults
+	// to the entity path of the connection string.
+	EventHub string `mapstructure:"event_hub"`
+
+	// Encoding of the events, "otlp_proto" or "otlp_json".
+	Encoding string `mapstructure:"encoding"`
+
+	// PartitionKey is the template of the partition key of the events, where
+	// %{name} is replaced by the value of the name resource attribute. Data
+	// with different partition keys is sent as different events. Events
+	// are distributed across partitions if empty.
+	PartitionKey string `mapstructure:"partition_key"`
+
+	// Credentials defines how requests to the Event Hubs namespace are
+	// authorized.
+	Credentials CredentialsConfig `mapstructure:"credentials"`
+
+	// DNSCache is the ID of the dnscache extension resolving the hostname of
+	// the endpoint. The hostname is resolved by the Go resolver if empty.
+	DNSCache string `mapstructure:"dns_cache"`
+}
+
+// CredentialsConfig defines how requests to the Event Hubs namespace are
+// authorized.
+type CredentialsConfig struct {
+	// Type is "connection_string", "managed_identity" or "client_secret".
+	Type string `mapstructure:"type"`
+
+	// ConnectionString of a shared access policy of the namespace or event
+	// hub, used with the connection_string type.
+	ConnectionString string `mapstructure:"connection_string"`
+
+	// TenantID of the Azure AD application, used with the client_secret type.
+	TenantID string `mapstructure:"tenant_id"`
+
+	// ClientID of the Azure AD application with the client_secret type, or of
+	// the user-assigned managed identity with the managed_identity type. The
+	// system-assigned identity is used if empty.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret of the Azure AD application, used with the client_secret
+	// type.
+	ClientSecret string `mapstructure:"client_secret"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	endpoint, eventHub := cfg.Endpoint, cfg.EventHub
+	switch cfg.Credentials.Type {
+	case authConnectionString:
+		cs, err := parseConnectionString(cfg.Credentials.ConnectionString)
+		if err != nil {
+			return fmt.Errorf("credentials.connection_string is invalid: %w", err)
+		}
+		if endpoint == "" {
+			endpoint = cs.endpoint
+		}
+		if eventHub == "" {
+			eventHub = cs.entityPath
+		}
+	case authManagedIdentity:
+	case authClientSecret:
+		if cfg.Credentials.TenantID == "" || cfg.Credentials.ClientID == "" || cfg.Credentials.ClientSecret == "" {
+			return errors.New("credentials.tenant_id, credentials.client_id and credentials.client_secret must be specified with the client_secret auth type")
+		}
+	default:
+		return fmt.Errorf("credentials.type must be %q, %q or %q", authConnectionString, authManagedIdentity, authClientSecret)
+	}
+
+	u, err := url.Parse(endpoint)
+	if endpoint == "" || err != nil || u.Host == "" {
+		return errors.New("endpoint must be the URL of the Event Hubs namespace")
+	}
+	if eventHub == "" {
+		return errors.New("event_hub must be specified")
+	}
+	if cfg.Encoding != encodingProto && cfg.Encoding != encodingJSON {
+		return fmt.Errorf("encoding must be %q or %q", encodingProto, encodingJSON)
+	}
+	if cfg.DNSCache != "" {
+		if len(cfg.Headers) > 0 || cfg.Auth != nil {
+			return errors.New("dns_cache can't be used with headers or auth")
+		}
+		if _, err := config.NewComponentIDFromString(cfg.DNSCache); err != nil {
+			return fmt.Errorf("invalid dns_cache %q: %w", cfg.DNSCache, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+const testConnectionString = "Endpoint=sb://telemetry.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=c2VjcmV0;EntityPath=otlp"
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Credentials.ConnectionString = testConnectionString
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewComponentID(typeStr)])
+
+	aadCfg := factory.CreateDefaultConfig().(*Config)
+	aadCfg.ExporterSettings = config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "aad"))
+	aadCfg.Endpoint = "https://telemetry.servicebus.windows.net"
+	aadCfg.EventHub = "logs"
+	aadCfg.Encoding = encodingJSON
+	aadCfg.PartitionKey = "%{k8s.cluster.name}/%{service.name}"
+	aadCfg.Credentials = CredentialsConfig{
+		Type:         authClientSecret,
+		TenantID:     "72f988bf-86f1-41af-91ab-2d7cd011db47",
+		ClientID:     "3c1f3b5e-1d2a-4f3b-9a4e-2b1c0d9e8f7a",
+		ClientSecret: "secret",
+	}
+	aadCfg.QueueSettings.Enabled = false
+	aadCfg.DNSCache = "dnscache"
+	assert.Equal(t, aadCfg, cfg.Exporters[config.NewComponentIDWithName(typeStr, "aad")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name: "connection string",
+			modify: func(cfg *Config) {
+				cfg.Credentials.ConnectionString = "Endpoint=sb://telemetry.servicebus.windows.net/"
+			},
+			err: "credentials.connection_string is invalid: connection string must contain Endpoint, SharedAccessKeyName and SharedAccessKey",
+		},
+		{
+			name:   "connection string syntax",
+			modify: func(cfg *Config) { cfg.Credentials.ConnectionString = "Endpoint" },
+			err:    `credentials.connection_string is invalid: "Endpoint" is not a key=value pair`,
+		},
+		{
+			name:   "endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "telemetry" },
+			err:    "endpoint must be the URL of the Event Hubs namespace",
+		},
+		{
+			name: "managed identity endpoint",
+			modify: func(cfg *Config) {
+				cfg.Credentials = CredentialsConfig{Type: authManagedIdentity}
+			},
+			err: "endpoint must be the URL of the Event Hubs namespace",
+		},
+		{
+			name: "event hub",
+			modify: func(cfg *Config) {
+				cfg.Credentials.ConnectionString = "Endpoint=sb://telemetry.servicebus.windows.net/;SharedAccessKeyName=send;SharedAccessKey=c2VjcmV0"
+			},
+			err: "event_hub must be specified",
+		},
+		{
+			name:   "encoding",
+			modify: func(cfg *Config) { cfg.Encoding = "zipkin_proto" },
+			err:    `encoding must be "otlp_proto" or "otlp_json"`,
+		},
+		{
+			name:   "auth type",
+			modify: func(cfg *Config) { cfg.Credentials.Type = "sas_token" },
+			err:    `credentials.type must be "connection_string", "managed_identity" or "client_secret"`,
+		},
+		{
+			name:   "dns cache",
+			modify: func(cfg *Config) { cfg.DNSCache = "dnscache/" },
+			err:    `invalid dns_cache "dnscache/": name part must be specified after / in type/name key`,
+		},
+		{
+			name: "dns cache headers",
+			modify: func(cfg *Config) {
+				cfg.DNSCache = "dnscache"
+				cfg.Headers = map[string]string{"X-Tenant": "checkout"}
+			},
+			err: "dns_cache can't be used with headers or auth",
+		},
+		{
+			name: "client secret",
+			modify: func(cfg *Config) {
+				cfg.Credentials = CredentialsConfig{Type: authClientSecret, TenantID: "tenant", ClientID: "client"}
+			},
+			err: "credentials.tenant_id, credentials.client_id and credentials.client_secret must be specified with the client_secret auth type",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Credentials.ConnectionString = testConnectionString
+			require.NoError(t, cfg.Validate())
+			test.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), test.err)
+		})
+	}
+}
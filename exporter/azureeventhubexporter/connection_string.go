@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// connectionString holds the parts of an Event Hubs connection string used
+// by the exporter.
+type connectionString struct {
+	// endpoint is the HTTPS URL of the namespace.
+	endpoint   string
+	keyName    string
+	key        string
+	entityPath string
+}
+
+// parseConnectionString parses connection strings of the form
+// Endpoint=sb://<namespace>.servicebus.windows.net/;SharedAccessKeyName=<name>;SharedAccessKey=<key>[;EntityPath=<event hub>].
+func parseConnectionString(s string) (*connectionString, error) {
+	var cs connectionString
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%q is not a key=value pair", part)
+		}
+		switch strings.ToLower(kv[0]) {
+		case "endpoint":
+			cs.endpoint = "https://" + strings.TrimSuffix(strings.TrimPrefix(kv[1], "sb://"), "/")
+		case "sharedaccesskeyname":
+			cs.keyName = kv[1]
+		case "sharedaccesskey":
+			cs.key = kv[1]
+		case "entitypath":
+			cs.entityPath = kv[1]
+		}
+	}
+	if cs.endpoint == "" || cs.keyName == "" || cs.key == "" {
+		return nil, errors.New("connection string must contain Endpoint, SharedAccessKeyName and SharedAccessKey")
+	}
+	return &cs, nil
+}
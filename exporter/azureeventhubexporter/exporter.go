@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/dnscacheextension"
+)
+
+type eventHubExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	// partitionKey is nil if events are distributed across partitions.
+	partitionKey *partitionKeyTemplate
+	contentType  string
+
+	tracesMarshaler  pdata.TracesMarshaler
+	metricsMarshaler pdata.MetricsMarshaler
+	logsMarshaler    pdata.LogsMarshaler
+
+	client *eventHubClient
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) *eventHubExporter {
+	e := &eventHubExporter{
+		cfg:              cfg,
+		logger:           logger,
+		contentType:      "application/x-protobuf",
+		tracesMarshaler:  otlp.NewProtobufTracesMarshaler(),
+		metricsMarshaler: otlp.NewProtobufMetricsMarshaler(),
+		logsMarshaler:    otlp.NewProtobufLogsMarshaler(),
+	}
+	if cfg.Encoding == encodingJSON {
+		e.contentType = "application/json"
+		e.tracesMarshaler = otlp.NewJSONTracesMarshaler()
+		e.metricsMarshaler = otlp.NewJSONMetricsMarshaler()
+		e.logsMarshaler = otlp.NewJSONLogsMarshaler()
+	}
+	if cfg.PartitionKey != "" {
+		e.partitionKey = newPartitionKeyTemplate(cfg.PartitionKey)
+	}
+	return e
+}
+
+func (e *eventHubExporter) start(_ context.Context, host component.Host) error {
+	client, err := e.cfg.HTTPClientSettings.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	if e.cfg.DNSCache != "" {
+		id, err := config.NewComponentIDFromString(e.cfg.DNSCache)
+		if err != nil {
+			return err
+		}
+		if err = dnscacheextension.ConfigureClient(host, &id, client); err != nil {
+			return err
+		}
+	}
+	endpoint, eventHub := e.cfg.Endpoint, e.cfg.EventHub
+	if e.cfg.Credentials.Type == authConnectionString {
+		cs, err := parseConnectionString(e.cfg.Credentials.ConnectionString)
+		if err != nil {
+			return err
+		}
+		if endpoint == "" {
+			endpoint = cs.endpoint
+		}
+		if eventHub == "" {
+			eventHub = cs.entityPath
+		}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	auth, err := newAuthorizer(&e.cfg.Credentials, resourceURI(u, eventHub))
+	if err != nil {
+		return err
+	}
+	e.client = &eventHubClient{
+		client:   client,
+		endpoint: u,
+		eventHub: eventHub,
+		auth:     auth,
+	}
+	return nil
+}
+
+// pushTraces sends the traces as one event per partition key. Only the
+// traces of the events that failed are retried.
+func (e *eventHubExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	var keys []string
+	groups := make(map[string]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		key := e.renderPartitionKey(rss.At(i).Resource())
+		group, ok := groups[key]
+		if !ok {
+			group = pdata.NewTraces()
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		rss.At(i).CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+
+	var errs error
+	failed := pdata.NewTraces()
+	for _, key := range keys {
+		body, err := e.tracesMarshaler.MarshalTraces(groups[key])
+		if err != nil {
+			err = consumererror.NewPermanent(err)
+		} else {
+			err = e.client.send(ctx, body, e.contentType, key)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			if retryable(err) {
+				groups[key].ResourceSpans().MoveAndAppendTo(failed.ResourceSpans())
+			}
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	if failed.ResourceSpans().Len() == 0 {
+		return consumererror.NewPermanent(errs)
+	}
+	return consumererror.NewTraces(errs, failed)
+}
+
+// pushMetrics sends the metrics as one event per partition key. Only the
+// metrics of the events that failed are retried.
+func (e *eventHubExporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
+	var keys []string
+	groups := make(map[string]pdata.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		key := e.renderPartitionKey(rms.At(i).Resource())
+		group, ok := groups[key]
+		if !ok {
+			group = pdata.NewMetrics()
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		rms.At(i).CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+
+	var errs error
+	failed := pdata.NewMetrics()
+	for _, key := range keys {
+		body, err := e.metricsMarshaler.MarshalMetrics(groups[key])
+		if err != nil {
+			err = consumererror.NewPermanent(err)
+		} else {
+			err = e.client.send(ctx, body, e.contentType, key)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			if retryable(err) {
+				groups[key].ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+			}
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	if failed.ResourceMetrics().Len() == 0 {
+		return consumererror.NewPermanent(errs)
+	}
+	return consumererror.NewMetrics(errs, failed)
+}
+
+// pushLogs sends the logs as one event per partition key. Only the logs of
+// the events that failed are retried.
+func (e *eventHubExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	var keys []string
+	groups := make(map[string]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		key := e.renderPartitionKey(rls.At(i).Resource())
+		group, ok := groups[key]
+		if !ok {
+			group = pdata.NewLogs()
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		rls.At(i).CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+
+	var errs error
+	failed := pdata.NewLogs()
+	for _, key := range keys {
+		body, err := e.logsMarshaler.MarshalLogs(groups[key])
+		if err != nil {
+			err = consumererror.NewPermanent(err)
+		} else {
+			err = e.client.send(ctx, body, e.contentType, key)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			if retryable(err) {
+				groups[key].ResourceLogs().MoveAndAppendTo(failed.ResourceLogs())
+			}
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	if failed.ResourceLogs().Len() == 0 {
+		return consumererror.NewPermanent(errs)
+	}
+	return consumererror.NewLogs(errs, failed)
+}
+
+func (e *eventHubExporter) renderPartitionKey(resource pdata.Resource) string {
+	if e.partitionKey == nil {
+		return ""
+	}
+	return e.partitionKey.render(resource)
+}
+
+// retryable returns whether sending an event may succeed if retried:
+// marshaling errors and the rejections of the event by Event Hubs, such as
+// events larger than the maximum event size, are permanent.
+func retryable(err error) bool {
+	if consumererror.IsPermanent(err) {
+		return false
+	}
+	var statusErr *statusError
+	return !errors.As(err, &statusErr) || statusErr.retryable()
+}
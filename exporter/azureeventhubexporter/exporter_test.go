@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type event struct {
+	path             string
+	contentType      string
+	brokerProperties string
+	authorization    string
+	body             []byte
+}
+
+// fakeEventHub implements the Send Event operation, failing the events of
+// the partition keys in failStatus.
+type fakeEventHub struct {
+	mu         sync.Mutex
+	events     []event
+	failStatus map[string]int
+}
+
+func (h *fakeEventHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if r.Method != http.MethodPost || r.URL.Query().Get("api-version") != apiVersion {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for key, status := range h.failStatus {
+		if strings.Contains(r.Header.Get("BrokerProperties"), key) {
+			w.WriteHeader(status)
+			return
+		}
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	h.events = append(h.events, event{
+		path:             r.URL.Path,
+		contentType:      r.Header.Get("Content-Type"),
+		brokerProperties: r.Header.Get("BrokerProperties"),
+		authorization:    r.Header.Get("Authorization"),
+		body:             body,
+	})
+	w.WriteHeader(http.StatusCreated)
+}
+
+func newTestExporter(t *testing.T, modify func(*Config)) (*eventHubExporter, *fakeEventHub) {
+	hub := &fakeEventHub{}
+	srv := httptest.NewServer(hub)
+	t.Cleanup(srv.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL
+	cfg.Credentials.ConnectionString = testConnectionString
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp, hub
+}
+
+// fakeResolver is a DNS cache extension resolving all the hostnames to addr.
+type fakeResolver struct {
+	component.Extension
+	addr  string
+	dials int
+}
+
+func (r *fakeResolver) LookupHost(context.Context, string) ([]string, error) {
+	host, _, err := net.SplitHostPort(r.addr)
+	return []string{host}, err
+}
+
+func (r *fakeResolver) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	r.dials++
+	var d net.Dialer
+	return d.DialContext(ctx, network, r.addr)
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func newTestTraces(services ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, service := range services {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		rs.Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func TestPushTraces(t *testing.T) {
+	exp, hub := newTestExporter(t, nil)
+
+	td := newTestTraces("checkout", "cart")
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	require.Len(t, hub.events, 1)
+	e := hub.events[0]
+	assert.Equal(t, "/otlp/messages", e.path)
+	assert.Equal(t, "application/x-protobuf", e.contentType)
+	assert.Empty(t, e.brokerProperties)
+	assert.True(t, strings.HasPrefix(e.authorization, "SharedAccessSignature sr="))
+	received, err := otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces(e.body)
+	require.NoError(t, err)
+	assert.Equal(t, td, received)
+}
+
+func TestDNSCache(t *testing.T) {
+	hub := &fakeEventHub{}
+	srv := httptest.NewServer(hub)
+	defer srv.Close()
+	resolver := &fakeResolver{Extension: componenthelper.New(), addr: srv.Listener.Addr().String()}
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("dnscache"): resolver},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "http://telemetry.servicebus.test"
+	cfg.Credentials.ConnectionString = testConnectionString
+	cfg.DNSCache = "dnscache"
+	require.NoError(t, cfg.Validate())
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), host))
+
+	// The hostname of the endpoint is only resolved by the extension.
+	require.NoError(t, exp.pushTraces(context.Background(), newTestTraces("checkout")))
+	assert.Len(t, hub.events, 1)
+	assert.Equal(t, 1, resolver.dials)
+
+	cfg.DNSCache = "dnscache/missing"
+	assert.EqualError(t, newExporter(cfg, zap.NewNop()).start(context.Background(), host), `extension "dnscache/missing" not found`)
+}
+
+func TestPushPartitionKeys(t *testing.T) {
+	exp, hub := newTestExporter(t, func(cfg *Config) {
+		cfg.EventHub = "telemetry"
+		cfg.Encoding = encodingJSON
+		cfg.PartitionKey = "%{k8s.cluster.name}/%{service.name}"
+	})
+
+	require.NoError(t, exp.pushTraces(context.Background(), newTestTraces("checkout", "cart", "checkout")))
+	require.Len(t, hub.events, 2)
+	assert.Equal(t, "/telemetry/messages", hub.events[0].path)
+	assert.Equal(t, "application/json", hub.events[0].contentType)
+	assert.Equal(t, `{"PartitionKey":"prod/checkout"}`, hub.events[0].brokerProperties)
+	assert.Equal(t, `{"PartitionKey":"prod/cart"}`, hub.events[1].brokerProperties)
+	received, err := otlp.NewJSONTracesUnmarshaler().UnmarshalTraces(hub.events[0].body)
+	require.NoError(t, err)
+	assert.Equal(t, 2, received.ResourceSpans().Len())
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("service.name", "checkout")
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+	require.Len(t, hub.events, 3)
+	assert.Equal(t, `{"PartitionKey":"/checkout"}`, hub.events[2].brokerProperties)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("service.name", "cart")
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+	require.Len(t, hub.events, 4)
+	assert.Equal(t, `{"PartitionKey":"/cart"}`, hub.events[3].brokerProperties)
+}
+
+func TestPushPartialFailure(t *testing.T) {
+	exp, hub := newTestExporter(t, func(cfg *Config) {
+		cfg.PartitionKey = "%{service.name}"
+	})
+
+	// Only the traces of the events that can be retried are returned.
+	hub.failStatus = map[string]int{"cart": http.StatusServiceUnavailable, "search": http.StatusRequestEntityTooLarge}
+	err := exp.pushTraces(context.Background(), newTestTraces("checkout", "cart", "search"))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	var tracesErr consumererror.Traces
+	require.True(t, consumererror.AsTraces(err, &tracesErr))
+	failed := tracesErr.GetTraces()
+	require.Equal(t, 1, failed.ResourceSpans().Len())
+	name, _ := failed.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "cart", name.StringVal())
+	assert.Len(t, hub.events, 1)
+
+	// Events rejected by Event Hubs are not retried.
+	err = exp.pushTraces(context.Background(), newTestTraces("search"))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "sending event failed with HTTP 413")
+}
+
+func TestPartitionKeyTemplate(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+	resource.Attributes().InsertInt("shard", 3)
+
+	tests := []struct {
+		template string
+		key      string
+	}{
+		{template: "static", key: "static"},
+		{template: "%{service.name}", key: "checkout"},
+		{template: "%{service.name}-%{shard}%", key: "checkout-3%"},
+		{template: "%{missing}/%{service.name}", key: "/checkout"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.key, newPartitionKeyTemplate(test.template).render(resource), test.template)
+	}
+}
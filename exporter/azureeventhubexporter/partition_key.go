@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubexporter
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var attributePattern = regexp.MustCompile(`%\{([\w.\-/]+)\}`)
+
+// partitionKeyTemplate renders partition keys from resource attributes.
+type partitionKeyTemplate struct {
+	// literals surround the attributes: literals[i] precedes attributes[i],
+	// and the last literal follows the last attribute.
+	literals   []string
+	attributes []string
+}
+
+// newPartitionKeyTemplate parses a template where %{name} is replaced by the
+// value of the name resource attribute, e.g. "%{k8s.cluster.name}/%{service.name}".
+func newPartitionKeyTemplate(text string) *partitionKeyTemplate {
+	t := &partitionKeyTemplate{}
+	last := 0
+	for _, match := range attributePattern.FindAllStringSubmatchIndex(text, -1) {
+		t.literals = append(t.literals, text[last:match[0]])
+		t.attributes = append(t.attributes, text[match[2]:match[3]])
+		last = match[1]
+	}
+	t.literals = append(t.literals, text[last:])
+	return t
+}
+
+// render returns the partition key of a resource. Missing attributes are
+// replaced by empty strings.
+func (t *partitionKeyTemplate) render(resource pdata.Resource) string {
+	var sb strings.Builder
+	for i, attribute := range t.attributes {
+		sb.WriteString(t.literals[i])
+		if v, ok := resource.Attributes().Get(attribute); ok {
+			sb.WriteString(v.AsString())
+		}
+	}
+	sb.WriteString(t.literals[len(t.literals)-1])
+	return sb.String()
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterexpr"
+)
+
+// Config defines configuration for the debug exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Conditions select the items to print. An item is printed if it matches
+	// any of the conditions of its signal. All items are printed for a signal
+	// without conditions.
+	Conditions ConditionsConfig `mapstructure:"conditions"`
+
+	// Attributes are the names of the attributes printed for each item. All
+	// attributes are printed if empty.
+	Attributes []string `mapstructure:"attributes"`
+
+	// ResourceAttributes are the names of the resource attributes printed for
+	// each item. All resource attributes are printed if empty.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// SamplingRatio is the ratio of matching items that are printed.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+
+	// MaxItemsPerSecond limits the number of items printed per second, 0
+	// disables the limit.
+	MaxItemsPerSecond int `mapstructure:"max_items_per_second"`
+}
+
+// ConditionsConfig holds the conditions of each signal, as expressions of
+// the filterexpr package.
+type ConditionsConfig struct {
+	Traces  []string `mapstructure:"traces"`
+	Metrics []string `mapstructure:"metrics"`
+	Logs    []string `mapstructure:"logs"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	for _, conditions := range [][]string{cfg.Conditions.Traces, cfg.Conditions.Metrics, cfg.Conditions.Logs} {
+		if _, err := newMatchers(conditions); err != nil {
+			return err
+		}
+	}
+	if cfg.SamplingRatio <= 0 || cfg.SamplingRatio > 1 {
+		return errors.New("sampling_ratio must be in (0, 1]")
+	}
+	if cfg.MaxItemsPerSecond < 0 {
+		return errors.New("max_items_per_second must not be negative")
+	}
+	return nil
+}
+
+func newMatchers(conditions []string) ([]*filterexpr.Matcher, error) {
+	matchers := make([]*filterexpr.Matcher, 0, len(conditions))
+	for _, condition := range conditions {
+		matcher, err := filterexpr.NewMatcher(condition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition %q: %w", condition, err)
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
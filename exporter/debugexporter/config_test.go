@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Exporters[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
+		Conditions: ConditionsConfig{
+			Traces: []string{`StatusCode == "STATUS_CODE_ERROR"`, `ResourceAttribute("service.name") == "checkout"`},
+			Logs:   []string{"SeverityNumber >= 17"},
+		},
+		Attributes:         []string{"http.method", "http.status_code"},
+		ResourceAttributes: []string{"service.name"},
+		SamplingRatio:      0.5,
+		MaxItemsPerSecond:  5,
+	}, cfg.Exporters[config.NewComponentIDWithName(typeStr, "2")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "invalid condition",
+			modify: func(cfg *Config) { cfg.Conditions.Metrics = []string{"MetricName =="} },
+			err:    `invalid condition "MetricName =="`,
+		},
+		{
+			name:   "zero sampling ratio",
+			modify: func(cfg *Config) { cfg.SamplingRatio = 0 },
+			err:    "sampling_ratio must be in (0, 1]",
+		},
+		{
+			name:   "sampling ratio over 1",
+			modify: func(cfg *Config) { cfg.SamplingRatio = 1.5 },
+			err:    "sampling_ratio must be in (0, 1]",
+		},
+		{
+			name:   "negative rate limit",
+			modify: func(cfg *Config) { cfg.MaxItemsPerSecond = -1 },
+			err:    "max_items_per_second must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
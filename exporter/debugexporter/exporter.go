@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterexpr"
+)
+
+type debugExporter struct {
+	logger *zap.Logger
+
+	attributes         map[string]bool
+	resourceAttributes map[string]bool
+	samplingRatio      float64
+	limiter            *rateLimiter
+
+	// The expression VMs of the matchers and the random source are not safe
+	// for concurrent use.
+	mu             sync.Mutex
+	traceMatchers  []*filterexpr.Matcher
+	metricMatchers []*filterexpr.Matcher
+	logMatchers    []*filterexpr.Matcher
+	random         *rand.Rand
+}
+
+func newDebugExporter(cfg *Config, logger *zap.Logger) (*debugExporter, error) {
+	e := &debugExporter{
+		logger:             logger,
+		attributes:         toSet(cfg.Attributes),
+		resourceAttributes: toSet(cfg.ResourceAttributes),
+		samplingRatio:      cfg.SamplingRatio,
+		limiter:            newRateLimiter(cfg.MaxItemsPerSecond),
+		random:             rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	var err error
+	if e.traceMatchers, err = newMatchers(cfg.Conditions.Traces); err != nil {
+		return nil, err
+	}
+	if e.metricMatchers, err = newMatchers(cfg.Conditions.Metrics); err != nil {
+		return nil, err
+	}
+	if e.logMatchers, err = newMatchers(cfg.Conditions.Logs); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func toSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// project returns the attributes to print, which are all of them when no
+// projection is configured.
+func project(attrs pdata.AttributeMap, names map[string]bool) map[string]interface{} {
+	projected := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if names == nil || names[k] {
+			projected[k] = v.AsString()
+		}
+		return true
+	})
+	return projected
+}
+
+// matchAny reports whether match returns true for any of the matchers, or
+// true when there is no matcher. Evaluation errors count as no match.
+func matchAny(matchers []*filterexpr.Matcher, match func(*filterexpr.Matcher) (bool, error)) bool {
+	if len(matchers) == 0 {
+		return true
+	}
+	for _, m := range matchers {
+		if matched, err := match(m); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sampled applies the sampling ratio and the rate limit to a matching item.
+func (e *debugExporter) sampled() bool {
+	if e.samplingRatio < 1 && e.random.Float64() >= e.samplingRatio {
+		return false
+	}
+	allowed, dropped := e.limiter.allow()
+	if dropped > 0 {
+		e.logger.Info("Items were not printed because of the rate limit", zap.Int("dropped_items", dropped))
+	}
+	return allowed
+}
+
+func (e *debugExporter) pushTraces(_ context.Context, td pdata.Traces) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		var resource map[string]interface{}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !matchAny(e.traceMatchers, func(m *filterexpr.Matcher) (bool, error) {
+					return m.MatchSpan(span, rs.Resource())
+				}) || !e.sampled() {
+					continue
+				}
+				if resource == nil {
+					resource = project(rs.Resource().Attributes(), e.resourceAttributes)
+				}
+				e.logger.Info("Span",
+					zap.Any("resource", resource),
+					zap.String("trace_id", span.TraceID().HexString()),
+					zap.String("span_id", span.SpanID().HexString()),
+					zap.String("parent_span_id", span.ParentSpanID().HexString()),
+					zap.String("name", span.Name()),
+					zap.String("kind", span.Kind().String()),
+					zap.Time("start_time", span.StartTimestamp().AsTime()),
+					zap.Duration("duration", span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime())),
+					zap.String("status_code", span.Status().Code().String()),
+					zap.String("status_message", span.Status().Message()),
+					zap.Any("attributes", project(span.Attributes(), e.attributes)),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *debugExporter) pushMetrics(_ context.Context, md pdata.Metrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		var resource map[string]interface{}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if !matchAny(e.metricMatchers, func(m *filterexpr.Matcher) (bool, error) {
+					return m.MatchMetric(metric)
+				}) || !e.sampled() {
+					continue
+				}
+				if resource == nil {
+					resource = project(rm.Resource().Attributes(), e.resourceAttributes)
+				}
+				e.logger.Info("Metric",
+					zap.Any("resource", resource),
+					zap.String("name", metric.Name()),
+					zap.String("unit", metric.Unit()),
+					zap.String("type", metric.DataType().String()),
+					zap.Any("data_points", e.dataPoints(metric)),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *debugExporter) dataPoints(metric pdata.Metric) []map[string]interface{} {
+	var points []map[string]interface{}
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge, pdata.MetricDataTypeSum:
+		dps := metric.Gauge().DataPoints()
+		if metric.DataType() == pdata.MetricDataTypeSum {
+			dps = metric.Sum().DataPoints()
+		}
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			point := map[string]interface{}{"attributes": project(dp.Attributes(), e.attributes)}
+			switch dp.Type() {
+			case pdata.MetricValueTypeInt:
+				point["value"] = dp.IntVal()
+			case pdata.MetricValueTypeDouble:
+				point["value"] = dp.DoubleVal()
+			}
+			points = append(points, point)
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, map[string]interface{}{
+				"attributes": project(dp.Attributes(), e.attributes),
+				"count":      dp.Count(),
+				"sum":        dp.Sum(),
+			})
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			points = append(points, map[string]interface{}{
+				"attributes": project(dp.Attributes(), e.attributes),
+				"count":      dp.Count(),
+				"sum":        dp.Sum(),
+			})
+		}
+	}
+	return points
+}
+
+func (e *debugExporter) pushLogs(_ context.Context, ld pdata.Logs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var resource map[string]interface{}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if !matchAny(e.logMatchers, func(m *filterexpr.Matcher) (bool, error) {
+					return m.MatchLogRecord(lr, rl.Resource())
+				}) || !e.sampled() {
+					continue
+				}
+				if resource == nil {
+					resource = project(rl.Resource().Attributes(), e.resourceAttributes)
+				}
+				e.logger.Info("LogRecord",
+					zap.Any("resource", resource),
+					zap.Time("time", lr.Timestamp().AsTime()),
+					zap.String("severity_text", lr.SeverityText()),
+					zap.Int32("severity_number", int32(lr.SeverityNumber())),
+					zap.String("body", lr.Body().AsString()),
+					zap.String("trace_id", lr.TraceID().HexString()),
+					zap.String("span_id", lr.SpanID().HexString()),
+					zap.Any("attributes", project(lr.Attributes(), e.attributes)),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// rateLimiter allows up to limit events per one second window. A limit of 0
+// allows every event.
+type rateLimiter struct {
+	mu          sync.Mutex
+	limit       int
+	windowStart time.Time
+	count       int
+	dropped     int
+	now         func() time.Time
+}
+
+func newRateLimiter(limit int) *rateLimiter {
+	return &rateLimiter{limit: limit, now: time.Now}
+}
+
+// allow reports whether the event is allowed and, when a new window starts,
+// the number of events dropped during the previous one.
+func (rl *rateLimiter) allow() (bool, int) {
+	if rl.limit == 0 {
+		return true, 0
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	dropped := 0
+	now := rl.now()
+	if now.Sub(rl.windowStart) >= time.Second {
+		rl.windowStart = now
+		rl.count = 0
+		dropped = rl.dropped
+		rl.dropped = 0
+	}
+	if rl.count >= rl.limit {
+		rl.dropped++
+		return false, dropped
+	}
+	rl.count++
+	return true, dropped
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestExporter(t *testing.T, modify func(*Config)) (*debugExporter, *observer.ObservedLogs) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxItemsPerSecond = 0
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	core, logs := observer.New(zapcore.InfoLevel)
+	e, err := newDebugExporter(cfg, zap.New(core))
+	require.NoError(t, err)
+	return e, logs
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	rs.Resource().Attributes().InsertString("host.name", "node-1")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET /cart", "POST /pay"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(time.Unix(100, 0)))
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(time.Unix(101, 0)))
+		span.Attributes().InsertString("http.method", name[:4])
+		span.Attributes().InsertInt("http.status_code", 200)
+	}
+	spans.At(1).Status().SetCode(pdata.StatusCodeError)
+	return td
+}
+
+func TestPushTraces(t *testing.T) {
+	e, logs := newTestExporter(t, func(cfg *Config) {
+		cfg.Conditions.Traces = []string{`StatusCode == "STATUS_CODE_ERROR"`}
+		cfg.Attributes = []string{"http.method"}
+		cfg.ResourceAttributes = []string{"service.name"}
+	})
+
+	require.NoError(t, e.pushTraces(context.Background(), testTraces()))
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "Span", entry.Message)
+	fields := entry.ContextMap()
+	assert.Equal(t, "POST /pay", fields["name"])
+	assert.Equal(t, "STATUS_CODE_ERROR", fields["status_code"])
+	assert.Equal(t, time.Second, fields["duration"])
+	assert.Equal(t, map[string]interface{}{"http.method": "POST"}, fields["attributes"])
+	assert.Equal(t, map[string]interface{}{"service.name": "checkout"}, fields["resource"])
+}
+
+func TestPushTracesWithoutConditions(t *testing.T) {
+	e, logs := newTestExporter(t, nil)
+
+	require.NoError(t, e.pushTraces(context.Background(), testTraces()))
+
+	require.Equal(t, 2, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "GET /cart", fields["name"])
+	assert.Equal(t, map[string]interface{}{"http.method": "GET ", "http.status_code": "200"}, fields["attributes"])
+	assert.Equal(t, map[string]interface{}{"service.name": "checkout", "host.name": "node-1"}, fields["resource"])
+}
+
+func TestPushMetrics(t *testing.T) {
+	e, logs := newTestExporter(t, func(cfg *Config) {
+		cfg.Conditions.Metrics = []string{`MetricName startsWith "http."`, `Label("queue") == "orders"`}
+	})
+
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	queue := metrics.AppendEmpty()
+	queue.SetName("queue.size")
+	queue.SetDataType(pdata.MetricDataTypeGauge)
+	dp := queue.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(42)
+	dp.Attributes().InsertString("queue", "orders")
+
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("system.cpu.time")
+	cpu.SetDataType(pdata.MetricDataTypeSum)
+	cpu.Sum().DataPoints().AppendEmpty().SetDoubleVal(1.5)
+
+	duration := metrics.AppendEmpty()
+	duration.SetName("http.server.duration")
+	duration.SetUnit("ms")
+	duration.SetDataType(pdata.MetricDataTypeHistogram)
+	hdp := duration.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(12.5)
+
+	require.NoError(t, e.pushMetrics(context.Background(), md))
+
+	require.Equal(t, 2, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "queue.size", fields["name"])
+	assert.Equal(t, "Gauge", fields["type"])
+	assert.Equal(t, []map[string]interface{}{{
+		"attributes": map[string]interface{}{"queue": "orders"},
+		"value":      int64(42),
+	}}, fields["data_points"])
+
+	fields = logs.All()[1].ContextMap()
+	assert.Equal(t, "http.server.duration", fields["name"])
+	assert.Equal(t, "ms", fields["unit"])
+	assert.Equal(t, []map[string]interface{}{{
+		"attributes": map[string]interface{}{},
+		"count":      uint64(3),
+		"sum":        12.5,
+	}}, fields["data_points"])
+}
+
+func TestPushLogs(t *testing.T) {
+	e, logs := newTestExporter(t, func(cfg *Config) {
+		cfg.Conditions.Logs = []string{"SeverityNumber >= 17", `Body contains "timeout"`}
+	})
+
+	ld := pdata.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, r := range []struct {
+		body     string
+		severity pdata.SeverityNumber
+	}{
+		{"started", pdata.SeverityNumberINFO},
+		{"payment failed", pdata.SeverityNumberERROR},
+		{"upstream timeout", pdata.SeverityNumberWARN},
+	} {
+		lr := records.AppendEmpty()
+		lr.Body().SetStringVal(r.body)
+		lr.SetSeverityNumber(r.severity)
+	}
+
+	require.NoError(t, e.pushLogs(context.Background(), ld))
+
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "LogRecord", logs.All()[0].Message)
+	assert.Equal(t, "payment failed", logs.All()[0].ContextMap()["body"])
+	assert.Equal(t, "upstream timeout", logs.All()[1].ContextMap()["body"])
+}
+
+func TestRateLimit(t *testing.T) {
+	e, logs := newTestExporter(t, func(cfg *Config) { cfg.MaxItemsPerSecond = 1 })
+	now := time.Unix(0, 0)
+	e.limiter.now = func() time.Time { return now }
+
+	require.NoError(t, e.pushTraces(context.Background(), testTraces()))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "GET /cart", logs.All()[0].ContextMap()["name"])
+
+	now = now.Add(time.Second)
+	require.NoError(t, e.pushTraces(context.Background(), testTraces()))
+	require.Equal(t, 3, logs.Len())
+	assert.Equal(t, "Items were not printed because of the rate limit", logs.All()[1].Message)
+	assert.Equal(t, int64(1), logs.All()[1].ContextMap()["dropped_items"])
+	assert.Equal(t, "GET /cart", logs.All()[2].ContextMap()["name"])
+}
+
+func TestSampling(t *testing.T) {
+	e, logs := newTestExporter(t, func(cfg *Config) { cfg.SamplingRatio = 0.5 })
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, e.pushTraces(context.Background(), testTraces()))
+	}
+	assert.Greater(t, logs.Len(), 50)
+	assert.Less(t, logs.Len(), 150)
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	rl := newRateLimiter(0)
+	for i := 0; i < 1000; i++ {
+		allowed, dropped := rl.allow()
+		require.True(t, allowed)
+		require.Zero(t, dropped)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsexporter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	partitionHour   = "hour"
+	partitionMinute = "minute"
+
+	encodingJSONL   = "jsonl"
+	encodingParquet = "parquet"
+
+	compressionGzip = "gzip"
+	compressionNone = "none"
+)
+
+// Config defines configuration for the Google Cloud Storage exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Bucket is the name of the bucket objects are written to.
+	Bucket string `mapstructure:"bucket"`
+
+	// Prefix is prepended to the names of the objects.
+	Prefix string `mapstructure:"prefix"`
+
+	// Partition is the duration covered by a folder, "hour" or "minute".
+	Partition string `mapstructure:"partition"`
+
+	// PartitionAttributes are the resource attributes whose values are added
+	// to the names of the objects, as key=value folders.
+	PartitionAttributes []string `mapstructure:"partition_attributes"`
+
+	// Encoding of the objects, "jsonl" or "parquet".
+	Encoding string `mapstructure:"encoding"`
+
+	// Compression of JSON lines objects, "gzip" or "none". Parquet objects
+	// are always compressed with snappy.
+	Compression string `mapstructure:"compression"`
+
+	// ChunkSize is the size of the chunks of resumable uploads. Objects
+	// larger than a chunk are uploaded with resumable uploads, and smaller
+	// ones with a single request.
+	ChunkSize int `mapstructure:"chunk_size"`
+
+	// KMSKeyName is the Cloud KMS key objects are encrypted with. The default
+	// encryption of the bucket is used if empty.
+	KMSKeyName string `mapstructure:"kms_key_name"`
+
+	// Endpoint overrides the endpoint of the Cloud Storage API.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Bucket == "" {
+		return errors.New("bucket must be specified")
+	}
+	if cfg.Partition != partitionHour && cfg.Partition != partitionMinute {
+		return fmt.Errorf("partition must be %q or %q", partitionHour, partitionMinute)
+	}
+	for _, key := range cfg.PartitionAttributes {
+		if key == "" {
+			return errors.New("partition_attributes must not contain empty keys")
+		}
+	}
+	if cfg.Encoding != encodingJSONL && cfg.Encoding != encodingParquet {
+		return fmt.Errorf("encoding must be %q or %q", encodingJSONL, encodingParquet)
+	}
+	if cfg.Compression != compressionGzip && cfg.Compression != compressionNone {
+		return fmt.Errorf("compression must be %q or %q", compressionGzip, compressionNone)
+	}
+	if cfg.ChunkSize < 0 {
+		return errors.New("chunk_size must not be negative")
+	}
+	return nil
+}
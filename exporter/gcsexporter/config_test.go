@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Bucket = "telemetry"
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewComponentID(typeStr)])
+
+	parquetCfg := factory.CreateDefaultConfig().(*Config)
+	parquetCfg.ExporterSettings = config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "parquet"))
+	parquetCfg.Bucket = "lake"
+	parquetCfg.Prefix = "otel"
+	parquetCfg.Partition = partitionMinute
+	parquetCfg.PartitionAttributes = []string{"service.name"}
+	parquetCfg.Encoding = encodingParquet
+	parquetCfg.Compression = compressionNone
+	parquetCfg.ChunkSize = 1048576
+	parquetCfg.KMSKeyName = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+	parquetCfg.Timeout = time.Minute
+	assert.Equal(t, parquetCfg, cfg.Exporters[config.NewComponentIDWithName(typeStr, "parquet")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "bucket",
+			modify: func(cfg *Config) { cfg.Bucket = "" },
+			err:    "bucket must be specified",
+		},
+		{
+			name:   "partition",
+			modify: func(cfg *Config) { cfg.Partition = "day" },
+			err:    `partition must be "hour" or "minute"`,
+		},
+		{
+			name:   "partition_attributes",
+			modify: func(cfg *Config) { cfg.PartitionAttributes = []string{""} },
+			err:    "partition_attributes must not contain empty keys",
+		},
+		{
+			name:   "encoding",
+			modify: func(cfg *Config) { cfg.Encoding = "avro" },
+			err:    `encoding must be "jsonl" or "parquet"`,
+		},
+		{
+			name:   "compression",
+			modify: func(cfg *Config) { cfg.Compression = "zstd" },
+			err:    `compression must be "gzip" or "none"`,
+		},
+		{
+			name:   "chunk_size",
+			modify: func(cfg *Config) { cfg.ChunkSize = -1 },
+			err:    "chunk_size must not be negative",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Bucket = "telemetry"
+			require.NoError(t, cfg.Validate())
+			test.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), test.err)
+		})
+	}
+}
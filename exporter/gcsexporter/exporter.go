@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+const (
+	signalTraces = "traces"
+	signalLogs   = "logs"
+
+	// missingAttribute is the folder name of partition attributes missing
+	// from a resource.
+	missingAttribute = "__missing__"
+)
+
+type gcsExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	signal string
+	// instance identifies the objects written by this exporter, so that
+	// several collectors can write to the same folders.
+	instance string
+	sequence uint64
+	now      func() time.Time
+
+	// clientOptions are added to the options of the Cloud Storage client.
+	clientOptions []option.ClientOption
+	objects       *storage.ObjectsService
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, signal string) (*gcsExporter, error) {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	return &gcsExporter{
+		cfg:      cfg,
+		logger:   logger,
+		signal:   signal,
+		instance: hex.EncodeToString(id[:]),
+		now:      time.Now,
+	}, nil
+}
+
+func (e *gcsExporter) start(ctx context.Context, _ component.Host) error {
+	opts := []option.ClientOption{option.WithScopes(storage.DevstorageReadWriteScope)}
+	if e.cfg.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(e.cfg.Endpoint))
+	}
+	opts = append(opts, e.clientOptions...)
+	svc, err := storage.NewService(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create the Cloud Storage client: %w", err)
+	}
+	e.objects = svc.Objects
+	return nil
+}
+
+func (e *gcsExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	for _, batch := range splitTraces(e.cfg.PartitionAttributes, td) {
+		w, err := newRecordWriter(e.cfg, spanColumns)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		if err = writeSpans(w, batch.traces); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		if err := e.upload(ctx, batch.folders, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *gcsExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	for _, batch := range splitLogs(e.cfg.PartitionAttributes, ld) {
+		w, err := newRecordWriter(e.cfg, logColumns)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		if err = writeLogs(w, batch.logs); err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		if err := e.upload(ctx, batch.folders, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upload writes the records as a new object.
+func (e *gcsExporter) upload(ctx context.Context, folders string, w recordWriter) error {
+	data, err := w.bytes()
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	name := e.objectName(folders)
+	object := &storage.Object{Name: name}
+	if e.cfg.Encoding == encodingJSONL && e.cfg.Compression == compressionGzip {
+		// Objects are served decompressed to the clients that don't accept
+		// gzip.
+		object.ContentEncoding = "gzip"
+	}
+	call := e.objects.Insert(e.cfg.Bucket, object).
+		Media(bytes.NewReader(data), googleapi.ChunkSize(e.cfg.ChunkSize), googleapi.ContentType(e.contentType())).
+		Context(ctx)
+	if e.cfg.KMSKeyName != "" {
+		call = call.KmsKeyName(e.cfg.KMSKeyName)
+	}
+	if _, err = call.Do(); err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code >= 400 && apiErr.Code < 500 &&
+			apiErr.Code != http.StatusRequestTimeout && apiErr.Code != http.StatusTooManyRequests {
+			return consumererror.NewPermanent(err)
+		}
+		return err
+	}
+	e.logger.Debug("Uploaded object", zap.String("bucket", e.cfg.Bucket), zap.String("name", name), zap.Int("size", len(data)))
+	return nil
+}
+
+// objectName returns the name of a new object, in folders partitioned by
+// attributes then time, e.g.
+// prefix/logs/service.name=checkout/year=2021/month=10/day=04/hour=15/logs_<instance>_<n>.jsonl.gz.
+func (e *gcsExporter) objectName(folders string) string {
+	t := e.now().UTC()
+	timeFolder := fmt.Sprintf("year=%04d/month=%02d/day=%02d/hour=%02d", t.Year(), t.Month(), t.Day(), t.Hour())
+	if e.cfg.Partition == partitionMinute {
+		timeFolder += fmt.Sprintf("/minute=%02d", t.Minute())
+	}
+	name := fmt.Sprintf("%s_%s_%d.%s", e.signal, e.instance, atomic.AddUint64(&e.sequence, 1), e.extension())
+	return path.Join(e.cfg.Prefix, e.signal, folders, timeFolder, name)
+}
+
+func (e *gcsExporter) extension() string {
+	if e.cfg.Encoding == encodingParquet {
+		return "parquet"
+	}
+	if e.cfg.Compression == compressionGzip {
+		return "jsonl.gz"
+	}
+	return "jsonl"
+}
+
+func (e *gcsExporter) contentType() string {
+	if e.cfg.Encoding == encodingParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "application/x-ndjson"
+}
+
+// partitionFolders returns the key=value folders of the partition attributes
+// of a resource.
+func partitionFolders(keys []string, resource pdata.Resource) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	folders := make([]string, len(keys))
+	for i, key := range keys {
+		value := missingAttribute
+		if v, ok := resource.Attributes().Get(key); ok && v.AsString() != "" {
+			value = v.AsString()
+		}
+		folders[i] = url.PathEscape(key) + "=" + url.PathEscape(value)
+	}
+	return strings.Join(folders, "/")
+}
+
+type tracesBatch struct {
+	folders string
+	traces  pdata.Traces
+}
+
+// splitTraces groups resources by the folders of their partition attributes.
+func splitTraces(keys []string, td pdata.Traces) []tracesBatch {
+	if len(keys) == 0 {
+		return []tracesBatch{{traces: td}}
+	}
+	var batches []tracesBatch
+	index := map[string]int{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		folders := partitionFolders(keys, rs.Resource())
+		idx, ok := index[folders]
+		if !ok {
+			idx = len(batches)
+			index[folders] = idx
+			batches = append(batches, tracesBatch{folders: folders, traces: pdata.NewTraces()})
+		}
+		rs.CopyTo(batches[idx].traces.ResourceSpans().AppendEmpty())
+	}
+	return batches
+}
+
+type logsBatch struct {
+	folders string
+	logs    pdata.Logs
+}
+
+// splitLogs groups resources by the folders of their partition attributes.
+func splitLogs(keys []string, ld pdata.Logs) []logsBatch {
+	if len(keys) == 0 {
+		return []logsBatch{{logs: ld}}
+	}
+	var batches []logsBatch
+	index := map[string]int{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		folders := partitionFolders(keys, rl.Resource())
+		idx, ok := index[folders]
+		if !ok {
+			idx = len(batches)
+			index[folders] = idx
+			batches = append(batches, logsBatch{folders: folders, logs: pdata.NewLogs()})
+		}
+		rl.CopyTo(batches[idx].logs.ResourceLogs().AppendEmpty())
+	}
+	return batches
+}
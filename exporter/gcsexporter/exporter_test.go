@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsexporter
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+)
+
+type fakeObject struct {
+	metadata    map[string]interface{}
+	contentType string
+	kmsKeyName  string
+	data        []byte
+	resumable   bool
+}
+
+// fakeGCS implements the multipart and resumable uploads of the JSON API of
+// Cloud Storage.
+type fakeGCS struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu         sync.Mutex
+	objects    map[string]*fakeObject
+	sessions   map[string]*fakeObject
+	failStatus int
+}
+
+func newFakeGCS(t *testing.T) *fakeGCS {
+	f := &fakeGCS{t: t, objects: map[string]*fakeObject{}, sessions: map[string]*fakeObject{}}
+	f.server = httptest.NewServer(f)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failStatus != 0 {
+		http.Error(w, `{"error":{"code":`+fmt.Sprint(f.failStatus)+`,"message":"failed"}}`, f.failStatus)
+		return
+	}
+	switch {
+	case r.URL.Path == "/upload/storage/v1/b/bucket/o" && r.URL.Query().Get("uploadType") == "multipart":
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		require.NoError(f.t, err)
+		require.Equal(f.t, "multipart/related", mediaType)
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		obj := &fakeObject{kmsKeyName: r.URL.Query().Get("kmsKeyName")}
+		part, err := mr.NextPart()
+		require.NoError(f.t, err)
+		require.NoError(f.t, json.NewDecoder(part).Decode(&obj.metadata))
+		part, err = mr.NextPart()
+		require.NoError(f.t, err)
+		obj.contentType = part.Header.Get("Content-Type")
+		obj.data, err = ioutil.ReadAll(part)
+		require.NoError(f.t, err)
+		f.finish(w, obj)
+	case r.URL.Path == "/upload/storage/v1/b/bucket/o" && r.URL.Query().Get("uploadType") == "resumable":
+		obj := &fakeObject{
+			kmsKeyName:  r.URL.Query().Get("kmsKeyName"),
+			contentType: r.Header.Get("X-Upload-Content-Type"),
+			resumable:   true,
+		}
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&obj.metadata))
+		id := fmt.Sprint(len(f.sessions))
+		f.sessions[id] = obj
+		w.Header().Set("Location", f.server.URL+"/upload/session/"+id)
+		w.WriteHeader(http.StatusOK)
+	case strings.HasPrefix(r.URL.Path, "/upload/session/"):
+		obj := f.sessions[strings.TrimPrefix(r.URL.Path, "/upload/session/")]
+		require.NotNil(f.t, obj)
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(f.t, err)
+		obj.data = append(obj.data, data...)
+		if strings.HasSuffix(r.Header.Get("Content-Range"), "/*") {
+			// The client asks for a 200 status with an override header
+			// instead of 308.
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(obj.data)-1))
+			w.Header().Set("X-Http-Status-Code-Override", "308")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		f.finish(w, obj)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeGCS) finish(w http.ResponseWriter, obj *fakeObject) {
+	name := obj.metadata["name"].(string)
+	f.objects[name] = obj
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"bucket": "bucket", "name": name})
+}
+
+func (f *fakeGCS) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for name := range f.objects {
+		names = append(names, name)
+	}
+	return names
+}
+
+func newTestExporter(t *testing.T, f *fakeGCS, signal string, modify func(*Config)) *gcsExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket = "bucket"
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	exp, err := newExporter(cfg, zap.NewNop(), signal)
+	require.NoError(t, err)
+	exp.instance = "instance"
+	exp.now = func() time.Time { return time.Date(2021, 10, 4, 15, 5, 0, 0, time.UTC) }
+	exp.clientOptions = []option.ClientOption{
+		option.WithEndpoint(f.server.URL + "/storage/v1/"),
+		option.WithoutAuthentication(),
+	}
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	for _, service := range []string{"checkout", "cart", "checkout"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", service)
+		ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+		ill.InstrumentationLibrary().SetName("lib")
+		log := ill.Logs().AppendEmpty()
+		log.SetTimestamp(pdata.Timestamp(1633359900000000000))
+		log.SetSeverityNumber(pdata.SeverityNumberINFO)
+		log.SetSeverityText("INFO")
+		log.Body().SetStringVal(service + " message")
+		log.Attributes().InsertInt("attempt", 2)
+	}
+	return ld
+}
+
+func TestPushLogsJSONLines(t *testing.T) {
+	f := newFakeGCS(t)
+	exp := newTestExporter(t, f, signalLogs, func(cfg *Config) {
+		cfg.Prefix = "archive"
+		cfg.PartitionAttributes = []string{"service.name", "env"}
+		cfg.KMSKeyName = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+	})
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs()))
+
+	checkout := "archive/logs/service.name=checkout/env=__missing__/year=2021/month=10/day=04/hour=15/logs_instance_1.jsonl.gz"
+	cart := "archive/logs/service.name=cart/env=__missing__/year=2021/month=10/day=04/hour=15/logs_instance_2.jsonl.gz"
+	assert.ElementsMatch(t, []string{checkout, cart}, f.names())
+
+	obj := f.objects[checkout]
+	assert.Equal(t, "projects/p/locations/l/keyRings/r/cryptoKeys/k", obj.kmsKeyName)
+	assert.Equal(t, "application/x-ndjson", obj.contentType)
+	assert.Equal(t, "gzip", obj.metadata["contentEncoding"])
+	assert.False(t, obj.resumable)
+
+	zr, err := gzip.NewReader(bytes.NewReader(obj.data))
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(zr)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"time_unix_nano":1633359900000000000,"severity_number":9,"severity_text":"INFO","name":"",`+
+		`"body":"checkout message","trace_id":"","span_id":"","attributes":"{\"attempt\":2}",`+
+		`"resource_attributes":"{\"service.name\":\"checkout\"}","instrumentation_library_name":"lib","instrumentation_library_version":""}`, lines[0])
+}
+
+func TestPushTracesParquetResumable(t *testing.T) {
+	f := newFakeGCS(t)
+	exp := newTestExporter(t, f, signalTraces, func(cfg *Config) {
+		cfg.Encoding = encodingParquet
+		cfg.Partition = partitionMinute
+		cfg.ChunkSize = 256 * 1024
+	})
+
+	rnd := rand.New(rand.NewSource(1))
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i := 0; i < 5000; i++ {
+		span := spans.AppendEmpty()
+		span.SetName(fmt.Sprintf("span-%d", i))
+		span.SetTraceID(pdata.NewTraceID([16]byte{byte(i), byte(i >> 8), 1}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{byte(i), byte(i >> 8), 2}))
+		payload := make([]byte, 64)
+		rnd.Read(payload)
+		span.Attributes().InsertString("payload", hex.EncodeToString(payload))
+	}
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	name := "traces/year=2021/month=10/day=04/hour=15/minute=05/traces_instance_1.parquet"
+	require.Equal(t, []string{name}, f.names())
+	obj := f.objects[name]
+	assert.True(t, obj.resumable)
+	assert.Equal(t, "application/vnd.apache.parquet", obj.contentType)
+	assert.Greater(t, len(obj.data), 256*1024)
+	assert.Equal(t, "PAR1", string(obj.data[:4]))
+	assert.Equal(t, "PAR1", string(obj.data[len(obj.data)-4:]))
+}
+
+func TestPushErrors(t *testing.T) {
+	f := newFakeGCS(t)
+	exp := newTestExporter(t, f, signalLogs, nil)
+
+	f.failStatus = http.StatusForbidden
+	err := exp.pushLogs(context.Background(), testLogs())
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	f.failStatus = http.StatusTooManyRequests
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = exp.pushLogs(ctx, testLogs())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestSplitWithoutPartitionAttributes(t *testing.T) {
+	ld := testLogs()
+	batches := splitLogs(nil, ld)
+	require.Len(t, batches, 1)
+	assert.Equal(t, "", batches[0].folders)
+	assert.Equal(t, 3, batches[0].logs.ResourceLogs().Len())
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("k", "a/b")
+	traceBatches := splitTraces([]string{"k"}, td)
+	require.Len(t, traceBatches, 1)
+	assert.Equal(t, "k=a%2Fb", traceBatches[0].folders)
+}
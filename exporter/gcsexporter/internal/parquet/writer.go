@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquet writes tables of flat, required columns as Parquet files,
+// compressed with snappy.
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Type is the type of the values of a column.
+type Type int
+
+const (
+	// String columns hold UTF-8 strings.
+	String Type = iota
+	// Int64 columns hold signed 64-bit integers.
+	Int64
+	// Int32 columns hold signed 32-bit integers.
+	Int32
+	// Double columns hold 64-bit floating point numbers.
+	Double
+)
+
+// Column describes a column of a table.
+type Column struct {
+	Name string
+	Type Type
+}
+
+// metadata returns the schema of the column in the format of the CSV writer
+// of parquet-go.
+func (c Column) metadata() string {
+	switch c.Type {
+	case Int64:
+		return fmt.Sprintf("name=%s, type=INT64, repetitiontype=REQUIRED", c.Name)
+	case Int32:
+		return fmt.Sprintf("name=%s, type=INT32, repetitiontype=REQUIRED", c.Name)
+	case Double:
+		return fmt.Sprintf("name=%s, type=DOUBLE, repetitiontype=REQUIRED", c.Name)
+	default:
+		return fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REQUIRED", c.Name)
+	}
+}
+
+// Writer encodes the rows of a table as a Parquet file.
+type Writer struct {
+	columns []Column
+	buf     bytes.Buffer
+	w       *writer.CSVWriter
+	rows    int
+}
+
+// NewWriter creates a Writer for a table with the given columns.
+func NewWriter(columns []Column) (*Writer, error) {
+	md := make([]string, len(columns))
+	for i, col := range columns {
+		md[i] = col.metadata()
+	}
+	w := &Writer{columns: columns}
+	var err error
+	if w.w, err = writer.NewCSVWriterFromWriter(md, &w.buf, 1); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Rows returns the number of rows written.
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Write appends a row, with a value of the type of each column: string,
+// int64, int32 or float64.
+func (w *Writer) Write(row ...interface{}) error {
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(row), len(w.columns))
+	}
+	for i, col := range w.columns {
+		var ok bool
+		switch col.Type {
+		case String:
+			_, ok = row[i].(string)
+		case Int64:
+			_, ok = row[i].(int64)
+		case Int32:
+			_, ok = row[i].(int32)
+		case Double:
+			_, ok = row[i].(float64)
+		}
+		if !ok {
+			return fmt.Errorf("value of column %q has unexpected type %T", col.Name, row[i])
+		}
+	}
+	if err := w.w.Write(row); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+// Bytes flushes the rows written and returns the Parquet file. No row can be
+// written afterwards.
+func (w *Writer) Bytes() ([]byte, error) {
+	if err := w.w.WriteStop(); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
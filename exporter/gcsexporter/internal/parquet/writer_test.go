@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/common"
+	goparquet "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestWriter(t *testing.T) {
+	columns := []Column{
+		{Name: "name", Type: String},
+		{Name: "time", Type: Int64},
+		{Name: "severity", Type: Int32},
+		{Name: "value", Type: Double},
+	}
+	w, err := NewWriter(columns)
+	require.NoError(t, err)
+	require.NoError(t, w.Write("first", int64(1633359600000000000), int32(9), 1.5))
+	require.NoError(t, w.Write("", int64(-1), int32(17), -2.25))
+	assert.Equal(t, 2, w.Rows())
+	assert.EqualError(t, w.Write("x", 1, int32(1), 1.0), `value of column "time" has unexpected type int`)
+	assert.EqualError(t, w.Write("x"), "row has 1 values, expected 4")
+
+	file, err := w.Bytes()
+	require.NoError(t, err)
+
+	// The file is read back with the reader of parquet-go.
+	f, err := buffer.NewBufferFile(file)
+	require.NoError(t, err)
+	r, err := reader.NewParquetColumnReader(f, 1)
+	require.NoError(t, err)
+	defer r.ReadStop()
+	require.Equal(t, int64(2), r.GetNumRows())
+
+	schema := r.Footer.Schema
+	require.Len(t, schema, 5)
+	for i, col := range columns {
+		assert.Equal(t, col.Name, r.SchemaHandler.GetExName(i+1))
+		assert.Equal(t, goparquet.FieldRepetitionType_REQUIRED, schema[i+1].GetRepetitionType())
+	}
+	for _, rowGroup := range r.Footer.RowGroups {
+		for _, chunk := range rowGroup.Columns {
+			assert.Equal(t, goparquet.CompressionCodec_SNAPPY, chunk.MetaData.Codec)
+		}
+	}
+
+	expected := [][]interface{}{
+		{"first", ""},
+		{int64(1633359600000000000), int64(-1)},
+		{int32(9), int32(17)},
+		{1.5, -2.25},
+	}
+	for i, col := range columns {
+		values, _, _, err := r.ReadColumnByPath(common.ReformPathStr(r.SchemaHandler.GetRootExName()+"."+col.Name), 2)
+		require.NoError(t, err)
+		assert.Equal(t, expected[i], values, col.Name)
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	w, err := NewWriter([]Column{{Name: "name", Type: String}})
+	require.NoError(t, err)
+	file, err := w.Bytes()
+	require.NoError(t, err)
+
+	f, err := buffer.NewBufferFile(file)
+	require.NoError(t, err)
+	r, err := reader.NewParquetColumnReader(f, 1)
+	require.NoError(t, err)
+	defer r.ReadStop()
+	assert.Equal(t, int64(0), r.GetNumRows())
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/gcsexporter/internal/parquet"
+)
+
+// spanColumns are the columns of the records of spans.
+var spanColumns = []parquet.Column{
+	{Name: "trace_id", Type: parquet.String},
+	{Name: "span_id", Type: parquet.String},
+	{Name: "parent_span_id", Type: parquet.String},
+	{Name: "trace_state", Type: parquet.String},
+	{Name: "name", Type: parquet.String},
+	{Name: "kind", Type: parquet.String},
+	{Name: "start_time_unix_nano", Type: parquet.Int64},
+	{Name: "end_time_unix_nano", Type: parquet.Int64},
+	{Name: "status_code", Type: parquet.String},
+	{Name: "status_message", Type: parquet.String},
+	{Name: "attributes", Type: parquet.String},
+	{Name: "resource_attributes", Type: parquet.String},
+	{Name: "instrumentation_library_name", Type: parquet.String},
+	{Name: "instrumentation_library_version", Type: parquet.String},
+}
+
+// logColumns are the columns of the records of logs.
+var logColumns = []parquet.Column{
+	{Name: "time_unix_nano", Type: parquet.Int64},
+	{Name: "severity_number", Type: parquet.Int32},
+	{Name: "severity_text", Type: parquet.String},
+	{Name: "name", Type: parquet.String},
+	{Name: "body", Type: parquet.String},
+	{Name: "trace_id", Type: parquet.String},
+	{Name: "span_id", Type: parquet.String},
+	{Name: "attributes", Type: parquet.String},
+	{Name: "resource_attributes", Type: parquet.String},
+	{Name: "instrumentation_library_name", Type: parquet.String},
+	{Name: "instrumentation_library_version", Type: parquet.String},
+}
+
+// recordWriter writes flat records, a row per span or log record, with the
+// values of the columns of the signal.
+type recordWriter interface {
+	write(row ...interface{}) error
+	bytes() ([]byte, error)
+}
+
+func newRecordWriter(cfg *Config, columns []parquet.Column) (recordWriter, error) {
+	if cfg.Encoding == encodingParquet {
+		w, err := parquet.NewWriter(columns)
+		if err != nil {
+			return nil, err
+		}
+		return &parquetWriter{w: w}, nil
+	}
+	return &jsonlWriter{columns: columns, gzip: cfg.Compression == compressionGzip}, nil
+}
+
+// jsonlWriter writes records as JSON objects, one per line, with the keys in
+// the order of the columns.
+type jsonlWriter struct {
+	columns []parquet.Column
+	gzip    bool
+	buf     bytes.Buffer
+}
+
+func (w *jsonlWriter) write(row ...interface{}) error {
+	w.buf.WriteByte('{')
+	for i, col := range w.columns {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.Name)
+		w.buf.Write(key)
+		w.buf.WriteByte(':')
+		value, err := json.Marshal(row[i])
+		if err != nil {
+			return err
+		}
+		w.buf.Write(value)
+	}
+	w.buf.WriteString("}\n")
+	return nil
+}
+
+func (w *jsonlWriter) bytes() ([]byte, error) {
+	if !w.gzip {
+		return w.buf.Bytes(), nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(w.buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type parquetWriter struct {
+	w *parquet.Writer
+}
+
+func (w *parquetWriter) write(row ...interface{}) error {
+	return w.w.Write(row...)
+}
+
+func (w *parquetWriter) bytes() ([]byte, error) {
+	return w.w.Bytes()
+}
+
+// writeSpans writes a record per span.
+func writeSpans(w recordWriter, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttrs, err := attributesJSON(rs.Resource().Attributes())
+		if err != nil {
+			return err
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			library := ils.InstrumentationLibrary()
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				attrs, err := attributesJSON(span.Attributes())
+				if err != nil {
+					return err
+				}
+				err = w.write(
+					span.TraceID().HexString(),
+					span.SpanID().HexString(),
+					span.ParentSpanID().HexString(),
+					string(span.TraceState()),
+					span.Name(),
+					span.Kind().String(),
+					int64(span.StartTimestamp()),
+					int64(span.EndTimestamp()),
+					span.Status().Code().String(),
+					span.Status().Message(),
+					attrs,
+					resourceAttrs,
+					library.Name(),
+					library.Version(),
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeLogs writes a record per log record.
+func writeLogs(w recordWriter, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttrs, err := attributesJSON(rl.Resource().Attributes())
+		if err != nil {
+			return err
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			library := ill.InstrumentationLibrary()
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				attrs, err := attributesJSON(log.Attributes())
+				if err != nil {
+					return err
+				}
+				err = w.write(
+					int64(log.Timestamp()),
+					int32(log.SeverityNumber()),
+					log.SeverityText(),
+					log.Name(),
+					log.Body().AsString(),
+					log.TraceID().HexString(),
+					log.SpanID().HexString(),
+					attrs,
+					resourceAttrs,
+					library.Name(),
+					library.Version(),
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// attributesJSON encodes attributes as a JSON object.
+func attributesJSON(attrs pdata.AttributeMap) (string, error) {
+	buf, err := json.Marshal(attrs.AsRaw())
+	return string(buf), err
+}
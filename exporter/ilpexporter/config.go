@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for the ILP exporter.
+type Config struct {
+	config.ExporterSettings      `mapstructure:",squash"`
+	exporterhelper.QueueSettings `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the host and port of the line protocol TCP listener.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Timeout is the maximum duration allowed to connect and send a batch of
+	// lines to the endpoint.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TLSSetting configures TLS on the connections. Connections are in
+	// plaintext if not set.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// CertReloader is the ID of the certreloader extension reloading the
+	// certificate, key and CA files of TLSSetting when they change. The files
+	// are only loaded at start if empty.
+	CertReloader string `mapstructure:"cert_reloader"`
+
+	// Measurement is the measurement all the metrics are written to, using
+	// the name of the metrics as field keys. Each metric is written to the
+	// measurement of its name if empty.
+	Measurement string `mapstructure:"measurement"`
+
+	// Tags lists the attributes written as tags. All the attributes are
+	// written as tags if empty, others are dropped otherwise.
+	Tags []string `mapstructure:"tags"`
+
+	// Fields lists the attributes written as string fields rather than tags.
+	Fields []string `mapstructure:"fields"`
+
+	// ResourceAttributes writes the resource attributes along with the
+	// attributes of the data points, which take precedence.
+	ResourceAttributes bool `mapstructure:"resource_attributes"`
+
+	// ValueField is the field key of the values of gauges, sums and summary
+	// quantiles when metrics are written to the measurement of their name.
+	ValueField string `mapstructure:"value_field"`
+
+	// ReplaceDots replaces the dots of measurements, tag keys and field keys
+	// with underscores. QuestDB doesn't accept dots in column names.
+	ReplaceDots bool `mapstructure:"replace_dots"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if cfg.CertReloader != "" {
+		if cfg.TLSSetting == nil {
+			return errors.New("cert_reloader requires tls to be configured")
+		}
+		if _, err := config.NewComponentIDFromString(cfg.CertReloader); err != nil {
+			return fmt.Errorf("invalid cert_reloader %q: %w", cfg.CertReloader, err)
+		}
+	}
+	if cfg.Measurement == "" && cfg.ValueField == "" {
+		return errors.New("value_field must be specified when measurement is empty")
+	}
+	tags := make(map[string]bool, len(cfg.Tags))
+	for _, key := range cfg.Tags {
+		tags[key] = true
+	}
+	for _, key := range cfg.Fields {
+		if tags[key] {
+			return fmt.Errorf("attribute %q can't be both a tag and a field", key)
+		}
+	}
+	return nil
+}
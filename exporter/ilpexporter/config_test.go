@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Exporters[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "customized")),
+		QueueSettings: exporterhelper.QueueSettings{
+			Enabled:      true,
+			NumConsumers: 2,
+			QueueSize:    10,
+		},
+		RetrySettings: exporterhelper.RetrySettings{
+			Enabled:         true,
+			InitialInterval: 10 * time.Second,
+			MaxInterval:     time.Minute,
+			MaxElapsedTime:  10 * time.Minute,
+		},
+		Endpoint: "questdb:9009",
+		Timeout:  10 * time.Second,
+		TLSSetting: &configtls.TLSClientSetting{
+			InsecureSkipVerify: true,
+		},
+		CertReloader:       "certreloader",
+		Measurement:        "otel",
+		Tags:               []string{"host.name", "service.name"},
+		Fields:             []string{"http.url"},
+		ResourceAttributes: false,
+		ValueField:         "value",
+		ReplaceDots:        false,
+	}, cfg.Exporters[config.NewComponentIDWithName(typeStr, "customized")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "missing port",
+			modify: func(cfg *Config) { cfg.Endpoint = "localhost" },
+			err:    `invalid endpoint "localhost": address localhost: missing port in address`,
+		},
+		{
+			name:   "zero timeout",
+			modify: func(cfg *Config) { cfg.Timeout = 0 },
+			err:    "timeout must be positive",
+		},
+		{
+			name:   "cert reloader without tls",
+			modify: func(cfg *Config) { cfg.CertReloader = "certreloader" },
+			err:    "cert_reloader requires tls to be configured",
+		},
+		{
+			name: "invalid cert reloader",
+			modify: func(cfg *Config) {
+				cfg.TLSSetting = &configtls.TLSClientSetting{}
+				cfg.CertReloader = "certreloader/"
+			},
+			err: `invalid cert_reloader "certreloader/": name part must be specified after / in type/name key`,
+		},
+		{
+			name:   "missing value field",
+			modify: func(cfg *Config) { cfg.ValueField = "" },
+			err:    "value_field must be specified when measurement is empty",
+		},
+		{
+			name: "measurement without value field",
+			modify: func(cfg *Config) {
+				cfg.Measurement = "otel"
+				cfg.ValueField = ""
+			},
+		},
+		{
+			name: "tag and field",
+			modify: func(cfg *Config) {
+				cfg.Tags = []string{"host.name", "http.url"}
+				cfg.Fields = []string{"http.url"}
+			},
+			err: `attribute "http.url" can't be both a tag and a field`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	lineprotocol "github.com/influxdata/line-protocol/v2/influxdata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// lineEncoder converts metrics to lines of the InfluxDB line protocol
+// according to the mapping of the configuration.
+type lineEncoder struct {
+	measurement        string
+	tags               map[string]bool
+	fields             map[string]bool
+	resourceAttributes bool
+	valueField         string
+	replaceDots        bool
+}
+
+// keyValue is a tag or a string field.
+type keyValue struct {
+	key   string
+	value string
+}
+
+// field is a field of a line.
+type field struct {
+	key   string
+	value lineprotocol.Value
+}
+
+func newLineEncoder(cfg *Config) *lineEncoder {
+	le := &lineEncoder{
+		measurement:        cfg.Measurement,
+		fields:             make(map[string]bool, len(cfg.Fields)),
+		resourceAttributes: cfg.ResourceAttributes,
+		valueField:         cfg.ValueField,
+		replaceDots:        cfg.ReplaceDots,
+	}
+	if len(cfg.Tags) > 0 {
+		le.tags = make(map[string]bool, len(cfg.Tags))
+		for _, key := range cfg.Tags {
+			le.tags[key] = true
+		}
+	}
+	for _, key := range cfg.Fields {
+		le.fields[key] = true
+	}
+	return le
+}
+
+// encode returns the lines of the metrics. The points that can't be encoded
+// are left out and the first encoding error is returned along with the
+// lines of the others.
+func (le *lineEncoder) encode(md pdata.Metrics) ([]byte, error) {
+	var enc lineprotocol.Encoder
+	enc.SetPrecision(lineprotocol.Nanosecond)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				le.encodeMetric(&enc, rm.Resource().Attributes(), metrics.At(k))
+			}
+		}
+	}
+	return enc.Bytes(), enc.Err()
+}
+
+func (le *lineEncoder) encodeMetric(enc *lineprotocol.Encoder, resource pdata.AttributeMap, metric pdata.Metric) {
+	measurement := le.measurement
+	if measurement == "" {
+		measurement = metric.Name()
+	}
+	measurement = le.key(measurement)
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		le.encodeNumberDataPoints(enc, measurement, resource, metric.Name(), metric.Gauge().DataPoints())
+	case pdata.MetricDataTypeSum:
+		le.encodeNumberDataPoints(enc, measurement, resource, metric.Name(), metric.Sum().DataPoints())
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			tags, strFields := le.labels(resource, dp.Attributes())
+			fields := []field{{le.fieldKey(metric.Name(), "count"), countValue(dp.Count())}}
+			fields = appendFloat(fields, le.fieldKey(metric.Name(), "sum"), dp.Sum())
+			writeLine(enc, measurement, tags, strFields, fields, dp.Timestamp())
+
+			// The bucket counts are cumulative, as the le label of Prometheus
+			// histograms.
+			bounds := dp.ExplicitBounds()
+			var count uint64
+			for j, bucketCount := range dp.BucketCounts() {
+				count += bucketCount
+				bound := math.Inf(1)
+				if j < len(bounds) {
+					bound = bounds[j]
+				}
+				bucketTags := withTag(tags, keyValue{"le", formatFloat(bound)})
+				fields := []field{{le.fieldKey(metric.Name(), "bucket"), countValue(count)}}
+				writeLine(enc, measurement, bucketTags, strFields, fields, dp.Timestamp())
+			}
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			tags, strFields := le.labels(resource, dp.Attributes())
+			fields := []field{{le.fieldKey(metric.Name(), "count"), countValue(dp.Count())}}
+			fields = appendFloat(fields, le.fieldKey(metric.Name(), "sum"), dp.Sum())
+			writeLine(enc, measurement, tags, strFields, fields, dp.Timestamp())
+
+			quantiles := dp.QuantileValues()
+			for j := 0; j < quantiles.Len(); j++ {
+				q := quantiles.At(j)
+				quantileTags := withTag(tags, keyValue{"quantile", formatFloat(q.Quantile())})
+				fields := appendFloat(nil, le.fieldKey(metric.Name(), ""), q.Value())
+				writeLine(enc, measurement, quantileTags, strFields, fields, dp.Timestamp())
+			}
+		}
+	}
+}
+
+func (le *lineEncoder) encodeNumberDataPoints(enc *lineprotocol.Encoder, measurement string, resource pdata.AttributeMap, name string, dps pdata.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		var fields []field
+		switch dp.Type() {
+		case pdata.MetricValueTypeInt:
+			fields = []field{{le.fieldKey(name, ""), lineprotocol.IntValue(dp.IntVal())}}
+		case pdata.MetricValueTypeDouble:
+			fields = appendFloat(nil, le.fieldKey(name, ""), dp.DoubleVal())
+		}
+		tags, strFields := le.labels(resource, dp.Attributes())
+		writeLine(enc, measurement, tags, strFields, fields, dp.Timestamp())
+	}
+}
+
+// labels splits the attributes of a data point, and of its resource if
+// enabled, into the sorted tags and the string fields of its lines.
+func (le *lineEncoder) labels(resource, attrs pdata.AttributeMap) ([]keyValue, []keyValue) {
+	values := make(map[string]string, attrs.Len())
+	if le.resourceAttributes {
+		resource.Range(func(k string, v pdata.AttributeValue) bool {
+			values[k] = v.AsString()
+			return true
+		})
+	}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		values[k] = v.AsString()
+		return true
+	})
+
+	var tags, strFields []keyValue
+	for k, v := range values {
+		// Empty tag values are invalid.
+		if v == "" {
+			continue
+		}
+		switch {
+		case le.fields[k]:
+			strFields = append(strFields, keyValue{le.key(k), v})
+		case le.tags == nil || le.tags[k]:
+			tags = append(tags, keyValue{le.key(k), v})
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].key < tags[j].key })
+	sort.Slice(strFields, func(i, j int) bool { return strFields[i].key < strFields[j].key })
+	return tags, strFields
+}
+
+// fieldKey returns the key of the field of a metric with the given suffix,
+// the empty suffix being its value.
+func (le *lineEncoder) fieldKey(name, suffix string) string {
+	if le.measurement == "" {
+		if suffix == "" {
+			return le.key(le.valueField)
+		}
+		return le.key(suffix)
+	}
+	if suffix == "" {
+		return le.key(name)
+	}
+	return le.key(name + "_" + suffix)
+}
+
+func (le *lineEncoder) key(s string) string {
+	if le.replaceDots {
+		return strings.ReplaceAll(s, ".", "_")
+	}
+	return s
+}
+
+// writeLine adds a line with the given sorted tags. The line is skipped if
+// it has no numeric field.
+func writeLine(enc *lineprotocol.Encoder, measurement string, tags, strFields []keyValue, fields []field, ts pdata.Timestamp) {
+	if len(fields) == 0 {
+		return
+	}
+
+	enc.StartLine(measurement)
+	for _, tag := range tags {
+		enc.AddTag(tag.key, tag.value)
+	}
+	for _, f := range fields {
+		enc.AddField(f.key, f.value)
+	}
+	for _, f := range strFields {
+		if v, ok := lineprotocol.StringValue(f.value); ok {
+			enc.AddField(f.key, v)
+		}
+	}
+	// Lines without timestamp are timestamped by the server.
+	var t time.Time
+	if ts != 0 {
+		t = ts.AsTime()
+	}
+	enc.EndLine(t)
+}
+
+// withTag returns a copy of the sorted tags with an additional one.
+func withTag(tags []keyValue, tag keyValue) []keyValue {
+	result := make([]keyValue, 0, len(tags)+1)
+	for _, t := range tags {
+		if t.key != tag.key {
+			result = append(result, t)
+		}
+	}
+	result = append(result, tag)
+	sort.Slice(result, func(i, j int) bool { return result[i].key < result[j].key })
+	return result
+}
+
+// countValue returns a signed integer, as QuestDB doesn't support the
+// unsigned integers of InfluxDB 2.
+func countValue(count uint64) lineprotocol.Value {
+	if count > math.MaxInt64 {
+		count = math.MaxInt64
+	}
+	return lineprotocol.IntValue(int64(count))
+}
+
+// appendFloat appends the field of a float, unless it is NaN or infinite
+// which can't be encoded.
+func appendFloat(fields []field, key string, f float64) []field {
+	if v, ok := lineprotocol.FloatValue(f); ok {
+		return append(fields, field{key, v})
+	}
+	return fields
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var testTime = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func testMetrics() (pdata.Metrics, pdata.MetricSlice) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	rm.Resource().Attributes().InsertString("host.name", "host-1")
+	return md, rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+}
+
+func addGauge(metrics pdata.MetricSlice, name string, value float64, attrs map[string]string) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	dp.SetDoubleVal(value)
+	for k, v := range attrs {
+		dp.Attributes().InsertString(k, v)
+	}
+}
+
+func encodeLines(t *testing.T, cfg *Config, md pdata.Metrics) []string {
+	lines, err := newLineEncoder(cfg).encode(md)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(lines), "\n"), "\n")
+}
+
+func TestEncodeNumbers(t *testing.T) {
+	md, metrics := testMetrics()
+	addGauge(metrics, "system.cpu.utilization", 0.5, map[string]string{"state": "idle"})
+	m := metrics.AppendEmpty()
+	m.SetName("http.requests")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetIntVal(42)
+	dp.Attributes().InsertString("host.name", "host-2")
+
+	assert.Equal(t, []string{
+		"system_cpu_utilization,host_name=host-1,service_name=checkout,state=idle value=0.5 1633089600000000000",
+		// Data point attributes take precedence and lines without
+		// timestamp are timestamped by the server.
+		"http_requests,host_name=host-2,service_name=checkout value=42i",
+	}, encodeLines(t, createDefaultConfig().(*Config), md))
+}
+
+func TestEncodeFixedMeasurement(t *testing.T) {
+	md, metrics := testMetrics()
+	addGauge(metrics, "system.cpu.utilization", 0.5, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Measurement = "otel"
+	cfg.ReplaceDots = false
+	assert.Equal(t, []string{
+		"otel,host.name=host-1,service.name=checkout system.cpu.utilization=0.5 1633089600000000000",
+	}, encodeLines(t, cfg, md))
+}
+
+func TestEncodeTagsAndFields(t *testing.T) {
+	md, metrics := testMetrics()
+	addGauge(metrics, "http.duration", 1.5, map[string]string{"http.url": "https://example.com/cart", "http.method": "GET", "empty": ""})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tags = []string{"service.name", "http.method", "empty"}
+	cfg.Fields = []string{"http.url"}
+	assert.Equal(t, []string{
+		`http_duration,http_method=GET,service_name=checkout value=1.5,http_url="https://example.com/cart" 1633089600000000000`,
+	}, encodeLines(t, cfg, md))
+
+	cfg.ResourceAttributes = false
+	assert.Equal(t, []string{
+		`http_duration,http_method=GET value=1.5,http_url="https://example.com/cart" 1633089600000000000`,
+	}, encodeLines(t, cfg, md))
+}
+
+func TestEncodeHistogram(t *testing.T) {
+	md, metrics := testMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("http.duration")
+	m.SetDataType(pdata.MetricDataTypeHistogram)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	dp.SetCount(10)
+	dp.SetSum(4.5)
+	dp.SetExplicitBounds([]float64{0.1, 1})
+	dp.SetBucketCounts([]uint64{3, 5, 2})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResourceAttributes = false
+	assert.Equal(t, []string{
+		"http_duration count=10i,sum=4.5 1633089600000000000",
+		"http_duration,le=0.1 bucket=3i 1633089600000000000",
+		"http_duration,le=1 bucket=8i 1633089600000000000",
+		"http_duration,le=+Inf bucket=10i 1633089600000000000",
+	}, encodeLines(t, cfg, md))
+
+	cfg.Measurement = "otel"
+	assert.Equal(t, []string{
+		"otel http_duration_count=10i,http_duration_sum=4.5 1633089600000000000",
+		"otel,le=0.1 http_duration_bucket=3i 1633089600000000000",
+		"otel,le=1 http_duration_bucket=8i 1633089600000000000",
+		"otel,le=+Inf http_duration_bucket=10i 1633089600000000000",
+	}, encodeLines(t, cfg, md))
+}
+
+func TestEncodeSummary(t *testing.T) {
+	md, metrics := testMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("rpc.duration")
+	m.SetDataType(pdata.MetricDataTypeSummary)
+	dp := m.Summary().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	dp.SetCount(4)
+	dp.SetSum(2)
+	dp.Attributes().InsertString("rpc.method", "Get")
+	q := dp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(0.9)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResourceAttributes = false
+	assert.Equal(t, []string{
+		"rpc_duration,rpc_method=Get count=4i,sum=2 1633089600000000000",
+		"rpc_duration,quantile=0.99,rpc_method=Get value=0.9 1633089600000000000",
+	}, encodeLines(t, cfg, md))
+}
+
+func TestEncodeInvalidPoints(t *testing.T) {
+	md, metrics := testMetrics()
+	addGauge(metrics, "nan", math.NaN(), nil)
+	addGauge(metrics, "invalid", 1, map[string]string{"": "empty key"})
+	addGauge(metrics, "valid", 2, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResourceAttributes = false
+	lines, err := newLineEncoder(cfg).encode(md)
+	assert.Error(t, err)
+	assert.Equal(t, "valid value=2 1633089600000000000\n", string(lines))
+}
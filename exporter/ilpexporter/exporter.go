@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/certreloaderextension"
+)
+
+type ilpExporter struct {
+	cfg     *Config
+	logger  *zap.Logger
+	encoder *lineEncoder
+	conns   *connPool
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) *ilpExporter {
+	return &ilpExporter{
+		cfg:     cfg,
+		logger:  logger,
+		encoder: newLineEncoder(cfg),
+	}
+}
+
+func (e *ilpExporter) start(_ context.Context, host component.Host) error {
+	var tlsConfig *tls.Config
+	if e.cfg.TLSSetting != nil {
+		var reloader *config.ComponentID
+		if e.cfg.CertReloader != "" {
+			id, err := config.NewComponentIDFromString(e.cfg.CertReloader)
+			if err != nil {
+				return err
+			}
+			reloader = &id
+		}
+		var err error
+		if tlsConfig, err = certreloaderextension.LoadClientTLSConfig(host, reloader, *e.cfg.TLSSetting); err != nil {
+			return err
+		}
+	}
+	e.conns = newConnPool(e.cfg.Endpoint, e.cfg.Timeout, tlsConfig)
+	return nil
+}
+
+func (e *ilpExporter) shutdown(context.Context) error {
+	if e.conns != nil {
+		e.conns.close()
+	}
+	return nil
+}
+
+func (e *ilpExporter) pushMetrics(_ context.Context, md pdata.Metrics) error {
+	lines, err := e.encoder.encode(md)
+	if err != nil {
+		// Sending the points again wouldn't fix them, the valid ones are
+		// still sent.
+		e.logger.Warn("Dropped data points which can't be encoded", zap.Error(err))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return e.conns.write(lines)
+}
+
+// connPool is a stack of connections to the endpoint, so that batches of
+// lines can be sent concurrently by the consumers of the sending queue.
+//
+// The line protocol over TCP has no acknowledgements: the server closes the
+// connection on errors, which fails a later write. The lines written since
+// then are lost, or sent again if the write fails altogether.
+type connPool struct {
+	mtx       sync.Mutex
+	conns     []net.Conn
+	endpoint  string
+	timeout   time.Duration
+	tlsConfig *tls.Config
+}
+
+func newConnPool(endpoint string, timeout time.Duration, tlsConfig *tls.Config) *connPool {
+	return &connPool{
+		endpoint:  endpoint,
+		timeout:   timeout,
+		tlsConfig: tlsConfig,
+	}
+}
+
+// write writes the lines to a connection of the pool, or to a new one if
+// none is idle. Connections are put back in the pool unless the write fails.
+func (cp *connPool) write(lines []byte) error {
+	start := time.Now()
+	conn, err := cp.get()
+	if err != nil {
+		return err
+	}
+	if err = conn.SetWriteDeadline(start.Add(cp.timeout)); err == nil {
+		_, err = conn.Write(lines)
+	}
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	cp.put(conn)
+	return nil
+}
+
+func (cp *connPool) get() (net.Conn, error) {
+	cp.mtx.Lock()
+	if last := len(cp.conns) - 1; last >= 0 {
+		conn := cp.conns[last]
+		cp.conns = cp.conns[:last]
+		cp.mtx.Unlock()
+		return conn, nil
+	}
+	cp.mtx.Unlock()
+
+	dialer := &net.Dialer{Timeout: cp.timeout}
+	if cp.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", cp.endpoint, cp.tlsConfig)
+	}
+	return dialer.Dial("tcp", cp.endpoint)
+}
+
+func (cp *connPool) put(conn net.Conn) {
+	cp.mtx.Lock()
+	defer cp.mtx.Unlock()
+	cp.conns = append(cp.conns, conn)
+}
+
+func (cp *connPool) close() {
+	cp.mtx.Lock()
+	defer cp.mtx.Unlock()
+	for _, conn := range cp.conns {
+		conn.Close()
+	}
+	cp.conns = nil
+}
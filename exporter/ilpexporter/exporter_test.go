@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/certreloaderextension"
+)
+
+// newFakeServer starts a TCP listener sending the received lines to the
+// returned channel.
+func newFakeServer(t *testing.T) (net.Listener, <-chan string) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	lines := make(chan string, 100)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+	return ln, lines
+}
+
+func receiveLine(t *testing.T, lines <-chan string) string {
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no line received")
+		return ""
+	}
+}
+
+func newTestExporter(t *testing.T, endpoint string) *ilpExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.ResourceAttributes = false
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.shutdown(context.Background())) })
+	return exp
+}
+
+func TestPushMetrics(t *testing.T) {
+	ln, lines := newFakeServer(t)
+	exp := newTestExporter(t, ln.Addr().String())
+
+	md, metrics := testMetrics()
+	addGauge(metrics, "queue.size", 3, nil)
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+	assert.Equal(t, "queue_size value=3 1633089600000000000", receiveLine(t, lines))
+
+	// The connection is reused.
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+	assert.Equal(t, "queue_size value=3 1633089600000000000", receiveLine(t, lines))
+	assert.Len(t, exp.conns.conns, 1)
+}
+
+func TestPushMetricsEmpty(t *testing.T) {
+	exp := newTestExporter(t, "localhost:1")
+	md, metrics := testMetrics()
+	addGauge(metrics, "invalid", 1, map[string]string{"": "empty key"})
+	assert.NoError(t, exp.pushMetrics(context.Background(), md))
+}
+
+func TestPushMetricsConnectionRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	exp := newTestExporter(t, endpoint)
+	md, metrics := testMetrics()
+	addGauge(metrics, "queue.size", 3, nil)
+	assert.Error(t, exp.pushMetrics(context.Background(), md))
+	assert.Empty(t, exp.conns.conns)
+}
+
+func TestConnPoolDropsFailedConnections(t *testing.T) {
+	ln, lines := newFakeServer(t)
+	cp := newConnPool(ln.Addr().String(), time.Second, nil)
+	defer cp.close()
+
+	require.NoError(t, cp.write([]byte("a value=1\n")))
+	assert.Equal(t, "a value=1", receiveLine(t, lines))
+
+	// A closed connection isn't put back in the pool.
+	cp.conns[0].Close()
+	assert.Error(t, cp.write([]byte("b value=2\n")))
+	assert.Empty(t, cp.conns)
+
+	require.NoError(t, cp.write([]byte("c value=3\n")))
+	assert.Equal(t, "c value=3", receiveLine(t, lines))
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// newSelfSignedCert returns a new self-signed certificate for localhost and
+// its key, PEM encoded.
+func newSelfSignedCert(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// newTLSServer starts a TLS listener requiring client certificates, sending
+// the serial number of the client certificate of each new connection
+// to the returned channel.
+func newTLSServer(t *testing.T) (net.Listener, <-chan *big.Int) {
+	certPEM, keyPEM := newSelfSignedCert(t)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	ln, err := tls.Listen("tcp", "localhost:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	serials := make(chan *big.Int, 100)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				tlsConn := conn.(*tls.Conn)
+				if tlsConn.Handshake() != nil {
+					return
+				}
+				serials <- tlsConn.ConnectionState().PeerCertificates[0].SerialNumber
+				_, _ = ioutil.ReadAll(conn)
+			}()
+		}
+	}()
+	return ln, serials
+}
+
+func TestCertReloader(t *testing.T) {
+	ctx := context.Background()
+	ln, serials := newTLSServer(t)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writeCert := func() *big.Int {
+		certPEM, keyPEM := newSelfSignedCert(t)
+		require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+		require.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+		block, _ := pem.Decode(certPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		return cert.SerialNumber
+	}
+	first := writeCert()
+
+	factory := certreloaderextension.NewFactory()
+	extCfg := factory.CreateDefaultConfig().(*certreloaderextension.Config)
+	extCfg.CheckInterval = 10 * time.Millisecond
+	ext, err := factory.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), extCfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(ctx)) }()
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("certreloader"): ext},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	cfg.TLSSetting = &configtls.TLSClientSetting{
+		TLSSetting:         configtls.TLSSetting{CertFile: certFile, KeyFile: keyFile},
+		InsecureSkipVerify: true,
+	}
+	cfg.CertReloader = "certreloader"
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(ctx, host))
+	defer func() { require.NoError(t, exp.shutdown(ctx)) }()
+
+	md, metrics := testMetrics()
+	addGauge(metrics, "queue.size", 3, nil)
+	require.NoError(t, exp.pushMetrics(ctx, md))
+	assert.Equal(t, first, <-serials)
+
+	// New connections present the renewed certificate.
+	second := writeCert()
+	assert.Eventually(t, func() bool {
+		exp.conns.close()
+		require.NoError(t, exp.pushMetrics(ctx, md))
+		return (<-serials).Cmp(second) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ilpexporter
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "ilp"
+
+	// defaultEndpoint is the line protocol TCP listener of QuestDB.
+	defaultEndpoint   = "localhost:9009"
+	defaultTimeout    = 5 * time.Second
+	defaultValueField = "value"
+)
+
+// NewFactory creates a factory for the ILP exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter),
+	)
+}
+
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings:   config.NewExporterSettings(config.NewComponentID(typeStr)),
+		QueueSettings:      exporterhelper.DefaultQueueSettings(),
+		RetrySettings:      exporterhelper.DefaultRetrySettings(),
+		Endpoint:           defaultEndpoint,
+		Timeout:            defaultTimeout,
+		ResourceAttributes: true,
+		ValueField:         defaultValueField,
+		ReplaceDots:        true,
+	}
+}
+
+func createMetricsExporter(_ context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	oCfg := cfg.(*Config)
+	exp := newExporter(oCfg, set.Logger)
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		exp.pushMetrics,
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown),
+	)
+}
@@ -15,8 +15,11 @@
 package kafkaexporter
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Shopify/sarama"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -47,6 +50,11 @@ type Config struct {
 
 	// Authentication defines used authentication mechanism.
 	Authentication Authentication `mapstructure:"auth"`
+
+	// ResourceAttributesAsHeaders is the list of resource attribute keys copied
+	// to Kafka record headers. Resources are split into separate messages so
+	// that every message carries the values of a single resource.
+	ResourceAttributesAsHeaders []string `mapstructure:"resource_attributes_as_headers"`
 }
 
 // Metadata defines configuration for retrieving metadata from the broker.
@@ -67,6 +75,20 @@ type Metadata struct {
 type Producer struct {
 	// Maximum message bytes the producer will accept to produce.
 	MaxMessageBytes int `mapstructure:"max_message_bytes"`
+
+	// Compression codec used to produce messages (default "none").
+	// One of none, gzip, snappy, lz4 or zstd.
+	Compression string `mapstructure:"compression"`
+
+	// TransactionalID enables transactions when set: each batch is produced
+	// in a transaction, which also commits the offset of the message consumed
+	// by a Kafka receiver in exactly_once mode. It must be unique among the
+	// running Collectors, and stable across restarts.
+	TransactionalID string `mapstructure:"transactional_id"`
+
+	// TransactionTimeout is the time after which the coordinator aborts a
+	// transaction that wasn't committed (default 1m).
+	TransactionTimeout time.Duration `mapstructure:"transaction_timeout"`
 }
 
 // MetadataRetry defines retry configuration for Metadata.
@@ -83,5 +105,70 @@ var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	codec, err := compressionCodec(cfg.Producer.Compression)
+	if err != nil {
+		return err
+	}
+	if codec == sarama.CompressionZSTD {
+		version := sarama.V1_0_0_0
+		if cfg.ProtocolVersion != "" {
+			if version, err = sarama.ParseKafkaVersion(cfg.ProtocolVersion); err != nil {
+				return err
+			}
+		}
+		if !version.IsAtLeast(sarama.V2_1_0_0) {
+			return fmt.Errorf("zstd compression requires protocol_version 2.1.0 or later, got %q", cfg.ProtocolVersion)
+		}
+	}
+	if cfg.Producer.TransactionalID != "" {
+		if err := cfg.validateTransactions(); err != nil {
+			return err
+		}
+	}
+	for _, key := range cfg.ResourceAttributesAsHeaders {
+		if key == "" {
+			return fmt.Errorf("resource_attributes_as_headers must not contain empty keys")
+		}
+	}
 	return nil
 }
+
+func (cfg *Config) validateTransactions() error {
+	version := sarama.V1_0_0_0
+	if cfg.ProtocolVersion != "" {
+		var err error
+		if version, err = sarama.ParseKafkaVersion(cfg.ProtocolVersion); err != nil {
+			return err
+		}
+	}
+	if !version.IsAtLeast(sarama.V0_11_0_0) {
+		return fmt.Errorf("transactions require protocol_version 0.11.0 or later, got %q", cfg.ProtocolVersion)
+	}
+	if cfg.Producer.TransactionTimeout <= 0 {
+		return fmt.Errorf("producer.transaction_timeout must be positive")
+	}
+	// The sending queue returns before the data is produced, which would
+	// let the receiver move on without the offset being committed.
+	if cfg.QueueSettings.Enabled {
+		return fmt.Errorf("sending_queue must be disabled when producer.transactional_id is set")
+	}
+	return nil
+}
+
+// compressionCodec maps the configured compression name to the sarama codec.
+func compressionCodec(name string) (sarama.CompressionCodec, error) {
+	switch name {
+	case "", "none":
+		return sarama.CompressionNone, nil
+	case "gzip":
+		return sarama.CompressionGZIP, nil
+	case "snappy":
+		return sarama.CompressionSnappy, nil
+	case "lz4":
+		return sarama.CompressionLZ4, nil
+	case "zstd":
+		return sarama.CompressionZSTD, nil
+	default:
+		return sarama.CompressionNone, fmt.Errorf("unsupported compression %q", name)
+	}
+}
@@ -71,7 +71,96 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		Producer: Producer{
-			MaxMessageBytes: 10000000,
+			MaxMessageBytes:    10000000,
+			Compression:        "lz4",
+			TransactionTimeout: 30 * time.Second,
 		},
+		ResourceAttributesAsHeaders: []string{"service.name", "tenant"},
 	}, c)
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "zstd",
+			modify: func(cfg *Config) {
+				cfg.ProtocolVersion = "2.1.0"
+				cfg.Producer.Compression = "zstd"
+			},
+		},
+		{
+			name: "unsupported compression",
+			modify: func(cfg *Config) {
+				cfg.Producer.Compression = "brotli"
+			},
+			err: `unsupported compression "brotli"`,
+		},
+		{
+			name: "zstd old protocol",
+			modify: func(cfg *Config) {
+				cfg.ProtocolVersion = "2.0.0"
+				cfg.Producer.Compression = "zstd"
+			},
+			err: `zstd compression requires protocol_version 2.1.0 or later, got "2.0.0"`,
+		},
+		{
+			name: "empty header key",
+			modify: func(cfg *Config) {
+				cfg.ResourceAttributesAsHeaders = []string{""}
+			},
+			err: "resource_attributes_as_headers must not contain empty keys",
+		},
+		{
+			name: "transactions",
+			modify: func(cfg *Config) {
+				cfg.Producer.TransactionalID = "collector-1"
+				cfg.QueueSettings.Enabled = false
+			},
+		},
+		{
+			name: "transactions old protocol",
+			modify: func(cfg *Config) {
+				cfg.ProtocolVersion = "0.10.2.0"
+				cfg.Producer.TransactionalID = "collector-1"
+				cfg.QueueSettings.Enabled = false
+			},
+			err: `transactions require protocol_version 0.11.0 or later, got "0.10.2.0"`,
+		},
+		{
+			name: "transactions without timeout",
+			modify: func(cfg *Config) {
+				cfg.Producer.TransactionalID = "collector-1"
+				cfg.Producer.TransactionTimeout = 0
+				cfg.QueueSettings.Enabled = false
+			},
+			err: "producer.transaction_timeout must be positive",
+		},
+		{
+			name: "transactions with sending queue",
+			modify: func(cfg *Config) {
+				cfg.Producer.TransactionalID = "collector-1"
+			},
+			err: "sending_queue must be disabled when producer.transactional_id is set",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			test.modify(cfg)
+			err := cfg.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -39,6 +39,8 @@ const (
 	defaultMetadataFull = true
 	// default max.message.bytes for the producer
 	defaultProducerMaxMessageBytes = 1000000
+	// default transaction.timeout.ms of the Java producer
+	defaultProducerTransactionTimeout = time.Minute
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -90,7 +92,8 @@ func createDefaultConfig() config.Exporter {
 			},
 		},
 		Producer: Producer{
-			MaxMessageBytes: defaultProducerMaxMessageBytes,
+			MaxMessageBytes:    defaultProducerMaxMessageBytes,
+			TransactionTimeout: defaultProducerTransactionTimeout,
 		},
 	}
 }
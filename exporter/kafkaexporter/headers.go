@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type tracesBatch struct {
+	traces  pdata.Traces
+	headers []sarama.RecordHeader
+}
+
+type metricsBatch struct {
+	metrics pdata.Metrics
+	headers []sarama.RecordHeader
+}
+
+type logsBatch struct {
+	logs    pdata.Logs
+	headers []sarama.RecordHeader
+}
+
+// resourceHeaders returns the record headers built from the given resource
+// attributes and a key identifying that set of header values.
+func resourceHeaders(keys []string, resource pdata.Resource) ([]sarama.RecordHeader, string) {
+	var headers []sarama.RecordHeader
+	var id strings.Builder
+	attrs := resource.Attributes()
+	for _, key := range keys {
+		value, ok := attrs.Get(key)
+		if !ok {
+			id.WriteByte(0)
+			continue
+		}
+		str := value.AsString()
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(str)})
+		id.WriteByte(1)
+		id.WriteString(str)
+		id.WriteByte(0)
+	}
+	return headers, id.String()
+}
+
+// setHeaders appends the headers to every message.
+func setHeaders(messages []*sarama.ProducerMessage, headers []sarama.RecordHeader) {
+	if len(headers) == 0 {
+		return
+	}
+	for _, msg := range messages {
+		msg.Headers = append(msg.Headers, headers...)
+	}
+}
+
+// splitTracesByHeaders groups resources sharing the same header values, so
+// that each batch can be sent with its own record headers.
+func splitTracesByHeaders(keys []string, td pdata.Traces) []tracesBatch {
+	if len(keys) == 0 {
+		return []tracesBatch{{traces: td}}
+	}
+	var batches []tracesBatch
+	index := map[string]int{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		headers, id := resourceHeaders(keys, rs.Resource())
+		idx, ok := index[id]
+		if !ok {
+			idx = len(batches)
+			index[id] = idx
+			batches = append(batches, tracesBatch{traces: pdata.NewTraces(), headers: headers})
+		}
+		rs.CopyTo(batches[idx].traces.ResourceSpans().AppendEmpty())
+	}
+	return batches
+}
+
+// splitMetricsByHeaders groups resources sharing the same header values, so
+// that each batch can be sent with its own record headers.
+func splitMetricsByHeaders(keys []string, md pdata.Metrics) []metricsBatch {
+	if len(keys) == 0 {
+		return []metricsBatch{{metrics: md}}
+	}
+	var batches []metricsBatch
+	index := map[string]int{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		headers, id := resourceHeaders(keys, rm.Resource())
+		idx, ok := index[id]
+		if !ok {
+			idx = len(batches)
+			index[id] = idx
+			batches = append(batches, metricsBatch{metrics: pdata.NewMetrics(), headers: headers})
+		}
+		rm.CopyTo(batches[idx].metrics.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitLogsByHeaders groups resources sharing the same header values, so
+// that each batch can be sent with its own record headers.
+func splitLogsByHeaders(keys []string, ld pdata.Logs) []logsBatch {
+	if len(keys) == 0 {
+		return []logsBatch{{logs: ld}}
+	}
+	var batches []logsBatch
+	index := map[string]int{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		headers, id := resourceHeaders(keys, rl.Resource())
+		idx, ok := index[id]
+		if !ok {
+			idx = len(batches)
+			index[id] = idx
+			batches = append(batches, logsBatch{logs: pdata.NewLogs(), headers: headers})
+		}
+		rl.CopyTo(batches[idx].logs.ResourceLogs().AppendEmpty())
+	}
+	return batches
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestSplitTracesByHeaders(t *testing.T) {
+	td := pdata.NewTraces()
+	for _, tenant := range []string{"a", "b", "a", ""} {
+		rs := td.ResourceSpans().AppendEmpty()
+		if tenant != "" {
+			rs.Resource().Attributes().InsertString("tenant", tenant)
+		}
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName(tenant)
+	}
+
+	batches := splitTracesByHeaders([]string{"tenant"}, td)
+	require.Len(t, batches, 3)
+	assert.Equal(t, []sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("a")}}, batches[0].headers)
+	assert.Equal(t, 2, batches[0].traces.SpanCount())
+	assert.Equal(t, []sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("b")}}, batches[1].headers)
+	assert.Equal(t, 1, batches[1].traces.SpanCount())
+	assert.Nil(t, batches[2].headers)
+	assert.Equal(t, 1, batches[2].traces.SpanCount())
+}
+
+func TestSplitWithoutHeaderKeys(t *testing.T) {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	batches := splitTracesByHeaders(nil, td)
+	require.Len(t, batches, 1)
+	assert.Equal(t, td, batches[0].traces)
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	require.Len(t, splitMetricsByHeaders(nil, md), 1)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	require.Len(t, splitLogsByHeaders(nil, ld), 1)
+}
+
+func TestSplitMetricsAndLogsByHeaders(t *testing.T) {
+	md := pdata.NewMetrics()
+	ld := pdata.NewLogs()
+	for _, service := range []string{"svc1", "svc2"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", service)
+		rm.Resource().Attributes().InsertInt("shard", 3)
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", service)
+	}
+
+	metricBatches := splitMetricsByHeaders([]string{"service.name", "shard"}, md)
+	require.Len(t, metricBatches, 2)
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte("service.name"), Value: []byte("svc2")},
+		{Key: []byte("shard"), Value: []byte("3")},
+	}, metricBatches[1].headers)
+
+	logBatches := splitLogsByHeaders([]string{"service.name"}, ld)
+	require.Len(t, logBatches, 2)
+	assert.Equal(t, 1, logBatches[0].logs.ResourceLogs().Len())
+}
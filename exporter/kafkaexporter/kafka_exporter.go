@@ -17,6 +17,8 @@ package kafkaexporter
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/component"
@@ -29,10 +31,11 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer   sarama.SyncProducer
+	topic      string
+	marshaler  TracesMarshaler
+	headerKeys []string
+	logger     *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -44,12 +47,17 @@ func (ke kafkaErrors) Error() string {
 	return fmt.Sprintf("Failed to deliver %d messages due to %s", ke.count, ke.err)
 }
 
-func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td pdata.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+func (e *kafkaTracesProducer) tracesPusher(ctx context.Context, td pdata.Traces) error {
+	var messages []*sarama.ProducerMessage
+	for _, batch := range splitTracesByHeaders(e.headerKeys, td) {
+		msgs, err := e.marshaler.Marshal(batch.traces, e.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setHeaders(msgs, batch.headers)
+		messages = append(messages, msgs...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := sendMessages(ctx, e.producer, messages)
 	if err != nil {
 		if value, ok := err.(sarama.ProducerErrors); ok {
 			if len(value) > 0 {
@@ -67,18 +75,24 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	producer   sarama.SyncProducer
+	topic      string
+	marshaler  MetricsMarshaler
+	headerKeys []string
+	logger     *zap.Logger
 }
 
-func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+func (e *kafkaMetricsProducer) metricsDataPusher(ctx context.Context, md pdata.Metrics) error {
+	var messages []*sarama.ProducerMessage
+	for _, batch := range splitMetricsByHeaders(e.headerKeys, md) {
+		msgs, err := e.marshaler.Marshal(batch.metrics, e.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setHeaders(msgs, batch.headers)
+		messages = append(messages, msgs...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := sendMessages(ctx, e.producer, messages)
 	if err != nil {
 		if value, ok := err.(sarama.ProducerErrors); ok {
 			if len(value) > 0 {
@@ -96,18 +110,24 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	producer   sarama.SyncProducer
+	topic      string
+	marshaler  LogsMarshaler
+	headerKeys []string
+	logger     *zap.Logger
 }
 
-func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+func (e *kafkaLogsProducer) logsDataPusher(ctx context.Context, ld pdata.Logs) error {
+	var messages []*sarama.ProducerMessage
+	for _, batch := range splitLogsByHeaders(e.headerKeys, ld) {
+		msgs, err := e.marshaler.Marshal(batch.logs, e.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setHeaders(msgs, batch.headers)
+		messages = append(messages, msgs...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := sendMessages(ctx, e.producer, messages)
 	if err != nil {
 		if value, ok := err.(sarama.ProducerErrors); ok {
 			if len(value) > 0 {
@@ -123,6 +143,105 @@ func (e *kafkaLogsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
+// ConsumedMessage is a message consumed by a consumer group, marked as
+// consumed within the transaction producing the data read from it.
+type ConsumedMessage struct {
+	GroupID string
+	Message *sarama.ConsumerMessage
+}
+
+type consumedMessageKey struct{}
+
+// consumedMessageCommit is the value of consumedMessageKey, recording whether
+// a transaction marked the message as consumed.
+type consumedMessageCommit struct {
+	consumed  ConsumedMessage
+	committed int32
+}
+
+// ContextWithConsumedMessage returns a context carrying the message to mark
+// as consumed when the data passed with the context is produced in a
+// transaction, and a function reporting whether a transaction committed it.
+// It is used by the Kafka receiver so that the data consumed from Kafka is
+// either both produced and marked as consumed, or neither.
+func ContextWithConsumedMessage(ctx context.Context, consumed ConsumedMessage) (context.Context, func() bool) {
+	commit := &consumedMessageCommit{consumed: consumed}
+	return context.WithValue(ctx, consumedMessageKey{}, commit), func() bool {
+		return atomic.LoadInt32(&commit.committed) == 1
+	}
+}
+
+// ConsumedMessageFromContext returns the message set by ContextWithConsumedMessage.
+func ConsumedMessageFromContext(ctx context.Context) (ConsumedMessage, bool) {
+	commit, ok := ctx.Value(consumedMessageKey{}).(*consumedMessageCommit)
+	if !ok {
+		return ConsumedMessage{}, false
+	}
+	return commit.consumed, true
+}
+
+// transactionalProducer runs the transactions of a sarama transactional
+// producer one at a time.
+type transactionalProducer struct {
+	sarama.SyncProducer
+	mu sync.Mutex
+}
+
+// sendMessagesInTransaction produces the messages and marks the consumed
+// message of the context in a single transaction, which is aborted when
+// either fails.
+func (p *transactionalProducer) sendMessagesInTransaction(ctx context.Context, msgs []*sarama.ProducerMessage) error {
+	commit, hasConsumed := ctx.Value(consumedMessageKey{}).(*consumedMessageCommit)
+	if len(msgs) == 0 && !hasConsumed {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.BeginTxn(); err != nil {
+		return p.abortTxn(err)
+	}
+	err := p.SendMessages(msgs)
+	if err == nil && hasConsumed {
+		err = p.AddMessageToTxn(commit.consumed.Message, commit.consumed.GroupID, nil)
+	}
+	if err == nil {
+		err = p.CommitTxn()
+	}
+	if err != nil {
+		return p.abortTxn(err)
+	}
+	if hasConsumed {
+		atomic.StoreInt32(&commit.committed, 1)
+	}
+	return nil
+}
+
+// abortTxn aborts the pending transaction after err. The producer can't be
+// used anymore after a fatal error, like being fenced by another producer
+// with the same transactional ID.
+func (p *transactionalProducer) abortTxn(err error) error {
+	status := p.TxnStatus()
+	if status&sarama.ProducerTxnFlagFatalError != 0 {
+		return consumererror.NewPermanent(fmt.Errorf("transactional producer failed: %w", err))
+	}
+	if status&(sarama.ProducerTxnFlagInTransaction|sarama.ProducerTxnFlagAbortableError) != 0 {
+		if abortErr := p.AbortTxn(); abortErr != nil {
+			return fmt.Errorf("%w (failed to abort the transaction: %v)", err, abortErr)
+		}
+	}
+	return err
+}
+
+// sendMessages produces the messages, in a transaction marking the consumed
+// message of the context when the producer is transactional.
+func sendMessages(ctx context.Context, producer sarama.SyncProducer, messages []*sarama.ProducerMessage) error {
+	if txn, ok := producer.(*transactionalProducer); ok {
+		return txn.sendMessagesInTransaction(ctx, messages)
+	}
+	return producer.SendMessages(messages)
+}
+
 func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c := sarama.NewConfig()
 	// These setting are required by the sarama.SyncProducer implementation.
@@ -136,6 +255,11 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c.Metadata.Retry.Max = config.Metadata.Retry.Max
 	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
 	c.Producer.MaxMessageBytes = config.Producer.MaxMessageBytes
+	codec, err := compressionCodec(config.Producer.Compression)
+	if err != nil {
+		return nil, err
+	}
+	c.Producer.Compression = codec
 	if config.ProtocolVersion != "" {
 		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
 		if err != nil {
@@ -146,10 +270,21 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	if err := ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
+	if config.Producer.TransactionalID != "" {
+		// Transactions require an idempotent producer.
+		c.Producer.Idempotent = true
+		c.Producer.RequiredAcks = sarama.WaitForAll
+		c.Net.MaxOpenRequests = 1
+		c.Producer.Transaction.ID = config.Producer.TransactionalID
+		c.Producer.Transaction.Timeout = config.Producer.TransactionTimeout
+	}
 	producer, err := sarama.NewSyncProducer(config.Brokers, c)
 	if err != nil {
 		return nil, err
 	}
+	if config.Producer.TransactionalID != "" {
+		return &transactionalProducer{SyncProducer: producer}, nil
+	}
 	return producer, nil
 }
 
@@ -164,10 +299,11 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 	}
 
 	return &kafkaMetricsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:   producer,
+		topic:      config.Topic,
+		marshaler:  marshaler,
+		headerKeys: config.ResourceAttributesAsHeaders,
+		logger:     set.Logger,
 	}, nil
 
 }
@@ -183,10 +319,11 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:   producer,
+		topic:      config.Topic,
+		marshaler:  marshaler,
+		headerKeys: config.ResourceAttributesAsHeaders,
+		logger:     set.Logger,
 	}, nil
 }
 
@@ -201,10 +338,11 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 	}
 
 	return &kafkaLogsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:   producer,
+		topic:      config.Topic,
+		marshaler:  marshaler,
+		headerKeys: config.ResourceAttributesAsHeaders,
+		logger:     set.Logger,
 	}, nil
 
 }
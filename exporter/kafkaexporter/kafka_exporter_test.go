@@ -134,6 +134,37 @@ func TestTracesPusher(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTracesPusher_headers(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	for _, service := range []string{"svc1", "svc2"} {
+		expected := sarama.RecordHeader{Key: []byte("service.name"), Value: []byte(service)}
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			if len(msg.Headers) != 1 || string(msg.Headers[0].Value) != string(expected.Value) {
+				return fmt.Errorf("unexpected headers %v", msg.Headers)
+			}
+			return nil
+		})
+	}
+
+	p := kafkaTracesProducer{
+		producer:   producer,
+		marshaler:  newPdataTracesMarshaler(otlp.NewProtobufTracesMarshaler(), defaultEncoding),
+		headerKeys: []string{"service.name"},
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	td := pdata.NewTraces()
+	for _, service := range []string{"svc1", "svc2"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	}
+	err := p.tracesPusher(context.Background(), td)
+	require.NoError(t, err)
+}
+
 func TestTracesPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
@@ -153,6 +184,52 @@ func TestTracesPusher_err(t *testing.T) {
 	assert.EqualError(t, err, expErr.Error())
 }
 
+func TestTracesPusher_transaction(t *testing.T) {
+	producer := newTxnRecorder(t)
+	producer.ExpectSendMessageAndSucceed()
+
+	p := kafkaTracesProducer{
+		producer:  &transactionalProducer{SyncProducer: producer},
+		marshaler: newPdataTracesMarshaler(otlp.NewProtobufTracesMarshaler(), defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	message := &sarama.ConsumerMessage{Topic: "otlp_spans", Partition: 5, Offset: 41}
+	ctx, committed := ContextWithConsumedMessage(context.Background(), ConsumedMessage{GroupID: "otel-collector", Message: message})
+	require.NoError(t, p.tracesPusher(ctx, testdata.GenerateTracesTwoSpansSameResource()))
+
+	assert.Equal(t, []string{"begin", "add otel-collector otlp_spans/5@41", "commit"}, producer.calls)
+	assert.True(t, committed())
+
+	// Without consumed message, the messages are still produced in a transaction.
+	producer.ExpectSendMessageAndSucceed()
+	require.NoError(t, p.tracesPusher(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	assert.Equal(t, []string{"begin", "commit"}, producer.calls[3:])
+}
+
+func TestTracesPusher_transactionAborted(t *testing.T) {
+	producer := newTxnRecorder(t)
+	expErr := fmt.Errorf("failed to send")
+	producer.ExpectSendMessageAndFail(expErr)
+
+	p := kafkaTracesProducer{
+		producer:  &transactionalProducer{SyncProducer: producer},
+		marshaler: newPdataTracesMarshaler(otlp.NewProtobufTracesMarshaler(), defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	message := &sarama.ConsumerMessage{Topic: "otlp_spans", Partition: 5, Offset: 41}
+	ctx, committed := ContextWithConsumedMessage(context.Background(), ConsumedMessage{GroupID: "otel-collector", Message: message})
+	err := p.tracesPusher(ctx, testdata.GenerateTracesTwoSpansSameResource())
+	assert.ErrorIs(t, err, expErr)
+
+	// The message isn't marked as consumed, and the transaction is aborted.
+	assert.Equal(t, []string{"begin", "abort"}, producer.calls)
+	assert.False(t, committed())
+}
+
 func TestTracesPusher_marshal_error(t *testing.T) {
 	expErr := fmt.Errorf("failed to marshal")
 	p := kafkaTracesProducer{
@@ -296,3 +373,40 @@ func (e logsErrorMarshaler) Marshal(_ pdata.Logs, _ string) ([]*sarama.ProducerM
 func (e logsErrorMarshaler) Encoding() string {
 	panic("implement me")
 }
+
+// txnRecorder records the transaction calls of a transactional mock producer.
+type txnRecorder struct {
+	*mocks.SyncProducer
+	calls []string
+}
+
+func newTxnRecorder(t *testing.T) *txnRecorder {
+	c := sarama.NewConfig()
+	c.Version = sarama.V2_0_0_0
+	c.Producer.Idempotent = true
+	c.Producer.RequiredAcks = sarama.WaitForAll
+	c.Producer.Return.Successes = true
+	c.Net.MaxOpenRequests = 1
+	c.Producer.Transaction.ID = "collector-1"
+	return &txnRecorder{SyncProducer: mocks.NewSyncProducer(t, c)}
+}
+
+func (r *txnRecorder) BeginTxn() error {
+	r.calls = append(r.calls, "begin")
+	return r.SyncProducer.BeginTxn()
+}
+
+func (r *txnRecorder) CommitTxn() error {
+	r.calls = append(r.calls, "commit")
+	return r.SyncProducer.CommitTxn()
+}
+
+func (r *txnRecorder) AbortTxn() error {
+	r.calls = append(r.calls, "abort")
+	return r.SyncProducer.AbortTxn()
+}
+
+func (r *txnRecorder) AddMessageToTxn(msg *sarama.ConsumerMessage, groupID string, metadata *string) error {
+	r.calls = append(r.calls, fmt.Sprintf("add %s %s/%d@%d", groupID, msg.Topic, msg.Partition, msg.Offset))
+	return r.SyncProducer.AddMessageToTxn(msg, groupID, metadata)
+}
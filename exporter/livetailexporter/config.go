@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the live tail exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MaxConnections is the maximum number of clients tailing at the same
+	// time. Further connections are rejected.
+	MaxConnections int `mapstructure:"max_connections"`
+
+	// MaxItemsPerSecond limits the number of items sent to each client.
+	// Items over the limit are dropped.
+	MaxItemsPerSecond int `mapstructure:"max_items_per_second"`
+
+	// BufferSize is the number of items buffered for each client. Items are
+	// dropped when a client can't keep up and its buffer is full.
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be non-empty")
+	}
+	if cfg.MaxConnections <= 0 {
+		return errors.New("max_connections must be positive")
+	}
+	if cfg.MaxItemsPerSecond <= 0 {
+		return errors.New("max_items_per_second must be positive")
+	}
+	if cfg.BufferSize <= 0 {
+		return errors.New("buffer_size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// The items below are the JSON documents sent to clients, one per span, log
+// record or metric. They are flattened compared to OTLP/JSON so that they
+// can be read directly by someone watching the stream.
+
+type spanItem struct {
+	Signal        string                 `json:"signal"`
+	Resource      map[string]interface{} `json:"resource,omitempty"`
+	TraceID       string                 `json:"trace_id"`
+	SpanID        string                 `json:"span_id"`
+	ParentSpanID  string                 `json:"parent_span_id,omitempty"`
+	Name          string                 `json:"name"`
+	Kind          string                 `json:"kind"`
+	StartTime     time.Time              `json:"start_time"`
+	EndTime       time.Time              `json:"end_time"`
+	Duration      string                 `json:"duration"`
+	StatusCode    string                 `json:"status_code"`
+	StatusMessage string                 `json:"status_message,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Events        []eventItem            `json:"events,omitempty"`
+}
+
+type eventItem struct {
+	Name       string                 `json:"name"`
+	Time       time.Time              `json:"time"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type logItem struct {
+	Signal         string                 `json:"signal"`
+	Resource       map[string]interface{} `json:"resource,omitempty"`
+	Time           time.Time              `json:"time"`
+	SeverityText   string                 `json:"severity_text,omitempty"`
+	SeverityNumber int32                  `json:"severity_number,omitempty"`
+	Body           interface{}            `json:"body"`
+	TraceID        string                 `json:"trace_id,omitempty"`
+	SpanID         string                 `json:"span_id,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type metricItem struct {
+	Signal      string                 `json:"signal"`
+	Resource    map[string]interface{} `json:"resource,omitempty"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Unit        string                 `json:"unit,omitempty"`
+	Type        string                 `json:"type"`
+	DataPoints  []dataPointItem        `json:"data_points"`
+}
+
+type dataPointItem struct {
+	Time       time.Time              `json:"time"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Value      interface{}            `json:"value,omitempty"`
+	Count      uint64                 `json:"count,omitempty"`
+	Sum        float64                `json:"sum,omitempty"`
+}
+
+func newSpanItem(span pdata.Span, resource map[string]interface{}) spanItem {
+	item := spanItem{
+		Signal:        "span",
+		Resource:      resource,
+		TraceID:       span.TraceID().HexString(),
+		SpanID:        span.SpanID().HexString(),
+		ParentSpanID:  span.ParentSpanID().HexString(),
+		Name:          span.Name(),
+		Kind:          span.Kind().String(),
+		StartTime:     span.StartTimestamp().AsTime(),
+		EndTime:       span.EndTimestamp().AsTime(),
+		Duration:      span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()).String(),
+		StatusCode:    span.Status().Code().String(),
+		StatusMessage: span.Status().Message(),
+		Attributes:    attributesOrNil(span.Attributes()),
+	}
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		e := events.At(i)
+		item.Events = append(item.Events, eventItem{
+			Name:       e.Name(),
+			Time:       e.Timestamp().AsTime(),
+			Attributes: attributesOrNil(e.Attributes()),
+		})
+	}
+	return item
+}
+
+func newLogItem(lr pdata.LogRecord, resource map[string]interface{}) logItem {
+	return logItem{
+		Signal:         "log",
+		Resource:       resource,
+		Time:           lr.Timestamp().AsTime(),
+		SeverityText:   lr.SeverityText(),
+		SeverityNumber: int32(lr.SeverityNumber()),
+		Body:           attributeValueToRaw(lr.Body()),
+		TraceID:        lr.TraceID().HexString(),
+		SpanID:         lr.SpanID().HexString(),
+		Attributes:     attributesOrNil(lr.Attributes()),
+	}
+}
+
+func newMetricItem(metric pdata.Metric, resource map[string]interface{}) metricItem {
+	item := metricItem{
+		Signal:      "metric",
+		Resource:    resource,
+		Name:        metric.Name(),
+		Description: metric.Description(),
+		Unit:        metric.Unit(),
+		Type:        metric.DataType().String(),
+	}
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		item.DataPoints = numberDataPoints(metric.Gauge().DataPoints())
+	case pdata.MetricDataTypeSum:
+		item.DataPoints = numberDataPoints(metric.Sum().DataPoints())
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			item.DataPoints = append(item.DataPoints, dataPointItem{
+				Time:       dp.Timestamp().AsTime(),
+				Attributes: attributesOrNil(dp.Attributes()),
+				Count:      dp.Count(),
+				Sum:        dp.Sum(),
+			})
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			item.DataPoints = append(item.DataPoints, dataPointItem{
+				Time:       dp.Timestamp().AsTime(),
+				Attributes: attributesOrNil(dp.Attributes()),
+				Count:      dp.Count(),
+				Sum:        dp.Sum(),
+			})
+		}
+	}
+	return item
+}
+
+func numberDataPoints(dps pdata.NumberDataPointSlice) []dataPointItem {
+	items := make([]dataPointItem, 0, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		item := dataPointItem{
+			Time:       dp.Timestamp().AsTime(),
+			Attributes: attributesOrNil(dp.Attributes()),
+		}
+		if dp.Type() == pdata.MetricValueTypeInt {
+			item.Value = dp.IntVal()
+		} else {
+			item.Value = dp.DoubleVal()
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func attributesOrNil(attrs pdata.AttributeMap) map[string]interface{} {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	return attrs.AsRaw()
+}
+
+func attributeValueToRaw(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		return v.StringVal()
+	case pdata.AttributeValueTypeInt:
+		return v.IntVal()
+	case pdata.AttributeValueTypeDouble:
+		return v.DoubleVal()
+	case pdata.AttributeValueTypeBool:
+		return v.BoolVal()
+	case pdata.AttributeValueTypeMap:
+		return v.MapVal().AsRaw()
+	case pdata.AttributeValueTypeEmpty:
+		return nil
+	default:
+		return v.AsString()
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	tailPath     = "/tail"
+	writeTimeout = 10 * time.Second
+)
+
+type liveTailExporter struct {
+	config   *Config
+	settings component.TelemetrySettings
+
+	server   *http.Server
+	upgrader websocket.Upgrader
+
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	done        chan struct{}
+}
+
+func newLiveTailExporter(cfg *Config, settings component.TelemetrySettings) *liveTailExporter {
+	return &liveTailExporter{
+		config:   cfg,
+		settings: settings,
+		upgrader: websocket.Upgrader{
+			// The endpoint is meant to be reached by browsers from any page,
+			// e.g. a local HTML file.
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		subscribers: map[*subscriber]struct{}{},
+		done:        make(chan struct{}),
+	}
+}
+
+func (e *liveTailExporter) Start(_ context.Context, host component.Host) error {
+	ln, err := e.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", e.config.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(tailPath, e.handleTail)
+	e.server = e.config.HTTPServerSettings.ToServer(mux, e.settings)
+
+	go func() {
+		if errHTTP := e.server.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (e *liveTailExporter) Shutdown(context.Context) error {
+	close(e.done)
+	if e.server == nil {
+		return nil
+	}
+	return e.server.Close()
+}
+
+func (e *liveTailExporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	subs := e.subscribersFor(signalTraces)
+	if len(subs) == 0 {
+		return nil
+	}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := attributesOrNil(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				e.publish(subs, func(s *subscriber) bool {
+					return s.matchSpan(span, rs.Resource())
+				}, func() interface{} {
+					return newSpanItem(span, resource)
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (e *liveTailExporter) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
+	subs := e.subscribersFor(signalMetrics)
+	if len(subs) == 0 {
+		return nil
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := attributesOrNil(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				e.publish(subs, func(s *subscriber) bool {
+					return s.matchMetric(metric)
+				}, func() interface{} {
+					return newMetricItem(metric, resource)
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func (e *liveTailExporter) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	subs := e.subscribersFor(signalLogs)
+	if len(subs) == 0 {
+		return nil
+	}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource := attributesOrNil(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				e.publish(subs, func(s *subscriber) bool {
+					return s.matchLogRecord(lr, rl.Resource())
+				}, func() interface{} {
+					return newLogItem(lr, resource)
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// publish sends one item to the subscribers it matches. The item is only
+// encoded if at least one subscriber takes it, and at most once.
+func (e *liveTailExporter) publish(subs []*subscriber, match func(*subscriber) bool, item func() interface{}) {
+	var encoded []byte
+	for _, s := range subs {
+		if !match(s) || !s.sampled() {
+			continue
+		}
+		if encoded == nil {
+			var err error
+			if encoded, err = json.Marshal(item()); err != nil {
+				e.settings.Logger.Debug("Failed to encode item", zap.Error(err))
+				return
+			}
+		}
+		s.send(encoded)
+	}
+}
+
+func (e *liveTailExporter) subscribersFor(signal string) []*subscriber {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var subs []*subscriber
+	for s := range e.subscribers {
+		if s.wants(signal) {
+			subs = append(subs, s)
+		}
+	}
+	return subs
+}
+
+func (e *liveTailExporter) addSubscriber(s *subscriber) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.subscribers) >= e.config.MaxConnections {
+		return false
+	}
+	e.subscribers[s] = struct{}{}
+	return true
+}
+
+func (e *liveTailExporter) removeSubscriber(s *subscriber) {
+	e.mu.Lock()
+	delete(e.subscribers, s)
+	e.mu.Unlock()
+	if dropped := atomic.LoadUint64(&s.dropped); dropped > 0 {
+		e.settings.Logger.Debug("Live tail client dropped items", zap.Uint64("dropped", dropped))
+	}
+}
+
+func (e *liveTailExporter) handleTail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s, err := newSubscriber(r.URL.Query(), e.config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !e.addSubscriber(s) {
+		http.Error(w, "too many live tail connections", http.StatusServiceUnavailable)
+		return
+	}
+	defer e.removeSubscriber(s)
+
+	if websocket.IsWebSocketUpgrade(r) {
+		e.streamWebSocket(w, r, s)
+		return
+	}
+	e.streamHTTP(w, r, s)
+}
+
+func (e *liveTailExporter) streamWebSocket(w http.ResponseWriter, r *http.Request, s *subscriber) {
+	conn, err := e.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied to the client.
+		return
+	}
+	defer conn.Close()
+
+	// Clients aren't expected to send anything, but reading is needed to
+	// process control frames and notice when the connection is closed.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case item := <-s.out:
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteMessage(websocket.TextMessage, item); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-e.done:
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "collector shutting down"),
+				time.Now().Add(time.Second))
+			return
+		}
+	}
+}
+
+// streamHTTP writes newline delimited JSON, for clients such as curl.
+func (e *liveTailExporter) streamHTTP(w http.ResponseWriter, r *http.Request, s *subscriber) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case item := <-s.out:
+			if _, err := w.Write(append(item, '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-e.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
+)
+
+func startExporter(t *testing.T, modify func(*Config)) (*liveTailExporter, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	if modify != nil {
+		modify(cfg)
+	}
+	e := newLiveTailExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, e.Shutdown(context.Background()))
+	})
+	return e, cfg.Endpoint
+}
+
+func waitForSubscribers(t *testing.T, e *liveTailExporter, n int) {
+	require.Eventually(t, func() bool {
+		e.mu.RLock()
+		defer e.mu.RUnlock()
+		return len(e.subscribers) == n
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET /cart", "POST /pay"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		span.SetKind(pdata.SpanKindServer)
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(time.Unix(100, 0)))
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(time.Unix(100, int64(250*time.Millisecond))))
+		span.Attributes().InsertString("http.method", name[:4])
+	}
+	return td
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal("payment declined")
+	lr.SetSeverityText("WARN")
+	return ld
+}
+
+func testMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("queue.size")
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(42)
+	dp.Attributes().InsertString("queue", "orders")
+	return md
+}
+
+func TestHTTPStream(t *testing.T) {
+	e, endpoint := startExporter(t, nil)
+
+	resp, err := http.Get("http://" + endpoint + tailPath + "?signals=traces&filter=" + url.QueryEscape(`SpanName == "POST /pay"`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	waitForSubscribers(t, e, 1)
+
+	require.NoError(t, e.ConsumeLogs(context.Background(), testLogs()))
+	require.NoError(t, e.ConsumeTraces(context.Background(), testTraces()))
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(t, scanner.Scan())
+	var item map[string]interface{}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &item))
+	assert.Equal(t, map[string]interface{}{
+		"signal":      "span",
+		"resource":    map[string]interface{}{"service.name": "checkout"},
+		"trace_id":    "0102030405060708090a0b0c0d0e0f10",
+		"span_id":     "0102030405060708",
+		"name":        "POST /pay",
+		"kind":        "SPAN_KIND_SERVER",
+		"start_time":  "1970-01-01T00:01:40Z",
+		"end_time":    "1970-01-01T00:01:40.25Z",
+		"duration":    "250ms",
+		"status_code": "STATUS_CODE_UNSET",
+		"attributes":  map[string]interface{}{"http.method": "POST"},
+	}, item)
+}
+
+func TestWebSocketStream(t *testing.T) {
+	e, endpoint := startExporter(t, nil)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+endpoint+tailPath+"?signals=logs,metrics", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	waitForSubscribers(t, e, 1)
+
+	require.NoError(t, e.ConsumeTraces(context.Background(), testTraces()))
+	require.NoError(t, e.ConsumeLogs(context.Background(), testLogs()))
+	require.NoError(t, e.ConsumeMetrics(context.Background(), testMetrics()))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var logItem map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&logItem))
+	assert.Equal(t, "log", logItem["signal"])
+	assert.Equal(t, "payment declined", logItem["body"])
+	assert.Equal(t, "WARN", logItem["severity_text"])
+
+	var metricItem map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&metricItem))
+	assert.Equal(t, "metric", metricItem["signal"])
+	assert.Equal(t, "queue.size", metricItem["name"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"time":       "1970-01-01T00:00:00Z",
+		"attributes": map[string]interface{}{"queue": "orders"},
+		"value":      float64(42),
+	}}, metricItem["data_points"])
+
+	require.NoError(t, conn.Close())
+	waitForSubscribers(t, e, 0)
+}
+
+func TestRejectedConnections(t *testing.T) {
+	e, endpoint := startExporter(t, func(cfg *Config) { cfg.MaxConnections = 1 })
+
+	resp, err := http.Get("http://" + endpoint + tailPath + "?signals=bogus")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get("http://" + endpoint + tailPath + "?filter=" + url.QueryEscape("SpanName =="))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	first, err := http.Get("http://" + endpoint + tailPath)
+	require.NoError(t, err)
+	defer first.Body.Close()
+	waitForSubscribers(t, e, 1)
+
+	resp, err = http.Get("http://" + endpoint + tailPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+endpoint+tailPath, nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestConsumeWithoutSubscribers(t *testing.T) {
+	e := newLiveTailExporter(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	assert.NoError(t, e.ConsumeTraces(context.Background(), testTraces()))
+	assert.NoError(t, e.ConsumeMetrics(context.Background(), testMetrics()))
+	assert.NoError(t, e.ConsumeLogs(context.Background(), testLogs()))
+}
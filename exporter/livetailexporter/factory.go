@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/sharedcomponent"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "livetail"
+
+	defaultEndpoint          = "localhost:12001"
+	defaultMaxConnections    = 10
+	defaultMaxItemsPerSecond = 100
+	defaultBufferSize        = 1000
+)
+
+// NewFactory creates a factory for the live tail exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+		MaxConnections:    defaultMaxConnections,
+		MaxItemsPerSecond: defaultMaxItemsPerSecond,
+		BufferSize:        defaultBufferSize,
+	}
+}
+
+func createTracesExporter(
+	_ context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	e := getOrCreateExporter(cfg, set)
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		e.Unwrap().(*liveTailExporter).ConsumeTraces,
+		exporterhelper.WithStart(e.Start),
+		exporterhelper.WithShutdown(e.Shutdown),
+	)
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	e := getOrCreateExporter(cfg, set)
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		e.Unwrap().(*liveTailExporter).ConsumeMetrics,
+		exporterhelper.WithStart(e.Start),
+		exporterhelper.WithShutdown(e.Shutdown),
+	)
+}
+
+func createLogsExporter(
+	_ context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.LogsExporter, error) {
+	e := getOrCreateExporter(cfg, set)
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		set,
+		e.Unwrap().(*liveTailExporter).ConsumeLogs,
+		exporterhelper.WithStart(e.Start),
+		exporterhelper.WithShutdown(e.Shutdown),
+	)
+}
+
+func getOrCreateExporter(cfg config.Exporter, set component.ExporterCreateSettings) *sharedcomponent.SharedComponent {
+	return exporters.GetOrAdd(cfg, func() component.Component {
+		return newLiveTailExporter(cfg.(*Config), set.TelemetrySettings)
+	})
+}
+
+// This is the map of already created live tail exporters for particular
+// configurations. All pipelines using the same configuration share a single
+// HTTP server and set of connected clients.
+var exporters = sharedcomponent.NewSharedComponents()
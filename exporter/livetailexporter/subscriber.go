@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterexpr"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+// subscriber is a client connected to the tail endpoint. Each subscriber has
+// its own filter, sampling ratio and rate limit, given as query parameters:
+//
+//   signals: comma separated list of traces, metrics and logs (default all).
+//   filter:  an expression items must match, see filterexpr.Matcher.
+//   sample:  the ratio of matching items to send, between 0 and 1 (default 1).
+type subscriber struct {
+	signals map[string]bool
+	sample  float64
+	limiter *rateLimiter
+
+	// The expression VM is not safe for concurrent use, while pipelines
+	// can push data concurrently.
+	matcherMu sync.Mutex
+	matcher   *filterexpr.Matcher
+
+	out     chan []byte
+	dropped uint64
+}
+
+func newSubscriber(query url.Values, cfg *Config) (*subscriber, error) {
+	s := &subscriber{
+		signals: map[string]bool{signalTraces: true, signalMetrics: true, signalLogs: true},
+		sample:  1,
+		limiter: newRateLimiter(cfg.MaxItemsPerSecond),
+		out:     make(chan []byte, cfg.BufferSize),
+	}
+
+	if signals := query.Get("signals"); signals != "" {
+		s.signals = map[string]bool{}
+		for _, sig := range strings.Split(signals, ",") {
+			sig = strings.TrimSpace(sig)
+			switch sig {
+			case signalTraces, signalMetrics, signalLogs:
+				s.signals[sig] = true
+			default:
+				return nil, fmt.Errorf("unknown signal %q", sig)
+			}
+		}
+	}
+
+	if filter := query.Get("filter"); filter != "" {
+		matcher, err := filterexpr.NewMatcher(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		s.matcher = matcher
+	}
+
+	if sample := query.Get("sample"); sample != "" {
+		ratio, err := strconv.ParseFloat(sample, 64)
+		if err != nil || ratio <= 0 || ratio > 1 {
+			return nil, errors.New("sample must be a number in (0, 1]")
+		}
+		s.sample = ratio
+	}
+
+	return s, nil
+}
+
+func (s *subscriber) wants(signal string) bool {
+	return s.signals[signal]
+}
+
+func (s *subscriber) matchSpan(span pdata.Span, resource pdata.Resource) bool {
+	if s.matcher == nil {
+		return true
+	}
+	s.matcherMu.Lock()
+	defer s.matcherMu.Unlock()
+	matched, err := s.matcher.MatchSpan(span, resource)
+	return err == nil && matched
+}
+
+func (s *subscriber) matchLogRecord(lr pdata.LogRecord, resource pdata.Resource) bool {
+	if s.matcher == nil {
+		return true
+	}
+	s.matcherMu.Lock()
+	defer s.matcherMu.Unlock()
+	matched, err := s.matcher.MatchLogRecord(lr, resource)
+	return err == nil && matched
+}
+
+func (s *subscriber) matchMetric(metric pdata.Metric) bool {
+	if s.matcher == nil {
+		return true
+	}
+	s.matcherMu.Lock()
+	defer s.matcherMu.Unlock()
+	matched, err := s.matcher.MatchMetric(metric)
+	return err == nil && matched
+}
+
+// sampled applies the sampling ratio and the rate limit.
+func (s *subscriber) sampled() bool {
+	if s.sample < 1 && rand.Float64() >= s.sample {
+		return false
+	}
+	return s.limiter.allow()
+}
+
+// send queues the encoded item without ever blocking the pipeline. Items are
+// dropped if the client is too slow.
+func (s *subscriber) send(item []byte) {
+	select {
+	case s.out <- item:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+// rateLimiter allows up to limit events per one second window.
+type rateLimiter struct {
+	mu          sync.Mutex
+	limit       int
+	windowStart time.Time
+	count       int
+	now         func() time.Time
+}
+
+func newRateLimiter(limit int) *rateLimiter {
+	return &rateLimiter{limit: limit, now: time.Now}
+}
+
+func (rl *rateLimiter) allow() bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := rl.now()
+	if now.Sub(rl.windowStart) >= time.Second {
+		rl.windowStart = now
+		rl.count = 0
+	}
+	if rl.count >= rl.limit {
+		return false
+	}
+	rl.count++
+	return true
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livetailexporter
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSubscriber(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	s, err := newSubscriber(url.Values{}, cfg)
+	require.NoError(t, err)
+	assert.True(t, s.wants(signalTraces))
+	assert.True(t, s.wants(signalMetrics))
+	assert.True(t, s.wants(signalLogs))
+	assert.Nil(t, s.matcher)
+	assert.Equal(t, 1.0, s.sample)
+	assert.Equal(t, cfg.BufferSize, cap(s.out))
+
+	s, err = newSubscriber(url.Values{"signals": {"logs, traces"}, "sample": {"0.25"}, "filter": {`Body contains "x"`}}, cfg)
+	require.NoError(t, err)
+	assert.True(t, s.wants(signalTraces))
+	assert.False(t, s.wants(signalMetrics))
+	assert.True(t, s.wants(signalLogs))
+	assert.NotNil(t, s.matcher)
+	assert.Equal(t, 0.25, s.sample)
+
+	for _, q := range []url.Values{
+		{"signals": {"profiles"}},
+		{"sample": {"0"}},
+		{"sample": {"1.5"}},
+		{"sample": {"half"}},
+		{"filter": {"("}},
+	} {
+		_, err = newSubscriber(q, cfg)
+		assert.Error(t, err, q.Encode())
+	}
+}
+
+func TestSubscriberDropsWhenFull(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.BufferSize = 1
+	s, err := newSubscriber(url.Values{}, cfg)
+	require.NoError(t, err)
+
+	s.send([]byte("1"))
+	s.send([]byte("2"))
+	assert.Equal(t, uint64(1), s.dropped)
+	assert.Equal(t, []byte("1"), <-s.out)
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	rl := newRateLimiter(2)
+	rl.now = func() time.Time { return now }
+
+	assert.True(t, rl.allow())
+	assert.True(t, rl.allow())
+	assert.False(t, rl.allow())
+
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, rl.allow())
+
+	now = now.Add(time.Second)
+	assert.True(t, rl.allow())
+}
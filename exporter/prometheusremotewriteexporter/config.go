@@ -16,6 +16,7 @@ package prometheusremotewriteexporter
 
 import (
 	"fmt"
+	"net/http"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -47,6 +48,28 @@ type Config struct {
 	// "Enabled" - A boolean field to enable/disable this option. Default is `false`.
 	// If enabled, all the resource attributes will be converted to metric labels by default.
 	ResourceToTelemetrySettings resourcetotelemetry.Settings `mapstructure:"resource_to_telemetry_conversion"`
+
+	// Tenant configures multi-tenant routing, where the tenant of each
+	// resource is sent to the backend in a request header.
+	Tenant TenantSettings `mapstructure:"tenant"`
+}
+
+// TenantSettings defines how the tenant of the exported metrics is derived.
+type TenantSettings struct {
+	// FromAttribute is the resource attribute holding the tenant id. Tenant
+	// routing is disabled when it is empty.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// Header is the request header carrying the tenant id. Default is X-Scope-OrgID.
+	Header string `mapstructure:"header"`
+
+	// Default is the tenant used for resources without the attribute. When it is
+	// empty, such resources are sent without a tenant header.
+	Default string `mapstructure:"default"`
+
+	// Headers defines additional headers, such as credentials, that are only
+	// attached to the requests of the given tenant.
+	Headers map[string]map[string]string `mapstructure:"headers"`
 }
 
 // RemoteWriteQueue allows to configure the remote write queue.
@@ -72,5 +95,22 @@ func (cfg *Config) Validate() error {
 	if cfg.RemoteWriteQueue.NumConsumers < 0 {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
+	if cfg.Tenant.FromAttribute != "" {
+		tenantHeader := http.CanonicalHeaderKey(cfg.Tenant.header())
+		for name := range cfg.HTTPClientSettings.Headers {
+			if http.CanonicalHeaderKey(name) == tenantHeader {
+				return fmt.Errorf("header %q is set by tenant routing and can't be configured in headers", tenantHeader)
+			}
+		}
+	}
 	return nil
 }
+
+const defaultTenantHeader = "X-Scope-OrgID"
+
+func (ts TenantSettings) header() string {
+	if ts.Header == "" {
+		return defaultTenantHeader
+	}
+	return ts.Header
+}
@@ -84,6 +84,30 @@ func Test_loadConfig(t *testing.T) {
 		})
 }
 
+func TestLoadTenantConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "tenant.yaml"), factories)
+	require.NoError(t, err)
+
+	e0 := cfg.Exporters[config.NewComponentID(typeStr)].(*Config)
+	assert.Equal(t, TenantSettings{
+		FromAttribute: "tenant.id",
+		Default:       "anonymous",
+		Headers: map[string]map[string]string{
+			"team-a": {"Authorization": "Bearer a"},
+		},
+	}, e0.Tenant)
+	assert.Equal(t, "X-Scope-OrgID", e0.Tenant.header())
+
+	e1 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "header")].(*Config)
+	assert.Equal(t, TenantSettings{FromAttribute: "k8s.namespace.name", Header: "X-Tenant"}, e1.Tenant)
+	assert.Equal(t, "X-Tenant", e1.Tenant.header())
+}
+
 func TestNegativeQueueSize(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	assert.NoError(t, err)
@@ -103,3 +127,13 @@ func TestNegativeNumConsumers(t *testing.T) {
 	_, err = configtest.LoadConfigAndValidate(path.Join(".", "testdata", "negative_num_consumers.yaml"), factories)
 	assert.Error(t, err)
 }
+
+func TestTenantHeaderConflict(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	_, err = configtest.LoadConfigAndValidate(path.Join(".", "testdata", "tenant_header_conflict.yaml"), factories)
+	assert.Error(t, err)
+}
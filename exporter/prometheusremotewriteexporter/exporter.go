@@ -33,6 +33,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumerhelper"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/multierr"
 )
@@ -50,6 +51,7 @@ type PRWExporter struct {
 	concurrency     int
 	userAgentHeader string
 	clientSettings  *confighttp.HTTPClientSettings
+	tenant          TenantSettings
 }
 
 // NewPRWExporter initializes a new PRWExporter instance and sets fields accordingly.
@@ -75,6 +77,7 @@ func NewPRWExporter(cfg *Config, buildInfo component.BuildInfo) (*PRWExporter, e
 		userAgentHeader: userAgentHeader,
 		concurrency:     cfg.RemoteWriteQueue.NumConsumers,
 		clientSettings:  &cfg.HTTPClientSettings,
+		tenant:          cfg.Tenant,
 	}, nil
 }
 
@@ -94,90 +97,125 @@ func (prwe *PRWExporter) Shutdown(context.Context) error {
 
 // PushMetrics converts metrics to Prometheus remote write TimeSeries and send to remote endpoint. It maintain a map of
 // TimeSeries, validates and handles each individual metric, adding the converted TimeSeries to the map, and finally
-// exports the map.
+// exports the map. The metrics are sent without tenant header, tenant routing is done by the exporters created by
+// the factory, through pushTenantMetrics.
 func (prwe *PRWExporter) PushMetrics(ctx context.Context, md pdata.Metrics) error {
-	prwe.wg.Add(1)
-	defer prwe.wg.Done()
-
-	select {
-	case <-prwe.closeChan:
-		return errors.New("shutdown has been called")
-	default:
-		tsMap := map[string]*prompb.TimeSeries{}
-		dropped := 0
-		var errs error
-		resourceMetricsSlice := md.ResourceMetrics()
-		for i := 0; i < resourceMetricsSlice.Len(); i++ {
-			resourceMetrics := resourceMetricsSlice.At(i)
-			resource := resourceMetrics.Resource()
-			instrumentationLibraryMetricsSlice := resourceMetrics.InstrumentationLibraryMetrics()
-			// TODO: add resource attributes as labels, probably in next PR
-			for j := 0; j < instrumentationLibraryMetricsSlice.Len(); j++ {
-				instrumentationLibraryMetrics := instrumentationLibraryMetricsSlice.At(j)
-				metricSlice := instrumentationLibraryMetrics.Metrics()
-
-				// TODO: decide if instrumentation library information should be exported as labels
-				for k := 0; k < metricSlice.Len(); k++ {
-					metric := metricSlice.At(k)
-
-					// check for valid type and temporality combination and for matching data field and type
-					if ok := validateMetrics(metric); !ok {
+	return prwe.pushTenantMetrics("")(ctx, md)
+}
+
+// pushTenantMetrics returns the function pushing the metrics of a tenant. An empty tenant sends no tenant header.
+func (prwe *PRWExporter) pushTenantMetrics(tenant string) consumerhelper.ConsumeMetricsFunc {
+	return func(ctx context.Context, md pdata.Metrics) error {
+		prwe.wg.Add(1)
+		defer prwe.wg.Done()
+
+		select {
+		case <-prwe.closeChan:
+			return errors.New("shutdown has been called")
+		default:
+			return prwe.pushMetrics(ctx, tenant, md)
+		}
+	}
+}
+
+// splitByTenant groups the resource metrics by the tenant found in the configured resource attribute.
+func (prwe *PRWExporter) splitByTenant(md pdata.Metrics) map[string]pdata.Metrics {
+	byTenant := make(map[string]pdata.Metrics)
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		resourceMetrics := resourceMetricsSlice.At(i)
+
+		tenant := prwe.tenant.Default
+		if value, ok := resourceMetrics.Resource().Attributes().Get(prwe.tenant.FromAttribute); ok && value.AsString() != "" {
+			tenant = value.AsString()
+		}
+
+		tenantMetrics, ok := byTenant[tenant]
+		if !ok {
+			tenantMetrics = pdata.NewMetrics()
+			byTenant[tenant] = tenantMetrics
+		}
+		resourceMetrics.CopyTo(tenantMetrics.ResourceMetrics().AppendEmpty())
+	}
+	return byTenant
+}
+
+// pushMetrics converts and exports the metrics of a single tenant. An empty tenant sends no tenant header.
+func (prwe *PRWExporter) pushMetrics(ctx context.Context, tenant string, md pdata.Metrics) error {
+	tsMap := map[string]*prompb.TimeSeries{}
+	dropped := 0
+	var errs error
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		resourceMetrics := resourceMetricsSlice.At(i)
+		resource := resourceMetrics.Resource()
+		instrumentationLibraryMetricsSlice := resourceMetrics.InstrumentationLibraryMetrics()
+		// TODO: add resource attributes as labels, probably in next PR
+		for j := 0; j < instrumentationLibraryMetricsSlice.Len(); j++ {
+			instrumentationLibraryMetrics := instrumentationLibraryMetricsSlice.At(j)
+			metricSlice := instrumentationLibraryMetrics.Metrics()
+
+			// TODO: decide if instrumentation library information should be exported as labels
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+
+				// check for valid type and temporality combination and for matching data field and type
+				if ok := validateMetrics(metric); !ok {
+					dropped++
+					errs = multierr.Append(errs, consumererror.NewPermanent(errors.New("invalid temporality and type combination")))
+					continue
+				}
+
+				// handle individual metric based on type
+				switch metric.DataType() {
+				case pdata.MetricDataTypeGauge:
+					dataPoints := metric.Gauge().DataPoints()
+					if err := prwe.addNumberDataPointSlice(dataPoints, tsMap, resource, metric); err != nil {
 						dropped++
-						errs = multierr.Append(errs, consumererror.NewPermanent(errors.New("invalid temporality and type combination")))
-						continue
+						errs = multierr.Append(errs, err)
 					}
-
-					// handle individual metric based on type
-					switch metric.DataType() {
-					case pdata.MetricDataTypeGauge:
-						dataPoints := metric.Gauge().DataPoints()
-						if err := prwe.addNumberDataPointSlice(dataPoints, tsMap, resource, metric); err != nil {
-							dropped++
-							errs = multierr.Append(errs, err)
-						}
-					case pdata.MetricDataTypeSum:
-						dataPoints := metric.Sum().DataPoints()
-						if err := prwe.addNumberDataPointSlice(dataPoints, tsMap, resource, metric); err != nil {
-							dropped++
-							errs = multierr.Append(errs, err)
-						}
-					case pdata.MetricDataTypeHistogram:
-						dataPoints := metric.Histogram().DataPoints()
-						if dataPoints.Len() == 0 {
-							dropped++
-							errs = multierr.Append(errs, consumererror.NewPermanent(fmt.Errorf("empty data points. %s is dropped", metric.Name())))
-						}
-						for x := 0; x < dataPoints.Len(); x++ {
-							addSingleHistogramDataPoint(dataPoints.At(x), resource, metric, prwe.namespace, tsMap, prwe.externalLabels)
-						}
-					case pdata.MetricDataTypeSummary:
-						dataPoints := metric.Summary().DataPoints()
-						if dataPoints.Len() == 0 {
-							dropped++
-							errs = multierr.Append(errs, consumererror.NewPermanent(fmt.Errorf("empty data points. %s is dropped", metric.Name())))
-						}
-						for x := 0; x < dataPoints.Len(); x++ {
-							addSingleSummaryDataPoint(dataPoints.At(x), resource, metric, prwe.namespace, tsMap, prwe.externalLabels)
-						}
-					default:
+				case pdata.MetricDataTypeSum:
+					dataPoints := metric.Sum().DataPoints()
+					if err := prwe.addNumberDataPointSlice(dataPoints, tsMap, resource, metric); err != nil {
+						dropped++
+						errs = multierr.Append(errs, err)
+					}
+				case pdata.MetricDataTypeHistogram:
+					dataPoints := metric.Histogram().DataPoints()
+					if dataPoints.Len() == 0 {
 						dropped++
-						errs = multierr.Append(errs, consumererror.NewPermanent(errors.New("unsupported metric type")))
+						errs = multierr.Append(errs, consumererror.NewPermanent(fmt.Errorf("empty data points. %s is dropped", metric.Name())))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						addSingleHistogramDataPoint(dataPoints.At(x), resource, metric, prwe.namespace, tsMap, prwe.externalLabels)
+					}
+				case pdata.MetricDataTypeSummary:
+					dataPoints := metric.Summary().DataPoints()
+					if dataPoints.Len() == 0 {
+						dropped++
+						errs = multierr.Append(errs, consumererror.NewPermanent(fmt.Errorf("empty data points. %s is dropped", metric.Name())))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						addSingleSummaryDataPoint(dataPoints.At(x), resource, metric, prwe.namespace, tsMap, prwe.externalLabels)
 					}
+				default:
+					dropped++
+					errs = multierr.Append(errs, consumererror.NewPermanent(errors.New("unsupported metric type")))
 				}
 			}
 		}
+	}
 
-		if exportErrors := prwe.export(ctx, tsMap); len(exportErrors) != 0 {
-			dropped = md.MetricCount()
-			errs = multierr.Append(errs, multierr.Combine(exportErrors...))
-		}
-
-		if dropped != 0 {
-			return errs
-		}
+	if exportErrors := prwe.export(ctx, tenant, tsMap); len(exportErrors) != 0 {
+		dropped = md.MetricCount()
+		errs = multierr.Append(errs, multierr.Combine(exportErrors...))
+	}
 
-		return nil
+	if dropped != 0 {
+		return errs
 	}
+
+	return nil
 }
 
 func validateAndSanitizeExternalLabels(externalLabels map[string]string) (map[string]string, error) {
@@ -210,7 +248,7 @@ func (prwe *PRWExporter) addNumberDataPointSlice(dataPoints pdata.NumberDataPoin
 }
 
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
-func (prwe *PRWExporter) export(ctx context.Context, tsMap map[string]*prompb.TimeSeries) []error {
+func (prwe *PRWExporter) export(ctx context.Context, tenant string, tsMap map[string]*prompb.TimeSeries) []error {
 	var errs []error
 	// Calls the helper function to convert and batch the TsMap to the desired format
 	requests, err := batchTimeSeries(tsMap, maxBatchByteSize)
@@ -238,7 +276,7 @@ func (prwe *PRWExporter) export(ctx context.Context, tsMap map[string]*prompb.Ti
 			defer wg.Done()
 
 			for request := range input {
-				err := prwe.execute(ctx, request)
+				err := prwe.execute(ctx, tenant, request)
 				if err != nil {
 					mu.Lock()
 					errs = append(errs, err)
@@ -252,7 +290,7 @@ func (prwe *PRWExporter) export(ctx context.Context, tsMap map[string]*prompb.Ti
 	return errs
 }
 
-func (prwe *PRWExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+func (prwe *PRWExporter) execute(ctx context.Context, tenant string, writeReq *prompb.WriteRequest) error {
 	// Uses proto.Marshal to convert the WriteRequest into bytes array
 	data, err := proto.Marshal(writeReq)
 	if err != nil {
@@ -274,6 +312,15 @@ func (prwe *PRWExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
 	req.Header.Set("User-Agent", prwe.userAgentHeader)
 
+	// Tenant headers are set before the request reaches the client's round
+	// trippers, so authenticators that sign requests also sign them.
+	if tenant != "" {
+		req.Header.Set(prwe.tenant.header(), tenant)
+		for name, value := range prwe.tenant.Headers[tenant] {
+			req.Header.Set(name, value)
+		}
+	}
+
 	resp, err := prwe.client.Do(req)
 	if err != nil {
 		return consumererror.NewPermanent(err)
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -345,7 +346,7 @@ func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) []error {
 		return errs
 	}
 
-	errs = append(errs, prwe.export(context.Background(), testmap)...)
+	errs = append(errs, prwe.export(context.Background(), "", testmap)...)
 	return errs
 }
 
@@ -601,3 +602,83 @@ func Test_validateAndSanitizeExternalLabels(t *testing.T) {
 		})
 	}
 }
+
+func Test_PushMetricsTenants(t *testing.T) {
+	tenantMetrics := func(tenant string) pdata.Metrics {
+		md := getMetricsFromMetricList(validMetrics1[validDoubleGauge])
+		if tenant != "" {
+			md.ResourceMetrics().At(0).Resource().Attributes().InsertString("tenant", tenant)
+		}
+		return md
+	}
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	received := map[string]int{}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := r.Header.Get("X-Scope-OrgID")
+		if tenant == "slow" {
+			<-release
+		}
+		mu.Lock()
+		received[tenant]++
+		attempt := received[tenant]
+		if tenant == "team-a" {
+			authorization = r.Header.Get("Authorization")
+		}
+		mu.Unlock()
+		if tenant == "flaky" && attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	receivedCounts := func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		counts := make(map[string]int, len(received))
+		for tenant, count := range received {
+			counts[tenant] = count
+		}
+		return counts
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.Tenant.FromAttribute = "tenant"
+	cfg.Tenant.Default = "anonymous"
+	cfg.Tenant.Headers = map[string]map[string]string{
+		"team-a": {"Authorization": "Bearer a"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp, err := createMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The slow tenant holds its own queue only.
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("slow")))
+	md := pdata.NewMetrics()
+	for _, tenant := range []string{"team-a", "flaky", ""} {
+		tenantMetrics(tenant).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	}
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a")))
+
+	// The failed tenant is retried on its own, without resending the metrics of the other tenants.
+	assert.Eventually(t, func() bool {
+		counts := receivedCounts()
+		return counts["team-a"] == 2 && counts["flaky"] == 2 && counts["anonymous"] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, receivedCounts()["slow"])
+	mu.Lock()
+	assert.Equal(t, "Bearer a", authorization)
+	mu.Unlock()
+
+	close(release)
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Equal(t, map[string]int{"slow": 1, "team-a": 2, "flaky": 2, "anonymous": 1}, receivedCounts())
+	assert.Error(t, exp.ConsumeMetrics(context.Background(), tenantMetrics("team-a")))
+}
@@ -59,22 +59,34 @@ func createMetricsExporter(_ context.Context, set component.ExporterCreateSettin
 	// order for each timeseries. If we shard the incoming metrics
 	// without considering this limitation, we experience
 	// "out of order samples" errors.
-	exporter, err := exporterhelper.NewMetricsExporter(
-		cfg,
-		set,
-		prwe.PushMetrics,
-		exporterhelper.WithTimeout(prwCfg.TimeoutSettings),
-		exporterhelper.WithQueue(exporterhelper.QueueSettings{
-			Enabled:      true,
-			NumConsumers: 1,
-			QueueSize:    prwCfg.RemoteWriteQueue.QueueSize,
-		}),
-		exporterhelper.WithRetry(prwCfg.RetrySettings),
-		exporterhelper.WithStart(prwe.Start),
-		exporterhelper.WithShutdown(prwe.Shutdown),
-	)
-	if err != nil {
-		return nil, err
+	newExporter := func(tenant string, options ...exporterhelper.Option) (component.MetricsExporter, error) {
+		return exporterhelper.NewMetricsExporter(
+			cfg,
+			set,
+			prwe.pushTenantMetrics(tenant),
+			append([]exporterhelper.Option{
+				exporterhelper.WithTimeout(prwCfg.TimeoutSettings),
+				exporterhelper.WithQueue(exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 1,
+					QueueSize:    prwCfg.RemoteWriteQueue.QueueSize,
+				}),
+				exporterhelper.WithRetry(prwCfg.RetrySettings),
+			}, options...)...,
+		)
+	}
+
+	var exporter component.MetricsExporter
+	if prwCfg.Tenant.FromAttribute == "" {
+		exporter, err = newExporter("", exporterhelper.WithStart(prwe.Start), exporterhelper.WithShutdown(prwe.Shutdown))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Every tenant has its own queue, so that a slow or failing tenant doesn't hold back the others.
+		exporter = newTenantExporter(prwe, func(tenant string) (component.MetricsExporter, error) {
+			return newExporter(tenant)
+		})
 	}
 	return resourcetotelemetry.WrapMetricsExporter(prwCfg.ResourceToTelemetrySettings, exporter), nil
 }
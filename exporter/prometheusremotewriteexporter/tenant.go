@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/multierr"
+)
+
+// tenantExporter routes the metrics of every tenant to an exporter of its own, with its own sending queue
+// and retries, so that a slow or failing tenant doesn't hold back the others. The exporters of the tenants are
+// created when their first metrics are received.
+type tenantExporter struct {
+	prwe      *PRWExporter
+	newTenant func(tenant string) (component.MetricsExporter, error)
+
+	mu       sync.Mutex
+	host     component.Host
+	tenants  map[string]component.MetricsExporter
+	shutdown bool
+}
+
+var _ component.MetricsExporter = (*tenantExporter)(nil)
+
+func newTenantExporter(prwe *PRWExporter, newTenant func(tenant string) (component.MetricsExporter, error)) *tenantExporter {
+	return &tenantExporter{
+		prwe:      prwe,
+		newTenant: newTenant,
+		tenants:   make(map[string]component.MetricsExporter),
+	}
+}
+
+func (te *tenantExporter) Start(ctx context.Context, host component.Host) error {
+	te.mu.Lock()
+	te.host = host
+	te.mu.Unlock()
+	return te.prwe.Start(ctx, host)
+}
+
+// Shutdown drains the queues of the tenants before closing the client.
+func (te *tenantExporter) Shutdown(ctx context.Context) error {
+	te.mu.Lock()
+	te.shutdown = true
+	tenants := te.tenants
+	te.tenants = nil
+	te.mu.Unlock()
+
+	var errs error
+	for _, exp := range tenants {
+		errs = multierr.Append(errs, exp.Shutdown(ctx))
+	}
+	return multierr.Append(errs, te.prwe.Shutdown(ctx))
+}
+
+func (te *tenantExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeMetrics splits the metrics by tenant and hands them to the queues of the tenants. Only the metrics of the
+// tenants whose queue rejected them are returned for retry.
+func (te *tenantExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	var errs error
+	failed := pdata.NewMetrics()
+	for tenant, tenantMetrics := range te.prwe.splitByTenant(md) {
+		exp, err := te.tenantExporter(tenant)
+		if err == nil {
+			err = exp.ConsumeMetrics(ctx, tenantMetrics)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("tenant %q: %w", tenant, err))
+			tenantMetrics.ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+		}
+	}
+	if errs != nil {
+		return consumererror.NewMetrics(errs, failed)
+	}
+	return nil
+}
+
+// tenantExporter returns the exporter of the tenant, starting it if needed.
+func (te *tenantExporter) tenantExporter(tenant string) (component.MetricsExporter, error) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	if te.shutdown {
+		return nil, errors.New("shutdown has been called")
+	}
+	if exp, ok := te.tenants[tenant]; ok {
+		return exp, nil
+	}
+	exp, err := te.newTenant(tenant)
+	if err != nil {
+		return nil, err
+	}
+	// The queue consumers don't use the context, which is only valid during Start.
+	if err = exp.Start(context.Background(), te.host); err != nil {
+		return nil, err
+	}
+	te.tenants[tenant] = exp
+	return exp, nil
+}
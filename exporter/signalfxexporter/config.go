@@ -27,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 const (
@@ -104,6 +105,14 @@ type Config struct {
 	// to be used in a dimension key.
 	NonAlphanumericDimensionChars string `mapstructure:"nonalphanumeric_dimension_chars"`
 
+	// DimensionSanitization defines how dimension keys are sanitized. When set it
+	// takes precedence over NonAlphanumericDimensionChars.
+	DimensionSanitization *signalfx.SanitizationRules `mapstructure:"dimension_sanitization"`
+
+	// MetricSanitization defines how metric names are sanitized. Metric names are
+	// sent as is when it is not set.
+	MetricSanitization *signalfx.SanitizationRules `mapstructure:"metric_sanitization"`
+
 	// MaxConnections is used to set a limit to the maximum idle HTTP connection the exporter can keep open.
 	MaxConnections int `mapstructure:"max_connections"`
 }
@@ -160,9 +169,30 @@ func (cfg *Config) validateConfig() error {
 		return errors.New(`cannot have a negative "max_connections"`)
 	}
 
+	if cfg.DimensionSanitization != nil {
+		if err := cfg.DimensionSanitization.Validate(); err != nil {
+			return fmt.Errorf(`invalid "dimension_sanitization": %v`, err)
+		}
+	}
+
+	if cfg.MetricSanitization != nil {
+		if err := cfg.MetricSanitization.Validate(); err != nil {
+			return fmt.Errorf(`invalid "metric_sanitization": %v`, err)
+		}
+	}
+
 	return nil
 }
 
+// dimensionSanitizationRules returns the rules sanitizing dimension keys,
+// built from NonAlphanumericDimensionChars if DimensionSanitization is not set.
+func (cfg *Config) dimensionSanitizationRules() signalfx.SanitizationRules {
+	if cfg.DimensionSanitization != nil {
+		return *cfg.DimensionSanitization
+	}
+	return signalfx.SanitizationRules{AllowedChars: cfg.NonAlphanumericDimensionChars}
+}
+
 func (cfg *Config) getIngestURL() (*url.URL, error) {
 	if cfg.IngestURL != "" {
 		// Ignore realm and use the IngestURL. Typically used for debugging.
@@ -34,6 +34,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -178,6 +179,10 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		NonAlphanumericDimensionChars: "_-.",
+		MetricSanitization: &signalfx.SanitizationRules{
+			AllowedChars: "_",
+			PreserveDots: true,
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -333,3 +338,23 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_sanitization(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AccessToken = "access_token"
+	cfg.Realm = "us0"
+	assert.Equal(t, signalfx.SanitizationRules{AllowedChars: "_-."}, cfg.dimensionSanitizationRules())
+
+	cfg.DimensionSanitization = &signalfx.SanitizationRules{AllowedChars: "_-"}
+	require.NoError(t, cfg.validateConfig())
+	assert.Equal(t, signalfx.SanitizationRules{AllowedChars: "_-"}, cfg.dimensionSanitizationRules())
+
+	cfg.DimensionSanitization.Replacement = "."
+	assert.EqualError(t, cfg.validateConfig(),
+		`invalid "dimension_sanitization": replacement "." must be a letter, a digit or one of the allowed characters`)
+
+	cfg.DimensionSanitization = nil
+	cfg.MetricSanitization = &signalfx.SanitizationRules{Replacement: "ab"}
+	assert.EqualError(t, cfg.validateConfig(),
+		`invalid "metric_sanitization": replacement "ab" must be a single character`)
+}
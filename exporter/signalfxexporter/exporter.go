@@ -89,7 +89,7 @@ func newSignalFxExporter(
 
 	headers := buildHeaders(config)
 
-	converter, err := translation.NewMetricsConverter(logger, options.metricTranslator, config.ExcludeMetrics, config.IncludeMetrics, config.NonAlphanumericDimensionChars)
+	converter, err := translation.NewMetricsConverter(logger, options.metricTranslator, config.ExcludeMetrics, config.IncludeMetrics, config.dimensionSanitizationRules(), config.MetricSanitization)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metric converter: %v", err)
 	}
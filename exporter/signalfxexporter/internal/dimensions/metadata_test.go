@@ -23,6 +23,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation"
 	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 func TestGetDimensionUpdateFromMetadata(t *testing.T) {
@@ -214,7 +215,8 @@ func TestGetDimensionUpdateFromMetadata(t *testing.T) {
 				tt.args.metricTranslator,
 				nil,
 				nil,
-				"-_.",
+				signalfx.SanitizationRules{AllowedChars: "-_."},
+				nil,
 			)
 			require.NoError(t, err)
 			got := getDimensionUpdateFromMetadata(tt.args.metadata, *converter)
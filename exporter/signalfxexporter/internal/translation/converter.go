@@ -18,9 +18,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
-	"strings"
 	"time"
-	"unicode"
 
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -29,6 +27,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 // Some fields on SignalFx protobuf are pointers, in order to reduce
@@ -58,13 +57,14 @@ type MetricsConverter struct {
 
 // NewMetricsConverter creates a MetricsConverter from the passed in logger and
 // MetricTranslator. Pass in a nil MetricTranslator to not use translation
-// rules.
+// rules, and nil metricRules to not sanitize metric names.
 func NewMetricsConverter(
 	logger *zap.Logger,
 	t *MetricTranslator,
 	excludes []dpfilters.MetricFilter,
 	includes []dpfilters.MetricFilter,
-	nonAlphanumericDimChars string) (*MetricsConverter, error) {
+	dimensionRules signalfx.SanitizationRules,
+	metricRules *signalfx.SanitizationRules) (*MetricsConverter, error) {
 	fs, err := dpfilters.NewFilterSet(excludes, includes)
 	if err != nil {
 		return nil, err
@@ -73,7 +73,7 @@ func NewMetricsConverter(
 		logger:             logger,
 		metricTranslator:   t,
 		filterSet:          fs,
-		datapointValidator: newDatapointValidator(logger, nonAlphanumericDimChars),
+		datapointValidator: newDatapointValidator(logger, dimensionRules, metricRules),
 	}, nil
 }
 
@@ -316,17 +316,6 @@ func convertHistogram(histDPs pdata.HistogramDataPointSlice, basePoint *sfxpb.Da
 	return out
 }
 
-func filterKeyChars(str string, nonAlphanumericDimChars string) string {
-	filterMap := func(r rune) rune {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(nonAlphanumericDimChars, r) {
-			return r
-		}
-		return '_'
-	}
-
-	return strings.Map(filterMap, str)
-}
-
 func float64ToDimValue(f float64) string {
 	// Parameters below are the same used by Prometheus
 	// see https://github.com/prometheus/common/blob/b5fe7d854c42dc7842e48d1ca58f60feae09d77b/expfmt/text_create.go#L450
@@ -376,7 +365,7 @@ func (c *MetricsConverter) ConvertDimension(dim string) string {
 	if c.metricTranslator != nil {
 		res = c.metricTranslator.translateDimension(dim)
 	}
-	return filterKeyChars(res, c.datapointValidator.nonAlphanumericDimChars)
+	return c.datapointValidator.dimensionRules.Sanitize(res)
 }
 
 // Values obtained from https://dev.splunk.com/observability/docs/datamodel/ingest#Criteria-for-metric-and-dimension-names-and-values
@@ -396,20 +385,25 @@ var (
 )
 
 type datapointValidator struct {
-	logger                  *zap.Logger
-	nonAlphanumericDimChars string
+	logger         *zap.Logger
+	dimensionRules signalfx.SanitizationRules
+	metricRules    *signalfx.SanitizationRules
 }
 
-func newDatapointValidator(logger *zap.Logger, nonAlphanumericDimChars string) *datapointValidator {
-	return &datapointValidator{logger: createSampledLogger(logger), nonAlphanumericDimChars: nonAlphanumericDimChars}
+func newDatapointValidator(logger *zap.Logger, dimensionRules signalfx.SanitizationRules, metricRules *signalfx.SanitizationRules) *datapointValidator {
+	return &datapointValidator{logger: createSampledLogger(logger), dimensionRules: dimensionRules, metricRules: metricRules}
 }
 
 // sanitizeDataPoints sanitizes datapoints prior to dispatching them to the backend.
 // Datapoints that do not conform to the requirements are removed. This method drops
-// datapoints with metric name greater than 256 characters.
+// datapoints with metric name greater than 256 characters. Metric names are
+// sanitized first when metric sanitization rules are set.
 func (dpv *datapointValidator) sanitizeDataPoints(dps []*sfxpb.DataPoint) []*sfxpb.DataPoint {
 	resultDatapointsLen := 0
 	for dpIndex, dp := range dps {
+		if dpv.metricRules != nil {
+			dp.Metric = dpv.metricRules.Sanitize(dp.Metric)
+		}
 		if dpv.isValidMetricName(dp.Metric) {
 			dp.Dimensions = dpv.sanitizeDimensions(dp.Dimensions)
 			if resultDatapointsLen < dpIndex {
@@ -430,7 +424,7 @@ func (dpv *datapointValidator) sanitizeDimensions(dimensions []*sfxpb.Dimension)
 	resultDimensionsLen := 0
 	for dimensionIndex, d := range dimensions {
 		if dpv.isValidDimension(d) {
-			d.Key = filterKeyChars(d.Key, dpv.nonAlphanumericDimChars)
+			d.Key = dpv.dimensionRules.Sanitize(d.Key)
 			if resultDimensionsLen < dimensionIndex {
 				dimensions[resultDimensionsLen] = d
 			}
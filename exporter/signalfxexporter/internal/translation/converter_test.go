@@ -32,6 +32,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testing/util"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 func Test_MetricDataToSignalFxV2(t *testing.T) {
@@ -763,7 +764,7 @@ func Test_MetricDataToSignalFxV2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, err := NewMetricsConverter(logger, nil, tt.excludeMetrics, tt.includeMetrics, "")
+			c, err := NewMetricsConverter(logger, nil, tt.excludeMetrics, tt.includeMetrics, signalfx.SanitizationRules{}, nil)
 			require.NoError(t, err)
 			md := tt.metricsDataFn()
 			gotSfxDataPoints := c.MetricDataToSignalFxV2(md)
@@ -813,7 +814,7 @@ func TestMetricDataToSignalFxV2WithTranslation(t *testing.T) {
 			},
 		},
 	}
-	c, err := NewMetricsConverter(zap.NewNop(), translator, nil, nil, "")
+	c, err := NewMetricsConverter(zap.NewNop(), translator, nil, nil, signalfx.SanitizationRules{}, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, expected, c.MetricDataToSignalFxV2(rm))
 }
@@ -855,12 +856,51 @@ func TestDimensionKeyCharsWithPeriod(t *testing.T) {
 			},
 		},
 	}
-	c, err := NewMetricsConverter(zap.NewNop(), translator, nil, nil, "_-.")
+	c, err := NewMetricsConverter(zap.NewNop(), translator, nil, nil, signalfx.SanitizationRules{AllowedChars: "_-."}, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, expected, c.MetricDataToSignalFxV2(rm))
 
 }
 
+func TestMetricNameSanitization(t *testing.T) {
+	rm := pdata.NewResourceMetrics()
+	md := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	md.SetDataType(pdata.MetricDataTypeGauge)
+	md.SetName("k8s.pod/cpu-usage")
+	dp := md.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(123)
+	dp.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"k8s.pod.name": pdata.NewAttributeValueString("val1"),
+	})
+
+	gaugeType := sfxpb.MetricType_GAUGE
+	expected := []*sfxpb.DataPoint{
+		{
+			Metric: "k8s.pod_cpu_usage",
+			Value: sfxpb.Datum{
+				IntValue: generateIntPtr(123),
+			},
+			MetricType: &gaugeType,
+			Dimensions: []*sfxpb.Dimension{
+				{
+					Key:   "k8s_pod_name",
+					Value: "val1",
+				},
+			},
+		},
+	}
+	c, err := NewMetricsConverter(
+		zap.NewNop(),
+		nil,
+		nil,
+		nil,
+		signalfx.SanitizationRules{AllowedChars: "_-"},
+		&signalfx.SanitizationRules{AllowedChars: "_", PreserveDots: true},
+	)
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, c.MetricDataToSignalFxV2(rm))
+}
+
 func sortDimensions(points []*sfxpb.DataPoint) {
 	for _, point := range points {
 		if point.Dimensions == nil {
@@ -1009,7 +1049,7 @@ func TestNewMetricsConverter(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewMetricsConverter(zap.NewNop(), nil, tt.excludes, nil, "")
+			got, err := NewMetricsConverter(zap.NewNop(), nil, tt.excludes, nil, signalfx.SanitizationRules{}, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewMetricsConverter() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1023,8 +1063,8 @@ func TestNewMetricsConverter(t *testing.T) {
 
 func TestMetricsConverter_ConvertDimension(t *testing.T) {
 	type fields struct {
-		metricTranslator        *MetricTranslator
-		nonAlphanumericDimChars string
+		metricTranslator *MetricTranslator
+		dimensionRules   signalfx.SanitizationRules
 	}
 	type args struct {
 		dim string
@@ -1038,8 +1078,8 @@ func TestMetricsConverter_ConvertDimension(t *testing.T) {
 		{
 			name: "No translations",
 			fields: fields{
-				metricTranslator:        nil,
-				nonAlphanumericDimChars: "_-",
+				metricTranslator: nil,
+				dimensionRules:   signalfx.SanitizationRules{AllowedChars: "_-"},
 			},
 			args: args{
 				dim: "d.i.m",
@@ -1060,7 +1100,7 @@ func TestMetricsConverter_ConvertDimension(t *testing.T) {
 					}, 0)
 					return t
 				}(),
-				nonAlphanumericDimChars: "_-",
+				dimensionRules: signalfx.SanitizationRules{AllowedChars: "_-"},
 			},
 			args: args{
 				dim: "d.i.m",
@@ -1070,7 +1110,7 @@ func TestMetricsConverter_ConvertDimension(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, err := NewMetricsConverter(zap.NewNop(), tt.fields.metricTranslator, nil, nil, tt.fields.nonAlphanumericDimChars)
+			c, err := NewMetricsConverter(zap.NewNop(), tt.fields.metricTranslator, nil, nil, tt.fields.dimensionRules, nil)
 			require.NoError(t, err)
 			if got := c.ConvertDimension(tt.args.dim); got != tt.want {
 				t.Errorf("ConvertDimension() = %v, want %v", got, tt.want)
@@ -40,11 +40,12 @@ type Config struct {
 	// Format to post logs into Sumo. (default json)
 	//   * text - Logs will appear in Sumo Logic in text format.
 	//   * json - Logs will appear in Sumo Logic in json format.
+	//   * otlp - Logs will be sent to the OTLP/HTTP source in OTLP format.
 	LogFormat LogFormatType `mapstructure:"log_format"`
 
 	// Metrics related configuration
-	// The format of metrics you will be sending, either graphite or carbon2 or prometheus (Default is prometheus)
-	// Possible values are `carbon2` and `prometheus`
+	// The format of metrics you will be sending, either graphite or carbon2 or prometheus or otlp (Default is prometheus)
+	// Possible values are `carbon2`, `graphite`, `prometheus` and `otlp`
 	MetricFormat MetricFormatType `mapstructure:"metric_format"`
 	// Graphite template.
 	// Placeholders `%{attr_name}` will be replaced with attribute value for attr_name.
@@ -68,6 +69,10 @@ type Config struct {
 	SourceHost string `mapstructure:"source_host"`
 	// Name of the client
 	Client string `mapstructure:"client"`
+
+	// Fields to set on the data sent in otlp format, by field name.
+	// Placeholders `%{attr_name}` will be replaced with the value of the resource attribute attr_name.
+	Fields map[string]string `mapstructure:"fields"`
 }
 
 // CreateDefaultHTTPClientSettings returns default http client settings
@@ -94,12 +99,16 @@ const (
 	TextFormat LogFormatType = "text"
 	// JSONFormat represents log_format: json
 	JSONFormat LogFormatType = "json"
+	// OTLPLogFormat represents log_format: otlp
+	OTLPLogFormat LogFormatType = "otlp"
 	// GraphiteFormat represents metric_format: text
 	GraphiteFormat MetricFormatType = "graphite"
 	// Carbon2Format represents metric_format: json
 	Carbon2Format MetricFormatType = "carbon2"
 	// PrometheusFormat represents metric_format: json
 	PrometheusFormat MetricFormatType = "prometheus"
+	// OTLPMetricFormat represents metric_format: otlp
+	OTLPMetricFormat MetricFormatType = "otlp"
 	// GZIPCompression represents compress_encoding: gzip
 	GZIPCompression CompressEncodingType = "gzip"
 	// DeflateCompression represents compress_encoding: deflate
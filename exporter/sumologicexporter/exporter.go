@@ -40,6 +40,7 @@ func initExporter(cfg *Config) (*sumologicexporter, error) {
 	switch cfg.LogFormat {
 	case JSONFormat:
 	case TextFormat:
+	case OTLPLogFormat:
 	default:
 		return nil, fmt.Errorf("unexpected log format: %s", cfg.LogFormat)
 	}
@@ -48,6 +49,7 @@ func initExporter(cfg *Config) (*sumologicexporter, error) {
 	case GraphiteFormat:
 	case Carbon2Format:
 	case PrometheusFormat:
+	case OTLPMetricFormat:
 	default:
 		return nil, fmt.Errorf("unexpected metric format: %s", cfg.MetricFormat)
 	}
@@ -155,6 +157,10 @@ func (se *sumologicexporter) start(_ context.Context, host component.Host) (err
 // It returns the number of unsent logs and an error which contains a list of dropped records
 // so they can be handled by OTC retry mechanism
 func (se *sumologicexporter) pushLogsData(ctx context.Context, ld pdata.Logs) error {
+	if se.config.LogFormat == OTLPLogFormat {
+		return se.pushOTLPLogsData(ctx, ld)
+	}
+
 	var (
 		currentMetadata  fields = newFields(pdata.NewAttributeMap())
 		previousMetadata fields = newFields(pdata.NewAttributeMap())
@@ -255,6 +261,10 @@ func (se *sumologicexporter) pushLogsData(ctx context.Context, ld pdata.Logs) er
 // it returns number of unsent metrics and error which contains list of dropped records
 // so they can be handle by the OTC retry mechanism
 func (se *sumologicexporter) pushMetricsData(ctx context.Context, md pdata.Metrics) error {
+	if se.config.MetricFormat == OTLPMetricFormat {
+		return se.pushOTLPMetricsData(ctx, md)
+	}
+
 	var (
 		currentMetadata  fields = newFields(pdata.NewAttributeMap())
 		previousMetadata fields = newFields(pdata.NewAttributeMap())
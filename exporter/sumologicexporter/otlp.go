@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	// Resource attributes read by the Sumo Logic OTLP/HTTP source to set the
+	// source metadata
+	attributeSourceCategory string = "_sourceCategory"
+	attributeSourceName     string = "_sourceName"
+	attributeSourceHost     string = "_sourceHost"
+
+	otlpLogsPath    string = "/v1/logs"
+	otlpMetricsPath string = "/v1/metrics"
+)
+
+var (
+	otlpLogsMarshaler    = otlp.NewProtobufLogsMarshaler()
+	otlpMetricsMarshaler = otlp.NewProtobufMetricsMarshaler()
+)
+
+// otlpEndpoint returns the URL of the OTLP/HTTP source the data of the pipeline is sent to,
+// adding the signal path to the endpoint unless already present
+func otlpEndpoint(endpoint string, pipeline PipelineType) string {
+	path := otlpMetricsPath
+	if pipeline == LogsPipeline {
+		path = otlpLogsPath
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if strings.HasSuffix(endpoint, path) {
+		return endpoint
+	}
+	return endpoint + path
+}
+
+// setOTLPMetadata sets the source metadata and the fields in the resource attributes,
+// formatting the templates with the resource attributes
+func (se *sumologicexporter) setOTLPMetadata(attributes pdata.AttributeMap) {
+	flds := newFields(attributes)
+	values := make(map[string]string, len(se.sources.fields)+3)
+	for name, format := range se.sources.fields {
+		values[name] = format.format(flds)
+	}
+	if se.sources.category.isSet() {
+		values[attributeSourceCategory] = se.sources.category.format(flds)
+	}
+	if se.sources.name.isSet() {
+		values[attributeSourceName] = se.sources.name.format(flds)
+	}
+	if se.sources.host.isSet() {
+		values[attributeSourceHost] = se.sources.host.format(flds)
+	}
+
+	// All the templates are formatted before updating the attributes,
+	// so that they don't depend on each other
+	for name, value := range values {
+		attributes.UpsertString(name, value)
+	}
+}
+
+// pushOTLPLogsData sends logs to the OTLP/HTTP source in a single request
+func (se *sumologicexporter) pushOTLPLogsData(ctx context.Context, ld pdata.Logs) error {
+	ld = ld.Clone()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		se.setOTLPMetadata(rls.At(i).Resource().Attributes())
+	}
+
+	body, err := otlpLogsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal logs: %w", err))
+	}
+	sdr, err := se.newOTLPSender()
+	if err != nil {
+		return err
+	}
+	return sdr.send(ctx, LogsPipeline, bytes.NewReader(body), newFields(pdata.NewAttributeMap()))
+}
+
+// pushOTLPMetricsData sends metrics to the OTLP/HTTP source in a single request
+func (se *sumologicexporter) pushOTLPMetricsData(ctx context.Context, md pdata.Metrics) error {
+	md = md.Clone()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		se.setOTLPMetadata(rms.At(i).Resource().Attributes())
+	}
+
+	body, err := otlpMetricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal metrics: %w", err))
+	}
+	sdr, err := se.newOTLPSender()
+	if err != nil {
+		return err
+	}
+	return sdr.send(ctx, MetricsPipeline, bytes.NewReader(body), newFields(pdata.NewAttributeMap()))
+}
+
+func (se *sumologicexporter) newOTLPSender() (*sender, error) {
+	c, err := newCompressor(se.config.CompressEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize compressor: %w", err)
+	}
+	return newSender(
+		se.config,
+		se.client,
+		se.filter,
+		se.sources,
+		c,
+		se.prometheusFormatter,
+		se.graphiteFormatter,
+	), nil
+}
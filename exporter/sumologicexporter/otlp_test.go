@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestOTLPEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		pipeline PipelineType
+		expected string
+	}{
+		{
+			endpoint: "https://collectors.sumologic.com/receiver/v1/otlp/token",
+			pipeline: LogsPipeline,
+			expected: "https://collectors.sumologic.com/receiver/v1/otlp/token/v1/logs",
+		},
+		{
+			endpoint: "https://collectors.sumologic.com/receiver/v1/otlp/token/",
+			pipeline: MetricsPipeline,
+			expected: "https://collectors.sumologic.com/receiver/v1/otlp/token/v1/metrics",
+		},
+		{
+			endpoint: "https://collectors.sumologic.com/receiver/v1/otlp/token/v1/logs",
+			pipeline: LogsPipeline,
+			expected: "https://collectors.sumologic.com/receiver/v1/otlp/token/v1/logs",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, otlpEndpoint(tt.endpoint, tt.pipeline))
+	}
+}
+
+func TestInitExporterOTLP(t *testing.T) {
+	_, err := initExporter(&Config{
+		LogFormat:        "otlp",
+		MetricFormat:     "otlp",
+		CompressEncoding: "gzip",
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Timeout:  defaultTimeout,
+			Endpoint: "test_endpoint",
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func prepareOTLPExporterTest(t *testing.T, handler http.HandlerFunc) *sumologicexporter {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	exp, err := initExporter(&Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: srv.URL,
+			Timeout:  defaultTimeout,
+		},
+		LogFormat:      "otlp",
+		MetricFormat:   "otlp",
+		Client:         "otelcol",
+		SourceCategory: "%{k8s.namespace.name}/%{k8s.container.name}",
+		SourceHost:     "%{host.name}",
+		Fields: map[string]string{
+			"cluster": "%{k8s.cluster.name}",
+			"team":    "payments",
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func exampleOTLPResource(attributes pdata.AttributeMap) {
+	attributes.InsertString("k8s.namespace.name", "prod")
+	attributes.InsertString("k8s.container.name", "api")
+	attributes.InsertString("k8s.cluster.name", "eu-1")
+	attributes.InsertString("host.name", "node-1")
+}
+
+func TestPushOTLPLogs(t *testing.T) {
+	var received pdata.Logs
+	exp := prepareOTLPExporterTest(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/logs", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+		assert.Equal(t, "otelcol", req.Header.Get("X-Sumo-Client"))
+		assert.Empty(t, req.Header.Get("X-Sumo-Category"))
+		assert.Empty(t, req.Header.Get("X-Sumo-Fields"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		received, err = otlp.NewProtobufLogsUnmarshaler().UnmarshalLogs(body)
+		require.NoError(t, err)
+	})
+
+	logs := LogRecordsToLogs(exampleLog())
+	exampleOTLPResource(logs.ResourceLogs().At(0).Resource().Attributes())
+
+	require.NoError(t, exp.pushLogsData(context.Background(), logs))
+	require.Equal(t, 1, received.LogRecordCount())
+
+	attributes := received.ResourceLogs().At(0).Resource().Attributes()
+	assert.Equal(t, map[string]interface{}{
+		"k8s.namespace.name": "prod",
+		"k8s.container.name": "api",
+		"k8s.cluster.name":   "eu-1",
+		"host.name":          "node-1",
+		"_sourceCategory":    "prod/api",
+		"_sourceHost":        "node-1",
+		"cluster":            "eu-1",
+		"team":               "payments",
+	}, attributes.AsRaw())
+	body := received.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body()
+	assert.Equal(t, "Example log", body.StringVal())
+
+	// The data of the pipeline is not modified.
+	_, ok := logs.ResourceLogs().At(0).Resource().Attributes().Get("_sourceCategory")
+	assert.False(t, ok)
+}
+
+func TestPushOTLPMetrics(t *testing.T) {
+	var received pdata.Metrics
+	exp := prepareOTLPExporterTest(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/metrics", req.URL.Path)
+		assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		received, err = otlp.NewProtobufMetricsUnmarshaler().UnmarshalMetrics(body)
+		require.NoError(t, err)
+	})
+
+	mp := exampleIntMetric()
+	metrics := metricPairToMetrics([]metricPair{mp})
+	exampleOTLPResource(metrics.ResourceMetrics().At(0).Resource().Attributes())
+
+	require.NoError(t, exp.pushMetricsData(context.Background(), metrics))
+	require.Equal(t, 1, received.MetricCount())
+
+	attributes := received.ResourceMetrics().At(0).Resource().Attributes()
+	category, ok := attributes.Get("_sourceCategory")
+	require.True(t, ok)
+	assert.Equal(t, "prod/api", category.StringVal())
+	cluster, ok := attributes.Get("cluster")
+	require.True(t, ok)
+	assert.Equal(t, "eu-1", cluster.StringVal())
+}
+
+func TestPushOTLPLogsFailure(t *testing.T) {
+	exp := prepareOTLPExporterTest(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := exp.pushLogsData(context.Background(), LogRecordsToLogs(exampleLog()))
+	assert.EqualError(t, err, "error during sending data: 500 Internal Server Error")
+}
@@ -70,6 +70,7 @@ const (
 	contentTypePrometheus string = "application/vnd.sumologic.prometheus"
 	contentTypeCarbon2    string = "application/vnd.sumologic.carbon2"
 	contentTypeGraphite   string = "application/vnd.sumologic.graphite"
+	contentTypeOTLP       string = "application/x-protobuf"
 
 	contentEncodingGzip    string = "gzip"
 	contentEncodingDeflate string = "deflate"
@@ -107,7 +108,12 @@ func (s *sender) send(ctx context.Context, pipeline PipelineType, body io.Reader
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.HTTPClientSettings.Endpoint, data)
+	endpoint := s.config.HTTPClientSettings.Endpoint
+	otlp := s.isOTLP(pipeline)
+	if otlp {
+		endpoint = otlpEndpoint(endpoint, pipeline)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, data)
 	if err != nil {
 		return err
 	}
@@ -125,22 +131,29 @@ func (s *sender) send(ctx context.Context, pipeline PipelineType, body io.Reader
 
 	req.Header.Add(headerClient, s.config.Client)
 
-	if s.sources.host.isSet() {
-		req.Header.Add(headerHost, s.sources.host.format(flds))
-	}
+	// In otlp format, the source metadata is sent in the resource attributes
+	if !otlp {
+		if s.sources.host.isSet() {
+			req.Header.Add(headerHost, s.sources.host.format(flds))
+		}
 
-	if s.sources.name.isSet() {
-		req.Header.Add(headerName, s.sources.name.format(flds))
-	}
+		if s.sources.name.isSet() {
+			req.Header.Add(headerName, s.sources.name.format(flds))
+		}
 
-	if s.sources.category.isSet() {
-		req.Header.Add(headerCategory, s.sources.category.format(flds))
+		if s.sources.category.isSet() {
+			req.Header.Add(headerCategory, s.sources.category.format(flds))
+		}
 	}
 
 	switch pipeline {
 	case LogsPipeline:
-		req.Header.Add(headerContentType, contentTypeLogs)
-		req.Header.Add(headerFields, flds.string())
+		if otlp {
+			req.Header.Add(headerContentType, contentTypeOTLP)
+		} else {
+			req.Header.Add(headerContentType, contentTypeLogs)
+			req.Header.Add(headerFields, flds.string())
+		}
 	case MetricsPipeline:
 		switch s.config.MetricFormat {
 		case PrometheusFormat:
@@ -149,6 +162,8 @@ func (s *sender) send(ctx context.Context, pipeline PipelineType, body io.Reader
 			req.Header.Add(headerContentType, contentTypeCarbon2)
 		case GraphiteFormat:
 			req.Header.Add(headerContentType, contentTypeGraphite)
+		case OTLPMetricFormat:
+			req.Header.Add(headerContentType, contentTypeOTLP)
 		default:
 			return fmt.Errorf("unsupported metrics format: %s", s.config.MetricFormat)
 		}
@@ -166,6 +181,18 @@ func (s *sender) send(ctx context.Context, pipeline PipelineType, body io.Reader
 	return nil
 }
 
+// isOTLP returns whether the data of the pipeline is sent in otlp format
+func (s *sender) isOTLP(pipeline PipelineType) bool {
+	switch pipeline {
+	case LogsPipeline:
+		return s.config.LogFormat == OTLPLogFormat
+	case MetricsPipeline:
+		return s.config.MetricFormat == OTLPMetricFormat
+	default:
+		return false
+	}
+}
+
 // logToText converts LogRecord to a plain text line, returns it and error eventually
 func (s *sender) logToText(record pdata.LogRecord) string {
 	return record.Body().AsString()
@@ -23,6 +23,8 @@ type sourceFormats struct {
 	name     sourceFormat
 	host     sourceFormat
 	category sourceFormat
+	// fields are the formats of the fields sent in otlp format, by field name
+	fields map[string]sourceFormat
 }
 
 type sourceFormat struct {
@@ -61,10 +63,19 @@ func newSourceFormats(cfg *Config) (sourceFormats, error) {
 		return sourceFormats{}, err
 	}
 
+	var fields map[string]sourceFormat
+	if len(cfg.Fields) > 0 {
+		fields = make(map[string]sourceFormat, len(cfg.Fields))
+		for name, text := range cfg.Fields {
+			fields[name] = newSourceFormat(r, text)
+		}
+	}
+
 	return sourceFormats{
 		category: newSourceFormat(r, cfg.SourceCategory),
 		host:     newSourceFormat(r, cfg.SourceHost),
 		name:     newSourceFormat(r, cfg.SourceName),
+		fields:   fields,
 	}, nil
 }
 
@@ -110,3 +110,20 @@ func TestIsNotSet(t *testing.T) {
 	s := getTestSourceFormat(t, "")
 	assert.False(t, s.isSet())
 }
+
+func TestNewSourceFormatsFields(t *testing.T) {
+	cfg := &Config{
+		Fields: map[string]string{
+			"cluster": "%{k8s.cluster.name}",
+			"team":    "payments",
+		},
+	}
+
+	s, err := newSourceFormats(cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]sourceFormat{
+		"cluster": {matches: []string{"k8s.cluster.name"}, template: "%s"},
+		"team":    {matches: []string{}, template: "payments"},
+	}, s.fields)
+}
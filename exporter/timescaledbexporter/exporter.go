@@ -0,0 +1,378 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timescaledbexporter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type timescaleDBExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	table  *table
+	db     *sql.DB
+
+	// open opens the database, it's replaced in tests.
+	open func(datasource string) (*sql.DB, error)
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, t *table) *timescaleDBExporter {
+	return &timescaleDBExporter{
+		cfg:    cfg,
+		logger: logger,
+		table:  t,
+		open: func(datasource string) (*sql.DB, error) {
+			return sql.Open("postgres", datasource)
+		},
+	}
+}
+
+func (e *timescaleDBExporter) start(ctx context.Context, _ component.Host) error {
+	db, err := e.open(e.cfg.Datasource)
+	if err != nil {
+		return err
+	}
+	e.db = db
+	if e.cfg.CreateTables {
+		return e.table.create(ctx, db, e.cfg.Hypertable)
+	}
+	return nil
+}
+
+func (e *timescaleDBExporter) shutdown(context.Context) error {
+	if e.db == nil {
+		return nil
+	}
+	return e.db.Close()
+}
+
+func (e *timescaleDBExporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
+	rows, err := metricRows(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.copy(ctx, rows)
+}
+
+func (e *timescaleDBExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	rows, err := logRows(ld, time.Now())
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.copy(ctx, rows)
+}
+
+// copy writes the rows to the table in a single transaction with the COPY
+// protocol.
+func (e *timescaleDBExporter) copy(ctx context.Context, rows [][]interface{}) (err error) {
+	if len(rows) == 0 {
+		return nil
+	}
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			err = classifyError(err)
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyInSchema(e.table.schema, e.table.name, e.table.columnNames()...))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+	// Executing the statement without arguments flushes the copied rows.
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		return err
+	}
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// classifyError makes the errors caused by the data permanent, so that it
+// isn't retried.
+func classifyError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code.Class() {
+	case "22", "23":
+		// Data exceptions and integrity constraint violations.
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
+
+func metricRows(md pdata.Metrics) ([][]interface{}, error) {
+	var rows [][]interface{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource, err := attributesJSON(rm.Resource().Attributes())
+		if err != nil {
+			return nil, err
+		}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				b := metricRowBuilder{
+					metric:   metrics.At(k),
+					resource: resource,
+					library:  ilm.InstrumentationLibrary(),
+				}
+				if rows, err = b.appendRows(rows); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+// metricRowBuilder builds the rows of the data points of a metric.
+type metricRowBuilder struct {
+	metric   pdata.Metric
+	resource string
+	library  pdata.InstrumentationLibrary
+}
+
+// metricValues are the columns depending on the type of the metric.
+type metricValues struct {
+	temporality    interface{}
+	isMonotonic    interface{}
+	value          interface{}
+	count          interface{}
+	sum            interface{}
+	bucketCounts   interface{}
+	explicitBounds interface{}
+	quantiles      interface{}
+}
+
+func (b *metricRowBuilder) row(start, ts pdata.Timestamp, attrs pdata.AttributeMap, metricType string, v metricValues) ([]interface{}, error) {
+	attributes, err := attributesJSON(attrs)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{
+		ts.AsTime().UTC(),
+		nullableTime(start),
+		b.metric.Name(),
+		b.metric.Unit(),
+		metricType,
+		v.temporality,
+		v.isMonotonic,
+		v.value,
+		v.count,
+		v.sum,
+		v.bucketCounts,
+		v.explicitBounds,
+		v.quantiles,
+		attributes,
+		b.resource,
+		b.library.Name(),
+		b.library.Version(),
+	}, nil
+}
+
+func (b *metricRowBuilder) appendRows(rows [][]interface{}) ([][]interface{}, error) {
+	switch b.metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		return b.appendNumberRows(rows, b.metric.Gauge().DataPoints(), "gauge", metricValues{})
+	case pdata.MetricDataTypeSum:
+		sum := b.metric.Sum()
+		return b.appendNumberRows(rows, sum.DataPoints(), "sum", metricValues{
+			temporality: temporality(sum.AggregationTemporality()),
+			isMonotonic: sum.IsMonotonic(),
+		})
+	case pdata.MetricDataTypeHistogram:
+		histogram := b.metric.Histogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			counts := make([]int64, len(dp.BucketCounts()))
+			for j, c := range dp.BucketCounts() {
+				counts[j] = int64(c)
+			}
+			row, err := b.row(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), "histogram", metricValues{
+				temporality:    temporality(histogram.AggregationTemporality()),
+				count:          int64(dp.Count()),
+				sum:            dp.Sum(),
+				bucketCounts:   pq.Array(counts),
+				explicitBounds: pq.Array(dp.ExplicitBounds()),
+			})
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := b.metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			quantiles, err := quantilesJSON(dp.QuantileValues())
+			if err != nil {
+				return nil, err
+			}
+			row, err := b.row(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), "summary", metricValues{
+				count:     int64(dp.Count()),
+				sum:       dp.Sum(),
+				quantiles: quantiles,
+			})
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (b *metricRowBuilder) appendNumberRows(rows [][]interface{}, dps pdata.NumberDataPointSlice, metricType string, v metricValues) ([][]interface{}, error) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.Type() {
+		case pdata.MetricValueTypeInt:
+			v.value = float64(dp.IntVal())
+		case pdata.MetricValueTypeDouble:
+			v.value = dp.DoubleVal()
+		default:
+			v.value = nil
+		}
+		row, err := b.row(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), metricType, v)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func logRows(ld pdata.Logs, now time.Time) ([][]interface{}, error) {
+	var rows [][]interface{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource, err := attributesJSON(rl.Resource().Attributes())
+		if err != nil {
+			return nil, err
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			library := ills.At(j).InstrumentationLibrary()
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				attributes, err := attributesJSON(lr.Attributes())
+				if err != nil {
+					return nil, err
+				}
+				// The time column can't be null, log records without
+				// timestamp are stored at the time they're exported.
+				ts := now.UTC()
+				if lr.Timestamp() != 0 {
+					ts = lr.Timestamp().AsTime().UTC()
+				}
+				var body interface{}
+				if lr.Body().Type() != pdata.AttributeValueTypeEmpty {
+					body = lr.Body().AsString()
+				}
+				var traceID, spanID interface{}
+				if !lr.TraceID().IsEmpty() {
+					traceID = lr.TraceID().HexString()
+				}
+				if !lr.SpanID().IsEmpty() {
+					spanID = lr.SpanID().HexString()
+				}
+				rows = append(rows, []interface{}{
+					ts,
+					int64(lr.SeverityNumber()),
+					lr.SeverityText(),
+					lr.Name(),
+					body,
+					traceID,
+					spanID,
+					attributes,
+					resource,
+					library.Name(),
+					library.Version(),
+				})
+			}
+		}
+	}
+	return rows, nil
+}
+
+func attributesJSON(attrs pdata.AttributeMap) (string, error) {
+	b, err := json.Marshal(attrs.AsRaw())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	return string(b), nil
+}
+
+// quantilesJSON encodes quantiles as a JSON object mapping quantiles to
+// values, such as {"0.5": 12.1, "0.99": 30.2}.
+func quantilesJSON(qvs pdata.ValueAtQuantileSlice) (string, error) {
+	quantiles := make(map[string]float64, qvs.Len())
+	for i := 0; i < qvs.Len(); i++ {
+		quantiles[strconv.FormatFloat(qvs.At(i).Quantile(), 'g', -1, 64)] = qvs.At(i).Value()
+	}
+	b, err := json.Marshal(quantiles)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode quantiles: %w", err)
+	}
+	return string(b), nil
+}
+
+func nullableTime(ts pdata.Timestamp) interface{} {
+	if ts == 0 {
+		return nil
+	}
+	return ts.AsTime().UTC()
+}
+
+func temporality(t pdata.MetricAggregationTemporality) interface{} {
+	switch t {
+	case pdata.MetricAggregationTemporalityCumulative:
+		return "cumulative"
+	case pdata.MetricAggregationTemporalityDelta:
+		return "delta"
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timescaledbexporter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+var (
+	testStart = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	testTime  = testStart.Add(time.Minute)
+)
+
+func newTestExporter(t *testing.T, cfg *Config, tbl func(*Config) *table) (*timescaleDBExporter, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	cfg.Datasource = "postgres://otel@localhost/otel"
+	e := newExporter(cfg, zap.NewNop(), tbl(cfg))
+	e.open = func(datasource string) (*sql.DB, error) {
+		assert.Equal(t, "postgres://otel@localhost/otel", datasource)
+		return db, nil
+	}
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	return e, mock
+}
+
+func TestStartCreatesHypertable(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	e, mock := newTestExporter(t, cfg, metricsTable)
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "public"."otel_metrics" ("time" TIMESTAMPTZ NOT NULL, "start_time" TIMESTAMPTZ, "name" TEXT NOT NULL,`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT create_hypertable($1::regclass, 'time'")).
+		WithArgs(`"public"."otel_metrics"`, (24 * time.Hour).Microseconds()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX IF NOT EXISTS "otel_metrics_name_time_idx" ON "public"."otel_metrics" (name, time DESC)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectClose()
+
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, e.shutdown(context.Background()))
+}
+
+func TestStartCreatesTable(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Schema = "telemetry"
+	cfg.LogsTable = "logs"
+	cfg.Hypertable.Enabled = false
+	e, mock := newTestExporter(t, cfg, logsTable)
+
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "telemetry"."logs" (`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX IF NOT EXISTS "logs_time_idx" ON "telemetry"."logs" (time DESC)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE INDEX IF NOT EXISTS "logs_trace_id_idx" ON "telemetry"."logs" (trace_id)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestStartCreateTableError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	e, mock := newTestExporter(t, cfg, metricsTable)
+
+	mock.ExpectExec("CREATE TABLE").WillReturnError(errors.New("permission denied for schema public"))
+
+	err := e.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `failed to create table "public"."otel_metrics": permission denied for schema public`)
+}
+
+func TestStartWithoutCreateTables(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CreateTables = false
+	e, _ := newTestExporter(t, cfg, metricsTable)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+}
+
+func newTestMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/hostmetrics")
+	ilm.InstrumentationLibrary().SetVersion("0.36.0")
+	metrics := ilm.Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetUnit("{items}")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	dp := gauge.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	dp.SetIntVal(12)
+	dp.Attributes().InsertString("queue", "orders")
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pdata.NewTimestampFromTime(testStart))
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	dp.SetDoubleVal(3.5)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetUnit("ms")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(pdata.NewTimestampFromTime(testStart))
+	hdp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	hdp.SetCount(6)
+	hdp.SetSum(420)
+	hdp.SetBucketCounts([]uint64{1, 3, 2})
+	hdp.SetExplicitBounds([]float64{10, 100})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("gc.pause")
+	summary.SetUnit("s")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	sdp.SetCount(4)
+	sdp.SetSum(0.2)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(0.04)
+	q = sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(0.09)
+	return md
+}
+
+func TestPushMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CreateTables = false
+	e, mock := newTestExporter(t, cfg, metricsTable)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+	resource := `{"service.name":"checkout"}`
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare(regexp.QuoteMeta(pq.CopyInSchema("public", "otel_metrics", e.table.columnNames()...)))
+	prep.ExpectExec().WithArgs(testTime, nil, "queue.size", "{items}", "gauge", nil, nil, float64(12), nil, nil, nil, nil, nil,
+		`{"queue":"orders"}`, resource, "otelcol/hostmetrics", "0.36.0").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs(testTime, testStart, "requests", "1", "sum", "cumulative", true, 3.5, nil, nil, nil, nil, nil,
+		`{}`, resource, "otelcol/hostmetrics", "0.36.0").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs(testTime, testStart, "latency", "ms", "histogram", "delta", nil, nil, int64(6), float64(420), "{1,3,2}", "{10,100}", nil,
+		`{}`, resource, "otelcol/hostmetrics", "0.36.0").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs(testTime, nil, "gc.pause", "s", "summary", nil, nil, nil, int64(4), 0.2, nil, nil, `{"0.5":0.04,"0.99":0.09}`,
+		`{}`, resource, "otelcol/hostmetrics", "0.36.0").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs().WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	require.NoError(t, e.pushMetrics(context.Background(), newTestMetrics()))
+}
+
+func TestPushLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CreateTables = false
+	e, mock := newTestExporter(t, cfg, logsTable)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "checkout")
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.NewTimestampFromTime(testTime))
+	lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	lr.SetSeverityText("error")
+	lr.Body().SetStringVal("payment declined")
+	lr.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	lr.Attributes().InsertInt("http.status_code", 402)
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare(regexp.QuoteMeta(pq.CopyInSchema("public", "otel_logs", e.table.columnNames()...)))
+	prep.ExpectExec().WithArgs(testTime, int64(17), "error", "", "payment declined",
+		"0102030405060708090a0b0c0d0e0f10", "0102030405060708", `{"http.status_code":402}`, `{"service.name":"checkout"}`, "", "").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs().WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, e.pushLogs(context.Background(), ld))
+}
+
+func TestPushEmpty(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CreateTables = false
+	e, _ := newTestExporter(t, cfg, logsTable)
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, e.pushLogs(context.Background(), pdata.NewLogs()))
+}
+
+func TestPushErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{
+			name:      "data exception",
+			err:       &pq.Error{Code: "22P02", Message: "invalid input syntax for type json"},
+			permanent: true,
+		},
+		{
+			name: "connection failure",
+			err:  &pq.Error{Code: "08006", Message: "connection failure"},
+		},
+		{
+			name: "network error",
+			err:  errors.New("dial tcp: connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CreateTables = false
+			e, mock := newTestExporter(t, cfg, metricsTable)
+			require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+
+			mock.ExpectBegin()
+			prep := mock.ExpectPrepare("COPY")
+			for i := 0; i < 4; i++ {
+				prep.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			prep.ExpectExec().WithArgs().WillReturnError(tt.err)
+			mock.ExpectRollback()
+
+			err := e.pushMetrics(context.Background(), newTestMetrics())
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.err))
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
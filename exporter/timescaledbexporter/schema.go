@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timescaledbexporter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type column struct {
+	name    string
+	sqlType string
+}
+
+type index struct {
+	// suffix is appended to the name of the table to name the index.
+	suffix  string
+	columns string
+}
+
+// table is a table telemetry is written to.
+type table struct {
+	schema  string
+	name    string
+	columns []column
+	indexes []index
+}
+
+func metricsTable(cfg *Config) *table {
+	return &table{
+		schema: cfg.Schema,
+		name:   cfg.MetricsTable,
+		columns: []column{
+			{"time", "TIMESTAMPTZ NOT NULL"},
+			{"start_time", "TIMESTAMPTZ"},
+			{"name", "TEXT NOT NULL"},
+			{"unit", "TEXT NOT NULL"},
+			{"type", "TEXT NOT NULL"},
+			{"temporality", "TEXT"},
+			{"is_monotonic", "BOOLEAN"},
+			{"value", "DOUBLE PRECISION"},
+			{"count", "BIGINT"},
+			{"sum", "DOUBLE PRECISION"},
+			{"bucket_counts", "BIGINT[]"},
+			{"explicit_bounds", "DOUBLE PRECISION[]"},
+			{"quantiles", "JSONB"},
+			{"attributes", "JSONB NOT NULL"},
+			{"resource_attributes", "JSONB NOT NULL"},
+			{"library_name", "TEXT NOT NULL"},
+			{"library_version", "TEXT NOT NULL"},
+		},
+		indexes: []index{
+			{"name_time_idx", "(name, time DESC)"},
+		},
+	}
+}
+
+func logsTable(cfg *Config) *table {
+	return &table{
+		schema: cfg.Schema,
+		name:   cfg.LogsTable,
+		columns: []column{
+			{"time", "TIMESTAMPTZ NOT NULL"},
+			{"severity_number", "INTEGER NOT NULL"},
+			{"severity_text", "TEXT NOT NULL"},
+			{"name", "TEXT NOT NULL"},
+			{"body", "TEXT"},
+			{"trace_id", "TEXT"},
+			{"span_id", "TEXT"},
+			{"attributes", "JSONB NOT NULL"},
+			{"resource_attributes", "JSONB NOT NULL"},
+			{"library_name", "TEXT NOT NULL"},
+			{"library_version", "TEXT NOT NULL"},
+		},
+		indexes: []index{
+			// Hypertables already have this index.
+			{"time_idx", "(time DESC)"},
+			{"trace_id_idx", "(trace_id)"},
+		},
+	}
+}
+
+func (t *table) qualifiedName() string {
+	return pq.QuoteIdentifier(t.schema) + "." + pq.QuoteIdentifier(t.name)
+}
+
+func (t *table) columnNames() []string {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// create creates the table and its indexes if they don't exist, converting
+// the table to a hypertable if enabled.
+func (t *table) create(ctx context.Context, db *sql.DB, hypertable HypertableConfig) error {
+	defs := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		defs[i] = pq.QuoteIdentifier(c.name) + " " + c.sqlType
+	}
+	stmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", t.qualifiedName(), strings.Join(defs, ", "))
+	if _, err := db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", t.qualifiedName(), err)
+	}
+
+	if hypertable.Enabled {
+		_, err := db.ExecContext(ctx,
+			"SELECT create_hypertable($1::regclass, 'time', chunk_time_interval => $2::bigint, if_not_exists => TRUE)",
+			t.qualifiedName(), hypertable.ChunkInterval.Microseconds())
+		if err != nil {
+			return fmt.Errorf("failed to create hypertable %s: %w", t.qualifiedName(), err)
+		}
+	}
+
+	for _, idx := range t.indexes {
+		name := pq.QuoteIdentifier(t.name + "_" + idx.suffix)
+		stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s %s", name, t.qualifiedName(), idx.columns)
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create index %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certreloaderextension
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/certreloader"
+)
+
+// TLSConfigLoader loads TLS configurations whose certificates, keys and CAs
+// are reloaded when their files change.
+type TLSConfigLoader interface {
+	// LoadClientTLSConfig loads the TLS configuration of a client.
+	LoadClientTLSConfig(setting configtls.TLSClientSetting) (*tls.Config, error)
+
+	// LoadServerTLSConfig loads the TLS configuration of a server.
+	LoadServerTLSConfig(setting configtls.TLSServerSetting) (*tls.Config, error)
+}
+
+// GetTLSConfigLoader returns the loader of the certificate reloader extension
+// with the given ID among the extensions of host.
+func GetTLSConfigLoader(host component.Host, id config.ComponentID) (TLSConfigLoader, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("extension %q not found", id)
+	}
+	loader, ok := ext.(TLSConfigLoader)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a certificate reloader", id)
+	}
+	return loader, nil
+}
+
+// LoadClientTLSConfig loads the TLS configuration of a client through the
+// certificate reloader extension with the given ID, or without reloading if
+// id is nil. Components call it in Start with an optional ID from their
+// configuration.
+func LoadClientTLSConfig(host component.Host, id *config.ComponentID, setting configtls.TLSClientSetting) (*tls.Config, error) {
+	if id == nil {
+		return setting.LoadTLSConfig()
+	}
+	loader, err := GetTLSConfigLoader(host, *id)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadClientTLSConfig(setting)
+}
+
+// LoadServerTLSConfig loads the TLS configuration of a server through the
+// certificate reloader extension with the given ID, or without reloading if
+// id is nil. Components call it in Start with an optional ID from their
+// configuration.
+func LoadServerTLSConfig(host component.Host, id *config.ComponentID, setting configtls.TLSServerSetting) (*tls.Config, error) {
+	if id == nil {
+		return setting.LoadTLSConfig()
+	}
+	loader, err := GetTLSConfigLoader(host, *id)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadServerTLSConfig(setting)
+}
+
+type certReloader struct {
+	*certreloader.Reloader
+}
+
+var _ TLSConfigLoader = (*certReloader)(nil)
+
+func newCertReloader(cfg *Config, logger *zap.Logger) *certReloader {
+	return &certReloader{Reloader: certreloader.New(logger, cfg.CheckInterval)}
+}
+
+func (r *certReloader) Start(context.Context, component.Host) error {
+	r.Reloader.Start()
+	return nil
+}
+
+func (r *certReloader) Shutdown(context.Context) error {
+	r.Reloader.Stop()
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certreloaderextension
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+)
+
+type testHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h testHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestGetTLSConfigLoader(t *testing.T) {
+	id := config.NewComponentID(typeStr)
+	reloader := newCertReloader(createDefaultConfig().(*Config), zap.NewNop())
+	nopFactory := componenttest.NewNopExtensionFactory()
+	nop, err := nopFactory.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), nopFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	host := testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			id:                         reloader,
+			config.NewComponentID("x"): nop,
+		},
+	}
+
+	loader, err := GetTLSConfigLoader(host, id)
+	require.NoError(t, err)
+	assert.Equal(t, reloader, loader)
+
+	_, err = GetTLSConfigLoader(host, config.NewComponentID("missing"))
+	assert.EqualError(t, err, `extension "missing" not found`)
+	_, err = GetTLSConfigLoader(host, config.NewComponentID("x"))
+	assert.EqualError(t, err, `extension "x" is not a certificate reloader`)
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	id := config.NewComponentID(typeStr)
+	reloader := newCertReloader(createDefaultConfig().(*Config), zap.NewNop())
+	require.NoError(t, reloader.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, reloader.Shutdown(context.Background()))
+	}()
+	host := testHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: reloader},
+	}
+	setting := configtls.TLSSetting{CAFile: filepath.Join("testdata", "missing.pem")}
+
+	// Without an extension ID the settings are loaded like configtls does.
+	_, err := LoadClientTLSConfig(host, nil, configtls.TLSClientSetting{TLSSetting: setting})
+	assert.Error(t, err)
+	cfg, err := LoadClientTLSConfig(host, nil, configtls.TLSClientSetting{Insecure: true})
+	assert.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = LoadServerTLSConfig(host, &id, configtls.TLSServerSetting{})
+	require.NoError(t, err)
+	assert.NotNil(t, cfg)
+	_, err = LoadServerTLSConfig(host, &id, configtls.TLSServerSetting{TLSSetting: setting})
+	assert.Error(t, err)
+
+	missing := config.NewComponentIDWithName(typeStr, "missing")
+	_, err = LoadClientTLSConfig(host, &missing, configtls.TLSClientSetting{})
+	assert.EqualError(t, err, `extension "certreloader/missing" not found`)
+	_, err = LoadServerTLSConfig(host, nil, configtls.TLSServerSetting{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataflowzextension
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// componentError is an error reported by a component.
+type componentError struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Message   string    `json:"message"`
+}
+
+// componentErrors are the recent errors of a component.
+type componentErrors struct {
+	Kind   string           `json:"kind"`
+	ID     string           `json:"id"`
+	Count  int64            `json:"count"`
+	Recent []componentError `json:"recent"`
+}
+
+// errorRecorder is a span processor keeping the recent errors of the
+// components, recorded by obsreport as the status of the spans of the
+// operations of receivers, scrapers and exporters. Span names are of the form
+// <kind>/<component ID>/<operation>.
+type errorRecorder struct {
+	maxErrors int
+
+	mu     sync.Mutex
+	errors map[string]*componentErrors
+}
+
+var _ sdktrace.SpanProcessor = (*errorRecorder)(nil)
+
+func newErrorRecorder(maxErrors int) *errorRecorder {
+	return &errorRecorder{
+		maxErrors: maxErrors,
+		errors:    make(map[string]*componentErrors),
+	}
+}
+
+func (r *errorRecorder) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (r *errorRecorder) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Status().Code != codes.Error {
+		return
+	}
+	parts := strings.Split(s.Name(), "/")
+	if len(parts) < 3 {
+		return
+	}
+	kind := parts[0]
+	switch kind {
+	case kindReceiver, kindExporter, "scraper":
+	default:
+		return
+	}
+	id := strings.Join(parts[1:len(parts)-1], "/")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := kind + "/" + id
+	errs, ok := r.errors[key]
+	if !ok {
+		errs = &componentErrors{Kind: kind, ID: id}
+		r.errors[key] = errs
+	}
+	errs.Count++
+	errs.Recent = append(errs.Recent, componentError{
+		Time:      s.EndTime(),
+		Operation: parts[len(parts)-1],
+		Message:   s.Status().Description,
+	})
+	if len(errs.Recent) > r.maxErrors {
+		errs.Recent = errs.Recent[len(errs.Recent)-r.maxErrors:]
+	}
+}
+
+func (r *errorRecorder) Shutdown(context.Context) error { return nil }
+
+func (r *errorRecorder) ForceFlush(context.Context) error { return nil }
+
+// snapshot returns a copy of the errors of the components, ordered by kind
+// and ID, with the most recent errors first.
+func (r *errorRecorder) snapshot() []componentErrors {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]componentErrors, 0, len(r.errors))
+	for _, errs := range r.errors {
+		c := componentErrors{Kind: errs.Kind, ID: errs.ID, Count: errs.Count}
+		for i := len(errs.Recent) - 1; i >= 0; i-- {
+			c.Recent = append(c.Recent, errs.Recent[i])
+		}
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Kind != b.Kind {
+			return kindOrder(a.Kind) < kindOrder(b.Kind) || kindOrder(a.Kind) == kindOrder(b.Kind) && a.Kind < b.Kind
+		}
+		return a.ID < b.ID
+	})
+	return result
+}
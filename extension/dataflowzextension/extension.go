@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataflowzextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.opencensus.io/metric/metricproducer"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+const dataFlowzPath = "/debug/dataflowz"
+
+type dataFlowz struct {
+	config    *Config
+	settings  component.TelemetrySettings
+	producers func() []metricproducer.Producer
+	errors    *errorRecorder
+	// tracerProvider is nil if errors are not recorded.
+	tracerProvider *sdktrace.TracerProvider
+	host           component.Host
+	server         *http.Server
+	stopCh         chan struct{}
+}
+
+var _ component.Extension = (*dataFlowz)(nil)
+
+func newDataFlowz(cfg *Config, settings component.TelemetrySettings, producers func() []metricproducer.Producer) *dataFlowz {
+	return &dataFlowz{
+		config:    cfg,
+		settings:  settings,
+		producers: producers,
+		errors:    newErrorRecorder(cfg.MaxErrors),
+	}
+}
+
+func (d *dataFlowz) Start(_ context.Context, host component.Host) error {
+	// The errors of the components are read from the spans of obsreport,
+	// which requires the tracer provider of the Collector to be the SDK one.
+	if tp, ok := d.settings.TracerProvider.(*sdktrace.TracerProvider); ok {
+		tp.RegisterSpanProcessor(d.errors)
+		d.tracerProvider = tp
+	} else {
+		d.settings.Logger.Warn("The tracer provider of the Collector is not the OpenTelemetry SDK one, component errors will not be shown")
+	}
+	d.host = host
+
+	ln, err := d.config.ToListener()
+	if err != nil {
+		return err
+	}
+
+	d.server = d.config.ToServer(d.handler(), d.settings)
+
+	d.stopCh = make(chan struct{})
+	go func() {
+		defer close(d.stopCh)
+
+		if err := d.server.Serve(ln); err != http.ErrServerClosed && err != nil {
+			host.ReportFatalError(err)
+		}
+	}()
+	return nil
+}
+
+func (d *dataFlowz) Shutdown(context.Context) error {
+	if d.tracerProvider != nil {
+		d.tracerProvider.UnregisterSpanProcessor(d.errors)
+	}
+	if d.server == nil {
+		return nil
+	}
+	err := d.server.Close()
+	<-d.stopCh
+	return err
+}
+
+func (d *dataFlowz) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(dataFlowzPath, d.handleDataFlowz)
+	return mux
+}
+
+// snapshot is the state shown by the page.
+type snapshot struct {
+	Time       time.Time         `json:"time"`
+	Components []*componentStats `json:"components"`
+	Errors     []componentErrors `json:"errors"`
+}
+
+func (d *dataFlowz) snapshot() snapshot {
+	components := collectStats(d.producers())
+	// Exporters that did not export anything yet are listed as well.
+	seen := make(map[statsKey]bool, len(components))
+	for _, s := range components {
+		seen[statsKey{kind: s.Kind, id: s.ID, signal: s.Signal}] = true
+	}
+	var idle []*componentStats
+	if d.host != nil {
+		for dataType, exporters := range d.host.GetExporters() {
+			signal := signalForDataType(dataType)
+			for id := range exporters {
+				if key := (statsKey{kind: kindExporter, id: id.String(), signal: signal}); !seen[key] {
+					idle = append(idle, &componentStats{Kind: kindExporter, ID: id.String(), Signal: signal})
+				}
+			}
+		}
+	}
+	if len(idle) > 0 {
+		components = sortStats(append(components, idle...))
+	}
+	return snapshot{
+		Time:       time.Now(),
+		Components: components,
+		Errors:     d.errors.snapshot(),
+	}
+}
+
+func (d *dataFlowz) handleDataFlowz(w http.ResponseWriter, r *http.Request) {
+	s := d.snapshot()
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s); err != nil {
+			d.settings.Logger.Debug("Failed to write response", zap.Error(err))
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, newPageData(s)); err != nil {
+		d.settings.Logger.Debug("Failed to write response", zap.Error(err))
+	}
+}
+
+func signalForDataType(dataType config.DataType) string {
+	switch dataType {
+	case config.TracesDataType:
+		return signalTraces
+	case config.MetricsDataType:
+		return signalMetrics
+	case config.LogsDataType:
+		return signalLogs
+	}
+	return string(dataType)
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataflowzextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type fakeProducer struct {
+	metrics []*metricdata.Metric
+}
+
+func (p *fakeProducer) Read() []*metricdata.Metric {
+	return p.metrics
+}
+
+func newMetric(name string, labelKeys []string, series ...[]interface{}) *metricdata.Metric {
+	m := &metricdata.Metric{Descriptor: metricdata.Descriptor{Name: name}}
+	for _, key := range labelKeys {
+		m.Descriptor.LabelKeys = append(m.Descriptor.LabelKeys, metricdata.LabelKey{Key: key})
+	}
+	for _, s := range series {
+		ts := &metricdata.TimeSeries{}
+		for _, v := range s[:len(s)-1] {
+			ts.LabelValues = append(ts.LabelValues, metricdata.NewLabelValue(v.(string)))
+		}
+		ts.Points = []metricdata.Point{{Value: s[len(s)-1]}}
+		m.TimeSeries = append(m.TimeSeries, ts)
+	}
+	return m
+}
+
+func testProducers() []metricproducer.Producer {
+	return []metricproducer.Producer{&fakeProducer{metrics: []*metricdata.Metric{
+		newMetric("receiver/accepted_spans", []string{"receiver", "transport"},
+			[]interface{}{"otlp", "grpc", int64(100)},
+			[]interface{}{"otlp", "http", int64(20)},
+			[]interface{}{"jaeger", "thrift_http", int64(5)}),
+		newMetric("receiver/refused_spans", []string{"receiver", "transport"},
+			[]interface{}{"otlp", "grpc", int64(3)}),
+		newMetric("processor/dropped_spans", []string{"processor"},
+			[]interface{}{"memory_limiter", int64(7)}),
+		newMetric("exporter/sent_spans", []string{"exporter"},
+			[]interface{}{"otlp/backend", int64(110)}),
+		newMetric("exporter/send_failed_spans", []string{"exporter"},
+			[]interface{}{"otlp/backend", int64(8)}),
+		newMetric("exporter/sent_metric_points", []string{"exporter"},
+			[]interface{}{"otlp/backend", float64(42)}),
+		newMetric("exporter/queue_size", []string{"exporter"},
+			[]interface{}{"otlp/backend", int64(4)}),
+		newMetric("runtime/total_alloc", nil, []interface{}{int64(1)}),
+	}}}
+}
+
+func TestCollectStats(t *testing.T) {
+	queueSize := int64(4)
+	assert.Equal(t, []*componentStats{
+		{Kind: kindReceiver, ID: "jaeger", Signal: signalTraces, Accepted: 5},
+		{Kind: kindReceiver, ID: "otlp", Signal: signalTraces, Accepted: 120, Refused: 3},
+		{Kind: kindProcessor, ID: "memory_limiter", Signal: signalTraces, Dropped: 7},
+		{Kind: kindExporter, ID: "otlp/backend", Signal: signalMetrics, Sent: 42, QueueSize: &queueSize},
+		{Kind: kindExporter, ID: "otlp/backend", Signal: signalTraces, Sent: 110, SendFailed: 8, QueueSize: &queueSize},
+	}, collectStats(testProducers()))
+}
+
+func TestErrorRecorder(t *testing.T) {
+	recorder := newErrorRecorder(2)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	record := func(name string, err error) {
+		_, span := tracer.Start(context.Background(), name)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+	record("exporter/otlp/backend/traces", errors.New("connection refused"))
+	record("exporter/otlp/backend/traces", nil)
+	for i := 1; i <= 3; i++ {
+		record("receiver/otlp/TraceDataReceived", fmt.Errorf("error %d", i))
+	}
+	record("batch/traces", errors.New("not a component"))
+
+	errs := recorder.snapshot()
+	require.Len(t, errs, 2)
+	assert.Equal(t, kindReceiver, errs[0].Kind)
+	assert.Equal(t, "otlp", errs[0].ID)
+	assert.Equal(t, int64(3), errs[0].Count)
+	require.Len(t, errs[0].Recent, 2)
+	assert.Equal(t, "error 3", errs[0].Recent[0].Message)
+	assert.Equal(t, "error 2", errs[0].Recent[1].Message)
+	assert.Equal(t, "TraceDataReceived", errs[0].Recent[0].Operation)
+
+	assert.Equal(t, kindExporter, errs[1].Kind)
+	assert.Equal(t, "otlp/backend", errs[1].ID)
+	assert.Equal(t, int64(1), errs[1].Count)
+	assert.Equal(t, "traces", errs[1].Recent[0].Operation)
+	assert.Equal(t, "connection refused", errs[1].Recent[0].Message)
+}
+
+type fakeHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *fakeHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestDataFlowzPage(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	settings := componenttest.NewNopTelemetrySettings()
+	tp := sdktrace.NewTracerProvider()
+	settings.TracerProvider = tp
+	d := newDataFlowz(cfg, settings, testProducers)
+	host := &fakeHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.LogsDataType: {config.NewComponentID("file"): nil},
+		},
+	}
+	require.NoError(t, d.Start(context.Background(), host))
+	defer func() { require.NoError(t, d.Shutdown(context.Background())) }()
+
+	_, span := tp.Tracer("test").Start(context.Background(), "exporter/otlp/backend/traces")
+	span.SetStatus(codes.Error, "context deadline exceeded")
+	span.End()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + endpoint + dataFlowzPath + "?format=json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var s snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&s))
+	require.Len(t, s.Components, 6)
+	assert.Equal(t, &componentStats{Kind: kindExporter, ID: "file", Signal: signalLogs}, s.Components[3])
+	require.Len(t, s.Errors, 1)
+	assert.Equal(t, "context deadline exceeded", s.Errors[0].Recent[0].Message)
+
+	resp, err = client.Get("http://" + endpoint + dataFlowzPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	page := string(body)
+	assert.Contains(t, page, "<p>Received: 125, refused: 3, dropped: 7, sent: 110, failed: 8</p>")
+	assert.Contains(t, page, "<tr><td>otlp/backend</td><td>110</td><td class=\"error\">8</td><td>0</td><td>4</td></tr>")
+	assert.Contains(t, page, "<tr><td>file</td><td>0</td><td>0</td><td>0</td><td>-</td></tr>")
+	assert.Contains(t, page, "context deadline exceeded")
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataflowzextension
+
+import (
+	"html/template"
+)
+
+// signalSection is the data flow of a signal, from its receivers to its
+// exporters.
+type signalSection struct {
+	Name       string
+	Received   int64
+	Refused    int64
+	Dropped    int64
+	Sent       int64
+	Failed     int64
+	Receivers  []*componentStats
+	Processors []*componentStats
+	Exporters  []*componentStats
+}
+
+type pageData struct {
+	snapshot
+	Signals []*signalSection
+}
+
+func newPageData(s snapshot) pageData {
+	sections := make(map[string]*signalSection)
+	var signals []*signalSection
+	for _, name := range []string{signalTraces, signalMetrics, signalLogs} {
+		sections[name] = &signalSection{Name: name}
+		signals = append(signals, sections[name])
+	}
+	for _, c := range s.Components {
+		section, ok := sections[c.Signal]
+		if !ok {
+			continue
+		}
+		switch c.Kind {
+		case kindReceiver:
+			section.Receivers = append(section.Receivers, c)
+			section.Received += c.Accepted
+			section.Refused += c.Refused
+		case kindProcessor:
+			section.Processors = append(section.Processors, c)
+			section.Refused += c.Refused
+			section.Dropped += c.Dropped
+		case kindExporter:
+			section.Exporters = append(section.Exporters, c)
+			section.Sent += c.Sent
+			section.Failed += c.SendFailed + c.EnqueueFailed
+		}
+	}
+	// Signals without components are not shown.
+	shown := signals[:0]
+	for _, section := range signals {
+		if len(section.Receivers)+len(section.Processors)+len(section.Exporters) > 0 {
+			shown = append(shown, section)
+		}
+	}
+	return pageData{snapshot: s, Signals: shown}
+}
+
+var pageTemplate = template.Must(template.New("dataflowz").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Data flow</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Data flow</h1>
+<p>Counters since the Collector started, at {{.Time.Format "2006-01-02 15:04:05 MST"}}. <a href="?format=json">JSON</a></p>
+{{range .Signals}}
+<h2>{{.Name}}</h2>
+<p>Received: {{.Received}}, refused: {{.Refused}}, dropped: {{.Dropped}}, sent: {{.Sent}}, failed: {{.Failed}}</p>
+{{if .Receivers}}
+<table>
+<tr><th>Receiver</th><th>Accepted</th><th>Refused</th></tr>
+{{range .Receivers}}<tr><td>{{.ID}}</td><td>{{.Accepted}}</td><td{{if .Refused}} class="error"{{end}}>{{.Refused}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .Processors}}
+<table>
+<tr><th>Processor</th><th>Accepted</th><th>Refused</th><th>Dropped</th></tr>
+{{range .Processors}}<tr><td>{{.ID}}</td><td>{{.Accepted}}</td><td{{if .Refused}} class="error"{{end}}>{{.Refused}}</td><td{{if .Dropped}} class="error"{{end}}>{{.Dropped}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .Exporters}}
+<table>
+<tr><th>Exporter</th><th>Sent</th><th>Send failed</th><th>Enqueue failed</th><th>Queue size</th></tr>
+{{range .Exporters}}<tr><td>{{.ID}}</td><td>{{.Sent}}</td><td{{if .SendFailed}} class="error"{{end}}>{{.SendFailed}}</td><td{{if .EnqueueFailed}} class="error"{{end}}>{{.EnqueueFailed}}</td><td>{{if .QueueSize}}{{.QueueSize}}{{else}}-{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+<h2>Errors</h2>
+{{if .Errors}}
+<table>
+<tr><th>Component</th><th>Errors</th><th>Time</th><th>Operation</th><th>Message</th></tr>
+{{range .Errors}}{{$c := .}}{{range $i, $e := .Recent}}<tr>{{if eq $i 0}}<td rowspan="{{len $c.Recent}}">{{$c.Kind}}/{{$c.ID}}</td><td rowspan="{{len $c.Recent}}">{{$c.Count}}</td>{{end}}<td>{{$e.Time.Format "15:04:05"}}</td><td>{{$e.Operation}}</td><td class="error" style="text-align: left">{{$e.Message}}</td></tr>
+{{end}}{{end}}</table>
+{{else}}
+<p>No errors recorded.</p>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataflowzextension
+
+import (
+	"sort"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+)
+
+const (
+	kindReceiver  = "receiver"
+	kindProcessor = "processor"
+	kindExporter  = "exporter"
+
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+// counter is a counter of the Collector's own metrics, recorded by obsreport.
+type counter int
+
+const (
+	accepted counter = iota
+	refused
+	dropped
+	sent
+	sendFailed
+	enqueueFailed
+	numCounters
+)
+
+// componentStats are the counters of a component for a signal.
+type componentStats struct {
+	Kind          string `json:"kind"`
+	ID            string `json:"id"`
+	Signal        string `json:"signal"`
+	Accepted      int64  `json:"accepted,omitempty"`
+	Refused       int64  `json:"refused,omitempty"`
+	Dropped       int64  `json:"dropped,omitempty"`
+	Sent          int64  `json:"sent,omitempty"`
+	SendFailed    int64  `json:"send_failed,omitempty"`
+	EnqueueFailed int64  `json:"enqueue_failed,omitempty"`
+	// QueueSize is the number of batches in the sending queue of an
+	// exporter, shared by the signals the exporter is used for.
+	QueueSize *int64 `json:"queue_size,omitempty"`
+}
+
+func (s *componentStats) add(c counter, v int64) {
+	switch c {
+	case accepted:
+		s.Accepted += v
+	case refused:
+		s.Refused += v
+	case dropped:
+		s.Dropped += v
+	case sent:
+		s.Sent += v
+	case sendFailed:
+		s.SendFailed += v
+	case enqueueFailed:
+		s.EnqueueFailed += v
+	}
+}
+
+// counterMetric is what a metric recorded by obsreport counts.
+type counterMetric struct {
+	kind    string
+	signal  string
+	counter counter
+}
+
+// counterMetrics maps the names of the metrics recorded by obsreport to what
+// they count. The component is identified by the label named after the kind.
+var counterMetrics = func() map[string]counterMetric {
+	units := map[string]string{signalTraces: "spans", signalMetrics: "metric_points", signalLogs: "log_records"}
+	names := map[string]map[counter]string{
+		kindReceiver:  {accepted: "accepted", refused: "refused"},
+		kindProcessor: {accepted: "accepted", refused: "refused", dropped: "dropped"},
+		kindExporter:  {sent: "sent", sendFailed: "send_failed", enqueueFailed: "enqueue_failed"},
+	}
+	m := make(map[string]counterMetric)
+	for kind, counters := range names {
+		for c, name := range counters {
+			for signal, unit := range units {
+				m[kind+"/"+name+"_"+unit] = counterMetric{kind: kind, signal: signal, counter: c}
+			}
+		}
+	}
+	return m
+}()
+
+// queueSizeMetric is the name of the gauge of the sending queue sizes,
+// recorded by exporterhelper.
+const queueSizeMetric = "exporter/queue_size"
+
+type statsKey struct {
+	kind   string
+	id     string
+	signal string
+}
+
+// collectStats reads the counters of the components from the metrics of the
+// producers, ordered by kind, ID and signal. The counters of a component
+// recorded with different labels, such as the transport of receivers, are
+// summed.
+func collectStats(producers []metricproducer.Producer) []*componentStats {
+	stats := make(map[statsKey]*componentStats)
+	queueSizes := make(map[string]int64)
+	for _, producer := range producers {
+		for _, metric := range producer.Read() {
+			if metric == nil {
+				continue
+			}
+			if metric.Descriptor.Name == queueSizeMetric {
+				for _, ts := range metric.TimeSeries {
+					if id, ok := labelValue(metric.Descriptor, ts, kindExporter); ok {
+						queueSizes[id] = lastValue(ts)
+					}
+				}
+				continue
+			}
+			cm, ok := counterMetrics[metric.Descriptor.Name]
+			if !ok {
+				continue
+			}
+			for _, ts := range metric.TimeSeries {
+				id, ok := labelValue(metric.Descriptor, ts, cm.kind)
+				if !ok {
+					continue
+				}
+				key := statsKey{kind: cm.kind, id: id, signal: cm.signal}
+				s, ok := stats[key]
+				if !ok {
+					s = &componentStats{Kind: cm.kind, ID: id, Signal: cm.signal}
+					stats[key] = s
+				}
+				s.add(cm.counter, lastValue(ts))
+			}
+		}
+	}
+
+	result := make([]*componentStats, 0, len(stats))
+	for _, s := range stats {
+		if size, ok := queueSizes[s.ID]; ok && s.Kind == kindExporter {
+			size := size
+			s.QueueSize = &size
+		}
+		result = append(result, s)
+	}
+	return sortStats(result)
+}
+
+// sortStats orders the stats by kind, ID and signal.
+func sortStats(stats []*componentStats) []*componentStats {
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Kind != b.Kind {
+			return kindOrder(a.Kind) < kindOrder(b.Kind)
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Signal < b.Signal
+	})
+	return stats
+}
+
+// kindOrder orders components the way data flows through pipelines.
+func kindOrder(kind string) int {
+	switch kind {
+	case kindReceiver:
+		return 0
+	case kindProcessor:
+		return 1
+	}
+	return 2
+}
+
+func labelValue(d metricdata.Descriptor, ts *metricdata.TimeSeries, key string) (string, bool) {
+	for i, k := range d.LabelKeys {
+		if k.Key == key && i < len(ts.LabelValues) && ts.LabelValues[i].Present {
+			return ts.LabelValues[i].Value, true
+		}
+	}
+	return "", false
+}
+
+// lastValue returns the value of the last point of an integer or double
+// time series.
+func lastValue(ts *metricdata.TimeSeries) int64 {
+	if len(ts.Points) == 0 {
+		return 0
+	}
+	switch v := ts.Points[len(ts.Points)-1].Value.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
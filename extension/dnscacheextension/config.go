@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscacheextension
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the DNS cache extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// MinTTL is the minimum duration answers are cached for, whatever the TTL
+	// of their records.
+	MinTTL time.Duration `mapstructure:"min_ttl"`
+
+	// MaxTTL is the maximum duration answers are cached for, and the duration
+	// answers without TTL, e.g. from the hosts file, are cached for.
+	MaxTTL time.Duration `mapstructure:"max_ttl"`
+
+	// MaxStale is how long expired answers are still used while they can't be
+	// refreshed.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+
+	// NegativeTTL is the duration failed lookups are cached for, and the
+	// duration between two refreshes of expired answers that fail.
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+
+	// MaxNegativeEntries is the maximum number of failed lookups cached.
+	MaxNegativeEntries int `mapstructure:"max_negative_entries"`
+
+	// Jitter is the fraction by which cache durations are randomly shortened,
+	// so that the answers of many hosts aren't refreshed at once.
+	Jitter float64 `mapstructure:"jitter"`
+
+	// Timeout is the timeout of lookups.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MinTTL < 0 || cfg.MaxStale < 0 || cfg.NegativeTTL < 0 {
+		return errors.New("min_ttl, max_stale and negative_ttl must not be negative")
+	}
+	if cfg.MaxTTL <= 0 || cfg.MaxTTL < cfg.MinTTL {
+		return errors.New("max_ttl must be positive and greater than or equal to min_ttl")
+	}
+	if cfg.MaxNegativeEntries < 0 {
+		return errors.New("max_negative_entries must not be negative")
+	}
+	if cfg.Jitter < 0 || cfg.Jitter >= 1 {
+		return errors.New("jitter must be in [0, 1)")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscacheextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Extensions[config.NewComponentID(typeStr)])
+	assert.Equal(t, &Config{
+		ExtensionSettings:  config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		MinTTL:             5 * time.Second,
+		MaxTTL:             time.Minute,
+		MaxStale:           10 * time.Minute,
+		NegativeTTL:        2 * time.Second,
+		MaxNegativeEntries: 10,
+		Jitter:             0.2,
+		Timeout:            time.Second,
+	}, cfg.Extensions[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "negative min_ttl",
+			modify: func(cfg *Config) { cfg.MinTTL = -time.Second },
+			err:    "min_ttl, max_stale and negative_ttl must not be negative",
+		},
+		{
+			name:   "max_ttl below min_ttl",
+			modify: func(cfg *Config) { cfg.MaxTTL = cfg.MinTTL / 2 },
+			err:    "max_ttl must be positive and greater than or equal to min_ttl",
+		},
+		{
+			name:   "negative max_negative_entries",
+			modify: func(cfg *Config) { cfg.MaxNegativeEntries = -1 },
+			err:    "max_negative_entries must not be negative",
+		},
+		{
+			name:   "jitter",
+			modify: func(cfg *Config) { cfg.Jitter = 1 },
+			err:    "jitter must be in [0, 1)",
+		},
+		{
+			name:   "timeout",
+			modify: func(cfg *Config) { cfg.Timeout = 0 },
+			err:    "timeout must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
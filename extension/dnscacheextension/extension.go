@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscacheextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// Resolver resolves hostnames through the cache of a DNS cache extension.
+type Resolver interface {
+	// LookupHost returns the addresses of host.
+	LookupHost(ctx context.Context, host string) ([]string, error)
+
+	// DialContext connects to address, resolving its host through the cache.
+	// It can be set as the DialContext of an http.Transport.
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// GetResolver returns the resolver of the DNS cache extension with the given
+// ID among the extensions of host.
+func GetResolver(host component.Host, id config.ComponentID) (Resolver, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("extension %q not found", id)
+	}
+	resolver, ok := ext.(Resolver)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a DNS cache", id)
+	}
+	return resolver, nil
+}
+
+// ConfigureClient makes client resolve hostnames through the DNS cache
+// extension with the given ID, or leaves it unchanged if id is nil. The
+// transport of client must be an *http.Transport, like the transport of the
+// clients created by confighttp without headers nor authenticator. Components
+// call it in Start with an optional ID from their configuration.
+func ConfigureClient(host component.Host, id *config.ComponentID, client *http.Client) error {
+	if id == nil {
+		return nil
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("transport %T can't resolve hostnames through the DNS cache", client.Transport)
+	}
+	resolver, err := GetResolver(host, *id)
+	if err != nil {
+		return err
+	}
+	transport.DialContext = resolver.DialContext
+	return nil
+}
+
+// lookupFunc looks up the addresses of host. The TTL of the answer is
+// negative if unknown.
+type lookupFunc func(ctx context.Context, host string) (addrs []string, ttl time.Duration, err error)
+
+type entry struct {
+	addrs []string
+	err   error
+	// expires is when the entry must be refreshed.
+	expires time.Time
+	// staleUntil is when the addresses of the entry become unusable.
+	staleUntil time.Time
+	refreshing bool
+}
+
+type dnsCache struct {
+	cfg    *Config
+	logger *zap.Logger
+	lookup lookupFunc
+	dialer net.Dialer
+	now    func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]*entry
+	negatives int
+	rand      *rand.Rand
+
+	group  singleflight.Group
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ Resolver = (*dnsCache)(nil)
+
+func newDNSCache(cfg *Config, logger *zap.Logger, lookup lookupFunc) *dnsCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &dnsCache{
+		cfg:     cfg,
+		logger:  logger,
+		lookup:  lookup,
+		dialer:  net.Dialer{KeepAlive: 30 * time.Second},
+		now:     time.Now,
+		entries: map[string]*entry{},
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (c *dnsCache) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (c *dnsCache) Shutdown(context.Context) error {
+	c.cancel()
+	c.wg.Wait()
+	return nil
+}
+
+// LookupHost returns the cached addresses of host. Expired addresses are
+// returned while they are refreshed in the background, so that callers don't
+// wait for DNS servers unless host was never resolved.
+func (c *dnsCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if net.ParseIP(host) != nil {
+		return []string{host}, nil
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	if e, ok := c.entries[host]; ok {
+		if now.Before(e.expires) {
+			c.mu.Unlock()
+			if e.err != nil {
+				return nil, e.err
+			}
+			return e.addrs, nil
+		}
+		if len(e.addrs) > 0 && now.Before(e.staleUntil) {
+			if !e.refreshing {
+				e.refreshing = true
+				c.wg.Add(1)
+				go func() {
+					defer c.wg.Done()
+					_, _, _ = c.group.Do(host, func() (interface{}, error) {
+						return c.refresh(host)
+					})
+				}()
+			}
+			c.mu.Unlock()
+			return e.addrs, nil
+		}
+	}
+	c.mu.Unlock()
+
+	ch := c.group.DoChan(host, func() (interface{}, error) {
+		return c.refresh(host)
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]string), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refresh looks up host and updates its entry.
+func (c *dnsCache) refresh(host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, c.cfg.Timeout)
+	defer cancel()
+	addrs, ttl, err := c.lookup(ctx, host)
+	if err == nil && len(addrs) == 0 {
+		err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	old := c.entries[host]
+	if err == nil {
+		if ttl < 0 || ttl > c.cfg.MaxTTL {
+			ttl = c.cfg.MaxTTL
+		}
+		if ttl < c.cfg.MinTTL {
+			ttl = c.cfg.MinTTL
+		}
+		if old != nil && old.err != nil {
+			c.negatives--
+		}
+		c.entries[host] = &entry{
+			addrs:      addrs,
+			expires:    now.Add(c.jitter(ttl)),
+			staleUntil: now.Add(ttl + c.cfg.MaxStale),
+		}
+		return addrs, nil
+	}
+
+	if old != nil && len(old.addrs) > 0 && now.Before(old.staleUntil) {
+		c.logger.Warn("Failed to refresh DNS answer, using the expired one",
+			zap.String("host", host), zap.Strings("addresses", old.addrs), zap.Error(err))
+		old.expires = now.Add(c.jitter(c.cfg.NegativeTTL))
+		old.refreshing = false
+		return old.addrs, nil
+	}
+
+	if old != nil && old.err == nil {
+		delete(c.entries, host)
+	}
+	if c.cfg.NegativeTTL > 0 && c.cfg.MaxNegativeEntries > 0 {
+		if old == nil || old.err == nil {
+			if c.negatives >= c.cfg.MaxNegativeEntries {
+				c.evictNegative()
+			}
+			c.negatives++
+		}
+		c.entries[host] = &entry{err: err, expires: now.Add(c.jitter(c.cfg.NegativeTTL))}
+	} else if old != nil && old.err != nil {
+		delete(c.entries, host)
+		c.negatives--
+	}
+	return nil, err
+}
+
+// evictNegative removes the failed lookup expiring first from the cache.
+func (c *dnsCache) evictNegative() {
+	var oldest string
+	var expires time.Time
+	for host, e := range c.entries {
+		if e.err != nil && (oldest == "" || e.expires.Before(expires)) {
+			oldest, expires = host, e.expires
+		}
+	}
+	if oldest != "" {
+		delete(c.entries, oldest)
+		c.negatives--
+	}
+}
+
+// jitter randomly shortens d by up to the configured fraction.
+func (c *dnsCache) jitter(d time.Duration) time.Duration {
+	return d - time.Duration(c.cfg.Jitter*c.rand.Float64()*float64(d))
+}
+
+// DialContext connects to the first reachable address of the host of
+// address.
+func (c *dnsCache) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := c.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialErr error
+	for _, addr := range addrs {
+		if !matchesNetwork(network, addr) {
+			continue
+		}
+		conn, err := c.dialer.DialContext(ctx, network, net.JoinHostPort(addr, port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if dialErr == nil {
+		dialErr = errors.New("no address of " + host + " matches network " + network)
+	}
+	return nil, dialErr
+}
+
+// matchesNetwork reports whether addr can be dialed on network, e.g. IPv6
+// addresses can't be dialed on tcp4.
+func matchesNetwork(network, addr string) bool {
+	ip := net.ParseIP(addr)
+	switch {
+	case strings.HasSuffix(network, "4"):
+		return ip.To4() != nil
+	case strings.HasSuffix(network, "6"):
+		return ip.To4() == nil
+	default:
+		return true
+	}
+}
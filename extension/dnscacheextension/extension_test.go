@@ -0,0 +1,412 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscacheextension
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+)
+
+type fakeAnswer struct {
+	addrs []string
+	ttl   time.Duration
+	err   error
+}
+
+// fakeDNS answers lookups from a map of hosts.
+type fakeDNS struct {
+	mu      sync.Mutex
+	answers map[string]fakeAnswer
+	lookups map[string]int
+	block   chan struct{}
+}
+
+func newFakeDNS() *fakeDNS {
+	return &fakeDNS{answers: map[string]fakeAnswer{}, lookups: map[string]int{}}
+}
+
+func (f *fakeDNS) set(host string, answer fakeAnswer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.answers[host] = answer
+}
+
+func (f *fakeDNS) count(host string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lookups[host]
+}
+
+func (f *fakeDNS) lookup(ctx context.Context, host string) ([]string, time.Duration, error) {
+	f.mu.Lock()
+	f.lookups[host]++
+	answer, ok := f.answers[host]
+	block := f.block
+	f.mu.Unlock()
+	if block != nil {
+		select {
+		case <-block:
+		case <-ctx.Done():
+			return nil, -1, ctx.Err()
+		}
+	}
+	if !ok {
+		return nil, -1, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return answer.addrs, answer.ttl, answer.err
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCache(t *testing.T, modify func(*Config)) (*dnsCache, *fakeDNS, *fakeClock) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Jitter = 0
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	dns := newFakeDNS()
+	clock := &fakeClock{now: time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)}
+	c := newDNSCache(cfg, zap.NewNop(), dns.lookup)
+	c.now = clock.Now
+	t.Cleanup(func() { require.NoError(t, c.Shutdown(context.Background())) })
+	return c, dns, clock
+}
+
+func TestLookupHostCachesAnswers(t *testing.T) {
+	c, dns, clock := newTestCache(t, nil)
+	dns.set("collector.example", fakeAnswer{addrs: []string{"10.0.0.1", "10.0.0.2"}, ttl: 30 * time.Second})
+
+	for i := 0; i < 3; i++ {
+		addrs, err := c.LookupHost(context.Background(), "collector.example")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, addrs)
+	}
+	assert.Equal(t, 1, dns.count("collector.example"))
+
+	// The answer is refreshed once its TTL expires.
+	dns.set("collector.example", fakeAnswer{addrs: []string{"10.0.0.3"}, ttl: 30 * time.Second})
+	clock.advance(30 * time.Second)
+	addrs, err := c.LookupHost(context.Background(), "collector.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, addrs, "the expired answer is used while refreshed")
+	c.wg.Wait()
+	assert.Equal(t, 2, dns.count("collector.example"))
+	addrs, err = c.LookupHost(context.Background(), "collector.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.3"}, addrs)
+}
+
+func TestLookupHostTTLBounds(t *testing.T) {
+	c, dns, clock := newTestCache(t, func(cfg *Config) {
+		cfg.MinTTL = 5 * time.Second
+		cfg.MaxTTL = time.Minute
+		cfg.MaxStale = 0
+	})
+	dns.set("low.example", fakeAnswer{addrs: []string{"10.0.0.1"}, ttl: 0})
+	dns.set("high.example", fakeAnswer{addrs: []string{"10.0.0.2"}, ttl: time.Hour})
+	dns.set("hosts.example", fakeAnswer{addrs: []string{"10.0.0.3"}, ttl: -1})
+
+	for _, host := range []string{"low.example", "high.example", "hosts.example"} {
+		_, err := c.LookupHost(context.Background(), host)
+		require.NoError(t, err)
+	}
+	clock.advance(4 * time.Second)
+	for _, host := range []string{"low.example", "high.example", "hosts.example"} {
+		_, err := c.LookupHost(context.Background(), host)
+		require.NoError(t, err)
+		assert.Equal(t, 1, dns.count(host), host)
+	}
+
+	clock.advance(time.Second)
+	_, err := c.LookupHost(context.Background(), "low.example")
+	require.NoError(t, err)
+	assert.Equal(t, 2, dns.count("low.example"))
+
+	clock.advance(55 * time.Second)
+	for _, host := range []string{"high.example", "hosts.example"} {
+		_, err := c.LookupHost(context.Background(), host)
+		require.NoError(t, err)
+		assert.Equal(t, 2, dns.count(host), host)
+	}
+}
+
+func TestLookupHostServesStaleAnswers(t *testing.T) {
+	c, dns, clock := newTestCache(t, func(cfg *Config) {
+		cfg.MaxStale = time.Minute
+		cfg.NegativeTTL = 10 * time.Second
+	})
+	dns.set("collector.example", fakeAnswer{addrs: []string{"10.0.0.1"}, ttl: 30 * time.Second})
+	_, err := c.LookupHost(context.Background(), "collector.example")
+	require.NoError(t, err)
+
+	// DNS servers are down.
+	dns.set("collector.example", fakeAnswer{err: errors.New("i/o timeout")})
+	clock.advance(30 * time.Second)
+	addrs, err := c.LookupHost(context.Background(), "collector.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+	c.wg.Wait()
+	assert.Equal(t, 2, dns.count("collector.example"))
+
+	// Failed refreshes are only retried after the negative TTL.
+	clock.advance(5 * time.Second)
+	addrs, err = c.LookupHost(context.Background(), "collector.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+	c.wg.Wait()
+	assert.Equal(t, 2, dns.count("collector.example"))
+
+	// The answer is dropped after max_stale.
+	clock.advance(time.Minute)
+	_, err = c.LookupHost(context.Background(), "collector.example")
+	assert.EqualError(t, err, "i/o timeout")
+}
+
+func TestLookupHostNegativeCache(t *testing.T) {
+	c, dns, clock := newTestCache(t, func(cfg *Config) {
+		cfg.NegativeTTL = 10 * time.Second
+		cfg.MaxNegativeEntries = 2
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := c.LookupHost(context.Background(), "missing.example")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 1, dns.count("missing.example"))
+
+	clock.advance(10 * time.Second)
+	_, err := c.LookupHost(context.Background(), "missing.example")
+	assert.Error(t, err)
+	assert.Equal(t, 2, dns.count("missing.example"))
+
+	// The failed lookup expiring first is evicted past the limit.
+	clock.advance(time.Second)
+	_, err = c.LookupHost(context.Background(), "missing2.example")
+	assert.Error(t, err)
+	clock.advance(time.Second)
+	_, err = c.LookupHost(context.Background(), "missing3.example")
+	assert.Error(t, err)
+	assert.Len(t, c.entries, 2)
+	assert.Equal(t, 2, c.negatives)
+	_, err = c.LookupHost(context.Background(), "missing.example")
+	assert.Error(t, err)
+	assert.Equal(t, 3, dns.count("missing.example"))
+
+	// A successful lookup replaces the failed one.
+	dns.set("missing3.example", fakeAnswer{addrs: []string{"10.0.0.1"}, ttl: time.Minute})
+	clock.advance(10 * time.Second)
+	addrs, err := c.LookupHost(context.Background(), "missing3.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+	assert.Equal(t, 1, c.negatives)
+}
+
+func TestLookupHostWithoutNegativeCache(t *testing.T) {
+	c, dns, _ := newTestCache(t, func(cfg *Config) { cfg.NegativeTTL = 0 })
+	for i := 0; i < 2; i++ {
+		_, err := c.LookupHost(context.Background(), "missing.example")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, dns.count("missing.example"))
+	assert.Empty(t, c.entries)
+}
+
+func TestLookupHostDeduplicatesLookups(t *testing.T) {
+	c, dns, _ := newTestCache(t, nil)
+	dns.set("collector.example", fakeAnswer{addrs: []string{"10.0.0.1"}, ttl: time.Minute})
+	dns.block = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addrs, err := c.LookupHost(context.Background(), "collector.example")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"10.0.0.1"}, addrs)
+		}()
+	}
+	require.Eventually(t, func() bool { return dns.count("collector.example") == 1 }, time.Second, time.Millisecond)
+	close(dns.block)
+	wg.Wait()
+	assert.Equal(t, 1, dns.count("collector.example"))
+}
+
+func TestLookupHostCanceled(t *testing.T) {
+	c, dns, _ := newTestCache(t, nil)
+	dns.block = make(chan struct{})
+	defer close(dns.block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.LookupHost(ctx, "collector.example")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLookupHostIP(t *testing.T) {
+	c, dns, _ := newTestCache(t, nil)
+	for _, ip := range []string{"10.0.0.1", "::1"} {
+		addrs, err := c.LookupHost(context.Background(), ip)
+		require.NoError(t, err)
+		assert.Equal(t, []string{ip}, addrs)
+	}
+	assert.Empty(t, dns.lookups)
+}
+
+func TestJitter(t *testing.T) {
+	c, _, _ := newTestCache(t, func(cfg *Config) { cfg.Jitter = 0.2 })
+	for i := 0; i < 100; i++ {
+		d := c.jitter(10 * time.Second)
+		assert.True(t, d > 8*time.Second && d <= 10*time.Second, d)
+	}
+}
+
+func TestDialContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	c, dns, _ := newTestCache(t, nil)
+	dns.set("collector.example", fakeAnswer{addrs: []string{"::1", "127.0.0.1"}, ttl: time.Minute})
+
+	client := &http.Client{Transport: &http.Transport{DialContext: c.DialContext}}
+	resp, err := client.Get("http://collector.example:" + port)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	_, err = c.DialContext(context.Background(), "tcp6", "collector.example:"+port)
+	assert.Error(t, err)
+	dns.set("v4.example", fakeAnswer{addrs: []string{"127.0.0.1"}, ttl: time.Minute})
+	_, err = c.DialContext(context.Background(), "tcp6", "v4.example:"+port)
+	assert.EqualError(t, err, "no address of v4.example matches network tcp6")
+	_, err = c.DialContext(context.Background(), "tcp", "missing.example:"+port)
+	assert.Error(t, err)
+	_, err = c.DialContext(context.Background(), "tcp", "collector.example")
+	assert.Error(t, err)
+}
+
+type testHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *testHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type nopExtension struct{}
+
+func (nopExtension) Start(context.Context, component.Host) error { return nil }
+
+func (nopExtension) Shutdown(context.Context) error { return nil }
+
+func TestGetResolver(t *testing.T) {
+	c, _, _ := newTestCache(t, nil)
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID(typeStr): c,
+			config.NewComponentID("other"):  nopExtension{},
+		},
+	}
+
+	resolver, err := GetResolver(host, config.NewComponentID(typeStr))
+	require.NoError(t, err)
+	assert.Equal(t, c, resolver)
+
+	_, err = GetResolver(host, config.NewComponentID("other"))
+	assert.EqualError(t, err, `extension "other" is not a DNS cache`)
+	_, err = GetResolver(host, config.NewComponentIDWithName(typeStr, "missing"))
+	assert.EqualError(t, err, `extension "dnscache/missing" not found`)
+}
+
+func TestConfigureClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	c, dns, clock := newTestCache(t, nil)
+	dns.set("collector.example", fakeAnswer{addrs: []string{"127.0.0.1"}, ttl: time.Minute})
+	id := config.NewComponentID(typeStr)
+	host := &testHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: c},
+	}
+
+	settings := confighttp.HTTPClientSettings{Endpoint: "http://collector.example:" + port}
+	client, err := settings.ToClient(host.GetExtensions())
+	require.NoError(t, err)
+	require.NoError(t, ConfigureClient(host, &id, client))
+	get := func() error {
+		resp, err := client.Get(settings.Endpoint)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	require.NoError(t, get())
+
+	// New connections keep using the cached addresses once lookups fail.
+	dns.set("collector.example", fakeAnswer{err: errors.New("server misbehaving")})
+	clock.advance(2 * time.Minute)
+	client.CloseIdleConnections()
+	require.NoError(t, get())
+	assert.Eventually(t, func() bool { return dns.count("collector.example") == 2 }, time.Second, 10*time.Millisecond)
+	client.CloseIdleConnections()
+	require.NoError(t, get())
+
+	// A transport wrapping the HTTP transport can't be configured.
+	settings.Headers = map[string]string{"X-Scope-OrgID": "tenant"}
+	client, err = settings.ToClient(host.GetExtensions())
+	require.NoError(t, err)
+	assert.Error(t, ConfigureClient(host, &id, client))
+}
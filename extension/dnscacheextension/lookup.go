@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscacheextension
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// lookupHost looks up host with the pure Go resolver, recording the TTL of the
+// DNS answers it receives. Go resolves hostnames as usual, with the hosts file
+// and the search domains of resolv.conf, but doesn't expose TTLs.
+func lookupHost(ctx context.Context, host string) ([]string, time.Duration, error) {
+	rec := &ttlRecorder{}
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, network, address)
+			if err != nil {
+				return nil, err
+			}
+			if pc, ok := conn.(net.PacketConn); ok {
+				return &packetConn{Conn: conn, pc: pc, rec: rec}, nil
+			}
+			return &streamConn{Conn: conn, rec: rec}, nil
+		},
+	}
+	addrs, err := resolver.LookupHost(ctx, host)
+	return addrs, rec.ttl(), err
+}
+
+// ttlRecorder records the lowest TTL of the address records of DNS answers.
+type ttlRecorder struct {
+	mu    sync.Mutex
+	min   uint32
+	found bool
+}
+
+// ttl returns the lowest recorded TTL, or -1 if none was recorded.
+func (r *ttlRecorder) ttl() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.found {
+		return -1
+	}
+	return time.Duration(r.min) * time.Second
+}
+
+// record parses a DNS message and records the TTLs of its answers.
+func (r *ttlRecorder) record(msg []byte) {
+	var p dnsmessage.Parser
+	h, err := p.Start(msg)
+	if err != nil || !h.Response || h.RCode != dnsmessage.RCodeSuccess {
+		return
+	}
+	if err = p.SkipAllQuestions(); err != nil {
+		return
+	}
+	for {
+		ah, err := p.AnswerHeader()
+		if err != nil {
+			return
+		}
+		switch ah.Type {
+		case dnsmessage.TypeA, dnsmessage.TypeAAAA, dnsmessage.TypeCNAME:
+			r.mu.Lock()
+			if !r.found || ah.TTL < r.min {
+				r.min = ah.TTL
+				r.found = true
+			}
+			r.mu.Unlock()
+		}
+		if err = p.SkipAnswer(); err != nil {
+			return
+		}
+	}
+}
+
+// packetConn records the TTLs of the DNS messages read from a UDP connection.
+// It implements net.PacketConn for the Go resolver to use it as such.
+type packetConn struct {
+	net.Conn
+	pc  net.PacketConn
+	rec *ttlRecorder
+}
+
+func (c *packetConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err == nil {
+		c.rec.record(b[:n])
+	}
+	return n, err
+}
+
+func (c *packetConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, addr, err := c.pc.ReadFrom(b)
+	if err == nil {
+		c.rec.record(b[:n])
+	}
+	return n, addr, err
+}
+
+func (c *packetConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	return c.pc.WriteTo(b, addr)
+}
+
+// streamConn records the TTLs of the length-prefixed DNS messages read from a
+// TCP connection.
+type streamConn struct {
+	net.Conn
+	rec *ttlRecorder
+	buf []byte
+}
+
+func (c *streamConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.buf = append(c.buf, b[:n]...)
+	for len(c.buf) >= 2 {
+		l := int(binary.BigEndian.Uint16(c.buf))
+		if len(c.buf) < 2+l {
+			break
+		}
+		c.rec.record(c.buf[2 : 2+l])
+		c.buf = c.buf[2+l:]
+	}
+	return n, err
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscacheextension
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func buildAnswer(t *testing.T, rcode dnsmessage.RCode, ttls ...uint32) []byte {
+	name := dnsmessage.MustNewName("collector.example.")
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, RCode: rcode})
+	require.NoError(t, b.StartQuestions())
+	require.NoError(t, b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}))
+	require.NoError(t, b.StartAnswers())
+	for i, ttl := range ttls {
+		require.NoError(t, b.AResource(
+			dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: ttl},
+			dnsmessage.AResource{A: [4]byte{10, 0, 0, byte(i)}}))
+	}
+	msg, err := b.Finish()
+	require.NoError(t, err)
+	return msg
+}
+
+func TestTTLRecorder(t *testing.T) {
+	rec := &ttlRecorder{}
+	assert.Equal(t, time.Duration(-1), rec.ttl())
+
+	rec.record(buildAnswer(t, dnsmessage.RCodeNameError))
+	rec.record([]byte("garbage"))
+	assert.Equal(t, time.Duration(-1), rec.ttl())
+
+	rec.record(buildAnswer(t, dnsmessage.RCodeSuccess, 60, 30))
+	assert.Equal(t, 30*time.Second, rec.ttl())
+	rec.record(buildAnswer(t, dnsmessage.RCodeSuccess, 45))
+	assert.Equal(t, 30*time.Second, rec.ttl())
+	rec.record(buildAnswer(t, dnsmessage.RCodeSuccess, 5))
+	assert.Equal(t, 5*time.Second, rec.ttl())
+}
+
+func TestStreamConn(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	rec := &ttlRecorder{}
+	conn := &streamConn{Conn: client, rec: rec}
+
+	msg := buildAnswer(t, dnsmessage.RCodeSuccess, 20)
+	go func() {
+		frame := make([]byte, 2+len(msg))
+		binary.BigEndian.PutUint16(frame, uint16(len(msg)))
+		copy(frame[2:], msg)
+		// Write the frame in pieces, as TCP may deliver it.
+		for _, piece := range [][]byte{frame[:1], frame[1:10], frame[10:]} {
+			_, _ = server.Write(piece)
+		}
+		server.Close()
+	}()
+
+	b, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Len(t, b, 2+len(msg))
+	assert.Equal(t, 20*time.Second, rec.ttl())
+}
+
+func TestPacketConn(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+	udp, err := net.Dial("udp", server.LocalAddr().String())
+	require.NoError(t, err)
+	defer udp.Close()
+
+	rec := &ttlRecorder{}
+	conn := &packetConn{Conn: udp, pc: udp.(net.PacketConn), rec: rec}
+	_, err = conn.Write([]byte("query"))
+	require.NoError(t, err)
+	_, addr, err := server.ReadFrom(make([]byte, 512))
+	require.NoError(t, err)
+	_, err = server.WriteTo(buildAnswer(t, dnsmessage.RCodeSuccess, 15), addr)
+	require.NoError(t, err)
+
+	_, err = conn.Read(make([]byte, 512))
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Second, rec.ttl())
+}
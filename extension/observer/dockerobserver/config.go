@@ -54,4 +54,11 @@ type Config struct {
 	// through the docker event listener example: cache_sync_interval: "20m"
 	// Default: "60m"
 	CacheSyncInterval time.Duration `mapstructure:"cache_sync_interval"`
+
+	// If true, the endpoint is the Docker-compatible API socket of Podman, and the
+	// container inspections are interpreted as Podman returns them: the published
+	// ports are not always exposed, the host IP of the bindings to all the interfaces
+	// is empty, and rootless containers have no network IP address, only published ports.
+	// Default: false
+	PodmanCompatibility bool `mapstructure:"podman_compatibility"`
 }
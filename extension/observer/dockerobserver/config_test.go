@@ -37,7 +37,7 @@ func TestLoadConfig(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
 
-	require.Len(t, cfg.Extensions, 6)
+	require.Len(t, cfg.Extensions, 7)
 
 	ext0 := cfg.Extensions[config.NewComponentID(typeStr)]
 	assert.Equal(t, factory.CreateDefaultConfig(), ext0)
@@ -55,4 +55,15 @@ func TestLoadConfig(t *testing.T) {
 			IgnoreNonHostBindings: true,
 		},
 		ext1)
+
+	ext2 := cfg.Extensions[config.NewComponentIDWithName(typeStr, "podman_compatibility")]
+	assert.Equal(t,
+		&Config{
+			Endpoint:            "unix:///run/podman/podman.sock",
+			ExtensionSettings:   config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "podman_compatibility")),
+			CacheSyncInterval:   60 * time.Minute,
+			Timeout:             5 * time.Second,
+			PodmanCompatibility: true,
+		},
+		ext2)
 }
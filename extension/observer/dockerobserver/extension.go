@@ -56,6 +56,12 @@ func (d *dockerObserver) endpointsForContainer(c *dtypes.ContainerJSON) []observ
 	for k := range c.Config.ExposedPorts {
 		knownPorts[k] = true
 	}
+	if d.config.PodmanCompatibility && c.NetworkSettings != nil {
+		// Podman does not add the published ports to the exposed ones.
+		for k := range c.NetworkSettings.Ports {
+			knownPorts[k] = true
+		}
+	}
 
 	// iterate over exposed ports and try to create endpoints
 	for portObj := range knownPorts {
@@ -85,6 +91,10 @@ func (d *dockerObserver) endpointForPort(portObj nat.Port, c *dtypes.ContainerJS
 	proto := portObj.Proto()
 
 	mappedPort, mappedIP := findHostMappedPort(c, portObj)
+	if d.config.PodmanCompatibility && mappedPort != 0 && mappedIP == "" {
+		// Podman leaves the host IP empty for the bindings to all interfaces.
+		mappedIP = "0.0.0.0"
+	}
 	if d.config.IgnoreNonHostBindings && mappedPort == 0 && mappedIP == "" {
 		return nil
 	}
@@ -106,6 +116,7 @@ func (d *dockerObserver) endpointForPort(portObj nat.Port, c *dtypes.ContainerJS
 		Labels:      c.Config.Labels,
 	}
 	var target string
+	useHostBindings := d.config.UseHostBindings
 
 	// Set our target and hostname based on config settings
 	if d.config.UseHostnameIfPresent && c.Config.Hostname != "" {
@@ -120,16 +131,22 @@ func (d *dockerObserver) endpointForPort(portObj nat.Port, c *dtypes.ContainerJS
 			break
 		}
 
+		// Rootless Podman containers have no IP address, they are only
+		// reachable through their host bindings.
+		if target == "" && d.config.PodmanCompatibility && mappedPort != 0 {
+			useHostBindings = true
+		}
+
 		// If we still haven't gotten a host at this point and we are using
 		// host bindings, just make it localhost.
-		if target == "" && d.config.UseHostBindings {
+		if target == "" && useHostBindings {
 			target = "127.0.0.1"
 			details.Host = "127.0.0.1"
 		}
 	}
 
 	// If we are using HostBindings & port and IP are set, use those
-	if d.config.UseHostBindings && mappedPort != 0 && mappedIP != "" {
+	if useHostBindings && mappedPort != 0 && mappedIP != "" {
 		details.Host = mappedIP
 		target = mappedIP
 		details.Port = mappedPort
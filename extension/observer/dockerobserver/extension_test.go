@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path"
+	"sort"
 	"testing"
 
 	dtypes "github.com/docker/docker/api/types"
@@ -32,7 +33,11 @@ import (
 )
 
 func containerJSON(t *testing.T) dtypes.ContainerJSON {
-	containerRaw, err := ioutil.ReadFile(path.Join(".", "testdata", "container.json"))
+	return loadContainerJSON(t, "container.json")
+}
+
+func loadContainerJSON(t *testing.T, file string) dtypes.ContainerJSON {
+	containerRaw, err := ioutil.ReadFile(path.Join(".", "testdata", file))
 	require.NoError(t, err)
 
 	var container dtypes.ContainerJSON
@@ -292,3 +297,67 @@ func TestCollectEndpointsIgnoreNonHostBindings(t *testing.T) {
 
 	require.Equal(t, cEndpoints, want)
 }
+
+func TestCollectEndpointsPodmanCompatibility(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	extPodman := cfg.Extensions[config.NewComponentIDWithName(typeStr, "podman_compatibility")]
+
+	ext, err := newObserver(zap.NewNop(), extPodman.(*Config))
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obvs := ext.(*dockerObserver)
+
+	// A rootless Podman container, without IP address and with the port 9090
+	// published but not exposed.
+	c := loadContainerJSON(t, "podman_container.json")
+	cEndpoints := obvs.endpointsForContainer(&c)
+	sort.Slice(cEndpoints, func(i, j int) bool { return cEndpoints[i].ID < cEndpoints[j].ID })
+
+	details := func(port, alternatePort uint16) *observer.Container {
+		return &observer.Container{
+			Name:        "/agitated_wu",
+			Image:       "nginx",
+			Command:     "nginx -g daemon off;",
+			ContainerID: "babc5a6d7af2a48e7f52e1da26047024dcf98b737e754c9c3459bb84d1e4f80c",
+			Transport:   observer.ProtocolTCP,
+			Labels: map[string]string{
+				"hello":      "world",
+				"maintainer": "NGINX Docker Maintainers",
+				"mstumpf":    "",
+			},
+			Port:          port,
+			AlternatePort: alternatePort,
+			Host:          "127.0.0.1",
+		}
+	}
+	want := []observer.Endpoint{
+		{
+			ID:      "babc5a6d7af2a48e7f52e1da26047024dcf98b737e754c9c3459bb84d1e4f80c:8080",
+			Target:  "127.0.0.1",
+			Details: details(8080, 80),
+		},
+		{
+			ID:      "babc5a6d7af2a48e7f52e1da26047024dcf98b737e754c9c3459bb84d1e4f80c:9090",
+			Target:  "127.0.0.1",
+			Details: details(9090, 9090),
+		},
+	}
+
+	require.Equal(t, want, cEndpoints)
+
+	// Without compatibility, the bindings are not used.
+	obvs.config.PodmanCompatibility = false
+	cEndpoints = obvs.endpointsForContainer(&c)
+	require.Len(t, cEndpoints, 1)
+	assert.Equal(t, "", cEndpoints[0].Target)
+}
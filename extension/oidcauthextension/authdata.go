@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import "context"
+
+type authDataKey struct{}
+
+// AuthData is the identity of a client authenticated by the OIDC
+// authenticator, added to the context of its requests.
+type AuthData struct {
+	// Subject is the value of the username claim, or the subject of the
+	// token.
+	Subject string
+	// Groups are the values of the groups claim.
+	Groups []string
+	// Scopes are the scopes of the token.
+	Scopes []string
+	// Attributes are the values of the claims mapped by client_attributes.
+	Attributes map[string]string
+}
+
+func newContextWithAuthData(ctx context.Context, data *AuthData) context.Context {
+	return context.WithValue(ctx, authDataKey{}, data)
+}
+
+// AuthDataFromContext returns the AuthData of the authenticated client of a
+// request, if any.
+func AuthDataFromContext(ctx context.Context) (*AuthData, bool) {
+	data, ok := ctx.Value(authDataKey{}).(*AuthData)
+	return data, ok
+}
@@ -14,7 +14,11 @@
 
 package oidcauthextension
 
-import "go.opentelemetry.io/collector/config"
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
 
 // Config has the configuration for the OIDC Authenticator extension.
 type Config struct {
@@ -43,4 +47,35 @@ type Config struct {
 	// The claim that holds the subject's group membership information.
 	// Optional.
 	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// RequiredScopes are the scopes that tokens must all have, in their
+	// 'scope' or 'scp' claim.
+	// Optional.
+	RequiredScopes []string `mapstructure:"required_scopes"`
+
+	// RequiredClaims are the claims that tokens must have, with the given
+	// values. A claim holding a list must contain the value.
+	// Optional.
+	RequiredClaims map[string]string `mapstructure:"required_claims"`
+
+	// ClientAttributes maps the names of attributes of the AuthData of
+	// authenticated clients to the claims they are read from.
+	// Optional.
+	ClientAttributes map[string]string `mapstructure:"client_attributes"`
+
+	// ClockSkew is the tolerance for the clock differences with the issuer
+	// when checking the expiry and not before times of tokens.
+	// Optional, default value: 1m.
+	ClockSkew time.Duration `mapstructure:"clock_skew"`
+
+	// JWKSRefreshInterval is the interval at which the keys of the issuer
+	// are refreshed. The keys are only refreshed when tokens are signed by
+	// unknown keys if zero.
+	// Optional, default value: 1h.
+	JWKSRefreshInterval time.Duration `mapstructure:"jwks_refresh_interval"`
+
+	// JWKSMinRefreshInterval is the minimum interval between the refreshes
+	// of the keys caused by tokens signed by unknown keys.
+	// Optional, default value: 1m.
+	JWKSMinRefreshInterval time.Duration `mapstructure:"jwks_min_refresh_interval"`
 }
@@ -42,6 +42,7 @@ type oidcExtension struct {
 
 	provider *oidc.Provider
 	verifier *oidc.IDTokenVerifier
+	keySet   *remoteKeySet
 
 	logger *zap.Logger
 }
@@ -57,6 +58,11 @@ var (
 	errUsernameNotString                 = errors.New("the username returned by the OIDC provider isn't a regular string")
 	errGroupsClaimNotFound               = errors.New("groups claim from the OIDC configuration not found on the token returned by the OIDC provider")
 	errNotAuthenticated                  = errors.New("authentication didn't succeed")
+	errTokenExpired                      = errors.New("the token is expired")
+	errTokenNotYetValid                  = errors.New("the token isn't valid yet")
+	errMissingScope                      = errors.New("the token doesn't have the required scopes")
+	errClaimMismatch                     = errors.New("a required claim of the token doesn't have the required value")
+	errNegativeDuration                  = errors.New("clock_skew, jwks_refresh_interval and jwks_min_refresh_interval can't be negative")
 )
 
 func newExtension(cfg *Config, logger *zap.Logger) (*oidcExtension, error) {
@@ -67,6 +73,10 @@ func newExtension(cfg *Config, logger *zap.Logger) (*oidcExtension, error) {
 		return nil, errNoIssuerURL
 	}
 
+	if cfg.ClockSkew < 0 || cfg.JWKSRefreshInterval < 0 || cfg.JWKSMinRefreshInterval < 0 {
+		return nil, errNegativeDuration
+	}
+
 	if cfg.Attribute == "" {
 		cfg.Attribute = defaultAttribute
 	}
@@ -80,14 +90,33 @@ func newExtension(cfg *Config, logger *zap.Logger) (*oidcExtension, error) {
 }
 
 func (e *oidcExtension) Start(ctx context.Context, _ component.Host) error {
-	provider, err := getProviderForConfig(e.cfg)
+	client, err := getHTTPClientForConfig(e.cfg)
+	if err != nil {
+		return err
+	}
+
+	provider, err := getProviderForConfig(e.cfg, client)
 	if err != nil {
 		return fmt.Errorf("failed to get configuration from the auth server: %w", err)
 	}
 	e.provider = provider
 
-	e.verifier = e.provider.Verifier(&oidc.Config{
-		ClientID: e.cfg.Audience,
+	var discovery struct {
+		JWKSURL string `json:"jwks_uri"`
+	}
+	if err = provider.Claims(&discovery); err != nil {
+		return fmt.Errorf("failed to get the JWKS URL of the auth server: %w", err)
+	}
+	e.keySet = newRemoteKeySet(discovery.JWKSURL, client, e.cfg.JWKSRefreshInterval, e.cfg.JWKSMinRefreshInterval, e.logger)
+	if err = e.keySet.start(ctx); err != nil {
+		return err
+	}
+
+	// The expiry is checked along with the not before time, with the
+	// configured clock skew.
+	e.verifier = oidc.NewVerifier(e.cfg.IssuerURL, e.keySet, &oidc.Config{
+		ClientID:        e.cfg.Audience,
+		SkipExpiryCheck: true,
 	})
 
 	return nil
@@ -95,6 +124,9 @@ func (e *oidcExtension) Start(ctx context.Context, _ component.Host) error {
 
 // Shutdown is invoked during service shutdown.
 func (e *oidcExtension) Shutdown(context.Context) error {
+	if e.keySet != nil {
+		e.keySet.shutdown()
+	}
 	return nil
 }
 
@@ -127,19 +159,35 @@ func (e *oidcExtension) Authenticate(ctx context.Context, headers map[string][]s
 		return ctx, errFailedToObtainClaimsFromToken
 	}
 
-	_, err = getSubjectFromClaims(claims, e.cfg.UsernameClaim, idToken.Subject)
+	if err = checkTokenTimes(idToken.Expiry, claims, time.Now(), e.cfg.ClockSkew); err != nil {
+		return ctx, err
+	}
+
+	scopes := getScopesFromClaims(claims)
+	if err = checkRequiredScopes(scopes, e.cfg.RequiredScopes); err != nil {
+		return ctx, err
+	}
+
+	if err = checkRequiredClaims(claims, e.cfg.RequiredClaims); err != nil {
+		return ctx, err
+	}
+
+	subject, err := getSubjectFromClaims(claims, e.cfg.UsernameClaim, idToken.Subject)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get subject from claims in the token: %w", err)
 	}
 
-	_, err = getGroupsFromClaims(claims, e.cfg.GroupsClaim)
+	groups, err := getGroupsFromClaims(claims, e.cfg.GroupsClaim)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get groups from claims in the token: %w", err)
 	}
 
-	// TODO: once the design for #2734 is determined, we will probably need to add the auth data to the context
-	// https://github.com/open-telemetry/opentelemetry-collector/issues/2734
-	return ctx, nil
+	return newContextWithAuthData(ctx, &AuthData{
+		Subject:    subject,
+		Groups:     groups,
+		Scopes:     scopes,
+		Attributes: getAttributesFromClaims(claims, e.cfg.ClientAttributes),
+	}), nil
 }
 
 // GRPCUnaryServerInterceptor is a helper method to provide a gRPC-compatible UnaryInterceptor, typically calling the authenticator's Authenticate method.
@@ -195,7 +243,92 @@ func getGroupsFromClaims(claims map[string]interface{}, groupsClaim string) ([]s
 	return []string{}, nil
 }
 
-func getProviderForConfig(config *Config) (*oidc.Provider, error) {
+// checkTokenTimes checks that the token isn't expired and that its not before
+// time, if any, is past, tolerating the given clock skew.
+func checkTokenTimes(expiry time.Time, claims map[string]interface{}, now time.Time, skew time.Duration) error {
+	if expiry.IsZero() || now.Add(-skew).After(expiry) {
+		return errTokenExpired
+	}
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if now.Add(skew).Before(time.Unix(int64(nbf), 0)) {
+			return errTokenNotYetValid
+		}
+	}
+	return nil
+}
+
+// getScopesFromClaims returns the scopes of the 'scope' claim, a space
+// separated list, or of the 'scp' claim, a list or a space separated list.
+func getScopesFromClaims(claims map[string]interface{}) []string {
+	for _, claim := range []string{"scope", "scp"} {
+		switch v := claims[claim].(type) {
+		case string:
+			return strings.Fields(v)
+		case []interface{}:
+			scopes := make([]string, 0, len(v))
+			for i := range v {
+				scopes = append(scopes, fmt.Sprintf("%v", v[i]))
+			}
+			return scopes
+		}
+	}
+	return nil
+}
+
+func checkRequiredScopes(scopes []string, required []string) error {
+	for _, r := range required {
+		found := false
+		for _, scope := range scopes {
+			if scope == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %q", errMissingScope, r)
+		}
+	}
+	return nil
+}
+
+func checkRequiredClaims(claims map[string]interface{}, required map[string]string) error {
+	for claim, value := range required {
+		matches := false
+		switch v := claims[claim].(type) {
+		case nil:
+		case []interface{}:
+			for i := range v {
+				if fmt.Sprintf("%v", v[i]) == value {
+					matches = true
+					break
+				}
+			}
+		default:
+			matches = fmt.Sprintf("%v", v) == value
+		}
+		if !matches {
+			return fmt.Errorf("%w: %q", errClaimMismatch, claim)
+		}
+	}
+	return nil
+}
+
+// getAttributesFromClaims returns the attributes read from the claims of
+// the token, leaving out the missing claims.
+func getAttributesFromClaims(claims map[string]interface{}, attributeClaims map[string]string) map[string]string {
+	if len(attributeClaims) == 0 {
+		return nil
+	}
+	attributes := make(map[string]string, len(attributeClaims))
+	for attribute, claim := range attributeClaims {
+		if v, ok := claims[claim]; ok && v != nil {
+			attributes[attribute] = fmt.Sprintf("%v", v)
+		}
+	}
+	return attributes
+}
+
+func getHTTPClientForConfig(config *Config) (*http.Client, error) {
 	t := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -222,10 +355,13 @@ func getProviderForConfig(config *Config) (*oidc.Provider, error) {
 		t.TLSClientConfig.RootCAs.AddCert(cert)
 	}
 
-	client := &http.Client{
+	return &http.Client{
 		Timeout:   5 * time.Second,
 		Transport: t,
-	}
+	}, nil
+}
+
+func getProviderForConfig(config *Config, client *http.Client) (*oidc.Provider, error) {
 	oidcContext := oidc.ClientContext(context.Background(), client)
 	return oidc.NewProvider(oidcContext, config.IssuerURL)
 }
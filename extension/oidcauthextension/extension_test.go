@@ -120,7 +120,9 @@ func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	}
 
 	// test
-	provider, err := getProviderForConfig(config)
+	client, err := getHTTPClientForConfig(config)
+	require.NoError(t, err)
+	provider, err := getProviderForConfig(config, client)
 
 	// verify
 	assert.NoError(t, err)
@@ -193,11 +195,11 @@ func TestOIDCFailedToLoadIssuerCAFromPathInvalidContent(t *testing.T) {
 	}
 
 	// test
-	provider, err := getProviderForConfig(config) // cross test with getIssuerCACertFromPath
+	client, err := getHTTPClientForConfig(config) // cross test with getIssuerCACertFromPath
 
 	// verify
 	assert.Error(t, err)
-	assert.Nil(t, provider)
+	assert.Nil(t, client)
 }
 
 func TestOIDCInvalidAuthHeader(t *testing.T) {
@@ -525,3 +527,197 @@ type mockServerStream struct {
 func (m *mockServerStream) Context() context.Context {
 	return m.ctx
 }
+
+func startTestExtension(t *testing.T, oidcServer *oidcServer, modify func(*Config)) *oidcExtension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.IssuerURL = oidcServer.URL
+	cfg.Audience = "unit-test"
+	modify(cfg)
+	p, err := newExtension(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, p.Shutdown(context.Background())) })
+	return p
+}
+
+func authenticate(t *testing.T, p *oidcExtension, oidcServer *oidcServer, claims map[string]interface{}) (context.Context, error) {
+	payload := map[string]interface{}{
+		"sub": "jdoe@example.com",
+		"iss": oidcServer.URL,
+		"aud": "unit-test",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		payload[k] = v
+	}
+	jsonPayload, err := json.Marshal(payload)
+	require.NoError(t, err)
+	token, err := oidcServer.token(jsonPayload)
+	require.NoError(t, err)
+	return p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+}
+
+func TestOIDCAuthData(t *testing.T) {
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	p := startTestExtension(t, oidcServer, func(cfg *Config) {
+		cfg.UsernameClaim = "name"
+		cfg.GroupsClaim = "memberships"
+		cfg.ClientAttributes = map[string]string{"tenant": "tenant_id", "service": "azp", "missing": "missing"}
+	})
+
+	ctx, err := authenticate(t, p, oidcServer, map[string]interface{}{
+		"name":        "jdoe",
+		"memberships": []string{"department-1"},
+		"scope":       "traces:write metrics:write",
+		"tenant_id":   "acme",
+		"azp":         "checkout",
+	})
+	require.NoError(t, err)
+
+	data, ok := AuthDataFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, &AuthData{
+		Subject:    "jdoe",
+		Groups:     []string{"department-1"},
+		Scopes:     []string{"traces:write", "metrics:write"},
+		Attributes: map[string]string{"tenant": "acme", "service": "checkout"},
+	}, data)
+}
+
+func TestOIDCRequiredScopesAndClaims(t *testing.T) {
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	p := startTestExtension(t, oidcServer, func(cfg *Config) {
+		cfg.RequiredScopes = []string{"traces:write"}
+		cfg.RequiredClaims = map[string]string{"tenant_id": "acme", "roles": "ingest"}
+	})
+
+	for _, tt := range []struct {
+		casename string
+		claims   map[string]interface{}
+		err      string
+	}{
+		{
+			casename: "scope claim",
+			claims:   map[string]interface{}{"scope": "openid traces:write", "tenant_id": "acme", "roles": []string{"read", "ingest"}},
+		},
+		{
+			casename: "scp claim",
+			claims:   map[string]interface{}{"scp": []string{"traces:write"}, "tenant_id": "acme", "roles": "ingest"},
+		},
+		{
+			casename: "missing scope",
+			claims:   map[string]interface{}{"scope": "openid metrics:write", "tenant_id": "acme", "roles": "ingest"},
+			err:      `the token doesn't have the required scopes: "traces:write"`,
+		},
+		{
+			casename: "no scopes",
+			claims:   map[string]interface{}{"tenant_id": "acme", "roles": "ingest"},
+			err:      `the token doesn't have the required scopes: "traces:write"`,
+		},
+		{
+			casename: "claim mismatch",
+			claims:   map[string]interface{}{"scope": "traces:write", "tenant_id": "other", "roles": "ingest"},
+			err:      `a required claim of the token doesn't have the required value: "tenant_id"`,
+		},
+		{
+			casename: "list claim mismatch",
+			claims:   map[string]interface{}{"scope": "traces:write", "tenant_id": "acme", "roles": []string{"read"}},
+			err:      `a required claim of the token doesn't have the required value: "roles"`,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			_, err := authenticate(t, p, oidcServer, tt.claims)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestOIDCClockSkew(t *testing.T) {
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	p := startTestExtension(t, oidcServer, func(*Config) {})
+
+	// Within the default clock skew.
+	_, err = authenticate(t, p, oidcServer, map[string]interface{}{
+		"exp": time.Now().Add(-30 * time.Second).Unix(),
+		"nbf": time.Now().Add(30 * time.Second).Unix(),
+	})
+	assert.NoError(t, err)
+
+	_, err = authenticate(t, p, oidcServer, map[string]interface{}{"exp": time.Now().Add(-2 * time.Minute).Unix()})
+	assert.Equal(t, errTokenExpired, err)
+
+	_, err = authenticate(t, p, oidcServer, map[string]interface{}{"nbf": time.Now().Add(2 * time.Minute).Unix()})
+	assert.Equal(t, errTokenNotYetValid, err)
+}
+
+func TestCheckTokenTimes(t *testing.T) {
+	now := time.Unix(1633089600, 0)
+	for _, tt := range []struct {
+		casename string
+		expiry   time.Time
+		claims   map[string]interface{}
+		skew     time.Duration
+		err      error
+	}{
+		{
+			casename: "valid",
+			expiry:   now.Add(time.Minute),
+			claims:   map[string]interface{}{"nbf": float64(now.Unix())},
+		},
+		{
+			casename: "no expiry",
+			err:      errTokenExpired,
+		},
+		{
+			casename: "expired",
+			expiry:   now.Add(-time.Second),
+			err:      errTokenExpired,
+		},
+		{
+			casename: "expired within skew",
+			expiry:   now.Add(-time.Second),
+			skew:     time.Minute,
+		},
+		{
+			casename: "not yet valid",
+			expiry:   now.Add(time.Hour),
+			claims:   map[string]interface{}{"nbf": float64(now.Add(time.Second).Unix())},
+			err:      errTokenNotYetValid,
+		},
+		{
+			casename: "not yet valid within skew",
+			expiry:   now.Add(time.Hour),
+			claims:   map[string]interface{}{"nbf": float64(now.Add(time.Second).Unix())},
+			skew:     time.Minute,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			assert.Equal(t, tt.err, checkTokenTimes(tt.expiry, tt.claims, now, tt.skew))
+		})
+	}
+}
+
+func TestNegativeDurations(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Audience = "collector"
+	cfg.IssuerURL = "https://auth.example.com"
+	cfg.ClockSkew = -time.Second
+	_, err := newExtension(cfg, zap.NewNop())
+	assert.Equal(t, errNegativeDuration, err)
+}
@@ -16,6 +16,7 @@ package oidcauthextension
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -26,7 +27,10 @@ const (
 	// The value of extension "type" in configuration.
 	typeStr = "oidc"
 
-	defaultAttribute = "authorization"
+	defaultAttribute              = "authorization"
+	defaultClockSkew              = time.Minute
+	defaultJWKSRefreshInterval    = time.Hour
+	defaultJWKSMinRefreshInterval = time.Minute
 )
 
 // NewFactory creates a factory for the OIDC Authenticator extension.
@@ -39,8 +43,11 @@ func NewFactory() component.ExtensionFactory {
 
 func createDefaultConfig() config.Extension {
 	return &Config{
-		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
-		Attribute:         defaultAttribute,
+		ExtensionSettings:      config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		Attribute:              defaultAttribute,
+		ClockSkew:              defaultClockSkew,
+		JWKSRefreshInterval:    defaultJWKSRefreshInterval,
+		JWKSMinRefreshInterval: defaultJWKSMinRefreshInterval,
 	}
 }
 
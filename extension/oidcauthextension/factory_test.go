@@ -27,8 +27,11 @@ import (
 func TestCreateDefaultConfig(t *testing.T) {
 	// prepare and test
 	expected := &Config{
-		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
-		Attribute:         defaultAttribute,
+		ExtensionSettings:      config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		Attribute:              defaultAttribute,
+		ClockSkew:              defaultClockSkew,
+		JWKSRefreshInterval:    defaultJWKSRefreshInterval,
+		JWKSMinRefreshInterval: defaultJWKSMinRefreshInterval,
 	}
 
 	// test
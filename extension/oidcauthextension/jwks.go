@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2"
+)
+
+var errNoMatchingKey = errors.New("failed to verify the token signature with the keys of the issuer")
+
+// remoteKeySet caches the JSON web keys of the issuer. The keys are refreshed
+// periodically, and when a token is signed by an unknown key, at most once per
+// minRefreshInterval so that tokens signed by random keys can't flood the
+// issuer with requests. The cached keys are kept when a refresh fails.
+type remoteKeySet struct {
+	jwksURL            string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	logger             *zap.Logger
+	now                func() time.Time
+
+	mu   sync.RWMutex
+	keys []jose.JSONWebKey
+
+	// refreshMu serializes the refreshes.
+	refreshMu   sync.Mutex
+	lastAttempt time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newRemoteKeySet(jwksURL string, client *http.Client, refreshInterval, minRefreshInterval time.Duration, logger *zap.Logger) *remoteKeySet {
+	return &remoteKeySet{
+		jwksURL:            jwksURL,
+		client:             client,
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: minRefreshInterval,
+		logger:             logger,
+		now:                time.Now,
+	}
+}
+
+// start fetches the keys and refreshes them periodically until stop is
+// called.
+func (ks *remoteKeySet) start(ctx context.Context) error {
+	ks.refreshMu.Lock()
+	err := ks.refresh(ctx)
+	ks.refreshMu.Unlock()
+	if err != nil {
+		return err
+	}
+	if ks.refreshInterval <= 0 {
+		return nil
+	}
+
+	ks.stop = make(chan struct{})
+	ks.done = make(chan struct{})
+	go func() {
+		defer close(ks.done)
+		ticker := time.NewTicker(ks.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ks.stop:
+				return
+			case <-ticker.C:
+				ks.refreshMu.Lock()
+				if err := ks.refresh(context.Background()); err != nil {
+					ks.logger.Warn("Failed to refresh the keys of the OIDC issuer, using the cached keys", zap.Error(err))
+				}
+				ks.refreshMu.Unlock()
+			}
+		}
+	}()
+	return nil
+}
+
+func (ks *remoteKeySet) shutdown() {
+	if ks.stop != nil {
+		close(ks.stop)
+		<-ks.done
+		ks.stop = nil
+	}
+}
+
+// VerifySignature verifies the signature of the token with the cached keys,
+// refreshing them if none matches.
+func (ks *remoteKeySet) VerifySignature(ctx context.Context, token string) ([]byte, error) {
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	var keyID string
+	if len(jws.Signatures) > 0 {
+		keyID = jws.Signatures[0].Header.KeyID
+	}
+
+	ks.mu.RLock()
+	keys := ks.keys
+	ks.mu.RUnlock()
+	if payload, ok := verifyWithKeys(jws, keyID, keys); ok {
+		return payload, nil
+	}
+
+	// The issuer may have rotated its keys.
+	refreshed, err := ks.refreshUnlessRecent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if refreshed {
+		ks.mu.RLock()
+		keys = ks.keys
+		ks.mu.RUnlock()
+		if payload, ok := verifyWithKeys(jws, keyID, keys); ok {
+			return payload, nil
+		}
+	}
+	return nil, errNoMatchingKey
+}
+
+// refreshUnlessRecent refreshes the keys unless they were refreshed less
+// than minRefreshInterval ago, and returns whether they were.
+func (ks *remoteKeySet) refreshUnlessRecent(ctx context.Context) (bool, error) {
+	ks.refreshMu.Lock()
+	defer ks.refreshMu.Unlock()
+	if ks.now().Sub(ks.lastAttempt) < ks.minRefreshInterval {
+		return false, nil
+	}
+	if err := ks.refresh(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// refresh fetches the keys of the issuer. refreshMu must be held.
+func (ks *remoteKeySet) refresh(ctx context.Context) error {
+	ks.lastAttempt = ks.now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the keys of the issuer: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch the keys of the issuer: %s returned %s", ks.jwksURL, resp.Status)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return fmt.Errorf("failed to decode the keys of the issuer: %w", err)
+	}
+
+	ks.mu.Lock()
+	ks.keys = keySet.Keys
+	ks.mu.Unlock()
+	return nil
+}
+
+// verifyWithKeys verifies the signature with the signing keys of the given
+// ID, or all of them if the token has no key ID.
+func verifyWithKeys(jws *jose.JSONWebSignature, keyID string, keys []jose.JSONWebKey) ([]byte, bool) {
+	for i := range keys {
+		key := &keys[i]
+		if key.Use == "enc" || (keyID != "" && key.KeyID != keyID) {
+			continue
+		}
+		if payload, err := jws.Verify(key); err == nil {
+			return payload, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestToken(t *testing.T, oidcServer *oidcServer) string {
+	payload, err := json.Marshal(map[string]interface{}{"sub": "jdoe@example.com"})
+	require.NoError(t, err)
+	token, err := oidcServer.token(payload)
+	require.NoError(t, err)
+	return token
+}
+
+func TestRemoteKeySetRotation(t *testing.T) {
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	ks := newRemoteKeySet(oidcServer.URL+"/.well-known/jwks.json", http.DefaultClient, 0, time.Minute, zap.NewNop())
+	now := time.Now()
+	ks.now = func() time.Time { return now }
+	require.NoError(t, ks.start(context.Background()))
+	defer ks.shutdown()
+	assert.Equal(t, 1, oidcServer.keyRequests())
+
+	// The cached keys are used.
+	_, err = ks.VerifySignature(context.Background(), newTestToken(t, oidcServer))
+	require.NoError(t, err)
+	assert.Equal(t, 1, oidcServer.keyRequests())
+
+	// The keys are refreshed when the issuer rotates them, unless they were
+	// refreshed recently.
+	require.NoError(t, oidcServer.rotateKey())
+	_, err = ks.VerifySignature(context.Background(), newTestToken(t, oidcServer))
+	assert.Equal(t, errNoMatchingKey, err)
+	assert.Equal(t, 1, oidcServer.keyRequests())
+
+	now = now.Add(time.Minute)
+	_, err = ks.VerifySignature(context.Background(), newTestToken(t, oidcServer))
+	require.NoError(t, err)
+	assert.Equal(t, 2, oidcServer.keyRequests())
+}
+
+func TestRemoteKeySetPeriodicRefresh(t *testing.T) {
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	ks := newRemoteKeySet(oidcServer.URL+"/.well-known/jwks.json", http.DefaultClient, 10*time.Millisecond, time.Minute, zap.NewNop())
+	require.NoError(t, ks.start(context.Background()))
+	assert.Eventually(t, func() bool { return oidcServer.keyRequests() >= 3 }, 5*time.Second, 10*time.Millisecond)
+	ks.shutdown()
+
+	// The cached keys are kept when a refresh fails.
+	oidcServer.Close()
+	_, err = ks.VerifySignature(context.Background(), newTestToken(t, oidcServer))
+	assert.NoError(t, err)
+}
+
+func TestRemoteKeySetErrors(t *testing.T) {
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	ks := newRemoteKeySet(oidcServer.URL+"/missing", http.DefaultClient, 0, 0, zap.NewNop())
+	err = ks.start(context.Background())
+	assert.EqualError(t, err, fmt.Sprintf("failed to fetch the keys of the issuer: %s/missing returned 404 Not Found", oidcServer.URL))
+
+	_, err = ks.VerifySignature(context.Background(), "not a token")
+	assert.Error(t, err)
+
+	_, err = ks.VerifySignature(context.Background(), newTestToken(t, oidcServer))
+	assert.Error(t, err)
+}
@@ -30,6 +30,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"time"
 )
 
@@ -39,13 +40,16 @@ type oidcServer struct {
 	*httptest.Server
 	x509Cert   []byte
 	privateKey *rsa.PrivateKey
+
+	mu           sync.Mutex
+	jwks         map[string]interface{}
+	jwksRequests int
 }
 
 func newOIDCServer() (*oidcServer, error) {
-	jwks := map[string]interface{}{}
-
 	mux := http.NewServeMux()
 	server := httptest.NewUnstartedServer(mux)
+	s := &oidcServer{Server: server}
 
 	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, req *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -59,21 +63,32 @@ func newOIDCServer() (*oidcServer, error) {
 		}
 	})
 	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.jwksRequests++
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		if err := json.NewEncoder(w).Encode(jwks); err != nil {
+		if err := json.NewEncoder(w).Encode(s.jwks); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	})
 
+	if err := s.rotateKey(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// rotateKey replaces the signing key of the server.
+func (s *oidcServer) rotateKey() error {
 	privateKey, err := createPrivateKey()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	x509Cert, err := createCertificate(privateKey)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	eBytes := make([]byte, 8)
@@ -82,7 +97,12 @@ func newOIDCServer() (*oidcServer, error) {
 
 	// #nosec
 	sum := sha1.Sum(x509Cert)
-	jwks["keys"] = []map[string]interface{}{{
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.x509Cert = x509Cert
+	s.privateKey = privateKey
+	s.jwks = map[string]interface{}{}
+	s.jwks["keys"] = []map[string]interface{}{{
 		"alg": "RS256",
 		"kty": "RSA",
 		"use": "sig",
@@ -92,8 +112,13 @@ func newOIDCServer() (*oidcServer, error) {
 		"kid": base64.RawURLEncoding.EncodeToString(sum[:]),
 		"x5t": base64.RawURLEncoding.EncodeToString(sum[:]),
 	}}
+	return nil
+}
 
-	return &oidcServer{server, x509Cert, privateKey}, nil
+func (s *oidcServer) keyRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jwksRequests
 }
 
 func (s *oidcServer) token(jsonPayload []byte) (string, error) {
@@ -106,7 +131,10 @@ func (s *oidcServer) token(jsonPayload []byte) (string, error) {
 	payload := base64.RawURLEncoding.EncodeToString(jsonPayload)
 	digest := sha256.Sum256([]byte(fmt.Sprintf("%s.%s", header, payload)))
 
-	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	s.mu.Lock()
+	privateKey := s.privateKey
+	s.mu.Unlock()
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimecontrolextension
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/featuregate"
+)
+
+const (
+	featureGatesPath = "/v1/featuregates"
+	reloadPath       = "/v1/reload"
+)
+
+type runtimeControl struct {
+	config   *Config
+	settings component.TelemetrySettings
+	registry *featuregate.Registry
+	provider func() *mapProvider
+	server   *http.Server
+	stopCh   chan struct{}
+}
+
+var _ component.Extension = (*runtimeControl)(nil)
+
+func newRuntimeControl(cfg *Config, settings component.TelemetrySettings, registry *featuregate.Registry, provider func() *mapProvider) *runtimeControl {
+	return &runtimeControl{
+		config:   cfg,
+		settings: settings,
+		registry: registry,
+		provider: provider,
+	}
+}
+
+func (rc *runtimeControl) Start(_ context.Context, host component.Host) error {
+	if rc.provider() == nil {
+		rc.settings.Logger.Warn("The collector was not built with runtimecontrolextension.NewMapProvider, configuration reloads are disabled")
+	}
+
+	ln, err := rc.config.ToListener()
+	if err != nil {
+		return err
+	}
+
+	rc.server = rc.config.ToServer(rc.handler(), rc.settings)
+
+	rc.stopCh = make(chan struct{})
+	go func() {
+		defer close(rc.stopCh)
+
+		if err := rc.server.Serve(ln); err != http.ErrServerClosed && err != nil {
+			host.ReportFatalError(err)
+		}
+	}()
+	return nil
+}
+
+func (rc *runtimeControl) Shutdown(context.Context) error {
+	if rc.server == nil {
+		return nil
+	}
+	err := rc.server.Close()
+	<-rc.stopCh
+	return err
+}
+
+func (rc *runtimeControl) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(featureGatesPath, rc.handleFeatureGates)
+	mux.HandleFunc(featureGatesPath+"/", rc.handleFeatureGate)
+	mux.HandleFunc(reloadPath, rc.handleReload)
+	return rc.authenticate(mux)
+}
+
+// authenticate rejects the requests without the configured bearer token.
+func (rc *runtimeControl) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(rc.config.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("invalid token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleFeatureGates lists the feature gates.
+func (rc *runtimeControl) handleFeatureGates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	writeJSON(w, http.StatusOK, rc.registry.List())
+}
+
+type featureGateUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// handleFeatureGate gets or updates a feature gate.
+func (rc *runtimeControl) handleFeatureGate(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, featureGatesPath+"/")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var update featureGateUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil || update.Enabled == nil {
+			writeError(w, http.StatusBadRequest, errors.New(`body must be {"enabled": true|false}`))
+			return
+		}
+		if err := rc.registry.Set(id, *update.Enabled); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		rc.settings.Logger.Info("Feature gate changed", zap.String("id", id), zap.Bool("enabled", *update.Enabled))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	for _, g := range rc.registry.List() {
+		if g.ID == id {
+			writeJSON(w, http.StatusOK, g)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, errors.New("feature gate not found"))
+}
+
+// handleReload requests the collector to reload its configuration. The
+// reload happens after the response is sent, as it shuts down this
+// extension.
+func (rc *runtimeControl) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	provider := rc.provider()
+	if provider == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("configuration reloads are not enabled in this collector"))
+		return
+	}
+	rc.settings.Logger.Info("Configuration reload requested")
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "reloading"})
+	provider.reload()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimecontrolextension
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/featuregate"
+)
+
+func newTestServer(t *testing.T, provider *mapProvider) (*httptest.Server, *featuregate.Registry) {
+	registry := featuregate.NewRegistry()
+	registry.MustRegister(featuregate.Gate{ID: "processor.example.fastPath", Description: "Use the fast path"})
+	registry.MustRegister(featuregate.Gate{ID: "receiver.example.newParser", Description: "Use the new parser", Enabled: true})
+
+	rc := newRuntimeControl(
+		&Config{Token: "secret"},
+		componenttest.NewNopTelemetrySettings(),
+		registry,
+		func() *mapProvider { return provider },
+	)
+	srv := httptest.NewServer(rc.handler())
+	t.Cleanup(srv.Close)
+	return srv, registry
+}
+
+func doRequest(t *testing.T, method, url, token, body string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, strings.TrimSpace(string(b))
+}
+
+func TestAuthentication(t *testing.T) {
+	srv, _ := newTestServer(t, nil)
+
+	status, body := doRequest(t, http.MethodGet, srv.URL+featureGatesPath, "", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, `{"error":"invalid token"}`, body)
+
+	status, _ = doRequest(t, http.MethodGet, srv.URL+featureGatesPath, "wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestFeatureGates(t *testing.T) {
+	srv, registry := newTestServer(t, nil)
+
+	status, body := doRequest(t, http.MethodGet, srv.URL+featureGatesPath, "secret", "")
+	assert.Equal(t, http.StatusOK, status)
+	var gates []featuregate.Gate
+	require.NoError(t, json.Unmarshal([]byte(body), &gates))
+	assert.Equal(t, registry.List(), gates)
+
+	status, body = doRequest(t, http.MethodPut, srv.URL+featureGatesPath+"/processor.example.fastPath", "secret", `{"enabled": true}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":"processor.example.fastPath","description":"Use the fast path","enabled":true}`, body)
+	assert.True(t, registry.IsEnabled("processor.example.fastPath"))
+
+	status, body = doRequest(t, http.MethodGet, srv.URL+featureGatesPath+"/receiver.example.newParser", "secret", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"id":"receiver.example.newParser","description":"Use the new parser","enabled":true}`, body)
+
+	status, body = doRequest(t, http.MethodPut, srv.URL+featureGatesPath+"/unknown", "secret", `{"enabled": true}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, `{"error":"feature gate \"unknown\" is not registered"}`, body)
+
+	status, _ = doRequest(t, http.MethodGet, srv.URL+featureGatesPath+"/unknown", "secret", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, body = doRequest(t, http.MethodPut, srv.URL+featureGatesPath+"/processor.example.fastPath", "secret", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, `{"error":"body must be {\"enabled\": true|false}"}`, body)
+
+	status, _ = doRequest(t, http.MethodDelete, srv.URL+featureGatesPath+"/processor.example.fastPath", "secret", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+	status, _ = doRequest(t, http.MethodPost, srv.URL+featureGatesPath, "secret", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestReload(t *testing.T) {
+	provider := NewMapProvider(&fakeMapProvider{}).(*mapProvider)
+	srv, _ := newTestServer(t, provider)
+
+	errCh := watch(provider)
+	status, body := doRequest(t, http.MethodPost, srv.URL+reloadPath, "secret", "")
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, `{"status":"reloading"}`, body)
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload was not triggered")
+	}
+
+	status, _ = doRequest(t, http.MethodGet, srv.URL+reloadPath, "secret", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestReloadDisabled(t *testing.T) {
+	srv, _ := newTestServer(t, nil)
+
+	status, body := doRequest(t, http.MethodPost, srv.URL+reloadPath, "secret", "")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, `{"error":"configuration reloads are not enabled in this collector"}`, body)
+}
+
+func TestStartShutdown(t *testing.T) {
+	rc := newRuntimeControl(
+		&Config{HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:0"}, Token: "secret"},
+		componenttest.NewNopTelemetrySettings(),
+		featuregate.NewRegistry(),
+		func() *mapProvider { return nil },
+	)
+	require.NoError(t, rc.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, rc.Shutdown(context.Background()))
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimecontrolextension
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+	"go.opentelemetry.io/collector/service/parserprovider"
+)
+
+// mapProvider wraps the provider of the configuration of the collector,
+// making it watchable so that the extension can trigger reloads.
+type mapProvider struct {
+	base parserprovider.MapProvider
+
+	mu       sync.Mutex
+	reloadCh chan struct{}
+	closeCh  chan struct{}
+}
+
+var (
+	currentMu sync.Mutex
+	current   *mapProvider
+)
+
+var _ parserprovider.Watchable = (*mapProvider)(nil)
+
+// NewMapProvider wraps the provider of the configuration of the collector,
+// set as service.CollectorSettings.ConfigMapProvider, so that the
+// configuration can be reloaded with the runtime_control extension. If the
+// wrapped provider is watchable, its updates are reloaded as well.
+func NewMapProvider(base parserprovider.MapProvider) parserprovider.MapProvider {
+	p := &mapProvider{
+		base:     base,
+		reloadCh: make(chan struct{}, 1),
+		closeCh:  make(chan struct{}),
+	}
+	currentMu.Lock()
+	current = p
+	currentMu.Unlock()
+	return p
+}
+
+// currentMapProvider returns the provider created by NewMapProvider, or nil
+// if the collector does not use it.
+func currentMapProvider() *mapProvider {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	return current
+}
+
+func (p *mapProvider) Get(ctx context.Context) (*config.Map, error) {
+	return p.base.Get(ctx)
+}
+
+// WatchForUpdate blocks until a reload is requested, the wrapped provider
+// reports an update, or the provider is closed.
+func (p *mapProvider) WatchForUpdate() error {
+	p.mu.Lock()
+	reloadCh, closeCh := p.reloadCh, p.closeCh
+	p.mu.Unlock()
+
+	var baseCh chan error
+	if watchable, ok := p.base.(parserprovider.Watchable); ok {
+		baseCh = make(chan error, 1)
+		go func() {
+			baseCh <- watchable.WatchForUpdate()
+		}()
+	}
+
+	select {
+	case <-reloadCh:
+		return nil
+	case <-closeCh:
+		return configsource.ErrSessionClosed
+	case err := <-baseCh:
+		return err
+	}
+}
+
+// Close is called by the collector before reloading the configuration and
+// on shutdown.
+func (p *mapProvider) Close(ctx context.Context) error {
+	p.mu.Lock()
+	close(p.closeCh)
+	p.closeCh = make(chan struct{})
+	p.reloadCh = make(chan struct{}, 1)
+	p.mu.Unlock()
+	return p.base.Close(ctx)
+}
+
+// reload requests the collector to reload its configuration. Requests made
+// while a reload is pending are merged.
+func (p *mapProvider) reload() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case p.reloadCh <- struct{}{}:
+	default:
+	}
+}
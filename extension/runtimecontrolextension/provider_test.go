@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtimecontrolextension
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/experimental/configsource"
+)
+
+type fakeMapProvider struct {
+	closed  int
+	watchCh chan error
+}
+
+func (f *fakeMapProvider) Get(context.Context) (*config.Map, error) {
+	return config.NewMapFromStringMap(map[string]interface{}{"key": "value"}), nil
+}
+
+func (f *fakeMapProvider) Close(context.Context) error {
+	f.closed++
+	return nil
+}
+
+type watchableMapProvider struct {
+	fakeMapProvider
+}
+
+func (w *watchableMapProvider) WatchForUpdate() error {
+	return <-w.watchCh
+}
+
+func watch(p *mapProvider) chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- p.WatchForUpdate()
+	}()
+	return errCh
+}
+
+func TestMapProviderReload(t *testing.T) {
+	base := &fakeMapProvider{}
+	p := NewMapProvider(base).(*mapProvider)
+	assert.Same(t, p, currentMapProvider())
+
+	cp, err := p.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "value", cp.Get("key"))
+
+	errCh := watch(p)
+	p.reload()
+	// Reloads requested while one is pending are merged.
+	p.reload()
+	require.NoError(t, <-errCh)
+
+	// The collector closes the provider before getting the new configuration.
+	require.NoError(t, p.Close(context.Background()))
+	assert.Equal(t, 1, base.closed)
+
+	errCh = watch(p)
+	select {
+	case err := <-errCh:
+		t.Fatalf("WatchForUpdate returned %v without reload", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// On shutdown, the watch is closed.
+	require.NoError(t, p.Close(context.Background()))
+	assert.Equal(t, configsource.ErrSessionClosed, <-errCh)
+}
+
+func TestMapProviderWatchable(t *testing.T) {
+	base := &watchableMapProvider{fakeMapProvider{watchCh: make(chan error, 1)}}
+	p := NewMapProvider(base).(*mapProvider)
+
+	errCh := watch(p)
+	updateErr := errors.New("updated")
+	base.watchCh <- updateErr
+	assert.Equal(t, updateErr, <-errCh)
+}
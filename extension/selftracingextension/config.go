@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the self-tracing extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// TracesExporter is the name of the traces exporter the spans of the
+	// Collector are sent to.
+	TracesExporter string `mapstructure:"traces_exporter"`
+
+	// SamplingRatio is the share of the traces of the Collector sent, between
+	// 0 and 1.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+
+	// FlushInterval is the interval the spans are sent at.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// MaxBatchSize is the maximum number of spans sent at once. Spans are
+	// sent as soon as a batch is full.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+
+	// MaxQueueSize is the maximum number of spans waiting to be sent. Spans
+	// are dropped past the limit.
+	MaxQueueSize int `mapstructure:"max_queue_size"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.TracesExporter == "" {
+		return errors.New("traces_exporter is required")
+	}
+	if cfg.SamplingRatio <= 0 || cfg.SamplingRatio > 1 {
+		return errors.New("sampling_ratio must be greater than 0 and at most 1")
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.New("flush_interval must be positive")
+	}
+	if cfg.MaxBatchSize <= 0 {
+		return errors.New("max_batch_size must be positive")
+	}
+	if cfg.MaxQueueSize < cfg.MaxBatchSize {
+		return errors.New("max_queue_size must be at least max_batch_size")
+	}
+	return nil
+}
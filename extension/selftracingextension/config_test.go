@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.TracesExporter = "nop"
+	assert.Equal(t, defaultCfg, cfg.Extensions[config.NewComponentID(typeStr)])
+	assert.Equal(t, &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		TracesExporter:    "nop/self",
+		SamplingRatio:     0.1,
+		FlushInterval:     10 * time.Second,
+		MaxBatchSize:      256,
+		MaxQueueSize:      1024,
+	}, cfg.Extensions[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{name: "no exporter", modify: func(cfg *Config) { cfg.TracesExporter = "" }, err: "traces_exporter is required"},
+		{name: "ratio", modify: func(cfg *Config) { cfg.SamplingRatio = 0 }, err: "sampling_ratio must be greater than 0 and at most 1"},
+		{name: "interval", modify: func(cfg *Config) { cfg.FlushInterval = 0 }, err: "flush_interval must be positive"},
+		{name: "batch", modify: func(cfg *Config) { cfg.MaxBatchSize = 0 }, err: "max_batch_size must be positive"},
+		{name: "queue", modify: func(cfg *Config) { cfg.MaxQueueSize = 100 }, err: "max_queue_size must be at least max_batch_size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.TracesExporter = "otlp"
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type selfTracing struct {
+	config    *Config
+	settings  component.TelemetrySettings
+	buildInfo component.BuildInfo
+	queue     *spanQueue
+	// sendContext is the context spans are sent with. Its span isn't
+	// sampled, so that the operations sending the spans aren't recorded with
+	// the default sampler of the Collector, and its trace is excluded from
+	// the queue otherwise.
+	sendContext context.Context
+
+	// tracerProvider is nil if spans are not recorded.
+	tracerProvider *sdktrace.TracerProvider
+	tracesExporter consumer.Traces
+
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+var (
+	_ component.Extension       = (*selfTracing)(nil)
+	_ component.PipelineWatcher = (*selfTracing)(nil)
+)
+
+func newSelfTracing(cfg *Config, settings component.TelemetrySettings, buildInfo component.BuildInfo) *selfTracing {
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	_, _ = rand.Read(traceID[:])
+	_, _ = rand.Read(spanID[:])
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+	return &selfTracing{
+		config:      cfg,
+		settings:    settings,
+		buildInfo:   buildInfo,
+		queue:       newSpanQueue(cfg, traceID),
+		sendContext: trace.ContextWithSpanContext(context.Background(), sc),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start looks up the traces exporter spans are sent to among the exporters of
+// the traces pipelines, and starts recording the spans of the Collector.
+// Spans are queued until the pipelines are ready.
+func (st *selfTracing) Start(_ context.Context, host component.Host) error {
+	var available []string
+	for id, exp := range host.GetExporters()[config.TracesDataType] {
+		tracesExp, ok := exp.(component.TracesExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a traces exporter", id.String())
+		}
+		if id.String() == st.config.TracesExporter {
+			st.tracesExporter = tracesExp
+			break
+		}
+		available = append(available, id.String())
+	}
+	if st.tracesExporter == nil {
+		sort.Strings(available)
+		return fmt.Errorf("failed to find traces exporter %q; please configure traces_exporter from one of: %v",
+			st.config.TracesExporter, available)
+	}
+
+	// The spans of the operations of the components are recorded by
+	// obsreport, which requires the tracer provider of the Collector to be
+	// the SDK one.
+	if tp, ok := st.settings.TracerProvider.(*sdktrace.TracerProvider); ok {
+		tp.RegisterSpanProcessor(st.queue)
+		st.tracerProvider = tp
+	} else {
+		st.settings.Logger.Warn("The tracer provider of the Collector is not the OpenTelemetry SDK one, no span will be sent")
+	}
+	return nil
+}
+
+// Ready starts sending spans, now that the exporter can receive them.
+func (st *selfTracing) Ready() error {
+	if st.tracerProvider == nil {
+		return nil
+	}
+	st.wg.Add(1)
+	go func() {
+		defer st.wg.Done()
+		ticker := time.NewTicker(st.config.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-st.queue.full:
+			case <-st.done:
+				return
+			}
+			st.flush()
+		}
+	}()
+	return nil
+}
+
+// NotReady stops recording spans, and sends the queued ones before the
+// pipelines are shut down.
+func (st *selfTracing) NotReady() error {
+	if st.stop() {
+		st.flush()
+	}
+	return nil
+}
+
+func (st *selfTracing) Shutdown(context.Context) error {
+	st.stop()
+	return nil
+}
+
+// stop stops recording and sending spans. It reports whether spans were
+// recorded and it wasn't stopped before.
+func (st *selfTracing) stop() bool {
+	stopped := false
+	st.stopOnce.Do(func() {
+		close(st.done)
+		st.wg.Wait()
+		if st.tracerProvider != nil {
+			st.tracerProvider.UnregisterSpanProcessor(st.queue)
+			stopped = true
+		}
+	})
+	return stopped
+}
+
+// flush sends the queued spans in batches.
+func (st *selfTracing) flush() {
+	for {
+		spans, dropped := st.queue.take()
+		if dropped > 0 {
+			st.settings.Logger.Warn("Span queue is full, spans were dropped",
+				zap.Int("dropped", dropped), zap.Int("max_queue_size", st.config.MaxQueueSize))
+		}
+		if len(spans) == 0 {
+			return
+		}
+		if err := st.tracesExporter.ConsumeTraces(st.sendContext, toTraces(spans, st.buildInfo)); err != nil {
+			st.settings.Logger.Error("Failed to send the spans of the Collector", zap.Error(err))
+		}
+	}
+}
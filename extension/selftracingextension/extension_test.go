@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type tracesExporter struct {
+	consumer.Traces
+}
+
+func (tracesExporter) Start(context.Context, component.Host) error { return nil }
+
+func (tracesExporter) Shutdown(context.Context) error { return nil }
+
+type testHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *testHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+// recordingConsumer records a span of each operation, like the exporters of
+// the Collector, before passing traces to next.
+type recordingConsumer struct {
+	tp   *sdktrace.TracerProvider
+	next consumer.Traces
+}
+
+func (c recordingConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (c recordingConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	ctx, span := c.tp.Tracer("otlp").Start(ctx, "exporter/otlp/traces")
+	defer span.End()
+	return c.next.ConsumeTraces(ctx, td)
+}
+
+func newTestHost(traces consumer.Traces) component.Host {
+	return &testHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {
+				config.NewComponentID("jaeger"): tracesExporter{consumertest.NewNop()},
+				config.NewComponentID("otlp"):   tracesExporter{traces},
+			},
+		},
+	}
+}
+
+func newTestExtension(t *testing.T, tp *sdktrace.TracerProvider, traces consumer.Traces) *selfTracing {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesExporter = "otlp"
+	cfg.FlushInterval = 10 * time.Millisecond
+	require.NoError(t, cfg.Validate())
+
+	set := componenttest.NewNopExtensionCreateSettings()
+	set.TracerProvider = tp
+	ext, err := createExtension(context.Background(), set, cfg)
+	require.NoError(t, err)
+	st := ext.(*selfTracing)
+	require.NoError(t, st.Start(context.Background(), newTestHost(traces)))
+	t.Cleanup(func() {
+		assert.NoError(t, st.Shutdown(context.Background()))
+	})
+	return st
+}
+
+func TestSelfTracing(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	sink := new(consumertest.TracesSink)
+	st := newTestExtension(t, tp, recordingConsumer{tp: tp, next: sink})
+
+	// Spans are queued until the pipelines are ready.
+	endSpans(tp, "otlp", 3)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 0, sink.SpanCount())
+
+	require.NoError(t, st.Ready())
+	require.Eventually(t, func() bool {
+		return sink.SpanCount() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	td := sink.AllTraces()[0]
+	assert.Equal(t, "otlp", td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).InstrumentationLibrary().Name())
+
+	// The spans of the operations sending the spans aren't recorded, but
+	// those of the other operations of the exporter are.
+	endSpans(tp, "jaeger", 1)
+	endSpans(tp, "otlp", 1)
+	require.Eventually(t, func() bool {
+		return sink.SpanCount() == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 5, sink.SpanCount())
+}
+
+func TestSelfTracingNotReady(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	sink := new(consumertest.TracesSink)
+	st := newTestExtension(t, tp, sink)
+	st.config.FlushInterval = time.Hour
+	require.NoError(t, st.Ready())
+
+	// The queued spans are sent before the pipelines are shut down.
+	endSpans(tp, "otlp", 2)
+	require.NoError(t, st.NotReady())
+	assert.Equal(t, 2, sink.SpanCount())
+
+	// Spans are no longer recorded.
+	endSpans(tp, "otlp", 1)
+	batch, _ := st.queue.take()
+	assert.Len(t, batch, 0)
+}
+
+func TestSelfTracingMissingExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesExporter = "zipkin"
+	st := newSelfTracing(cfg, componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo())
+	err := st.Start(context.Background(), newTestHost(consumertest.NewNop()))
+	assert.EqualError(t, err, `failed to find traces exporter "zipkin"; please configure traces_exporter from one of: [jaeger otlp]`)
+}
+
+func TestSelfTracingNonSDKTracerProvider(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesExporter = "otlp"
+	st := newSelfTracing(cfg, componenttest.NewNopTelemetrySettings(), component.NewDefaultBuildInfo())
+	require.NoError(t, st.Start(context.Background(), newTestHost(consumertest.NewNop())))
+	assert.NoError(t, st.Ready())
+	assert.NoError(t, st.NotReady())
+	assert.NoError(t, st.Shutdown(context.Background()))
+}
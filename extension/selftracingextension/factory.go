@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/extensionhelper"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "selftracing"
+
+	defaultSamplingRatio = 1
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxBatchSize  = 512
+	defaultMaxQueueSize  = 2048
+)
+
+// NewFactory creates a factory for the self-tracing extension.
+func NewFactory() component.ExtensionFactory {
+	return extensionhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		createExtension)
+}
+
+// Note: This isn't a valid configuration because the extension needs a traces exporter.
+func createDefaultConfig() config.Extension {
+	return &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		SamplingRatio:     defaultSamplingRatio,
+		FlushInterval:     defaultFlushInterval,
+		MaxBatchSize:      defaultMaxBatchSize,
+		MaxQueueSize:      defaultMaxQueueSize,
+	}
+}
+
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg config.Extension) (component.Extension, error) {
+	return newSelfTracing(cfg.(*Config), set.TelemetrySettings, set.BuildInfo), nil
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"context"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanQueue is a span processor queuing the spans of the Collector until they
+// are sent. Unlike the batch span processor of the SDK, it queues spans that
+// are recorded but not sampled, as the Collector records the spans of its
+// operations without sampling them.
+type spanQueue struct {
+	// excludedTraceID is the trace of the spans of the operations sending
+	// the spans, which aren't queued.
+	excludedTraceID trace.TraceID
+	sampler         sdktrace.Sampler
+	maxBatchSize    int
+	maxQueueSize    int
+
+	mu      sync.Mutex
+	spans   []sdktrace.ReadOnlySpan
+	dropped int
+	// full is signaled when a batch is full.
+	full chan struct{}
+}
+
+var _ sdktrace.SpanProcessor = (*spanQueue)(nil)
+
+func newSpanQueue(cfg *Config, excludedTraceID trace.TraceID) *spanQueue {
+	return &spanQueue{
+		excludedTraceID: excludedTraceID,
+		sampler:         sdktrace.TraceIDRatioBased(cfg.SamplingRatio),
+		maxBatchSize:    cfg.MaxBatchSize,
+		maxQueueSize:    cfg.MaxQueueSize,
+		full:            make(chan struct{}, 1),
+	}
+}
+
+func (q *spanQueue) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (q *spanQueue) OnEnd(s sdktrace.ReadOnlySpan) {
+	// The spans of the operations sending the spans would otherwise be sent
+	// in turn, endlessly.
+	if s.SpanContext().TraceID() == q.excludedTraceID {
+		return
+	}
+	result := q.sampler.ShouldSample(sdktrace.SamplingParameters{TraceID: s.SpanContext().TraceID()})
+	if result.Decision == sdktrace.Drop {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.spans) >= q.maxQueueSize {
+		q.dropped++
+		return
+	}
+	q.spans = append(q.spans, s)
+	if len(q.spans) == q.maxBatchSize {
+		select {
+		case q.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (q *spanQueue) Shutdown(context.Context) error { return nil }
+
+func (q *spanQueue) ForceFlush(context.Context) error { return nil }
+
+// take removes and returns a batch of spans from the queue, and the number of
+// spans dropped since the previous call.
+func (q *spanQueue) take() ([]sdktrace.ReadOnlySpan, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := len(q.spans)
+	if n > q.maxBatchSize {
+		n = q.maxBatchSize
+	}
+	batch := make([]sdktrace.ReadOnlySpan, n)
+	copy(batch, q.spans)
+	q.spans = q.spans[n:]
+	dropped := q.dropped
+	q.dropped = 0
+	return batch, dropped
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var excludedContext = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+	SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+}))
+
+func newTestQueue(modify func(*Config)) (*spanQueue, *sdktrace.TracerProvider) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TracesExporter = "otlp/self"
+	cfg.MaxBatchSize = 2
+	cfg.MaxQueueSize = 3
+	modify(cfg)
+	q := newSpanQueue(cfg, trace.SpanContextFromContext(excludedContext).TraceID())
+	return q, sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()), sdktrace.WithSpanProcessor(q))
+}
+
+func endSpans(tp *sdktrace.TracerProvider, tracer string, n int) {
+	endSpansWithContext(context.Background(), tp, tracer, n)
+}
+
+func endSpansWithContext(ctx context.Context, tp *sdktrace.TracerProvider, tracer string, n int) {
+	for i := 0; i < n; i++ {
+		_, span := tp.Tracer(tracer).Start(ctx, "span")
+		span.End()
+	}
+}
+
+func TestSpanQueue(t *testing.T) {
+	q, tp := newTestQueue(func(*Config) {})
+
+	endSpans(tp, "otlp", 1)
+	select {
+	case <-q.full:
+		t.Fatal("the batch isn't full")
+	default:
+	}
+	endSpans(tp, "otlp", 1)
+	<-q.full
+
+	// The spans of the operations sending the spans aren't queued, even if
+	// recorded.
+	endSpansWithContext(excludedContext, tp, "otlp/self", 1)
+	// Spans are dropped past the limit.
+	endSpans(tp, "otlp", 2)
+
+	batch, dropped := q.take()
+	assert.Len(t, batch, 2)
+	assert.Equal(t, 1, dropped)
+	batch, dropped = q.take()
+	assert.Len(t, batch, 1)
+	assert.Equal(t, 0, dropped)
+	batch, _ = q.take()
+	assert.Len(t, batch, 0)
+}
+
+func TestSpanQueueRecordedOnly(t *testing.T) {
+	q, _ := newTestQueue(func(*Config) {})
+	// Spans recorded but not sampled are queued.
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(recordOnlySampler{}),
+		sdktrace.WithSpanProcessor(q))
+	_, span := tp.Tracer("otlp").Start(context.Background(), "span")
+	require.True(t, span.IsRecording())
+	require.False(t, span.SpanContext().IsSampled())
+	span.End()
+
+	batch, _ := q.take()
+	assert.Len(t, batch, 1)
+}
+
+func TestSpanQueueSampling(t *testing.T) {
+	q, tp := newTestQueue(func(cfg *Config) {
+		cfg.SamplingRatio = 0.5
+		cfg.MaxBatchSize = 1000
+		cfg.MaxQueueSize = 1000
+	})
+	endSpans(tp, "otlp", 1000)
+
+	batch, _ := q.take()
+	assert.InDelta(t, 500, len(batch), 100)
+}
+
+type recordOnlySampler struct{}
+
+func (recordOnlySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordOnly,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (recordOnlySampler) Description() string {
+	return "record only"
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// toTraces converts the spans of the Collector to traces, grouped by tracer.
+// The tracers of obsreport are named after the IDs of the components.
+func toTraces(spans []sdktrace.ReadOnlySpan, buildInfo component.BuildInfo) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	attrs := rs.Resource().Attributes()
+	attrs.InsertString(conventions.AttributeServiceName, buildInfo.Command)
+	attrs.InsertString(conventions.AttributeServiceVersion, buildInfo.Version)
+
+	libraries := map[string]pdata.SpanSlice{}
+	for _, s := range spans {
+		library := s.InstrumentationLibrary()
+		key := library.Name + "\x00" + library.Version
+		dest, ok := libraries[key]
+		if !ok {
+			ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+			ils.InstrumentationLibrary().SetName(library.Name)
+			ils.InstrumentationLibrary().SetVersion(library.Version)
+			dest = ils.Spans()
+			libraries[key] = dest
+		}
+		copySpan(s, dest.AppendEmpty())
+	}
+	return td
+}
+
+func copySpan(s sdktrace.ReadOnlySpan, span pdata.Span) {
+	sc := s.SpanContext()
+	span.SetTraceID(pdata.NewTraceID(sc.TraceID()))
+	span.SetSpanID(pdata.NewSpanID(sc.SpanID()))
+	span.SetTraceState(pdata.TraceState(sc.TraceState().String()))
+	if s.Parent().IsValid() {
+		span.SetParentSpanID(pdata.NewSpanID(s.Parent().SpanID()))
+	}
+	span.SetName(s.Name())
+	span.SetKind(spanKind(s.SpanKind()))
+	span.SetStartTimestamp(pdata.NewTimestampFromTime(s.StartTime()))
+	span.SetEndTimestamp(pdata.NewTimestampFromTime(s.EndTime()))
+	copyAttributes(s.Attributes(), span.Attributes())
+	span.SetDroppedAttributesCount(uint32(s.DroppedAttributes()))
+
+	for _, e := range s.Events() {
+		event := span.Events().AppendEmpty()
+		event.SetName(e.Name)
+		event.SetTimestamp(pdata.NewTimestampFromTime(e.Time))
+		copyAttributes(e.Attributes, event.Attributes())
+		event.SetDroppedAttributesCount(uint32(e.DroppedAttributeCount))
+	}
+	span.SetDroppedEventsCount(uint32(s.DroppedEvents()))
+
+	for _, l := range s.Links() {
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(pdata.NewTraceID(l.SpanContext.TraceID()))
+		link.SetSpanID(pdata.NewSpanID(l.SpanContext.SpanID()))
+		link.SetTraceState(pdata.TraceState(l.SpanContext.TraceState().String()))
+		copyAttributes(l.Attributes, link.Attributes())
+		link.SetDroppedAttributesCount(uint32(l.DroppedAttributeCount))
+	}
+	span.SetDroppedLinksCount(uint32(s.DroppedLinks()))
+
+	switch s.Status().Code {
+	case codes.Error:
+		span.Status().SetCode(pdata.StatusCodeError)
+		span.Status().SetMessage(s.Status().Description)
+	case codes.Ok:
+		span.Status().SetCode(pdata.StatusCodeOk)
+	}
+}
+
+func spanKind(kind trace.SpanKind) pdata.SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return pdata.SpanKindInternal
+	case trace.SpanKindServer:
+		return pdata.SpanKindServer
+	case trace.SpanKindClient:
+		return pdata.SpanKindClient
+	case trace.SpanKindProducer:
+		return pdata.SpanKindProducer
+	case trace.SpanKindConsumer:
+		return pdata.SpanKindConsumer
+	default:
+		return pdata.SpanKindUnspecified
+	}
+}
+
+func copyAttributes(kvs []attribute.KeyValue, dest pdata.AttributeMap) {
+	for _, kv := range kvs {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			dest.UpsertBool(key, kv.Value.AsBool())
+		case attribute.INT64:
+			dest.UpsertInt(key, kv.Value.AsInt64())
+		case attribute.FLOAT64:
+			dest.UpsertDouble(key, kv.Value.AsFloat64())
+		case attribute.STRING:
+			dest.UpsertString(key, kv.Value.AsString())
+		case attribute.BOOLSLICE:
+			av := pdata.NewAttributeValueArray()
+			for _, v := range kv.Value.AsBoolSlice() {
+				av.ArrayVal().AppendEmpty().SetBoolVal(v)
+			}
+			dest.Upsert(key, av)
+		case attribute.INT64SLICE:
+			av := pdata.NewAttributeValueArray()
+			for _, v := range kv.Value.AsInt64Slice() {
+				av.ArrayVal().AppendEmpty().SetIntVal(v)
+			}
+			dest.Upsert(key, av)
+		case attribute.FLOAT64SLICE:
+			av := pdata.NewAttributeValueArray()
+			for _, v := range kv.Value.AsFloat64Slice() {
+				av.ArrayVal().AppendEmpty().SetDoubleVal(v)
+			}
+			dest.Upsert(key, av)
+		case attribute.STRINGSLICE:
+			av := pdata.NewAttributeValueArray()
+			for _, v := range kv.Value.AsStringSlice() {
+				av.ArrayVal().AppendEmpty().SetStringVal(v)
+			}
+			dest.Upsert(key, av)
+		default:
+			dest.UpsertString(key, kv.Value.Emit())
+		}
+	}
+}
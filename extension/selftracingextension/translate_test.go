@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selftracingextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestToTraces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	start := time.Date(2021, 10, 4, 12, 0, 0, 0, time.UTC)
+	ctx, receive := tp.Tracer("otlp").Start(context.Background(), "receiver/otlp/TraceDataReceived",
+		trace.WithTimestamp(start), trace.WithSpanKind(trace.SpanKindServer))
+	receive.SetAttributes(
+		attribute.String("transport", "grpc"),
+		attribute.Int64("accepted_spans", 120),
+		attribute.Bool("retry", false),
+		attribute.Float64("ratio", 0.5),
+		attribute.StringSlice("signals", []string{"traces"}),
+	)
+	receive.AddEvent("Start processing.", trace.WithTimestamp(start.Add(time.Millisecond)),
+		trace.WithAttributes(attribute.String("processor", "batch")))
+	_, export := tp.Tracer("jaeger").Start(ctx, "exporter/jaeger/traces",
+		trace.WithLinks(trace.Link{SpanContext: receive.SpanContext()}))
+	export.SetStatus(codes.Error, "connection refused")
+	export.End()
+	receive.End(trace.WithTimestamp(start.Add(5 * time.Millisecond)))
+
+	td := toTraces(recorder.Ended(), component.BuildInfo{Command: "otelcontribcol", Version: "0.36.0"})
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	rs := td.ResourceSpans().At(0)
+	service, _ := rs.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "otelcontribcol", service.StringVal())
+	version, _ := rs.Resource().Attributes().Get("service.version")
+	assert.Equal(t, "0.36.0", version.StringVal())
+
+	// The spans are grouped by tracer, in order of end.
+	ilss := rs.InstrumentationLibrarySpans()
+	require.Equal(t, 2, ilss.Len())
+	assert.Equal(t, "jaeger", ilss.At(0).InstrumentationLibrary().Name())
+	assert.Equal(t, "otlp", ilss.At(1).InstrumentationLibrary().Name())
+
+	exportSpan := ilss.At(0).Spans().At(0)
+	receiveSpan := ilss.At(1).Spans().At(0)
+	assert.Equal(t, "receiver/otlp/TraceDataReceived", receiveSpan.Name())
+	assert.Equal(t, pdata.SpanKindServer, receiveSpan.Kind())
+	assert.Equal(t, pdata.NewTraceID(receive.SpanContext().TraceID()), receiveSpan.TraceID())
+	assert.Equal(t, pdata.NewSpanID(receive.SpanContext().SpanID()), receiveSpan.SpanID())
+	assert.True(t, receiveSpan.ParentSpanID().IsEmpty())
+	assert.Equal(t, start, receiveSpan.StartTimestamp().AsTime())
+	assert.Equal(t, start.Add(5*time.Millisecond), receiveSpan.EndTimestamp().AsTime())
+	assert.Equal(t, map[string]interface{}{
+		"transport":      "grpc",
+		"accepted_spans": int64(120),
+		"retry":          false,
+		"ratio":          0.5,
+		"signals":        []interface{}{"traces"},
+	}, receiveSpan.Attributes().AsRaw())
+	require.Equal(t, 1, receiveSpan.Events().Len())
+	assert.Equal(t, "Start processing.", receiveSpan.Events().At(0).Name())
+	assert.Equal(t, start.Add(time.Millisecond), receiveSpan.Events().At(0).Timestamp().AsTime())
+	assert.Equal(t, pdata.StatusCodeUnset, receiveSpan.Status().Code())
+
+	assert.Equal(t, receiveSpan.SpanID(), exportSpan.ParentSpanID())
+	assert.Equal(t, pdata.SpanKindInternal, exportSpan.Kind())
+	assert.Equal(t, pdata.StatusCodeError, exportSpan.Status().Code())
+	assert.Equal(t, "connection refused", exportSpan.Status().Message())
+	require.Equal(t, 1, exportSpan.Links().Len())
+	assert.Equal(t, receiveSpan.SpanID(), exportSpan.Links().At(0).SpanID())
+}
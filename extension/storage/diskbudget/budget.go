@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbudget
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const mib = 1 << 20
+
+// errBudgetExceeded is returned when a write does not fit in the budget.
+type errBudgetExceeded struct {
+	component config.ComponentID
+	size      int64
+}
+
+func (e *errBudgetExceeded) Error() string {
+	return fmt.Sprintf("disk budget exceeded: cannot store %d more bytes for %q", e.size, e.component.String())
+}
+
+// budget accounts the bytes stored by every client of the extension against
+// the global limit and the quota of each component.
+type budget struct {
+	mu           sync.Mutex
+	maxBytes     int64
+	defaultQuota int64
+	quotas       map[config.ComponentID]int64
+	used         int64
+	usedBy       map[config.ComponentID]int64
+}
+
+func newBudget(cfg *Config) *budget {
+	b := &budget{
+		maxBytes:     cfg.MaxSizeMiB * mib,
+		defaultQuota: cfg.DefaultQuotaMiB * mib,
+		quotas:       make(map[config.ComponentID]int64, len(cfg.QuotasMiB)),
+		usedBy:       make(map[config.ComponentID]int64),
+	}
+	for id, quota := range cfg.QuotasMiB {
+		// IDs were checked by Config.Validate.
+		componentID, _ := config.NewComponentIDFromString(id)
+		b.quotas[componentID] = quota * mib
+	}
+	return b
+}
+
+func (b *budget) quota(id config.ComponentID) int64 {
+	if q, ok := b.quotas[id]; ok {
+		return q
+	}
+	return b.defaultQuota
+}
+
+// excess returns how many bytes over the budget the component would be
+// after storing delta more bytes.
+func (b *budget) excess(id config.ComponentID, delta int64) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.excessLocked(id, delta)
+}
+
+func (b *budget) excessLocked(id config.ComponentID, delta int64) int64 {
+	excess := b.used + delta - b.maxBytes
+	if q := b.quota(id); q > 0 {
+		if e := b.usedBy[id] + delta - q; e > excess {
+			excess = e
+		}
+	}
+	if excess < 0 {
+		return 0
+	}
+	return excess
+}
+
+// reserve accounts delta bytes to the component if they fit in the budget.
+// Releasing bytes, i.e. a negative delta, always succeeds.
+func (b *budget) reserve(id config.ComponentID, delta int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if delta > 0 && b.excessLocked(id, delta) > 0 {
+		return false
+	}
+	b.addLocked(id, delta)
+	return true
+}
+
+// add accounts delta bytes to the component unconditionally.
+func (b *budget) add(id config.ComponentID, delta int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.addLocked(id, delta)
+}
+
+func (b *budget) addLocked(id config.ComponentID, delta int64) {
+	b.used += delta
+	b.usedBy[id] += delta
+}
+
+// usage returns the bytes stored by the component and by all components.
+func (b *budget) usage(id config.ComponentID) (int64, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.usedBy[id], b.used
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbudget
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// indexKey is the key under which a client persists the sizes of the keys
+// it stored, so the budget survives restarts.
+const indexKey = "__disk_budget_index"
+
+type indexEntry struct {
+	Key  string `json:"key"`
+	Size int64  `json:"size"`
+}
+
+// client wraps a storage client and accounts the size of every key and value
+// it stores against the budget.
+type client struct {
+	storage.Client
+	budget    *budget
+	component config.ComponentID
+	evict     bool
+	logger    *zap.Logger
+
+	mu sync.Mutex
+	// entries indexes the elements of order by key.
+	entries map[string]*list.Element
+	// order holds the *indexEntry of the stored keys, least recently written first.
+	order *list.List
+}
+
+func newClient(ctx context.Context, backing storage.Client, b *budget, id config.ComponentID, evict bool, logger *zap.Logger) (*client, error) {
+	c := &client{
+		Client:    backing,
+		budget:    b,
+		component: id,
+		evict:     evict,
+		logger:    logger,
+		entries:   make(map[string]*list.Element),
+		order:     list.New(),
+	}
+
+	data, err := backing.Get(ctx, indexKey)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return c, nil
+	}
+	var index []indexEntry
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to load disk budget index of %q: %w", id.String(), err)
+	}
+	var total int64
+	for i := range index {
+		c.entries[index[i].Key] = c.order.PushBack(&index[i])
+		total += index[i].Size
+	}
+	b.add(id, total)
+	return c, nil
+}
+
+// Set stores the value if it fits in the budget
+func (c *client) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete deletes the key and releases its bytes from the budget
+func (c *client) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch executes the operations if what they store fits in the budget,
+// evicting the oldest keys of the client first when configured to
+func (c *client) Batch(ctx context.Context, ops ...storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// sizes holds the size of every written key after the batch, -1 when deleted.
+	sizes := make(map[string]int64)
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Set:
+			sizes[op.Key] = int64(len(op.Key) + len(op.Value))
+		case storage.Delete:
+			sizes[op.Key] = -1
+		}
+	}
+	var delta int64
+	for key, size := range sizes {
+		if size > 0 {
+			delta += size
+		}
+		delta -= c.size(key)
+	}
+
+	evicted, freed, err := c.makeRoom(delta, sizes)
+	if err != nil {
+		return err
+	}
+	if len(evicted) > 0 {
+		withEvictions := make([]storage.Operation, 0, len(evicted)+len(ops))
+		for _, key := range evicted {
+			withEvictions = append(withEvictions, storage.DeleteOperation(key))
+		}
+		ops = append(withEvictions, ops...)
+	}
+	if err := c.Client.Batch(ctx, ops...); err != nil {
+		c.budget.add(c.component, freed-delta)
+		return err
+	}
+
+	for _, key := range evicted {
+		c.order.Remove(c.entries[key])
+		delete(c.entries, key)
+	}
+	if len(evicted) > 0 {
+		c.logger.Debug("Evicted keys to stay within the disk budget",
+			zap.String("component", c.component.String()),
+			zap.Int("keys", len(evicted)),
+			zap.Int64("bytes", freed))
+	}
+	for _, op := range ops {
+		if op.Type == storage.Get {
+			continue
+		}
+		if e, ok := c.entries[op.Key]; ok {
+			c.order.Remove(e)
+			delete(c.entries, op.Key)
+		}
+		if size := sizes[op.Key]; size >= 0 && op.Type == storage.Set {
+			c.entries[op.Key] = c.order.PushBack(&indexEntry{Key: op.Key, Size: size})
+		}
+	}
+	return nil
+}
+
+// makeRoom reserves delta bytes in the budget. When they do not fit and
+// eviction is enabled, it picks the oldest keys of the client that are not
+// written by the batch and returns them with the number of bytes they free.
+func (c *client) makeRoom(delta int64, written map[string]int64) ([]string, int64, error) {
+	if c.budget.reserve(c.component, delta) {
+		return nil, 0, nil
+	}
+	exceeded := &errBudgetExceeded{component: c.component, size: delta}
+	if !c.evict {
+		return nil, 0, exceeded
+	}
+
+	need := c.budget.excess(c.component, delta)
+	var evicted []string
+	var freed int64
+	for e := c.order.Front(); e != nil && freed < need; e = e.Next() {
+		entry := e.Value.(*indexEntry)
+		if _, ok := written[entry.Key]; ok {
+			continue
+		}
+		evicted = append(evicted, entry.Key)
+		freed += entry.Size
+	}
+	if freed < need || !c.budget.reserve(c.component, delta-freed) {
+		return nil, 0, exceeded
+	}
+	return evicted, freed, nil
+}
+
+// size returns the accounted size of the key, 0 if it is not stored.
+func (c *client) size(key string) int64 {
+	if e, ok := c.entries[key]; ok {
+		return e.Value.(*indexEntry).Size
+	}
+	return 0
+}
+
+// Close persists the index of the client and closes the underlying client
+func (c *client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := make([]indexEntry, 0, c.order.Len())
+	var total int64
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*indexEntry)
+		index = append(index, *entry)
+		total += entry.Size
+	}
+	c.budget.add(c.component, -total)
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	err = c.Client.Set(ctx, indexKey, data)
+	return multierr.Append(err, c.Client.Close(ctx))
+}
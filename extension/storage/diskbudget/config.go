@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbudget
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// EvictionPolicyReject rejects writes that would exceed the budget.
+	EvictionPolicyReject = "reject"
+	// EvictionPolicyEvictOldest deletes the least recently written keys of the
+	// writing component until the write fits in the budget.
+	EvictionPolicyEvictOldest = "evict_oldest"
+)
+
+// Config defines configuration for the disk budget extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Directory is the directory in which the data is persisted, as for the
+	// file_storage extension.
+	Directory string `mapstructure:"directory,omitempty"`
+
+	// Timeout is the timeout for opening the files of a client.
+	Timeout time.Duration `mapstructure:"timeout,omitempty"`
+
+	// MaxSizeMiB is the total number of MiB that all the components using this
+	// extension may store together.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+
+	// DefaultQuotaMiB is the number of MiB a single component may store when
+	// it has no entry in QuotasMiB. Zero means the component is only limited
+	// by MaxSizeMiB.
+	DefaultQuotaMiB int64 `mapstructure:"default_quota_mib"`
+
+	// QuotasMiB maps component IDs, e.g. "filelog" or "otlp/backup", to
+	// the number of MiB that the component may store.
+	QuotasMiB map[string]int64 `mapstructure:"quotas_mib"`
+
+	// EvictionPolicy decides what happens to a write that does not fit in the
+	// budget, one of "reject" or "evict_oldest".
+	EvictionPolicy string `mapstructure:"eviction_policy"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("directory must be specified")
+	}
+	if cfg.MaxSizeMiB <= 0 {
+		return errors.New("max_size_mib must be positive")
+	}
+	if cfg.DefaultQuotaMiB < 0 || cfg.DefaultQuotaMiB > cfg.MaxSizeMiB {
+		return fmt.Errorf("default_quota_mib must be between 0 and max_size_mib (%d)", cfg.MaxSizeMiB)
+	}
+	for id, quota := range cfg.QuotasMiB {
+		if _, err := config.NewComponentIDFromString(id); err != nil {
+			return fmt.Errorf("invalid component %q in quotas_mib: %w", id, err)
+		}
+		if quota <= 0 || quota > cfg.MaxSizeMiB {
+			return fmt.Errorf("quota of %q must be between 1 and max_size_mib (%d)", id, cfg.MaxSizeMiB)
+		}
+	}
+	switch cfg.EvictionPolicy {
+	case EvictionPolicyReject, EvictionPolicyEvictOldest:
+	default:
+		return fmt.Errorf("invalid eviction_policy %q, must be %q or %q", cfg.EvictionPolicy, EvictionPolicyReject, EvictionPolicyEvictOldest)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbudget
+
+import (
+	"path"
+	"testing"
+
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	require.Len(t, cfg.Extensions, 2)
+
+	ext0 := cfg.Extensions[config.NewComponentID(typeStr)]
+	assert.Equal(t, factory.CreateDefaultConfig(), ext0)
+
+	ext1 := cfg.Extensions[config.NewComponentIDWithName(typeStr, "all_settings")]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "all_settings")),
+			Directory:         "/var/lib/otelcol/disk_budget",
+			Timeout:           2 * time.Second,
+			MaxSizeMiB:        512,
+			DefaultQuotaMiB:   64,
+			QuotasMiB:         map[string]int64{"otlp/backup": 256},
+			EvictionPolicy:    EvictionPolicyEvictOldest,
+		},
+		ext1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "missing directory",
+			modify: func(cfg *Config) { cfg.Directory = "" },
+			err:    "directory must be specified",
+		},
+		{
+			name:   "no budget",
+			modify: func(cfg *Config) { cfg.MaxSizeMiB = 0 },
+			err:    "max_size_mib must be positive",
+		},
+		{
+			name:   "default quota over budget",
+			modify: func(cfg *Config) { cfg.DefaultQuotaMiB = 2048 },
+			err:    "default_quota_mib must be between 0 and max_size_mib (1024)",
+		},
+		{
+			name:   "quota over budget",
+			modify: func(cfg *Config) { cfg.QuotasMiB = map[string]int64{"otlp": 2048} },
+			err:    `quota of "otlp" must be between 1 and max_size_mib (1024)`,
+		},
+		{
+			name:   "invalid eviction policy",
+			modify: func(cfg *Config) { cfg.EvictionPolicy = "drop" },
+			err:    `invalid eviction_policy "drop", must be "reject" or "evict_oldest"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
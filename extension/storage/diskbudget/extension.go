@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbudget
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+)
+
+type diskBudget struct {
+	config  *Config
+	logger  *zap.Logger
+	budget  *budget
+	storage storage.Extension
+}
+
+// Ensure this storage extension implements the appropriate interface
+var _ storage.Extension = (*diskBudget)(nil)
+
+// newDiskBudget creates the extension together with the file storage that
+// persists the data. The file storage is owned by the extension rather than
+// configured separately, because components refuse to start when more than
+// one storage extension is configured.
+func newDiskBudget(ctx context.Context, params component.ExtensionCreateSettings, config *Config) (component.Extension, error) {
+	storageFactory := filestorage.NewFactory()
+	storageCfg := storageFactory.CreateDefaultConfig().(*filestorage.Config)
+	storageCfg.Directory = config.Directory
+	storageCfg.Timeout = config.Timeout
+	backing, err := storageFactory.CreateExtension(ctx, params, storageCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &diskBudget{
+		config:  config,
+		logger:  params.Logger,
+		budget:  newBudget(config),
+		storage: backing.(storage.Extension),
+	}, nil
+}
+
+// Start starts the file storage that persists the data
+func (db *diskBudget) Start(ctx context.Context, host component.Host) error {
+	return db.storage.Start(ctx, host)
+}
+
+// Shutdown shuts down the file storage, the clients persist their index when closed
+func (db *diskBudget) Shutdown(ctx context.Context) error {
+	return db.storage.Shutdown(ctx)
+}
+
+// GetClient returns a storage client for an individual component, which
+// accounts everything it stores against the budget
+func (db *diskBudget) GetClient(ctx context.Context, kind component.Kind, id config.ComponentID, name string) (storage.Client, error) {
+	backing, err := db.storage.GetClient(ctx, kind, id, name)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(ctx, backing, db.budget, id, db.config.EvictionPolicy == EvictionPolicyEvictOldest, db.logger)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbudget
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func newTestExtension(t *testing.T, directory string, modify func(*Config)) *diskBudget {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = directory
+	cfg.MaxSizeMiB = 1
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	ext, err := newDiskBudget(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	db := ext.(*diskBudget)
+	require.NoError(t, db.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, db.Shutdown(context.Background())) })
+	return db
+}
+
+func newTestClient(t *testing.T, db *diskBudget, name string) storage.Client {
+	c, err := db.GetClient(context.Background(), component.KindExporter, config.NewComponentIDWithName("otlp", name), "")
+	require.NoError(t, err)
+	return c
+}
+
+// value returns a value that, stored under a one byte key, takes size bytes.
+func value(size int) []byte {
+	return []byte(strings.Repeat("x", size-1))
+}
+
+func TestCreateMissingDirectory(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = "/not/very/likely/a/real/dir"
+	_, err := newDiskBudget(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "directory must exist")
+}
+
+func TestRejectOverBudget(t *testing.T) {
+	ctx := context.Background()
+	db := newTestExtension(t, t.TempDir(), nil)
+	first := newTestClient(t, db, "first")
+	second := newTestClient(t, db, "second")
+
+	require.NoError(t, first.Set(ctx, "a", value(600*1024)))
+	err := second.Set(ctx, "a", value(600*1024))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `disk budget exceeded: cannot store 614400 more bytes for "otlp/second"`)
+
+	v, err := second.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	// Overwriting and deleting release the previous bytes.
+	require.NoError(t, first.Set(ctx, "a", value(100*1024)))
+	require.NoError(t, second.Set(ctx, "a", value(600*1024)))
+	require.NoError(t, second.Delete(ctx, "a"))
+	require.NoError(t, first.Set(ctx, "b", value(900*1024)))
+
+	firstUsed, used := db.budget.usage(config.NewComponentIDWithName("otlp", "first"))
+	assert.Equal(t, int64(1000*1024), firstUsed)
+	assert.Equal(t, int64(1000*1024), used)
+}
+
+func TestQuotas(t *testing.T) {
+	ctx := context.Background()
+	db := newTestExtension(t, t.TempDir(), func(cfg *Config) {
+		cfg.MaxSizeMiB = 4
+		cfg.DefaultQuotaMiB = 1
+		cfg.QuotasMiB = map[string]int64{"otlp/big": 2}
+	})
+	small := newTestClient(t, db, "small")
+	big := newTestClient(t, db, "big")
+
+	assert.Error(t, small.Set(ctx, "a", value(1536*1024)))
+	assert.NoError(t, big.Set(ctx, "a", value(1536*1024)))
+	assert.Error(t, big.Set(ctx, "b", value(1024*1024)))
+}
+
+func TestEvictOldest(t *testing.T) {
+	ctx := context.Background()
+	db := newTestExtension(t, t.TempDir(), func(cfg *Config) {
+		cfg.EvictionPolicy = EvictionPolicyEvictOldest
+	})
+	first := newTestClient(t, db, "first")
+	second := newTestClient(t, db, "second")
+
+	require.NoError(t, first.Set(ctx, "a", value(300*1024)))
+	require.NoError(t, first.Set(ctx, "b", value(300*1024)))
+	require.NoError(t, first.Set(ctx, "c", value(300*1024)))
+	// Rewriting "a" makes "b" the oldest key.
+	require.NoError(t, first.Set(ctx, "a", value(300*1024)))
+
+	require.NoError(t, first.Batch(ctx,
+		storage.SetOperation("c", value(300*1024)),
+		storage.SetOperation("d", value(300*1024)),
+	))
+	for key, stored := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		v, err := first.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, stored, v != nil, key)
+	}
+
+	// Keys of other components are never evicted.
+	assert.Error(t, second.Set(ctx, "a", value(300*1024)))
+	// Keys written by the batch are never evicted.
+	assert.Error(t, first.Batch(ctx,
+		storage.SetOperation("a", value(500*1024)),
+		storage.SetOperation("c", value(500*1024)),
+		storage.SetOperation("d", value(500*1024)),
+	))
+}
+
+func TestIndexPersistence(t *testing.T) {
+	ctx := context.Background()
+	directory := t.TempDir()
+	id := config.NewComponentIDWithName("otlp", "first")
+
+	db := newTestExtension(t, directory, nil)
+	c := newTestClient(t, db, "first")
+	require.NoError(t, c.Set(ctx, "a", value(600*1024)))
+	require.NoError(t, c.Close(ctx))
+	used, _ := db.budget.usage(id)
+	assert.Zero(t, used)
+
+	db = newTestExtension(t, directory, nil)
+	c = newTestClient(t, db, "first")
+	used, _ = db.budget.usage(id)
+	assert.Equal(t, int64(600*1024), used)
+	assert.Error(t, c.Set(ctx, "b", value(600*1024)))
+	require.NoError(t, c.Close(ctx))
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build enable_unstable
+// +build enable_unstable
+
+package diskbudget
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// TestPersistentQueue runs the persistent queue of an exporter with the
+// extensions of the example configuration, in which disk_budget is the only
+// storage extension.
+func TestPersistentQueue(t *testing.T) {
+	ctx := context.Background()
+	db := newTestExtension(t, t.TempDir(), nil)
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID(typeStr):        db,
+			config.NewComponentID("health_check"): componenthelper.New(),
+		},
+	}
+
+	id := config.NewComponentIDWithName("otlp", "backup")
+	var pushed int64
+	qCfg := exporterhelper.DefaultQueueSettings()
+	qCfg.PersistentStorageEnabled = true
+	cfg := config.NewExporterSettings(id)
+	exp, err := exporterhelper.NewTracesExporter(
+		&cfg,
+		componenttest.NewNopExporterCreateSettings(),
+		func(context.Context, pdata.Traces) error {
+			atomic.AddInt64(&pushed, 1)
+			return nil
+		},
+		exporterhelper.WithQueue(qCfg),
+	)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(ctx, host))
+	defer func() { require.NoError(t, exp.Shutdown(ctx)) }()
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.ConsumeTraces(ctx, td))
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&pushed) == 1 }, time.Second, 10*time.Millisecond)
+
+	// The queue persists its indexes through the budget.
+	used, _ := db.budget.usage(id)
+	assert.Greater(t, used, int64(0))
+}
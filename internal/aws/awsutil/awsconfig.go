@@ -21,6 +21,12 @@ type AWSSessionSettings struct {
 	NumberOfWorkers int `mapstructure:"num_workers"`
 	// X-Ray service endpoint to which the collector sends segment documents.
 	Endpoint string `mapstructure:"endpoint"`
+	// Use the FIPS endpoint of the service in the region.
+	UseFIPSEndpoint bool `mapstructure:"use_fips_endpoint"`
+	// Use the dual-stack (IPv4 and IPv6) endpoint of the service in the region.
+	UseDualStackEndpoint bool `mapstructure:"use_dualstack_endpoint"`
+	// Region requests sent to Endpoint are signed for, derived from Endpoint by default.
+	SigningRegion string `mapstructure:"signing_region"`
 	// Number of seconds before timing out a request.
 	RequestTimeoutSeconds int `mapstructure:"request_timeout_seconds"`
 	// Maximum number of retries before abandoning an attempt to post data.
@@ -43,6 +49,9 @@ func CreateDefaultSessionConfig() AWSSessionSettings {
 	return AWSSessionSettings{
 		NumberOfWorkers:       8,
 		Endpoint:              "",
+		UseFIPSEndpoint:       false,
+		UseDualStackEndpoint:  false,
+		SigningRegion:         "",
 		RequestTimeoutSeconds: 30,
 		MaxRetries:            2,
 		NoVerifySSL:           false,
@@ -53,3 +62,13 @@ func CreateDefaultSessionConfig() AWSSessionSettings {
 		RoleARN:               "",
 	}
 }
+
+// EndpointSettings returns the settings of the endpoint of the service.
+func (s *AWSSessionSettings) EndpointSettings() EndpointSettings {
+	return EndpointSettings{
+		Endpoint:             s.Endpoint,
+		UseFIPSEndpoint:      s.UseFIPSEndpoint,
+		UseDualStackEndpoint: s.UseDualStackEndpoint,
+		SigningRegion:        s.SigningRegion,
+	}
+}
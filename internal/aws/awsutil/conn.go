@@ -126,6 +126,9 @@ func GetAWSConfigSession(logger *zap.Logger, cn ConnAttr, cfg *AWSSessionSetting
 	} else if cfg.Region != "" {
 		awsRegion = cfg.Region
 		logger.Debug("Fetch region from commandline/config file", zap.String("region", awsRegion))
+	} else if endpointRegion := RegionFromEndpoint(cfg.Endpoint); endpointRegion != "" {
+		awsRegion = endpointRegion
+		logger.Debug("Fetch region from endpoint", zap.String("region", awsRegion))
 	} else if !cfg.NoVerifySSL {
 		var es *session.Session
 		es, err = GetDefaultSession(logger)
@@ -154,9 +157,12 @@ func GetAWSConfigSession(logger *zap.Logger, cn ConnAttr, cfg *AWSSessionSetting
 		Region:                 aws.String(awsRegion),
 		DisableParamValidation: aws.Bool(true),
 		MaxRetries:             aws.Int(cfg.MaxRetries),
-		Endpoint:               aws.String(cfg.Endpoint),
 		HTTPClient:             http,
 	}
+	if err = ConfigureEndpoint(config, cfg.EndpointSettings()); err != nil {
+		logger.Error("Unable to configure the endpoint", zap.Error(err))
+		return nil, nil, err
+	}
 	return config, s, nil
 }
 
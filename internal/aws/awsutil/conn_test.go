@@ -83,6 +83,28 @@ func TestRegionEnv(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// fetch region value from the endpoint override
+func TestRegionFromEndpointSession(t *testing.T) {
+	logger := zap.NewNop()
+	sessionCfg := CreateDefaultSessionConfig()
+	sessionCfg.Endpoint = "https://vpce-0123-abcd.logs.eu-west-1.vpce.amazonaws.com"
+	env := stashEnv()
+	defer popEnv(env)
+
+	var m = &mockConn{}
+	var expectedSession *session.Session
+	expectedSession, _ = session.NewSession()
+	m.sn = expectedSession
+	cfg, s, err := GetAWSConfigSession(logger, m, &sessionCfg)
+	assert.Nil(t, err)
+	assert.Equal(t, s, expectedSession, "Expect the session object is not overridden")
+	assert.Equal(t, "eu-west-1", *cfg.Region, "Region value fetched from endpoint")
+	assert.Nil(t, cfg.Endpoint, "Endpoint is left to the resolver")
+	resolved, err := cfg.EndpointResolver.EndpointFor("logs", *cfg.Region)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://vpce-0123-abcd.logs.eu-west-1.vpce.amazonaws.com", resolved.URL)
+}
+
 func TestGetAWSConfigSessionWithSessionErr(t *testing.T) {
 	logger := zap.NewNop()
 	sessionCfg := CreateDefaultSessionConfig()
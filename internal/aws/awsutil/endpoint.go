@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsutil
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// EndpointSettings defines how the endpoint of an AWS service is chosen.
+type EndpointSettings struct {
+	// Endpoint overrides the endpoint of the service, e.g. a VPC interface
+	// endpoint. The service endpoint of the region is used if empty.
+	Endpoint string
+	// UseFIPSEndpoint selects the FIPS endpoint of the service in the region.
+	UseFIPSEndpoint bool
+	// UseDualStackEndpoint selects the dual-stack (IPv4 and IPv6) endpoint of
+	// the service in the region.
+	UseDualStackEndpoint bool
+	// SigningRegion is the region requests sent to Endpoint are signed for.
+	// It's derived from Endpoint if empty.
+	SigningRegion string
+}
+
+// dualStackDNSSuffixes are the DNS suffixes of dual-stack endpoints by
+// partition.
+var dualStackDNSSuffixes = map[string]string{
+	endpoints.AwsPartitionID:      "api.aws",
+	endpoints.AwsUsGovPartitionID: "api.aws",
+	endpoints.AwsCnPartitionID:    "api.amazonwebservices.com.cn",
+}
+
+// fipsPartitions are the partitions with FIPS endpoints.
+var fipsPartitions = map[string]bool{
+	endpoints.AwsPartitionID:      true,
+	endpoints.AwsUsGovPartitionID: true,
+}
+
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+// Validate checks if the endpoint settings are valid.
+func (s EndpointSettings) Validate() error {
+	if s.Endpoint != "" {
+		if s.UseFIPSEndpoint || s.UseDualStackEndpoint {
+			return errors.New("'endpoint' can't be combined with 'use_fips_endpoint' or 'use_dualstack_endpoint'")
+		}
+		if _, err := endpointHost(s.Endpoint); err != nil {
+			return err
+		}
+	} else if s.SigningRegion != "" {
+		return errors.New("'signing_region' requires 'endpoint'")
+	}
+	return nil
+}
+
+// ConfigureEndpoint sets the endpoint resolver of cfg according to settings.
+// The region of cfg is derived from the endpoint override when not set, so that
+// requests sent to the override are signed for the right region.
+func ConfigureEndpoint(cfg *aws.Config, settings EndpointSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	if settings.Endpoint != "" && aws.StringValue(cfg.Region) == "" {
+		region, err := settings.signingRegion("")
+		if err != nil {
+			return err
+		}
+		cfg.Region = aws.String(region)
+	}
+	// The endpoint must be left to the resolver, the SDK would otherwise sign
+	// requests for the configured region.
+	cfg.Endpoint = nil
+	cfg.EndpointResolver = NewEndpointResolver(settings)
+	return nil
+}
+
+// NewEndpointResolver returns a resolver of the endpoints of AWS services
+// honoring settings. It falls back to the default resolver of the SDK for
+// regular endpoints.
+func NewEndpointResolver(settings EndpointSettings) endpoints.Resolver {
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		switch {
+		case settings.Endpoint != "":
+			signingRegion, err := settings.signingRegion(region)
+			if err != nil {
+				return endpoints.ResolvedEndpoint{}, err
+			}
+			return endpoints.ResolvedEndpoint{
+				URL:           endpoints.AddScheme(settings.Endpoint, false),
+				SigningRegion: signingRegion,
+			}, nil
+		case settings.UseFIPSEndpoint || settings.UseDualStackEndpoint:
+			return resolveVariantEndpoint(service, region, settings.UseFIPSEndpoint, settings.UseDualStackEndpoint)
+		default:
+			return endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+		}
+	})
+}
+
+// signingRegion returns the region requests sent to the endpoint override
+// are signed for: the configured signing region, the region in the hostname
+// of the endpoint, or the region of the client.
+func (s EndpointSettings) signingRegion(region string) (string, error) {
+	if s.SigningRegion != "" {
+		return s.SigningRegion, nil
+	}
+	if derived := RegionFromEndpoint(s.Endpoint); derived != "" {
+		return derived, nil
+	}
+	if region == "" {
+		return "", fmt.Errorf("cannot derive the signing region from endpoint %q, set 'region' or 'signing_region'", s.Endpoint)
+	}
+	return region, nil
+}
+
+// RegionFromEndpoint returns the region in the hostname of an AWS endpoint,
+// e.g. us-east-1 for logs.us-east-1.amazonaws.com,
+// logs-fips.us-east-1.amazonaws.com, logs.us-east-1.api.aws or
+// vpce-0123-abcd.logs.us-east-1.vpce.amazonaws.com. It returns an empty string
+// if the hostname holds no region.
+func RegionFromEndpoint(endpoint string) string {
+	host, err := endpointHost(endpoint)
+	if err != nil {
+		return ""
+	}
+	for _, label := range strings.Split(host, ".") {
+		if regionPattern.MatchString(label) {
+			return label
+		}
+	}
+	return ""
+}
+
+func endpointHost(endpoint string) (string, error) {
+	u, err := url.Parse(endpoints.AddScheme(endpoint, false))
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid endpoint %q: missing host", endpoint)
+	}
+	return u.Hostname(), nil
+}
+
+// resolveVariantEndpoint resolves the FIPS and/or dual-stack endpoint of a
+// service, e.g. logs-fips.us-east-1.amazonaws.com or
+// logs-fips.us-gov-west-1.api.aws.
+func resolveVariantEndpoint(service, region string, fips, dualStack bool) (endpoints.ResolvedEndpoint, error) {
+	if region == "" {
+		return endpoints.ResolvedEndpoint{}, errors.New("a region is required to resolve FIPS and dual-stack endpoints")
+	}
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return endpoints.ResolvedEndpoint{}, fmt.Errorf("unknown partition of region %q", region)
+	}
+
+	suffix := partition.DNSSuffix()
+	if dualStack {
+		if suffix, ok = dualStackDNSSuffixes[partition.ID()]; !ok {
+			return endpoints.ResolvedEndpoint{}, fmt.Errorf("dual-stack endpoints aren't available in partition %q", partition.ID())
+		}
+	}
+	name := service
+	if fips {
+		if !fipsPartitions[partition.ID()] {
+			return endpoints.ResolvedEndpoint{}, fmt.Errorf("FIPS endpoints aren't available in partition %q", partition.ID())
+		}
+		name += "-fips"
+	}
+	return endpoints.ResolvedEndpoint{
+		URL:           "https://" + name + "." + region + "." + suffix,
+		PartitionID:   partition.ID(),
+		SigningRegion: region,
+	}, nil
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsutil
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionFromEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		region   string
+	}{
+		{endpoint: "logs.us-east-1.amazonaws.com", region: "us-east-1"},
+		{endpoint: "https://logs-fips.us-gov-west-1.amazonaws.com", region: "us-gov-west-1"},
+		{endpoint: "https://logs.cn-north-1.amazonaws.com.cn:443", region: "cn-north-1"},
+		{endpoint: "monitoring.eu-west-3.api.aws", region: "eu-west-3"},
+		{endpoint: "https://vpce-0123-abcd.logs.ap-southeast-2.vpce.amazonaws.com", region: "ap-southeast-2"},
+		{endpoint: "vpce-0123-abcd-us-east-1a.logs.us-east-1.vpce.amazonaws.com", region: "us-east-1"},
+		{endpoint: "http://localhost:4566", region: ""},
+		{endpoint: "", region: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			assert.Equal(t, tt.region, RegionFromEndpoint(tt.endpoint))
+		})
+	}
+}
+
+func TestEndpointSettingsValidate(t *testing.T) {
+	assert.NoError(t, EndpointSettings{}.Validate())
+	assert.NoError(t, EndpointSettings{UseFIPSEndpoint: true, UseDualStackEndpoint: true}.Validate())
+	assert.NoError(t, EndpointSettings{Endpoint: "localhost:4566", SigningRegion: "us-east-1"}.Validate())
+	assert.EqualError(t, EndpointSettings{Endpoint: "logs.us-east-1.amazonaws.com", UseFIPSEndpoint: true}.Validate(),
+		"'endpoint' can't be combined with 'use_fips_endpoint' or 'use_dualstack_endpoint'")
+	assert.EqualError(t, EndpointSettings{SigningRegion: "us-east-1"}.Validate(), "'signing_region' requires 'endpoint'")
+	assert.Error(t, EndpointSettings{Endpoint: "https://"}.Validate())
+}
+
+func TestEndpointResolver(t *testing.T) {
+	tests := []struct {
+		name          string
+		settings      EndpointSettings
+		region        string
+		url           string
+		signingRegion string
+		err           string
+	}{
+		{
+			name:          "default",
+			region:        "us-east-1",
+			url:           "https://logs.us-east-1.amazonaws.com",
+			signingRegion: "us-east-1",
+		},
+		{
+			name:          "override",
+			settings:      EndpointSettings{Endpoint: "vpce-0123-abcd.logs.eu-west-1.vpce.amazonaws.com"},
+			region:        "us-east-1",
+			url:           "https://vpce-0123-abcd.logs.eu-west-1.vpce.amazonaws.com",
+			signingRegion: "eu-west-1",
+		},
+		{
+			name:          "override without region in hostname",
+			settings:      EndpointSettings{Endpoint: "http://localhost:4566"},
+			region:        "us-east-1",
+			url:           "http://localhost:4566",
+			signingRegion: "us-east-1",
+		},
+		{
+			name:          "override with signing region",
+			settings:      EndpointSettings{Endpoint: "http://localhost:4566", SigningRegion: "eu-west-1"},
+			region:        "us-east-1",
+			url:           "http://localhost:4566",
+			signingRegion: "eu-west-1",
+		},
+		{
+			name:     "override without any region",
+			settings: EndpointSettings{Endpoint: "http://localhost:4566"},
+			err:      `cannot derive the signing region from endpoint "http://localhost:4566", set 'region' or 'signing_region'`,
+		},
+		{
+			name:          "fips",
+			settings:      EndpointSettings{UseFIPSEndpoint: true},
+			region:        "us-west-2",
+			url:           "https://logs-fips.us-west-2.amazonaws.com",
+			signingRegion: "us-west-2",
+		},
+		{
+			name:          "dual-stack",
+			settings:      EndpointSettings{UseDualStackEndpoint: true},
+			region:        "cn-north-1",
+			url:           "https://logs.cn-north-1.api.amazonwebservices.com.cn",
+			signingRegion: "cn-north-1",
+		},
+		{
+			name:          "fips dual-stack",
+			settings:      EndpointSettings{UseFIPSEndpoint: true, UseDualStackEndpoint: true},
+			region:        "us-gov-west-1",
+			url:           "https://logs-fips.us-gov-west-1.api.aws",
+			signingRegion: "us-gov-west-1",
+		},
+		{
+			name:     "fips in china",
+			settings: EndpointSettings{UseFIPSEndpoint: true},
+			region:   "cn-north-1",
+			err:      `FIPS endpoints aren't available in partition "aws-cn"`,
+		},
+		{
+			name:     "fips without region",
+			settings: EndpointSettings{UseFIPSEndpoint: true},
+			err:      "a region is required to resolve FIPS and dual-stack endpoints",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := NewEndpointResolver(tt.settings).EndpointFor("logs", tt.region)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.url, resolved.URL)
+			assert.Equal(t, tt.signingRegion, resolved.SigningRegion)
+		})
+	}
+}
+
+func TestConfigureEndpoint(t *testing.T) {
+	cfg := &aws.Config{Endpoint: aws.String("stale")}
+	require.NoError(t, ConfigureEndpoint(cfg, EndpointSettings{Endpoint: "logs-fips.us-gov-east-1.amazonaws.com"}))
+	assert.Nil(t, cfg.Endpoint)
+	assert.Equal(t, "us-gov-east-1", aws.StringValue(cfg.Region))
+	resolved, err := cfg.EndpointResolver.EndpointFor("logs", aws.StringValue(cfg.Region))
+	require.NoError(t, err)
+	assert.Equal(t, endpoints.ResolvedEndpoint{
+		URL:           "https://logs-fips.us-gov-east-1.amazonaws.com",
+		SigningRegion: "us-gov-east-1",
+	}, resolved)
+
+	// The configured region is kept.
+	cfg = &aws.Config{Region: aws.String("us-east-1")}
+	require.NoError(t, ConfigureEndpoint(cfg, EndpointSettings{Endpoint: "http://localhost:4566"}))
+	assert.Equal(t, "us-east-1", aws.StringValue(cfg.Region))
+
+	assert.Error(t, ConfigureEndpoint(&aws.Config{}, EndpointSettings{Endpoint: "http://localhost:4566"}))
+}
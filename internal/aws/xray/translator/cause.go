@@ -32,6 +32,27 @@ import (
 // TODO: Remove this when collector defines this semantic convention.
 const ExceptionEventName = "exception"
 
+const (
+	// exceptionIDAttribute identifies an exception event so that other exception
+	// events of the same span can reference it as their cause.
+	exceptionIDAttribute = "exception.id"
+	// exceptionCauseAttribute references the exception.id of the exception event
+	// that caused the exception recorded by this event.
+	exceptionCauseAttribute = "exception.cause"
+)
+
+// exceptionEvent keeps track of where the exceptions parsed from a single
+// exception event are located in the cause, so that events can be chained.
+type exceptionEvent struct {
+	id            string
+	cause         string
+	exceptionType string
+	message       string
+	stacktrace    string
+	first         int
+	last          int
+}
+
 func makeCause(span pdata.Span, attributes map[string]pdata.AttributeValue, resource pdata.Resource) (isError, isFault, isThrottle bool,
 	filtered map[string]pdata.AttributeValue, cause *awsxray.CauseData) {
 	status := span.Status()
@@ -61,6 +82,7 @@ func makeCause(span pdata.Span, attributes map[string]pdata.AttributeValue, reso
 		}
 
 		exceptions := make([]awsxray.Exception, 0)
+		events := make([]exceptionEvent, 0)
 		for i := 0; i < span.Events().Len(); i++ {
 			event := span.Events().At(i)
 			if event.Name() == ExceptionEventName {
@@ -81,9 +103,24 @@ func makeCause(span pdata.Span, attributes map[string]pdata.AttributeValue, reso
 				}
 
 				parsed := parseException(exceptionType, message, stacktrace, language)
+				ev := exceptionEvent{
+					exceptionType: exceptionType,
+					message:       message,
+					stacktrace:    stacktrace,
+					first:         len(exceptions),
+					last:          len(exceptions) + len(parsed) - 1,
+				}
+				if val, ok := event.Attributes().Get(exceptionIDAttribute); ok {
+					ev.id = val.StringVal()
+				}
+				if val, ok := event.Attributes().Get(exceptionCauseAttribute); ok {
+					ev.cause = val.StringVal()
+				}
+				events = append(events, ev)
 				exceptions = append(exceptions, parsed...)
 			}
 		}
+		chainExceptionEvents(events, exceptions, language)
 		cause = &awsxray.CauseData{
 			Type: awsxray.CauseTypeObject,
 			CauseObject: awsxray.CauseObject{
@@ -144,6 +181,76 @@ func makeCause(span pdata.Span, attributes map[string]pdata.AttributeValue, reso
 	return isError, isFault, isThrottle, filtered, cause
 }
 
+// chainExceptionEvents links exceptions recorded as separate events of the same
+// span. An event referencing another event through the exception.cause attribute
+// is linked to it explicitly. For .NET, where inner exceptions are recorded as
+// separate events, an event is also linked to a following event whose type and
+// message appear as an inner exception ("--->") in its stack trace.
+func chainExceptionEvents(events []exceptionEvent, exceptions []awsxray.Exception, language string) {
+	if len(events) < 2 {
+		return
+	}
+	linked := make([]bool, len(events))
+	for i, ev := range events {
+		if ev.cause == "" {
+			continue
+		}
+		for j, other := range events {
+			if j != i && other.id == ev.cause {
+				linkExceptionEvents(ev, other, exceptions)
+				linked[j] = true
+				break
+			}
+		}
+	}
+
+	if language != "dotnet" {
+		return
+	}
+	for i, ev := range events {
+		if ev.cause != "" || ev.stacktrace == "" {
+			continue
+		}
+		for j := i + 1; j < len(events); j++ {
+			if linked[j] {
+				continue
+			}
+			if hasDotnetInnerException(ev.stacktrace, events[j].exceptionType, events[j].message) {
+				linkExceptionEvents(ev, events[j], exceptions)
+				linked[j] = true
+				break
+			}
+		}
+	}
+}
+
+// linkExceptionEvents marks the innermost exception parsed from the outer event
+// as caused by the top level exception of the inner event.
+func linkExceptionEvents(outer, inner exceptionEvent, exceptions []awsxray.Exception) {
+	if exceptions[outer.last].Cause != nil {
+		return
+	}
+	exceptions[outer.last].Cause = exceptions[inner.first].ID
+}
+
+func hasDotnetInnerException(stacktrace string, exceptionType string, message string) bool {
+	inner := exceptionType
+	if message != "" {
+		inner += ": " + message
+	}
+	for _, line := range strings.Split(stacktrace, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "---> ") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "---> "))
+		if line == inner || strings.HasPrefix(line, inner+" ") {
+			return true
+		}
+	}
+	return false
+}
+
 func parseException(exceptionType string, message string, stacktrace string, language string) []awsxray.Exception {
 	exceptions := make([]awsxray.Exception, 0, 1)
 	exceptions = append(exceptions, awsxray.Exception{
@@ -223,7 +330,7 @@ func fillJavaStacktrace(stacktrace string, exceptions []awsxray.Exception) []aws
 			causeMessage := ""
 			if colonIdx >= 0 {
 				// Skip space after colon too.
-				causeMessage = causeType[colonIdx+2:]
+				causeMessage = strings.TrimPrefix(causeType[colonIdx+1:], " ")
 				causeType = causeType[0:colonIdx]
 			}
 			for {
@@ -290,7 +397,10 @@ func fillPythonStacktrace(stacktrace string, exceptions []awsxray.Exception) []a
 			parts := strings.Split(line, ",")
 			if len(parts) == 3 {
 				filePart := parts[0]
-				file := filePart[8 : len(filePart)-1]
+				file := ""
+				if len(filePart) > 8 {
+					file = filePart[8 : len(filePart)-1]
+				}
 				lineNumber := 0
 				if strings.HasPrefix(parts[1], " line ") {
 					lineNumber, _ = strconv.Atoi(parts[1][6:])
@@ -324,6 +434,10 @@ func fillPythonStacktrace(stacktrace string, exceptions []awsxray.Exception) []a
 
 			// Join message which potentially has newlines. Message starts two lines from the next "File " line and ends
 			// two lines before the "During handling " line.
+			if nextFileLineIdx+2 > lineIdx-1 {
+				// No room for a message between the lines, malformed stack trace.
+				return exceptions
+			}
 			message := strings.Join(lines[nextFileLineIdx+2:lineIdx-1], "\n")
 
 			lineIdx = nextFileLineIdx
@@ -335,7 +449,7 @@ func fillPythonStacktrace(stacktrace string, exceptions []awsxray.Exception) []a
 			}
 
 			causeType := message[0:colonIdx]
-			causeMessage := message[colonIdx+2:]
+			causeMessage := strings.TrimPrefix(message[colonIdx+1:], " ")
 			exceptions = append(exceptions, awsxray.Exception{
 				ID:      aws.String(newSegmentID().HexString()),
 				Type:    aws.String(causeType),
@@ -437,20 +551,19 @@ func fillDotnetStacktrace(stacktrace string, exceptions []awsxray.Exception) []a
 	exception.Stack = make([]awsxray.StackFrame, 0)
 	for {
 		if strings.HasPrefix(line, "\tat ") {
-			index := strings.Index(line, " in ")
+			frame := line[len("\tat "):]
+			index := strings.Index(frame, " in ")
 			if index >= 0 {
-				parts := strings.Split(line, " in ")
-
-				label := parts[0][len("\tat "):]
-				path := parts[1]
+				label := frame[:index]
+				path := frame[index+len(" in "):]
 				lineNumber := 0
 
-				colonIdx := strings.LastIndexByte(parts[1], ':')
+				colonIdx := strings.LastIndexByte(path, ':')
 				if colonIdx >= 0 {
 					lineStr := path[colonIdx+1:]
 
 					if strings.HasPrefix(lineStr, "line") {
-						lineStr = lineStr[5:]
+						lineStr = strings.TrimPrefix(lineStr[len("line"):], " ")
 					}
 					path = path[0:colonIdx]
 					lineNumber, _ = strconv.Atoi(lineStr)
@@ -72,6 +72,103 @@ Caused by: java.lang.IllegalArgumentException: bad argument`)
 	assert.Empty(t, cause.Exceptions[2].Message)
 }
 
+func TestCauseWithChainedExceptionEvents(t *testing.T) {
+	span := constructExceptionServerSpan(make(map[string]interface{}), pdata.StatusCodeError)
+
+	event1 := span.Events().AppendEmpty()
+	event1.SetName(ExceptionEventName)
+	event1.Attributes().InsertString(conventions.AttributeExceptionType, "java.lang.RuntimeException")
+	event1.Attributes().InsertString(conventions.AttributeExceptionMessage, "request failed")
+	event1.Attributes().InsertString(exceptionCauseAttribute, "db")
+
+	event2 := span.Events().AppendEmpty()
+	event2.SetName(ExceptionEventName)
+	event2.Attributes().InsertString(conventions.AttributeExceptionType, "java.sql.SQLException")
+	event2.Attributes().InsertString(conventions.AttributeExceptionMessage, "connection refused")
+	event2.Attributes().InsertString(exceptionIDAttribute, "db")
+
+	event3 := span.Events().AppendEmpty()
+	event3.SetName(ExceptionEventName)
+	event3.Attributes().InsertString(conventions.AttributeExceptionType, "UnrelatedError")
+
+	filtered, _ := makeHTTP(span)
+	res := pdata.NewResource()
+	res.Attributes().InsertString(conventions.AttributeTelemetrySDKLanguage, "java")
+	_, _, _, _, cause := makeCause(span, filtered, res)
+
+	assert.NotNil(t, cause)
+	assert.Len(t, cause.Exceptions, 3)
+	assert.Equal(t, cause.Exceptions[1].ID, cause.Exceptions[0].Cause)
+	assert.Nil(t, cause.Exceptions[1].Cause)
+	assert.Nil(t, cause.Exceptions[2].Cause)
+}
+
+func TestCauseWithChainedExceptionEventsAndStacktraceCause(t *testing.T) {
+	span := constructExceptionServerSpan(make(map[string]interface{}), pdata.StatusCodeError)
+
+	event1 := span.Events().AppendEmpty()
+	event1.SetName(ExceptionEventName)
+	event1.Attributes().InsertString(conventions.AttributeExceptionType, "java.lang.IllegalStateException")
+	event1.Attributes().InsertString(conventions.AttributeExceptionMessage, "bad state")
+	event1.Attributes().InsertString(conventions.AttributeExceptionStacktrace, `java.lang.IllegalStateException: bad state
+	at com.example.Foo.bar(Foo.java:10)
+Caused by: java.lang.IllegalArgumentException: bad argument`)
+	event1.Attributes().InsertString(exceptionCauseAttribute, "root")
+
+	event2 := span.Events().AppendEmpty()
+	event2.SetName(ExceptionEventName)
+	event2.Attributes().InsertString(conventions.AttributeExceptionType, "java.io.IOException")
+	event2.Attributes().InsertString(exceptionIDAttribute, "root")
+
+	filtered, _ := makeHTTP(span)
+	res := pdata.NewResource()
+	res.Attributes().InsertString(conventions.AttributeTelemetrySDKLanguage, "java")
+	_, _, _, _, cause := makeCause(span, filtered, res)
+
+	assert.NotNil(t, cause)
+	assert.Len(t, cause.Exceptions, 3)
+	assert.Equal(t, cause.Exceptions[1].ID, cause.Exceptions[0].Cause)
+	assert.Equal(t, cause.Exceptions[2].ID, cause.Exceptions[1].Cause)
+	assert.Nil(t, cause.Exceptions[2].Cause)
+}
+
+func TestCauseWithDotnetInnerExceptionEvents(t *testing.T) {
+	span := constructExceptionServerSpan(make(map[string]interface{}), pdata.StatusCodeError)
+
+	event1 := span.Events().AppendEmpty()
+	event1.SetName(ExceptionEventName)
+	event1.Attributes().InsertString(conventions.AttributeExceptionType, "System.Exception")
+	event1.Attributes().InsertString(conventions.AttributeExceptionMessage, "outer")
+	event1.Attributes().InsertString(conventions.AttributeExceptionStacktrace, `System.Exception: outer
+ ---> System.InvalidOperationException: inner
+	at App.Service.Run() in /src/Service.cs:line 12
+	--- End of inner exception stack trace ---
+	at App.Controller.Get() in /src/Controller.cs:line 30`)
+
+	event2 := span.Events().AppendEmpty()
+	event2.SetName(ExceptionEventName)
+	event2.Attributes().InsertString(conventions.AttributeExceptionType, "System.TimeoutException")
+	event2.Attributes().InsertString(conventions.AttributeExceptionMessage, "unrelated")
+
+	event3 := span.Events().AppendEmpty()
+	event3.SetName(ExceptionEventName)
+	event3.Attributes().InsertString(conventions.AttributeExceptionType, "System.InvalidOperationException")
+	event3.Attributes().InsertString(conventions.AttributeExceptionMessage, "inner")
+	event3.Attributes().InsertString(conventions.AttributeExceptionStacktrace, `System.InvalidOperationException: inner
+	at App.Service.Run() in /src/Service.cs:line 12`)
+
+	filtered, _ := makeHTTP(span)
+	res := pdata.NewResource()
+	res.Attributes().InsertString(conventions.AttributeTelemetrySDKLanguage, "dotnet")
+	_, _, _, _, cause := makeCause(span, filtered, res)
+
+	assert.NotNil(t, cause)
+	assert.Len(t, cause.Exceptions, 3)
+	assert.Equal(t, cause.Exceptions[2].ID, cause.Exceptions[0].Cause)
+	assert.Nil(t, cause.Exceptions[1].Cause)
+	assert.Nil(t, cause.Exceptions[2].Cause)
+}
+
 func TestCauseWithStatusMessage(t *testing.T) {
 	errorMsg := "this is a test"
 	attributes := make(map[string]interface{})
@@ -1116,3 +1213,25 @@ created by testing.(*T).Run
 	assert.Equal(t, "/usr/local/Cellar/go/1.16.3/libexec/src/testing/testing.go", *exceptions[0].Stack[10].Path)
 	assert.Equal(t, 1238, *exceptions[0].Stack[10].Line)
 }
+
+func TestParseExceptionMalformedStacktraceDoesNotPanic(t *testing.T) {
+	tests := []struct {
+		name       string
+		stacktrace string
+		language   string
+	}{
+		{name: "java cause ending with colon", stacktrace: "\nCaused by: :", language: "java"},
+		{name: "python short file line", stacktrace: "  File ,,\n", language: "python"},
+		{name: "python cause without message", stacktrace: "  File \"a.py\", line 1, in <module>\nDuring handling of the above exception, another exception occurred:\n", language: "python"},
+		{name: "dotnet frame without label", stacktrace: "\n\tat in 0", language: "dotnet"},
+		{name: "dotnet line without number", stacktrace: "\n\tat Foo() in Foo.cs:line", language: "dotnet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				exceptions := parseException("0", "0", tt.stacktrace, tt.language)
+				assert.NotEmpty(t, exceptions)
+			})
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	awsP "github.com/aws/aws-sdk-go/aws"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// EventsMode controls how the span events other than exceptions are
+// translated. Exception events are always recorded in the cause of the
+// segment.
+type EventsMode string
+
+const (
+	// EventsModeDrop drops the events.
+	EventsModeDrop EventsMode = "drop"
+	// EventsModeSubsegments records each event as a zero-duration embedded
+	// subsegment named after the event, whose attributes are recorded as
+	// annotations or metadata like the ones of spans.
+	EventsModeSubsegments EventsMode = "subsegments"
+	// EventsModeMetadata records the events in the "otel.events" entry of
+	// the default metadata namespace of the segment.
+	EventsModeMetadata EventsMode = "metadata"
+)
+
+// eventsMetadataKey is the key of the events in the default metadata
+// namespace.
+const eventsMetadataKey = "otel.events"
+
+// addEvents records the span events other than exceptions to the segment.
+func addEvents(segment *awsxray.Segment, span pdata.Span, opts TranslateOptions) {
+	if opts.Events != EventsModeSubsegments && opts.Events != EventsModeMetadata {
+		return
+	}
+
+	var metadataEvents []interface{}
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Name() == ExceptionEventName {
+			continue
+		}
+		ts := event.Timestamp()
+		if ts == 0 {
+			ts = span.StartTimestamp()
+		}
+
+		switch opts.Events {
+		case EventsModeSubsegments:
+			attributes := make(map[string]pdata.AttributeValue, event.Attributes().Len())
+			event.Attributes().Range(func(key string, value pdata.AttributeValue) bool {
+				attributes[key] = value
+				return true
+			})
+			_, annotations, metadata := makeXRayAttributes(attributes, pdata.NewResource(), false, opts.IndexedAttributes, opts.IndexAllAttributes)
+			timestamp := timestampToFloatSeconds(ts)
+			segment.Subsegments = append(segment.Subsegments, awsxray.Segment{
+				Name:        awsxray.String(fixSegmentName(event.Name())),
+				ID:          awsxray.String(newSegmentID().HexString()),
+				StartTime:   awsP.Float64(timestamp),
+				EndTime:     awsP.Float64(timestamp),
+				Annotations: annotations,
+				Metadata:    metadata,
+			})
+		case EventsModeMetadata:
+			attributes := make(map[string]interface{}, event.Attributes().Len())
+			event.Attributes().Range(func(key string, value pdata.AttributeValue) bool {
+				if metaVal := metadataValue(value); metaVal != nil {
+					attributes[key] = metaVal
+				}
+				return true
+			})
+			metadataEvents = append(metadataEvents, map[string]interface{}{
+				"name":       event.Name(),
+				"timestamp":  timestampToFloatSeconds(ts),
+				"attributes": attributes,
+			})
+		}
+	}
+
+	if len(metadataEvents) == 0 {
+		return
+	}
+	if segment.Metadata == nil {
+		segment.Metadata = map[string]map[string]interface{}{}
+	}
+	if segment.Metadata["default"] == nil {
+		segment.Metadata["default"] = map[string]interface{}{}
+	}
+	segment.Metadata["default"][eventsMetadataKey] = metadataEvents
+}
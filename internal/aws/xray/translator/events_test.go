@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+func constructSpanWithEvents() pdata.Span {
+	span := constructServerSpan(pdata.NewSpanID([8]byte{}), "/api/locations", pdata.StatusCodeOk, "OK",
+		map[string]interface{}{})
+
+	checkpoint := span.Events().AppendEmpty()
+	checkpoint.SetName("cache miss")
+	checkpoint.SetTimestamp(pdata.NewTimestampFromTime(time.Unix(1633428000, 500000000)))
+	checkpoint.Attributes().InsertString("cache.key", "locations")
+	checkpoint.Attributes().InsertInt("cache.size", 42)
+
+	exception := span.Events().AppendEmpty()
+	exception.SetName(ExceptionEventName)
+	exception.Attributes().InsertString(conventions.AttributeExceptionType, "java.lang.IllegalStateException")
+	exception.Attributes().InsertString(conventions.AttributeExceptionMessage, "bad state")
+
+	retry := span.Events().AppendEmpty()
+	retry.SetName("retry!")
+	return span
+}
+
+func TestTranslateSpanEventsDropped(t *testing.T) {
+	span := constructSpanWithEvents()
+
+	segment, err := TranslateSpan(span, TranslateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, segment.Subsegments)
+	assert.NotContains(t, segment.Metadata["default"], eventsMetadataKey)
+
+	segment, err = TranslateSpan(span, TranslateOptions{Events: EventsModeDrop})
+	require.NoError(t, err)
+	assert.Empty(t, segment.Subsegments)
+}
+
+func TestTranslateSpanEventsSubsegments(t *testing.T) {
+	span := constructSpanWithEvents()
+
+	segment, err := TranslateSpan(span, TranslateOptions{
+		Events:            EventsModeSubsegments,
+		IndexedAttributes: []string{"cache.key"},
+	})
+	require.NoError(t, err)
+	require.Len(t, segment.Subsegments, 2)
+
+	checkpoint := segment.Subsegments[0]
+	assert.Equal(t, "cache miss", *checkpoint.Name)
+	assert.Len(t, *checkpoint.ID, 16)
+	assert.Equal(t, 1633428000.5, *checkpoint.StartTime)
+	assert.Equal(t, *checkpoint.StartTime, *checkpoint.EndTime)
+	assert.Equal(t, map[string]interface{}{"cache_key": "locations"}, checkpoint.Annotations)
+	assert.Equal(t, map[string]map[string]interface{}{"default": {"cache.size": int64(42)}}, checkpoint.Metadata)
+
+	// Events without a timestamp are placed at the start of the span, and
+	// their name is fixed like segment names.
+	retry := segment.Subsegments[1]
+	assert.Equal(t, "retry", *retry.Name)
+	assert.Equal(t, timestampToFloatSeconds(span.StartTimestamp()), *retry.StartTime)
+	assert.Nil(t, retry.Annotations)
+	assert.Nil(t, retry.Metadata)
+}
+
+func TestTranslateSpanEventsMetadata(t *testing.T) {
+	span := constructSpanWithEvents()
+
+	segment, err := TranslateSpan(span, TranslateOptions{Events: EventsModeMetadata})
+	require.NoError(t, err)
+	assert.Empty(t, segment.Subsegments)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":      "cache miss",
+			"timestamp": 1633428000.5,
+			"attributes": map[string]interface{}{
+				"cache.key":  "locations",
+				"cache.size": int64(42),
+			},
+		},
+		map[string]interface{}{
+			"name":       "retry!",
+			"timestamp":  timestampToFloatSeconds(span.StartTimestamp()),
+			"attributes": map[string]interface{}{},
+		},
+	}, segment.Metadata["default"][eventsMetadataKey])
+}
+
+func TestTranslateSpanDocumentEvents(t *testing.T) {
+	span := constructSpanWithEvents()
+
+	document, err := TranslateSpanDocument(span, TranslateOptions{Events: EventsModeSubsegments})
+	require.NoError(t, err)
+
+	var parsed struct {
+		Subsegments []map[string]interface{} `json:"subsegments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(document), &parsed))
+	require.Len(t, parsed.Subsegments, 2)
+	assert.Equal(t, "cache miss", parsed.Subsegments[0]["name"])
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package translator
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+var fuzzLanguages = []string{"java", "python", "javascript", "dotnet", "php", "go", ""}
+
+func FuzzParseException(f *testing.F) {
+	f.Add("java.lang.IllegalStateException", "bad state", "java.lang.IllegalStateException: bad state\n\tat Foo.bar(Foo.java:10)\nCaused by: java.lang.IllegalArgumentException: bad argument\n\t... 1 more", uint8(0))
+	f.Add("ValueError", "bad value", "Traceback (most recent call last):\n  File \"main.py\", line 14, in <module>\n    foo()\nValueError: bad value", uint8(1))
+	f.Add("TypeError", "oops", "TypeError: oops\n    at foo (/app/index.js:10:5)\n    at bar (native)", uint8(2))
+	f.Add("System.Exception", "outer", "System.Exception: outer\n ---> System.InvalidOperationException: inner\n\tat App.Run() in /src/App.cs:line 12", uint8(3))
+	f.Add("Exception", "php", "Exception: php\n\tat foo(/app/index.php:10)", uint8(4))
+	f.Add("*errors.errorString", "go", "goroutine 1 [running]:\nmain.main()\n\t/app/main.go:10 +0x1d", uint8(5))
+	f.Add("", "", "", uint8(6))
+
+	f.Fuzz(func(t *testing.T, exceptionType string, message string, stacktrace string, language uint8) {
+		parseException(exceptionType, message, stacktrace, fuzzLanguages[int(language)%len(fuzzLanguages)])
+	})
+}
+
+func FuzzTranslateSpan(f *testing.F) {
+	f.Add("GET /users", "java", "java.lang.IllegalStateException", "java.lang.IllegalStateException: bad\n\tat Foo.bar(Foo.java:10)", "http://example.com/users?id=1", int64(500))
+	f.Add("", "dotnet", "System.Exception", "System.Exception: outer\n ---> System.Exception: inner", "", int64(0))
+
+	f.Fuzz(func(t *testing.T, name string, language string, exceptionType string, stacktrace string, url string, statusCode int64) {
+		resource := pdata.NewResource()
+		resource.Attributes().InsertString(conventions.AttributeTelemetrySDKLanguage, language)
+
+		span := pdata.NewSpan()
+		span.SetName(name)
+		span.SetTraceID(newTraceID())
+		span.SetSpanID(newSegmentID())
+		span.SetKind(pdata.SpanKindServer)
+		span.Status().SetCode(pdata.StatusCodeError)
+		span.Attributes().InsertString(conventions.AttributeHTTPURL, url)
+		span.Attributes().InsertInt(conventions.AttributeHTTPStatusCode, statusCode)
+		event := span.Events().AppendEmpty()
+		event.SetName(ExceptionEventName)
+		event.Attributes().InsertString(conventions.AttributeExceptionType, exceptionType)
+		event.Attributes().InsertString(conventions.AttributeExceptionStacktrace, stacktrace)
+
+		// MakeSegment is used directly so that panics are not hidden by TranslateSpan.
+		_, _ = MakeSegment(span, resource, nil, true)
+	})
+}
@@ -65,17 +65,11 @@ var (
 
 // MakeSegmentDocumentString converts an OpenTelemetry Span to an X-Ray Segment and then serialzies to JSON
 func MakeSegmentDocumentString(span pdata.Span, resource pdata.Resource, indexedAttrs []string, indexAllAttrs bool) (string, error) {
-	segment, err := MakeSegment(span, resource, indexedAttrs, indexAllAttrs)
-	if err != nil {
-		return "", err
-	}
-	w := writers.borrow()
-	if err := w.Encode(*segment); err != nil {
-		return "", err
-	}
-	jsonStr := w.String()
-	writers.release(w)
-	return jsonStr, nil
+	return TranslateSpanDocument(span, TranslateOptions{
+		Resource:           resource,
+		IndexedAttributes:  indexedAttrs,
+		IndexAllAttributes: indexAllAttrs,
+	})
 }
 
 // MakeSegment converts an OpenTelemetry Span to an X-Ray Segment
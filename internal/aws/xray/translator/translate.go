@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/model/pdata"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// TranslateOptions controls how spans are translated to X-Ray segments.
+type TranslateOptions struct {
+	// Resource is the resource the span belongs to. An empty resource is used
+	// when it is not set.
+	Resource pdata.Resource
+	// IndexedAttributes lists the span and resource attributes recorded as
+	// X-Ray annotations instead of metadata.
+	IndexedAttributes []string
+	// IndexAllAttributes records all span and resource attributes as X-Ray
+	// annotations.
+	IndexAllAttributes bool
+	// Events controls how the span events other than exceptions are
+	// translated. They are dropped when it is not set.
+	Events EventsMode
+}
+
+// TranslateSpan converts an OpenTelemetry span to an X-Ray segment. Any panic
+// raised while translating malformed input is returned as an error.
+func TranslateSpan(span pdata.Span, opts TranslateOptions) (segment *awsxray.Segment, err error) {
+	resource := opts.Resource
+	if resource == (pdata.Resource{}) {
+		resource = pdata.NewResource()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			segment = nil
+			err = fmt.Errorf("failed to translate span %s: %v", span.SpanID().HexString(), r)
+		}
+	}()
+	segment, err = MakeSegment(span, resource, opts.IndexedAttributes, opts.IndexAllAttributes)
+	if err != nil {
+		return nil, err
+	}
+	addEvents(segment, span, opts)
+	return segment, nil
+}
+
+// TranslateSpanDocument converts an OpenTelemetry span to an X-Ray segment
+// and serializes it to JSON.
+func TranslateSpanDocument(span pdata.Span, opts TranslateOptions) (string, error) {
+	segment, err := TranslateSpan(span, opts)
+	if err != nil {
+		return "", err
+	}
+	w := writers.borrow()
+	if err := w.Encode(*segment); err != nil {
+		return "", err
+	}
+	jsonStr := w.String()
+	writers.release(w)
+	return jsonStr, nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+func TestTranslateSpan(t *testing.T) {
+	resource := constructDefaultResource()
+	span := constructServerSpan(pdata.NewSpanID([8]byte{}), "/api/locations", pdata.StatusCodeOk, "OK",
+		map[string]interface{}{
+			conventions.AttributeHTTPMethod: "GET",
+			"user.id":                       "junit",
+		})
+
+	segment, err := TranslateSpan(span, TranslateOptions{
+		Resource:          resource,
+		IndexedAttributes: []string{"user.id"},
+	})
+	require.NoError(t, err)
+	expected, err := MakeSegment(span, resource, []string{"user.id"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, *expected.Name, *segment.Name)
+	assert.Equal(t, *expected.TraceID, *segment.TraceID)
+	assert.Equal(t, expected.Annotations, segment.Annotations)
+}
+
+func TestTranslateSpanWithoutResource(t *testing.T) {
+	span := constructServerSpan(pdata.NewSpanID([8]byte{}), "/api/locations", pdata.StatusCodeError, "ERROR",
+		map[string]interface{}{})
+
+	segment, err := TranslateSpan(span, TranslateOptions{})
+	require.NoError(t, err)
+	assert.NotNil(t, segment)
+	assert.True(t, *segment.Fault)
+}
+
+func TestTranslateSpanInvalidTraceID(t *testing.T) {
+	span := constructServerSpan(pdata.NewSpanID([8]byte{}), "/api/locations", pdata.StatusCodeOk, "OK",
+		map[string]interface{}{})
+	span.SetTraceID(pdata.NewTraceID([16]byte{}))
+
+	_, err := TranslateSpan(span, TranslateOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package managedidentity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// IMDSTokenEndpoint is the token endpoint of the Azure Instance Metadata
+	// Service, serving the tokens of managed identities.
+	IMDSTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+	// tokenRefreshMargin is how long before their expiration tokens are
+	// refreshed.
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// TokenSource requests the tokens of a managed identity for a resource, and
+// caches them until they are about to expire.
+type TokenSource struct {
+	client   *http.Client
+	endpoint string
+	resource string
+	clientID string
+	now      func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewTokenSource returns a TokenSource requesting the tokens of resource, e.g.
+// "https://storage.azure.com/", to the token endpoint, usually
+// IMDSTokenEndpoint. clientID selects a user-assigned managed identity, the
+// system-assigned one is used if empty.
+func NewTokenSource(endpoint, resource, clientID string) *TokenSource {
+	return &TokenSource{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		endpoint: endpoint,
+		resource: resource,
+		clientID: clientID,
+		now:      time.Now,
+	}
+}
+
+// Token returns the cached token, or requests a new one if it's about to
+// expire.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.now().Add(tokenRefreshMargin).Before(s.expires) {
+		return s.token, nil
+	}
+
+	query := url.Values{"api-version": {"2018-02-01"}, "resource": {s.resource}}
+	if s.clientID != "" {
+		query.Set("client_id", s.clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request managed identity token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read managed identity token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request managed identity token: HTTP %d: %s", resp.StatusCode, body)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err = json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to decode managed identity token: %w", err)
+	}
+	expiresOn, err := strconv.ParseInt(token.ExpiresOn, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode managed identity token expiration %q: %w", token.ExpiresOn, err)
+	}
+	s.token = token.AccessToken
+	s.expires = time.Unix(expiresOn, 0)
+	return s.token, nil
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package managedidentity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+	var requests int32
+	now := time.Date(2021, 10, 4, 15, 0, 0, 0, time.UTC)
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, "2018-02-01", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "https://storage.azure.com/", r.URL.Query().Get("resource"))
+		assert.Equal(t, "client", r.URL.Query().Get("client_id"))
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_on":"%d"}`, atomic.LoadInt32(&requests), now.Add(time.Hour).Unix())
+	}))
+	defer imds.Close()
+
+	s := NewTokenSource(imds.URL, "https://storage.azure.com/", "client")
+	s.now = func() time.Time { return now }
+
+	token, err := s.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The token is cached until it's about to expire.
+	now = now.Add(50 * time.Minute)
+	token, err = s.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(6 * time.Minute)
+	token, err = s.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestTokenSystemAssigned(t *testing.T) {
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.URL.Query()["client_id"]
+		assert.False(t, ok)
+		fmt.Fprint(w, `{"access_token":"token","expires_on":"1633359600"}`)
+	}))
+	defer imds.Close()
+
+	token, err := NewTokenSource(imds.URL, "https://eventhubs.azure.net/", "").Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
+
+func TestTokenErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+	}{
+		{
+			name:    "status",
+			status:  http.StatusBadRequest,
+			body:    "no identity",
+			message: "HTTP 400: no identity",
+		},
+		{
+			name:    "invalid json",
+			status:  http.StatusOK,
+			body:    "{",
+			message: "failed to decode managed identity token",
+		},
+		{
+			name:    "invalid expiration",
+			status:  http.StatusOK,
+			body:    `{"access_token":"token","expires_on":"tomorrow"}`,
+			message: `failed to decode managed identity token expiration "tomorrow"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer imds.Close()
+
+			_, err := NewTokenSource(imds.URL, "https://storage.azure.com/", "").Token(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sanitize repairs the strings that backends requiring valid UTF-8
+// without control characters reject, often along with the whole batch they
+// were sent in.
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Scrubber replaces the invalid UTF-8 byte sequences of strings and removes
+// their control characters.
+type Scrubber struct {
+	// Replacement replaces each run of invalid UTF-8 bytes. The invalid bytes
+	// are removed when it's empty.
+	Replacement string
+	// StripControlCharacters removes the C0 and C1 control characters and
+	// DEL, except tabs, line feeds and carriage returns.
+	StripControlCharacters bool
+}
+
+// String returns the repaired string, and whether it was changed. Clean
+// strings are returned without allocating.
+func (s Scrubber) String(str string) (string, bool) {
+	if s.isClean(str) {
+		return str, false
+	}
+	var b strings.Builder
+	b.Grow(len(str))
+	invalid := false
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			// Like strings.ToValidUTF8, a run of invalid bytes is replaced
+			// once.
+			if !invalid {
+				b.WriteString(s.Replacement)
+				invalid = true
+			}
+			i++
+			continue
+		}
+		invalid = false
+		if !s.StripControlCharacters || !isControl(r) {
+			b.WriteString(str[i : i+size])
+		}
+		i += size
+	}
+	return b.String(), true
+}
+
+func (s Scrubber) isClean(str string) bool {
+	if !s.StripControlCharacters {
+		return utf8.ValidString(str)
+	}
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if (r == utf8.RuneError && size == 1) || isControl(r) {
+			return false
+		}
+		i += size
+	}
+	return true
+}
+
+// AttributeValue repairs a string value, or the values and keys nested in a
+// map or array value, in place. It returns the number of strings changed.
+func (s Scrubber) AttributeValue(v pdata.AttributeValue) int {
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		if str, changed := s.String(v.StringVal()); changed {
+			v.SetStringVal(str)
+			return 1
+		}
+	case pdata.AttributeValueTypeMap:
+		return s.AttributeMap(v.MapVal())
+	case pdata.AttributeValueTypeArray:
+		values := v.ArrayVal()
+		changed := 0
+		for i := 0; i < values.Len(); i++ {
+			changed += s.AttributeValue(values.At(i))
+		}
+		return changed
+	}
+	return 0
+}
+
+// AttributeMap repairs the keys and values of a map in place. It returns the
+// number of strings changed. A repaired key replaces any attribute already
+// having that key.
+func (s Scrubber) AttributeMap(m pdata.AttributeMap) int {
+	changed := 0
+	var renamed map[string]string
+	m.Range(func(k string, v pdata.AttributeValue) bool {
+		changed += s.AttributeValue(v)
+		if key, keyChanged := s.String(k); keyChanged {
+			if renamed == nil {
+				renamed = map[string]string{}
+			}
+			renamed[k] = key
+			changed++
+		}
+		return true
+	})
+	for k, key := range renamed {
+		v, _ := m.Get(k)
+		value := pdata.NewAttributeValueEmpty()
+		v.CopyTo(value)
+		m.Delete(k)
+		m.Upsert(key, value)
+	}
+	return changed
+}
+
+func isControl(r rune) bool {
+	return unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r'
+}
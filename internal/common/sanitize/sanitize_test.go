@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestString(t *testing.T) {
+	replace := Scrubber{Replacement: "�", StripControlCharacters: true}
+	tests := []struct {
+		name     string
+		scrubber Scrubber
+		in       string
+		want     string
+	}{
+		{name: "clean", scrubber: replace, in: "GET /api/v1/users 200 é世\U0001F600\ttab\r\n", want: "GET /api/v1/users 200 é世\U0001F600\ttab\r\n"},
+		{name: "empty", scrubber: replace, in: "", want: ""},
+		{name: "invalid byte", scrubber: replace, in: "caf\xe9 au lait", want: "caf� au lait"},
+		{name: "run of invalid bytes", scrubber: replace, in: "a\xff\xfe\xfdb", want: "a�b"},
+		{name: "truncated sequence", scrubber: replace, in: "end \xe4\xb8", want: "end �"},
+		{name: "surrogate", scrubber: replace, in: "\xed\xa0\x80x", want: "�x"},
+		{name: "valid replacement character", scrubber: replace, in: "�", want: "�"},
+		{name: "drop invalid bytes", scrubber: Scrubber{}, in: "a\xffb\xfe", want: "ab"},
+		{name: "custom replacement", scrubber: Scrubber{Replacement: "?"}, in: "a\xffb", want: "a?b"},
+		{name: "control characters", scrubber: replace, in: "\x00null\x1b[31mred\x1b[0m\x7f\u0085", want: "null[31mred[0m"},
+		{name: "control characters kept", scrubber: Scrubber{Replacement: "�"}, in: "\x00a\x1b\xff", want: "\x00a\x1b�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := tt.scrubber.String(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.in != tt.want, changed)
+		})
+	}
+}
+
+func TestAttributeMap(t *testing.T) {
+	m := pdata.NewAttributeMap()
+	m.InsertString("clean", "value")
+	m.InsertInt("count", 3)
+	m.InsertString("bad\xff", "v\x00alue")
+	nested := pdata.NewAttributeValueMap()
+	nested.MapVal().InsertString("inner", "x\xfey")
+	m.Insert("nested", nested)
+	array := pdata.NewAttributeValueArray()
+	array.ArrayVal().AppendEmpty().SetStringVal("ok")
+	array.ArrayVal().AppendEmpty().SetStringVal("\x07bell")
+	m.Insert("array", array)
+
+	s := Scrubber{Replacement: "�", StripControlCharacters: true}
+	assert.Equal(t, 4, s.AttributeMap(m))
+
+	assert.Equal(t, 5, m.Len())
+	v, ok := m.Get("bad�")
+	assert.True(t, ok)
+	assert.Equal(t, "value", v.StringVal())
+	v, _ = m.Get("nested")
+	inner, _ := v.MapVal().Get("inner")
+	assert.Equal(t, "x�y", inner.StringVal())
+	v, _ = m.Get("array")
+	assert.Equal(t, "bell", v.ArrayVal().At(1).StringVal())
+	v, _ = m.Get("count")
+	assert.EqualValues(t, 3, v.IntVal())
+
+	// Repairing twice changes nothing.
+	assert.Equal(t, 0, s.AttributeMap(m))
+}
+
+func BenchmarkStringClean(b *testing.B) {
+	s := Scrubber{Replacement: "�", StripControlCharacters: true}
+	str := "10.0.0.1 - - [04/Oct/2021:12:30:15 +0000] \"GET /api/v1/users HTTP/1.1\" 200 512 \"-\" \"curl/7.68.0\""
+	for i := 0; i < b.N; i++ {
+		s.String(str)
+	}
+}
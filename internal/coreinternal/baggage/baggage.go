@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baggage promotes the W3C baggage of incoming requests to the
+// attributes of the telemetry they carry.
+package baggage
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// header is the name of the W3C baggage HTTP header.
+const header = "baggage"
+
+// Config defines which baggage members of requests are promoted to
+// attributes.
+type Config struct {
+	// Keys are the keys of the baggage members to promote. No baggage is
+	// promoted if empty.
+	Keys []string `mapstructure:"keys"`
+
+	// Prefix is prepended to the keys of promoted members to form the names
+	// of the attributes.
+	Prefix string `mapstructure:"prefix"`
+}
+
+// Promoter sets baggage members as attributes. Attributes already set on
+// the telemetry take precedence over baggage members. A nil Promoter
+// promotes nothing.
+type Promoter struct {
+	keys   []string
+	prefix string
+}
+
+// NewPromoter returns a Promoter for cfg, or nil if cfg promotes no key.
+func NewPromoter(cfg Config) *Promoter {
+	if len(cfg.Keys) == 0 {
+		return nil
+	}
+	return &Promoter{keys: cfg.Keys, prefix: cfg.Prefix}
+}
+
+// attributes returns the attributes promoted from the baggage headers of a
+// request, or nil if there are none. Malformed baggage is ignored.
+func (p *Promoter) attributes(h http.Header) map[string]string {
+	if p == nil {
+		return nil
+	}
+	values := h.Values(header)
+	if len(values) == 0 {
+		return nil
+	}
+	bag, err := baggage.Parse(strings.Join(values, ","))
+	if err != nil {
+		return nil
+	}
+	var attrs map[string]string
+	for _, key := range p.keys {
+		member := bag.Member(key)
+		if member.Key() == "" {
+			continue
+		}
+		if attrs == nil {
+			attrs = map[string]string{}
+		}
+		// Values are percent-encoded, but the parser doesn't decode them.
+		value := member.Value()
+		if decoded, err := url.PathUnescape(value); err == nil {
+			value = decoded
+		}
+		attrs[p.prefix+key] = value
+	}
+	return attrs
+}
+
+// PromoteTraces sets the promoted baggage members of a request on all the
+// spans of td.
+func (p *Promoter) PromoteTraces(h http.Header, td pdata.Traces) {
+	attrs := p.attributes(h)
+	if attrs == nil {
+		return
+	}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				insert(spans.At(k).Attributes(), attrs)
+			}
+		}
+	}
+}
+
+// PromoteLogs sets the promoted baggage members of a request on all the log
+// records of ld.
+func (p *Promoter) PromoteLogs(h http.Header, ld pdata.Logs) {
+	attrs := p.attributes(h)
+	if attrs == nil {
+		return
+	}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				insert(logs.At(k).Attributes(), attrs)
+			}
+		}
+	}
+}
+
+func insert(dest pdata.AttributeMap, attrs map[string]string) {
+	for k, v := range attrs {
+		dest.InsertString(k, v)
+	}
+}
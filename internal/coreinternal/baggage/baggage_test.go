@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggage
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func newHeader(values ...string) http.Header {
+	h := http.Header{}
+	for _, v := range values {
+		h.Add("Baggage", v)
+	}
+	return h
+}
+
+func TestPromoteTraces(t *testing.T) {
+	p := NewPromoter(Config{Keys: []string{"tenant", "feature", "missing"}, Prefix: "baggage."})
+
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	spans.AppendEmpty()
+	spans.AppendEmpty().Attributes().InsertString("baggage.tenant", "span")
+
+	p.PromoteTraces(newHeader("tenant=acme,user=alice", "feature=new%20checkout;ttl=60"), td)
+
+	assert.Equal(t, map[string]interface{}{
+		"baggage.tenant":  "acme",
+		"baggage.feature": "new checkout",
+	}, spans.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"baggage.tenant":  "span",
+		"baggage.feature": "new checkout",
+	}, spans.At(1).Attributes().AsRaw())
+}
+
+func TestPromoteLogs(t *testing.T) {
+	p := NewPromoter(Config{Keys: []string{"tenant"}})
+
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	logs.AppendEmpty()
+
+	p.PromoteLogs(newHeader("tenant=acme"), ld)
+	assert.Equal(t, map[string]interface{}{"tenant": "acme"}, logs.At(0).Attributes().AsRaw())
+}
+
+func TestPromoteNothing(t *testing.T) {
+	tests := []struct {
+		name     string
+		promoter *Promoter
+		header   http.Header
+	}{
+		{
+			name:     "no keys",
+			promoter: NewPromoter(Config{}),
+			header:   newHeader("tenant=acme"),
+		},
+		{
+			name:     "no baggage",
+			promoter: NewPromoter(Config{Keys: []string{"tenant"}}),
+			header:   newHeader(),
+		},
+		{
+			name:     "malformed baggage",
+			promoter: NewPromoter(Config{Keys: []string{"tenant"}}),
+			header:   newHeader("tenant"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := pdata.NewTraces()
+			spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+			spans.AppendEmpty()
+			tt.promoter.PromoteTraces(tt.header, td)
+			assert.Equal(t, 0, spans.At(0).Attributes().Len())
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certreloader loads TLS configurations whose certificates, keys and
+// CAs are reloaded when their files change, so that renewed certificates are
+// used by new connections without restarting the components using them.
+package certreloader
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+)
+
+// Reloader checks the files of the TLS configurations it loaded every interval,
+// and reloads those which changed. Files are shared by all the configurations
+// using them, so that they're read once per check.
+type Reloader struct {
+	logger   *zap.Logger
+	interval time.Duration
+
+	mu        sync.Mutex
+	keyPairs  map[[2]string]*keyPair
+	certPools map[string]*certPool
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a Reloader checking files every interval.
+func New(logger *zap.Logger, interval time.Duration) *Reloader {
+	return &Reloader{
+		logger:    logger,
+		interval:  interval,
+		keyPairs:  map[[2]string]*keyPair{},
+		certPools: map[string]*certPool{},
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start starts checking files in the background.
+func (r *Reloader) Start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.Reload()
+			case <-r.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops checking files. Loaded configurations keep using the last
+// certificates loaded.
+func (r *Reloader) Stop() {
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+// Reload reloads the files which changed since they were last loaded. A file
+// that fails to load, e.g. because a certificate and its key are being
+// replaced, is retried on the next check while the previous content is used.
+func (r *Reloader) Reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, kp := range r.keyPairs {
+		changed, err := kp.reload()
+		switch {
+		case err != nil:
+			r.logger.Warn("Failed to reload TLS certificate, using the previous one",
+				zap.String("cert_file", kp.certFile), zap.String("key_file", kp.keyFile), zap.Error(err))
+		case changed:
+			r.logger.Info("Reloaded TLS certificate",
+				zap.String("cert_file", kp.certFile), zap.String("key_file", kp.keyFile))
+		}
+	}
+	for _, cp := range r.certPools {
+		changed, err := cp.reload()
+		switch {
+		case err != nil:
+			r.logger.Warn("Failed to reload CA, using the previous one",
+				zap.String("ca_file", cp.file), zap.Error(err))
+		case changed:
+			r.logger.Info("Reloaded CA", zap.String("ca_file", cp.file))
+		}
+	}
+}
+
+// LoadClientTLSConfig loads the TLS configuration of a client like
+// configtls.TLSClientSetting.LoadTLSConfig does, with its certificate and CA
+// reloaded when their files change.
+func (r *Reloader) LoadClientTLSConfig(setting configtls.TLSClientSetting) (*tls.Config, error) {
+	cfg, err := setting.LoadTLSConfig()
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	if setting.CertFile != "" {
+		kp, err := r.keyPair(setting.CertFile, setting.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		cfg.Certificates = nil
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return kp.certificate(), nil
+		}
+	}
+	if setting.CAFile != "" && !cfg.InsecureSkipVerify {
+		cp, err := r.certPool(setting.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		// The roots used by the standard verification can't change, so the
+		// server certificate is verified against the current CA instead.
+		cfg.RootCAs = nil
+		cfg.InsecureSkipVerify = true // nolint:gosec
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyServer(cs, cp.pool())
+		}
+	}
+	return cfg, nil
+}
+
+// LoadServerTLSConfig loads the TLS configuration of a server like
+// configtls.TLSServerSetting.LoadTLSConfig does, with its certificate and
+// client CA reloaded when their files change.
+func (r *Reloader) LoadServerTLSConfig(setting configtls.TLSServerSetting) (*tls.Config, error) {
+	cfg, err := setting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if setting.CertFile != "" {
+		kp, err := r.keyPair(setting.CertFile, setting.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		cfg.Certificates = nil
+		cfg.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return kp.certificate(), nil
+		}
+	}
+	if setting.ClientCAFile != "" {
+		cp, err := r.certPool(setting.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		base := cfg.Clone()
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientCfg := base.Clone()
+			clientCfg.ClientCAs = cp.pool()
+			return clientCfg, nil
+		}
+	}
+	return cfg, nil
+}
+
+func (r *Reloader) keyPair(certFile, keyFile string) (*keyPair, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := [2]string{certFile, keyFile}
+	if kp, ok := r.keyPairs[key]; ok {
+		return kp, nil
+	}
+	kp := &keyPair{certFile: certFile, keyFile: keyFile}
+	if _, err := kp.reload(); err != nil {
+		return nil, err
+	}
+	r.keyPairs[key] = kp
+	return kp, nil
+}
+
+func (r *Reloader) certPool(file string) (*certPool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cp, ok := r.certPools[file]; ok {
+		return cp, nil
+	}
+	cp := &certPool{file: file}
+	if _, err := cp.reload(); err != nil {
+		return nil, err
+	}
+	r.certPools[file] = cp
+	return cp, nil
+}
+
+// verifyServer verifies the certificate chain and host name of a server like
+// the standard verification does, with the given roots.
+func verifyServer(cs tls.ConnectionState, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server didn't provide a certificate")
+	}
+	if cs.ServerName == "" {
+		return errors.New("tls: server name is required to verify the server certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       cs.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// keyPair is a certificate and its key, reloaded when either file changes.
+type keyPair struct {
+	certFile string
+	keyFile  string
+	certPEM  []byte
+	keyPEM   []byte
+	current  atomic.Value // *tls.Certificate
+}
+
+func (kp *keyPair) reload() (bool, error) {
+	certPEM, err := ioutil.ReadFile(filepath.Clean(kp.certFile))
+	if err != nil {
+		return false, err
+	}
+	keyPEM, err := ioutil.ReadFile(filepath.Clean(kp.keyFile))
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(certPEM, kp.certPEM) && bytes.Equal(keyPEM, kp.keyPEM) {
+		return false, nil
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS cert and key: %w", err)
+	}
+	kp.certPEM, kp.keyPEM = certPEM, keyPEM
+	kp.current.Store(&cert)
+	return true, nil
+}
+
+func (kp *keyPair) certificate() *tls.Certificate {
+	return kp.current.Load().(*tls.Certificate)
+}
+
+// certPool is a CA, reloaded when its file changes.
+type certPool struct {
+	file    string
+	pem     []byte
+	current atomic.Value // *x509.CertPool
+}
+
+func (cp *certPool) reload() (bool, error) {
+	pem, err := ioutil.ReadFile(filepath.Clean(cp.file))
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(pem, cp.pem) {
+		return false, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return false, fmt.Errorf("failed to parse CA %s", cp.file)
+	}
+	cp.pem = pem
+	cp.current.Store(pool)
+	return true, nil
+}
+
+func (cp *certPool) pool() *x509.CertPool {
+	return cp.current.Load().(*x509.CertPool)
+}
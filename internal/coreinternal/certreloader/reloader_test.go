@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certreloader
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newCert(t *testing.T, cn string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+type testFiles struct {
+	ca, cert, key string
+}
+
+// writeCerts writes a new CA and a certificate it signed.
+func writeCerts(t *testing.T, files testFiles) *testCert {
+	ca := newCert(t, "ca", nil)
+	leaf := newCert(t, "localhost", ca)
+	require.NoError(t, ioutil.WriteFile(files.ca, ca.pem, 0600))
+	require.NoError(t, ioutil.WriteFile(files.cert, leaf.pem, 0600))
+	require.NoError(t, ioutil.WriteFile(files.key, leaf.keyPEM(t), 0600))
+	return leaf
+}
+
+func newTestFiles(t *testing.T, prefix string) testFiles {
+	dir := t.TempDir()
+	return testFiles{
+		ca:   filepath.Join(dir, prefix+"ca.pem"),
+		cert: filepath.Join(dir, prefix+"cert.pem"),
+		key:  filepath.Join(dir, prefix+"key.pem"),
+	}
+}
+
+// handshake connects a client to a server and returns the certificates each
+// side received.
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) (serverPeer, clientPeer *x509.Certificate, err error) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	server := tls.Server(serverConn, serverCfg)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Handshake()
+	}()
+	clientCfg = clientCfg.Clone()
+	clientCfg.ServerName = "localhost"
+	client := tls.Client(clientConn, clientCfg)
+	err = client.Handshake()
+	if err != nil {
+		clientConn.Close()
+		<-errCh
+		return nil, nil, err
+	}
+	if err = <-errCh; err != nil {
+		return nil, nil, err
+	}
+	if peers := server.ConnectionState().PeerCertificates; len(peers) > 0 {
+		serverPeer = peers[0]
+	}
+	return serverPeer, client.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestReloadCertificates(t *testing.T) {
+	serverFiles := newTestFiles(t, "server")
+	clientFiles := newTestFiles(t, "client")
+	serverCert := writeCerts(t, serverFiles)
+	clientCert := writeCerts(t, clientFiles)
+
+	r := New(zap.NewNop(), time.Hour)
+	serverCfg, err := r.LoadServerTLSConfig(configtls.TLSServerSetting{
+		TLSSetting:   configtls.TLSSetting{CertFile: serverFiles.cert, KeyFile: serverFiles.key},
+		ClientCAFile: clientFiles.ca,
+	})
+	require.NoError(t, err)
+	clientCfg, err := r.LoadClientTLSConfig(configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{CAFile: serverFiles.ca, CertFile: clientFiles.cert, KeyFile: clientFiles.key},
+	})
+	require.NoError(t, err)
+
+	serverPeer, clientPeer, err := handshake(t, serverCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, clientCert.cert.SerialNumber, serverPeer.SerialNumber)
+	assert.Equal(t, serverCert.cert.SerialNumber, clientPeer.SerialNumber)
+
+	serverCert = writeCerts(t, serverFiles)
+	clientCert = writeCerts(t, clientFiles)
+	r.Reload()
+
+	serverPeer, clientPeer, err = handshake(t, serverCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, clientCert.cert.SerialNumber, serverPeer.SerialNumber)
+	assert.Equal(t, serverCert.cert.SerialNumber, clientPeer.SerialNumber)
+}
+
+func TestReloadKeepsPreviousOnError(t *testing.T) {
+	files := newTestFiles(t, "")
+	cert := writeCerts(t, files)
+
+	r := New(zap.NewNop(), time.Hour)
+	serverCfg, err := r.LoadServerTLSConfig(configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{CertFile: files.cert, KeyFile: files.key},
+	})
+	require.NoError(t, err)
+	clientCfg, err := r.LoadClientTLSConfig(configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{CAFile: files.ca},
+	})
+	require.NoError(t, err)
+
+	// A certificate written without its key doesn't replace the previous one.
+	other := newCert(t, "localhost", newCert(t, "ca", nil))
+	require.NoError(t, ioutil.WriteFile(files.cert, other.pem, 0600))
+	r.Reload()
+
+	_, clientPeer, err := handshake(t, serverCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, cert.cert.SerialNumber, clientPeer.SerialNumber)
+}
+
+func TestClientRejectsUnknownServer(t *testing.T) {
+	serverFiles := newTestFiles(t, "server")
+	caFiles := newTestFiles(t, "other")
+	writeCerts(t, serverFiles)
+	writeCerts(t, caFiles)
+
+	r := New(zap.NewNop(), time.Hour)
+	serverCfg, err := r.LoadServerTLSConfig(configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{CertFile: serverFiles.cert, KeyFile: serverFiles.key},
+	})
+	require.NoError(t, err)
+	clientCfg, err := r.LoadClientTLSConfig(configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{CAFile: caFiles.ca},
+	})
+	require.NoError(t, err)
+
+	_, _, err = handshake(t, serverCfg, clientCfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate signed by unknown authority")
+}
+
+func TestLoadErrors(t *testing.T) {
+	r := New(zap.NewNop(), time.Hour)
+	_, err := r.LoadServerTLSConfig(configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{CertFile: "missing.pem", KeyFile: "missing.pem"},
+	})
+	assert.Error(t, err)
+
+	cfg, err := r.LoadClientTLSConfig(configtls.TLSClientSetting{Insecure: true})
+	assert.NoError(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestStartStop(t *testing.T) {
+	r := New(zap.NewNop(), time.Millisecond)
+	r.Start()
+	time.Sleep(10 * time.Millisecond)
+	r.Stop()
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstestutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// FindIntPoint returns the value of the int data point of the gauge or sum
+// metric with the given name whose attributes are exactly the given string
+// attributes, failing the test when there is none.
+func FindIntPoint(t *testing.T, metrics pdata.MetricSlice, name string, attrs map[string]string) int64 {
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		var dps pdata.NumberDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pdata.MetricDataTypeSum:
+			dps = m.Sum().DataPoints()
+		}
+	points:
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			if dp.Attributes().Len() != len(attrs) {
+				continue
+			}
+			for k, v := range attrs {
+				if got, ok := dp.Attributes().Get(k); !ok || got.StringVal() != v {
+					continue points
+				}
+			}
+			return dp.IntVal()
+		}
+		require.Failf(t, "data point not found", "%s %v", name, attrs)
+	}
+	require.Failf(t, "metric not found", name)
+	return 0
+}
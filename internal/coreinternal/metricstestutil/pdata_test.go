@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstestutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestFindIntPoint(t *testing.T) {
+	metrics := pdata.NewMetricSlice()
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	dp := gauge.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(1)
+	dp = gauge.Gauge().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("queue", "a")
+	dp.SetIntVal(2)
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("sum")
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("queue", "a")
+	dp.Attributes().InsertString("direction", "in")
+	dp.SetIntVal(3)
+
+	assert.Equal(t, int64(1), FindIntPoint(t, metrics, "gauge", nil))
+	assert.Equal(t, int64(2), FindIntPoint(t, metrics, "gauge", map[string]string{"queue": "a"}))
+	assert.Equal(t, int64(3), FindIntPoint(t, metrics, "sum", map[string]string{"queue": "a", "direction": "in"}))
+}
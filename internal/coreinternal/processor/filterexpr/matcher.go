@@ -114,7 +114,78 @@ func createEnv(metricName string, attributes pdata.AttributeMap) env {
 	}
 }
 
-func (m *Matcher) match(env env) (bool, error) {
+// MatchSpan evaluates the expression against a span and the resource it
+// belongs to. The expression can use SpanName, SpanKind, StatusCode,
+// HasAttribute(key), Attribute(key), HasResourceAttribute(key) and
+// ResourceAttribute(key).
+func (m *Matcher) MatchSpan(span pdata.Span, resource pdata.Resource) (bool, error) {
+	hasAttr, attr := attributeFuncs(span.Attributes())
+	hasResAttr, resAttr := attributeFuncs(resource.Attributes())
+	return m.match(spanEnv{
+		SpanName:             span.Name(),
+		SpanKind:             span.Kind().String(),
+		StatusCode:           span.Status().Code().String(),
+		HasAttribute:         hasAttr,
+		Attribute:            attr,
+		HasResourceAttribute: hasResAttr,
+		ResourceAttribute:    resAttr,
+	})
+}
+
+// MatchLogRecord evaluates the expression against a log record and the
+// resource it belongs to. The expression can use Body, SeverityText,
+// SeverityNumber, HasAttribute(key), Attribute(key),
+// HasResourceAttribute(key) and ResourceAttribute(key).
+func (m *Matcher) MatchLogRecord(lr pdata.LogRecord, resource pdata.Resource) (bool, error) {
+	hasAttr, attr := attributeFuncs(lr.Attributes())
+	hasResAttr, resAttr := attributeFuncs(resource.Attributes())
+	return m.match(logEnv{
+		Body:                 lr.Body().AsString(),
+		SeverityText:         lr.SeverityText(),
+		SeverityNumber:       int(lr.SeverityNumber()),
+		HasAttribute:         hasAttr,
+		Attribute:            attr,
+		HasResourceAttribute: hasResAttr,
+		ResourceAttribute:    resAttr,
+	})
+}
+
+type spanEnv struct {
+	SpanName             string
+	SpanKind             string
+	StatusCode           string
+	HasAttribute         func(key string) bool
+	Attribute            func(key string) string
+	HasResourceAttribute func(key string) bool
+	ResourceAttribute    func(key string) string
+}
+
+type logEnv struct {
+	Body                 string
+	SeverityText         string
+	SeverityNumber       int
+	HasAttribute         func(key string) bool
+	Attribute            func(key string) string
+	HasResourceAttribute func(key string) bool
+	ResourceAttribute    func(key string) string
+}
+
+func attributeFuncs(attributes pdata.AttributeMap) (func(string) bool, func(string) string) {
+	has := func(key string) bool {
+		_, ok := attributes.Get(key)
+		return ok
+	}
+	get := func(key string) string {
+		v, ok := attributes.Get(key)
+		if !ok {
+			return ""
+		}
+		return v.AsString()
+	}
+	return has, get
+}
+
+func (m *Matcher) match(env interface{}) (bool, error) {
 	result, err := m.v.Run(m.program, env)
 	if err != nil {
 		return false, err
@@ -209,3 +209,62 @@ func matchHistogram(t *testing.T, metricName string) bool {
 	assert.NoError(t, err)
 	return matched
 }
+
+func TestMatchSpan(t *testing.T) {
+	span := pdata.NewSpan()
+	span.SetName("GET /users")
+	span.SetKind(pdata.SpanKindServer)
+	span.Status().SetCode(pdata.StatusCodeError)
+	span.Attributes().InsertInt("http.status_code", 500)
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "users")
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{`SpanName == "GET /users"`, true},
+		{`SpanKind == "SPAN_KIND_SERVER"`, true},
+		{`StatusCode == "STATUS_CODE_ERROR"`, true},
+		{`Attribute("http.status_code") == "500"`, true},
+		{`HasAttribute("http.method")`, false},
+		{`ResourceAttribute("service.name") == "users" && HasResourceAttribute("service.name")`, true},
+		{`SpanName matches "^POST"`, false},
+	}
+	for _, tt := range tests {
+		matcher, err := NewMatcher(tt.expression)
+		require.NoError(t, err)
+		matched, err := matcher.MatchSpan(span, resource)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, matched, tt.expression)
+	}
+}
+
+func TestMatchLogRecord(t *testing.T) {
+	lr := pdata.NewLogRecord()
+	lr.Body().SetStringVal("connection refused")
+	lr.SetSeverityText("ERROR")
+	lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	lr.Attributes().InsertBool("retry", true)
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("host.name", "node-1")
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{`Body contains "refused"`, true},
+		{`SeverityText == "ERROR"`, true},
+		{`SeverityNumber >= 17`, true},
+		{`Attribute("retry") == "true"`, true},
+		{`HasAttribute("missing")`, false},
+		{`ResourceAttribute("host.name") == "node-2"`, false},
+	}
+	for _, tt := range tests {
+		matcher, err := NewMatcher(tt.expression)
+		require.NoError(t, err)
+		matched, err := matcher.MatchLogRecord(lr, resource)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, matched, tt.expression)
+	}
+}
@@ -32,6 +32,10 @@ type Config struct {
 
 	// Docker client API version.
 	DockerAPIVersion float64 `mapstructure:"api_version"`
+
+	// Whether the endpoint is the Docker-compatible API socket of Podman, whose
+	// responses differ from the ones of Docker in places.
+	PodmanCompatibility bool `mapstructure:"podman_compatibility"`
 }
 
 // NewConfig creates a new config to be used when creating
@@ -32,6 +32,9 @@ import (
 const (
 	minimalRequiredDockerAPIVersion = 1.22
 	userAgent                       = "OpenTelemetry-Collector Docker Stats Receiver/v0.0.1"
+	// Podman rejects the requests to versions of the Docker API older than
+	// 1.24.
+	minimalPodmanAPIVersion = 1.24
 )
 
 // Container is client.ContainerInspect() response container
@@ -52,12 +55,19 @@ type Client struct {
 	containersLock       sync.Mutex
 	excludedImageMatcher *StringMatcher
 	logger               *zap.Logger
+	// previousCPUStats are the last CPU stats of the containers, to fill the
+	// previous CPU stats Podman leaves empty.
+	previousCPUStats map[string]dtypes.CPUStats
 }
 
 func NewDockerClient(config *Config, logger *zap.Logger) (*Client, error) {
+	apiVersion := config.DockerAPIVersion
+	if config.PodmanCompatibility && apiVersion < minimalPodmanAPIVersion {
+		apiVersion = minimalPodmanAPIVersion
+	}
 	client, err := docker.NewClientWithOpts(
 		docker.WithHost(config.Endpoint),
-		docker.WithVersion(fmt.Sprintf("v%v", config.DockerAPIVersion)),
+		docker.WithVersion(fmt.Sprintf("v%v", apiVersion)),
 		docker.WithHTTPHeaders(map[string]string{"User-Agent": userAgent}),
 	)
 	if err != nil {
@@ -76,6 +86,7 @@ func NewDockerClient(config *Config, logger *zap.Logger) (*Client, error) {
 		containers:           make(map[string]Container),
 		containersLock:       sync.Mutex{},
 		excludedImageMatcher: excludedImageMatcher,
+		previousCPUStats:     make(map[string]dtypes.CPUStats),
 	}
 
 	return dc, nil
@@ -148,9 +159,24 @@ func (dc *Client) FetchContainerStatsAsJSON(
 		return nil, err
 	}
 
+	if dc.config.PodmanCompatibility {
+		dc.fillPreviousCPUStats(container.ID, statsJSON)
+	}
 	return statsJSON, nil
 }
 
+// fillPreviousCPUStats sets the previous CPU stats of the container from the
+// ones last fetched, since Podman leaves them empty when the stats are not
+// streamed, and remembers the current ones.
+func (dc *Client) fillPreviousCPUStats(cid string, statsJSON *dtypes.StatsJSON) {
+	dc.containersLock.Lock()
+	defer dc.containersLock.Unlock()
+	if previous, ok := dc.previousCPUStats[cid]; ok && statsJSON.PreCPUStats.SystemUsage == 0 {
+		statsJSON.PreCPUStats = previous
+	}
+	dc.previousCPUStats[cid] = statsJSON.CPUStats
+}
+
 // FetchContainerStats will query the desired container stats
 // and return them as ContainerStats
 func (dc *Client) FetchContainerStats(
@@ -214,6 +240,11 @@ func (dc *Client) ContainerEventLoop(ctx context.Context) {
 		{Key: "event", Value: "unpause"},
 		{Key: "event", Value: "update"},
 	}...)
+	if dc.config.PodmanCompatibility {
+		// Podman reports containers removed and exited with its own event names.
+		filters.Add("event", "remove")
+		filters.Add("event", "died")
+	}
 	lastTime := time.Now()
 
 EVENT_LOOP:
@@ -230,7 +261,7 @@ EVENT_LOOP:
 				return
 			case event := <-eventCh:
 				switch event.Action {
-				case "destroy":
+				case "destroy", "remove":
 					dc.logger.Debug("Docker container was destroyed:", zap.String("id", event.ID))
 					dc.removeContainer(event.ID)
 				default:
@@ -312,6 +343,7 @@ func (dc *Client) removeContainer(cid string) {
 	dc.containersLock.Lock()
 	defer dc.containersLock.Unlock()
 	delete(dc.containers, cid)
+	delete(dc.previousCPUStats, cid)
 	dc.logger.Debug("Removed container from stores.", zap.String("id", cid))
 }
 
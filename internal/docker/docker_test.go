@@ -34,6 +34,7 @@ import (
 	"time"
 
 	dtypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -250,3 +251,88 @@ func TestEventLoopHandlesError(t *testing.T) {
 		return
 	}
 }
+
+func TestPodmanCompatibilityStats(t *testing.T) {
+	var paths []string
+	var usage uint64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		// Podman leaves the previous CPU stats empty when not streaming.
+		usage += 1000
+		fmt.Fprintf(w, `{"cpu_stats":{"cpu_usage":{"total_usage":%d},"system_cpu_usage":%d,"online_cpus":2},"precpu_stats":{}}`, usage, usage*10)
+	}))
+	defer srv.Close()
+
+	config := &Config{
+		Endpoint:            srv.URL,
+		Timeout:             time.Second,
+		DockerAPIVersion:    minimalRequiredDockerAPIVersion,
+		PodmanCompatibility: true,
+	}
+	cli, err := NewDockerClient(config, zap.NewNop())
+	require.NoError(t, err)
+
+	c := Container{
+		ContainerJSON: &dtypes.ContainerJSON{
+			ContainerJSONBase: &dtypes.ContainerJSONBase{ID: "c1"},
+		},
+	}
+	statsJSON, err := cli.FetchContainerStatsAsJSON(context.Background(), c)
+	require.NoError(t, err)
+	assert.Zero(t, statsJSON.PreCPUStats.SystemUsage)
+
+	statsJSON, err = cli.FetchContainerStatsAsJSON(context.Background(), c)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1000, statsJSON.PreCPUStats.CPUUsage.TotalUsage)
+	assert.EqualValues(t, 10000, statsJSON.PreCPUStats.SystemUsage)
+	assert.EqualValues(t, 2000, statsJSON.CPUStats.CPUUsage.TotalUsage)
+
+	// Podman rejects the API versions older than 1.24.
+	require.Len(t, paths, 2)
+	assert.Equal(t, "/v1.24/containers/c1/stats", paths[0])
+
+	cli.removeContainer("c1")
+	assert.Empty(t, cli.previousCPUStats)
+}
+
+func TestPodmanCompatibilityRemoveEvent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/events") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Contains(t, r.URL.Query().Get("filters"), `"remove"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.(http.Flusher).Flush()
+		fmt.Fprintf(w, `{"Type":"container","Action":"remove","id":"c1","timeNano":%d}`+"\n", time.Now().UnixNano())
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	config := &Config{
+		Endpoint:            srv.URL,
+		Timeout:             time.Second,
+		DockerAPIVersion:    minimalRequiredDockerAPIVersion,
+		PodmanCompatibility: true,
+	}
+	cli, err := NewDockerClient(config, zap.NewNop())
+	require.NoError(t, err)
+	cli.persistContainer(&dtypes.ContainerJSON{
+		ContainerJSONBase: &dtypes.ContainerJSONBase{
+			ID:    "c1",
+			State: &dtypes.ContainerState{Running: true},
+		},
+		Config: &container.Config{},
+	})
+	require.Len(t, cli.Containers(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cli.ContainerEventLoop(ctx)
+
+	assert.Eventually(t, func() bool {
+		return len(cli.Containers()) == 0
+	}, 5*time.Second, 10*time.Millisecond, "container not removed on Podman remove event")
+	cancel()
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pollingsaas
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	initialRetryDelay = time.Second
+	maxRetryDelay     = time.Minute
+)
+
+// Authenticator authorizes the requests sent to a SaaS API.
+type Authenticator interface {
+	// Authorize adds credentials to the request. The HTTP client can be used
+	// to obtain them, for instance to request an access token.
+	Authorize(ctx context.Context, client *http.Client, req *http.Request) error
+
+	// Invalidate discards cached credentials after the API rejected them.
+	Invalidate()
+}
+
+// Client sends requests to a SaaS API. It limits the rate of requests,
+// retries requests the API rejects because of rate limits, and renews
+// credentials the API rejects.
+type Client struct {
+	httpClient *http.Client
+	auth       Authenticator
+	limiter    *rate.Limiter
+	maxRetries int
+	retryDelay time.Duration
+}
+
+// NewClient returns a client sending requests with httpClient, authorized by
+// auth which can be nil for APIs not requiring authentication.
+func NewClient(httpClient *http.Client, auth Authenticator, settings Settings) *Client {
+	return &Client{
+		httpClient: httpClient,
+		auth:       auth,
+		limiter:    rate.NewLimiter(rate.Limit(settings.RequestsPerSecond), 1),
+		maxRetries: settings.MaxRetries,
+		retryDelay: initialRetryDelay,
+	}
+}
+
+// HTTPClient returns the underlying HTTP client, which sends requests as is.
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// Do sends the request and returns the response, which is successful unless
+// the returned error is non-nil. Requests with a body must be created with
+// http.NewRequest or set GetBody so that they can be retried.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	reauthorized := false
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		if c.auth != nil {
+			if err := c.auth.Authorize(ctx, c.httpClient, req); err != nil {
+				return nil, fmt.Errorf("failed to authorize request: %w", err)
+			}
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		retry := false
+		wait := time.Duration(0)
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && c.auth != nil && !reauthorized:
+			c.auth.Invalidate()
+			reauthorized = true
+			retry = true
+		case (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) && attempt < c.maxRetries:
+			wait = retryAfter(resp.Header, delay)
+			delay *= 2
+			if delay > maxRetryDelay {
+				delay = maxRetryDelay
+			}
+			retry = true
+		}
+		if !retry {
+			return nil, newStatusError(resp)
+		}
+		drain(resp)
+
+		if err := rewind(req); err != nil {
+			return nil, err
+		}
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// StatusError is returned by Client.Do when the API responds with an
+// unsuccessful status.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func newStatusError(resp *http.Response) *StatusError {
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// retryAfter returns the delay the Retry-After header asks for, or def if
+// the header is missing or invalid.
+func retryAfter(header http.Header, def time.Duration) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return def
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return def
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+}
+
+func rewind(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return fmt.Errorf("request to %s can't be retried", req.URL)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
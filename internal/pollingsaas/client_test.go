@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pollingsaas
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthenticator struct {
+	token       string
+	issued      int
+	invalidated int
+	err         error
+}
+
+func (a *fakeAuthenticator) Authorize(_ context.Context, _ *http.Client, req *http.Request) error {
+	if a.err != nil {
+		return a.err
+	}
+	if a.token == "" {
+		a.issued++
+		a.token = "token-" + string(rune('0'+a.issued))
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func (a *fakeAuthenticator) Invalidate() {
+	a.invalidated++
+	a.token = ""
+}
+
+func newTestClient(auth Authenticator) *Client {
+	settings := NewDefaultSettings()
+	settings.RequestsPerSecond = 1000
+	c := NewClient(http.DefaultClient, auth, settings)
+	c.retryDelay = time.Millisecond
+	return c
+}
+
+func TestClientRetriesRateLimitedRequests(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+	require.NoError(t, err)
+	resp, err := newTestClient(nil).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = newTestClient(nil).Do(req)
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, "slow down", statusErr.Body)
+	assert.EqualValues(t, defaultMaxRetries+1, atomic.LoadInt32(&calls))
+}
+
+func TestClientRenewsRejectedCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	auth := &fakeAuthenticator{}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := newTestClient(auth).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 2, auth.issued)
+	assert.Equal(t, 1, auth.invalidated)
+
+	// Credentials are only renewed once per request.
+	auth.token = "expired"
+	auth.issued = 5
+	req, err = http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = newTestClient(auth).Do(req)
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+}
+
+func TestClientAuthorizationError(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = newTestClient(&fakeAuthenticator{err: errors.New("bad credentials")}).Do(req)
+	assert.EqualError(t, err, "failed to authorize request: bad credentials")
+}
+
+func TestClientCanceledWhileWaiting(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = newTestClient(nil).Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := http.Header{}
+	assert.Equal(t, time.Second, retryAfter(header, time.Second))
+
+	header.Set("Retry-After", "120")
+	assert.Equal(t, 2*time.Minute, retryAfter(header, time.Second))
+
+	header.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Duration(0), retryAfter(header, time.Second))
+
+	header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Hour), float64(retryAfter(header, time.Second)), float64(5*time.Second))
+
+	header.Set("Retry-After", "soon")
+	assert.Equal(t, time.Second, retryAfter(header, time.Second))
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pollingsaas
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const cursorKey = "cursor"
+
+// Page is the result of a poll of a source.
+type Page struct {
+	// Logs are the events of the page, for sources of logs receivers.
+	Logs pdata.Logs
+	// Traces are the events of the page, for sources of traces receivers.
+	Traces pdata.Traces
+	// Cursor is the position to resume from once the page is consumed.
+	Cursor string
+	// HasMore reports whether the next page can be fetched right away.
+	// Sources return false when they are done, or to wait for the next
+	// interval when they get close to the API limits.
+	HasMore bool
+}
+
+// Source is a SaaS API polled for events.
+type Source interface {
+	// Poll returns the page of events following the cursor, which is empty
+	// for the first poll ever.
+	Poll(ctx context.Context, client *Client, cursor string) (Page, error)
+}
+
+// Receiver polls a source at regular intervals and sends its events to the
+// next consumer, as logs or traces. The cursor only advances once a page is
+// consumed, so that events are delivered at least once.
+type Receiver struct {
+	id             config.ComponentID
+	settings       Settings
+	clientSettings confighttp.HTTPClientSettings
+	auth           Authenticator
+	source         Source
+	nextConsumer   consumer.Logs
+	nextTraces     consumer.Traces
+	logger         *zap.Logger
+	obsrecv        *obsreport.Receiver
+	format         string
+
+	client  *Client
+	storage storage.Client
+	cursor  string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var (
+	_ component.LogsReceiver   = (*Receiver)(nil)
+	_ component.TracesReceiver = (*Receiver)(nil)
+)
+
+// NewReceiver returns a receiver polling source. format identifies the
+// source in the receiver observability metrics.
+func NewReceiver(
+	id config.ComponentID,
+	format string,
+	settings Settings,
+	clientSettings confighttp.HTTPClientSettings,
+	auth Authenticator,
+	source Source,
+	nextConsumer consumer.Logs,
+	set component.ReceiverCreateSettings,
+) *Receiver {
+	return &Receiver{
+		id:             id,
+		settings:       settings,
+		clientSettings: clientSettings,
+		auth:           auth,
+		source:         source,
+		nextConsumer:   nextConsumer,
+		logger:         set.Logger,
+		obsrecv:        obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: id, Transport: "http"}),
+		format:         format,
+	}
+}
+
+// NewTracesReceiver returns a receiver polling source for traces, which
+// must set the Traces of the pages it returns.
+func NewTracesReceiver(
+	id config.ComponentID,
+	format string,
+	settings Settings,
+	clientSettings confighttp.HTTPClientSettings,
+	auth Authenticator,
+	source Source,
+	nextConsumer consumer.Traces,
+	set component.ReceiverCreateSettings,
+) *Receiver {
+	r := NewReceiver(id, format, settings, clientSettings, auth, source, nil, set)
+	r.nextTraces = nextConsumer
+	return r
+}
+
+// Start loads the persisted cursor and starts polling.
+func (r *Receiver) Start(ctx context.Context, host component.Host) error {
+	httpClient, err := r.clientSettings.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	r.client = NewClient(httpClient, r.auth, r.settings)
+
+	r.storage, err = getStorageClient(ctx, host, r.settings.Storage, r.id)
+	if err != nil {
+		return err
+	}
+	cursor, err := r.storage.Get(ctx, cursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to load cursor: %w", err)
+	}
+	r.cursor = string(cursor)
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.run(pollCtx)
+	return nil
+}
+
+// Shutdown stops polling.
+func (r *Receiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	r.wg.Wait()
+	return r.storage.Close(ctx)
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageName string, receiverID config.ComponentID) (storage.Client, error) {
+	if storageName == "" {
+		return storage.NewNopClient(), nil
+	}
+	storageID, err := config.NewComponentIDFromString(storageName)
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	return storageExt.GetClient(ctx, component.KindReceiver, receiverID, "")
+}
+
+func (r *Receiver) run(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.settings.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches and consumes pages until the source has nothing more to
+// return or an error occurs, in which case the next poll retries from the
+// last consumed page.
+func (r *Receiver) poll(ctx context.Context) {
+	for ctx.Err() == nil {
+		page, err := r.source.Poll(ctx, r.client, r.cursor)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error("Failed to poll source", zap.Error(err))
+			}
+			return
+		}
+
+		if err = r.consume(ctx, page); err != nil {
+			r.logger.Error("Failed to consume events", zap.Error(err))
+			return
+		}
+
+		if page.Cursor != r.cursor {
+			r.cursor = page.Cursor
+			if err = r.storage.Set(ctx, cursorKey, []byte(page.Cursor)); err != nil {
+				r.logger.Error("Failed to persist cursor", zap.Error(err))
+			}
+		}
+		if !page.HasMore {
+			return
+		}
+	}
+}
+
+func (r *Receiver) consume(ctx context.Context, page Page) error {
+	if r.nextTraces != nil {
+		count := page.Traces.SpanCount()
+		if count == 0 {
+			return nil
+		}
+		obsCtx := r.obsrecv.StartTracesOp(ctx)
+		err := r.nextTraces.ConsumeTraces(obsCtx, page.Traces)
+		r.obsrecv.EndTracesOp(obsCtx, r.format, count, err)
+		return err
+	}
+
+	count := page.Logs.LogRecordCount()
+	if count == 0 {
+		return nil
+	}
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err := r.nextConsumer.ConsumeLogs(obsCtx, page.Logs)
+	r.obsrecv.EndLogsOp(obsCtx, r.format, count, err)
+	return err
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pollingsaas
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+// fakeSource returns numbered events, pageSize at a time, up to total.
+type fakeSource struct {
+	mu       sync.Mutex
+	total    int
+	pageSize int
+	cursors  []string
+	err      error
+}
+
+func (s *fakeSource) Poll(_ context.Context, _ *Client, cursor string) (Page, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors = append(s.cursors, cursor)
+	if s.err != nil {
+		return Page{}, s.err
+	}
+	next := 0
+	if cursor != "" {
+		next, _ = strconv.Atoi(cursor)
+	}
+	logs := pdata.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for ; next < s.total && records.Len() < s.pageSize; next++ {
+		records.AppendEmpty().Body().SetIntVal(int64(next))
+	}
+	return Page{Logs: logs, Cursor: strconv.Itoa(next), HasMore: next < s.total}, nil
+}
+
+func (s *fakeSource) polledCursors() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.cursors...)
+}
+
+func newTestReceiver(source Source, storage string, next *consumertest.LogsSink) *Receiver {
+	settings := NewDefaultSettings()
+	settings.PollInterval = time.Hour
+	settings.RequestsPerSecond = 1000
+	settings.Storage = storage
+	return NewReceiver(config.NewComponentID("test"), "test", settings, confighttp.HTTPClientSettings{}, nil, source,
+		next, componenttest.NewNopReceiverCreateSettings())
+}
+
+func TestReceiverPollsAllPages(t *testing.T) {
+	source := &fakeSource{total: 5, pageSize: 2}
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(source, "", sink)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 5 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"", "2", "4"}, source.polledCursors())
+	assert.Len(t, sink.AllLogs(), 3)
+}
+
+func TestReceiverPersistsCursor(t *testing.T) {
+	storageHost := storagetest.NewStorageHost(t, t.TempDir(), "cursor")
+
+	source := &fakeSource{total: 3, pageSize: 2}
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(source, "nop/cursor", sink)
+	require.NoError(t, r.Start(context.Background(), storageHost))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// A new receiver resumes after the events already consumed.
+	source = &fakeSource{total: 4, pageSize: 2}
+	sink = new(consumertest.LogsSink)
+	r = newTestReceiver(source, "nop/cursor", sink)
+	require.NoError(t, r.Start(context.Background(), storageHost))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, "3", source.polledCursors()[0])
+	assert.Equal(t, int64(3), sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body().IntVal())
+}
+
+func TestReceiverDoesNotAdvanceOnConsumerError(t *testing.T) {
+	source := &fakeSource{total: 4, pageSize: 2}
+	r := newTestReceiver(source, "", nil)
+	r.nextConsumer = consumertest.NewErr(errors.New("consumer failed"))
+	r.client = newTestClient(nil)
+
+	r.poll(context.Background())
+	assert.Equal(t, "", r.cursor)
+	r.poll(context.Background())
+	assert.Equal(t, []string{"", ""}, source.polledCursors())
+}
+
+func TestReceiverSourceError(t *testing.T) {
+	source := &fakeSource{err: errors.New("unavailable")}
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(source, "", sink)
+	r.client = newTestClient(nil)
+
+	r.poll(context.Background())
+	assert.Equal(t, 0, sink.LogRecordCount())
+	assert.Equal(t, []string{""}, source.polledCursors())
+}
+
+func TestReceiverStorageErrors(t *testing.T) {
+	r := newTestReceiver(&fakeSource{}, "nop/missing", new(consumertest.LogsSink))
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), `storage extension "nop/missing" not found`)
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+// traceSource returns a span per page, up to total.
+type traceSource struct {
+	total int
+}
+
+func (s *traceSource) Poll(_ context.Context, _ *Client, cursor string) (Page, error) {
+	next := 0
+	if cursor != "" {
+		next, _ = strconv.Atoi(cursor)
+	}
+	traces := pdata.NewTraces()
+	if next < s.total {
+		span := traces.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName(strconv.Itoa(next))
+		next++
+	}
+	return Page{Traces: traces, Cursor: strconv.Itoa(next), HasMore: next < s.total}, nil
+}
+
+func TestTracesReceiver(t *testing.T) {
+	settings := NewDefaultSettings()
+	settings.PollInterval = time.Hour
+	settings.RequestsPerSecond = 1000
+	sink := new(consumertest.TracesSink)
+	r := NewTracesReceiver(config.NewComponentID("test"), "test", settings, confighttp.HTTPClientSettings{}, nil,
+		&traceSource{total: 3}, sink, componenttest.NewNopReceiverCreateSettings())
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.SpanCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Len(t, sink.AllTraces(), 3)
+	assert.Equal(t, "3", r.cursor)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pollingsaas
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	defaultPollInterval      = 5 * time.Minute
+	defaultRequestsPerSecond = 5
+	defaultMaxRetries        = 3
+)
+
+// Settings are the polling settings shared by SaaS receivers, meant to be
+// squashed into their configuration.
+type Settings struct {
+	// PollInterval is the time between two polls. A poll fetches pages until
+	// the source reports there is nothing more to fetch.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// Storage is the name of the storage extension used to persist the
+	// cursor, so that a restarted receiver resumes where it stopped. The
+	// cursor is only kept in memory if not set.
+	Storage string `mapstructure:"storage"`
+
+	// RequestsPerSecond limits the rate of requests sent to the API.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// MaxRetries is the number of times a request is retried when the API
+	// responds it is rate limited or unavailable.
+	MaxRetries int `mapstructure:"max_retries"`
+}
+
+// NewDefaultSettings returns the default polling settings.
+func NewDefaultSettings() Settings {
+	return Settings{
+		PollInterval:      defaultPollInterval,
+		RequestsPerSecond: defaultRequestsPerSecond,
+		MaxRetries:        defaultMaxRetries,
+	}
+}
+
+// Validate checks if the polling settings are valid.
+func (s *Settings) Validate() error {
+	if s.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	if s.RequestsPerSecond <= 0 {
+		return errors.New("requests_per_second must be positive")
+	}
+	if s.MaxRetries < 0 {
+		return errors.New("max_retries must not be negative")
+	}
+	if s.Storage != "" {
+		if _, err := config.NewComponentIDFromString(s.Storage); err != nil {
+			return fmt.Errorf("invalid storage: %w", err)
+		}
+	}
+	return nil
+}
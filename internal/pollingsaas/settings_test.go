@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pollingsaas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSettings(t *testing.T) {
+	s := NewDefaultSettings()
+	assert.NoError(t, s.Validate())
+
+	s = NewDefaultSettings()
+	s.PollInterval = 0
+	assert.EqualError(t, s.Validate(), "poll_interval must be positive")
+
+	s = NewDefaultSettings()
+	s.RequestsPerSecond = 0
+	assert.EqualError(t, s.Validate(), "requests_per_second must be positive")
+
+	s = NewDefaultSettings()
+	s.MaxRetries = -1
+	assert.EqualError(t, s.Validate(), "max_retries must not be negative")
+
+	s = NewDefaultSettings()
+	s.Storage = "file_storage/"
+	assert.Error(t, s.Validate())
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prommetrics converts Prometheus metric families, as decoded from the
+// text or protobuf exposition formats, to pdata metrics.
+package prommetrics
+
+import (
+	"fmt"
+	"math"
+
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Validate checks that the metrics of a family can be converted: they must not
+// have timestamps, and the buckets of histograms must be cumulative.
+func Validate(mf *dto.MetricFamily) error {
+	for _, m := range mf.GetMetric() {
+		if m.TimestampMs != nil {
+			return fmt.Errorf("metric family %q must not have timestamps", mf.GetName())
+		}
+		if mf.GetType() != dto.MetricType_HISTOGRAM {
+			continue
+		}
+		var previous uint64
+		for _, b := range m.GetHistogram().GetBucket() {
+			if b.GetCumulativeCount() < previous {
+				return fmt.Errorf("buckets of histogram %q are not cumulative", mf.GetName())
+			}
+			previous = b.GetCumulativeCount()
+			if math.IsInf(b.GetUpperBound(), 1) {
+				break
+			}
+		}
+		if m.GetHistogram().GetSampleCount() < previous {
+			return fmt.Errorf("count of histogram %q is lower than its buckets", mf.GetName())
+		}
+	}
+	return nil
+}
+
+// AppendFamily appends the metric converted from a validated family. Counters,
+// histograms and summaries are cumulative from start, untyped metrics are
+// gauges. Labels in dropLabels are not set as attributes of the data points.
+func AppendFamily(metrics pdata.MetricSlice, mf *dto.MetricFamily, start, ts pdata.Timestamp, dropLabels map[string]bool) {
+	metric := metrics.AppendEmpty()
+	metric.SetName(mf.GetName())
+	metric.SetDescription(mf.GetHelp())
+
+	switch mf.GetType() {
+	case dto.MetricType_COUNTER:
+		metric.SetDataType(pdata.MetricDataTypeSum)
+		sum := metric.Sum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		for _, m := range mf.GetMetric() {
+			dp := sum.DataPoints().AppendEmpty()
+			setAttributes(dp.Attributes(), m, dropLabels)
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			dp.SetDoubleVal(m.GetCounter().GetValue())
+		}
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		metric.SetDataType(pdata.MetricDataTypeGauge)
+		for _, m := range mf.GetMetric() {
+			dp := metric.Gauge().DataPoints().AppendEmpty()
+			setAttributes(dp.Attributes(), m, dropLabels)
+			dp.SetTimestamp(ts)
+			if mf.GetType() == dto.MetricType_GAUGE {
+				dp.SetDoubleVal(m.GetGauge().GetValue())
+			} else {
+				dp.SetDoubleVal(m.GetUntyped().GetValue())
+			}
+		}
+	case dto.MetricType_SUMMARY:
+		metric.SetDataType(pdata.MetricDataTypeSummary)
+		for _, m := range mf.GetMetric() {
+			dp := metric.Summary().DataPoints().AppendEmpty()
+			setAttributes(dp.Attributes(), m, dropLabels)
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			dp.SetCount(m.GetSummary().GetSampleCount())
+			dp.SetSum(m.GetSummary().GetSampleSum())
+			for _, q := range m.GetSummary().GetQuantile() {
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(q.GetQuantile())
+				qv.SetValue(q.GetValue())
+			}
+		}
+	case dto.MetricType_HISTOGRAM:
+		metric.SetDataType(pdata.MetricDataTypeHistogram)
+		histogram := metric.Histogram()
+		histogram.SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		for _, m := range mf.GetMetric() {
+			dp := histogram.DataPoints().AppendEmpty()
+			setAttributes(dp.Attributes(), m, dropLabels)
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(ts)
+			setHistogram(dp, m.GetHistogram())
+		}
+	}
+}
+
+// setHistogram sets the buckets of a data point from the cumulative buckets
+// of a Prometheus histogram, whose +Inf bucket is implied by the count.
+func setHistogram(dp pdata.HistogramDataPoint, h *dto.Histogram) {
+	dp.SetCount(h.GetSampleCount())
+	dp.SetSum(h.GetSampleSum())
+
+	bounds := make([]float64, 0, len(h.GetBucket()))
+	counts := make([]uint64, 0, len(h.GetBucket())+1)
+	var previous uint64
+	for _, b := range h.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			continue
+		}
+		bounds = append(bounds, b.GetUpperBound())
+		counts = append(counts, b.GetCumulativeCount()-previous)
+		previous = b.GetCumulativeCount()
+	}
+	counts = append(counts, h.GetSampleCount()-previous)
+	dp.SetExplicitBounds(bounds)
+	dp.SetBucketCounts(counts)
+}
+
+func setAttributes(attrs pdata.AttributeMap, m *dto.Metric, dropLabels map[string]bool) {
+	for _, lp := range m.GetLabel() {
+		if !dropLabels[lp.GetName()] {
+			attrs.UpsertString(lp.GetName(), lp.GetValue())
+		}
+	}
+}
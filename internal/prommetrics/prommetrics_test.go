@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prommetrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func parse(t *testing.T, text string) *dto.MetricFamily {
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(strings.NewReader(text))
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	for _, mf := range families {
+		return mf
+	}
+	return nil
+}
+
+func TestAppendFamily(t *testing.T) {
+	start := pdata.NewTimestampFromTime(time.Unix(100, 0))
+	ts := pdata.NewTimestampFromTime(time.Unix(200, 0))
+
+	t.Run("counter", func(t *testing.T) {
+		metrics := pdata.NewMetricSlice()
+		AppendFamily(metrics, parse(t, "# HELP c_total Things.\n# TYPE c_total counter\nc_total{a=\"1\",job=\"j\"} 3\n"), start, ts, map[string]bool{"job": true})
+		m := metrics.At(0)
+		assert.Equal(t, "c_total", m.Name())
+		assert.Equal(t, "Things.", m.Description())
+		require.Equal(t, pdata.MetricDataTypeSum, m.DataType())
+		assert.True(t, m.Sum().IsMonotonic())
+		dp := m.Sum().DataPoints().At(0)
+		assert.Equal(t, start, dp.StartTimestamp())
+		assert.Equal(t, ts, dp.Timestamp())
+		assert.Equal(t, 3.0, dp.DoubleVal())
+		assert.Equal(t, map[string]interface{}{"a": "1"}, dp.Attributes().AsRaw())
+	})
+
+	t.Run("untyped", func(t *testing.T) {
+		metrics := pdata.NewMetricSlice()
+		AppendFamily(metrics, parse(t, "u 7\n"), start, ts, nil)
+		require.Equal(t, pdata.MetricDataTypeGauge, metrics.At(0).DataType())
+		dp := metrics.At(0).Gauge().DataPoints().At(0)
+		assert.Equal(t, 7.0, dp.DoubleVal())
+		assert.Equal(t, pdata.Timestamp(0), dp.StartTimestamp())
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		metrics := pdata.NewMetricSlice()
+		AppendFamily(metrics, parse(t, "# TYPE h histogram\nh_bucket{le=\"1\"} 2\nh_bucket{le=\"5\"} 2\nh_bucket{le=\"+Inf\"} 4\nh_sum 30\nh_count 4\n"), start, ts, nil)
+		require.Equal(t, pdata.MetricDataTypeHistogram, metrics.At(0).DataType())
+		dp := metrics.At(0).Histogram().DataPoints().At(0)
+		assert.Equal(t, []float64{1, 5}, dp.ExplicitBounds())
+		assert.Equal(t, []uint64{2, 0, 2}, dp.BucketCounts())
+		assert.Equal(t, uint64(4), dp.Count())
+		assert.Equal(t, 30.0, dp.Sum())
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		metrics := pdata.NewMetricSlice()
+		AppendFamily(metrics, parse(t, "# TYPE s summary\ns{quantile=\"0.9\"} 2\ns_sum 10\ns_count 5\n"), start, ts, nil)
+		require.Equal(t, pdata.MetricDataTypeSummary, metrics.At(0).DataType())
+		dp := metrics.At(0).Summary().DataPoints().At(0)
+		assert.Equal(t, uint64(5), dp.Count())
+		assert.Equal(t, 0.9, dp.QuantileValues().At(0).Quantile())
+		assert.Equal(t, 2.0, dp.QuantileValues().At(0).Value())
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{
+			name: "valid",
+			text: "# TYPE h histogram\nh_bucket{le=\"1\"} 2\nh_bucket{le=\"+Inf\"} 4\nh_sum 3\nh_count 4\n",
+		},
+		{
+			name: "timestamp",
+			text: "g 1 1633359600000\n",
+			err:  `metric family "g" must not have timestamps`,
+		},
+		{
+			name: "buckets",
+			text: "# TYPE h histogram\nh_bucket{le=\"1\"} 5\nh_bucket{le=\"2\"} 3\nh_bucket{le=\"+Inf\"} 5\nh_sum 3\nh_count 5\n",
+			err:  `buckets of histogram "h" are not cumulative`,
+		},
+		{
+			name: "count",
+			text: "# TYPE h histogram\nh_bucket{le=\"1\"} 5\nh_sum 3\nh_count 4\n",
+			err:  `count of histogram "h" is lower than its buckets`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(parse(t, test.text))
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
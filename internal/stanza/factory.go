@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
@@ -33,6 +34,25 @@ type LogReceiverType interface {
 	DecodeInputConfig(config.Receiver) (*operator.Config, error)
 }
 
+// ObservableLogReceiverType can be implemented by a LogReceiverType that needs
+// to observe its receivers while they run, e.g. to report acquisition metrics.
+type ObservableLogReceiverType interface {
+	LogReceiverType
+	// CreateObserver creates the observer of a receiver. A nil Observer disables observation.
+	CreateObserver(component.ReceiverCreateSettings, config.Receiver) (Observer, error)
+}
+
+// Observer observes the state persisted by the operators of a receiver and
+// the logs it emits. It is started before and shut down after the receiver's
+// operators.
+type Observer interface {
+	component.Component
+	// ObservePersisted is called with every value persisted by the operators.
+	ObservePersisted(key string, value []byte)
+	// ObserveLogs is called with every batch of logs before it is passed to the next consumer.
+	ObserveLogs(pdata.Logs)
+}
+
 // NewFactory creates a factory for a Stanza-based receiver
 func NewFactory(logReceiverType LogReceiverType) component.ReceiverFactory {
 	return receiverhelper.NewFactory(
@@ -85,6 +105,13 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 		}
 		converter := NewConverter(opts...)
 
+		var observer Observer
+		if observable, ok := logReceiverType.(ObservableLogReceiverType); ok {
+			if observer, err = observable.CreateObserver(params, cfg); err != nil {
+				return nil, err
+			}
+		}
+
 		return &receiver{
 			id:        cfg.ID(),
 			agent:     logAgent,
@@ -92,6 +119,7 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 			consumer:  nextConsumer,
 			logger:    params.Logger,
 			converter: converter,
+			observer:  observer,
 		}, nil
 	}
 }
@@ -39,6 +39,7 @@ type receiver struct {
 	storageClient storage.Client
 	converter     *Converter
 	logger        *zap.Logger
+	observer      Observer
 }
 
 // Ensure this receiver adheres to required interface
@@ -54,6 +55,12 @@ func (r *receiver) Start(ctx context.Context, host component.Host) error {
 		return fmt.Errorf("storage client: %s", setErr)
 	}
 
+	if r.observer != nil {
+		if err := r.observer.Start(ctx, host); err != nil {
+			return fmt.Errorf("start observer: %s", err)
+		}
+	}
+
 	if obsErr := r.agent.Start(r.getPersister()); obsErr != nil {
 		return fmt.Errorf("start stanza: %s", obsErr)
 	}
@@ -125,6 +132,9 @@ func (r *receiver) consumerLoop(ctx context.Context) {
 				r.logger.Debug("Converter channel got closed")
 				continue
 			}
+			if r.observer != nil {
+				r.observer.ObserveLogs(pLogs)
+			}
 			if cErr := r.consumer.ConsumeLogs(ctx, pLogs); cErr != nil {
 				r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
 			}
@@ -140,6 +150,11 @@ func (r *receiver) Shutdown(ctx context.Context) error {
 	r.cancel()
 	r.wg.Wait()
 
+	var observerErr error
+	if r.observer != nil {
+		observerErr = r.observer.Shutdown(ctx)
+	}
+
 	clientErr := r.storageClient.Close(ctx)
-	return multierr.Combine(agentErr, clientErr)
+	return multierr.Combine(agentErr, observerErr, clientErr)
 }
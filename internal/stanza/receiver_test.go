@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,7 +28,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-log-collection/pipeline"
 	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
 )
@@ -99,6 +104,70 @@ func TestHandleConsumeError(t *testing.T) {
 	logsReceiver.Shutdown(context.Background())
 }
 
+type testObserver struct {
+	component.Component
+	mu        sync.Mutex
+	persisted map[string][]byte
+	logs      int
+}
+
+func (o *testObserver) ObservePersisted(key string, value []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.persisted[key] = value
+}
+
+func (o *testObserver) ObserveLogs(ld pdata.Logs) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.logs += ld.LogRecordCount()
+}
+
+func (o *testObserver) observedLogs() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.logs
+}
+
+type observableReceiverType struct {
+	TestReceiverType
+	observer *testObserver
+}
+
+func (f observableReceiverType) CreateObserver(component.ReceiverCreateSettings, config.Receiver) (Observer, error) {
+	return f.observer, nil
+}
+
+func TestObserver(t *testing.T) {
+	mockConsumer := mockLogsConsumer{}
+	observer := &testObserver{Component: componenthelper.New(), persisted: map[string][]byte{}}
+
+	factory := NewFactory(observableReceiverType{observer: observer})
+	logsReceiver, err := factory.CreateLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		factory.CreateDefaultConfig(),
+		&mockConsumer,
+	)
+	require.NoError(t, err, "receiver should successfully build")
+	require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	stanzaReceiver := logsReceiver.(*receiver)
+	require.NoError(t, stanzaReceiver.getPersister().Set(context.Background(), "key", []byte("value")))
+	stanzaReceiver.emitter.logChan <- entry.New()
+
+	require.Eventually(t,
+		func() bool {
+			return mockConsumer.Received() == 1 && observer.observedLogs() == 1
+		},
+		10*time.Second, 5*time.Millisecond, "one log entry expected",
+	)
+	observer.mu.Lock()
+	require.Equal(t, []byte("value"), observer.persisted["key"])
+	observer.mu.Unlock()
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+}
+
 func BenchmarkReadLine(b *testing.B) {
 
 	tempDir, err := ioutil.TempDir("", "")
@@ -49,11 +49,12 @@ func (r *receiver) setStorageClient(ctx context.Context, host component.Host) er
 }
 
 func (r *receiver) getPersister() operator.Persister {
-	return &persister{r.storageClient}
+	return &persister{client: r.storageClient, observer: r.observer}
 }
 
 type persister struct {
-	client storage.Client
+	client   storage.Client
+	observer Observer
 }
 
 var _ operator.Persister = &persister{}
@@ -63,6 +64,9 @@ func (p *persister) Get(ctx context.Context, key string) ([]byte, error) {
 }
 
 func (p *persister) Set(ctx context.Context, key string, value []byte) error {
+	if p.observer != nil {
+		p.observer.ObservePersisted(key, value)
+	}
 	return p.client.Set(ctx, key, value)
 }
 
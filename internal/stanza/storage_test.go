@@ -17,12 +17,18 @@ package stanza
 import (
 	"context"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/diskbudget"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
@@ -85,6 +91,53 @@ func TestFailOnMultipleStorageExtensions(t *testing.T) {
 	require.Equal(t, "storage client: multiple storage extensions found", err.Error())
 }
 
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestDiskBudgetStorage(t *testing.T) {
+	ctx := context.Background()
+
+	factory := diskbudget.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*diskbudget.Config)
+	cfg.Directory = t.TempDir()
+	cfg.MaxSizeMiB = 1
+	ext, err := factory.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(ctx)) }()
+
+	// The extensions of the example configuration: disk_budget is the only storage
+	// extension, next to an extension that does not store anything.
+	host := extensionsHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID("disk_budget"):  ext,
+			config.NewComponentID("health_check"): componenthelper.New(),
+		},
+	}
+
+	r := createReceiver(t)
+	require.NoError(t, r.Start(ctx, host))
+	defer func() { require.NoError(t, r.Shutdown(ctx)) }()
+
+	myBytes := []byte("my_value")
+	require.NoError(t, r.storageClient.Set(ctx, "key", myBytes))
+	val, err := r.storageClient.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, myBytes, val)
+
+	// Writes go through the budget.
+	err = r.storageClient.Set(ctx, "big", []byte(strings.Repeat("x", 1024*1024)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "disk budget exceeded")
+}
+
 func createReceiver(t *testing.T) *receiver {
 	params := component.ReceiverCreateSettings{
 		TelemetrySettings: component.TelemetrySettings{
@@ -123,3 +176,12 @@ func TestPersisterImplementation(t *testing.T) {
 	err = p.Delete(ctx, "key")
 	require.NoError(t, err)
 }
+
+func TestPersisterNotifiesObserver(t *testing.T) {
+	ctx := context.Background()
+	observer := &testObserver{Component: componenthelper.New(), persisted: map[string][]byte{}}
+	p := &persister{client: storage.NewNopClient(), observer: observer}
+
+	require.NoError(t, p.Set(ctx, "$.file_input.knownFiles", []byte("state")))
+	require.Equal(t, []byte("state"), observer.persisted["$.file_input.knownFiles"])
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featuregate provides a registry of feature gates, flags enabling
+// or disabling features of components, which can be changed while the
+// collector is running.
+package featuregate
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Gate is a feature gate.
+type Gate struct {
+	// ID uniquely identifies the gate, e.g. processor.foo.newAlgorithm.
+	ID string `json:"id"`
+	// Description describes the feature enabled by the gate.
+	Description string `json:"description"`
+	// Enabled is whether the feature is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// Registry holds the feature gates.
+type Registry struct {
+	mu    sync.RWMutex
+	gates map[string]Gate
+}
+
+var globalRegistry = NewRegistry()
+
+// GetRegistry returns the global registry, in which components register
+// their feature gates.
+func GetRegistry() *Registry {
+	return globalRegistry
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{gates: make(map[string]Gate)}
+}
+
+// Register adds a gate to the registry. It fails if a gate with the same ID
+// is already registered.
+func (r *Registry) Register(g Gate) error {
+	if g.ID == "" {
+		return fmt.Errorf("feature gate ID must not be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.gates[g.ID]; ok {
+		return fmt.Errorf("feature gate %q is already registered", g.ID)
+	}
+	r.gates[g.ID] = g
+	return nil
+}
+
+// MustRegister adds a gate to the registry, and panics if it fails.
+// It is meant to be called from the init function of packages.
+func (r *Registry) MustRegister(g Gate) {
+	if err := r.Register(g); err != nil {
+		panic(err)
+	}
+}
+
+// IsEnabled returns whether the gate is enabled, false if it is not
+// registered.
+func (r *Registry) IsEnabled(id string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.gates[id].Enabled
+}
+
+// Set enables or disables a registered gate.
+func (r *Registry) Set(id string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.gates[id]
+	if !ok {
+		return fmt.Errorf("feature gate %q is not registered", id)
+	}
+	g.Enabled = enabled
+	r.gates[id] = g
+	return nil
+}
+
+// List returns the registered gates sorted by ID.
+func (r *Registry) List() []Gate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	gates := make([]Gate, 0, len(r.gates))
+	for _, g := range r.gates {
+		gates = append(gates, g)
+	}
+	sort.Slice(gates, func(i, j int) bool { return gates[i].ID < gates[j].ID })
+	return gates
+}
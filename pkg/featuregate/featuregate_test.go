@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featuregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(Gate{ID: "b", Description: "feature b", Enabled: true}))
+	require.NoError(t, r.Register(Gate{ID: "a", Description: "feature a"}))
+
+	assert.EqualError(t, r.Register(Gate{ID: "a"}), `feature gate "a" is already registered`)
+	assert.EqualError(t, r.Register(Gate{}), "feature gate ID must not be empty")
+	assert.Panics(t, func() { r.MustRegister(Gate{ID: "a"}) })
+
+	assert.False(t, r.IsEnabled("a"))
+	assert.True(t, r.IsEnabled("b"))
+	assert.False(t, r.IsEnabled("unknown"))
+
+	require.NoError(t, r.Set("a", true))
+	require.NoError(t, r.Set("b", false))
+	assert.EqualError(t, r.Set("unknown", true), `feature gate "unknown" is not registered`)
+
+	assert.Equal(t, []Gate{
+		{ID: "a", Description: "feature a", Enabled: true},
+		{ID: "b", Description: "feature b", Enabled: false},
+	}, r.List())
+}
+
+func TestGetRegistry(t *testing.T) {
+	assert.Same(t, GetRegistry(), GetRegistry())
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultReplacement replaces the characters not kept by SanitizationRules
+// when no Replacement is configured.
+const defaultReplacement = '_'
+
+// SanitizationRules define how metric and dimension names are sanitized
+// before being sent to SignalFx. Letters and digits are always kept.
+type SanitizationRules struct {
+	// AllowedChars lists the characters, in addition to letters and digits,
+	// that are kept as is.
+	AllowedChars string `mapstructure:"allowed_chars"`
+
+	// PreserveDots keeps the dots of the names, even if AllowedChars does not
+	// list them. Disable it and keep "." out of AllowedChars to match the
+	// naming of the SignalFx Smart Agent.
+	PreserveDots bool `mapstructure:"preserve_dots"`
+
+	// Replacement is the single character that replaces the characters that
+	// are not kept, "_" if empty.
+	Replacement string `mapstructure:"replacement"`
+}
+
+// Validate checks that the replacement is a single character kept by the
+// rules, which makes sanitizing a name twice yield the same name.
+func (r SanitizationRules) Validate() error {
+	if r.Replacement == "" {
+		return nil
+	}
+	c, size := utf8.DecodeRuneInString(r.Replacement)
+	if c == utf8.RuneError || size != len(r.Replacement) {
+		return fmt.Errorf("replacement %q must be a single character", r.Replacement)
+	}
+	if !r.keeps(c) {
+		return fmt.Errorf("replacement %q must be a letter, a digit or one of the allowed characters", r.Replacement)
+	}
+	return nil
+}
+
+// Sanitize replaces the characters of name that are not kept by the rules.
+func (r SanitizationRules) Sanitize(name string) string {
+	replacement := defaultReplacement
+	if r.Replacement != "" {
+		replacement, _ = utf8.DecodeRuneInString(r.Replacement)
+	}
+	return strings.Map(func(c rune) rune {
+		if r.keeps(c) {
+			return c
+		}
+		return replacement
+	}, name)
+}
+
+func (r SanitizationRules) keeps(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) ||
+		(r.PreserveDots && c == '.') ||
+		strings.ContainsRune(r.AllowedChars, c)
+}
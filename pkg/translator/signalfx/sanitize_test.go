@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules SanitizationRules
+		in    string
+		want  string
+	}{
+		{
+			name: "letters and digits only",
+			in:   "k8s.pod-name/ü",
+			want: "k8s_pod_name_ü",
+		},
+		{
+			name:  "allowed chars",
+			rules: SanitizationRules{AllowedChars: "_-."},
+			in:    "k8s.pod-name/x",
+			want:  "k8s.pod-name_x",
+		},
+		{
+			name:  "preserve dots",
+			rules: SanitizationRules{AllowedChars: "_-", PreserveDots: true},
+			in:    "k8s.pod-name/x",
+			want:  "k8s.pod-name_x",
+		},
+		{
+			name:  "smart agent",
+			rules: SanitizationRules{AllowedChars: "_-"},
+			in:    "k8s.pod-name/x",
+			want:  "k8s_pod-name_x",
+		},
+		{
+			name:  "custom replacement",
+			rules: SanitizationRules{AllowedChars: "-", Replacement: "-"},
+			in:    "k8s.pod_name",
+			want:  "k8s-pod-name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rules.Sanitize(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, got, tt.rules.Sanitize(got))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules SanitizationRules
+		err   string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:  "allowed replacement",
+			rules: SanitizationRules{AllowedChars: ":", Replacement: ":"},
+		},
+		{
+			name:  "dot with preserved dots",
+			rules: SanitizationRules{PreserveDots: true, Replacement: "."},
+		},
+		{
+			name:  "several characters",
+			rules: SanitizationRules{AllowedChars: "_", Replacement: "__"},
+			err:   `replacement "__" must be a single character`,
+		},
+		{
+			name:  "replacement not kept",
+			rules: SanitizationRules{Replacement: "-"},
+			err:   `replacement "-" must be a letter, a digit or one of the allowed characters`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomalyprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// DetectorType is the type of a detector.
+type DetectorType string
+
+const (
+	// Threshold detects values above or below static limits.
+	Threshold DetectorType = "threshold"
+	// RateOfChange detects values changing faster than a rate per second.
+	RateOfChange DetectorType = "rate_of_change"
+	// ZScore detects values deviating from the mean of the previous values of
+	// their series by more than a number of standard deviations.
+	ZScore DetectorType = "zscore"
+)
+
+const (
+	defaultWindowSize = 30
+	defaultMinSamples = 10
+	defaultMaxZScore  = 3
+	defaultSeverity   = "WARN"
+)
+
+// Config defines configuration for the anomaly processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// LogsExporter is the name of the logs exporter events are sent to.
+	LogsExporter string `mapstructure:"logs_exporter"`
+
+	// Detectors are applied to the data points of the metrics they select.
+	Detectors []DetectorConfig `mapstructure:"detectors"`
+
+	// MaxSeries is the maximum number of series the state of each detector is
+	// kept for. Data points of new series are ignored past the limit.
+	MaxSeries int `mapstructure:"max_series"`
+}
+
+// DetectorConfig defines a detector.
+type DetectorConfig struct {
+	// Name identifies the detector in events.
+	Name string `mapstructure:"name"`
+
+	// Metrics are the names of the metrics the detector applies to. Only
+	// gauges and sums are supported.
+	Metrics []string `mapstructure:"metrics"`
+
+	// Config configures how metric names are matched. Names are matched
+	// strictly by default.
+	filterset.Config `mapstructure:",squash"`
+
+	// Type is the type of the detector: threshold, rate_of_change or zscore.
+	Type DetectorType `mapstructure:"type"`
+
+	// Above and Below are the limits of threshold detectors.
+	Above *float64 `mapstructure:"above"`
+	Below *float64 `mapstructure:"below"`
+
+	// MaxRate is the maximum absolute change per second of the values of
+	// rate_of_change detectors.
+	MaxRate float64 `mapstructure:"max_rate"`
+
+	// WindowSize is the number of previous values the mean and standard
+	// deviation of zscore detectors are computed over. Defaults to 30.
+	WindowSize int `mapstructure:"window_size"`
+	// MinSamples is the minimum number of previous values zscore detectors
+	// need to detect anomalies. Defaults to 10.
+	MinSamples int `mapstructure:"min_samples"`
+	// MaxZScore is the number of standard deviations from the mean values of
+	// zscore detectors must not exceed. Defaults to 3.
+	MaxZScore float64 `mapstructure:"max_zscore"`
+
+	// Severity is the severity text of events: INFO, WARN, ERROR or FATAL.
+	// Defaults to WARN.
+	Severity string `mapstructure:"severity"`
+
+	// Cooldown is the minimum duration between two events of a series.
+	Cooldown time.Duration `mapstructure:"cooldown"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.LogsExporter == "" {
+		return errors.New("logs_exporter is required")
+	}
+	if len(cfg.Detectors) == 0 {
+		return errors.New("at least one detector is required")
+	}
+	if cfg.MaxSeries <= 0 {
+		return errors.New("max_series must be positive")
+	}
+	names := map[string]bool{}
+	for i := range cfg.Detectors {
+		d := cfg.Detectors[i].withDefaults()
+		if err := d.validate(); err != nil {
+			return fmt.Errorf("detectors[%d]: %w", i, err)
+		}
+		if names[d.Name] {
+			return fmt.Errorf("detectors[%d]: duplicate name %q", i, d.Name)
+		}
+		names[d.Name] = true
+	}
+	return nil
+}
+
+func (d *DetectorConfig) validate() error {
+	if d.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(d.Metrics) == 0 {
+		return errors.New("metrics are required")
+	}
+	if _, err := d.filterSet(); err != nil {
+		return err
+	}
+	switch d.Type {
+	case Threshold:
+		if d.Above == nil && d.Below == nil {
+			return errors.New("above or below is required")
+		}
+	case RateOfChange:
+		if d.MaxRate <= 0 {
+			return errors.New("max_rate must be positive")
+		}
+	case ZScore:
+		if d.WindowSize < 2 {
+			return errors.New("window_size must be at least 2")
+		}
+		if d.MinSamples < 2 || d.MinSamples > d.WindowSize {
+			return errors.New("min_samples must be between 2 and window_size")
+		}
+		if d.MaxZScore <= 0 {
+			return errors.New("max_zscore must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", d.Type)
+	}
+	if _, ok := severities[d.Severity]; !ok {
+		return fmt.Errorf("unknown severity %q", d.Severity)
+	}
+	if d.Cooldown < 0 {
+		return errors.New("cooldown must not be negative")
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the detector with defaults applied to the
+// settings left empty. Detectors are a list, so their defaults can't be set
+// in the default configuration.
+func (d DetectorConfig) withDefaults() DetectorConfig {
+	if d.Type == ZScore {
+		if d.WindowSize == 0 {
+			d.WindowSize = defaultWindowSize
+		}
+		if d.MinSamples == 0 {
+			d.MinSamples = defaultMinSamples
+			if d.MinSamples > d.WindowSize {
+				d.MinSamples = d.WindowSize
+			}
+		}
+		if d.MaxZScore == 0 {
+			d.MaxZScore = defaultMaxZScore
+		}
+	}
+	if d.Severity == "" {
+		d.Severity = defaultSeverity
+	}
+	return d
+}
+
+func (d *DetectorConfig) filterSet() (filterset.FilterSet, error) {
+	fsCfg := d.Config
+	if fsCfg.MatchType == "" {
+		fsCfg.MatchType = filterset.Strict
+	}
+	return filterset.CreateFilterSet(d.Metrics, &fsCfg)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomalyprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		LogsExporter:      "nop",
+		MaxSeries:         defaultMaxSeries,
+		Detectors: []DetectorConfig{
+			{
+				Name:    "high_cpu",
+				Metrics: []string{"system.cpu.utilization"},
+				Type:    Threshold,
+				Above:   float64Ptr(0.9),
+			},
+		},
+	}, cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		LogsExporter:      "nop/events",
+		MaxSeries:         100,
+		Detectors: []DetectorConfig{
+			{
+				Name:     "disk_fill_rate",
+				Metrics:  []string{`^system\.filesystem\..*`},
+				Config:   filterset.Config{MatchType: filterset.Regexp},
+				Type:     RateOfChange,
+				MaxRate:  1048576,
+				Severity: "ERROR",
+				Cooldown: 5 * time.Minute,
+			},
+			{
+				Name:       "latency",
+				Metrics:    []string{"http.server.duration"},
+				Type:       ZScore,
+				WindowSize: 60,
+				MinSamples: 20,
+				MaxZScore:  4,
+			},
+			{
+				Name:    "queue_size",
+				Metrics: []string{"queue.size"},
+				Type:    Threshold,
+				Above:   float64Ptr(1000),
+				Below:   float64Ptr(0),
+			},
+		},
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no logs exporter",
+			modify: func(cfg *Config) { cfg.LogsExporter = "" },
+			err:    "logs_exporter is required",
+		},
+		{
+			name:   "no detectors",
+			modify: func(cfg *Config) { cfg.Detectors = nil },
+			err:    "at least one detector is required",
+		},
+		{
+			name:   "max series",
+			modify: func(cfg *Config) { cfg.MaxSeries = 0 },
+			err:    "max_series must be positive",
+		},
+		{
+			name:   "no name",
+			modify: func(cfg *Config) { cfg.Detectors[0].Name = "" },
+			err:    "detectors[0]: name is required",
+		},
+		{
+			name:   "duplicate name",
+			modify: func(cfg *Config) { cfg.Detectors[1].Name = "cpu" },
+			err:    `detectors[1]: duplicate name "cpu"`,
+		},
+		{
+			name:   "no metrics",
+			modify: func(cfg *Config) { cfg.Detectors[0].Metrics = nil },
+			err:    "detectors[0]: metrics are required",
+		},
+		{
+			name: "invalid regexp",
+			modify: func(cfg *Config) {
+				cfg.Detectors[0].MatchType = filterset.Regexp
+				cfg.Detectors[0].Metrics = []string{"("}
+			},
+			err: "detectors[0]: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "unknown type",
+			modify: func(cfg *Config) { cfg.Detectors[0].Type = "ewma" },
+			err:    `detectors[0]: unknown type "ewma"`,
+		},
+		{
+			name:   "threshold without limits",
+			modify: func(cfg *Config) { cfg.Detectors[0].Above = nil },
+			err:    "detectors[0]: above or below is required",
+		},
+		{
+			name:   "rate of change without max rate",
+			modify: func(cfg *Config) { cfg.Detectors[1].MaxRate = 0 },
+			err:    "detectors[1]: max_rate must be positive",
+		},
+		{
+			name:   "small window",
+			modify: func(cfg *Config) { cfg.Detectors[2].WindowSize = 1 },
+			err:    "detectors[2]: window_size must be at least 2",
+		},
+		{
+			name:   "min samples above window",
+			modify: func(cfg *Config) { cfg.Detectors[2].MinSamples = 40 },
+			err:    "detectors[2]: min_samples must be between 2 and window_size",
+		},
+		{
+			name:   "negative z-score",
+			modify: func(cfg *Config) { cfg.Detectors[2].MaxZScore = -1 },
+			err:    "detectors[2]: max_zscore must be positive",
+		},
+		{
+			name:   "unknown severity",
+			modify: func(cfg *Config) { cfg.Detectors[0].Severity = "warning" },
+			err:    `detectors[0]: unknown severity "warning"`,
+		},
+		{
+			name:   "negative cooldown",
+			modify: func(cfg *Config) { cfg.Detectors[0].Cooldown = -time.Second },
+			err:    "detectors[0]: cooldown must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.LogsExporter = "nop"
+			cfg.Detectors = []DetectorConfig{
+				{Name: "cpu", Metrics: []string{"cpu"}, Type: Threshold, Above: float64Ptr(0.9)},
+				{Name: "requests", Metrics: []string{"requests"}, Type: RateOfChange, MaxRate: 100},
+				{Name: "latency", Metrics: []string{"latency"}, Type: ZScore},
+			}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomalyprocessor
+
+import (
+	"math"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+var severities = map[string]pdata.SeverityNumber{
+	"INFO":  pdata.SeverityNumberINFO,
+	"WARN":  pdata.SeverityNumberWARN,
+	"ERROR": pdata.SeverityNumberERROR,
+	"FATAL": pdata.SeverityNumberFATAL,
+}
+
+// series is the state of a detector for a series of data points.
+type series struct {
+	prevValue float64
+	prevTime  time.Time
+	hasPrev   bool
+
+	// window is a ring buffer of the previous values of the series.
+	window []float64
+	next   int
+	count  int
+
+	lastEvent time.Time
+}
+
+// push adds a value to the window of the series.
+func (s *series) push(value float64, size int) {
+	if s.window == nil {
+		s.window = make([]float64, size)
+	}
+	s.window[s.next] = value
+	s.next = (s.next + 1) % size
+	if s.count < size {
+		s.count++
+	}
+}
+
+// stats returns the mean and standard deviation of the window.
+func (s *series) stats() (mean, stddev float64) {
+	for _, v := range s.window[:s.count] {
+		mean += v
+	}
+	mean /= float64(s.count)
+	for _, v := range s.window[:s.count] {
+		stddev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(s.count))
+}
+
+// anomaly describes a data point triggering a detector.
+type anomaly struct {
+	// score is the statistic compared to the limit: the value for threshold
+	// detectors, the rate for rate_of_change detectors and the z-score for
+	// zscore detectors.
+	score float64
+	limit float64
+}
+
+type detector struct {
+	cfg      DetectorConfig
+	metrics  filterset.FilterSet
+	severity pdata.SeverityNumber
+	series   map[string]*series
+}
+
+func newDetector(cfg DetectorConfig) (*detector, error) {
+	cfg = cfg.withDefaults()
+	fs, err := cfg.filterSet()
+	if err != nil {
+		return nil, err
+	}
+	return &detector{
+		cfg:      cfg,
+		metrics:  fs,
+		severity: severities[cfg.Severity],
+		series:   map[string]*series{},
+	}, nil
+}
+
+// observe updates the state of a series with a data point, and returns
+// whether the data point is anomalous.
+func (d *detector) observe(s *series, ts time.Time, value float64) (anomaly, bool) {
+	var a anomaly
+	var anomalous bool
+	switch d.cfg.Type {
+	case Threshold:
+		if d.cfg.Above != nil && value > *d.cfg.Above {
+			a, anomalous = anomaly{score: value, limit: *d.cfg.Above}, true
+		} else if d.cfg.Below != nil && value < *d.cfg.Below {
+			a, anomalous = anomaly{score: value, limit: *d.cfg.Below}, true
+		}
+	case RateOfChange:
+		if s.hasPrev && ts.After(s.prevTime) {
+			rate := (value - s.prevValue) / ts.Sub(s.prevTime).Seconds()
+			if math.Abs(rate) > d.cfg.MaxRate {
+				a, anomalous = anomaly{score: rate, limit: d.cfg.MaxRate}, true
+			}
+		}
+	case ZScore:
+		if s.count >= d.cfg.MinSamples {
+			mean, stddev := s.stats()
+			if stddev > 0 {
+				z := (value - mean) / stddev
+				if math.Abs(z) > d.cfg.MaxZScore {
+					a, anomalous = anomaly{score: z, limit: d.cfg.MaxZScore}, true
+				}
+			}
+		}
+		s.push(value, d.cfg.WindowSize)
+	}
+	if !s.hasPrev || ts.After(s.prevTime) {
+		s.prevValue, s.prevTime, s.hasPrev = value, ts, true
+	}
+
+	if anomalous && d.cfg.Cooldown > 0 && !s.lastEvent.IsZero() && ts.Sub(s.lastEvent) < d.cfg.Cooldown {
+		return a, false
+	}
+	if anomalous {
+		s.lastEvent = ts
+	}
+	return a, anomalous
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomalyprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type point struct {
+	offset    time.Duration
+	value     float64
+	anomalous bool
+	score     float64
+}
+
+func runDetector(t *testing.T, cfg DetectorConfig, points []point) {
+	d, err := newDetector(cfg)
+	require.NoError(t, err)
+	s := &series{}
+	for i, p := range points {
+		a, anomalous := d.observe(s, start.Add(p.offset), p.value)
+		assert.Equal(t, p.anomalous, anomalous, "point %d", i)
+		if p.anomalous {
+			assert.InDelta(t, p.score, a.score, 0.01, "point %d", i)
+		}
+	}
+}
+
+func TestThresholdDetector(t *testing.T) {
+	runDetector(t, DetectorConfig{Name: "t", Metrics: []string{"m"}, Type: Threshold, Above: float64Ptr(10), Below: float64Ptr(0)}, []point{
+		{value: 5},
+		{value: 10},
+		{value: 11, anomalous: true, score: 11},
+		{value: 0},
+		{value: -1, anomalous: true, score: -1},
+	})
+}
+
+func TestRateOfChangeDetector(t *testing.T) {
+	runDetector(t, DetectorConfig{Name: "r", Metrics: []string{"m"}, Type: RateOfChange, MaxRate: 10}, []point{
+		{offset: 0, value: 100},
+		{offset: 10 * time.Second, value: 150},
+		{offset: 20 * time.Second, value: 400, anomalous: true, score: 25},
+		{offset: 30 * time.Second, value: 200, anomalous: true, score: -20},
+		// Points out of order are ignored.
+		{offset: 25 * time.Second, value: 1000},
+		{offset: 40 * time.Second, value: 1000, anomalous: true, score: 80},
+	})
+}
+
+func TestZScoreDetector(t *testing.T) {
+	var points []point
+	for i := 0; i < 10; i++ {
+		points = append(points, point{offset: time.Duration(i) * time.Second, value: float64(10 + i%2*2)})
+	}
+	// The mean of the window is 11 and its standard deviation 1.
+	points = append(points,
+		point{offset: 10 * time.Second, value: 13},
+		point{offset: 11 * time.Second, value: 15, anomalous: true, score: 3.36},
+	)
+	runDetector(t, DetectorConfig{Name: "z", Metrics: []string{"m"}, Type: ZScore, WindowSize: 10, MinSamples: 5, MaxZScore: 3}, points)
+}
+
+func TestZScoreDetectorMinSamples(t *testing.T) {
+	runDetector(t, DetectorConfig{Name: "z", Metrics: []string{"m"}, Type: ZScore, WindowSize: 10, MinSamples: 3, MaxZScore: 0.5}, []point{
+		{value: 1},
+		{value: 100},
+		{value: 2},
+		{value: 1, anomalous: true, score: -0.72},
+	})
+	// Constant windows have no standard deviation.
+	runDetector(t, DetectorConfig{Name: "z", Metrics: []string{"m"}, Type: ZScore, WindowSize: 3, MaxZScore: 1}, []point{
+		{value: 5},
+		{value: 5},
+		{value: 5},
+		{value: 6},
+	})
+}
+
+func TestDetectorCooldown(t *testing.T) {
+	runDetector(t, DetectorConfig{Name: "t", Metrics: []string{"m"}, Type: Threshold, Above: float64Ptr(10), Cooldown: time.Minute}, []point{
+		{offset: 0, value: 20, anomalous: true, score: 20},
+		{offset: 30 * time.Second, value: 20},
+		{offset: 60 * time.Second, value: 20, anomalous: true, score: 20},
+		{offset: 90 * time.Second, value: 5},
+		{offset: 100 * time.Second, value: 20},
+	})
+}
+
+func TestSeriesStats(t *testing.T) {
+	s := &series{}
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		s.push(v, 8)
+	}
+	mean, stddev := s.stats()
+	assert.Equal(t, 5.0, mean)
+	assert.Equal(t, 2.0, stddev)
+
+	// The oldest values leave the window.
+	s.push(5, 8)
+	s.push(5, 8)
+	mean, _ = s.stats()
+	assert.Equal(t, 5.5, mean)
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomalyprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	attributeDetector = "anomaly.detector"
+	attributeType     = "anomaly.type"
+	attributeMetric   = "anomaly.metric"
+	attributeValue    = "anomaly.value"
+	attributeScore    = "anomaly.score"
+	attributeLimit    = "anomaly.limit"
+)
+
+type anomalyProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	logsExporter consumer.Logs
+
+	mu        sync.Mutex
+	detectors []*detector
+	// limitLogged tracks the detectors the series limit was logged for.
+	limitLogged map[string]bool
+}
+
+func newAnomalyProcessor(cfg *Config, logger *zap.Logger) (*anomalyProcessor, error) {
+	p := &anomalyProcessor{cfg: cfg, logger: logger, limitLogged: map[string]bool{}}
+	for _, dc := range cfg.Detectors {
+		d, err := newDetector(dc)
+		if err != nil {
+			return nil, err
+		}
+		p.detectors = append(p.detectors, d)
+	}
+	return p, nil
+}
+
+// start looks up the logs exporter events are sent to among the exporters of
+// the logs pipelines.
+func (p *anomalyProcessor) start(_ context.Context, host component.Host) error {
+	var available []string
+	for id, exp := range host.GetExporters()[config.LogsDataType] {
+		logsExp, ok := exp.(component.LogsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a logs exporter", id.String())
+		}
+		if id.String() == p.cfg.LogsExporter {
+			p.logsExporter = logsExp
+			return nil
+		}
+		available = append(available, id.String())
+	}
+	sort.Strings(available)
+	return fmt.Errorf("failed to find logs exporter %q; please configure logs_exporter from one of: %v",
+		p.cfg.LogsExporter, available)
+}
+
+func (p *anomalyProcessor) processMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	events := p.detect(md)
+	if events.LogRecordCount() > 0 {
+		if err := p.logsExporter.ConsumeLogs(ctx, events); err != nil {
+			p.logger.Error("Failed to export anomaly events", zap.Error(err))
+		}
+	}
+	return md, nil
+}
+
+// detect applies the detectors to the data points of md, and returns the
+// events of the anomalies.
+func (p *anomalyProcessor) detect(md pdata.Metrics) pdata.Logs {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := pdata.NewLogs()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := attributesKey(rm.Resource().Attributes())
+		// The resource is only added to events once it has an event.
+		var records pdata.LogSlice
+		created := false
+		emit := func() pdata.LogRecord {
+			if !created {
+				rl := events.ResourceLogs().AppendEmpty()
+				rm.Resource().CopyTo(rl.Resource())
+				records = rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+				created = true
+			}
+			return records.AppendEmpty()
+		}
+
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				var dps pdata.NumberDataPointSlice
+				switch metric.DataType() {
+				case pdata.MetricDataTypeGauge:
+					dps = metric.Gauge().DataPoints()
+				case pdata.MetricDataTypeSum:
+					dps = metric.Sum().DataPoints()
+				default:
+					continue
+				}
+				for _, d := range p.detectors {
+					if !d.metrics.Matches(metric.Name()) {
+						continue
+					}
+					for l := 0; l < dps.Len(); l++ {
+						p.observe(d, resourceKey, metric, dps.At(l), emit)
+					}
+				}
+			}
+		}
+	}
+	return events
+}
+
+func (p *anomalyProcessor) observe(d *detector, resourceKey string, metric pdata.Metric, dp pdata.NumberDataPoint, emit func() pdata.LogRecord) {
+	key := resourceKey + "\x00" + metric.Name() + "\x00" + attributesKey(dp.Attributes())
+	s, ok := d.series[key]
+	if !ok {
+		if len(d.series) >= p.cfg.MaxSeries {
+			if !p.limitLogged[d.cfg.Name] {
+				p.logger.Warn("Maximum number of series reached, ignoring new series",
+					zap.String("detector", d.cfg.Name), zap.Int("max_series", p.cfg.MaxSeries))
+				p.limitLogged[d.cfg.Name] = true
+			}
+			return
+		}
+		s = &series{}
+		d.series[key] = s
+	}
+
+	value := dp.DoubleVal()
+	if dp.Type() == pdata.MetricValueTypeInt {
+		value = float64(dp.IntVal())
+	}
+	a, anomalous := d.observe(s, dp.Timestamp().AsTime(), value)
+	if !anomalous {
+		return
+	}
+
+	lr := emit()
+	lr.SetTimestamp(dp.Timestamp())
+	lr.SetSeverityNumber(d.severity)
+	lr.SetSeverityText(d.cfg.Severity)
+	lr.SetName(d.cfg.Name)
+	lr.Body().SetStringVal(fmt.Sprintf("Anomaly detected by %q on %s: %s %g, limit %g",
+		d.cfg.Name, metric.Name(), scoreName(d.cfg.Type), a.score, a.limit))
+	attrs := lr.Attributes()
+	dp.Attributes().CopyTo(attrs)
+	attrs.UpsertString(attributeDetector, d.cfg.Name)
+	attrs.UpsertString(attributeType, string(d.cfg.Type))
+	attrs.UpsertString(attributeMetric, metric.Name())
+	attrs.UpsertDouble(attributeValue, value)
+	attrs.UpsertDouble(attributeScore, a.score)
+	attrs.UpsertDouble(attributeLimit, a.limit)
+}
+
+func scoreName(t DetectorType) string {
+	switch t {
+	case RateOfChange:
+		return "rate"
+	case ZScore:
+		return "z-score"
+	default:
+		return "value"
+	}
+}
+
+// attributesKey returns a string identifying the attributes, whatever their
+// order.
+func attributesKey(attrs pdata.AttributeMap) string {
+	kvs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		kvs = append(kvs, k+"="+v.AsString())
+		return true
+	})
+	sort.Strings(kvs)
+	return strings.Join(kvs, "\x00")
+}
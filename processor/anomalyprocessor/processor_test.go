@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomalyprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type logsExporter struct {
+	consumer.Logs
+}
+
+func (logsExporter) Start(context.Context, component.Host) error { return nil }
+
+func (logsExporter) Shutdown(context.Context) error { return nil }
+
+type metricsExporter struct {
+	consumer.Metrics
+}
+
+func (metricsExporter) Start(context.Context, component.Host) error { return nil }
+
+func (metricsExporter) Shutdown(context.Context) error { return nil }
+
+type testHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *testHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func newTestHost(logs consumer.Logs) component.Host {
+	return &testHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.LogsDataType: {
+				config.NewComponentID("otlp"):                   logsExporter{consumertest.NewNop()},
+				config.NewComponentIDWithName("otlp", "events"): logsExporter{logs},
+			},
+			config.MetricsDataType: {
+				config.NewComponentID("prometheus"): metricsExporter{consumertest.NewNop()},
+			},
+		},
+	}
+}
+
+func newTestProcessor(t *testing.T, logs consumer.Logs, next consumer.Metrics, detectors ...DetectorConfig) component.MetricsProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogsExporter = "otlp/events"
+	cfg.Detectors = detectors
+	require.NoError(t, cfg.Validate())
+
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), newTestHost(logs)))
+	return mp
+}
+
+func newMetrics(service string, points map[string]float64, ts time.Time) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", service)
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("system.cpu.utilization")
+	cpu.SetDataType(pdata.MetricDataTypeGauge)
+	for state, value := range points {
+		dp := cpu.Gauge().DataPoints().AppendEmpty()
+		dp.Attributes().InsertString("state", state)
+		dp.SetTimestamp(pdata.NewTimestampFromTime(ts))
+		dp.SetDoubleVal(value)
+	}
+
+	requests := metrics.AppendEmpty()
+	requests.SetName("http.server.requests")
+	requests.SetDataType(pdata.MetricDataTypeSum)
+	dp := requests.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(ts))
+	dp.SetIntVal(int64(ts.Sub(start).Seconds()) * 1000)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("http.server.duration")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().SetTimestamp(pdata.NewTimestampFromTime(ts))
+	return md
+}
+
+func TestProcessMetrics(t *testing.T) {
+	logs := new(consumertest.LogsSink)
+	next := new(consumertest.MetricsSink)
+	mp := newTestProcessor(t, logs, next,
+		DetectorConfig{
+			Name:     "high_cpu",
+			Metrics:  []string{"system.cpu.utilization"},
+			Type:     Threshold,
+			Above:    float64Ptr(0.9),
+			Severity: "ERROR",
+		},
+		DetectorConfig{
+			Name:    "request_spike",
+			Metrics: []string{"http.server.requests", "http.server.duration"},
+			Type:    RateOfChange,
+			MaxRate: 500,
+		},
+	)
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.5, "system": 0.2}, start)))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.95, "system": 0.2}, start.Add(10*time.Second))))
+	assert.Len(t, next.AllMetrics(), 2)
+
+	// Events are only sent when there are anomalies.
+	require.Len(t, logs.AllLogs(), 1)
+	events := logs.AllLogs()[0]
+	require.Equal(t, 1, events.ResourceLogs().Len())
+	rl := events.ResourceLogs().At(0)
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.name": pdata.NewAttributeValueString("api"),
+	}).Sort(), rl.Resource().Attributes().Sort())
+
+	records := rl.InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 2, records.Len())
+
+	cpu := records.At(0)
+	assert.Equal(t, "high_cpu", cpu.Name())
+	assert.Equal(t, pdata.SeverityNumberERROR, cpu.SeverityNumber())
+	assert.Equal(t, "ERROR", cpu.SeverityText())
+	assert.Equal(t, pdata.NewTimestampFromTime(start.Add(10*time.Second)), cpu.Timestamp())
+	assert.Equal(t, `Anomaly detected by "high_cpu" on system.cpu.utilization: value 0.95, limit 0.9`, cpu.Body().StringVal())
+	assert.Equal(t, map[string]interface{}{
+		"state":            "user",
+		"anomaly.detector": "high_cpu",
+		"anomaly.type":     "threshold",
+		"anomaly.metric":   "system.cpu.utilization",
+		"anomaly.value":    0.95,
+		"anomaly.score":    0.95,
+		"anomaly.limit":    0.9,
+	}, cpu.Attributes().AsRaw())
+
+	requests := records.At(1)
+	assert.Equal(t, "request_spike", requests.Name())
+	assert.Equal(t, pdata.SeverityNumberWARN, requests.SeverityNumber())
+	assert.Equal(t, `Anomaly detected by "request_spike" on http.server.requests: rate 1000, limit 500`, requests.Body().StringVal())
+	v, _ := requests.Attributes().Get("anomaly.value")
+	assert.Equal(t, 10000.0, v.DoubleVal())
+}
+
+func TestProcessMetricsSeriesAreIndependent(t *testing.T) {
+	logs := new(consumertest.LogsSink)
+	mp := newTestProcessor(t, logs, consumertest.NewNop(), DetectorConfig{
+		Name:    "cpu_jump",
+		Metrics: []string{"system.cpu.utilization"},
+		Type:    RateOfChange,
+		MaxRate: 0.01,
+	})
+
+	// The CPU of db jumps, but it's its first data point.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.1}, start)))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("db", map[string]float64{"user": 0.9}, start.Add(10*time.Second))))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.1, "system": 0.9}, start.Add(10*time.Second))))
+	assert.Equal(t, 0, logs.LogRecordCount())
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.9}, start.Add(20*time.Second))))
+	assert.Equal(t, 1, logs.LogRecordCount())
+}
+
+func TestProcessMetricsMaxSeries(t *testing.T) {
+	logs := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogsExporter = "otlp/events"
+	cfg.MaxSeries = 1
+	cfg.Detectors = []DetectorConfig{{
+		Name:    "high_cpu",
+		Metrics: []string{"system.cpu.utilization"},
+		Type:    Threshold,
+		Above:   float64Ptr(0.9),
+	}}
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), newTestHost(logs)))
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.95}, start)))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("db", map[string]float64{"user": 0.95}, start)))
+	assert.Equal(t, 1, logs.LogRecordCount())
+}
+
+func TestProcessMetricsExportError(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	mp := newTestProcessor(t, consumertest.NewErr(errors.New("unavailable")), next, DetectorConfig{
+		Name:    "high_cpu",
+		Metrics: []string{"system.cpu.utilization"},
+		Type:    Threshold,
+		Above:   float64Ptr(0.9),
+	})
+
+	// Metrics are forwarded even if events can't be exported.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), newMetrics("api", map[string]float64{"user": 0.95}, start)))
+	assert.Len(t, next.AllMetrics(), 1)
+}
+
+func TestStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Detectors = []DetectorConfig{{Name: "cpu", Metrics: []string{"cpu"}, Type: Threshold, Above: float64Ptr(1)}}
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	cfg.LogsExporter = "prometheus"
+	assert.EqualError(t, mp.Start(context.Background(), newTestHost(consumertest.NewNop())),
+		`failed to find logs exporter "prometheus"; please configure logs_exporter from one of: [otlp otlp/events]`)
+
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.LogsDataType: {config.NewComponentID("prometheus"): metricsExporter{consumertest.NewNop()}},
+		},
+	}
+	assert.EqualError(t, mp.Start(context.Background(), host), `the exporter "prometheus" isn't a logs exporter`)
+}
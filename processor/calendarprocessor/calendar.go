@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendarprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+type window struct {
+	value string
+	// days are the days the window starts on, nil for every day.
+	days map[time.Weekday]bool
+	// start and end are minutes of the day. The window spans midnight if
+	// end <= start.
+	start, end int
+}
+
+type calendar struct {
+	attribute string
+	location  *time.Location
+	windows   []window
+	holidays  map[string]bool
+	def       string
+}
+
+func newCalendar(cfg *CalendarConfig) (*calendar, error) {
+	if cfg.Attribute == "" {
+		return nil, errors.New("attribute must be specified")
+	}
+	if len(cfg.Windows) == 0 {
+		return nil, errors.New("at least one window is required")
+	}
+	c := &calendar{attribute: cfg.Attribute, location: time.UTC, def: cfg.Default}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+		c.location = loc
+	}
+	for i, wc := range cfg.Windows {
+		w, err := newWindow(wc)
+		if err != nil {
+			return nil, fmt.Errorf("windows[%d]: %w", i, err)
+		}
+		c.windows = append(c.windows, w)
+	}
+	if len(cfg.Holidays) > 0 {
+		c.holidays = map[string]bool{}
+		for _, holiday := range cfg.Holidays {
+			if _, err := time.Parse(dateLayout, holiday); err != nil {
+				return nil, fmt.Errorf("invalid holiday %q: expected YYYY-MM-DD", holiday)
+			}
+			c.holidays[holiday] = true
+		}
+	}
+	return c, nil
+}
+
+func newWindow(cfg WindowConfig) (window, error) {
+	w := window{value: cfg.Value}
+	if w.value == "" {
+		return w, errors.New("value must be specified")
+	}
+	if len(cfg.Days) > 0 {
+		w.days = map[time.Weekday]bool{}
+		for _, day := range cfg.Days {
+			d, ok := weekdays[day]
+			if !ok {
+				return w, fmt.Errorf("unknown day %q", day)
+			}
+			w.days[d] = true
+		}
+	}
+	if cfg.Start == "" && cfg.End == "" {
+		w.end = minutesPerDay
+		return w, nil
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(cfg.Start); err != nil {
+		return w, fmt.Errorf("invalid start: %w", err)
+	}
+	if w.end, err = parseTimeOfDay(cfg.End); err != nil {
+		return w, fmt.Errorf("invalid end: %w", err)
+	}
+	if w.start == w.end {
+		return w, errors.New("start and end must differ")
+	}
+	return w, nil
+}
+
+const dateLayout = "2006-01-02"
+
+// parseTimeOfDay parses a HH:MM time into minutes of the day.
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q isn't formatted as HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// value returns the value of the calendar at t, and false if the attribute
+// must not be set.
+func (c *calendar) value(t time.Time) (string, bool) {
+	t = t.In(c.location)
+	minute := t.Hour()*60 + t.Minute()
+	for i := range c.windows {
+		if c.windows[i].start < c.windows[i].end {
+			if c.startsOn(&c.windows[i], t) && minute >= c.windows[i].start && minute < c.windows[i].end {
+				return c.windows[i].value, true
+			}
+			continue
+		}
+		// The window spans midnight: t is either in the part starting on its
+		// day, or in the part ending on its day, which started the day before.
+		if minute >= c.windows[i].start && c.startsOn(&c.windows[i], t) {
+			return c.windows[i].value, true
+		}
+		if minute < c.windows[i].end && c.startsOn(&c.windows[i], t.AddDate(0, 0, -1)) {
+			return c.windows[i].value, true
+		}
+	}
+	return c.def, c.def != ""
+}
+
+// startsOn reports whether the window starts on the day of t.
+func (c *calendar) startsOn(w *window, t time.Time) bool {
+	if w.days != nil && !w.days[t.Weekday()] {
+		return false
+	}
+	return !c.holidays[t.Format(dateLayout)]
+}
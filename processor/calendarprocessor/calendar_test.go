@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendarprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarValue(t *testing.T) {
+	businessHours := &CalendarConfig{
+		Attribute: "business_hours",
+		Timezone:  "America/New_York",
+		Windows:   []WindowConfig{{Value: "true", Days: weekdayNames, Start: "09:00", End: "17:00"}},
+		Holidays:  []string{"2021-11-25"},
+		Default:   "false",
+	}
+	onCall := &CalendarConfig{
+		Attribute: "on_call_shift",
+		Windows: []WindowConfig{
+			{Value: "emea", Days: weekdayNames, Start: "07:00", End: "15:00"},
+			{Value: "amer", Days: weekdayNames, Start: "15:00", End: "23:00"},
+			{Value: "apac", Days: weekdayNames, Start: "23:00", End: "07:00"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		calendar *CalendarConfig
+		time     time.Time
+		value    string
+		ok       bool
+	}{
+		{
+			name:     "within business hours",
+			calendar: businessHours,
+			// Monday 09:30 in New York.
+			time:  time.Date(2021, 11, 22, 14, 30, 0, 0, time.UTC),
+			value: "true",
+			ok:    true,
+		},
+		{
+			name:     "before business hours in local time",
+			calendar: businessHours,
+			// Monday 08:59 in New York, 13:59 in UTC.
+			time:  time.Date(2021, 11, 22, 13, 59, 0, 0, time.UTC),
+			value: "false",
+			ok:    true,
+		},
+		{
+			name:     "end is exclusive",
+			calendar: businessHours,
+			time:     time.Date(2021, 11, 22, 22, 0, 0, 0, time.UTC),
+			value:    "false",
+			ok:       true,
+		},
+		{
+			name:     "weekend",
+			calendar: businessHours,
+			time:     time.Date(2021, 11, 20, 15, 0, 0, 0, time.UTC),
+			value:    "false",
+			ok:       true,
+		},
+		{
+			name:     "holiday",
+			calendar: businessHours,
+			time:     time.Date(2021, 11, 25, 15, 0, 0, 0, time.UTC),
+			value:    "false",
+			ok:       true,
+		},
+		{
+			name:     "first window",
+			calendar: onCall,
+			time:     time.Date(2021, 11, 22, 7, 0, 0, 0, time.UTC),
+			value:    "emea",
+			ok:       true,
+		},
+		{
+			name:     "window spanning midnight before midnight",
+			calendar: onCall,
+			time:     time.Date(2021, 11, 26, 23, 30, 0, 0, time.UTC),
+			value:    "apac",
+			ok:       true,
+		},
+		{
+			name:     "window spanning midnight after midnight",
+			calendar: onCall,
+			// Saturday, in the shift started on Friday.
+			time:  time.Date(2021, 11, 27, 6, 59, 0, 0, time.UTC),
+			value: "apac",
+			ok:    true,
+		},
+		{
+			name:     "no window and no default",
+			calendar: onCall,
+			time:     time.Date(2021, 11, 27, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "whole day window",
+			calendar: &CalendarConfig{
+				Attribute: "weekend",
+				Windows:   []WindowConfig{{Value: "true", Days: []string{"saturday", "sunday"}}},
+			},
+			time:  time.Date(2021, 11, 28, 23, 59, 0, 0, time.UTC),
+			value: "true",
+			ok:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newCalendar(tt.calendar)
+			require.NoError(t, err)
+			value, ok := c.value(tt.time)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendarprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// TimestampItem evaluates calendars at the timestamp of spans, log
+	// records and data points.
+	TimestampItem = "item"
+	// TimestampProcessing evaluates calendars at the time the processor
+	// handles the data.
+	TimestampProcessing = "processing"
+)
+
+// Config defines configuration for the calendar processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Timestamp is the time calendars are evaluated at: item or processing.
+	// Items without a timestamp are evaluated at the processing time.
+	// Defaults to item.
+	Timestamp string `mapstructure:"timestamp"`
+
+	// Calendars each set an attribute on spans, log records and data points.
+	Calendars []CalendarConfig `mapstructure:"calendars"`
+}
+
+// CalendarConfig defines the values of an attribute over time.
+type CalendarConfig struct {
+	// Attribute is the name of the attribute set by the calendar.
+	Attribute string `mapstructure:"attribute"`
+
+	// Timezone is the IANA name of the timezone of the windows and holidays
+	// of the calendar. Defaults to UTC.
+	Timezone string `mapstructure:"timezone"`
+
+	// Windows are the weekly time windows of the calendar. The attribute is
+	// set to the value of the first window an item falls into.
+	Windows []WindowConfig `mapstructure:"windows"`
+
+	// Holidays are dates, formatted as YYYY-MM-DD, on which no window
+	// starts.
+	Holidays []string `mapstructure:"holidays"`
+
+	// Default is the value of the attribute for items falling into no
+	// window. The attribute isn't set on such items if empty.
+	Default string `mapstructure:"default"`
+}
+
+// WindowConfig defines a weekly time window.
+type WindowConfig struct {
+	// Value is the value of the attribute during the window.
+	Value string `mapstructure:"value"`
+
+	// Days are the days of the week the window starts on, such as monday.
+	// The window starts every day if empty.
+	Days []string `mapstructure:"days"`
+
+	// Start and End are the times of day, formatted as HH:MM, the window
+	// starts and ends at. A window ending before it starts spans midnight.
+	// The window lasts the whole day if both are empty.
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.Timestamp {
+	case "", TimestampItem, TimestampProcessing:
+	default:
+		return fmt.Errorf("unknown timestamp %q", cfg.Timestamp)
+	}
+	if len(cfg.Calendars) == 0 {
+		return errors.New("at least one calendar is required")
+	}
+	for i := range cfg.Calendars {
+		if _, err := newCalendar(&cfg.Calendars[i]); err != nil {
+			return fmt.Errorf("calendars[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
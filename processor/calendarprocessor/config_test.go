@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendarprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+var weekdayNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Calendars = []CalendarConfig{{
+		Attribute: "business_hours",
+		Windows:   []WindowConfig{{Value: "true", Days: weekdayNames, Start: "09:00", End: "17:00"}},
+		Default:   "false",
+	}}
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "oncall")),
+		Timestamp:         TimestampProcessing,
+		Calendars: []CalendarConfig{
+			{
+				Attribute: "business_hours",
+				Timezone:  "Europe/Paris",
+				Windows:   []WindowConfig{{Value: "true", Days: weekdayNames, Start: "09:00", End: "18:00"}},
+				Holidays:  []string{"2021-12-24", "2021-12-25"},
+				Default:   "false",
+			},
+			{
+				Attribute: "on_call_shift",
+				Windows: []WindowConfig{
+					{Value: "emea", Start: "07:00", End: "15:00"},
+					{Value: "amer", Start: "15:00", End: "23:00"},
+					{Value: "apac", Start: "23:00", End: "07:00"},
+				},
+			},
+		},
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "oncall")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "unknown timestamp",
+			modify: func(cfg *Config) { cfg.Timestamp = "observed" },
+			err:    `unknown timestamp "observed"`,
+		},
+		{
+			name:   "no calendar",
+			modify: func(cfg *Config) { cfg.Calendars = nil },
+			err:    "at least one calendar is required",
+		},
+		{
+			name:   "missing attribute",
+			modify: func(cfg *Config) { cfg.Calendars[0].Attribute = "" },
+			err:    "calendars[0]: attribute must be specified",
+		},
+		{
+			name:   "no window",
+			modify: func(cfg *Config) { cfg.Calendars[0].Windows = nil },
+			err:    "calendars[0]: at least one window is required",
+		},
+		{
+			name:   "invalid timezone",
+			modify: func(cfg *Config) { cfg.Calendars[0].Timezone = "Mars/Olympus_Mons" },
+			err:    `calendars[0]: invalid timezone "Mars/Olympus_Mons": unknown time zone Mars/Olympus_Mons`,
+		},
+		{
+			name:   "invalid holiday",
+			modify: func(cfg *Config) { cfg.Calendars[0].Holidays = []string{"12/25/2021"} },
+			err:    `calendars[0]: invalid holiday "12/25/2021": expected YYYY-MM-DD`,
+		},
+		{
+			name:   "missing value",
+			modify: func(cfg *Config) { cfg.Calendars[0].Windows[0].Value = "" },
+			err:    "calendars[0]: windows[0]: value must be specified",
+		},
+		{
+			name:   "unknown day",
+			modify: func(cfg *Config) { cfg.Calendars[0].Windows[0].Days = []string{"mon"} },
+			err:    `calendars[0]: windows[0]: unknown day "mon"`,
+		},
+		{
+			name:   "invalid start",
+			modify: func(cfg *Config) { cfg.Calendars[0].Windows[0].Start = "9am" },
+			err:    `calendars[0]: windows[0]: invalid start: "9am" isn't formatted as HH:MM`,
+		},
+		{
+			name:   "missing end",
+			modify: func(cfg *Config) { cfg.Calendars[0].Windows[0].End = "" },
+			err:    `calendars[0]: windows[0]: invalid end: "" isn't formatted as HH:MM`,
+		},
+		{
+			name:   "empty window",
+			modify: func(cfg *Config) { cfg.Calendars[0].Windows[0].End = "09:00" },
+			err:    "calendars[0]: windows[0]: start and end must differ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Calendars = []CalendarConfig{{
+				Attribute: "business_hours",
+				Windows:   []WindowConfig{{Value: "true", Start: "09:00", End: "17:00"}},
+			}}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendarprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type calendarProcessor struct {
+	calendars []*calendar
+	// useItemTime is true if calendars are evaluated at the timestamp of
+	// items rather than the processing time.
+	useItemTime bool
+	now         func() time.Time
+}
+
+func newCalendarProcessor(cfg *Config) (*calendarProcessor, error) {
+	p := &calendarProcessor{
+		useItemTime: cfg.Timestamp != TimestampProcessing,
+		now:         time.Now,
+	}
+	for i := range cfg.Calendars {
+		c, err := newCalendar(&cfg.Calendars[i])
+		if err != nil {
+			return nil, err
+		}
+		p.calendars = append(p.calendars, c)
+	}
+	return p, nil
+}
+
+// tag sets the attributes of the calendars at the time of an item, or at now
+// if the item has no timestamp or the processing time is used.
+func (p *calendarProcessor) tag(attrs pdata.AttributeMap, ts pdata.Timestamp, now time.Time) {
+	t := now
+	if p.useItemTime && ts != 0 {
+		t = ts.AsTime()
+	}
+	for _, c := range p.calendars {
+		if value, ok := c.value(t); ok {
+			attrs.UpsertString(c.attribute, value)
+		}
+	}
+}
+
+func (p *calendarProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	now := p.now()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				p.tag(span.Attributes(), span.StartTimestamp(), now)
+			}
+		}
+	}
+	return td, nil
+}
+
+func (p *calendarProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	now := p.now()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				p.tag(lr.Attributes(), lr.Timestamp(), now)
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (p *calendarProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	now := p.now()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				p.processMetric(metrics.At(k), now)
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *calendarProcessor) processMetric(metric pdata.Metric, now time.Time) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		p.processNumberDataPoints(metric.Gauge().DataPoints(), now)
+	case pdata.MetricDataTypeSum:
+		p.processNumberDataPoints(metric.Sum().DataPoints(), now)
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.tag(dps.At(i).Attributes(), dps.At(i).Timestamp(), now)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.tag(dps.At(i).Attributes(), dps.At(i).Timestamp(), now)
+		}
+	}
+}
+
+func (p *calendarProcessor) processNumberDataPoints(dps pdata.NumberDataPointSlice, now time.Time) {
+	for i := 0; i < dps.Len(); i++ {
+		p.tag(dps.At(i).Attributes(), dps.At(i).Timestamp(), now)
+	}
+}
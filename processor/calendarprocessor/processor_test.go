@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calendarprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var (
+	// Monday 10:00 UTC.
+	businessTime = time.Date(2021, 11, 22, 10, 0, 0, 0, time.UTC)
+	// Sunday 10:00 UTC.
+	weekendTime = time.Date(2021, 11, 21, 10, 0, 0, 0, time.UTC)
+)
+
+func newTestProcessor(t *testing.T, timestamp string) *calendarProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timestamp = timestamp
+	cfg.Calendars = []CalendarConfig{{
+		Attribute: "business_hours",
+		Windows:   []WindowConfig{{Value: "true", Days: weekdayNames, Start: "09:00", End: "17:00"}},
+		Default:   "false",
+	}}
+	require.NoError(t, cfg.Validate())
+	p, err := newCalendarProcessor(cfg)
+	require.NoError(t, err)
+	p.now = func() time.Time { return weekendTime }
+	return p
+}
+
+func assertAttribute(t *testing.T, attrs pdata.AttributeMap, expected string) {
+	v, ok := attrs.Get("business_hours")
+	require.True(t, ok)
+	assert.Equal(t, expected, v.StringVal())
+}
+
+func TestProcessTraces(t *testing.T) {
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetStartTimestamp(pdata.NewTimestampFromTime(businessTime))
+	spans.AppendEmpty().SetStartTimestamp(pdata.NewTimestampFromTime(weekendTime))
+	// A span without timestamp is evaluated at the processing time.
+	spans.AppendEmpty().Attributes().InsertString("business_hours", "true")
+
+	td, err := newTestProcessor(t, TimestampItem).processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assertAttribute(t, spans.At(0).Attributes(), "true")
+	assertAttribute(t, spans.At(1).Attributes(), "false")
+	assertAttribute(t, spans.At(2).Attributes(), "false")
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	logs.AppendEmpty().SetTimestamp(pdata.NewTimestampFromTime(businessTime))
+
+	_, err := newTestProcessor(t, TimestampItem).processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assertAttribute(t, logs.At(0).Attributes(), "true")
+
+	_, err = newTestProcessor(t, TimestampProcessing).processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assertAttribute(t, logs.At(0).Attributes(), "false")
+}
+
+func TestProcessMetrics(t *testing.T) {
+	ts := pdata.NewTimestampFromTime(businessTime)
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetTimestamp(ts)
+	sum := metrics.AppendEmpty()
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().DataPoints().AppendEmpty().SetTimestamp(ts)
+	histogram := metrics.AppendEmpty()
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().SetTimestamp(ts)
+	summary := metrics.AppendEmpty()
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	summary.Summary().DataPoints().AppendEmpty().SetTimestamp(ts)
+
+	_, err := newTestProcessor(t, TimestampItem).processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assertAttribute(t, gauge.Gauge().DataPoints().At(0).Attributes(), "true")
+	assertAttribute(t, sum.Sum().DataPoints().At(0).Attributes(), "true")
+	assertAttribute(t, histogram.Histogram().DataPoints().At(0).Attributes(), "true")
+	assertAttribute(t, summary.Summary().DataPoints().At(0).Attributes(), "true")
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MatchType is how Metrics are matched, strict (the default) or regexp.
+	filterset.Config `mapstructure:",squash"`
+
+	// Metrics are the names of the metrics to convert. All the delta sums
+	// and histograms are converted if empty.
+	Metrics []string `mapstructure:"metrics"`
+
+	// MaxStale is how long the state of a stream is kept after its last data
+	// point. The next data point of an expired stream starts a new
+	// cumulative stream.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+
+	// MaxStreams limits the number of streams tracked at the same time. The
+	// data points of new streams are dropped while the limit is reached.
+	// No limit is applied if zero.
+	MaxStreams int `mapstructure:"max_streams"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if cfg.MaxStale <= 0 {
+		return errors.New("max_stale must be positive")
+	}
+	if cfg.MaxStreams < 0 {
+		return errors.New("max_streams must not be negative")
+	}
+	if len(cfg.Metrics) > 0 {
+		if _, err := createFilterSet(cfg.Metrics, cfg.Config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createFilterSet creates the filter set of metric names, strict by default.
+func createFilterSet(metrics []string, cfg filterset.Config) (filterset.FilterSet, error) {
+	if cfg.MatchType == "" {
+		cfg.MatchType = filterset.Strict
+	}
+	return filterset.CreateFilterSet(metrics, &cfg)
+}
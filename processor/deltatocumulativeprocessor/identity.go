@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// metricKey identifies the metric a stream belongs to, in its resource and
+// instrumentation library.
+func metricKey(resource pdata.Resource, il pdata.InstrumentationLibrary, metric pdata.Metric) string {
+	var b strings.Builder
+	writeAttributes(&b, resource.Attributes())
+	b.WriteString(il.Name())
+	b.WriteByte(0)
+	b.WriteString(il.Version())
+	b.WriteByte(0)
+	b.WriteString(metric.Name())
+	b.WriteByte(0)
+	b.WriteString(metric.DataType().String())
+	if metric.DataType() == pdata.MetricDataTypeSum && metric.Sum().IsMonotonic() {
+		b.WriteString("/monotonic")
+	}
+	b.WriteByte(0)
+	return b.String()
+}
+
+// streamKey identifies a stream, the data points of a metric with the same
+// attributes.
+func streamKey(metricKey string, attrs pdata.AttributeMap) string {
+	var b strings.Builder
+	b.WriteString(metricKey)
+	writeAttributes(&b, attrs)
+	return b.String()
+}
+
+// writeAttributes writes the attributes sorted by key, so that the order of
+// the attributes does not change the identity of a stream.
+func writeAttributes(b *strings.Builder, attrs pdata.AttributeMap) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(v.Type().String())
+		b.WriteByte(0)
+		b.WriteString(v.AsString())
+		b.WriteByte(0)
+	}
+	b.WriteByte(0)
+}
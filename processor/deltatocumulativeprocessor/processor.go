@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// stream is the accumulated state of the data points of a metric with the
+// same attributes.
+type stream struct {
+	start    pdata.Timestamp
+	last     pdata.Timestamp
+	lastSeen time.Time
+
+	valueType pdata.MetricValueType
+	intVal    int64
+	doubleVal float64
+
+	count   uint64
+	sum     float64
+	bounds  []float64
+	buckets []uint64
+}
+
+type deltaToCumulativeProcessor struct {
+	logger *zap.Logger
+	// metrics is nil if all the delta metrics are converted.
+	metrics    filterset.FilterSet
+	maxStale   time.Duration
+	maxStreams int
+	now        func() time.Time
+
+	mu        sync.Mutex
+	streams   map[string]*stream
+	lastSweep time.Time
+}
+
+func newDeltaToCumulativeProcessor(cfg *Config, logger *zap.Logger) (*deltaToCumulativeProcessor, error) {
+	p := &deltaToCumulativeProcessor{
+		logger:     logger,
+		maxStale:   cfg.MaxStale,
+		maxStreams: cfg.MaxStreams,
+		now:        time.Now,
+		streams:    make(map[string]*stream),
+	}
+	if len(cfg.Metrics) > 0 {
+		var err error
+		if p.metrics, err = createFilterSet(cfg.Metrics, cfg.Config); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *deltaToCumulativeProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.sweep(now)
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			ilm.Metrics().RemoveIf(func(metric pdata.Metric) bool {
+				return !p.processMetric(rm.Resource(), ilm.InstrumentationLibrary(), metric, now)
+			})
+		}
+	}
+	return md, nil
+}
+
+// processMetric converts the metric if it is a delta sum or histogram. It
+// returns false if all the data points of the metric were dropped.
+func (p *deltaToCumulativeProcessor) processMetric(resource pdata.Resource, il pdata.InstrumentationLibrary, metric pdata.Metric, now time.Time) bool {
+	if p.metrics != nil && !p.metrics.Matches(metric.Name()) {
+		return true
+	}
+	switch metric.DataType() {
+	case pdata.MetricDataTypeSum:
+		sum := metric.Sum()
+		if sum.AggregationTemporality() != pdata.MetricAggregationTemporalityDelta {
+			return true
+		}
+		key := metricKey(resource, il, metric)
+		dps := sum.DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pdata.NumberDataPoint) bool {
+			return !p.accumulateNumber(streamKey(key, dp.Attributes()), dp, now)
+		})
+		sum.SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		return n == 0 || dps.Len() > 0
+	case pdata.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		if histogram.AggregationTemporality() != pdata.MetricAggregationTemporalityDelta {
+			return true
+		}
+		key := metricKey(resource, il, metric)
+		dps := histogram.DataPoints()
+		n := dps.Len()
+		dps.RemoveIf(func(dp pdata.HistogramDataPoint) bool {
+			return !p.accumulateHistogram(streamKey(key, dp.Attributes()), dp, now)
+		})
+		histogram.SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		return n == 0 || dps.Len() > 0
+	}
+	return true
+}
+
+// accumulateNumber adds the value of the data point to its stream and sets
+// the cumulative value in the data point. It returns false if the data point
+// must be dropped.
+func (p *deltaToCumulativeProcessor) accumulateNumber(key string, dp pdata.NumberDataPoint, now time.Time) bool {
+	s := p.lookup(key, now)
+	if s != nil && s.valueType != dp.Type() {
+		p.logger.Debug("Value type of the data points changed, restarting the stream")
+		s = nil
+	}
+	if s == nil {
+		if s = p.startStream(key, dp.StartTimestamp(), dp.Timestamp(), now); s == nil {
+			return false
+		}
+		s.valueType = dp.Type()
+	} else if !p.advance(s, dp.Timestamp(), now) {
+		return false
+	}
+
+	switch dp.Type() {
+	case pdata.MetricValueTypeInt:
+		s.intVal += dp.IntVal()
+		dp.SetIntVal(s.intVal)
+	case pdata.MetricValueTypeDouble:
+		s.doubleVal += dp.DoubleVal()
+		dp.SetDoubleVal(s.doubleVal)
+	}
+	dp.SetStartTimestamp(s.start)
+	return true
+}
+
+// accumulateHistogram adds the counts of the data point to its stream and
+// sets the cumulative counts in the data point. It returns false if the data
+// point must be dropped.
+func (p *deltaToCumulativeProcessor) accumulateHistogram(key string, dp pdata.HistogramDataPoint, now time.Time) bool {
+	s := p.lookup(key, now)
+	if s != nil && !sameLayout(s, dp) {
+		p.logger.Debug("Buckets of the data points changed, restarting the stream")
+		s = nil
+	}
+	if s == nil {
+		if s = p.startStream(key, dp.StartTimestamp(), dp.Timestamp(), now); s == nil {
+			return false
+		}
+		s.bounds = append([]float64(nil), dp.ExplicitBounds()...)
+		s.buckets = make([]uint64, len(dp.BucketCounts()))
+	} else if !p.advance(s, dp.Timestamp(), now) {
+		return false
+	}
+
+	s.count += dp.Count()
+	s.sum += dp.Sum()
+	for i, c := range dp.BucketCounts() {
+		s.buckets[i] += c
+	}
+	dp.SetCount(s.count)
+	dp.SetSum(s.sum)
+	dp.SetBucketCounts(append([]uint64(nil), s.buckets...))
+	dp.SetStartTimestamp(s.start)
+	return true
+}
+
+func sameLayout(s *stream, dp pdata.HistogramDataPoint) bool {
+	bounds := dp.ExplicitBounds()
+	if len(bounds) != len(s.bounds) || len(dp.BucketCounts()) != len(s.buckets) {
+		return false
+	}
+	for i, b := range bounds {
+		if b != s.bounds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the stream with the key, or nil if there is none or it
+// expired.
+func (p *deltaToCumulativeProcessor) lookup(key string, now time.Time) *stream {
+	s, ok := p.streams[key]
+	if !ok {
+		return nil
+	}
+	if now.Sub(s.lastSeen) > p.maxStale {
+		delete(p.streams, key)
+		return nil
+	}
+	return s
+}
+
+// startStream starts a new cumulative stream at the start of the data point,
+// or returns nil if the data point must be dropped because of the limit of
+// streams.
+func (p *deltaToCumulativeProcessor) startStream(key string, start, ts pdata.Timestamp, now time.Time) *stream {
+	if _, ok := p.streams[key]; !ok && p.maxStreams > 0 && len(p.streams) >= p.maxStreams {
+		p.logger.Debug("Dropping data point of a new stream, max_streams reached", zap.Int("max_streams", p.maxStreams))
+		return nil
+	}
+	if start == 0 || start > ts {
+		start = ts
+	}
+	s := &stream{start: start, last: ts, lastSeen: now}
+	p.streams[key] = s
+	return s
+}
+
+// advance moves the stream to the timestamp of the next data point. It
+// returns false if the data point is not newer than the last one of the
+// stream, as accumulating it again would count it twice.
+func (p *deltaToCumulativeProcessor) advance(s *stream, ts pdata.Timestamp, now time.Time) bool {
+	if ts <= s.last {
+		p.logger.Debug("Dropping data point not newer than the last one of its stream",
+			zap.Time("timestamp", ts.AsTime()), zap.Time("last", s.last.AsTime()))
+		return false
+	}
+	s.last = ts
+	s.lastSeen = now
+	return true
+}
+
+// sweep removes the expired streams, at most twice per max_stale.
+func (p *deltaToCumulativeProcessor) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < p.maxStale/2 {
+		return
+	}
+	p.lastSweep = now
+	for key, s := range p.streams {
+		if now.Sub(s.lastSeen) > p.maxStale {
+			delete(p.streams, key)
+		}
+	}
+}
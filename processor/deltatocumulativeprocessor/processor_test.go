@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+var t0 = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func ts(sec int) pdata.Timestamp {
+	return pdata.NewTimestampFromTime(t0.Add(time.Duration(sec) * time.Second))
+}
+
+type testProcessor struct {
+	*deltaToCumulativeProcessor
+	now time.Time
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) *testProcessor {
+	if cfg.MaxStale == 0 {
+		cfg.MaxStale = defaultMaxStale
+	}
+	p, err := newDeltaToCumulativeProcessor(cfg, zap.NewNop())
+	require.NoError(t, err)
+	tp := &testProcessor{deltaToCumulativeProcessor: p, now: t0}
+	p.now = func() time.Time { return tp.now }
+	return tp
+}
+
+// sum processes a delta sum with a data point per value, with the given
+// attribute, and returns the resulting metric.
+func (tp *testProcessor) sum(t *testing.T, name string, start, end int, attr string, values ...int64) pdata.Metric {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "api")
+	metric := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	metric.Sum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+	for _, v := range values {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(start))
+		dp.SetTimestamp(ts(end))
+		dp.SetIntVal(v)
+		dp.Attributes().InsertString("route", attr)
+	}
+	md, err := tp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	if metrics.Len() == 0 {
+		return pdata.NewMetric()
+	}
+	return metrics.At(0)
+}
+
+func TestSum(t *testing.T) {
+	tp := newTestProcessor(t, &Config{})
+
+	m := tp.sum(t, "requests", 0, 10, "/a", 5)
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(5), dp.IntVal())
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+	assert.Equal(t, ts(10), dp.Timestamp())
+
+	dp = tp.sum(t, "requests", 10, 20, "/a", 3).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(8), dp.IntVal())
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+	assert.Equal(t, ts(20), dp.Timestamp())
+
+	// Other attributes are another stream.
+	dp = tp.sum(t, "requests", 10, 20, "/b", 4).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(4), dp.IntVal())
+	assert.Equal(t, ts(10), dp.StartTimestamp())
+
+	// Gaps between delta points are accumulated.
+	dp = tp.sum(t, "requests", 30, 40, "/a", 2).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(10), dp.IntVal())
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+}
+
+func TestSumDouble(t *testing.T) {
+	tp := newTestProcessor(t, &Config{})
+
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("bytes")
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+	for i, v := range []float64{1.5, 2.25} {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(i * 10))
+		dp.SetTimestamp(ts(i*10 + 10))
+		dp.SetDoubleVal(v)
+	}
+	_, err := tp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, metric.Sum().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 3.75, metric.Sum().DataPoints().At(1).DoubleVal())
+	assert.Equal(t, ts(0), metric.Sum().DataPoints().At(1).StartTimestamp())
+}
+
+func TestOutOfOrder(t *testing.T) {
+	tp := newTestProcessor(t, &Config{})
+
+	tp.sum(t, "requests", 10, 20, "/a", 5)
+	// Duplicated and older points are dropped, and so is the metric without
+	// data points left.
+	m := tp.sum(t, "requests", 10, 20, "/a", 5)
+	assert.Equal(t, pdata.MetricDataTypeNone, m.DataType())
+	m = tp.sum(t, "requests", 0, 10, "/a", 1)
+	assert.Equal(t, pdata.MetricDataTypeNone, m.DataType())
+
+	dp := tp.sum(t, "requests", 20, 30, "/a", 1).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(6), dp.IntVal())
+}
+
+func TestStale(t *testing.T) {
+	tp := newTestProcessor(t, &Config{MaxStale: time.Minute})
+
+	tp.sum(t, "requests", 0, 10, "/a", 5)
+	tp.sum(t, "other", 0, 10, "/a", 5)
+
+	tp.now = t0.Add(50 * time.Second)
+	dp := tp.sum(t, "requests", 10, 20, "/a", 5).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(10), dp.IntVal())
+
+	// The stream restarts once expired.
+	tp.now = t0.Add(200 * time.Second)
+	dp = tp.sum(t, "requests", 190, 200, "/a", 1).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntVal())
+	assert.Equal(t, ts(190), dp.StartTimestamp())
+
+	// The expired streams are swept.
+	assert.Len(t, tp.streams, 1)
+}
+
+func TestMaxStreams(t *testing.T) {
+	tp := newTestProcessor(t, &Config{MaxStreams: 1})
+
+	tp.sum(t, "requests", 0, 10, "/a", 5)
+	m := tp.sum(t, "requests", 0, 10, "/b", 5)
+	assert.Equal(t, pdata.MetricDataTypeNone, m.DataType())
+	dp := tp.sum(t, "requests", 10, 20, "/a", 5).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(10), dp.IntVal())
+}
+
+func TestUnmatchedMetrics(t *testing.T) {
+	tp := newTestProcessor(t, &Config{Metrics: []string{"requests"}})
+
+	m := tp.sum(t, "errors", 0, 10, "/a", 5)
+	assert.Equal(t, pdata.MetricAggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	m = tp.sum(t, "errors", 10, 20, "/a", 5)
+	assert.Equal(t, int64(5), m.Sum().DataPoints().At(0).IntVal())
+
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	metric.Sum().DataPoints().AppendEmpty().SetIntVal(7)
+	_, err := tp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), metric.Sum().DataPoints().At(0).IntVal())
+	assert.Empty(t, tp.streams)
+}
+
+func TestHistogram(t *testing.T) {
+	tp := newTestProcessor(t, &Config{})
+
+	histogram := func(start, end int, bounds []float64, counts []uint64, sum float64) pdata.HistogramDataPoint {
+		md := pdata.NewMetrics()
+		metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("latency")
+		metric.SetDataType(pdata.MetricDataTypeHistogram)
+		metric.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(ts(start))
+		dp.SetTimestamp(ts(end))
+		dp.SetExplicitBounds(bounds)
+		dp.SetBucketCounts(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		_, err := tp.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+		assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, metric.Histogram().AggregationTemporality())
+		return dp
+	}
+
+	dp := histogram(0, 10, []float64{1, 10}, []uint64{1, 2, 0}, 12)
+	assert.Equal(t, []uint64{1, 2, 0}, dp.BucketCounts())
+
+	dp = histogram(10, 20, []float64{1, 10}, []uint64{0, 1, 1}, 25)
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 37.0, dp.Sum())
+	assert.Equal(t, ts(0), dp.StartTimestamp())
+
+	// A new bucket layout restarts the stream.
+	dp = histogram(20, 30, []float64{1, 5, 10}, []uint64{1, 0, 0, 0}, 0.5)
+	assert.Equal(t, []uint64{1, 0, 0, 0}, dp.BucketCounts())
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.Equal(t, ts(20), dp.StartTimestamp())
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Units lists the unit conversions, applied before the histograms are
+	// rebucketed.
+	Units []UnitConfig `mapstructure:"units"`
+
+	// Rebucket lists the bucket layouts histograms are converted to.
+	Rebucket []RebucketConfig `mapstructure:"rebucket"`
+}
+
+// UnitConfig converts the values of metrics from a unit to another.
+type UnitConfig struct {
+	// MatchType is how Metrics are matched, strict (the default) or regexp.
+	filterset.Config `mapstructure:",squash"`
+
+	// Metrics are the names of the metrics to convert. All the metrics with
+	// the From unit are converted if empty.
+	Metrics []string `mapstructure:"metrics"`
+
+	// From is the unit of the metrics to convert, e.g. ms.
+	From string `mapstructure:"from"`
+
+	// To is the unit the metrics are converted to, e.g. s.
+	To string `mapstructure:"to"`
+}
+
+// RebucketConfig merges the buckets of histograms into coarser buckets.
+type RebucketConfig struct {
+	// MatchType is how Metrics are matched, strict (the default) or regexp.
+	filterset.Config `mapstructure:",squash"`
+
+	// Metrics are the names of the histograms to rebucket.
+	Metrics []string `mapstructure:"metrics"`
+
+	// Boundaries are the explicit bounds of the buckets, which must be a
+	// subset of the bounds of the histograms.
+	Boundaries []float64 `mapstructure:"boundaries"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if len(cfg.Units) == 0 && len(cfg.Rebucket) == 0 {
+		return errors.New("at least one of units or rebucket must be specified")
+	}
+	for i, u := range cfg.Units {
+		if _, err := newUnitConversion(u.From, u.To); err != nil {
+			return fmt.Errorf("units[%d]: %w", i, err)
+		}
+		if _, err := createFilterSet(u.Metrics, u.Config); err != nil {
+			return fmt.Errorf("units[%d]: %w", i, err)
+		}
+	}
+	for i, r := range cfg.Rebucket {
+		if len(r.Metrics) == 0 {
+			return fmt.Errorf("rebucket[%d]: metrics must be specified", i)
+		}
+		if len(r.Boundaries) == 0 {
+			return fmt.Errorf("rebucket[%d]: boundaries must be specified", i)
+		}
+		for j := 1; j < len(r.Boundaries); j++ {
+			if r.Boundaries[j] <= r.Boundaries[j-1] {
+				return fmt.Errorf("rebucket[%d]: boundaries must be strictly increasing", i)
+			}
+		}
+		if _, err := createFilterSet(r.Metrics, r.Config); err != nil {
+			return fmt.Errorf("rebucket[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// createFilterSet creates the filter set of metric names, strict by default.
+func createFilterSet(metrics []string, cfg filterset.Config) (filterset.FilterSet, error) {
+	if cfg.MatchType == "" {
+		cfg.MatchType = filterset.Strict
+	}
+	return filterset.CreateFilterSet(metrics, &cfg)
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Units: []UnitConfig{
+			{From: "ms", To: "s"},
+			{Metrics: []string{"process.memory.usage"}, From: "KiBy", To: "By"},
+		},
+		Rebucket: []RebucketConfig{
+			{
+				Metrics:    []string{"http.server.duration", "rpc.server.duration"},
+				Boundaries: []float64{0.01, 0.1, 1, 10},
+			},
+			{
+				Config:     filterset.Config{MatchType: filterset.Regexp},
+				Metrics:    []string{`^db\..*`},
+				Boundaries: []float64{0.001, 0.1},
+			},
+		},
+	}, cfg.Processors[config.NewComponentID(typeStr)])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{
+			name: "empty",
+			err:  "at least one of units or rebucket must be specified",
+		},
+		{
+			name: "unknown unit",
+			cfg:  Config{Units: []UnitConfig{{From: "ms", To: "fortnight"}}},
+			err:  `units[0]: unsupported unit "fortnight"`,
+		},
+		{
+			name: "same unit",
+			cfg:  Config{Units: []UnitConfig{{From: "s", To: "s"}}},
+			err:  `units[0]: units to convert from and to must be different, got "s"`,
+		},
+		{
+			name: "different dimensions",
+			cfg:  Config{Units: []UnitConfig{{From: "ms", To: "By"}}},
+			err:  `units[0]: cannot convert "ms" to "By"`,
+		},
+		{
+			name: "invalid match type",
+			cfg:  Config{Units: []UnitConfig{{Config: filterset.Config{MatchType: "glob"}, Metrics: []string{"a"}, From: "ms", To: "s"}}},
+			err:  "units[0]: unrecognized match_type: 'glob', valid types are: [regexp strict]",
+		},
+		{
+			name: "no metrics",
+			cfg:  Config{Rebucket: []RebucketConfig{{Boundaries: []float64{1}}}},
+			err:  "rebucket[0]: metrics must be specified",
+		},
+		{
+			name: "no boundaries",
+			cfg:  Config{Rebucket: []RebucketConfig{{Metrics: []string{"a"}}}},
+			err:  "rebucket[0]: boundaries must be specified",
+		},
+		{
+			name: "unsorted boundaries",
+			cfg:  Config{Rebucket: []RebucketConfig{{Metrics: []string{"a"}, Boundaries: []float64{1, 1}}}},
+			err:  "rebucket[0]: boundaries must be strictly increasing",
+		},
+		{
+			name: "invalid regexp",
+			cfg:  Config{Rebucket: []RebucketConfig{{Config: filterset.Config{MatchType: filterset.Regexp}, Metrics: []string{"("}, Boundaries: []float64{1}}}},
+			err:  "rebucket[0]: error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, test.cfg.Validate(), test.err)
+		})
+	}
+}
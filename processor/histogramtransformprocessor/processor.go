@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+type unitRule struct {
+	// metrics is nil if all the metrics with the unit are converted.
+	metrics    filterset.FilterSet
+	conversion *unitConversion
+}
+
+type rebucketRule struct {
+	metrics    filterset.FilterSet
+	boundaries []float64
+}
+
+type histogramTransformProcessor struct {
+	logger        *zap.Logger
+	unitRules     []unitRule
+	rebucketRules []rebucketRule
+}
+
+func newHistogramTransformProcessor(cfg *Config, logger *zap.Logger) (*histogramTransformProcessor, error) {
+	p := &histogramTransformProcessor{logger: logger}
+	for _, u := range cfg.Units {
+		conversion, err := newUnitConversion(u.From, u.To)
+		if err != nil {
+			return nil, err
+		}
+		rule := unitRule{conversion: conversion}
+		if len(u.Metrics) > 0 {
+			if rule.metrics, err = createFilterSet(u.Metrics, u.Config); err != nil {
+				return nil, err
+			}
+		}
+		p.unitRules = append(p.unitRules, rule)
+	}
+	for _, r := range cfg.Rebucket {
+		fs, err := createFilterSet(r.Metrics, r.Config)
+		if err != nil {
+			return nil, err
+		}
+		p.rebucketRules = append(p.rebucketRules, rebucketRule{metrics: fs, boundaries: r.Boundaries})
+	}
+	return p, nil
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *histogramTransformProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				p.processMetric(metrics.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *histogramTransformProcessor) processMetric(metric pdata.Metric) {
+	// Units are converted first, so that boundaries are in the target unit.
+	for _, rule := range p.unitRules {
+		if metric.Unit() != rule.conversion.from || (rule.metrics != nil && !rule.metrics.Matches(metric.Name())) {
+			continue
+		}
+		convertMetric(metric, rule.conversion)
+		break
+	}
+
+	if metric.DataType() != pdata.MetricDataTypeHistogram {
+		return
+	}
+	for _, rule := range p.rebucketRules {
+		if !rule.metrics.Matches(metric.Name()) {
+			continue
+		}
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			if !rebucket(dps.At(i), rule.boundaries) {
+				p.logger.Debug("Histogram buckets can't be merged into the configured boundaries",
+					zap.String("metric", metric.Name()),
+					zap.Float64s("bounds", dps.At(i).ExplicitBounds()),
+					zap.Float64s("boundaries", rule.boundaries))
+			}
+		}
+		break
+	}
+}
+
+// convertMetric converts the values, unit and name of a metric.
+func convertMetric(metric pdata.Metric, c *unitConversion) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		convertNumberDataPoints(metric.Gauge().DataPoints(), c)
+	case pdata.MetricDataTypeSum:
+		convertNumberDataPoints(metric.Sum().DataPoints(), c)
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetSum(c.convert(dp.Sum()))
+			bounds := make([]float64, len(dp.ExplicitBounds()))
+			for j, b := range dp.ExplicitBounds() {
+				bounds[j] = c.convert(b)
+			}
+			dp.SetExplicitBounds(bounds)
+			convertExemplars(dp.Exemplars(), c)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetSum(c.convert(dp.Sum()))
+			qvs := dp.QuantileValues()
+			for j := 0; j < qvs.Len(); j++ {
+				qvs.At(j).SetValue(c.convert(qvs.At(j).Value()))
+			}
+		}
+	}
+	metric.SetUnit(c.to)
+	metric.SetName(c.rename(metric.Name()))
+}
+
+// convertNumberDataPoints converts the values of data points. Integer values
+// stay integers if the conversion multiplies them by an integer, and become
+// doubles otherwise.
+func convertNumberDataPoints(dps pdata.NumberDataPointSlice, c *unitConversion) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch {
+		case dp.Type() == pdata.MetricValueTypeInt && c.integerRatio:
+			dp.SetIntVal(dp.IntVal() * int64(c.multiplier))
+		case dp.Type() == pdata.MetricValueTypeInt:
+			dp.SetDoubleVal(c.convert(float64(dp.IntVal())))
+		default:
+			dp.SetDoubleVal(c.convert(dp.DoubleVal()))
+		}
+		convertExemplars(dp.Exemplars(), c)
+	}
+}
+
+func convertExemplars(exemplars pdata.ExemplarSlice, c *unitConversion) {
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		switch {
+		case e.Type() == pdata.MetricValueTypeInt && c.integerRatio:
+			e.SetIntVal(e.IntVal() * int64(c.multiplier))
+		case e.Type() == pdata.MetricValueTypeInt:
+			e.SetDoubleVal(c.convert(float64(e.IntVal())))
+		default:
+			e.SetDoubleVal(c.convert(e.DoubleVal()))
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func newMetrics() (pdata.Metrics, pdata.MetricSlice) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	return md, metrics
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p, err := newHistogramTransformProcessor(&Config{
+		Units: []UnitConfig{
+			{From: "ms", To: "s"},
+			{Metrics: []string{"heap_kib"}, From: "KiBy", To: "By"},
+		},
+		Rebucket: []RebucketConfig{
+			{Metrics: []string{"request_latency_s"}, Boundaries: []float64{0.01, 1}},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	md, metrics := newMetrics()
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("request_latency_ms")
+	histogram.SetUnit("ms")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetCount(10)
+	hdp.SetSum(2500)
+	hdp.SetExplicitBounds([]float64{5, 10, 100, 1000})
+	hdp.SetBucketCounts([]uint64{1, 2, 3, 3, 1})
+	hdp.Exemplars().AppendEmpty().SetDoubleVal(1500)
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("gc.pause")
+	gauge.SetUnit("ms")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetIntVal(20)
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("heap_kib")
+	sum.SetUnit("KiBy")
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().DataPoints().AppendEmpty().SetIntVal(3)
+
+	other := metrics.AppendEmpty()
+	other.SetName("cache_kib")
+	other.SetUnit("KiBy")
+	other.SetDataType(pdata.MetricDataTypeGauge)
+	other.Gauge().DataPoints().AppendEmpty().SetIntVal(3)
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("query_time")
+	summary.SetUnit("ms")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetSum(300)
+	sdp.QuantileValues().AppendEmpty().SetValue(50)
+
+	_, err = p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "request_latency_s", histogram.Name())
+	assert.Equal(t, "s", histogram.Unit())
+	assert.Equal(t, 2.5, hdp.Sum())
+	assert.Equal(t, uint64(10), hdp.Count())
+	assert.Equal(t, []float64{0.01, 1}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{3, 6, 1}, hdp.BucketCounts())
+	assert.Equal(t, 1.5, hdp.Exemplars().At(0).DoubleVal())
+
+	assert.Equal(t, "gc.pause", gauge.Name())
+	assert.Equal(t, "s", gauge.Unit())
+	assert.Equal(t, pdata.MetricValueTypeDouble, gauge.Gauge().DataPoints().At(0).Type())
+	assert.Equal(t, 0.02, gauge.Gauge().DataPoints().At(0).DoubleVal())
+
+	assert.Equal(t, "heap_bytes", sum.Name())
+	assert.Equal(t, "By", sum.Unit())
+	assert.Equal(t, int64(3072), sum.Sum().DataPoints().At(0).IntVal())
+
+	assert.Equal(t, "cache_kib", other.Name())
+	assert.Equal(t, "KiBy", other.Unit())
+	assert.Equal(t, int64(3), other.Gauge().DataPoints().At(0).IntVal())
+
+	assert.Equal(t, "s", summary.Unit())
+	assert.Equal(t, 0.3, sdp.Sum())
+	assert.Equal(t, 0.05, sdp.QuantileValues().At(0).Value())
+}
+
+func TestProcessMetricsUnmergeable(t *testing.T) {
+	p, err := newHistogramTransformProcessor(&Config{
+		Rebucket: []RebucketConfig{
+			{Metrics: []string{"latency"}, Boundaries: []float64{0.25}},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	md, metrics := newMetrics()
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	dp := histogram.Histogram().DataPoints().AppendEmpty()
+	dp.SetExplicitBounds([]float64{0.1, 0.5})
+	dp.SetBucketCounts([]uint64{1, 2, 3})
+
+	_, err = p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.1, 0.5}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 2, 3}, dp.BucketCounts())
+}
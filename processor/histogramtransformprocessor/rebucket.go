@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// boundsEpsilon is the relative tolerance when comparing bounds, so that bounds
+// computed by unit conversions match the configured ones.
+const boundsEpsilon = 1e-9
+
+// rebucket merges the buckets of a data point into the coarser buckets with
+// the boundaries. It returns false, leaving the data point unchanged, if one of
+// the boundaries isn't a bound of the data point, as its buckets can't be
+// split.
+func rebucket(dp pdata.HistogramDataPoint, boundaries []float64) bool {
+	bounds := dp.ExplicitBounds()
+	counts := dp.BucketCounts()
+	if len(counts) != len(bounds)+1 {
+		return false
+	}
+
+	// last[j] is the index of the last bucket of the data point merged into
+	// the new bucket j.
+	last := make([]int, 0, len(boundaries)+1)
+	i := 0
+	for _, boundary := range boundaries {
+		for i < len(bounds) && bounds[i] < boundary && !equalBounds(bounds[i], boundary) {
+			i++
+		}
+		if i == len(bounds) || !equalBounds(bounds[i], boundary) {
+			return false
+		}
+		last = append(last, i)
+		i++
+	}
+	last = append(last, len(counts)-1)
+
+	merged := make([]uint64, len(last))
+	first := 0
+	for j, l := range last {
+		for k := first; k <= l; k++ {
+			merged[j] += counts[k]
+		}
+		first = l + 1
+	}
+
+	newBounds := make([]float64, len(boundaries))
+	copy(newBounds, boundaries)
+	dp.SetExplicitBounds(newBounds)
+	dp.SetBucketCounts(merged)
+	return true
+}
+
+func equalBounds(a, b float64) bool {
+	return math.Abs(a-b) <= boundsEpsilon*math.Max(math.Abs(a), math.Abs(b))
+}
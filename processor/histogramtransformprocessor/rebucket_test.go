@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestRebucket(t *testing.T) {
+	tests := []struct {
+		name       string
+		bounds     []float64
+		counts     []uint64
+		boundaries []float64
+		outBounds  []float64
+		outCounts  []uint64
+		ok         bool
+	}{
+		{
+			name:       "merge",
+			bounds:     []float64{1, 2, 5, 10, 20, 50},
+			counts:     []uint64{1, 2, 3, 4, 5, 6, 7},
+			boundaries: []float64{2, 10, 50},
+			outBounds:  []float64{2, 10, 50},
+			outCounts:  []uint64{3, 7, 11, 7},
+			ok:         true,
+		},
+		{
+			name:       "single boundary",
+			bounds:     []float64{1, 2, 5},
+			counts:     []uint64{1, 2, 3, 4},
+			boundaries: []float64{5},
+			outBounds:  []float64{5},
+			outCounts:  []uint64{6, 4},
+			ok:         true,
+		},
+		{
+			name:       "same layout",
+			bounds:     []float64{1, 2},
+			counts:     []uint64{1, 2, 3},
+			boundaries: []float64{1, 2},
+			outBounds:  []float64{1, 2},
+			outCounts:  []uint64{1, 2, 3},
+			ok:         true,
+		},
+		{
+			name:       "converted bounds",
+			bounds:     []float64{0.1 + 0.2, 1},
+			counts:     []uint64{1, 2, 3},
+			boundaries: []float64{0.3},
+			outBounds:  []float64{0.3},
+			outCounts:  []uint64{1, 5},
+			ok:         true,
+		},
+		{
+			name:       "split bucket",
+			bounds:     []float64{1, 5, 10},
+			counts:     []uint64{1, 2, 3, 4},
+			boundaries: []float64{2, 10},
+			outBounds:  []float64{1, 5, 10},
+			outCounts:  []uint64{1, 2, 3, 4},
+		},
+		{
+			name:       "boundary above bounds",
+			bounds:     []float64{1, 5},
+			counts:     []uint64{1, 2, 3},
+			boundaries: []float64{1, 100},
+			outBounds:  []float64{1, 5},
+			outCounts:  []uint64{1, 2, 3},
+		},
+		{
+			name:       "no buckets",
+			boundaries: []float64{1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dp := pdata.NewHistogramDataPoint()
+			dp.SetExplicitBounds(test.bounds)
+			dp.SetBucketCounts(test.counts)
+			assert.Equal(t, test.ok, rebucket(dp, test.boundaries))
+			assert.Equal(t, test.outBounds, dp.ExplicitBounds())
+			assert.Equal(t, test.outCounts, dp.BucketCounts())
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"fmt"
+	"strings"
+)
+
+type dimension int
+
+const (
+	dimensionTime dimension = iota
+	dimensionBytes
+)
+
+// unit is a UCUM unit the processor can convert.
+type unit struct {
+	dimension dimension
+	// scale is the size of the unit, in the smallest unit of its dimension.
+	scale float64
+	// suffixes are the short and long forms of the unit in metric names. An
+	// empty form isn't matched.
+	suffixes [2]string
+}
+
+var units = map[string]unit{
+	"ns":   {dimensionTime, 1, [2]string{"ns", "nanoseconds"}},
+	"us":   {dimensionTime, 1e3, [2]string{"us", "microseconds"}},
+	"ms":   {dimensionTime, 1e6, [2]string{"ms", "milliseconds"}},
+	"s":    {dimensionTime, 1e9, [2]string{"s", "seconds"}},
+	"min":  {dimensionTime, 60e9, [2]string{"min", "minutes"}},
+	"h":    {dimensionTime, 3600e9, [2]string{"h", "hours"}},
+	"d":    {dimensionTime, 86400e9, [2]string{"d", "days"}},
+	"By":   {dimensionBytes, 1, [2]string{"", "bytes"}},
+	"kBy":  {dimensionBytes, 1e3, [2]string{"kb", "kilobytes"}},
+	"MBy":  {dimensionBytes, 1e6, [2]string{"mb", "megabytes"}},
+	"GBy":  {dimensionBytes, 1e9, [2]string{"gb", "gigabytes"}},
+	"KiBy": {dimensionBytes, 1 << 10, [2]string{"kib", "kibibytes"}},
+	"MiBy": {dimensionBytes, 1 << 20, [2]string{"mib", "mebibytes"}},
+	"GiBy": {dimensionBytes, 1 << 30, [2]string{"gib", "gibibytes"}},
+}
+
+// unitConversion converts values and metric names from a unit to another.
+type unitConversion struct {
+	from, to     string
+	fromUnit     unit
+	toUnit       unit
+	multiplier   float64
+	divisor      float64
+	integerRatio bool
+}
+
+func newUnitConversion(from, to string) (*unitConversion, error) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return nil, fmt.Errorf("unsupported unit %q", from)
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return nil, fmt.Errorf("unsupported unit %q", to)
+	}
+	if from == to {
+		return nil, fmt.Errorf("units to convert from and to must be different, got %q", from)
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return nil, fmt.Errorf("cannot convert %q to %q", from, to)
+	}
+
+	c := &unitConversion{from: from, to: to, fromUnit: fromUnit, toUnit: toUnit, multiplier: 1, divisor: 1}
+	// Values are multiplied or divided by the ratio of the scales, rather
+	// than multiplied by a fraction, so that e.g. 5ms is exactly 0.005s.
+	if fromUnit.scale >= toUnit.scale {
+		c.multiplier = fromUnit.scale / toUnit.scale
+		c.integerRatio = c.multiplier == float64(int64(c.multiplier))
+	} else {
+		c.divisor = toUnit.scale / fromUnit.scale
+	}
+	return c, nil
+}
+
+func (c *unitConversion) convert(v float64) float64 {
+	return v * c.multiplier / c.divisor
+}
+
+// rename returns the name of a metric with its unit suffix, separated by _ or
+// ., replaced by the suffix of the target unit, in the same form. A _total
+// suffix following the unit is kept. The name is returned unchanged if it
+// doesn't end with the unit.
+func (c *unitConversion) rename(name string) string {
+	base, total := name, ""
+	if strings.HasSuffix(base, "_total") {
+		base, total = strings.TrimSuffix(base, "_total"), "_total"
+	}
+	for form, suffix := range c.fromUnit.suffixes {
+		if suffix == "" {
+			continue
+		}
+		for _, sep := range []string{"_", "."} {
+			if !strings.HasSuffix(base, sep+suffix) {
+				continue
+			}
+			target := c.toUnit.suffixes[form]
+			if target == "" {
+				target = c.toUnit.suffixes[1]
+			}
+			return strings.TrimSuffix(base, suffix) + target + total
+		}
+	}
+	return name
+}
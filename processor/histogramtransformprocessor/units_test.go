@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogramtransformprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitConversion(t *testing.T) {
+	tests := []struct {
+		from, to string
+		in, out  float64
+		integer  bool
+	}{
+		{from: "ms", to: "s", in: 5, out: 0.005},
+		{from: "ms", to: "s", in: 250, out: 0.25},
+		{from: "s", to: "ms", in: 1.5, out: 1500, integer: true},
+		{from: "us", to: "ms", in: 2500, out: 2.5},
+		{from: "h", to: "min", in: 2, out: 120, integer: true},
+		{from: "KiBy", to: "By", in: 2, out: 2048, integer: true},
+		{from: "By", to: "MiBy", in: 3 << 20, out: 3},
+		{from: "kBy", to: "KiBy", in: 1024, out: 1000},
+	}
+	for _, test := range tests {
+		t.Run(test.from+"->"+test.to, func(t *testing.T) {
+			c, err := newUnitConversion(test.from, test.to)
+			require.NoError(t, err)
+			assert.Equal(t, test.out, c.convert(test.in))
+			assert.Equal(t, test.integer, c.integerRatio)
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		from, to string
+		name     string
+		renamed  string
+	}{
+		{from: "ms", to: "s", name: "http.server.duration", renamed: "http.server.duration"},
+		{from: "ms", to: "s", name: "request_latency_ms", renamed: "request_latency_s"},
+		{from: "ms", to: "s", name: "request_latency_milliseconds", renamed: "request_latency_seconds"},
+		{from: "ms", to: "s", name: "gc.pause.ms", renamed: "gc.pause.s"},
+		{from: "ms", to: "s", name: "cpu_time_milliseconds_total", renamed: "cpu_time_seconds_total"},
+		{from: "ms", to: "s", name: "items", renamed: "items"},
+		{from: "KiBy", to: "By", name: "heap_kib", renamed: "heap_bytes"},
+		{from: "By", to: "MiBy", name: "heap_bytes", renamed: "heap_mebibytes"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := newUnitConversion(test.from, test.to)
+			require.NoError(t, err)
+			assert.Equal(t, test.renamed, c.rename(test.name))
+		})
+	}
+}
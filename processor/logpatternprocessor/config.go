@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the log pattern processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter the occurrences of
+	// the patterns are sent to.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// Interval is the interval the occurrences of the patterns are sent at.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// SimilarityThreshold is the minimum share of tokens a message must have
+	// in common with a pattern to match it, between 0 and 1.
+	SimilarityThreshold float64 `mapstructure:"similarity_threshold"`
+
+	// MaxDepth is the depth of the parse tree. Messages are routed by their
+	// first max_depth - 2 tokens.
+	MaxDepth int `mapstructure:"max_depth"`
+
+	// MaxChildren is the maximum number of children of a node of the parse
+	// tree. Past the limit, new tokens share a wildcard node.
+	MaxChildren int `mapstructure:"max_children"`
+
+	// MaxPatterns is the maximum number of patterns. Messages matching no
+	// pattern are left as is past the limit.
+	MaxPatterns int `mapstructure:"max_patterns"`
+
+	// PatternIDAttribute is the name of the attribute set to the identifier
+	// of the pattern of logs.
+	PatternIDAttribute string `mapstructure:"pattern_id_attribute"`
+
+	// TemplateAttribute is the name of the attribute set to the template of
+	// the pattern of logs. The template isn't set if empty.
+	TemplateAttribute string `mapstructure:"template_attribute"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MetricsExporter == "" {
+		return errors.New("metrics_exporter is required")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if cfg.SimilarityThreshold <= 0 || cfg.SimilarityThreshold > 1 {
+		return errors.New("similarity_threshold must be greater than 0 and at most 1")
+	}
+	if cfg.MaxDepth < 3 {
+		return errors.New("max_depth must be at least 3")
+	}
+	if cfg.MaxChildren < 2 {
+		return errors.New("max_children must be at least 2")
+	}
+	if cfg.MaxPatterns <= 0 {
+		return errors.New("max_patterns must be positive")
+	}
+	if cfg.PatternIDAttribute == "" {
+		return errors.New("pattern_id_attribute must be non-empty")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.MetricsExporter = "nop"
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings:   config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		MetricsExporter:     "nop/patterns",
+		Interval:            15 * time.Second,
+		SimilarityThreshold: 0.7,
+		MaxDepth:            5,
+		MaxChildren:         50,
+		MaxPatterns:         500,
+		PatternIDAttribute:  "pattern.id",
+		TemplateAttribute:   "pattern.template",
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{name: "no exporter", modify: func(cfg *Config) { cfg.MetricsExporter = "" }, err: "metrics_exporter is required"},
+		{name: "interval", modify: func(cfg *Config) { cfg.Interval = 0 }, err: "interval must be positive"},
+		{name: "threshold", modify: func(cfg *Config) { cfg.SimilarityThreshold = 1.5 }, err: "similarity_threshold must be greater than 0 and at most 1"},
+		{name: "depth", modify: func(cfg *Config) { cfg.MaxDepth = 2 }, err: "max_depth must be at least 3"},
+		{name: "children", modify: func(cfg *Config) { cfg.MaxChildren = 1 }, err: "max_children must be at least 2"},
+		{name: "patterns", modify: func(cfg *Config) { cfg.MaxPatterns = 0 }, err: "max_patterns must be positive"},
+		{name: "attribute", modify: func(cfg *Config) { cfg.PatternIDAttribute = "" }, err: "pattern_id_attribute must be non-empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = "otlp"
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// wildcard replaces the tokens that vary among the messages of a pattern.
+const wildcard = "<*>"
+
+// pattern is a cluster of messages sharing a template.
+type pattern struct {
+	id     string
+	tokens []string
+	// firstSeen is the time the pattern was created.
+	firstSeen time.Time
+}
+
+func (p *pattern) template() string {
+	return strings.Join(p.tokens, " ")
+}
+
+// similarity returns the share of the tokens equal to the template's, and the
+// number of wildcards of the template.
+func (p *pattern) similarity(tokens []string) (float64, int) {
+	equal, wildcards := 0, 0
+	for i, token := range p.tokens {
+		switch {
+		case token == wildcard:
+			wildcards++
+		case token == tokens[i]:
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(tokens)), wildcards
+}
+
+// merge replaces the tokens of the template that differ from tokens with
+// wildcards.
+func (p *pattern) merge(tokens []string) {
+	for i, token := range p.tokens {
+		if token != wildcard && token != tokens[i] {
+			p.tokens[i] = wildcard
+		}
+	}
+}
+
+// node is a node of the parse tree. The first level of the tree groups
+// messages by number of tokens, the following levels by their first tokens,
+// and the leaves hold the patterns.
+type node struct {
+	children map[string]*node
+	patterns []*pattern
+}
+
+func newNode() *node {
+	return &node{children: map[string]*node{}}
+}
+
+// drain clusters messages into patterns with the Drain algorithm: messages
+// are routed through a fixed depth parse tree by their length and first
+// tokens, then matched against the patterns of the leaf by token similarity.
+// See https://jiemingzhu.github.io/pub/pjhe_icws2017.pdf.
+type drain struct {
+	// depth is the number of tokens the tree routes messages by.
+	depth       int
+	maxChildren int
+	threshold   float64
+	maxPatterns int
+
+	root     *node
+	patterns int
+}
+
+func newDrain(cfg *Config) *drain {
+	return &drain{
+		depth:       cfg.MaxDepth - 2,
+		maxChildren: cfg.MaxChildren,
+		threshold:   cfg.SimilarityThreshold,
+		maxPatterns: cfg.MaxPatterns,
+		root:        newNode(),
+	}
+}
+
+// add returns the pattern of message, and whether it was created. It returns
+// nil for empty messages, and for messages matching no pattern once the
+// maximum number of patterns is reached.
+func (d *drain) add(message string, now time.Time) (*pattern, bool) {
+	tokens := strings.Fields(message)
+	if len(tokens) == 0 {
+		return nil, false
+	}
+	leaf := d.leaf(tokens)
+
+	var best *pattern
+	bestSimilarity, bestWildcards := -1.0, -1
+	for _, p := range leaf.patterns {
+		similarity, wildcards := p.similarity(tokens)
+		if similarity > bestSimilarity || (similarity == bestSimilarity && wildcards > bestWildcards) {
+			best, bestSimilarity, bestWildcards = p, similarity, wildcards
+		}
+	}
+	if best != nil && bestSimilarity >= d.threshold {
+		best.merge(tokens)
+		return best, false
+	}
+
+	if d.patterns >= d.maxPatterns {
+		return nil, false
+	}
+	p := &pattern{id: patternID(tokens), tokens: tokens, firstSeen: now}
+	leaf.patterns = append(leaf.patterns, p)
+	d.patterns++
+	return p, true
+}
+
+// leaf returns the leaf of tokens, creating the missing nodes.
+func (d *drain) leaf(tokens []string) *node {
+	length := fmt.Sprint(len(tokens))
+	n, ok := d.root.children[length]
+	if !ok {
+		n = newNode()
+		d.root.children[length] = n
+	}
+	for i := 0; i < d.depth && i < len(tokens); i++ {
+		key := tokens[i]
+		if hasDigit(key) {
+			// Tokens with digits are likely variables.
+			key = wildcard
+		}
+		child, ok := n.children[key]
+		if !ok {
+			if len(n.children) >= d.maxChildren {
+				// Past the limit, the tokens share the wildcard node.
+				key = wildcard
+				child, ok = n.children[key]
+			}
+			if !ok {
+				child = newNode()
+				n.children[key] = child
+			}
+		}
+		n = child
+	}
+	return n
+}
+
+// patternID returns an identifier derived from the tokens of the first message
+// of a pattern, so that the same messages get the same identifiers across
+// restarts and collectors.
+func patternID(tokens []string) string {
+	h := fnv.New64a()
+	for _, token := range tokens {
+		_, _ = h.Write([]byte(token))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func hasDigit(s string) bool {
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
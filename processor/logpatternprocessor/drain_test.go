@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDrain() *drain {
+	cfg := createDefaultConfig().(*Config)
+	return newDrain(cfg)
+}
+
+func TestDrainClusters(t *testing.T) {
+	d := newTestDrain()
+	now := time.Now()
+
+	p1, created := d.add("Connection from 10.0.0.1 closed by user alice", now)
+	require.NotNil(t, p1)
+	assert.True(t, created)
+	assert.Equal(t, "Connection from 10.0.0.1 closed by user alice", p1.template())
+
+	p2, created := d.add("Connection from 10.0.0.2 closed by user bob", now)
+	assert.Same(t, p1, p2)
+	assert.False(t, created)
+	assert.Equal(t, "Connection from <*> closed by user <*>", p1.template())
+
+	// Messages of another length or prefix are other patterns.
+	p3, created := d.add("Connection from 10.0.0.3 closed", now)
+	assert.True(t, created)
+	assert.NotSame(t, p1, p3)
+	p4, created := d.add("Disk /dev/sda1 is 95% full, 2 GiB left", now)
+	assert.True(t, created)
+	assert.NotSame(t, p1, p4)
+
+	// The identifier doesn't change as the template generalizes.
+	assert.Equal(t, patternID([]string{"Connection", "from", "10.0.0.1", "closed", "by", "user", "alice"}), p1.id)
+	assert.Equal(t, 3, d.patterns)
+}
+
+func TestDrainSimilarityThreshold(t *testing.T) {
+	d := newTestDrain()
+	now := time.Now()
+
+	p1, _ := d.add("login failed for user alice from web", now)
+	// 2 of 7 tokens in common is below the threshold.
+	p2, created := d.add("login failed after the token had expired", now)
+	assert.True(t, created)
+	assert.NotSame(t, p1, p2)
+	// 6 of 7 tokens in common matches the closest pattern.
+	p3, created := d.add("login failed for user carol from web", now)
+	assert.False(t, created)
+	assert.Same(t, p1, p3)
+	assert.Equal(t, "login failed for user <*> from web", p1.template())
+}
+
+func TestDrainNumericPrefix(t *testing.T) {
+	d := newTestDrain()
+	now := time.Now()
+
+	// Tokens with digits are routed through the wildcard node, so messages
+	// starting with variables still cluster.
+	p1, _ := d.add("200 GET /index.html", now)
+	p2, created := d.add("404 GET /index.html", now)
+	assert.False(t, created)
+	assert.Same(t, p1, p2)
+	assert.Equal(t, "<*> GET /index.html", p1.template())
+}
+
+func TestDrainMaxChildren(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxChildren = 2
+	d := newDrain(cfg)
+	now := time.Now()
+
+	d.add("alpha started worker", now)
+	d.add("beta started worker", now)
+	// Past the limit, the first tokens share the wildcard node.
+	p1, _ := d.add("gamma started worker", now)
+	p2, created := d.add("delta started worker", now)
+	assert.False(t, created)
+	assert.Same(t, p1, p2)
+	assert.Equal(t, "<*> started worker", p1.template())
+	assert.Len(t, d.root.children["3"].children, 3)
+}
+
+func TestDrainMaxPatterns(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxPatterns = 1
+	d := newDrain(cfg)
+	now := time.Now()
+
+	p1, _ := d.add("cache miss for key 1", now)
+	require.NotNil(t, p1)
+	p2, created := d.add("queue is full", now)
+	assert.Nil(t, p2)
+	assert.False(t, created)
+	// Messages matching existing patterns are still clustered.
+	p3, _ := d.add("cache miss for key 2", now)
+	assert.Same(t, p1, p3)
+
+	p4, _ := d.add("   ", now)
+	assert.Nil(t, p4)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "logpattern"
+
+	defaultInterval            = time.Minute
+	defaultSimilarityThreshold = 0.5
+	defaultMaxDepth            = 4
+	defaultMaxChildren         = 100
+	defaultMaxPatterns         = 1000
+	defaultPatternIDAttribute  = "log.pattern.id"
+)
+
+// NewFactory returns a new factory for the log pattern processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+// Note: This isn't a valid configuration because the processor needs a metrics exporter.
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings:   config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Interval:            defaultInterval,
+		SimilarityThreshold: defaultSimilarityThreshold,
+		MaxDepth:            defaultMaxDepth,
+		MaxChildren:         defaultMaxChildren,
+		MaxPatterns:         defaultMaxPatterns,
+		PatternIDAttribute:  defaultPatternIDAttribute,
+	}
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Logs) (component.LogsProcessor, error) {
+	proc := newLogPatternProcessor(cfg.(*Config), set.Logger)
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		proc.processLogs,
+		processorhelper.WithStart(proc.start),
+		processorhelper.WithShutdown(proc.shutdown),
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
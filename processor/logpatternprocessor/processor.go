@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.uber.org/zap"
+)
+
+const (
+	metricOccurrences = "log.pattern.occurrences"
+	metricNewPatterns = "log.pattern.new"
+
+	attributePatternID = "log.pattern.id"
+	attributeTemplate  = "log.pattern.template"
+)
+
+// occurrences counts the logs of a service matching a pattern.
+type occurrences struct {
+	pattern *pattern
+	start   pdata.Timestamp
+	count   int64
+}
+
+// serviceOccurrences holds the occurrences of the patterns of a service by
+// pattern identifier.
+type serviceOccurrences struct {
+	start    pdata.Timestamp
+	patterns map[string]*occurrences
+}
+
+type logPatternProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	metricsExporter consumer.Metrics
+	done            chan struct{}
+	wg              sync.WaitGroup
+
+	mu    sync.Mutex
+	drain *drain
+	// services holds the occurrences by service name.
+	services    map[string]*serviceOccurrences
+	limitLogged bool
+}
+
+func newLogPatternProcessor(cfg *Config, logger *zap.Logger) *logPatternProcessor {
+	return &logPatternProcessor{
+		cfg:      cfg,
+		logger:   logger,
+		done:     make(chan struct{}),
+		drain:    newDrain(cfg),
+		services: map[string]*serviceOccurrences{},
+	}
+}
+
+// start looks up the metrics exporter the occurrences are sent to among the
+// exporters of the metrics pipelines, and starts sending them.
+func (p *logPatternProcessor) start(_ context.Context, host component.Host) error {
+	var available []string
+	for id, exp := range host.GetExporters()[config.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", id.String())
+		}
+		if id.String() == p.cfg.MetricsExporter {
+			p.metricsExporter = metricsExp
+			break
+		}
+		available = append(available, id.String())
+	}
+	if p.metricsExporter == nil {
+		sort.Strings(available)
+		return fmt.Errorf("failed to find metrics exporter %q; please configure metrics_exporter from one of: %v",
+			p.cfg.MetricsExporter, available)
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.export(context.Background())
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *logPatternProcessor) shutdown(context.Context) error {
+	if p.metricsExporter != nil {
+		close(p.done)
+		p.wg.Wait()
+	}
+	return nil
+}
+
+func (p *logPatternProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var service string
+		if v, ok := rl.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+			service = v.AsString()
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				p.processLog(service, logs.At(k), now)
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (p *logPatternProcessor) processLog(service string, lr pdata.LogRecord, now time.Time) {
+	if lr.Body().Type() != pdata.AttributeValueTypeString {
+		return
+	}
+	pt, created := p.drain.add(lr.Body().StringVal(), now)
+	if pt == nil {
+		if !p.limitLogged && p.drain.patterns >= p.cfg.MaxPatterns {
+			p.logger.Warn("Maximum number of patterns reached, ignoring new patterns",
+				zap.Int("max_patterns", p.cfg.MaxPatterns))
+			p.limitLogged = true
+		}
+		return
+	}
+	if created {
+		p.logger.Debug("New log pattern", zap.String("id", pt.id), zap.String("template", pt.template()))
+	}
+
+	lr.Attributes().UpsertString(p.cfg.PatternIDAttribute, pt.id)
+	if p.cfg.TemplateAttribute != "" {
+		lr.Attributes().UpsertString(p.cfg.TemplateAttribute, pt.template())
+	}
+
+	ts := pdata.NewTimestampFromTime(now)
+	so, ok := p.services[service]
+	if !ok {
+		so = &serviceOccurrences{start: ts, patterns: map[string]*occurrences{}}
+		p.services[service] = so
+	}
+	o, ok := so.patterns[pt.id]
+	if !ok {
+		o = &occurrences{pattern: pt, start: ts}
+		so.patterns[pt.id] = o
+	}
+	o.count++
+}
+
+// export sends the occurrences of the patterns to the metrics exporter.
+func (p *logPatternProcessor) export(ctx context.Context) {
+	md := p.buildMetrics(pdata.NewTimestampFromTime(time.Now()))
+	if md.DataPointCount() == 0 {
+		return
+	}
+	if err := p.metricsExporter.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Error("Failed to export log pattern metrics", zap.Error(err))
+	}
+}
+
+// buildMetrics returns the occurrences of the patterns of each service, and
+// the number of patterns first seen in its logs, as cumulative sums.
+func (p *logPatternProcessor) buildMetrics(now pdata.Timestamp) pdata.Metrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	md := pdata.NewMetrics()
+	services := make([]string, 0, len(p.services))
+	for service := range p.services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		so := p.services[service]
+		rm := md.ResourceMetrics().AppendEmpty()
+		if service != "" {
+			rm.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+		}
+		ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+		ilm.InstrumentationLibrary().SetName(typeStr)
+
+		occurrencesMetric := newSum(ilm.Metrics().AppendEmpty(), metricOccurrences,
+			"Number of logs matching the pattern.", "{logs}")
+		ids := make([]string, 0, len(so.patterns))
+		for id := range so.patterns {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			o := so.patterns[id]
+			dp := occurrencesMetric.DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(o.start)
+			dp.SetTimestamp(now)
+			dp.SetIntVal(o.count)
+			dp.Attributes().InsertString(attributePatternID, id)
+			dp.Attributes().InsertString(attributeTemplate, o.pattern.template())
+		}
+
+		newMetric := newSum(ilm.Metrics().AppendEmpty(), metricNewPatterns,
+			"Number of patterns first seen in the logs of the service.", "{patterns}")
+		dp := newMetric.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(so.start)
+		dp.SetTimestamp(now)
+		dp.SetIntVal(int64(len(so.patterns)))
+	}
+	return md
+}
+
+func newSum(metric pdata.Metric, name, description, unit string) pdata.Sum {
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit(unit)
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	metric.Sum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	return metric.Sum()
+}
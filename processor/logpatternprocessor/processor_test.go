@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logpatternprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type metricsExporter struct {
+	consumer.Metrics
+}
+
+func (metricsExporter) Start(context.Context, component.Host) error { return nil }
+
+func (metricsExporter) Shutdown(context.Context) error { return nil }
+
+type testHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *testHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func newTestHost(metrics consumer.Metrics) component.Host {
+	return &testHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {
+				config.NewComponentID("otlp"):                     metricsExporter{consumertest.NewNop()},
+				config.NewComponentIDWithName("otlp", "patterns"): metricsExporter{metrics},
+			},
+		},
+	}
+}
+
+func newTestProcessor(t *testing.T, metrics consumer.Metrics, modify func(*Config)) *logPatternProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp/patterns"
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+
+	p := newLogPatternProcessor(cfg, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), newTestHost(metrics)))
+	t.Cleanup(func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	})
+	return p
+}
+
+func newLogs(service string, bodies ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	if service != "" {
+		rl.Resource().Attributes().InsertString("service.name", service)
+	}
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, body := range bodies {
+		logs.AppendEmpty().Body().SetStringVal(body)
+	}
+	return ld
+}
+
+func logAttribute(t *testing.T, ld pdata.Logs, i int, key string) string {
+	v, ok := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(i).Attributes().Get(key)
+	if !ok {
+		return ""
+	}
+	return v.StringVal()
+}
+
+func TestProcessLogs(t *testing.T) {
+	p := newTestProcessor(t, consumertest.NewNop(), func(cfg *Config) {
+		cfg.TemplateAttribute = "log.pattern.template"
+	})
+
+	ld := newLogs("checkout",
+		"Connection from 10.0.0.1 closed",
+		"Connection from 10.0.0.2 closed",
+		"Payment declined for order 42")
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().AppendEmpty().Body().SetIntVal(7)
+	ld, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	connection := logAttribute(t, ld, 0, "log.pattern.id")
+	assert.NotEmpty(t, connection)
+	assert.Equal(t, connection, logAttribute(t, ld, 1, "log.pattern.id"))
+	assert.Equal(t, "Connection from 10.0.0.1 closed", logAttribute(t, ld, 0, "log.pattern.template"))
+	assert.Equal(t, "Connection from <*> closed", logAttribute(t, ld, 1, "log.pattern.template"))
+	assert.NotEqual(t, connection, logAttribute(t, ld, 2, "log.pattern.id"))
+	// Logs without a string body are left as is.
+	assert.Equal(t, "", logAttribute(t, ld, 3, "log.pattern.id"))
+}
+
+func TestExportMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestProcessor(t, sink, func(*Config) {})
+
+	// Nothing is sent before logs are processed.
+	p.export(context.Background())
+	assert.Len(t, sink.AllMetrics(), 0)
+
+	_, err := p.processLogs(context.Background(), newLogs("checkout",
+		"Connection from 10.0.0.1 closed",
+		"Connection from 10.0.0.2 closed",
+		"Payment declined for order 42"))
+	require.NoError(t, err)
+	_, err = p.processLogs(context.Background(), newLogs("",
+		"Connection from 10.0.0.3 closed"))
+	require.NoError(t, err)
+
+	p.export(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+
+	// The logs without service are counted on a resource without attributes.
+	assert.Equal(t, 0, rms.At(0).Resource().Attributes().Len())
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "log.pattern.occurrences", metrics.At(0).Name())
+	dps := metrics.At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.EqualValues(t, 1, dps.At(0).IntVal())
+	template, _ := dps.At(0).Attributes().Get("log.pattern.template")
+	assert.Equal(t, "Connection from <*> closed", template.StringVal())
+
+	service, _ := rms.At(1).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.StringVal())
+	metrics = rms.At(1).InstrumentationLibraryMetrics().At(0).Metrics()
+	occurrences := metrics.At(0).Sum()
+	assert.True(t, occurrences.IsMonotonic())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, occurrences.AggregationTemporality())
+	counts := map[string]int64{}
+	for i := 0; i < occurrences.DataPoints().Len(); i++ {
+		dp := occurrences.DataPoints().At(i)
+		template, _ := dp.Attributes().Get("log.pattern.template")
+		counts[template.StringVal()] = dp.IntVal()
+	}
+	assert.Equal(t, map[string]int64{
+		"Connection from <*> closed":    2,
+		"Payment declined for order 42": 1,
+	}, counts)
+	assert.Equal(t, "log.pattern.new", metrics.At(1).Name())
+	assert.EqualValues(t, 2, metrics.At(1).Sum().DataPoints().At(0).IntVal())
+
+	// The counts are cumulative.
+	_, err = p.processLogs(context.Background(), newLogs("", "Connection from 10.0.0.4 closed"))
+	require.NoError(t, err)
+	p.export(context.Background())
+	require.Len(t, sink.AllMetrics(), 2)
+	dp := sink.AllMetrics()[1].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.EqualValues(t, 2, dp.IntVal())
+}
+
+func TestMaxPatterns(t *testing.T) {
+	p := newTestProcessor(t, consumertest.NewNop(), func(cfg *Config) {
+		cfg.MaxPatterns = 1
+	})
+	ld, err := p.processLogs(context.Background(), newLogs("checkout", "cache miss for key 1", "queue is full"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, logAttribute(t, ld, 0, "log.pattern.id"))
+	assert.Equal(t, "", logAttribute(t, ld, 1, "log.pattern.id"))
+}
+
+func TestStartMissingExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	p := newLogPatternProcessor(cfg, zap.NewNop())
+	err := p.start(context.Background(), newTestHost(consumertest.NewNop()))
+	assert.EqualError(t, err, `failed to find metrics exporter "prometheus"; please configure metrics_exporter from one of: [otlp otlp/patterns]`)
+	assert.NoError(t, p.shutdown(context.Background()))
+}
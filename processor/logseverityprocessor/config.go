@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logseverityprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// SourceType specifies where a severity is read from.
+type SourceType string
+
+// These are the source types users can specify.
+const (
+	FromSeverityText      = SourceType("severity_text")
+	FromAttribute         = SourceType("attribute")
+	FromResourceAttribute = SourceType("resource_attribute")
+)
+
+// Format specifies how a severity value is interpreted.
+type Format string
+
+// These are the formats users can specify.
+const (
+	// Text values are level names such as "warn", "Warning" or "ERR".
+	Text = Format("text")
+	// Syslog values are syslog severity levels, from 0 (emergency) to 7
+	// (debug).
+	Syslog = Format("syslog")
+	// HTTPStatus values are HTTP status codes: 5xx are errors, 4xx are
+	// warnings and other codes are informational.
+	HTTPStatus = Format("http_status")
+	// Number values are OpenTelemetry severity numbers, from 1 to 24.
+	Number = Format("number")
+)
+
+// Config defines configuration for the log severity processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Sources are the places severities are read from, in order. The first
+	// source holding a recognized severity sets the severity of the record.
+	// Defaults to the severity text, then the level and severity attributes.
+	Sources []SourceConfig `mapstructure:"sources"`
+
+	// Mappings add text values to recognize for severity levels, keyed by
+	// level name: trace, debug, info, warn, error or fatal, optionally
+	// followed by 2, 3 or 4.
+	Mappings map[string][]string `mapstructure:"mappings"`
+
+	// Overwrite sets the severity of records that already have a severity
+	// number. These records are left as is otherwise.
+	Overwrite bool `mapstructure:"overwrite"`
+
+	// PreserveText keeps the severity text of records that have one.
+	// Otherwise, the severity text is set to the short name of the severity
+	// number, e.g. WARN.
+	PreserveText bool `mapstructure:"preserve_text"`
+}
+
+// SourceConfig defines a place a severity is read from.
+type SourceConfig struct {
+	// From is where the severity is read from: severity_text, attribute or
+	// resource_attribute.
+	From SourceType `mapstructure:"from"`
+
+	// Key is the attribute key, for attribute and resource_attribute
+	// sources.
+	Key string `mapstructure:"key"`
+
+	// Format is how the value is interpreted: text, syslog, http_status or
+	// number. Defaults to text.
+	Format Format `mapstructure:"format"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	for i, source := range cfg.Sources {
+		switch source.From {
+		case FromSeverityText:
+			if source.Key != "" {
+				return fmt.Errorf("sources[%d]: key is only supported for attribute sources", i)
+			}
+		case FromAttribute, FromResourceAttribute:
+			if source.Key == "" {
+				return fmt.Errorf("sources[%d]: key is required", i)
+			}
+		default:
+			return fmt.Errorf("sources[%d]: unknown source %q", i, source.From)
+		}
+		switch source.Format {
+		case "", Text, Syslog, HTTPStatus, Number:
+		default:
+			return fmt.Errorf("sources[%d]: unknown format %q", i, source.Format)
+		}
+	}
+	for level := range cfg.Mappings {
+		if _, ok := parseLevel(level); !ok {
+			return fmt.Errorf("mappings: unknown severity level %q", level)
+		}
+	}
+	return nil
+}
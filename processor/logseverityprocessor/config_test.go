@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logseverityprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		Sources: []SourceConfig{
+			{From: FromSeverityText},
+			{From: FromAttribute, Key: "syslog.severity", Format: Syslog},
+			{From: FromAttribute, Key: "http.status_code", Format: HTTPStatus},
+			{From: FromResourceAttribute, Key: "default.severity"},
+		},
+		Mappings: map[string][]string{
+			"error": {"failure", "E"},
+			"warn2": {"degraded"},
+		},
+		Overwrite:    true,
+		PreserveText: true,
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "unknown source",
+			modify: func(cfg *Config) { cfg.Sources[1].From = "body" },
+			err:    `sources[1]: unknown source "body"`,
+		},
+		{
+			name:   "missing key",
+			modify: func(cfg *Config) { cfg.Sources[1].Key = "" },
+			err:    "sources[1]: key is required",
+		},
+		{
+			name:   "key for severity text",
+			modify: func(cfg *Config) { cfg.Sources[0].Key = "level" },
+			err:    "sources[0]: key is only supported for attribute sources",
+		},
+		{
+			name:   "unknown format",
+			modify: func(cfg *Config) { cfg.Sources[2].Format = "log4j" },
+			err:    `sources[2]: unknown format "log4j"`,
+		},
+		{
+			name:   "unknown level",
+			modify: func(cfg *Config) { cfg.Mappings = map[string][]string{"warning": {"w"}} },
+			err:    `mappings: unknown severity level "warning"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Sources = append([]SourceConfig{}, defaultSources...)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
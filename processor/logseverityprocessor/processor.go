@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logseverityprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// defaultSources are used when no source is configured.
+var defaultSources = []SourceConfig{
+	{From: FromSeverityText},
+	{From: FromAttribute, Key: "level"},
+	{From: FromAttribute, Key: "severity"},
+}
+
+type logSeverityProcessor struct {
+	sources      []SourceConfig
+	normalizer   *normalizer
+	overwrite    bool
+	preserveText bool
+}
+
+func newLogSeverityProcessor(cfg *Config) *logSeverityProcessor {
+	sources := cfg.Sources
+	if len(sources) == 0 {
+		sources = defaultSources
+	}
+	return &logSeverityProcessor{
+		sources:      sources,
+		normalizer:   newNormalizer(cfg.Mappings),
+		overwrite:    cfg.Overwrite,
+		preserveText: cfg.PreserveText,
+	}
+}
+
+func (p *logSeverityProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				p.processLogRecord(logs.At(k), rl.Resource())
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (p *logSeverityProcessor) processLogRecord(lr pdata.LogRecord, resource pdata.Resource) {
+	if lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED && !p.overwrite {
+		return
+	}
+	severity, ok := p.severity(lr, resource)
+	if !ok {
+		return
+	}
+	lr.SetSeverityNumber(severity)
+	if !p.preserveText || lr.SeverityText() == "" {
+		lr.SetSeverityText(shortNames[severity])
+	}
+}
+
+// severity returns the severity of the first source holding a recognized
+// value.
+func (p *logSeverityProcessor) severity(lr pdata.LogRecord, resource pdata.Resource) (pdata.SeverityNumber, bool) {
+	for _, source := range p.sources {
+		var value pdata.AttributeValue
+		switch source.From {
+		case FromSeverityText:
+			if lr.SeverityText() == "" {
+				continue
+			}
+			value = pdata.NewAttributeValueString(lr.SeverityText())
+		case FromAttribute:
+			v, ok := lr.Attributes().Get(source.Key)
+			if !ok {
+				continue
+			}
+			value = v
+		case FromResourceAttribute:
+			v, ok := resource.Attributes().Get(source.Key)
+			if !ok {
+				continue
+			}
+			value = v
+		}
+		if severity, ok := p.normalizer.normalize(value, source.Format); ok {
+			return severity, true
+		}
+	}
+	return pdata.SeverityNumberUNDEFINED, false
+}
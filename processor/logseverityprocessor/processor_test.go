@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logseverityprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+type testRecord struct {
+	severityText   string
+	severityNumber pdata.SeverityNumber
+	attributes     map[string]pdata.AttributeValue
+}
+
+func generateLogs(resourceAttrs map[string]pdata.AttributeValue, records ...testRecord) pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	pdata.NewAttributeMapFromMap(resourceAttrs).CopyTo(rl.Resource().Attributes())
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, r := range records {
+		lr := logs.AppendEmpty()
+		lr.SetSeverityText(r.severityText)
+		lr.SetSeverityNumber(r.severityNumber)
+		pdata.NewAttributeMapFromMap(r.attributes).CopyTo(lr.Attributes())
+	}
+	return ld
+}
+
+type severity struct {
+	text   string
+	number pdata.SeverityNumber
+}
+
+func severities(ld pdata.Logs) []severity {
+	var result []severity
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	for i := 0; i < logs.Len(); i++ {
+		result = append(result, severity{logs.At(i).SeverityText(), logs.At(i).SeverityNumber()})
+	}
+	return result
+}
+
+func TestProcessLogsDefaultConfig(t *testing.T) {
+	p := newLogSeverityProcessor(createDefaultConfig().(*Config))
+
+	ld, err := p.processLogs(context.Background(), generateLogs(nil,
+		testRecord{severityText: "warning"},
+		testRecord{severityText: "Err"},
+		testRecord{attributes: map[string]pdata.AttributeValue{"level": pdata.NewAttributeValueString("debug")}},
+		testRecord{attributes: map[string]pdata.AttributeValue{"severity": pdata.NewAttributeValueString("CRITICAL")}},
+		// The severity text wins over attributes.
+		testRecord{severityText: "info", attributes: map[string]pdata.AttributeValue{"level": pdata.NewAttributeValueString("error")}},
+		// Unknown severity texts fall back to attributes.
+		testRecord{severityText: "???", attributes: map[string]pdata.AttributeValue{"level": pdata.NewAttributeValueString("error")}},
+		// Records with a severity number are left as is.
+		testRecord{severityText: "warning", severityNumber: pdata.SeverityNumberINFO},
+		testRecord{severityText: "unknown"},
+		testRecord{},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []severity{
+		{"WARN", pdata.SeverityNumberWARN},
+		{"ERROR", pdata.SeverityNumberERROR},
+		{"DEBUG", pdata.SeverityNumberDEBUG},
+		{"FATAL", pdata.SeverityNumberFATAL},
+		{"INFO", pdata.SeverityNumberINFO},
+		{"ERROR", pdata.SeverityNumberERROR},
+		{"warning", pdata.SeverityNumberINFO},
+		{"unknown", pdata.SeverityNumberUNDEFINED},
+		{"", pdata.SeverityNumberUNDEFINED},
+	}, severities(ld))
+}
+
+func TestProcessLogsCustomConfig(t *testing.T) {
+	p := newLogSeverityProcessor(&Config{
+		Sources: []SourceConfig{
+			{From: FromAttribute, Key: "syslog.severity", Format: Syslog},
+			{From: FromAttribute, Key: "http.status_code", Format: HTTPStatus},
+			{From: FromSeverityText},
+			{From: FromResourceAttribute, Key: "default.severity", Format: Number},
+		},
+		Mappings:     map[string][]string{"warn2": {"degraded"}},
+		Overwrite:    true,
+		PreserveText: true,
+	})
+
+	ld, err := p.processLogs(context.Background(), generateLogs(
+		map[string]pdata.AttributeValue{"default.severity": pdata.NewAttributeValueInt(int64(pdata.SeverityNumberINFO3))},
+		testRecord{severityText: "notice", attributes: map[string]pdata.AttributeValue{"syslog.severity": pdata.NewAttributeValueInt(5)}},
+		testRecord{attributes: map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(502)}},
+		testRecord{severityText: "degraded", severityNumber: pdata.SeverityNumberINFO},
+		testRecord{},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []severity{
+		{"notice", pdata.SeverityNumberINFO2},
+		{"ERROR", pdata.SeverityNumberERROR},
+		{"degraded", pdata.SeverityNumberWARN2},
+		{"INFO3", pdata.SeverityNumberINFO3},
+	}, severities(ld))
+}
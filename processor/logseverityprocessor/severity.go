@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logseverityprocessor
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// levels maps the short names of severity numbers to their base number,
+// names can be followed by 2, 3 or 4 to get the following numbers.
+var levels = map[string]pdata.SeverityNumber{
+	"trace": pdata.SeverityNumberTRACE,
+	"debug": pdata.SeverityNumberDEBUG,
+	"info":  pdata.SeverityNumberINFO,
+	"warn":  pdata.SeverityNumberWARN,
+	"error": pdata.SeverityNumberERROR,
+	"fatal": pdata.SeverityNumberFATAL,
+}
+
+// shortNames are the short names of severity numbers, indexed by number.
+var shortNames = [...]string{
+	"", "TRACE", "TRACE2", "TRACE3", "TRACE4", "DEBUG", "DEBUG2", "DEBUG3", "DEBUG4",
+	"INFO", "INFO2", "INFO3", "INFO4", "WARN", "WARN2", "WARN3", "WARN4",
+	"ERROR", "ERROR2", "ERROR3", "ERROR4", "FATAL", "FATAL2", "FATAL3", "FATAL4",
+}
+
+// syslogLevels maps syslog severity levels, from emergency to debug, to
+// severity numbers.
+var syslogLevels = [...]pdata.SeverityNumber{
+	pdata.SeverityNumberFATAL4, // emergency
+	pdata.SeverityNumberFATAL3, // alert
+	pdata.SeverityNumberFATAL,  // critical
+	pdata.SeverityNumberERROR,  // error
+	pdata.SeverityNumberWARN,   // warning
+	pdata.SeverityNumberINFO2,  // notice
+	pdata.SeverityNumberINFO,   // informational
+	pdata.SeverityNumberDEBUG,  // debug
+}
+
+// defaultTextValues are the text values recognized in addition to the
+// short names, compared case insensitively.
+var defaultTextValues = map[string]pdata.SeverityNumber{
+	"trc":           pdata.SeverityNumberTRACE,
+	"finest":        pdata.SeverityNumberTRACE,
+	"verbose":       pdata.SeverityNumberTRACE,
+	"dbg":           pdata.SeverityNumberDEBUG,
+	"fine":          pdata.SeverityNumberDEBUG,
+	"finer":         pdata.SeverityNumberDEBUG,
+	"inf":           pdata.SeverityNumberINFO,
+	"information":   pdata.SeverityNumberINFO,
+	"informational": pdata.SeverityNumberINFO,
+	"notice":        pdata.SeverityNumberINFO2,
+	"wrn":           pdata.SeverityNumberWARN,
+	"warning":       pdata.SeverityNumberWARN,
+	"err":           pdata.SeverityNumberERROR,
+	"severe":        pdata.SeverityNumberERROR,
+	"crit":          pdata.SeverityNumberFATAL,
+	"critical":      pdata.SeverityNumberFATAL,
+	"ftl":           pdata.SeverityNumberFATAL,
+	"panic":         pdata.SeverityNumberFATAL,
+	"alert":         pdata.SeverityNumberFATAL3,
+	"emerg":         pdata.SeverityNumberFATAL4,
+	"emergency":     pdata.SeverityNumberFATAL4,
+}
+
+// parseLevel parses a short name such as "warn" or "ERROR2".
+func parseLevel(name string) (pdata.SeverityNumber, bool) {
+	name = strings.ToLower(name)
+	offset := pdata.SeverityNumber(0)
+	if n := len(name); n > 1 && name[n-1] >= '2' && name[n-1] <= '4' {
+		offset = pdata.SeverityNumber(name[n-1] - '1')
+		name = name[:n-1]
+	}
+	base, ok := levels[name]
+	if !ok {
+		return pdata.SeverityNumberUNDEFINED, false
+	}
+	return base + offset, true
+}
+
+// normalizer turns severity values into severity numbers.
+type normalizer struct {
+	textValues map[string]pdata.SeverityNumber
+}
+
+func newNormalizer(mappings map[string][]string) *normalizer {
+	n := &normalizer{textValues: make(map[string]pdata.SeverityNumber, len(defaultTextValues))}
+	for text, severity := range defaultTextValues {
+		n.textValues[text] = severity
+	}
+	for level, texts := range mappings {
+		severity, _ := parseLevel(level)
+		for _, text := range texts {
+			n.textValues[strings.ToLower(strings.TrimSpace(text))] = severity
+		}
+	}
+	return n
+}
+
+// normalize returns the severity number of the value in the given format,
+// or false if the value is not recognized.
+func (n *normalizer) normalize(v pdata.AttributeValue, format Format) (pdata.SeverityNumber, bool) {
+	switch format {
+	case Syslog:
+		level, ok := intValue(v)
+		if !ok || level < 0 || level >= int64(len(syslogLevels)) {
+			return pdata.SeverityNumberUNDEFINED, false
+		}
+		return syslogLevels[level], true
+	case HTTPStatus:
+		status, ok := intValue(v)
+		switch {
+		case !ok || status < 100 || status > 599:
+			return pdata.SeverityNumberUNDEFINED, false
+		case status >= 500:
+			return pdata.SeverityNumberERROR, true
+		case status >= 400:
+			return pdata.SeverityNumberWARN, true
+		default:
+			return pdata.SeverityNumberINFO, true
+		}
+	case Number:
+		number, ok := intValue(v)
+		if !ok || number < int64(pdata.SeverityNumberTRACE) || number > int64(pdata.SeverityNumberFATAL4) {
+			return pdata.SeverityNumberUNDEFINED, false
+		}
+		return pdata.SeverityNumber(number), true
+	default:
+		if v.Type() != pdata.AttributeValueTypeString {
+			return pdata.SeverityNumberUNDEFINED, false
+		}
+		return n.normalizeText(v.StringVal())
+	}
+}
+
+func (n *normalizer) normalizeText(text string) (pdata.SeverityNumber, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if severity, ok := n.textValues[text]; ok {
+		return severity, true
+	}
+	return parseLevel(text)
+}
+
+// intValue returns the value of integer attributes, and of string
+// attributes holding an integer.
+func intValue(v pdata.AttributeValue) (int64, bool) {
+	switch v.Type() {
+	case pdata.AttributeValueTypeInt:
+		return v.IntVal(), true
+	case pdata.AttributeValueTypeDouble:
+		return int64(v.DoubleVal()), v.DoubleVal() == float64(int64(v.DoubleVal()))
+	case pdata.AttributeValueTypeString:
+		i, err := strconv.ParseInt(strings.TrimSpace(v.StringVal()), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
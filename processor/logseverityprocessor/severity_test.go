@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logseverityprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity pdata.SeverityNumber
+		ok       bool
+	}{
+		{name: "trace", severity: pdata.SeverityNumberTRACE, ok: true},
+		{name: "DEBUG3", severity: pdata.SeverityNumberDEBUG3, ok: true},
+		{name: "Info", severity: pdata.SeverityNumberINFO, ok: true},
+		{name: "warn2", severity: pdata.SeverityNumberWARN2, ok: true},
+		{name: "error4", severity: pdata.SeverityNumberERROR4, ok: true},
+		{name: "FATAL", severity: pdata.SeverityNumberFATAL, ok: true},
+		{name: "fatal5"},
+		{name: "warning"},
+		{name: "2"},
+		{name: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			severity, ok := parseLevel(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.severity, severity)
+		})
+	}
+}
+
+func TestShortNames(t *testing.T) {
+	for name, base := range levels {
+		assert.Equal(t, name, lower(shortNames[base]))
+	}
+	assert.Equal(t, "FATAL4", shortNames[pdata.SeverityNumberFATAL4])
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+func TestNormalize(t *testing.T) {
+	n := newNormalizer(map[string][]string{"error": {"E", " Failure "}, "info3": {"chatty"}})
+	tests := []struct {
+		name     string
+		value    pdata.AttributeValue
+		format   Format
+		severity pdata.SeverityNumber
+		ok       bool
+	}{
+		{name: "text short name", value: pdata.NewAttributeValueString("WARN"), format: Text, severity: pdata.SeverityNumberWARN, ok: true},
+		{name: "text alias", value: pdata.NewAttributeValueString(" Warning "), format: Text, severity: pdata.SeverityNumberWARN, ok: true},
+		{name: "text default format", value: pdata.NewAttributeValueString("err"), severity: pdata.SeverityNumberERROR, ok: true},
+		{name: "text notice", value: pdata.NewAttributeValueString("notice"), severity: pdata.SeverityNumberINFO2, ok: true},
+		{name: "text custom mapping", value: pdata.NewAttributeValueString("e"), severity: pdata.SeverityNumberERROR, ok: true},
+		{name: "text custom mapping trimmed", value: pdata.NewAttributeValueString("FAILURE"), severity: pdata.SeverityNumberERROR, ok: true},
+		{name: "text custom level", value: pdata.NewAttributeValueString("chatty"), severity: pdata.SeverityNumberINFO3, ok: true},
+		{name: "text unknown", value: pdata.NewAttributeValueString("loud"), format: Text},
+		{name: "text not a string", value: pdata.NewAttributeValueInt(3), format: Text},
+		{name: "syslog int", value: pdata.NewAttributeValueInt(3), format: Syslog, severity: pdata.SeverityNumberERROR, ok: true},
+		{name: "syslog string", value: pdata.NewAttributeValueString("0"), format: Syslog, severity: pdata.SeverityNumberFATAL4, ok: true},
+		{name: "syslog debug", value: pdata.NewAttributeValueDouble(7), format: Syslog, severity: pdata.SeverityNumberDEBUG, ok: true},
+		{name: "syslog out of range", value: pdata.NewAttributeValueInt(8), format: Syslog},
+		{name: "syslog fraction", value: pdata.NewAttributeValueDouble(2.5), format: Syslog},
+		{name: "syslog name", value: pdata.NewAttributeValueString("warning"), format: Syslog},
+		{name: "http 200", value: pdata.NewAttributeValueInt(200), format: HTTPStatus, severity: pdata.SeverityNumberINFO, ok: true},
+		{name: "http 404", value: pdata.NewAttributeValueString("404"), format: HTTPStatus, severity: pdata.SeverityNumberWARN, ok: true},
+		{name: "http 503", value: pdata.NewAttributeValueInt(503), format: HTTPStatus, severity: pdata.SeverityNumberERROR, ok: true},
+		{name: "http invalid", value: pdata.NewAttributeValueInt(42), format: HTTPStatus},
+		{name: "number", value: pdata.NewAttributeValueInt(14), format: Number, severity: pdata.SeverityNumberWARN2, ok: true},
+		{name: "number out of range", value: pdata.NewAttributeValueInt(25), format: Number},
+		{name: "number zero", value: pdata.NewAttributeValueInt(0), format: Number},
+		{name: "bool", value: pdata.NewAttributeValueBool(true), format: Number},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			severity, ok := n.normalize(tt.value, tt.format)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.severity, severity)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/container"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -47,6 +48,8 @@ type DetectorConfig struct {
 	EC2Config ec2.Config `mapstructure:"ec2"`
 	// SystemConfig contains user-specified configurations for the System detector
 	SystemConfig system.Config `mapstructure:"system"`
+	// ContainerConfig contains user-specified configurations for the Container detector
+	ContainerConfig container.Config `mapstructure:"container"`
 }
 
 func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) internal.DetectorConfig {
@@ -55,6 +58,8 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.EC2Config
 	case system.TypeStr:
 		return d.SystemConfig
+	case container.TypeStr:
+		return d.ContainerConfig
 	default:
 		return nil
 	}
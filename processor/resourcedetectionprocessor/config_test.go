@@ -26,6 +26,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/container"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -76,6 +77,19 @@ func TestLoadConfig(t *testing.T) {
 		Timeout:  2 * time.Second,
 		Override: false,
 	})
+
+	p5 := cfg.Processors[config.NewComponentIDWithName(typeStr, "container")]
+	assert.Equal(t, p5, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "container")),
+		Detectors:         []string{"env", "container"},
+		DetectorConfig: DetectorConfig{
+			ContainerConfig: container.Config{
+				DockerEndpoint: "unix:///var/run/docker.sock",
+			},
+		},
+		Timeout:  2 * time.Second,
+		Override: false,
+	})
 }
 
 func TestLoadInvalidConfig(t *testing.T) {
@@ -131,6 +145,18 @@ func TestGetConfigFromType(t *testing.T) {
 				HostnameSources: []string{"os"},
 			},
 		},
+		{
+			name:         "Get Container Config",
+			detectorType: container.TypeStr,
+			inputDetectorConfig: DetectorConfig{
+				ContainerConfig: container.Config{
+					DockerEndpoint: "unix:///var/run/docker.sock",
+				},
+			},
+			expectedConfig: container.Config{
+				DockerEndpoint: "unix:///var/run/docker.sock",
+			},
+		},
 	}
 
 	for _, tt := range tests {
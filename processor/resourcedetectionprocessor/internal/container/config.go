@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// Config defines user-specified configurations unique to the container detector
+type Config struct {
+	// DockerEndpoint is the address of the Docker daemon, e.g.
+	// unix:///var/run/docker.sock. If set, the image and the name of the
+	// container are looked up with the Docker API when they can't be read from
+	// the filesystem of the container.
+	DockerEndpoint string `mapstructure:"docker_endpoint"`
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package container provides a detector that identifies the container the
+// collector runs in, from its cgroup and mounts, and sets the container.*
+// resource attributes.
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "container"
+
+	cgroupPath       = "/proc/self/cgroup"
+	mountinfoPath    = "/proc/self/mountinfo"
+	containerenvPath = "/run/.containerenv"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// inspector looks up the name and image of a container from its runtime.
+type inspector interface {
+	inspect(ctx context.Context, id string) (name, image string, err error)
+}
+
+// Detector is a container metadata detector
+type Detector struct {
+	logger    *zap.Logger
+	inspector inspector
+
+	cgroupPath       string
+	mountinfoPath    string
+	containerenvPath string
+}
+
+// NewDetector creates a new container metadata detector
+func NewDetector(p component.ProcessorCreateSettings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	d := &Detector{
+		logger:           p.Logger,
+		cgroupPath:       cgroupPath,
+		mountinfoPath:    mountinfoPath,
+		containerenvPath: containerenvPath,
+	}
+	if cfg.DockerEndpoint != "" {
+		cli, err := client.NewClientWithOpts(client.WithHost(cfg.DockerEndpoint), client.WithAPIVersionNegotiation())
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize Docker client: %w", err)
+		}
+		d.inspector = &dockerInspector{client: cli}
+	}
+	return d, nil
+}
+
+// Detect detects the container the collector runs in and returns a resource
+// with its ID, runtime, name and image when available. The resource is empty
+// if the collector doesn't run in a container.
+func (d *Detector) Detect(ctx context.Context) (resource pdata.Resource, schemaURL string, err error) {
+	res := pdata.NewResource()
+
+	info, err := d.detectContainer()
+	if err != nil {
+		return res, "", err
+	}
+	if info.id == "" {
+		d.logger.Debug("No container detected")
+		return res, "", nil
+	}
+
+	if (info.name == "" || info.image == "") && d.inspector != nil {
+		name, image, err := d.inspector.inspect(ctx, info.id)
+		if err != nil {
+			return res, "", fmt.Errorf("failed inspecting container %s: %w", info.id, err)
+		}
+		if info.name == "" {
+			info.name = name
+		}
+		if info.image == "" {
+			info.image = image
+		}
+	}
+
+	attrs := res.Attributes()
+	attrs.InsertString(conventions.AttributeContainerID, info.id)
+	if info.runtime != "" {
+		attrs.InsertString(conventions.AttributeContainerRuntime, info.runtime)
+	}
+	if info.name != "" {
+		attrs.InsertString(conventions.AttributeContainerName, info.name)
+	}
+	if info.image != "" {
+		name, tag := splitImage(info.image)
+		attrs.InsertString(conventions.AttributeContainerImageName, name)
+		if tag != "" {
+			attrs.InsertString(conventions.AttributeContainerImageTag, tag)
+		}
+	}
+	return res, conventions.SchemaURL, nil
+}
+
+// detectContainer reads the container ID and runtime from the cgroup of the
+// process, falling back to its mounts, then the name and image from the
+// Podman container environment file.
+func (d *Detector) detectContainer() (info containerInfo, err error) {
+	err = parseFile(d.cgroupPath, func(r io.Reader) (err error) {
+		info.id, info.runtime, err = parseCgroup(r)
+		return err
+	})
+	if err != nil {
+		return info, fmt.Errorf("failed reading cgroup: %w", err)
+	}
+	if info.id == "" {
+		err = parseFile(d.mountinfoPath, func(r io.Reader) (err error) {
+			info.id, info.runtime, err = parseMountinfo(r)
+			return err
+		})
+		if err != nil {
+			return info, fmt.Errorf("failed reading mountinfo: %w", err)
+		}
+	}
+	if info.id == "" {
+		return info, nil
+	}
+
+	err = parseFile(d.containerenvPath, func(r io.Reader) (err error) {
+		info.name, info.image, err = parseContainerenv(r)
+		if info.runtime == "" {
+			info.runtime = runtimePodman
+		}
+		return err
+	})
+	if err != nil {
+		return info, fmt.Errorf("failed reading container environment: %w", err)
+	}
+	return info, nil
+}
+
+type dockerInspector struct {
+	client *client.Client
+}
+
+func (i *dockerInspector) inspect(ctx context.Context, id string) (name, image string, err error) {
+	container, err := i.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", "", err
+	}
+	if container.Config != nil {
+		image = container.Config.Image
+	}
+	return strings.TrimPrefix(container.Name, "/"), image, nil
+}
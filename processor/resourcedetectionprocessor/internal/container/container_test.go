@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockInspector struct {
+	name, image string
+	err         error
+	calls       int
+}
+
+func (m *mockInspector) inspect(_ context.Context, _ string) (string, string, error) {
+	m.calls++
+	return m.name, m.image, m.err
+}
+
+func newTestDetector(cgroup, mountinfo, containerenv string) *Detector {
+	path := func(name string) string {
+		if name == "" {
+			return filepath.Join("testdata", "missing")
+		}
+		return filepath.Join("testdata", name)
+	}
+	return &Detector{
+		logger:           zap.NewNop(),
+		cgroupPath:       path(cgroup),
+		mountinfoPath:    path(mountinfo),
+		containerenvPath: path(containerenv),
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(componenttest.NewNopProcessorCreateSettings(), Config{})
+	require.NoError(t, err)
+	assert.Nil(t, d.(*Detector).inspector)
+
+	d, err = NewDetector(componenttest.NewNopProcessorCreateSettings(), Config{DockerEndpoint: "unix:///var/run/docker.sock"})
+	require.NoError(t, err)
+	assert.NotNil(t, d.(*Detector).inspector)
+}
+
+func TestDetectCgroupV1(t *testing.T) {
+	d := newTestDetector("cgroup_v1_docker", "mountinfo_docker", "")
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeContainerID:      "3c2a7e1f9b0d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f",
+		conventions.AttributeContainerRuntime: "docker",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectCgroupV2(t *testing.T) {
+	inspector := &mockInspector{name: "agent", image: "otel/opentelemetry-collector-contrib:0.36.0"}
+	d := newTestDetector("cgroup_v2_private", "mountinfo_docker", "")
+	d.inspector = inspector
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, inspector.calls)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeContainerID:        "9d1f0b8c7a6e5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c",
+		conventions.AttributeContainerRuntime:   "docker",
+		conventions.AttributeContainerName:      "agent",
+		conventions.AttributeContainerImageName: "otel/opentelemetry-collector-contrib",
+		conventions.AttributeContainerImageTag:  "0.36.0",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestDetectContainerenv(t *testing.T) {
+	inspector := &mockInspector{err: errors.New("not called")}
+	d := newTestDetector("cgroup_v2_private", "mountinfo_docker", "containerenv")
+	d.inspector = inspector
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, inspector.calls)
+	attrs := internal.AttributesToMap(res.Attributes())
+	assert.Equal(t, "otel-agent", attrs[conventions.AttributeContainerName])
+	assert.Equal(t, "ghcr.io/acme/otelcol", attrs[conventions.AttributeContainerImageName])
+	assert.Equal(t, "0.36.0", attrs[conventions.AttributeContainerImageTag])
+}
+
+func TestDetectNoContainer(t *testing.T) {
+	d := newTestDetector("cgroup_v2_private", "", "")
+	d.inspector = &mockInspector{err: errors.New("not called")}
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDetectInspectError(t *testing.T) {
+	d := newTestDetector("cgroup_v1_docker", "", "")
+	d.inspector = &mockInspector{err: errors.New("connection refused")}
+	_, _, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "failed inspecting container 3c2a7e1f9b0d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f: connection refused")
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Container runtimes, as identified from cgroup paths and mounts.
+const (
+	runtimeDocker     = "docker"
+	runtimeContainerd = "containerd"
+	runtimeCRIO       = "cri-o"
+	runtimePodman     = "podman"
+)
+
+// containerInfo is what is known about the current container.
+type containerInfo struct {
+	id      string
+	runtime string
+	name    string
+	image   string
+}
+
+var (
+	// cgroupRegexp matches the last element of cgroup paths naming a
+	// container, e.g. /docker/<id>, /system.slice/docker-<id>.scope or
+	// /kubepods.slice/.../cri-containerd-<id>.scope.
+	cgroupRegexp = regexp.MustCompile(`(?:^|/)(docker-|cri-containerd-|crio-|libpod-)?([0-9a-f]{64})(?:\.scope)?(?:/container)?$`)
+
+	// mountRegexp matches the files that Docker and Podman bind mount from
+	// the directories of containers, e.g. /etc/hostname.
+	mountRegexp = regexp.MustCompile(`/(docker/containers|overlay-containers)/([0-9a-f]{64})/`)
+)
+
+var cgroupPrefixRuntimes = map[string]string{
+	"docker-":         runtimeDocker,
+	"cri-containerd-": runtimeContainerd,
+	"crio-":           runtimeCRIO,
+	"libpod-":         runtimePodman,
+}
+
+// parseCgroup returns the container ID and runtime found in the paths of a
+// /proc/<pid>/cgroup file, with lines of the form
+// hierarchy-ID:controller-list:cgroup-path. With cgroup v2 and a private
+// cgroup namespace, the path is / and no ID is found.
+func parseCgroup(r io.Reader) (id, runtime string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := parts[2]
+		match := cgroupRegexp.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		runtime = cgroupPrefixRuntimes[match[1]]
+		if runtime == "" && strings.HasPrefix(path, "/docker/") {
+			runtime = runtimeDocker
+		}
+		return match[2], runtime, nil
+	}
+	return "", "", scanner.Err()
+}
+
+// parseMountinfo returns the container ID and runtime found in the roots of
+// the mounts of a /proc/<pid>/mountinfo file, for cgroup v2 hosts where the
+// cgroup path doesn't name the container.
+func parseMountinfo(r io.Reader) (id, runtime string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		// The fourth field is the root of the mount in its filesystem.
+		match := mountRegexp.FindStringSubmatch(fields[3])
+		if match == nil {
+			continue
+		}
+		runtime = runtimeDocker
+		if match[1] == "overlay-containers" {
+			runtime = runtimePodman
+		}
+		return match[2], runtime, nil
+	}
+	return "", "", scanner.Err()
+}
+
+// parseContainerenv returns the name and image of the container from the
+// /run/.containerenv file created by Podman, with lines of the form
+// key="value".
+func parseContainerenv(r io.Reader) (name, image string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.Trim(parts[1], `"`)
+		switch parts[0] {
+		case "name":
+			name = value
+		case "image":
+			image = value
+		}
+	}
+	return name, image, scanner.Err()
+}
+
+// splitImage splits an image reference into its name and tag. The tag of an
+// image referenced by digest is empty, and it is "latest" if not specified.
+func splitImage(image string) (name, tag string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[:i], ""
+	}
+	slash := strings.LastIndex(image, "/")
+	if i := strings.LastIndex(image, ":"); i > slash {
+		return image[:i], image[i+1:]
+	}
+	return image, "latest"
+}
+
+// parseFile parses a file with parse, ignoring it if it doesn't exist.
+func parseFile(path string, parse func(io.Reader) error) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return parse(f)
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCgroup(t *testing.T) {
+	const id = "3c2a7e1f9b0d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f"
+	tests := []struct {
+		name    string
+		cgroup  string
+		id      string
+		runtime string
+	}{
+		{
+			name:    "docker cgroupfs",
+			cgroup:  "4:pids:/docker/" + id,
+			id:      id,
+			runtime: runtimeDocker,
+		},
+		{
+			name:    "docker systemd",
+			cgroup:  "1:name=systemd:/system.slice/docker-" + id + ".scope",
+			id:      id,
+			runtime: runtimeDocker,
+		},
+		{
+			name:    "containerd",
+			cgroup:  "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-" + id + ".scope",
+			id:      id,
+			runtime: runtimeContainerd,
+		},
+		{
+			name:    "cri-o",
+			cgroup:  "0::/kubepods.slice/kubepods-pod1.slice/crio-" + id + ".scope",
+			id:      id,
+			runtime: runtimeCRIO,
+		},
+		{
+			name:    "podman",
+			cgroup:  "0::/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-" + id + ".scope/container",
+			id:      id,
+			runtime: runtimePodman,
+		},
+		{
+			name:   "kubepods cgroupfs",
+			cgroup: "11:memory:/kubepods/burstable/pod1/" + id,
+			id:     id,
+		},
+		{
+			name:   "cgroup v2 namespace",
+			cgroup: "0::/",
+		},
+		{
+			name:   "host",
+			cgroup: "12:memory:/user.slice/user-1000.slice/session-2.scope\n1:name=systemd:/init.scope",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, runtime, err := parseCgroup(strings.NewReader(test.cgroup))
+			require.NoError(t, err)
+			assert.Equal(t, test.id, id)
+			assert.Equal(t, test.runtime, runtime)
+		})
+	}
+}
+
+func TestParseMountinfo(t *testing.T) {
+	const containerID = "5e4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d"
+	id, runtime, err := parseMountinfo(strings.NewReader(
+		"1 0 0:1 / / rw - overlay overlay rw\n" +
+			"2 1 0:2 /containers/storage/overlay-containers/" + containerID + "/userdata/hostname /etc/hostname rw - tmpfs tmpfs rw\n"))
+	require.NoError(t, err)
+	assert.Equal(t, containerID, id)
+	assert.Equal(t, runtimePodman, runtime)
+
+	id, runtime, err = parseMountinfo(strings.NewReader("1 0 0:1 / / rw - overlay overlay rw\n"))
+	require.NoError(t, err)
+	assert.Empty(t, id)
+	assert.Empty(t, runtime)
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{image: "nginx", name: "nginx", tag: "latest"},
+		{image: "nginx:1.21", name: "nginx", tag: "1.21"},
+		{image: "registry:5000/acme/app", name: "registry:5000/acme/app", tag: "latest"},
+		{image: "registry:5000/acme/app:v2", name: "registry:5000/acme/app", tag: "v2"},
+		{image: "acme/app@sha256:0123", name: "acme/app"},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			name, tag := splitImage(test.image)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.tag, tag)
+		})
+	}
+}
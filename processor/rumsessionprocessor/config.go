@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rumsessionprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter session metrics are
+	// sent to.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LogsExporter is the name of the logs exporter a log per ended session
+	// is sent to.
+	LogsExporter string `mapstructure:"logs_exporter"`
+
+	// SessionAttribute is the span or resource attribute identifying the
+	// session of spans. Defaults to session.id.
+	SessionAttribute string `mapstructure:"session_attribute"`
+
+	// InactivityTimeout is the duration after the last span of a session
+	// after which the session ends. Defaults to 15m.
+	InactivityTimeout time.Duration `mapstructure:"inactivity_timeout"`
+
+	// MaxDuration is the duration after which sessions end, even if they are
+	// still active. Defaults to 4h.
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// MaxSessions is the maximum number of active sessions. Spans of new
+	// sessions are not aggregated past the limit. Defaults to 100000.
+	MaxSessions int `mapstructure:"max_sessions"`
+
+	// Dimensions are the resource attributes session metrics are aggregated
+	// by. Defaults to service.name.
+	Dimensions []string `mapstructure:"dimensions"`
+
+	// DurationBuckets are the bounds of the buckets of the session duration
+	// histogram. Defaults to 10s, 30s, 1m, 5m, 15m, 30m, 1h and 4h.
+	DurationBuckets []time.Duration `mapstructure:"duration_buckets"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.MetricsExporter == "" && cfg.LogsExporter == "" {
+		return errors.New("at least one of metrics_exporter or logs_exporter is required")
+	}
+	if cfg.SessionAttribute == "" {
+		return errors.New("session_attribute must not be empty")
+	}
+	if cfg.InactivityTimeout <= 0 {
+		return errors.New("inactivity_timeout must be positive")
+	}
+	if cfg.MaxDuration < cfg.InactivityTimeout {
+		return errors.New("max_duration must not be lower than inactivity_timeout")
+	}
+	if cfg.MaxSessions <= 0 {
+		return errors.New("max_sessions must be positive")
+	}
+	for i := 1; i < len(cfg.DurationBuckets); i++ {
+		if cfg.DurationBuckets[i] <= cfg.DurationBuckets[i-1] {
+			return errors.New("duration_buckets must be strictly increasing")
+		}
+	}
+	return nil
+}
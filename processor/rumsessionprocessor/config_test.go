@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rumsessionprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.MetricsExporter = "nop"
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		MetricsExporter:   "nop",
+		LogsExporter:      "nop/sessions",
+		SessionAttribute:  "app.session.id",
+		InactivityTimeout: 30 * time.Minute,
+		MaxDuration:       2 * time.Hour,
+		MaxSessions:       1000,
+		Dimensions:        []string{"service.name", "browser.name"},
+		DurationBuckets:   []time.Duration{time.Minute, 10 * time.Minute, time.Hour},
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "" },
+			err:    "at least one of metrics_exporter or logs_exporter is required",
+		},
+		{
+			name:   "no session attribute",
+			modify: func(cfg *Config) { cfg.SessionAttribute = "" },
+			err:    "session_attribute must not be empty",
+		},
+		{
+			name:   "no inactivity timeout",
+			modify: func(cfg *Config) { cfg.InactivityTimeout = 0 },
+			err:    "inactivity_timeout must be positive",
+		},
+		{
+			name:   "max duration too low",
+			modify: func(cfg *Config) { cfg.MaxDuration = time.Minute },
+			err:    "max_duration must not be lower than inactivity_timeout",
+		},
+		{
+			name:   "no max sessions",
+			modify: func(cfg *Config) { cfg.MaxSessions = 0 },
+			err:    "max_sessions must be positive",
+		},
+		{
+			name:   "unsorted buckets",
+			modify: func(cfg *Config) { cfg.DurationBuckets = []time.Duration{time.Minute, time.Second} },
+			err:    "duration_buckets must be strictly increasing",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = "prometheus"
+			test.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), test.err)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rumsessionprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "rumsession"
+
+	defaultSessionAttribute  = "session.id"
+	defaultInactivityTimeout = 15 * time.Minute
+	defaultMaxDuration       = 4 * time.Hour
+	defaultMaxSessions       = 100000
+)
+
+var defaultDimensions = []string{"service.name"}
+
+var defaultDurationBuckets = []time.Duration{
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	4 * time.Hour,
+}
+
+// NewFactory returns a new factory for the RUM session processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTracesProcessor))
+}
+
+// Note: This isn't a valid configuration because the processor needs a metrics or logs exporter.
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		SessionAttribute:  defaultSessionAttribute,
+		InactivityTimeout: defaultInactivityTimeout,
+		MaxDuration:       defaultMaxDuration,
+		MaxSessions:       defaultMaxSessions,
+	}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	set component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces) (component.TracesProcessor, error) {
+	proc := newRUMSessionProcessor(cfg.(*Config), set.Logger)
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		proc.processTraces,
+		processorhelper.WithStart(proc.start),
+		processorhelper.WithShutdown(proc.shutdown),
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}))
+}
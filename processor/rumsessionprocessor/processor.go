@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rumsessionprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// flushInterval is how often ended sessions are looked for and metrics are
+// exported.
+const flushInterval = 10 * time.Second
+
+type rumSessionProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+	// bounds are the bounds of the duration histogram, in seconds.
+	bounds     []float64
+	dimensions []string
+	now        func() time.Time
+	startTime  pdata.Timestamp
+
+	metricsExporter consumer.Metrics
+	logsExporter    consumer.Logs
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	// aggregates are the cumulative session metrics, by dimensions.
+	aggregates  map[string]*aggregate
+	limitLogged bool
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newRUMSessionProcessor(cfg *Config, logger *zap.Logger) *rumSessionProcessor {
+	buckets := cfg.DurationBuckets
+	if len(buckets) == 0 {
+		buckets = defaultDurationBuckets
+	}
+	bounds := make([]float64, len(buckets))
+	for i, b := range buckets {
+		bounds[i] = b.Seconds()
+	}
+	dimensions := cfg.Dimensions
+	if dimensions == nil {
+		dimensions = defaultDimensions
+	}
+	return &rumSessionProcessor{
+		cfg:        cfg,
+		dimensions: dimensions,
+		logger:     logger,
+		bounds:     bounds,
+		now:        time.Now,
+		sessions:   map[string]*session{},
+		aggregates: map[string]*aggregate{},
+	}
+}
+
+// start looks up the exporters session metrics and logs are sent to among
+// the exporters of the metrics and logs pipelines, and starts flushing ended
+// sessions.
+func (p *rumSessionProcessor) start(_ context.Context, host component.Host) error {
+	exporters := host.GetExporters()
+	if p.cfg.MetricsExporter != "" {
+		exp, err := findExporter(exporters[config.MetricsDataType], p.cfg.MetricsExporter, "metrics")
+		if err != nil {
+			return err
+		}
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", p.cfg.MetricsExporter)
+		}
+		p.metricsExporter = metricsExp
+	}
+	if p.cfg.LogsExporter != "" {
+		exp, err := findExporter(exporters[config.LogsDataType], p.cfg.LogsExporter, "logs")
+		if err != nil {
+			return err
+		}
+		logsExp, ok := exp.(component.LogsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a logs exporter", p.cfg.LogsExporter)
+		}
+		p.logsExporter = logsExp
+	}
+
+	p.startTime = pdata.NewTimestampFromTime(p.now())
+	p.stopCh = make(chan struct{})
+	p.doneCh = make(chan struct{})
+	go p.flushLoop()
+	return nil
+}
+
+func findExporter(exporters map[config.ComponentID]component.Exporter, name string, dataType string) (component.Exporter, error) {
+	var available []string
+	for id, exp := range exporters {
+		if id.String() == name {
+			return exp, nil
+		}
+		available = append(available, id.String())
+	}
+	sort.Strings(available)
+	return nil, fmt.Errorf("failed to find %s exporter %q; please configure %s_exporter from one of: %v",
+		dataType, name, dataType, available)
+}
+
+// shutdown ends all the active sessions and exports them.
+func (p *rumSessionProcessor) shutdown(ctx context.Context) error {
+	if p.stopCh == nil {
+		return nil
+	}
+	close(p.stopCh)
+	<-p.doneCh
+	metrics, logs := p.flush(p.now(), true)
+	p.export(ctx, metrics, logs)
+	return nil
+}
+
+func (p *rumSessionProcessor) flushLoop() {
+	defer close(p.doneCh)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			metrics, logs := p.flush(p.now(), false)
+			p.export(context.Background(), metrics, logs)
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+func (p *rumSessionProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceSessionID := stringAttribute(rs.Resource().Attributes(), p.cfg.SessionAttribute)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				id := stringAttribute(span.Attributes(), p.cfg.SessionAttribute)
+				if id == "" {
+					id = resourceSessionID
+				}
+				if id == "" {
+					continue
+				}
+				s := p.sessions[id]
+				if s == nil {
+					if s = p.newSession(id, rs.Resource(), now); s == nil {
+						continue
+					}
+				}
+				s.add(span, now)
+			}
+		}
+	}
+	return td, nil
+}
+
+// newSession starts tracking a session, or returns nil if the limit of
+// sessions is reached.
+func (p *rumSessionProcessor) newSession(id string, resource pdata.Resource, now time.Time) *session {
+	if len(p.sessions) >= p.cfg.MaxSessions {
+		if !p.limitLogged {
+			p.logger.Warn("Maximum number of sessions reached, spans of new sessions are not aggregated",
+				zap.Int("max_sessions", p.cfg.MaxSessions))
+			p.limitLogged = true
+		}
+		return nil
+	}
+
+	dimensions := pdata.NewAttributeMap()
+	for _, d := range p.dimensions {
+		if v, ok := resource.Attributes().Get(d); ok {
+			dimensions.Insert(d, v)
+		}
+	}
+	key := attributesKey(dimensions)
+	agg := p.aggregates[key]
+	if agg == nil {
+		agg = newAggregate(dimensions, len(p.bounds))
+		p.aggregates[key] = agg
+	}
+	agg.active++
+
+	s := newSession(id, resource, agg, now)
+	p.sessions[id] = s
+	return s
+}
+
+// flush ends the sessions inactive for inactivity_timeout or older than
+// max_duration, or all the sessions if all is true, and returns the session
+// metrics and the logs of the ended sessions.
+func (p *rumSessionProcessor) flush(now time.Time, all bool) (pdata.Metrics, pdata.Logs) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	logs := pdata.NewLogs()
+	ids := make([]string, 0, len(p.sessions))
+	for id := range p.sessions {
+		ids = append(ids, id)
+	}
+	// Sessions are ended in a stable order for their logs.
+	sort.Strings(ids)
+	for _, id := range ids {
+		s := p.sessions[id]
+		var reason string
+		switch {
+		case all:
+			reason = endReasonShutdown
+		case now.Sub(s.lastSeen) >= p.cfg.InactivityTimeout:
+			reason = endReasonInactivity
+		case now.Sub(s.firstSeen) >= p.cfg.MaxDuration:
+			reason = endReasonMaxDuration
+		default:
+			continue
+		}
+		s.aggregate.end(s, p.bounds)
+		if p.logsExporter != nil {
+			s.appendLog(logs, p.cfg.SessionAttribute, reason)
+		}
+		delete(p.sessions, id)
+	}
+	if len(p.sessions) < p.cfg.MaxSessions {
+		p.limitLogged = false
+	}
+
+	metrics := pdata.NewMetrics()
+	if p.metricsExporter != nil {
+		ts := pdata.NewTimestampFromTime(now)
+		keys := make([]string, 0, len(p.aggregates))
+		for key := range p.aggregates {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			p.aggregates[key].appendMetrics(metrics, p.bounds, p.startTime, ts)
+		}
+	}
+	return metrics, logs
+}
+
+func (p *rumSessionProcessor) export(ctx context.Context, metrics pdata.Metrics, logs pdata.Logs) {
+	if metrics.MetricCount() > 0 {
+		if err := p.metricsExporter.ConsumeMetrics(ctx, metrics); err != nil {
+			p.logger.Error("Failed to export session metrics", zap.Error(err))
+		}
+	}
+	if logs.LogRecordCount() > 0 {
+		if err := p.logsExporter.ConsumeLogs(ctx, logs); err != nil {
+			p.logger.Error("Failed to export session logs", zap.Error(err))
+		}
+	}
+}
+
+func stringAttribute(attrs pdata.AttributeMap, key string) string {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return ""
+	}
+	return v.AsString()
+}
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rumsessionprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type logsExporter struct {
+	consumer.Logs
+}
+
+func (logsExporter) Start(context.Context, component.Host) error { return nil }
+
+func (logsExporter) Shutdown(context.Context) error { return nil }
+
+type metricsExporter struct {
+	consumer.Metrics
+}
+
+func (metricsExporter) Start(context.Context, component.Host) error { return nil }
+
+func (metricsExporter) Shutdown(context.Context) error { return nil }
+
+type testHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *testHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func newTestHost(metrics consumer.Metrics, logs consumer.Logs) component.Host {
+	return &testHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {
+				config.NewComponentID("otlp"): metricsExporter{metrics},
+			},
+			config.LogsDataType: {
+				config.NewComponentID("otlp"):                     logsExporter{logs},
+				config.NewComponentIDWithName("otlp", "sessions"): logsExporter{logs},
+			},
+		},
+	}
+}
+
+var t0 = time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type testProcessor struct {
+	*rumSessionProcessor
+	now     time.Time
+	metrics *consumertest.MetricsSink
+	logs    *consumertest.LogsSink
+}
+
+func newTestProcessor(t *testing.T, modify func(*Config)) *testProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+	cfg.LogsExporter = "otlp/sessions"
+	cfg.DurationBuckets = []time.Duration{time.Minute, 10 * time.Minute}
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	tp := &testProcessor{
+		rumSessionProcessor: newRUMSessionProcessor(cfg, zap.NewNop()),
+		now:                 t0,
+		metrics:             new(consumertest.MetricsSink),
+		logs:                new(consumertest.LogsSink),
+	}
+	tp.rumSessionProcessor.now = func() time.Time { return tp.now }
+	require.NoError(t, tp.start(context.Background(), newTestHost(tp.metrics, tp.logs)))
+	t.Cleanup(func() { require.NoError(t, tp.shutdown(context.Background())) })
+	return tp
+}
+
+type testSpan struct {
+	session string
+	start   time.Duration
+	end     time.Duration
+	error   bool
+}
+
+func (tp *testProcessor) consume(t *testing.T, service string, spans ...testSpan) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", service)
+	rs.Resource().Attributes().InsertString("browser.name", "Firefox")
+	ss := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, s := range spans {
+		span := ss.AppendEmpty()
+		span.SetName("documentLoad")
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(t0.Add(s.start)))
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(t0.Add(s.end)))
+		if s.session != "" {
+			span.Attributes().InsertString("session.id", s.session)
+		}
+		if s.error {
+			span.Status().SetCode(pdata.StatusCodeError)
+		}
+	}
+	out, err := tp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, out)
+}
+
+func (tp *testProcessor) flushAt(d time.Duration) {
+	tp.now = t0.Add(d)
+	metrics, logs := tp.flush(tp.now, false)
+	tp.export(context.Background(), metrics, logs)
+}
+
+func metricsByName(md pdata.Metrics, service string) map[string]pdata.Metric {
+	metrics := map[string]pdata.Metric{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		name, _ := rms.At(i).Resource().Attributes().Get("service.name")
+		if name.StringVal() != service {
+			continue
+		}
+		ms := rms.At(i).InstrumentationLibraryMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			metrics[ms.At(j).Name()] = ms.At(j)
+		}
+	}
+	return metrics
+}
+
+func TestSessions(t *testing.T) {
+	tp := newTestProcessor(t, nil)
+
+	tp.consume(t, "web",
+		testSpan{session: "s1", start: 0, end: time.Second},
+		testSpan{session: "s2", start: 0, end: time.Second},
+		testSpan{start: 0, end: time.Hour},
+	)
+	tp.now = t0.Add(5 * time.Minute)
+	tp.consume(t, "web",
+		testSpan{session: "s1", start: 5 * time.Minute, end: 5*time.Minute + 30*time.Second, error: true},
+	)
+
+	// s2 ends after 15m of inactivity, s1 is still active.
+	tp.flushAt(16 * time.Minute)
+	require.Len(t, tp.metrics.AllMetrics(), 1)
+	metrics := metricsByName(tp.metrics.AllMetrics()[0], "web")
+	assert.Equal(t, int64(1), metrics[metricSessionCount].Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, int64(0), metrics[metricSessionErrorCount].Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, int64(1), metrics[metricSessionActive].Gauge().DataPoints().At(0).IntVal())
+	duration := metrics[metricSessionDuration].Histogram().DataPoints().At(0)
+	assert.Equal(t, []uint64{1, 0, 0}, duration.BucketCounts())
+	assert.Equal(t, 1.0, duration.Sum())
+
+	require.Equal(t, 1, tp.logs.LogRecordCount())
+	rl := tp.logs.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "web", "browser.name": "Firefox"}, rl.Resource().Attributes().AsRaw())
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, sessionLogName, lr.Name())
+	assert.Equal(t, pdata.NewTimestampFromTime(t0.Add(time.Second)), lr.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"session.id":          "s2",
+		"session.duration":    1.0,
+		"session.span_count":  int64(1),
+		"session.error_count": int64(0),
+		"session.end_reason":  endReasonInactivity,
+	}, lr.Attributes().AsRaw())
+
+	// s1 ends 15m after its last span.
+	tp.flushAt(21 * time.Minute)
+	metrics = metricsByName(tp.metrics.AllMetrics()[1], "web")
+	assert.Equal(t, int64(2), metrics[metricSessionCount].Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, int64(1), metrics[metricSessionErrorCount].Sum().DataPoints().At(0).IntVal())
+	assert.Equal(t, int64(0), metrics[metricSessionActive].Gauge().DataPoints().At(0).IntVal())
+	duration = metrics[metricSessionDuration].Histogram().DataPoints().At(0)
+	assert.Equal(t, []uint64{1, 1, 0}, duration.BucketCounts())
+	assert.Equal(t, uint64(2), duration.Count())
+	assert.Equal(t, 331.0, duration.Sum())
+
+	require.Equal(t, 2, tp.logs.LogRecordCount())
+	lr = tp.logs.AllLogs()[1].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"session.id":          "s1",
+		"session.duration":    330.0,
+		"session.span_count":  int64(2),
+		"session.error_count": int64(1),
+		"session.end_reason":  endReasonInactivity,
+	}, lr.Attributes().AsRaw())
+}
+
+func TestSessionDimensions(t *testing.T) {
+	tp := newTestProcessor(t, nil)
+
+	tp.consume(t, "web", testSpan{session: "s1", end: time.Second})
+	tp.consume(t, "mobile", testSpan{session: "s2", end: time.Second})
+	tp.flushAt(time.Minute)
+
+	md := tp.metrics.AllMetrics()[0]
+	assert.Equal(t, 2, md.ResourceMetrics().Len())
+	// Only the dimensions are kept in the resource of metrics.
+	assert.Equal(t, map[string]interface{}{"service.name": "mobile"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, int64(1), metricsByName(md, "mobile")[metricSessionActive].Gauge().DataPoints().At(0).IntVal())
+	assert.Equal(t, int64(1), metricsByName(md, "web")[metricSessionActive].Gauge().DataPoints().At(0).IntVal())
+}
+
+func TestSessionAttributeFromResource(t *testing.T) {
+	tp := newTestProcessor(t, nil)
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("session.id", "s1")
+	rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	_, err := tp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Contains(t, tp.sessions, "s1")
+}
+
+func TestMaxDuration(t *testing.T) {
+	tp := newTestProcessor(t, func(cfg *Config) {
+		cfg.InactivityTimeout = time.Minute
+		cfg.MaxDuration = 2 * time.Minute
+	})
+
+	for i := 0; i <= 4; i++ {
+		tp.now = t0.Add(time.Duration(i) * 30 * time.Second)
+		tp.consume(t, "web", testSpan{session: "s1", start: time.Duration(i) * 30 * time.Second, end: time.Duration(i)*30*time.Second + time.Second})
+	}
+	tp.flushAt(2*time.Minute + time.Second)
+
+	require.Equal(t, 1, tp.logs.LogRecordCount())
+	lr := tp.logs.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	reason, _ := lr.Attributes().Get(attributeEndReason)
+	assert.Equal(t, endReasonMaxDuration, reason.StringVal())
+	assert.Empty(t, tp.sessions)
+}
+
+func TestMaxSessions(t *testing.T) {
+	tp := newTestProcessor(t, func(cfg *Config) {
+		cfg.MaxSessions = 1
+	})
+
+	tp.consume(t, "web", testSpan{session: "s1"}, testSpan{session: "s2"})
+	assert.Len(t, tp.sessions, 1)
+	assert.Contains(t, tp.sessions, "s1")
+}
+
+func TestShutdownEndsSessions(t *testing.T) {
+	tp := newTestProcessor(t, nil)
+
+	tp.consume(t, "web", testSpan{session: "s1", end: time.Second})
+	require.NoError(t, tp.shutdown(context.Background()))
+	tp.stopCh = nil
+
+	require.Equal(t, 1, tp.logs.LogRecordCount())
+	lr := tp.logs.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	reason, _ := lr.Attributes().Get(attributeEndReason)
+	assert.Equal(t, endReasonShutdown, reason.StringVal())
+	require.Len(t, tp.metrics.AllMetrics(), 1)
+}
+
+func TestStartMissingExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	p := newRUMSessionProcessor(cfg, zap.NewNop())
+	assert.EqualError(t, p.start(context.Background(), newTestHost(consumertest.NewNop(), consumertest.NewNop())),
+		`failed to find metrics exporter "prometheus"; please configure metrics_exporter from one of: [otlp]`)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.LogsExporter = "loki"
+	p = newRUMSessionProcessor(cfg, zap.NewNop())
+	assert.EqualError(t, p.start(context.Background(), newTestHost(consumertest.NewNop(), consumertest.NewNop())),
+		`failed to find logs exporter "loki"; please configure logs_exporter from one of: [otlp otlp/sessions]`)
+}
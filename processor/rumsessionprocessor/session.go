@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rumsessionprocessor
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	metricSessionCount      = "session.count"
+	metricSessionErrorCount = "session.error.count"
+	metricSessionDuration   = "session.duration"
+	metricSessionActive     = "session.active"
+
+	attributeDuration   = "session.duration"
+	attributeSpanCount  = "session.span_count"
+	attributeErrorCount = "session.error_count"
+	attributeEndReason  = "session.end_reason"
+
+	endReasonInactivity  = "inactivity"
+	endReasonMaxDuration = "max_duration"
+	endReasonShutdown    = "shutdown"
+
+	sessionLogName = "session.end"
+)
+
+// session aggregates the spans of a session.
+type session struct {
+	id        string
+	resource  pdata.Resource
+	aggregate *aggregate
+	// start and end are the earliest start and the latest end of the spans
+	// of the session.
+	start      pdata.Timestamp
+	end        pdata.Timestamp
+	spanCount  int64
+	errorCount int64
+	firstSeen  time.Time
+	lastSeen   time.Time
+}
+
+func newSession(id string, resource pdata.Resource, agg *aggregate, now time.Time) *session {
+	s := &session{
+		id:        id,
+		resource:  pdata.NewResource(),
+		aggregate: agg,
+		firstSeen: now,
+	}
+	resource.CopyTo(s.resource)
+	return s
+}
+
+func (s *session) add(span pdata.Span, now time.Time) {
+	if s.start == 0 || span.StartTimestamp() < s.start {
+		s.start = span.StartTimestamp()
+	}
+	if span.EndTimestamp() > s.end {
+		s.end = span.EndTimestamp()
+	}
+	s.spanCount++
+	if span.Status().Code() == pdata.StatusCodeError {
+		s.errorCount++
+	}
+	s.lastSeen = now
+}
+
+func (s *session) duration() float64 {
+	if s.end < s.start {
+		return 0
+	}
+	return time.Duration(s.end - s.start).Seconds()
+}
+
+func (s *session) appendLog(logs pdata.Logs, sessionAttribute string, reason string) {
+	rl := logs.ResourceLogs().AppendEmpty()
+	s.resource.CopyTo(rl.Resource())
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetName(sessionLogName)
+	lr.SetTimestamp(s.end)
+	lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	lr.SetSeverityText("INFO")
+	lr.Body().SetStringVal("session ended")
+	attrs := lr.Attributes()
+	attrs.UpsertString(sessionAttribute, s.id)
+	attrs.UpsertDouble(attributeDuration, s.duration())
+	attrs.UpsertInt(attributeSpanCount, s.spanCount)
+	attrs.UpsertInt(attributeErrorCount, s.errorCount)
+	attrs.UpsertString(attributeEndReason, reason)
+}
+
+// aggregate holds the cumulative metrics of the sessions with the same
+// dimensions.
+type aggregate struct {
+	dimensions   pdata.AttributeMap
+	active       int64
+	count        int64
+	errorCount   int64
+	durationSum  float64
+	bucketCounts []uint64
+}
+
+func newAggregate(dimensions pdata.AttributeMap, bounds int) *aggregate {
+	return &aggregate{
+		dimensions:   dimensions,
+		bucketCounts: make([]uint64, bounds+1),
+	}
+}
+
+func (a *aggregate) end(s *session, bounds []float64) {
+	a.active--
+	a.count++
+	if s.errorCount > 0 {
+		a.errorCount++
+	}
+	d := s.duration()
+	a.durationSum += d
+	a.bucketCounts[sort.SearchFloat64s(bounds, d)]++
+}
+
+func (a *aggregate) appendMetrics(md pdata.Metrics, bounds []float64, start, ts pdata.Timestamp) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	a.dimensions.CopyTo(rm.Resource().Attributes())
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName(typeStr)
+	metrics := ilm.Metrics()
+
+	appendCounter(metrics, metricSessionCount, "Number of ended sessions", a.count, start, ts)
+	appendCounter(metrics, metricSessionErrorCount, "Number of ended sessions with at least an error span", a.errorCount, start, ts)
+
+	active := metrics.AppendEmpty()
+	active.SetName(metricSessionActive)
+	active.SetDescription("Number of active sessions")
+	active.SetUnit("1")
+	active.SetDataType(pdata.MetricDataTypeGauge)
+	dp := active.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(a.active)
+
+	duration := metrics.AppendEmpty()
+	duration.SetName(metricSessionDuration)
+	duration.SetDescription("Duration of ended sessions, from the start of their first span to the end of their last span")
+	duration.SetUnit("s")
+	duration.SetDataType(pdata.MetricDataTypeHistogram)
+	duration.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	hdp := duration.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(start)
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(uint64(a.count))
+	hdp.SetSum(a.durationSum)
+	hdp.SetExplicitBounds(bounds)
+	hdp.SetBucketCounts(append([]uint64(nil), a.bucketCounts...))
+}
+
+func appendCounter(metrics pdata.MetricSlice, name, description string, value int64, start, ts pdata.Timestamp) {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit("1")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(value)
+}
+
+// attributesKey returns a key identifying the attributes, whatever their
+// order.
+func attributesKey(attrs pdata.AttributeMap) string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		keys = append(keys, k+"="+v.AsString())
+		return true
+	})
+	sort.Strings(keys)
+	return strings.Join(keys, "\x00")
+}
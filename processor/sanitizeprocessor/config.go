@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sanitizeprocessor
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the sanitize processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Replacement replaces each run of invalid UTF-8 bytes. The invalid bytes
+	// are removed when it's empty. Defaults to U+FFFD, the Unicode
+	// replacement character.
+	Replacement string `mapstructure:"replacement"`
+
+	// StripControlCharacters removes the control characters, except tabs,
+	// line feeds and carriage returns. Defaults to true.
+	StripControlCharacters bool `mapstructure:"strip_control_characters"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if !utf8.ValidString(cfg.Replacement) {
+		return errors.New("replacement must be valid UTF-8")
+	}
+	return nil
+}
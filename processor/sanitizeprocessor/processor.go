@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sanitizeprocessor
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/sanitize"
+)
+
+type sanitizeProcessor struct {
+	scrubber sanitize.Scrubber
+}
+
+func newSanitizeProcessor(cfg *Config) *sanitizeProcessor {
+	return &sanitizeProcessor{
+		scrubber: sanitize.Scrubber{
+			Replacement:            cfg.Replacement,
+			StripControlCharacters: cfg.StripControlCharacters,
+		},
+	}
+}
+
+func (p *sanitizeProcessor) processTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	repaired := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		repaired += p.scrubber.AttributeMap(rs.Resource().Attributes())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			repaired += p.instrumentationLibrary(ils.InstrumentationLibrary())
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				repaired += p.span(spans.At(k))
+			}
+		}
+	}
+	p.record(ctx, repaired)
+	return td, nil
+}
+
+func (p *sanitizeProcessor) span(span pdata.Span) int {
+	repaired := p.string(span.Name(), span.SetName)
+	repaired += p.string(span.Status().Message(), span.Status().SetMessage)
+	repaired += p.scrubber.AttributeMap(span.Attributes())
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		repaired += p.string(event.Name(), event.SetName)
+		repaired += p.scrubber.AttributeMap(event.Attributes())
+	}
+	links := span.Links()
+	for i := 0; i < links.Len(); i++ {
+		repaired += p.scrubber.AttributeMap(links.At(i).Attributes())
+	}
+	return repaired
+}
+
+func (p *sanitizeProcessor) processMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	repaired := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		repaired += p.scrubber.AttributeMap(rm.Resource().Attributes())
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			repaired += p.instrumentationLibrary(ilm.InstrumentationLibrary())
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				repaired += p.metric(metrics.At(k))
+			}
+		}
+	}
+	p.record(ctx, repaired)
+	return md, nil
+}
+
+func (p *sanitizeProcessor) metric(metric pdata.Metric) int {
+	repaired := p.string(metric.Name(), metric.SetName)
+	repaired += p.string(metric.Description(), metric.SetDescription)
+	repaired += p.string(metric.Unit(), metric.SetUnit)
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		repaired += p.numberDataPoints(metric.Gauge().DataPoints())
+	case pdata.MetricDataTypeSum:
+		repaired += p.numberDataPoints(metric.Sum().DataPoints())
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			repaired += p.scrubber.AttributeMap(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			repaired += p.scrubber.AttributeMap(dps.At(i).Attributes())
+		}
+	}
+	return repaired
+}
+
+func (p *sanitizeProcessor) numberDataPoints(dps pdata.NumberDataPointSlice) int {
+	repaired := 0
+	for i := 0; i < dps.Len(); i++ {
+		repaired += p.scrubber.AttributeMap(dps.At(i).Attributes())
+	}
+	return repaired
+}
+
+func (p *sanitizeProcessor) processLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	repaired := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		repaired += p.scrubber.AttributeMap(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			repaired += p.instrumentationLibrary(ill.InstrumentationLibrary())
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				repaired += p.string(lr.Name(), lr.SetName)
+				repaired += p.string(lr.SeverityText(), lr.SetSeverityText)
+				repaired += p.scrubber.AttributeValue(lr.Body())
+				repaired += p.scrubber.AttributeMap(lr.Attributes())
+			}
+		}
+	}
+	p.record(ctx, repaired)
+	return ld, nil
+}
+
+func (p *sanitizeProcessor) instrumentationLibrary(il pdata.InstrumentationLibrary) int {
+	return p.string(il.Name(), il.SetName) + p.string(il.Version(), il.SetVersion)
+}
+
+// string repairs a string field, returning 1 when it was changed.
+func (p *sanitizeProcessor) string(s string, set func(string)) int {
+	repaired, changed := p.scrubber.String(s)
+	if !changed {
+		return 0
+	}
+	set(repaired)
+	return 1
+}
+
+func (p *sanitizeProcessor) record(ctx context.Context, repaired int) {
+	if repaired > 0 {
+		stats.Record(ctx, mRepairedValues.M(int64(repaired)))
+	}
+}
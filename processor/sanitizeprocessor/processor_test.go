@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sanitizeprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func newTestProcessor() *sanitizeProcessor {
+	return newSanitizeProcessor(createDefaultConfig().(*Config))
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "web\x00-1")
+	ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+	ill.InstrumentationLibrary().SetName("filelog")
+	lr := ill.Logs().AppendEmpty()
+	lr.Body().SetStringVal("user caf\xe9 logged in\x1b[0m\n")
+	lr.SetSeverityText("INFO")
+	lr.Attributes().InsertString("log.file.name", "access.log")
+	lr.Attributes().InsertString("user\xff", "caf\xc3\xa9")
+	clean := ill.Logs().AppendEmpty()
+	clean.Body().SetStringVal("clean line")
+
+	ld, err := newTestProcessor().processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	host, _ := rl.Resource().Attributes().Get("host.name")
+	assert.Equal(t, "web-1", host.StringVal())
+	assert.Equal(t, "user caf� logged in[0m\n", lr.Body().StringVal())
+	user, ok := lr.Attributes().Get("user�")
+	require.True(t, ok)
+	assert.Equal(t, "café", user.StringVal())
+	assert.Equal(t, 2, lr.Attributes().Len())
+	assert.Equal(t, "clean line", clean.Body().StringVal())
+}
+
+func TestProcessLogsMapBody(t *testing.T) {
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	body := pdata.NewAttributeValueMap()
+	body.MapVal().InsertString("message", "bad \xff byte")
+	body.CopyTo(lr.Body())
+
+	_, err := newTestProcessor().processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	message, _ := lr.Body().MapVal().Get("message")
+	assert.Equal(t, "bad � byte", message.StringVal())
+}
+
+func TestProcessTraces(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /items/\xfe")
+	span.Status().SetMessage("failed\x07")
+	span.Attributes().InsertString("http.url", "/items/\xfe")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().InsertString("exception.message", "\x00oops")
+	span.Links().AppendEmpty().Attributes().InsertString("reason", "retry\xc3")
+
+	_, err := newTestProcessor().processTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, "GET /items/�", span.Name())
+	assert.Equal(t, "failed", span.Status().Message())
+	url, _ := span.Attributes().Get("http.url")
+	assert.Equal(t, "/items/�", url.StringVal())
+	message, _ := event.Attributes().Get("exception.message")
+	assert.Equal(t, "oops", message.StringVal())
+	reason, _ := span.Links().At(0).Attributes().Get("reason")
+	assert.Equal(t, "retry�", reason.StringVal())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests\x00")
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().DataPoints().AppendEmpty().Attributes().InsertString("path", "/caf\xe9")
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("path", "\x1b/")
+
+	_, err := newTestProcessor().processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "requests", sum.Name())
+	path, _ := sum.Sum().DataPoints().At(0).Attributes().Get("path")
+	assert.Equal(t, "/caf�", path.StringVal())
+	path, _ = histogram.Histogram().DataPoints().At(0).Attributes().Get("path")
+	assert.Equal(t, "/", path.StringVal())
+}
+
+func TestProcessDropInvalidBytes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Replacement = ""
+	cfg.StripControlCharacters = false
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal("caf\xe9\x1b")
+
+	_, err := newSanitizeProcessor(cfg).processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, "caf\x1b", lr.Body().StringVal())
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the schema processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Targets are the schema URLs data is translated to, at most one per
+	// schema family. Data of other families is left as is.
+	Targets []string `mapstructure:"targets"`
+
+	// SchemaFiles are paths to local schema files. Schema files of the
+	// targets that are not given here are fetched from their schema URL
+	// when the processor starts.
+	SchemaFiles []string `mapstructure:"schema_files"`
+
+	// DefaultSchemaURL is the schema URL assumed for data that doesn't have
+	// one. Such data is left as is if not set.
+	DefaultSchemaURL string `mapstructure:"default_schema_url"`
+
+	// FetchTimeout is the timeout to fetch a schema file.
+	FetchTimeout time.Duration `mapstructure:"fetch_timeout"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Targets) == 0 {
+		return errors.New("at least one target schema URL is required")
+	}
+	families := map[string]bool{}
+	for _, target := range cfg.Targets {
+		familyURL, _, err := splitSchemaURL(target)
+		if err != nil {
+			return err
+		}
+		if families[familyURL] {
+			return fmt.Errorf("more than one target for schema family %q", familyURL)
+		}
+		families[familyURL] = true
+	}
+	if cfg.DefaultSchemaURL != "" {
+		familyURL, _, err := splitSchemaURL(cfg.DefaultSchemaURL)
+		if err != nil {
+			return err
+		}
+		if !families[familyURL] {
+			return fmt.Errorf("default_schema_url %q doesn't belong to any target schema family", cfg.DefaultSchemaURL)
+		}
+	}
+	if cfg.FetchTimeout <= 0 {
+		return errors.New("fetch_timeout must be positive")
+	}
+	return nil
+}
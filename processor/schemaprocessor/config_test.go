@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Targets:           []string{"https://example.com/schemas/1.2.0"},
+		FetchTimeout:      defaultFetchTimeout,
+	}, cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "customized")),
+		Targets:           []string{"https://example.com/schemas/1.1.0", "https://opentelemetry.io/schemas/1.7.0"},
+		SchemaFiles:       []string{"./testdata/schemas/1.2.0.yaml"},
+		DefaultSchemaURL:  "https://example.com/schemas/1.0.0",
+		FetchTimeout:      5 * time.Second,
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "customized")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no target",
+			modify: func(cfg *Config) { cfg.Targets = nil },
+			err:    "at least one target schema URL is required",
+		},
+		{
+			name:   "invalid target",
+			modify: func(cfg *Config) { cfg.Targets = []string{"https://example.com/schemas/latest"} },
+			err:    `invalid schema URL "https://example.com/schemas/latest": invalid version "latest"`,
+		},
+		{
+			name:   "relative target",
+			modify: func(cfg *Config) { cfg.Targets = []string{"schemas/1.0.0"} },
+			err:    `invalid schema URL "schemas/1.0.0": missing scheme or host`,
+		},
+		{
+			name: "same family",
+			modify: func(cfg *Config) {
+				cfg.Targets = []string{"https://example.com/schemas/1.0.0", "https://example.com/schemas/1.2.0"}
+			},
+			err: `more than one target for schema family "https://example.com/schemas"`,
+		},
+		{
+			name:   "default schema of another family",
+			modify: func(cfg *Config) { cfg.DefaultSchemaURL = "https://opentelemetry.io/schemas/1.7.0" },
+			err:    `default_schema_url "https://opentelemetry.io/schemas/1.7.0" doesn't belong to any target schema family`,
+		},
+		{
+			name:   "no fetch timeout",
+			modify: func(cfg *Config) { cfg.FetchTimeout = 0 },
+			err:    "fetch_timeout must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Targets = []string{"https://example.com/schemas/1.2.0"}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
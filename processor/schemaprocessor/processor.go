@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type schemaProcessor struct {
+	cfg        *Config
+	logger     *zap.Logger
+	client     *http.Client
+	translator *translator
+}
+
+func newSchemaProcessor(cfg *Config, logger *zap.Logger) *schemaProcessor {
+	return &schemaProcessor{
+		cfg:    cfg,
+		logger: logger,
+		client: &http.Client{Timeout: cfg.FetchTimeout},
+	}
+}
+
+// start loads the local schema files and fetches the ones of the targets
+// that are still missing.
+func (sp *schemaProcessor) start(ctx context.Context, _ component.Host) error {
+	reg := newRegistry()
+	for _, path := range sp.cfg.SchemaFiles {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read schema file: %w", err)
+		}
+		sf, err := parseSchemaFile(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse schema file %q: %w", path, err)
+		}
+		reg.add(sf)
+	}
+
+	targets := make(map[string]version, len(sp.cfg.Targets))
+	for _, target := range sp.cfg.Targets {
+		familyURL, v, err := splitSchemaURL(target)
+		if err != nil {
+			return err
+		}
+		targets[familyURL] = v
+		if reg.hasVersion(familyURL, v) {
+			continue
+		}
+		sf, err := sp.fetch(ctx, target)
+		if err != nil {
+			return err
+		}
+		reg.add(sf)
+		if !reg.hasVersion(familyURL, v) {
+			return fmt.Errorf("schema file fetched from %q doesn't define version %s", target, v)
+		}
+	}
+
+	sp.translator = newTranslator(sp.logger, reg, targets, sp.cfg.DefaultSchemaURL)
+	return nil
+}
+
+func (sp *schemaProcessor) fetch(ctx context.Context, schemaURL string) (*schemaFile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sp.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch schema file from %q: %s", schemaURL, resp.Status)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema file: %w", err)
+	}
+	sf, err := parseSchemaFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema file fetched from %q: %w", schemaURL, err)
+	}
+	return sf, nil
+}
+
+func (sp *schemaProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	sp.translator.translateTraces(td)
+	return td, nil
+}
+
+func (sp *schemaProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	sp.translator.translateMetrics(md)
+	return md, nil
+}
+
+func (sp *schemaProcessor) processLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	sp.translator.translateLogs(ld)
+	return ld, nil
+}
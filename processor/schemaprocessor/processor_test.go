@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	v100 = "https://example.com/schemas/1.0.0"
+	v110 = "https://example.com/schemas/1.1.0"
+	v120 = "https://example.com/schemas/1.2.0"
+)
+
+func newTestProcessor(t *testing.T, target string, modify func(*Config)) *schemaProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []string{target}
+	cfg.SchemaFiles = []string{"testdata/schemas/1.2.0.yaml"}
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	sp := newSchemaProcessor(cfg, zap.NewNop())
+	require.NoError(t, sp.start(context.Background(), componenttest.NewNopHost()))
+	return sp
+}
+
+func generateTraces(schemaURL string, attrs map[string]string) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl(schemaURL)
+	rs.Resource().Attributes().InsertString("k8s.cluster", "prod")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, name := range []string{"query", "GET"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		for k, v := range attrs {
+			span.Attributes().InsertString(k, v)
+		}
+		event := span.Events().AppendEmpty()
+		event.SetName("exception_event")
+		event.Attributes().InsertString("http.url", "https://example.com")
+	}
+	return td
+}
+
+func TestTranslateTracesUpgrade(t *testing.T) {
+	sp := newTestProcessor(t, v120, nil)
+
+	td, err := sp.processTraces(context.Background(), generateTraces(v100, map[string]string{
+		"http.url":     "https://example.com/cart",
+		"db.statement": "SELECT 1",
+	}))
+	require.NoError(t, err)
+
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, v120, rs.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"k8s.cluster.name": "prod"}, rs.Resource().Attributes().AsRaw())
+
+	ils := rs.InstrumentationLibrarySpans().At(0)
+	assert.Equal(t, v120, ils.SchemaUrl())
+	query := ils.Spans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"url.full":      "https://example.com/cart",
+		"db.query.text": "SELECT 1",
+	}, query.Attributes().AsRaw())
+	assert.Equal(t, "exception", query.Events().At(0).Name())
+	assert.Equal(t, map[string]interface{}{"url.full": "https://example.com"}, query.Events().At(0).Attributes().AsRaw())
+
+	// db.statement is only renamed for spans named "query".
+	get := ils.Spans().At(1)
+	assert.Equal(t, map[string]interface{}{
+		"url.full":     "https://example.com/cart",
+		"db.statement": "SELECT 1",
+	}, get.Attributes().AsRaw())
+}
+
+func TestTranslateTracesDowngrade(t *testing.T) {
+	sp := newTestProcessor(t, v100, nil)
+
+	td := generateTraces(v120, map[string]string{"url.full": "https://example.com/cart", "db.query.text": "SELECT 1"})
+	td.ResourceSpans().At(0).Resource().Attributes().Clear()
+	td.ResourceSpans().At(0).Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+	event := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Events().At(0)
+	event.SetName("exception")
+
+	td, err := sp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, v100, rs.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"k8s.cluster": "prod"}, rs.Resource().Attributes().AsRaw())
+	query := rs.InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"http.url":     "https://example.com/cart",
+		"db.statement": "SELECT 1",
+	}, query.Attributes().AsRaw())
+	assert.Equal(t, "exception_event", query.Events().At(0).Name())
+}
+
+func TestTranslateKeepsExistingAttribute(t *testing.T) {
+	sp := newTestProcessor(t, v120, nil)
+
+	td, err := sp.processTraces(context.Background(), generateTraces(v110, map[string]string{
+		"http.url": "old",
+		"url.full": "new",
+	}))
+	require.NoError(t, err)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(1)
+	assert.Equal(t, map[string]interface{}{"url.full": "new"}, span.Attributes().AsRaw())
+}
+
+func TestTranslateLibrarySchemaURL(t *testing.T) {
+	sp := newTestProcessor(t, v120, nil)
+
+	td := generateTraces(v100, map[string]string{"http.url": "https://example.com/cart"})
+	ils := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0)
+	ils.SetSchemaUrl(v120)
+
+	td, err := sp.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	// The resource is translated, but spans already follow the target schema.
+	assert.Equal(t, map[string]interface{}{"k8s.cluster.name": "prod"}, td.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"http.url": "https://example.com/cart"}, ils.Spans().At(0).Attributes().AsRaw())
+}
+
+func TestTranslateUntouched(t *testing.T) {
+	tests := []struct {
+		name      string
+		schemaURL string
+	}{
+		{name: "no schema url", schemaURL: ""},
+		{name: "other family", schemaURL: "https://opentelemetry.io/schemas/1.7.0"},
+		{name: "unknown version", schemaURL: "https://example.com/schemas/1.5.0"},
+		{name: "invalid schema url", schemaURL: "not a url"},
+		{name: "target", schemaURL: v120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := newTestProcessor(t, v120, nil)
+			td, err := sp.processTraces(context.Background(), generateTraces(tt.schemaURL, map[string]string{"http.url": "x"}))
+			require.NoError(t, err)
+			rs := td.ResourceSpans().At(0)
+			assert.Equal(t, tt.schemaURL, rs.SchemaUrl())
+			assert.Equal(t, map[string]interface{}{"k8s.cluster": "prod"}, rs.Resource().Attributes().AsRaw())
+			assert.Equal(t, map[string]interface{}{"http.url": "x"}, rs.InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().AsRaw())
+		})
+	}
+}
+
+func TestTranslateDefaultSchemaURL(t *testing.T) {
+	sp := newTestProcessor(t, v120, func(cfg *Config) { cfg.DefaultSchemaURL = v110 })
+
+	td, err := sp.processTraces(context.Background(), generateTraces("", map[string]string{"http.url": "x"}))
+	require.NoError(t, err)
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, v120, rs.SchemaUrl())
+	// Resource attributes are assumed to follow 1.1.0 already.
+	assert.Equal(t, map[string]interface{}{"k8s.cluster": "prod"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"url.full": "x"}, rs.InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestTranslateMetrics(t *testing.T) {
+	sp := newTestProcessor(t, v120, nil)
+
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl(v100)
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("system.cpu.time")
+	cpu.SetDataType(pdata.MetricDataTypeSum)
+	cpu.Sum().DataPoints().AppendEmpty().Attributes().InsertString("state", "idle")
+
+	mem := metrics.AppendEmpty()
+	mem.SetName("system.memory.usage")
+	mem.SetDataType(pdata.MetricDataTypeGauge)
+	mem.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("state", "used")
+
+	duration := metrics.AppendEmpty()
+	duration.SetName("http.server.duration_ms")
+	duration.SetDataType(pdata.MetricDataTypeHistogram)
+	duration.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("http.url", "/")
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("rpc.duration")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	summary.Summary().DataPoints().AppendEmpty().Attributes().InsertString("http.url", "/")
+
+	md, err := sp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, v120, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"system.cpu.state": "idle"}, cpu.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"state": "used"}, mem.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "http.server.request.duration", duration.Name())
+	assert.Equal(t, map[string]interface{}{"url.full": "/"}, duration.Histogram().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"url.full": "/"}, summary.Summary().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslateLogs(t *testing.T) {
+	sp := newTestProcessor(t, v120, nil)
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl(v100)
+	rl.Resource().Attributes().InsertString("k8s.cluster", "prod")
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Attributes().InsertString("http.url", "/")
+	lr.Attributes().InsertString("log.source", "stdout")
+
+	ld, err := sp.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	assert.Equal(t, v120, rl.SchemaUrl())
+	assert.Equal(t, v120, rl.InstrumentationLibraryLogs().At(0).SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"k8s.cluster.name": "prod"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"url.full": "/", "log.origin": "stdout"}, ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes().AsRaw())
+}
+
+func TestApplyRenamesSwap(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("a", "1")
+	attrs.InsertString("b", "2")
+	applyRenames(attrs, map[string]string{"a": "b", "b": "a"})
+	assert.Equal(t, map[string]interface{}{"a": "2", "b": "1"}, attrs.AsRaw())
+}
+
+func TestStartFetchesSchema(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/schemas/1.2.0.yaml")
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas/1.2.0" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	// The schema file declares the example.com family, so fetched files must
+	// define the requested version of their own family.
+	sp := newSchemaProcessor(&Config{Targets: []string{srv.URL + "/schemas/1.2.0"}, FetchTimeout: defaultFetchTimeout}, zap.NewNop())
+	assert.EqualError(t, sp.start(context.Background(), componenttest.NewNopHost()),
+		`schema file fetched from "`+srv.URL+`/schemas/1.2.0" doesn't define version 1.2.0`)
+
+	sp = newSchemaProcessor(&Config{Targets: []string{srv.URL + "/schemas/1.3.0"}, FetchTimeout: defaultFetchTimeout}, zap.NewNop())
+	assert.EqualError(t, sp.start(context.Background(), componenttest.NewNopHost()),
+		`failed to fetch schema file from "`+srv.URL+`/schemas/1.3.0": 404 Not Found`)
+}
+
+func TestStartFetchesTargetSchema(t *testing.T) {
+	srv := httptest.NewServer(nil)
+	defer srv.Close()
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`file_format: 1.0.0
+schema_url: ` + srv.URL + `/schemas/1.1.0
+versions:
+  1.1.0:
+    all:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              http.url: url.full
+  1.0.0:
+`))
+	})
+
+	sp := newSchemaProcessor(&Config{Targets: []string{srv.URL + "/schemas/1.1.0"}, FetchTimeout: defaultFetchTimeout}, zap.NewNop())
+	require.NoError(t, sp.start(context.Background(), componenttest.NewNopHost()))
+
+	td, err := sp.processTraces(context.Background(), generateTraces(srv.URL+"/schemas/1.0.0", map[string]string{"http.url": "x"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"url.full": "x"}, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestStartInvalidSchemaFile(t *testing.T) {
+	sp := newSchemaProcessor(&Config{Targets: []string{v120}, SchemaFiles: []string{"testdata/missing.yaml"}}, zap.NewNop())
+	assert.Error(t, sp.start(context.Background(), componenttest.NewNopHost()))
+
+	sp = newSchemaProcessor(&Config{Targets: []string{v120}, SchemaFiles: []string{"testdata/config.yaml"}}, zap.NewNop())
+	assert.Error(t, sp.start(context.Background(), componenttest.NewNopHost()))
+}
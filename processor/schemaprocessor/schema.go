@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// schemaFile is the content of an OpenTelemetry schema file, see
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/schemas/file_format_v1.0.0.md
+type schemaFile struct {
+	FileFormat string                       `yaml:"file_format"`
+	SchemaURL  string                       `yaml:"schema_url"`
+	Versions   map[string]versionDefinition `yaml:"versions"`
+}
+
+type versionDefinition struct {
+	All        changeSet `yaml:"all"`
+	Resources  changeSet `yaml:"resources"`
+	Spans      changeSet `yaml:"spans"`
+	SpanEvents changeSet `yaml:"span_events"`
+	Metrics    changeSet `yaml:"metrics"`
+	Logs       changeSet `yaml:"logs"`
+}
+
+type changeSet struct {
+	Changes []change `yaml:"changes"`
+}
+
+// change holds exactly one of the transformations a schema file can define.
+type change struct {
+	RenameAttributes *renameAttributes `yaml:"rename_attributes"`
+	RenameMetrics    map[string]string `yaml:"rename_metrics"`
+	RenameEvents     *renameEvents     `yaml:"rename_events"`
+}
+
+type renameAttributes struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToEvents  []string          `yaml:"apply_to_events"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+type renameEvents struct {
+	NameMap map[string]string `yaml:"name_map"`
+}
+
+// version is a semantic version as used in schema URLs.
+type version [3]int
+
+func parseVersion(s string) (version, error) {
+	var v version
+	parts := strings.Split(s, ".")
+	if len(parts) < 1 || len(parts) > 3 {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v version) less(o version) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// splitSchemaURL splits a schema URL into its family, which is the URL
+// without its last path segment, and the version the last segment names.
+func splitSchemaURL(schemaURL string) (string, version, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return "", version{}, fmt.Errorf("invalid schema URL %q: %w", schemaURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", version{}, fmt.Errorf("invalid schema URL %q: missing scheme or host", schemaURL)
+	}
+	i := strings.LastIndex(schemaURL, "/")
+	v, err := parseVersion(schemaURL[i+1:])
+	if err != nil {
+		return "", version{}, fmt.Errorf("invalid schema URL %q: %w", schemaURL, err)
+	}
+	return schemaURL[:i], v, nil
+}
+
+// versionedChanges is a version of a schema family and the changes needed to
+// upgrade to it from the previous version.
+type versionedChanges struct {
+	version version
+	def     versionDefinition
+}
+
+// family holds every known version of a schema family, sorted in ascending
+// order.
+type family struct {
+	url      string
+	versions []versionedChanges
+}
+
+func parseSchemaFile(content []byte) (*schemaFile, error) {
+	var sf schemaFile
+	if err := yaml.UnmarshalStrict(content, &sf); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(sf.FileFormat, "1.") {
+		return nil, fmt.Errorf("unsupported file_format %q", sf.FileFormat)
+	}
+	if sf.SchemaURL == "" {
+		return nil, errors.New("missing schema_url")
+	}
+	if _, _, err := splitSchemaURL(sf.SchemaURL); err != nil {
+		return nil, err
+	}
+	for name, def := range sf.Versions {
+		if _, err := parseVersion(name); err != nil {
+			return nil, err
+		}
+		for _, cs := range []changeSet{def.All, def.Resources, def.Spans, def.SpanEvents, def.Metrics, def.Logs} {
+			for _, c := range cs.Changes {
+				if err := c.validate(); err != nil {
+					return nil, fmt.Errorf("version %s: %w", name, err)
+				}
+			}
+		}
+	}
+	return &sf, nil
+}
+
+func (c change) validate() error {
+	n := 0
+	if c.RenameAttributes != nil {
+		n++
+		if err := validateRenames(c.RenameAttributes.AttributeMap); err != nil {
+			return err
+		}
+	}
+	if c.RenameMetrics != nil {
+		n++
+		if err := validateRenames(c.RenameMetrics); err != nil {
+			return err
+		}
+	}
+	if c.RenameEvents != nil {
+		n++
+		if err := validateRenames(c.RenameEvents.NameMap); err != nil {
+			return err
+		}
+	}
+	if n != 1 {
+		return errors.New("each change must define exactly one transformation")
+	}
+	return nil
+}
+
+// validateRenames ensures renames can be reverted, which is required to
+// translate to older versions.
+func validateRenames(m map[string]string) error {
+	seen := make(map[string]string, len(m))
+	for from, to := range m {
+		if other, ok := seen[to]; ok {
+			return fmt.Errorf("both %q and %q are renamed to %q", other, from, to)
+		}
+		seen[to] = from
+	}
+	return nil
+}
+
+// registry holds the schema families known to the processor.
+type registry struct {
+	families map[string]*family
+}
+
+func newRegistry() *registry {
+	return &registry{families: map[string]*family{}}
+}
+
+// add merges the versions of a schema file into its family. Files of the
+// same family are expected to agree on the versions they both define.
+func (r *registry) add(sf *schemaFile) {
+	familyURL, _, _ := splitSchemaURL(sf.SchemaURL)
+	f, ok := r.families[familyURL]
+	if !ok {
+		f = &family{url: familyURL}
+		r.families[familyURL] = f
+	}
+	known := map[version]bool{}
+	for _, vc := range f.versions {
+		known[vc.version] = true
+	}
+	for name, def := range sf.Versions {
+		v, _ := parseVersion(name)
+		if known[v] {
+			continue
+		}
+		f.versions = append(f.versions, versionedChanges{version: v, def: def})
+	}
+	sort.Slice(f.versions, func(i, j int) bool {
+		return f.versions[i].version.less(f.versions[j].version)
+	})
+}
+
+func (r *registry) hasVersion(familyURL string, v version) bool {
+	f, ok := r.families[familyURL]
+	if !ok {
+		return false
+	}
+	for _, vc := range f.versions {
+		if vc.version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// path returns the changes to apply to translate data from one version to
+// another and whether the translation is an upgrade. ok is false if either
+// version is unknown.
+func (r *registry) path(familyURL string, from, to version) (changes []versionedChanges, upgrade bool, ok bool) {
+	if !r.hasVersion(familyURL, from) || !r.hasVersion(familyURL, to) {
+		return nil, false, false
+	}
+	f := r.families[familyURL]
+	if from.less(to) {
+		for _, vc := range f.versions {
+			if from.less(vc.version) && !to.less(vc.version) {
+				changes = append(changes, vc)
+			}
+		}
+		return changes, true, true
+	}
+	for i := len(f.versions) - 1; i >= 0; i-- {
+		vc := f.versions[i]
+		if to.less(vc.version) && !from.less(vc.version) {
+			changes = append(changes, vc)
+		}
+	}
+	return changes, false, true
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := parseVersion("1.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, version{1, 7, 0}, v)
+
+	v, err = parseVersion("2")
+	require.NoError(t, err)
+	assert.Equal(t, version{2, 0, 0}, v)
+	assert.Equal(t, "2.0.0", v.String())
+
+	for _, s := range []string{"", "1.2.3.4", "1.x", "-1.0"} {
+		_, err = parseVersion(s)
+		assert.Error(t, err, s)
+	}
+
+	assert.True(t, version{1, 2, 3}.less(version{1, 10, 0}))
+	assert.False(t, version{1, 2, 3}.less(version{1, 2, 3}))
+}
+
+func TestSplitSchemaURL(t *testing.T) {
+	familyURL, v, err := splitSchemaURL("https://opentelemetry.io/schemas/1.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas", familyURL)
+	assert.Equal(t, version{1, 7, 0}, v)
+
+	for _, s := range []string{"https://opentelemetry.io/schemas/", "/schemas/1.7.0", "://x/1.0.0"} {
+		_, _, err = splitSchemaURL(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseSchemaFile(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/schemas/1.2.0.yaml")
+	require.NoError(t, err)
+	sf, err := parseSchemaFile(content)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/schemas/1.2.0", sf.SchemaURL)
+	assert.Len(t, sf.Versions, 3)
+	assert.Equal(t, map[string]string{"http.url": "url.full"}, sf.Versions["1.2.0"].All.Changes[0].RenameAttributes.AttributeMap)
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "unsupported format",
+			content: "file_format: 2.0.0\nschema_url: https://example.com/schemas/1.0.0\n",
+			err:     `unsupported file_format "2.0.0"`,
+		},
+		{
+			name:    "missing schema url",
+			content: "file_format: 1.0.0\n",
+			err:     "missing schema_url",
+		},
+		{
+			name:    "unknown field",
+			content: "file_format: 1.0.0\nschema_url: https://example.com/schemas/1.0.0\nextra: true\n",
+			err:     "yaml: unmarshal errors:\n  line 3: field extra not found in type schemaprocessor.schemaFile",
+		},
+		{
+			name: "invalid version",
+			content: `file_format: 1.0.0
+schema_url: https://example.com/schemas/1.0.0
+versions:
+  next:
+`,
+			err: `invalid version "next"`,
+		},
+		{
+			name: "several transformations",
+			content: `file_format: 1.0.0
+schema_url: https://example.com/schemas/1.0.0
+versions:
+  1.0.0:
+    metrics:
+      changes:
+        - rename_metrics:
+            a: b
+          rename_attributes:
+            attribute_map:
+              c: d
+`,
+			err: "version 1.0.0: each change must define exactly one transformation",
+		},
+		{
+			name: "irreversible rename",
+			content: `file_format: 1.0.0
+schema_url: https://example.com/schemas/1.0.0
+versions:
+  1.0.0:
+    all:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              a: c
+              b: c
+`,
+			err: "version 1.0.0: both",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSchemaFile([]byte(tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestRegistryPath(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/schemas/1.2.0.yaml")
+	require.NoError(t, err)
+	sf, err := parseSchemaFile(content)
+	require.NoError(t, err)
+	reg := newRegistry()
+	reg.add(sf)
+
+	versionsOf := func(changes []versionedChanges) []version {
+		var versions []version
+		for _, vc := range changes {
+			versions = append(versions, vc.version)
+		}
+		return versions
+	}
+
+	changes, upgrade, ok := reg.path("https://example.com/schemas", version{1, 0, 0}, version{1, 2, 0})
+	require.True(t, ok)
+	assert.True(t, upgrade)
+	assert.Equal(t, []version{{1, 1, 0}, {1, 2, 0}}, versionsOf(changes))
+
+	changes, upgrade, ok = reg.path("https://example.com/schemas", version{1, 2, 0}, version{1, 0, 0})
+	require.True(t, ok)
+	assert.False(t, upgrade)
+	assert.Equal(t, []version{{1, 2, 0}, {1, 1, 0}}, versionsOf(changes))
+
+	_, _, ok = reg.path("https://example.com/schemas", version{1, 3, 0}, version{1, 0, 0})
+	assert.False(t, ok)
+	_, _, ok = reg.path("https://opentelemetry.io/schemas", version{1, 0, 0}, version{1, 7, 0})
+	assert.False(t, ok)
+}
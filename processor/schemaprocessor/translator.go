@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// operation is a single transformation of a schema file, oriented in the
+// direction of the translation.
+type operation struct {
+	// renameAttributes maps old attribute names to new ones.
+	renameAttributes map[string]string
+	// renameNames maps old metric or span event names to new ones.
+	renameNames map[string]string
+
+	// Restrictions on the items the operation applies to, nil means all.
+	spans   map[string]bool
+	events  map[string]bool
+	metrics map[string]bool
+}
+
+func newOperation(c change, upgrade bool) operation {
+	var op operation
+	switch {
+	case c.RenameAttributes != nil:
+		op.renameAttributes = orient(c.RenameAttributes.AttributeMap, upgrade)
+		op.spans = toSet(c.RenameAttributes.ApplyToSpans)
+		op.events = toSet(c.RenameAttributes.ApplyToEvents)
+		op.metrics = toSet(c.RenameAttributes.ApplyToMetrics)
+	case c.RenameMetrics != nil:
+		op.renameNames = orient(c.RenameMetrics, upgrade)
+	case c.RenameEvents != nil:
+		op.renameNames = orient(c.RenameEvents.NameMap, upgrade)
+	}
+	return op
+}
+
+func orient(m map[string]string, upgrade bool) map[string]string {
+	if upgrade {
+		return m
+	}
+	inverted := make(map[string]string, len(m))
+	for from, to := range m {
+		inverted[to] = from
+	}
+	return inverted
+}
+
+func toSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// translation is the list of operations to apply to each kind of telemetry
+// to translate it from one version of a schema family to another.
+type translation struct {
+	resources  []operation
+	spans      []operation
+	spanEvents []operation
+	metrics    []operation
+	logs       []operation
+}
+
+func newTranslation(changes []versionedChanges, upgrade bool) *translation {
+	t := &translation{}
+	for _, vc := range changes {
+		t.resources = append(t.resources, sectionOperations(vc.def.All, vc.def.Resources, upgrade)...)
+		t.spans = append(t.spans, sectionOperations(vc.def.All, vc.def.Spans, upgrade)...)
+		t.spanEvents = append(t.spanEvents, sectionOperations(vc.def.All, vc.def.SpanEvents, upgrade)...)
+		t.metrics = append(t.metrics, sectionOperations(vc.def.All, vc.def.Metrics, upgrade)...)
+		t.logs = append(t.logs, sectionOperations(vc.def.All, vc.def.Logs, upgrade)...)
+	}
+	return t
+}
+
+// sectionOperations returns the operations of a version for one kind of
+// telemetry. Changes of the "all" section are applied first when upgrading,
+// and everything is applied in reverse order when downgrading.
+func sectionOperations(all, section changeSet, upgrade bool) []operation {
+	changes := append(append([]change{}, all.Changes...), section.Changes...)
+	ops := make([]operation, 0, len(changes))
+	if upgrade {
+		for _, c := range changes {
+			ops = append(ops, newOperation(c, upgrade))
+		}
+		return ops
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		ops = append(ops, newOperation(changes[i], upgrade))
+	}
+	return ops
+}
+
+// applyRenames renames attributes of the map. Renames are applied
+// simultaneously, so that swapping names works. If the new name is already
+// present, its value is kept and the old attribute is removed.
+func applyRenames(attrs pdata.AttributeMap, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	moved := map[string]pdata.AttributeValue{}
+	for from, to := range renames {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		value := pdata.NewAttributeValueEmpty()
+		v.CopyTo(value)
+		moved[to] = value
+		attrs.Delete(from)
+	}
+	for to, v := range moved {
+		attrs.Insert(to, v)
+	}
+}
+
+func (t *translation) translateResource(resource pdata.Resource) {
+	for _, op := range t.resources {
+		applyRenames(resource.Attributes(), op.renameAttributes)
+	}
+}
+
+func (t *translation) translateSpan(span pdata.Span) {
+	for _, op := range t.spans {
+		if op.spans != nil && !op.spans[span.Name()] {
+			continue
+		}
+		applyRenames(span.Attributes(), op.renameAttributes)
+	}
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		for _, op := range t.spanEvents {
+			if newName, ok := op.renameNames[event.Name()]; ok {
+				event.SetName(newName)
+				continue
+			}
+			if op.spans != nil && !op.spans[span.Name()] {
+				continue
+			}
+			if op.events != nil && !op.events[event.Name()] {
+				continue
+			}
+			applyRenames(event.Attributes(), op.renameAttributes)
+		}
+	}
+}
+
+func (t *translation) translateMetric(metric pdata.Metric) {
+	for _, op := range t.metrics {
+		if newName, ok := op.renameNames[metric.Name()]; ok {
+			metric.SetName(newName)
+			continue
+		}
+		if len(op.renameAttributes) == 0 || (op.metrics != nil && !op.metrics[metric.Name()]) {
+			continue
+		}
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			dps := metric.Gauge().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				applyRenames(dps.At(i).Attributes(), op.renameAttributes)
+			}
+		case pdata.MetricDataTypeSum:
+			dps := metric.Sum().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				applyRenames(dps.At(i).Attributes(), op.renameAttributes)
+			}
+		case pdata.MetricDataTypeHistogram:
+			dps := metric.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				applyRenames(dps.At(i).Attributes(), op.renameAttributes)
+			}
+		case pdata.MetricDataTypeSummary:
+			dps := metric.Summary().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				applyRenames(dps.At(i).Attributes(), op.renameAttributes)
+			}
+		}
+	}
+}
+
+func (t *translation) translateLogRecord(lr pdata.LogRecord) {
+	for _, op := range t.logs {
+		applyRenames(lr.Attributes(), op.renameAttributes)
+	}
+}
+
+// translator finds the translation to apply to data depending on its schema
+// URL and caches it.
+type translator struct {
+	logger   *zap.Logger
+	registry *registry
+	// targets maps schema families to the version data is translated to.
+	targets map[string]version
+	// defaultSchemaURL is assumed for data without a schema URL.
+	defaultSchemaURL string
+
+	mu    sync.Mutex
+	cache map[string]*translation
+}
+
+func newTranslator(logger *zap.Logger, reg *registry, targets map[string]version, defaultSchemaURL string) *translator {
+	return &translator{
+		logger:           logger,
+		registry:         reg,
+		targets:          targets,
+		defaultSchemaURL: defaultSchemaURL,
+		cache:            map[string]*translation{},
+	}
+}
+
+// translationFor returns the translation for data with the given schema URL
+// and the schema URL of the translated data, or nil if the data must be left
+// as is.
+func (t *translator) translationFor(schemaURL string) (*translation, string) {
+	if schemaURL == "" {
+		schemaURL = t.defaultSchemaURL
+	}
+	if schemaURL == "" {
+		return nil, ""
+	}
+	familyURL, from, err := splitSchemaURL(schemaURL)
+	if err != nil {
+		return nil, ""
+	}
+	to, ok := t.targets[familyURL]
+	if !ok || from == to {
+		return nil, ""
+	}
+	targetURL := familyURL + "/" + to.String()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tr, ok := t.cache[schemaURL]; ok {
+		if tr == nil {
+			return nil, ""
+		}
+		return tr, targetURL
+	}
+	changes, upgrade, ok := t.registry.path(familyURL, from, to)
+	if !ok {
+		t.logger.Warn("Unknown schema version, data is not translated", zap.String("schema_url", schemaURL))
+		t.cache[schemaURL] = nil
+		return nil, ""
+	}
+	tr := newTranslation(changes, upgrade)
+	t.cache[schemaURL] = tr
+	return tr, targetURL
+}
+
+// resolve returns the schema URL that applies to an instrumentation library,
+// which defaults to the one of its resource.
+func resolve(librarySchemaURL, resourceSchemaURL string) string {
+	if librarySchemaURL != "" {
+		return librarySchemaURL
+	}
+	return resourceSchemaURL
+}
+
+func (t *translator) translateTraces(td pdata.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceSchemaURL := rs.SchemaUrl()
+		if tr, target := t.translationFor(resourceSchemaURL); tr != nil {
+			tr.translateResource(rs.Resource())
+			rs.SetSchemaUrl(target)
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			tr, target := t.translationFor(resolve(ils.SchemaUrl(), resourceSchemaURL))
+			if tr == nil {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				tr.translateSpan(spans.At(k))
+			}
+			ils.SetSchemaUrl(target)
+		}
+	}
+}
+
+func (t *translator) translateMetrics(md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceSchemaURL := rm.SchemaUrl()
+		if tr, target := t.translationFor(resourceSchemaURL); tr != nil {
+			tr.translateResource(rm.Resource())
+			rm.SetSchemaUrl(target)
+		}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			tr, target := t.translationFor(resolve(ilm.SchemaUrl(), resourceSchemaURL))
+			if tr == nil {
+				continue
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				tr.translateMetric(metrics.At(k))
+			}
+			ilm.SetSchemaUrl(target)
+		}
+	}
+}
+
+func (t *translator) translateLogs(ld pdata.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceSchemaURL := rl.SchemaUrl()
+		if tr, target := t.translationFor(resourceSchemaURL); tr != nil {
+			tr.translateResource(rl.Resource())
+			rl.SetSchemaUrl(target)
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			tr, target := t.translationFor(resolve(ill.SchemaUrl(), resourceSchemaURL))
+			if tr == nil {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				tr.translateLogRecord(logs.At(k))
+			}
+			ill.SetSchemaUrl(target)
+		}
+	}
+}
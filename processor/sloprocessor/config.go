@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sloprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// Config defines configuration for the SLO processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Routes are the SLOs of the routes of services. A span is classified
+	// against the first route it matches.
+	Routes []RouteConfig `mapstructure:"routes"`
+
+	// Default is the SLO of spans that match no route. Such spans are left
+	// as is if not set.
+	Default *ObjectiveConfig `mapstructure:"default"`
+
+	// SpanKinds are the kinds of the spans to classify: server, client,
+	// internal, producer or consumer. Defaults to server.
+	SpanKinds []string `mapstructure:"span_kinds"`
+
+	// Attributes are the names of the attributes set on classified spans.
+	Attributes AttributesConfig `mapstructure:"attributes"`
+}
+
+// RouteConfig defines the SLO of a route.
+type RouteConfig struct {
+	// Name identifies the route in the attributes of classified spans.
+	Name string `mapstructure:"name"`
+
+	// Services restricts the route to spans of these services. The route
+	// applies to all services if empty.
+	Services []string `mapstructure:"services"`
+
+	// Routes are matched against the http.route attribute of spans, or their
+	// name for spans without http.route. The route applies to all spans of
+	// its services if empty.
+	Routes []string `mapstructure:"routes"`
+
+	// Config configures how routes are matched. Routes are matched strictly
+	// by default.
+	filterset.Config `mapstructure:",squash"`
+
+	ObjectiveConfig `mapstructure:",squash"`
+}
+
+// ObjectiveConfig defines latency objectives.
+type ObjectiveConfig struct {
+	// Threshold is the duration above which a span violates the SLO.
+	Threshold time.Duration `mapstructure:"threshold"`
+
+	// Timeout is the duration from which a span is considered timed out. No
+	// span is considered timed out if 0.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// AttributesConfig defines the names of the attributes set on classified
+// spans.
+type AttributesConfig struct {
+	// Violated is set to true when the span violates its SLO, false
+	// otherwise.
+	Violated string `mapstructure:"violated"`
+
+	// Bucket is set to fast, slow or timeout.
+	Bucket string `mapstructure:"bucket"`
+
+	// Route is set to the name of the route the span matched. It isn't set
+	// for spans classified against the default SLO.
+	Route string `mapstructure:"route"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Routes) == 0 && cfg.Default == nil {
+		return errors.New("at least one route or a default SLO is required")
+	}
+	for i, route := range cfg.Routes {
+		if len(route.Routes) > 0 {
+			if _, err := route.filterSet(); err != nil {
+				return fmt.Errorf("routes[%d]: %w", i, err)
+			}
+		}
+		if err := route.ObjectiveConfig.validate(); err != nil {
+			return fmt.Errorf("routes[%d]: %w", i, err)
+		}
+	}
+	if cfg.Default != nil {
+		if err := cfg.Default.validate(); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+	for _, kind := range cfg.SpanKinds {
+		if _, ok := spanKinds[kind]; !ok {
+			return fmt.Errorf("unknown span kind %q", kind)
+		}
+	}
+	if cfg.Attributes.Violated == "" || cfg.Attributes.Bucket == "" {
+		return errors.New("attributes: violated and bucket must be non-empty")
+	}
+	return nil
+}
+
+func (rc *RouteConfig) filterSet() (filterset.FilterSet, error) {
+	fsCfg := rc.Config
+	if fsCfg.MatchType == "" {
+		fsCfg.MatchType = filterset.Strict
+	}
+	return filterset.CreateFilterSet(rc.Routes, &fsCfg)
+}
+
+func (o ObjectiveConfig) validate() error {
+	if o.Threshold <= 0 {
+		return errors.New("threshold must be positive")
+	}
+	if o.Timeout != 0 && o.Timeout <= o.Threshold {
+		return errors.New("timeout must be greater than threshold")
+	}
+	return nil
+}
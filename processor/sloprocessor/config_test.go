@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sloprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Default = &ObjectiveConfig{Threshold: 500 * time.Millisecond}
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		Routes: []RouteConfig{
+			{
+				Name:            "checkout",
+				Services:        []string{"frontend"},
+				Routes:          []string{"/api/checkout"},
+				ObjectiveConfig: ObjectiveConfig{Threshold: 300 * time.Millisecond, Timeout: 5 * time.Second},
+			},
+			{
+				Name:            "api",
+				Routes:          []string{"^/api/.*"},
+				Config:          filterset.Config{MatchType: filterset.Regexp},
+				ObjectiveConfig: ObjectiveConfig{Threshold: time.Second},
+			},
+		},
+		Default:   &ObjectiveConfig{Threshold: 2 * time.Second, Timeout: 30 * time.Second},
+		SpanKinds: []string{"server", "consumer"},
+		Attributes: AttributesConfig{
+			Violated: defaultViolatedAttribute,
+			Bucket:   defaultBucketAttribute,
+			Route:    "slo.name",
+		},
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name: "no objective",
+			modify: func(cfg *Config) {
+				cfg.Routes = nil
+				cfg.Default = nil
+			},
+			err: "at least one route or a default SLO is required",
+		},
+		{
+			name:   "invalid route pattern",
+			modify: func(cfg *Config) { cfg.Routes[0].Routes = []string{"("} },
+			err:    "routes[0]: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "missing threshold",
+			modify: func(cfg *Config) { cfg.Routes[0].Threshold = 0 },
+			err:    "routes[0]: threshold must be positive",
+		},
+		{
+			name:   "timeout below threshold",
+			modify: func(cfg *Config) { cfg.Default.Timeout = time.Millisecond },
+			err:    "default: timeout must be greater than threshold",
+		},
+		{
+			name:   "unknown span kind",
+			modify: func(cfg *Config) { cfg.SpanKinds = []string{"server", "db"} },
+			err:    `unknown span kind "db"`,
+		},
+		{
+			name:   "empty attribute",
+			modify: func(cfg *Config) { cfg.Attributes.Bucket = "" },
+			err:    "attributes: violated and bucket must be non-empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Routes = []RouteConfig{{
+				Routes:          []string{"^/api/"},
+				Config:          filterset.Config{MatchType: filterset.Regexp},
+				ObjectiveConfig: ObjectiveConfig{Threshold: time.Second},
+			}}
+			cfg.Default = &ObjectiveConfig{Threshold: time.Second}
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
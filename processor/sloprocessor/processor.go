@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sloprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+const (
+	bucketFast    = "fast"
+	bucketSlow    = "slow"
+	bucketTimeout = "timeout"
+)
+
+var spanKinds = map[string]pdata.SpanKind{
+	"server":   pdata.SpanKindServer,
+	"client":   pdata.SpanKindClient,
+	"internal": pdata.SpanKindInternal,
+	"producer": pdata.SpanKindProducer,
+	"consumer": pdata.SpanKindConsumer,
+}
+
+type route struct {
+	name      string
+	services  map[string]bool
+	routes    filterset.FilterSet
+	objective ObjectiveConfig
+}
+
+// matches reports whether the route applies to a span of the given service.
+func (r *route) matches(service string, span pdata.Span) bool {
+	if r.services != nil && !r.services[service] {
+		return false
+	}
+	if r.routes == nil {
+		return true
+	}
+	if httpRoute, ok := span.Attributes().Get(conventions.AttributeHTTPRoute); ok {
+		return r.routes.Matches(httpRoute.StringVal())
+	}
+	return r.routes.Matches(span.Name())
+}
+
+type sloProcessor struct {
+	routes     []route
+	def        *ObjectiveConfig
+	kinds      map[pdata.SpanKind]bool
+	attributes AttributesConfig
+}
+
+func newSLOProcessor(cfg *Config) (*sloProcessor, error) {
+	p := &sloProcessor{
+		def:        cfg.Default,
+		kinds:      map[pdata.SpanKind]bool{},
+		attributes: cfg.Attributes,
+	}
+	for i := range cfg.Routes {
+		rc := &cfg.Routes[i]
+		r := route{name: rc.Name, objective: rc.ObjectiveConfig}
+		if len(rc.Services) > 0 {
+			r.services = map[string]bool{}
+			for _, service := range rc.Services {
+				r.services[service] = true
+			}
+		}
+		if len(rc.Routes) > 0 {
+			fs, err := rc.filterSet()
+			if err != nil {
+				return nil, err
+			}
+			r.routes = fs
+		}
+		p.routes = append(p.routes, r)
+	}
+	if len(cfg.SpanKinds) == 0 {
+		p.kinds[pdata.SpanKindServer] = true
+	}
+	for _, kind := range cfg.SpanKinds {
+		p.kinds[spanKinds[kind]] = true
+	}
+	return p, nil
+}
+
+func (p *sloProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := ""
+		if v, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+			service = v.StringVal()
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				p.processSpan(service, spans.At(k))
+			}
+		}
+	}
+	return td, nil
+}
+
+func (p *sloProcessor) processSpan(service string, span pdata.Span) {
+	if !p.kinds[span.Kind()] {
+		return
+	}
+	objective, routeName, ok := p.objective(service, span)
+	if !ok {
+		return
+	}
+
+	duration := span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime())
+	bucket := classify(duration, objective)
+	attrs := span.Attributes()
+	attrs.UpsertBool(p.attributes.Violated, bucket != bucketFast)
+	attrs.UpsertString(p.attributes.Bucket, bucket)
+	if routeName != "" && p.attributes.Route != "" {
+		attrs.UpsertString(p.attributes.Route, routeName)
+	}
+}
+
+// objective returns the objective of the first route matching the span, or
+// the default objective.
+func (p *sloProcessor) objective(service string, span pdata.Span) (ObjectiveConfig, string, bool) {
+	for i := range p.routes {
+		if p.routes[i].matches(service, span) {
+			return p.routes[i].objective, p.routes[i].name, true
+		}
+	}
+	if p.def != nil {
+		return *p.def, "", true
+	}
+	return ObjectiveConfig{}, "", false
+}
+
+func classify(duration time.Duration, objective ObjectiveConfig) string {
+	switch {
+	case objective.Timeout > 0 && duration >= objective.Timeout:
+		return bucketTimeout
+	case duration > objective.Threshold:
+		return bucketSlow
+	default:
+		return bucketFast
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sloprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+type testSpan struct {
+	name     string
+	kind     pdata.SpanKind
+	route    string
+	duration time.Duration
+}
+
+func newTraces(service string, spans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", service)
+	ss := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	start := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	for _, s := range spans {
+		span := ss.AppendEmpty()
+		span.SetName(s.name)
+		span.SetKind(s.kind)
+		if s.route != "" {
+			span.Attributes().InsertString("http.route", s.route)
+		}
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(start.Add(s.duration)))
+	}
+	return td
+}
+
+func processTraces(t *testing.T, cfg *Config, td pdata.Traces) pdata.SpanSlice {
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.TracesSink)
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	return sink.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+}
+
+func assertClassification(t *testing.T, span pdata.Span, violated bool, bucket, route string) {
+	attrs := span.Attributes()
+	v, ok := attrs.Get("slo.violated")
+	require.True(t, ok, "span %q isn't classified", span.Name())
+	assert.Equal(t, violated, v.BoolVal(), span.Name())
+	v, _ = attrs.Get("latency.bucket")
+	assert.Equal(t, bucket, v.StringVal(), span.Name())
+	v, ok = attrs.Get("slo.route")
+	if route == "" {
+		assert.False(t, ok, span.Name())
+	} else {
+		assert.Equal(t, route, v.StringVal(), span.Name())
+	}
+}
+
+func assertNotClassified(t *testing.T, span pdata.Span) {
+	_, ok := span.Attributes().Get("slo.violated")
+	assert.False(t, ok, span.Name())
+	_, ok = span.Attributes().Get("latency.bucket")
+	assert.False(t, ok, span.Name())
+}
+
+func TestProcessTracesBuckets(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Default = &ObjectiveConfig{Threshold: 100 * time.Millisecond, Timeout: time.Second}
+
+	spans := processTraces(t, cfg, newTraces("frontend",
+		testSpan{name: "fast", kind: pdata.SpanKindServer, duration: 50 * time.Millisecond},
+		testSpan{name: "threshold", kind: pdata.SpanKindServer, duration: 100 * time.Millisecond},
+		testSpan{name: "slow", kind: pdata.SpanKindServer, duration: 500 * time.Millisecond},
+		testSpan{name: "timeout", kind: pdata.SpanKindServer, duration: time.Second},
+		testSpan{name: "client", kind: pdata.SpanKindClient, duration: 5 * time.Second},
+	))
+
+	assertClassification(t, spans.At(0), false, "fast", "")
+	assertClassification(t, spans.At(1), false, "fast", "")
+	assertClassification(t, spans.At(2), true, "slow", "")
+	assertClassification(t, spans.At(3), true, "timeout", "")
+	assertNotClassified(t, spans.At(4))
+}
+
+func TestProcessTracesRoutes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Routes = []RouteConfig{
+		{
+			Name:            "checkout",
+			Services:        []string{"frontend"},
+			Routes:          []string{"/api/checkout"},
+			Config:          filterset.Config{MatchType: filterset.Strict},
+			ObjectiveConfig: ObjectiveConfig{Threshold: 100 * time.Millisecond},
+		},
+		{
+			Name:            "api",
+			Routes:          []string{"^/api/"},
+			Config:          filterset.Config{MatchType: filterset.Regexp},
+			ObjectiveConfig: ObjectiveConfig{Threshold: time.Second},
+		},
+	}
+	cfg.SpanKinds = []string{"server", "consumer"}
+
+	spans := processTraces(t, cfg, newTraces("frontend",
+		testSpan{name: "POST", kind: pdata.SpanKindServer, route: "/api/checkout", duration: 500 * time.Millisecond},
+		testSpan{name: "GET", kind: pdata.SpanKindServer, route: "/api/cart", duration: 500 * time.Millisecond},
+		testSpan{name: "/api/orders", kind: pdata.SpanKindConsumer, duration: 2 * time.Second},
+		testSpan{name: "GET /health", kind: pdata.SpanKindServer, route: "/health", duration: 2 * time.Second},
+	))
+	assertClassification(t, spans.At(0), true, "slow", "checkout")
+	assertClassification(t, spans.At(1), false, "fast", "api")
+	assertClassification(t, spans.At(2), true, "slow", "api")
+	assertNotClassified(t, spans.At(3))
+
+	// The checkout route only applies to the frontend.
+	spans = processTraces(t, cfg, newTraces("backend",
+		testSpan{name: "POST", kind: pdata.SpanKindServer, route: "/api/checkout", duration: 500 * time.Millisecond},
+	))
+	assertClassification(t, spans.At(0), false, "fast", "api")
+}
+
+func TestProcessTracesOverwrites(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Default = &ObjectiveConfig{Threshold: time.Second}
+
+	td := newTraces("frontend", testSpan{name: "GET", kind: pdata.SpanKindServer, duration: 2 * time.Second})
+	attrs := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+	attrs.InsertBool("slo.violated", false)
+	attrs.InsertString("latency.bucket", "fast")
+
+	spans := processTraces(t, cfg, td)
+	assertClassification(t, spans.At(0), true, "slow", "")
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Window is how long a span is remembered after it was first received.
+	// Identical spans received within the window are dropped.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxEntries limits the number of spans remembered at the same time.
+	// The oldest spans are forgotten before the end of the window when the
+	// limit is reached.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if cfg.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if cfg.MaxEntries <= 0 {
+		return errors.New("max_entries must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// spanHasher hashes the content of spans, so that a span resent with the
+// same IDs but updated content is not taken for a duplicate. The resource
+// and instrumentation library are not hashed since processors of other
+// Collectors the span went through may have changed them.
+type spanHasher struct {
+	h   hash.Hash64
+	buf [8]byte
+}
+
+func newSpanHasher() *spanHasher {
+	return &spanHasher{h: fnv.New64a()}
+}
+
+func (s *spanHasher) hashSpan(span pdata.Span) uint64 {
+	s.h.Reset()
+	parentSpanID := span.ParentSpanID().Bytes()
+	s.writeBytes(parentSpanID[:])
+	s.writeString(string(span.TraceState()))
+	s.writeString(span.Name())
+	s.writeUint64(uint64(span.Kind()))
+	s.writeUint64(uint64(span.StartTimestamp()))
+	s.writeUint64(uint64(span.EndTimestamp()))
+	s.writeUint64(uint64(span.Status().Code()))
+	s.writeString(span.Status().Message())
+	s.writeAttributes(span.Attributes())
+
+	events := span.Events()
+	s.writeUint64(uint64(events.Len()))
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		s.writeString(event.Name())
+		s.writeUint64(uint64(event.Timestamp()))
+		s.writeAttributes(event.Attributes())
+	}
+
+	links := span.Links()
+	s.writeUint64(uint64(links.Len()))
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		traceID, spanID := link.TraceID().Bytes(), link.SpanID().Bytes()
+		s.writeBytes(traceID[:])
+		s.writeBytes(spanID[:])
+		s.writeAttributes(link.Attributes())
+	}
+	return s.h.Sum64()
+}
+
+// writeAttributes hashes the attributes ordered by key, since their order
+// does not matter.
+func (s *spanHasher) writeAttributes(attrs pdata.AttributeMap) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	s.writeUint64(uint64(len(keys)))
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		s.writeString(k)
+		s.writeValue(v)
+	}
+}
+
+func (s *spanHasher) writeValue(v pdata.AttributeValue) {
+	s.writeUint64(uint64(v.Type()))
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		s.writeString(v.StringVal())
+	case pdata.AttributeValueTypeInt:
+		s.writeUint64(uint64(v.IntVal()))
+	case pdata.AttributeValueTypeDouble:
+		s.writeUint64(math.Float64bits(v.DoubleVal()))
+	case pdata.AttributeValueTypeBool:
+		if v.BoolVal() {
+			s.writeUint64(1)
+		} else {
+			s.writeUint64(0)
+		}
+	case pdata.AttributeValueTypeBytes:
+		s.writeUint64(uint64(len(v.BytesVal())))
+		s.writeBytes(v.BytesVal())
+	case pdata.AttributeValueTypeMap:
+		s.writeAttributes(v.MapVal())
+	case pdata.AttributeValueTypeArray:
+		values := v.ArrayVal()
+		s.writeUint64(uint64(values.Len()))
+		for i := 0; i < values.Len(); i++ {
+			s.writeValue(values.At(i))
+		}
+	}
+}
+
+// writeString hashes the length of the string before its content, so that
+// consecutive strings cannot be confused.
+func (s *spanHasher) writeString(str string) {
+	s.writeUint64(uint64(len(str)))
+	_, _ = s.h.Write([]byte(str))
+}
+
+func (s *spanHasher) writeBytes(b []byte) {
+	_, _ = s.h.Write(b)
+}
+
+func (s *spanHasher) writeUint64(v uint64) {
+	binary.LittleEndian.PutUint64(s.buf[:], v)
+	_, _ = s.h.Write(s.buf[:])
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	mDuplicateSpans = stats.Int64("duplicate_spans", "Number of spans dropped because they were already received", stats.UnitDimensionless)
+	mEarlyEvictions = stats.Int64("early_evictions", "Number of spans forgotten before the end of the window because max_entries was reached", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views of the processor.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mDuplicateSpans.Name()),
+			Measure:     mDuplicateSpans,
+			Description: mDuplicateSpans.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mEarlyEvictions.Name()),
+			Measure:     mEarlyEvictions,
+			Description: mEarlyEvictions.Description(),
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// spanKey identifies a span received: two spans with the same IDs and the
+// same content hash are duplicates.
+type spanKey struct {
+	traceID [16]byte
+	spanID  [8]byte
+	hash    uint64
+}
+
+// entry is a span remembered until its expiry.
+type entry struct {
+	key     spanKey
+	expires time.Time
+}
+
+type spanDedupProcessor struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Traces
+	window       time.Duration
+	maxEntries   int
+	now          func() time.Time
+
+	mu     sync.Mutex
+	hasher *spanHasher
+	seen   map[spanKey]*list.Element
+	// order holds the entries by expiry, the oldest first.
+	order *list.List
+}
+
+var _ component.TracesProcessor = (*spanDedupProcessor)(nil)
+
+func newSpanDedupProcessor(cfg *Config, logger *zap.Logger, nextConsumer consumer.Traces) *spanDedupProcessor {
+	return &spanDedupProcessor{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		window:       cfg.Window,
+		maxEntries:   cfg.MaxEntries,
+		now:          time.Now,
+		hasher:       newSpanHasher(),
+		seen:         make(map[spanKey]*list.Element),
+		order:        list.New(),
+	}
+}
+
+// Start does nothing.
+func (p *spanDedupProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown does nothing.
+func (p *spanDedupProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *spanDedupProcessor) Capabilities() consumer.Capabilities {
+	return processorCapabilities
+}
+
+// ConsumeTraces drops the duplicate spans of td and passes the rest to the
+// next consumer. The spans of a batch that the next consumer fails to process
+// are forgotten, so that the retry of the batch is not dropped as duplicates.
+func (p *spanDedupProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	recorded := p.processTraces(ctx, td)
+	if td.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	if err := p.nextConsumer.ConsumeTraces(ctx, td); err != nil {
+		p.forget(recorded)
+		return err
+	}
+	return nil
+}
+
+// processTraces removes the duplicate spans from td and records the others,
+// which it returns the entries of.
+func (p *spanDedupProcessor) processTraces(ctx context.Context, td pdata.Traces) []*list.Element {
+	p.mu.Lock()
+	now := p.now()
+	p.expire(now)
+	duplicates, evicted := 0, 0
+	var recorded []*list.Element
+	td.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				key := spanKey{
+					traceID: span.TraceID().Bytes(),
+					spanID:  span.SpanID().Bytes(),
+					hash:    p.hasher.hashSpan(span),
+				}
+				if _, ok := p.seen[key]; ok {
+					duplicates++
+					return true
+				}
+				if p.order.Len() >= p.maxEntries {
+					p.remove(p.order.Front())
+					evicted++
+				}
+				e := p.order.PushBack(&entry{key: key, expires: now.Add(p.window)})
+				p.seen[key] = e
+				recorded = append(recorded, e)
+				return false
+			})
+			return ils.Spans().Len() == 0
+		})
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	p.mu.Unlock()
+
+	if duplicates > 0 {
+		stats.Record(ctx, mDuplicateSpans.M(int64(duplicates)))
+		p.logger.Debug("Dropped duplicate spans", zap.Int("count", duplicates))
+	}
+	if evicted > 0 {
+		stats.Record(ctx, mEarlyEvictions.M(int64(evicted)))
+	}
+	return recorded
+}
+
+// forget removes the entries recorded for a batch that the next consumer
+// failed to process, unless they already expired or were evicted.
+func (p *spanDedupProcessor) forget(recorded []*list.Element) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range recorded {
+		if p.seen[e.Value.(*entry).key] == e {
+			p.remove(e)
+		}
+	}
+}
+
+// expire forgets the spans whose window ended.
+func (p *spanDedupProcessor) expire(now time.Time) {
+	for e := p.order.Front(); e != nil && !e.Value.(*entry).expires.After(now); e = p.order.Front() {
+		p.remove(e)
+	}
+}
+
+func (p *spanDedupProcessor) remove(e *list.Element) {
+	delete(p.seen, e.Value.(*entry).key)
+	p.order.Remove(e)
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spandedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type testSpan struct {
+	traceID byte
+	spanID  byte
+	name    string
+}
+
+func newTraces(spans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ss := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, s := range spans {
+		span := ss.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{s.traceID}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{s.spanID}))
+		span.SetName(s.name)
+		span.SetStartTimestamp(1000)
+		span.SetEndTimestamp(2000)
+		span.Attributes().InsertString("http.method", "GET")
+		span.Attributes().InsertInt("http.status_code", 200)
+	}
+	return td
+}
+
+func spanNames(td pdata.Traces) []string {
+	var names []string
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				names = append(names, spans.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func newTestProcessor(window time.Duration, maxEntries int) (*spanDedupProcessor, *consumertest.TracesSink, *time.Time) {
+	now := time.Date(2021, 10, 5, 10, 0, 0, 0, time.UTC)
+	sink := new(consumertest.TracesSink)
+	p := newSpanDedupProcessor(&Config{Window: window, MaxEntries: maxEntries}, zap.NewNop(), sink)
+	p.now = func() time.Time { return now }
+	return p, sink, &now
+}
+
+// lastNames returns the names of the spans last passed to the sink.
+func lastNames(sink *consumertest.TracesSink) []string {
+	all := sink.AllTraces()
+	return spanNames(all[len(all)-1])
+}
+
+func TestConsumeTracesDropsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	p, sink, _ := newTestProcessor(time.Minute, 100)
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"}, testSpan{1, 2, "b"}, testSpan{1, 1, "a"})))
+	assert.Equal(t, []string{"a", "b"}, lastNames(sink))
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 2, "b"}, testSpan{2, 2, "b"})))
+	assert.Equal(t, []string{"b"}, lastNames(sink))
+	assert.Equal(t, 1, sink.AllTraces()[1].ResourceSpans().Len())
+
+	// Batches left without spans are not passed on.
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"})))
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
+func TestConsumeTracesKeepsUpdatedSpans(t *testing.T) {
+	ctx := context.Background()
+	p, sink, _ := newTestProcessor(time.Minute, 100)
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"})))
+
+	updated := newTraces(testSpan{1, 1, "a"})
+	span := updated.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Status().SetCode(pdata.StatusCodeError)
+	require.NoError(t, p.ConsumeTraces(ctx, updated))
+	assert.Equal(t, []string{"a"}, lastNames(sink))
+
+	// The order of attributes and the resource do not matter.
+	reordered := pdata.NewTraces()
+	rs := reordered.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("host.name", "gateway-2")
+	span = rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	newTraces(testSpan{1, 1, "a"}).ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).CopyTo(span)
+	span.Attributes().Clear()
+	span.Attributes().InsertInt("http.status_code", 200)
+	span.Attributes().InsertString("http.method", "GET")
+	require.NoError(t, p.ConsumeTraces(ctx, reordered))
+	assert.Len(t, sink.AllTraces(), 2)
+}
+
+func TestConsumeTracesWindow(t *testing.T) {
+	ctx := context.Background()
+	p, sink, now := newTestProcessor(time.Minute, 100)
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"})))
+	*now = now.Add(30 * time.Second)
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 2, "b"})))
+
+	*now = now.Add(30 * time.Second)
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"}, testSpan{1, 2, "b"})))
+	assert.Equal(t, []string{"a"}, lastNames(sink))
+	assert.Equal(t, 2, p.order.Len())
+	assert.Len(t, p.seen, 2)
+}
+
+func TestConsumeTracesMaxEntries(t *testing.T) {
+	ctx := context.Background()
+	p, sink, _ := newTestProcessor(time.Minute, 2)
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"}, testSpan{1, 2, "b"}, testSpan{1, 3, "c"})))
+	assert.Len(t, p.seen, 2)
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"}, testSpan{1, 3, "c"})))
+	assert.Equal(t, []string{"a"}, lastNames(sink))
+}
+
+func TestConsumeTracesRetryAfterError(t *testing.T) {
+	ctx := context.Background()
+	p, sink, _ := newTestProcessor(time.Minute, 100)
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"})))
+
+	p.nextConsumer = consumertest.NewErr(errors.New("backend unavailable"))
+	err := p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"}, testSpan{1, 2, "b"}))
+	assert.EqualError(t, err, "backend unavailable")
+	assert.Len(t, p.seen, 1)
+
+	// The retry passes the spans of the failed batch, but not the span received before.
+	p.nextConsumer = sink
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, "a"}, testSpan{1, 2, "b"})))
+	assert.Equal(t, []string{"b"}, lastNames(sink))
+	assert.Len(t, p.seen, 2)
+}
@@ -28,6 +28,9 @@ const (
 	AlwaysSample PolicyType = "always_sample"
 	// Latency sample traces that are longer than a given threshold.
 	Latency PolicyType = "latency"
+	// LatencyOutlier sample traces that have a span much slower than the recent
+	// spans of the same service and route.
+	LatencyOutlier PolicyType = "latency_outlier"
 	// NumericAttribute sample traces that have a given numeric attribute in a specified
 	// range, e.g.: attribute "http.status_code" >= 399 and <= 999.
 	NumericAttribute PolicyType = "numeric_attribute"
@@ -50,6 +53,8 @@ type PolicyCfg struct {
 	Type PolicyType `mapstructure:"type"`
 	// Configs for latency filter sampling policy evaluator.
 	LatencyCfg LatencyCfg `mapstructure:"latency"`
+	// Configs for latency outlier sampling policy evaluator.
+	LatencyOutlierCfg LatencyOutlierCfg `mapstructure:"latency_outlier"`
 	// Configs for numeric attribute filter sampling policy evaluator.
 	NumericAttributeCfg NumericAttributeCfg `mapstructure:"numeric_attribute"`
 	// Configs for probabilistic sampling policy evaluator.
@@ -69,6 +74,28 @@ type LatencyCfg struct {
 	ThresholdMs int64 `mapstructure:"threshold_ms"`
 }
 
+// LatencyOutlierCfg holds the configurable settings to create a latency outlier
+// sampling policy evaluator. Each pair of service and route has a baseline, the
+// percentile of the recent durations of its spans.
+type LatencyOutlierCfg struct {
+	// RouteAttributes are the span attributes identifying the route of a span,
+	// the first one found being used. The span name is used if none is found.
+	RouteAttributes []string `mapstructure:"route_attributes"`
+	// Percentile of the span durations used as baseline. Defaults to 95.
+	Percentile float64 `mapstructure:"percentile"`
+	// Multiplier of the baseline above which spans are sampled. Defaults to 2.
+	Multiplier float64 `mapstructure:"multiplier"`
+	// HalfLife is the duration after which the weight of a span in the baseline
+	// is halved. Defaults to 10 minutes.
+	HalfLife time.Duration `mapstructure:"half_life"`
+	// MinSpans is the number of spans of a route observed before its baseline
+	// is used. Defaults to 100.
+	MinSpans int `mapstructure:"min_spans"`
+	// MaxRoutes is the maximum number of baselines kept, the least recently
+	// seen routes being evicted. Defaults to 10000.
+	MaxRoutes int `mapstructure:"max_routes"`
+}
+
 // NumericAttributeCfg holds the configurable settings to create a numeric attribute filter
 // sampling policy evaluator.
 type NumericAttributeCfg struct {
@@ -78,6 +78,18 @@ func TestLoadConfig(t *testing.T) {
 					Type:            RateLimiting,
 					RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35},
 				},
+				{
+					Name: "test-policy-8",
+					Type: LatencyOutlier,
+					LatencyOutlierCfg: LatencyOutlierCfg{
+						RouteAttributes: []string{"http.route", "rpc.method"},
+						Percentile:      99,
+						Multiplier:      3,
+						HalfLife:        5 * time.Minute,
+						MinSpans:        50,
+						MaxRoutes:       1000,
+					},
+				},
 			},
 		})
 }
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultOutlierPercentile = 95
+	defaultOutlierMultiplier = 2
+	defaultOutlierHalfLife   = 10 * time.Minute
+	defaultOutlierMinSpans   = 100
+	defaultOutlierMaxRoutes  = 10000
+
+	// bucketGrowth is the ratio between the bounds of consecutive buckets of
+	// the baselines, bounding their relative error to 10%.
+	bucketGrowth = 1.1
+	// maxForwardWeight is the weight of observations above which the
+	// weights of a baseline are rescaled, to keep them in the range of
+	// float64.
+	maxForwardWeight = 1 << 40
+	// serviceNameKey is the resource attribute of the service of spans.
+	serviceNameKey = "service.name"
+)
+
+var logBucketGrowth = math.Log(bucketGrowth)
+
+type latencyOutlier struct {
+	logger          *zap.Logger
+	routeAttributes []string
+	percentile      float64
+	multiplier      float64
+	halfLife        time.Duration
+	minSpans        int
+	now             func() time.Time
+
+	mu        sync.Mutex
+	baselines *lru.Cache
+}
+
+var _ PolicyEvaluator = (*latencyOutlier)(nil)
+
+// NewLatencyOutlier creates a policy evaluator sampling traces with a span
+// slower than multiplier times the percentile of the recent durations of the
+// spans of the same service and route. The durations are weighted with an
+// exponential decay of the given half-life, and the baseline of a route is
+// used once minSpans spans were observed. Zero values are replaced by
+// defaults.
+func NewLatencyOutlier(logger *zap.Logger, routeAttributes []string, percentile, multiplier float64, halfLife time.Duration, minSpans, maxRoutes int) (PolicyEvaluator, error) {
+	if percentile == 0 {
+		percentile = defaultOutlierPercentile
+	}
+	if multiplier == 0 {
+		multiplier = defaultOutlierMultiplier
+	}
+	if halfLife == 0 {
+		halfLife = defaultOutlierHalfLife
+	}
+	if minSpans == 0 {
+		minSpans = defaultOutlierMinSpans
+	}
+	if maxRoutes == 0 {
+		maxRoutes = defaultOutlierMaxRoutes
+	}
+
+	switch {
+	case percentile <= 0 || percentile >= 100:
+		return nil, errors.New("percentile must be between 0 and 100")
+	case multiplier <= 0:
+		return nil, errors.New("multiplier must be positive")
+	case halfLife < 0:
+		return nil, errors.New("half_life must be positive")
+	case minSpans < 0:
+		return nil, errors.New("min_spans must be positive")
+	case maxRoutes < 0:
+		return nil, errors.New("max_routes must be positive")
+	}
+
+	return &latencyOutlier{
+		logger:          logger,
+		routeAttributes: routeAttributes,
+		percentile:      percentile,
+		multiplier:      multiplier,
+		halfLife:        halfLife,
+		minSpans:        minSpans,
+		now:             time.Now,
+		baselines:       lru.New(maxRoutes),
+	}, nil
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (lo *latencyOutlier) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	lo.logger.Debug("Triggering action for late arriving spans in latency outlier filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+// All the spans update the baselines of their routes after being compared to
+// them, so that outliers don't raise the baselines they're compared to.
+func (lo *latencyOutlier) Evaluate(_ pdata.TraceID, traceData *TraceData) (Decision, error) {
+	lo.logger.Debug("Evaluating spans in latency outlier filter")
+
+	traceData.Lock()
+	batches := traceData.ReceivedBatches
+	traceData.Unlock()
+
+	now := lo.now()
+	lo.mu.Lock()
+	defer lo.mu.Unlock()
+
+	decision := NotSampled
+	for _, batch := range batches {
+		rss := batch.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			var service string
+			if v, ok := rs.Resource().Attributes().Get(serviceNameKey); ok {
+				service = v.StringVal()
+			}
+			ilss := rs.InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					if lo.observe(service, spans.At(k), now) {
+						decision = Sampled
+					}
+				}
+			}
+		}
+	}
+	return decision, nil
+}
+
+// observe adds the duration of a span to the baseline of its route and
+// returns whether it was an outlier of the baseline.
+func (lo *latencyOutlier) observe(service string, span pdata.Span, now time.Time) bool {
+	key := service + "\x00" + lo.route(span)
+	var b *baseline
+	if v, ok := lo.baselines.Get(key); ok {
+		b = v.(*baseline)
+	} else {
+		b = &baseline{landmark: now}
+		lo.baselines.Add(key, b)
+	}
+
+	duration := span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime())
+	outlier := b.count >= lo.minSpans && float64(duration) > lo.multiplier*b.quantile(lo.percentile/100)
+	b.add(duration, now, lo.halfLife)
+	return outlier
+}
+
+// route returns the value of the first route attribute of the span, or its
+// name if it has none.
+func (lo *latencyOutlier) route(span pdata.Span) string {
+	for _, key := range lo.routeAttributes {
+		if v, ok := span.Attributes().Get(key); ok {
+			return v.AsString()
+		}
+	}
+	return span.Name()
+}
+
+// baseline is an exponentially decayed histogram of durations, whose buckets
+// grow exponentially. Decay is applied forward: observations are weighted
+// by 2^((t - landmark) / halfLife) rather than decaying the former ones, and
+// the weights are rescaled when they grow too large.
+type baseline struct {
+	// buckets holds the weights of the buckets from index offset, bucket i
+	// holding the durations in (bucketGrowth^(i-1), bucketGrowth^i]
+	// nanoseconds.
+	buckets  []float64
+	offset   int
+	total    float64
+	count    int
+	landmark time.Time
+}
+
+func (b *baseline) add(duration time.Duration, now time.Time, halfLife time.Duration) {
+	weight := math.Exp2(float64(now.Sub(b.landmark)) / float64(halfLife))
+	if weight > maxForwardWeight {
+		for i := range b.buckets {
+			b.buckets[i] /= weight
+		}
+		b.total /= weight
+		b.landmark = now
+		weight = 1
+	}
+
+	index := bucketIndex(duration)
+	switch {
+	case len(b.buckets) == 0:
+		b.buckets = []float64{0}
+		b.offset = index
+	case index < b.offset:
+		b.buckets = append(make([]float64, b.offset-index), b.buckets...)
+		b.offset = index
+	case index >= b.offset+len(b.buckets):
+		b.buckets = append(b.buckets, make([]float64, index-b.offset-len(b.buckets)+1)...)
+	}
+	b.buckets[index-b.offset] += weight
+	b.total += weight
+	b.count++
+}
+
+// quantile returns the upper bound, in nanoseconds, of the bucket holding the
+// given quantile of the weighted durations.
+func (b *baseline) quantile(q float64) float64 {
+	rank := q * b.total
+	var cumulative float64
+	for i, w := range b.buckets {
+		cumulative += w
+		if cumulative >= rank {
+			return math.Pow(bucketGrowth, float64(b.offset+i))
+		}
+	}
+	return math.Pow(bucketGrowth, float64(b.offset+len(b.buckets)-1))
+}
+
+func bucketIndex(duration time.Duration) int {
+	if duration <= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log(float64(duration)) / logBucketGrowth))
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func newRouteTrace(service, route string, duration time.Duration) *TraceData {
+	traces := pdata.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", service)
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("HTTP GET")
+	span.Attributes().InsertString("http.route", route)
+	start := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	span.SetStartTimestamp(pdata.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pdata.NewTimestampFromTime(start.Add(duration)))
+	return &TraceData{ReceivedBatches: []pdata.Traces{traces}}
+}
+
+func newTestLatencyOutlier(t *testing.T, minSpans int) (*latencyOutlier, *time.Time) {
+	filter, err := NewLatencyOutlier(zap.NewNop(), []string{"rpc.method", "http.route"}, 95, 2, time.Minute, minSpans, 0)
+	require.NoError(t, err)
+	lo := filter.(*latencyOutlier)
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	lo.now = func() time.Time { return now }
+	return lo, &now
+}
+
+func evaluate(t *testing.T, filter PolicyEvaluator, trace *TraceData) Decision {
+	decision, err := filter.Evaluate(pdata.NewTraceID([16]byte{1}), trace)
+	require.NoError(t, err)
+	return decision
+}
+
+func TestEvaluate_LatencyOutlier(t *testing.T) {
+	filter, _ := newTestLatencyOutlier(t, 10)
+
+	// No trace is sampled while the baseline warms up.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, NotSampled, evaluate(t, filter, newRouteTrace("cart", "/cart", time.Duration(90+i)*time.Millisecond)))
+	}
+	assert.Equal(t, NotSampled, evaluate(t, filter, newRouteTrace("cart", "/cart", 150*time.Millisecond)))
+	assert.Equal(t, Sampled, evaluate(t, filter, newRouteTrace("cart", "/cart", 400*time.Millisecond)))
+
+	// Baselines are per service and route.
+	assert.Equal(t, NotSampled, evaluate(t, filter, newRouteTrace("cart", "/checkout", 300*time.Millisecond)))
+	assert.Equal(t, NotSampled, evaluate(t, filter, newRouteTrace("payment", "/cart", 300*time.Millisecond)))
+}
+
+func TestEvaluate_LatencyOutlierRouteAttributes(t *testing.T) {
+	filter, _ := newTestLatencyOutlier(t, 1)
+	trace := newRouteTrace("cart", "/cart", 10*time.Millisecond)
+	span := trace.ReceivedBatches[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, "/cart", filter.route(span))
+
+	span.Attributes().InsertString("rpc.method", "GetCart")
+	assert.Equal(t, "GetCart", filter.route(span))
+
+	span.Attributes().Clear()
+	assert.Equal(t, "HTTP GET", filter.route(span))
+}
+
+func TestEvaluate_LatencyOutlierDecay(t *testing.T) {
+	filter, now := newTestLatencyOutlier(t, 10)
+	for i := 0; i < 100; i++ {
+		evaluate(t, filter, newRouteTrace("cart", "/cart", 100*time.Millisecond))
+	}
+	assert.Equal(t, Sampled, evaluate(t, filter, newRouteTrace("cart", "/cart", 500*time.Millisecond)))
+
+	// The route became slower, old durations fade out after a few
+	// half-lives.
+	for i := 0; i < 100; i++ {
+		*now = now.Add(6 * time.Second)
+		evaluate(t, filter, newRouteTrace("cart", "/cart", 400*time.Millisecond))
+	}
+	assert.Equal(t, NotSampled, evaluate(t, filter, newRouteTrace("cart", "/cart", 500*time.Millisecond)))
+}
+
+func TestEvaluate_LatencyOutlierEviction(t *testing.T) {
+	filter, err := NewLatencyOutlier(zap.NewNop(), []string{"http.route"}, 0, 0, 0, 1, 1)
+	require.NoError(t, err)
+	evaluate(t, filter, newRouteTrace("cart", "/cart", 100*time.Millisecond))
+	evaluate(t, filter, newRouteTrace("cart", "/checkout", 100*time.Millisecond))
+	assert.Equal(t, Sampled, evaluate(t, filter, newRouteTrace("cart", "/checkout", time.Second)))
+	// The baseline of /cart was evicted.
+	assert.Equal(t, NotSampled, evaluate(t, filter, newRouteTrace("cart", "/cart", time.Second)))
+}
+
+func TestNewLatencyOutlierErrors(t *testing.T) {
+	_, err := NewLatencyOutlier(zap.NewNop(), nil, 100, 0, 0, 0, 0)
+	assert.EqualError(t, err, "percentile must be between 0 and 100")
+	_, err = NewLatencyOutlier(zap.NewNop(), nil, 0, -1, 0, 0, 0)
+	assert.EqualError(t, err, "multiplier must be positive")
+	_, err = NewLatencyOutlier(zap.NewNop(), nil, 0, 0, -time.Minute, 0, 0)
+	assert.EqualError(t, err, "half_life must be positive")
+}
+
+func TestBaselineQuantile(t *testing.T) {
+	b := &baseline{landmark: time.Unix(0, 0)}
+	for i := 1; i <= 100; i++ {
+		b.add(time.Duration(i)*time.Millisecond, b.landmark, time.Minute)
+	}
+	assert.InDelta(t, float64(95*time.Millisecond), b.quantile(0.95), float64(10*time.Millisecond))
+	assert.InDelta(t, float64(50*time.Millisecond), b.quantile(0.5), float64(5*time.Millisecond))
+
+	// Weights are rescaled when they grow too large.
+	b.add(time.Second, b.landmark.Add(time.Hour), time.Minute)
+	assert.Less(t, b.total, float64(maxForwardWeight))
+	assert.InDelta(t, float64(time.Second), b.quantile(0.5), float64(100*time.Millisecond))
+}
@@ -120,6 +120,9 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case Latency:
 		lfCfg := cfg.LatencyCfg
 		return sampling.NewLatency(logger, lfCfg.ThresholdMs), nil
+	case LatencyOutlier:
+		loCfg := cfg.LatencyOutlierCfg
+		return sampling.NewLatencyOutlier(logger, loCfg.RouteAttributes, loCfg.Percentile, loCfg.Multiplier, loCfg.HalfLife, loCfg.MinSpans, loCfg.MaxRoutes)
 	case NumericAttribute:
 		nafCfg := cfg.NumericAttributeCfg
 		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompletenessprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Wait is how long the spans of a trace are held after its first span
+	// was received, before the trace is annotated and released.
+	Wait time.Duration `mapstructure:"wait"`
+
+	// MaxTraces limits the number of traces held at the same time. The
+	// oldest traces are released before the end of their wait when the
+	// limit is reached.
+	MaxTraces int `mapstructure:"max_traces"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if cfg.Wait <= 0 {
+		return errors.New("wait must be positive")
+	}
+	if cfg.MaxTraces <= 0 {
+		return errors.New("max_traces must be positive")
+	}
+	return nil
+}
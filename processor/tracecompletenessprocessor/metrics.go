@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompletenessprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	mCompleteTraces   = stats.Int64("complete_traces", "Number of traces released with a single root and no missing parent", stats.UnitDimensionless)
+	mIncompleteTraces = stats.Int64("incomplete_traces", "Number of traces released with a missing root or parent span", stats.UnitDimensionless)
+	mEarlyReleases    = stats.Int64("early_releases", "Number of traces released before the end of the wait because max_traces was reached", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views of the processor.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mCompleteTraces.Name()),
+			Measure:     mCompleteTraces,
+			Description: mCompleteTraces.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mIncompleteTraces.Name()),
+			Measure:     mIncompleteTraces,
+			Description: mIncompleteTraces.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mEarlyReleases.Name()),
+			Measure:     mEarlyReleases,
+			Description: mEarlyReleases.Description(),
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompletenessprocessor
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
+)
+
+const (
+	// attrSpanCount is the number of spans received for the trace.
+	attrSpanCount = "trace.span_count"
+	// attrIsComplete tells whether the trace has a single root and every
+	// other span has its parent in the trace.
+	attrIsComplete = "trace.is_complete"
+	// attrOrphanSpanCount is the number of spans whose parent wasn't received.
+	attrOrphanSpanCount = "trace.orphan_span_count"
+
+	// maxTickInterval bounds how late after the end of its wait a trace
+	// is released.
+	maxTickInterval = time.Second
+)
+
+// heldTrace holds the spans received for a trace until its release.
+type heldTrace struct {
+	traceID   pdata.TraceID
+	releaseAt time.Time
+	batch     pdata.Traces
+}
+
+type traceCompletenessProcessor struct {
+	logger       *zap.Logger
+	nextConsumer consumer.Traces
+	wait         time.Duration
+	maxTraces    int
+	now          func() time.Time
+
+	mu     sync.Mutex
+	traces map[pdata.TraceID]*list.Element
+	// order holds the traces by release time, the first to release first.
+	order *list.List
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+var _ component.TracesProcessor = (*traceCompletenessProcessor)(nil)
+
+func newTraceCompletenessProcessor(cfg *Config, logger *zap.Logger, nextConsumer consumer.Traces) *traceCompletenessProcessor {
+	return &traceCompletenessProcessor{
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		wait:         cfg.Wait,
+		maxTraces:    cfg.MaxTraces,
+		now:          time.Now,
+		traces:       make(map[pdata.TraceID]*list.Element),
+		order:        list.New(),
+	}
+}
+
+// ConsumeTraces holds the spans until the end of the wait of their trace.
+func (p *traceCompletenessProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	var early []*heldTrace
+	p.mu.Lock()
+	now := p.now()
+	for _, single := range batchpersignal.SplitTraces(td) {
+		traceID := single.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID()
+		if e, ok := p.traces[traceID]; ok {
+			single.ResourceSpans().MoveAndAppendTo(e.Value.(*heldTrace).batch.ResourceSpans())
+			continue
+		}
+		if p.order.Len() >= p.maxTraces {
+			early = append(early, p.remove(p.order.Front()))
+		}
+		p.traces[traceID] = p.order.PushBack(&heldTrace{
+			traceID:   traceID,
+			releaseAt: now.Add(p.wait),
+			batch:     single,
+		})
+	}
+	p.mu.Unlock()
+
+	if len(early) > 0 {
+		stats.Record(ctx, mEarlyReleases.M(int64(len(early))))
+	}
+	return p.release(ctx, early)
+}
+
+// Capabilities implements the consumer interface.
+func (p *traceCompletenessProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// Start starts releasing the traces at the end of their wait.
+func (p *traceCompletenessProcessor) Start(context.Context, component.Host) error {
+	p.stopCh = make(chan struct{})
+	p.doneCh = make(chan struct{})
+	interval := p.wait
+	if interval > maxTickInterval {
+		interval = maxTickInterval
+	}
+	go p.run(interval)
+	return nil
+}
+
+// Shutdown releases all the traces still held.
+func (p *traceCompletenessProcessor) Shutdown(ctx context.Context) error {
+	if p.stopCh == nil {
+		return nil
+	}
+	close(p.stopCh)
+	<-p.doneCh
+
+	p.mu.Lock()
+	var all []*heldTrace
+	for e := p.order.Front(); e != nil; e = p.order.Front() {
+		all = append(all, p.remove(e))
+	}
+	p.mu.Unlock()
+	return p.release(ctx, all)
+}
+
+func (p *traceCompletenessProcessor) run(interval time.Duration) {
+	defer close(p.doneCh)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			if err := p.releaseExpired(context.Background()); err != nil {
+				p.logger.Warn("Failed to send the annotated traces", zap.Error(err))
+			}
+		}
+	}
+}
+
+// releaseExpired releases the traces whose wait ended.
+func (p *traceCompletenessProcessor) releaseExpired(ctx context.Context) error {
+	p.mu.Lock()
+	now := p.now()
+	var expired []*heldTrace
+	for e := p.order.Front(); e != nil && !e.Value.(*heldTrace).releaseAt.After(now); e = p.order.Front() {
+		expired = append(expired, p.remove(e))
+	}
+	p.mu.Unlock()
+	return p.release(ctx, expired)
+}
+
+func (p *traceCompletenessProcessor) remove(e *list.Element) *heldTrace {
+	ht := e.Value.(*heldTrace)
+	delete(p.traces, ht.traceID)
+	p.order.Remove(e)
+	return ht
+}
+
+// release annotates the traces and sends them to the next consumer in a
+// single batch.
+func (p *traceCompletenessProcessor) release(ctx context.Context, traces []*heldTrace) error {
+	if len(traces) == 0 {
+		return nil
+	}
+	td := pdata.NewTraces()
+	complete := 0
+	for _, ht := range traces {
+		if annotate(ht.batch) {
+			complete++
+		}
+		ht.batch.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	stats.Record(ctx, mCompleteTraces.M(int64(complete)), mIncompleteTraces.M(int64(len(traces)-complete)))
+	return p.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+// annotate sets the completeness attributes on the root spans of the trace,
+// or on its orphan spans when the root is missing, and returns whether the
+// trace is complete.
+func annotate(trace pdata.Traces) bool {
+	var spans []pdata.Span
+	spanIDs := make(map[pdata.SpanID]struct{})
+	rss := trace.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ss := ilss.At(j).Spans()
+			for k := 0; k < ss.Len(); k++ {
+				spans = append(spans, ss.At(k))
+				spanIDs[ss.At(k).SpanID()] = struct{}{}
+			}
+		}
+	}
+
+	var roots, orphans []pdata.Span
+	for _, span := range spans {
+		if span.ParentSpanID().IsEmpty() {
+			roots = append(roots, span)
+		} else if _, ok := spanIDs[span.ParentSpanID()]; !ok {
+			orphans = append(orphans, span)
+		}
+	}
+
+	isComplete := len(roots) == 1 && len(orphans) == 0
+	targets := roots
+	if len(targets) == 0 {
+		targets = orphans
+	}
+	for _, span := range targets {
+		attrs := span.Attributes()
+		attrs.UpsertInt(attrSpanCount, int64(len(spans)))
+		attrs.UpsertBool(attrIsComplete, isComplete)
+		attrs.UpsertInt(attrOrphanSpanCount, int64(len(orphans)))
+	}
+	return isComplete
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompletenessprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type testSpan struct {
+	traceID  byte
+	spanID   byte
+	parentID byte
+	name     string
+}
+
+func newTraces(spans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ss := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, s := range spans {
+		span := ss.AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{s.traceID}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{s.spanID}))
+		if s.parentID != 0 {
+			span.SetParentSpanID(pdata.NewSpanID([8]byte{s.parentID}))
+		}
+		span.SetName(s.name)
+	}
+	return td
+}
+
+// spansByName returns the spans of all the batches received by the sink.
+func spansByName(sink *consumertest.TracesSink) map[string]pdata.Span {
+	spans := make(map[string]pdata.Span)
+	for _, td := range sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ilss := rss.At(i).InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ss := ilss.At(j).Spans()
+				for k := 0; k < ss.Len(); k++ {
+					spans[ss.At(k).Name()] = ss.At(k)
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func assertAnnotated(t *testing.T, span pdata.Span, spanCount int64, isComplete bool, orphanCount int64) {
+	count, ok := span.Attributes().Get(attrSpanCount)
+	require.True(t, ok, "%s isn't annotated", span.Name())
+	assert.Equal(t, spanCount, count.IntVal())
+	complete, ok := span.Attributes().Get(attrIsComplete)
+	require.True(t, ok)
+	assert.Equal(t, isComplete, complete.BoolVal())
+	orphans, ok := span.Attributes().Get(attrOrphanSpanCount)
+	require.True(t, ok)
+	assert.Equal(t, orphanCount, orphans.IntVal())
+}
+
+func assertNotAnnotated(t *testing.T, span pdata.Span) {
+	_, ok := span.Attributes().Get(attrSpanCount)
+	assert.False(t, ok, "%s is annotated", span.Name())
+}
+
+func newTestProcessor(wait time.Duration, maxTraces int) (*traceCompletenessProcessor, *consumertest.TracesSink, *time.Time) {
+	now := time.Date(2021, 10, 5, 10, 0, 0, 0, time.UTC)
+	sink := new(consumertest.TracesSink)
+	p := newTraceCompletenessProcessor(&Config{Wait: wait, MaxTraces: maxTraces}, zap.NewNop(), sink)
+	p.now = func() time.Time { return now }
+	return p, sink, &now
+}
+
+func TestCompleteTrace(t *testing.T) {
+	p, sink, now := newTestProcessor(10*time.Second, 100)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 2, 1, "child"}, testSpan{1, 3, 2, "grandchild"})))
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root"})))
+
+	// The wait starts with the first span of the trace.
+	*now = now.Add(4 * time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+	assert.Equal(t, 0, sink.SpanCount())
+
+	*now = now.Add(time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+	require.Equal(t, 3, sink.SpanCount())
+	require.Len(t, sink.AllTraces(), 1)
+
+	spans := spansByName(sink)
+	assertAnnotated(t, spans["root"], 3, true, 0)
+	assertNotAnnotated(t, spans["child"])
+	assertNotAnnotated(t, spans["grandchild"])
+}
+
+func TestMissingParent(t *testing.T) {
+	p, sink, now := newTestProcessor(time.Second, 100)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root"}, testSpan{1, 3, 2, "orphan"})))
+	*now = now.Add(time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+
+	spans := spansByName(sink)
+	assertAnnotated(t, spans["root"], 2, false, 1)
+	assertNotAnnotated(t, spans["orphan"])
+}
+
+func TestMissingRoot(t *testing.T) {
+	p, sink, now := newTestProcessor(time.Second, 100)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(
+		testSpan{1, 2, 1, "orphan-1"},
+		testSpan{1, 3, 1, "orphan-2"},
+		testSpan{1, 4, 3, "child"},
+	)))
+	*now = now.Add(time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+
+	// Without a root, the spans whose parent is missing are annotated.
+	spans := spansByName(sink)
+	assertAnnotated(t, spans["orphan-1"], 3, false, 2)
+	assertAnnotated(t, spans["orphan-2"], 3, false, 2)
+	assertNotAnnotated(t, spans["child"])
+}
+
+func TestSeveralRoots(t *testing.T) {
+	p, sink, now := newTestProcessor(time.Second, 100)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root-1"}, testSpan{1, 2, 0, "root-2"})))
+	*now = now.Add(time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+
+	spans := spansByName(sink)
+	assertAnnotated(t, spans["root-1"], 2, false, 0)
+	assertAnnotated(t, spans["root-2"], 2, false, 0)
+}
+
+func TestTracesAreHeldSeparately(t *testing.T) {
+	p, sink, now := newTestProcessor(10*time.Second, 100)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root-1"}, testSpan{2, 2, 1, "child-2"})))
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{3, 3, 0, "root-3"}, testSpan{2, 1, 0, "root-2"})))
+
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+	spans := spansByName(sink)
+	assert.Len(t, spans, 3)
+	assertAnnotated(t, spans["root-1"], 1, true, 0)
+	assertAnnotated(t, spans["root-2"], 2, true, 0)
+	assertNotAnnotated(t, spans["child-2"])
+
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, p.releaseExpired(ctx))
+	assertAnnotated(t, spansByName(sink)["root-3"], 1, true, 0)
+	assert.Empty(t, p.traces)
+}
+
+func TestMaxTracesReleasesOldest(t *testing.T) {
+	p, sink, _ := newTestProcessor(time.Minute, 2)
+	ctx := context.Background()
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root-1"})))
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{2, 1, 0, "root-2"})))
+	assert.Equal(t, 0, sink.SpanCount())
+
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{3, 1, 0, "root-3"})))
+	spans := spansByName(sink)
+	require.Len(t, spans, 1)
+	assertAnnotated(t, spans["root-1"], 1, true, 0)
+	assert.Len(t, p.traces, 2)
+}
+
+func TestShutdownReleasesAll(t *testing.T) {
+	p, sink, _ := newTestProcessor(time.Hour, 100)
+	ctx := context.Background()
+
+	require.NoError(t, p.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root-1"}, testSpan{2, 1, 0, "root-2"})))
+	assert.Equal(t, 0, sink.SpanCount())
+
+	require.NoError(t, p.Shutdown(ctx))
+	assert.Equal(t, 2, sink.SpanCount())
+	assert.Empty(t, p.traces)
+}
+
+func TestStartReleasesExpiredTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	p := newTraceCompletenessProcessor(&Config{Wait: 10 * time.Millisecond, MaxTraces: 100}, zap.NewNop(), sink)
+	ctx := context.Background()
+
+	require.NoError(t, p.Start(ctx, componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, p.Shutdown(ctx)) }()
+	require.NoError(t, p.ConsumeTraces(ctx, newTraces(testSpan{1, 1, 0, "root"})))
+	assert.Eventually(t, func() bool { return sink.SpanCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedsamplerprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Attribute is the key of the attribute whose values are tracked, looked
+	// up in the attributes of the span or log record, then of the resource.
+	Attribute string `mapstructure:"attribute"`
+
+	// TargetRate is the number of items per second kept for each value of
+	// the attribute. The values seen less often are kept entirely.
+	TargetRate float64 `mapstructure:"target_rate"`
+
+	// Window is the period over which the rate of each value is measured.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxValues limits the number of values tracked. The values seen once
+	// the limit is reached share a single rate until the values not seen for
+	// a window are forgotten.
+	MaxValues int `mapstructure:"max_values"`
+
+	// HashSeed allows choosing the seed of the hash of the trace IDs, the
+	// same seed must be used by all the collectors sampling the same traces.
+	HashSeed uint32 `mapstructure:"hash_seed"`
+
+	// AdjustedCountAttribute is the attribute set to the number of items
+	// each sampled item represents, when sampled with a probability lower
+	// than 1. Not set when empty.
+	AdjustedCountAttribute string `mapstructure:"adjusted_count_attribute"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (cfg *Config) Validate() error {
+	if cfg.Attribute == "" {
+		return errors.New("attribute must be specified")
+	}
+	if cfg.TargetRate <= 0 {
+		return errors.New("target_rate must be positive")
+	}
+	if cfg.Window < time.Second {
+		return errors.New("window must be at least 1s")
+	}
+	if cfg.MaxValues <= 0 {
+		return errors.New("max_values must be positive")
+	}
+	return nil
+}
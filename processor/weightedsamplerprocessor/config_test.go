@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedsamplerprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Attribute = "http.route"
+	assert.Equal(t, defaultCfg, cfg.Processors[config.NewComponentID(typeStr)])
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+		Attribute:         "service.name",
+		TargetRate:        2.5,
+		Window:            30 * time.Second,
+		MaxValues:         500,
+		HashSeed:          42,
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{
+			name: "no attribute",
+			cfg:  Config{TargetRate: 1, Window: time.Minute, MaxValues: 10},
+			err:  "attribute must be specified",
+		},
+		{
+			name: "no target_rate",
+			cfg:  Config{Attribute: "a", Window: time.Minute, MaxValues: 10},
+			err:  "target_rate must be positive",
+		},
+		{
+			name: "short window",
+			cfg:  Config{Attribute: "a", TargetRate: 1, Window: time.Millisecond, MaxValues: 10},
+			err:  "window must be at least 1s",
+		},
+		{
+			name: "no max_values",
+			cfg:  Config{Attribute: "a", TargetRate: 1, Window: time.Minute},
+			err:  "max_values must be positive",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, test.cfg.Validate(), test.err)
+		})
+	}
+}
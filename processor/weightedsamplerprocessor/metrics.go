@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedsamplerprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	mDroppedSpans      = stats.Int64("dropped_spans", "Number of spans dropped by sampling", stats.UnitDimensionless)
+	mDroppedLogRecords = stats.Int64("dropped_log_records", "Number of log records dropped by sampling", stats.UnitDimensionless)
+	mOverflowItems     = stats.Int64("overflow_items", "Number of items whose value was not tracked because max_values was reached", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views of the processor.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mDroppedSpans.Name()),
+			Measure:     mDroppedSpans,
+			Description: mDroppedSpans.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mDroppedLogRecords.Name()),
+			Measure:     mDroppedLogRecords,
+			Description: mDroppedLogRecords.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mOverflowItems.Name()),
+			Measure:     mOverflowItems,
+			Description: mOverflowItems.Description(),
+			Aggregation: view.Sum(),
+		},
+	}
+}
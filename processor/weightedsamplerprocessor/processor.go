@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedsamplerprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type weightedSampler struct {
+	cfg  *Config
+	now  func() time.Time
+	seed [4]byte
+
+	mu    sync.Mutex
+	rates *rateTracker
+	rand  *rand.Rand
+}
+
+func newWeightedSampler(cfg *Config) *weightedSampler {
+	s := &weightedSampler{
+		cfg:   cfg,
+		now:   time.Now,
+		rates: newRateTracker(cfg.Window, cfg.MaxValues),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	binary.BigEndian.PutUint32(s.seed[:], cfg.HashSeed)
+	return s
+}
+
+// batchStats counts the items dropped and not tracked in a batch.
+type batchStats struct {
+	dropped  int64
+	overflow int64
+}
+
+func (s *weightedSampler) processTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	var bs batchStats
+	now := s.now()
+
+	s.mu.Lock()
+	td.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resource := rs.Resource().Attributes()
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				p := s.probability(span.Attributes(), resource, now, &bs)
+				if !s.sampledTrace(span.TraceID(), p) {
+					bs.dropped++
+					return true
+				}
+				s.setAdjustedCount(span.Attributes(), p)
+				return false
+			})
+			return ils.Spans().Len() == 0
+		})
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	s.mu.Unlock()
+
+	s.record(ctx, bs, mDroppedSpans)
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (s *weightedSampler) processLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	var bs batchStats
+	now := s.now()
+
+	s.mu.Lock()
+	ld.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		resource := rl.Resource().Attributes()
+		rl.InstrumentationLibraryLogs().RemoveIf(func(ill pdata.InstrumentationLibraryLogs) bool {
+			ill.Logs().RemoveIf(func(lr pdata.LogRecord) bool {
+				p := s.probability(lr.Attributes(), resource, now, &bs)
+				var sampled bool
+				if lr.TraceID().IsEmpty() {
+					sampled = p >= 1 || s.rand.Float64() < p
+				} else {
+					// Keep the logs of the traces sampled with the same
+					// probability.
+					sampled = s.sampledTrace(lr.TraceID(), p)
+				}
+				if !sampled {
+					bs.dropped++
+					return true
+				}
+				s.setAdjustedCount(lr.Attributes(), p)
+				return false
+			})
+			return ill.Logs().Len() == 0
+		})
+		return rl.InstrumentationLibraryLogs().Len() == 0
+	})
+	s.mu.Unlock()
+
+	s.record(ctx, bs, mDroppedLogRecords)
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// probability counts the item and returns the probability it is sampled
+// with, given the rate of its value. Items without the attribute are
+// sampled as an empty value.
+func (s *weightedSampler) probability(attrs, resource pdata.AttributeMap, now time.Time, bs *batchStats) float64 {
+	var value string
+	if v, ok := attrs.Get(s.cfg.Attribute); ok {
+		value = v.AsString()
+	} else if v, ok := resource.Get(s.cfg.Attribute); ok {
+		value = v.AsString()
+	}
+
+	rate, tracked := s.rates.observe(value, now)
+	if !tracked {
+		bs.overflow++
+	}
+	if rate <= s.cfg.TargetRate {
+		return 1
+	}
+	return s.cfg.TargetRate / rate
+}
+
+// sampledTrace decides whether the item of the trace is sampled by hashing
+// the trace ID, so that the items of a trace sampled with the same
+// probability are all kept or all dropped, in every collector.
+func (s *weightedSampler) sampledTrace(traceID pdata.TraceID, p float64) bool {
+	if p >= 1 {
+		return true
+	}
+	id := traceID.Bytes()
+	h := fnv.New32a()
+	_, _ = h.Write(s.seed[:])
+	_, _ = h.Write(id[:])
+	return float64(h.Sum32()) < p*(math.MaxUint32+1.0)
+}
+
+func (s *weightedSampler) setAdjustedCount(attrs pdata.AttributeMap, p float64) {
+	if s.cfg.AdjustedCountAttribute == "" || p >= 1 {
+		return
+	}
+	attrs.UpsertDouble(s.cfg.AdjustedCountAttribute, 1/p)
+}
+
+func (s *weightedSampler) record(ctx context.Context, bs batchStats, dropped *stats.Int64Measure) {
+	if bs.dropped > 0 {
+		stats.Record(ctx, dropped.M(bs.dropped))
+	}
+	if bs.overflow > 0 {
+		stats.Record(ctx, mOverflowItems.M(bs.overflow))
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedsamplerprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestSampler(targetRate float64, maxValues int) (*weightedSampler, *time.Time) {
+	now := time.Date(2021, 10, 5, 10, 0, 0, 0, time.UTC)
+	s := newWeightedSampler(&Config{
+		Attribute:              "http.route",
+		TargetRate:             targetRate,
+		Window:                 10 * time.Second,
+		MaxValues:              maxValues,
+		AdjustedCountAttribute: "sampling.adjusted_count",
+	})
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func traceID(i int) pdata.TraceID {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[8:], uint64(i)*0x9e3779b97f4a7c15)
+	return pdata.NewTraceID(id)
+}
+
+// newTraces returns count spans of each route, each in its own trace.
+func newTraces(count int, routes ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, route := range routes {
+		for i := 0; i < count; i++ {
+			span := spans.AppendEmpty()
+			span.SetTraceID(traceID(spans.Len()))
+			span.SetName(route)
+			if route != "" {
+				span.Attributes().InsertString("http.route", route)
+			}
+		}
+	}
+	return td
+}
+
+func countSpans(td pdata.Traces) map[string]int {
+	counts := map[string]int{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				counts[spans.At(k).Name()]++
+			}
+		}
+	}
+	return counts
+}
+
+func TestProcessTracesKeepsRareValues(t *testing.T) {
+	s, _ := newTestSampler(10, 100)
+
+	td := newTraces(5, "/rare")
+	td = appendTraces(td, newTraces(2000, "/health"))
+	td, err := s.processTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	counts := countSpans(td)
+	assert.Equal(t, 5, counts["/rare"])
+	// The rate of /health reaches 2000/s, sampled down towards 10/s.
+	assert.Less(t, counts["/health"], 200)
+	assert.Greater(t, counts["/health"], 0)
+}
+
+func appendTraces(dst, src pdata.Traces) pdata.Traces {
+	src.ResourceSpans().MoveAndAppendTo(dst.ResourceSpans())
+	return dst
+}
+
+func TestProcessTracesAdjustedCount(t *testing.T) {
+	s, _ := newTestSampler(10, 100)
+
+	td, err := s.processTraces(context.Background(), newTraces(1000, "/health"))
+	require.NoError(t, err)
+
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	var withCount int
+	for i := 0; i < spans.Len(); i++ {
+		v, ok := spans.At(i).Attributes().Get("sampling.adjusted_count")
+		if !ok {
+			continue
+		}
+		withCount++
+		assert.GreaterOrEqual(t, v.DoubleVal(), 1.0)
+	}
+	// The first 10 spans are within the target rate and kept as is.
+	assert.Equal(t, spans.Len()-10, withCount)
+}
+
+func TestSampledTraceConsistent(t *testing.T) {
+	s, _ := newTestSampler(10, 100)
+	other := newWeightedSampler(&Config{HashSeed: 1})
+
+	var sampled, differ int
+	for i := 0; i < 1000; i++ {
+		id := traceID(i)
+		low := s.sampledTrace(id, 0.1)
+		// The same trace gets the same decision with the same probability,
+		// and stays sampled with a higher one.
+		assert.Equal(t, low, s.sampledTrace(id, 0.1))
+		if low {
+			sampled++
+			assert.True(t, s.sampledTrace(id, 0.5))
+		}
+		if low != other.sampledTrace(id, 0.1) {
+			differ++
+		}
+	}
+	assert.InDelta(t, 100, sampled, 40)
+	assert.Greater(t, differ, 0)
+}
+
+func TestProcessTracesMissingAttribute(t *testing.T) {
+	s, _ := newTestSampler(10, 100)
+
+	td, err := s.processTraces(context.Background(), newTraces(5, ""))
+	require.NoError(t, err)
+	assert.Equal(t, 5, countSpans(td)[""])
+	assert.Contains(t, s.rates.values, "")
+}
+
+func TestProcessTracesResourceAttribute(t *testing.T) {
+	s, _ := newTestSampler(10, 100)
+	s.cfg.Attribute = "service.name"
+
+	_, err := s.processTraces(context.Background(), newTraces(1, "/a"))
+	require.NoError(t, err)
+	assert.Contains(t, s.rates.values, "checkout")
+}
+
+func TestProcessTracesSkipsEmpty(t *testing.T) {
+	s, _ := newTestSampler(1, 100)
+
+	_, err := s.processTraces(context.Background(), newTraces(1000, "/health"))
+	require.NoError(t, err)
+
+	// The rate of the previous window keeps the sampling low in the next
+	// one.
+	dropped := 0
+	for i := 0; i < 20; i++ {
+		td, err := s.processTraces(context.Background(), newTraces(1, "/health"))
+		if err == processorhelper.ErrSkipProcessingData {
+			assert.Equal(t, 0, td.ResourceSpans().Len())
+			dropped++
+			continue
+		}
+		require.NoError(t, err)
+	}
+	assert.Greater(t, dropped, 0)
+}
+
+func TestProcessTracesOverflow(t *testing.T) {
+	s, _ := newTestSampler(10, 2)
+
+	td, err := s.processTraces(context.Background(), newTraces(1, "/a", "/b", "/c", "/d"))
+	require.NoError(t, err)
+	assert.Len(t, countSpans(td), 4)
+	assert.Len(t, s.rates.values, 2)
+	assert.Equal(t, int64(2), s.rates.overflow.current)
+}
+
+func TestProcessLogs(t *testing.T) {
+	s, _ := newTestSampler(10, 100)
+
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < 1000; i++ {
+		lr := logs.AppendEmpty()
+		lr.Attributes().InsertString("http.route", "/health")
+		if i%2 == 0 {
+			lr.SetTraceID(traceID(i))
+		}
+	}
+	rare := logs.AppendEmpty()
+	rare.SetName("rare")
+	rare.Attributes().InsertString("http.route", "/rare")
+
+	ld, err := s.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	logs = ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Less(t, logs.Len(), 200)
+	assert.Equal(t, "rare", logs.At(logs.Len()-1).Name())
+}
+
+func TestRateTracker(t *testing.T) {
+	now := time.Date(2021, 10, 5, 10, 0, 0, 0, time.UTC)
+	rt := newRateTracker(10*time.Second, 10)
+
+	var rate float64
+	for i := 0; i < 100; i++ {
+		rate, _ = rt.observe("a", now)
+	}
+	// Measured over at least a second.
+	assert.Equal(t, 100.0, rate)
+
+	now = now.Add(5 * time.Second)
+	rate, _ = rt.observe("a", now)
+	assert.InDelta(t, 101.0/5, rate, 0.001)
+
+	// The next window starts with the rate of the previous one.
+	now = now.Add(6 * time.Second)
+	rate, _ = rt.observe("a", now)
+	assert.InDelta(t, 10.1, rate, 0.001)
+	assert.Equal(t, now.Add(-time.Second), rt.windowStart)
+
+	// Values not seen for a whole window are forgotten.
+	rt.observe("b", now)
+	now = now.Add(10 * time.Second)
+	rt.observe("a", now)
+	assert.Contains(t, rt.values, "b")
+	now = now.Add(10 * time.Second)
+	rt.observe("a", now)
+	assert.NotContains(t, rt.values, "b")
+
+	// An idle window resets the rates.
+	now = now.Add(25 * time.Second)
+	rate, _ = rt.observe("a", now)
+	assert.InDelta(t, 1.0/6, rate, 0.001)
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedsamplerprocessor
+
+import "time"
+
+// valueCounts counts the items of a value in the current and previous
+// windows.
+type valueCounts struct {
+	current  int64
+	previous int64
+}
+
+// rateTracker measures the rate of each value of the attribute over fixed
+// windows. The rate of a value is the highest of its rate in the previous
+// window and its rate so far in the current window, so that the sampling
+// adapts quickly when a value becomes more frequent.
+type rateTracker struct {
+	window    time.Duration
+	maxValues int
+
+	windowStart time.Time
+	values      map[string]*valueCounts
+	// overflow counts the items of the values not tracked once maxValues is
+	// reached.
+	overflow valueCounts
+}
+
+func newRateTracker(window time.Duration, maxValues int) *rateTracker {
+	return &rateTracker{
+		window:    window,
+		maxValues: maxValues,
+		values:    make(map[string]*valueCounts),
+	}
+}
+
+// observe counts an item of the value and returns the rate of the value in
+// items per second, and whether the value is tracked on its own.
+func (t *rateTracker) observe(value string, now time.Time) (float64, bool) {
+	t.rotate(now)
+
+	tracked := true
+	counts, ok := t.values[value]
+	if !ok {
+		if len(t.values) < t.maxValues {
+			counts = &valueCounts{}
+			t.values[value] = counts
+		} else {
+			counts = &t.overflow
+			tracked = false
+		}
+	}
+	counts.current++
+
+	// A rate measured over less than a second is too noisy.
+	elapsed := now.Sub(t.windowStart)
+	if elapsed < time.Second {
+		elapsed = time.Second
+	}
+	rate := float64(counts.current) / elapsed.Seconds()
+	if previous := float64(counts.previous) / t.window.Seconds(); previous > rate {
+		rate = previous
+	}
+	return rate, tracked
+}
+
+// rotate starts a new window when the current one is over, forgetting the
+// values not seen during the last window.
+func (t *rateTracker) rotate(now time.Time) {
+	if t.windowStart.IsZero() {
+		t.windowStart = now
+		return
+	}
+	elapsed := now.Sub(t.windowStart)
+	if elapsed < t.window {
+		return
+	}
+	// When a whole window passed without any item, the previous window is
+	// empty.
+	idle := elapsed >= 2*t.window
+	for value, counts := range t.values {
+		counts.rotate(idle)
+		if counts.previous == 0 {
+			delete(t.values, value)
+		}
+	}
+	t.overflow.rotate(idle)
+	t.windowStart = t.windowStart.Add(elapsed.Truncate(t.window))
+}
+
+func (c *valueCounts) rotate(idle bool) {
+	c.previous = c.current
+	if idle {
+		c.previous = 0
+	}
+	c.current = 0
+}
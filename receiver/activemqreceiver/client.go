@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activemqreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// jolokiaPath is the path of the Jolokia API in the web console of ActiveMQ.
+const jolokiaPath = "/api/jolokia/"
+
+// readRequest reads attributes of MBeans with the Jolokia API.
+type readRequest struct {
+	Type      string   `json:"type"`
+	MBean     string   `json:"mbean"`
+	Attribute []string `json:"attribute"`
+}
+
+type readResponse struct {
+	Status    int             `json:"status"`
+	Value     json.RawMessage `json:"value"`
+	Error     string          `json:"error"`
+	ErrorType string          `json:"error_type"`
+}
+
+// jolokiaClient reads MBeans of ActiveMQ brokers from the Jolokia API of
+// their web console.
+type jolokiaClient struct {
+	client   *http.Client
+	endpoint string
+	username string
+	password string
+}
+
+// read returns the attributes of the MBeans matching the pattern, by MBean
+// name. A pattern matching no MBean returns no attributes.
+func (c *jolokiaClient) read(ctx context.Context, pattern string, attributes []string) (map[string]map[string]interface{}, error) {
+	body, err := json.Marshal(readRequest{Type: "read", MBean: pattern, Attribute: attributes})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.endpoint, "/")+jolokiaPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("POST %s returned %d: %s", jolokiaPath, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var rr readResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rr); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of POST %s: %w", jolokiaPath, err)
+	}
+	// Jolokia reports errors in the status of the response body.
+	switch {
+	case rr.Status == http.StatusNotFound && rr.ErrorType == "javax.management.InstanceNotFoundException":
+		return nil, nil
+	case rr.Status != http.StatusOK:
+		return nil, fmt.Errorf("failed to read %s: %d %s", pattern, rr.Status, rr.Error)
+	}
+
+	if !isPattern(pattern) {
+		// The attributes of a single MBean aren't keyed by its name.
+		var attrs map[string]interface{}
+		if err := json.Unmarshal(rr.Value, &attrs); err != nil {
+			return nil, fmt.Errorf("failed to decode the attributes of %s: %w", pattern, err)
+		}
+		return map[string]map[string]interface{}{pattern: attrs}, nil
+	}
+	var mbeans map[string]map[string]interface{}
+	if err := json.Unmarshal(rr.Value, &mbeans); err != nil {
+		return nil, fmt.Errorf("failed to decode the attributes of %s: %w", pattern, err)
+	}
+	return mbeans, nil
+}
+
+func isPattern(mbean string) bool {
+	return strings.ContainsAny(mbean, "*?")
+}
+
+// keyProperties returns the key properties of an MBean name, such as
+// brokerName in org.apache.activemq:type=Broker,brokerName=localhost.
+func keyProperties(mbean string) map[string]string {
+	i := strings.IndexByte(mbean, ':')
+	if i < 0 {
+		return nil
+	}
+	props := map[string]string{}
+	for _, prop := range strings.Split(mbean[i+1:], ",") {
+		kv := strings.SplitN(prop, "=", 2)
+		if len(kv) == 2 {
+			props[kv[0]] = kv[1]
+		}
+	}
+	return props
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activemqreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const (
+	defaultEndpoint   = "http://localhost:8161"
+	defaultBrokerName = "*"
+)
+
+// Config defines the configuration for the ActiveMQ receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+
+	// Username and Password authenticate against the web console of the
+	// broker, which serves the Jolokia API.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// BrokerName is the name of the broker to scrape. It can be a JMX pattern,
+	// all the brokers are scraped by default.
+	BrokerName string `mapstructure:"broker_name"`
+	// IncludeAdvisoryTopics enables the scraping of the ActiveMQ.Advisory
+	// topics the broker uses to publish its events.
+	IncludeAdvisoryTopics bool `mapstructure:"include_advisory_topics"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid endpoint %q: scheme must be http or https", cfg.Endpoint)
+	}
+	if cfg.BrokerName == "" {
+		return errors.New("broker_name must be specified")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activemqreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, "https://activemq.example.com:8162", custom.Endpoint)
+	assert.Equal(t, "/etc/activemq/ca.pem", custom.TLSSetting.CAFile)
+	assert.Equal(t, "admin", custom.Username)
+	assert.Equal(t, "secret", custom.Password)
+	assert.Equal(t, "broker-1", custom.BrokerName)
+	assert.True(t, custom.IncludeAdvisoryTopics)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{name: "default", modify: func(*Config) {}},
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "endpoint must be specified",
+		},
+		{
+			name:   "invalid scheme",
+			modify: func(cfg *Config) { cfg.Endpoint = "localhost:8161" },
+			err:    `invalid endpoint "localhost:8161": scheme must be http or https`,
+		},
+		{
+			name:   "missing broker name",
+			modify: func(cfg *Config) { cfg.BrokerName = "" },
+			err:    "broker_name must be specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
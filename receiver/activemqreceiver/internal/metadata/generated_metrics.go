@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "activemqreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	ActivemqBrokerConnections           MetricIntf
+	ActivemqBrokerUsage                 MetricIntf
+	ActivemqDestinationConsumers        MetricIntf
+	ActivemqDestinationMessagesDequeued MetricIntf
+	ActivemqDestinationMessagesEnqueued MetricIntf
+	ActivemqDestinationMessagesExpired  MetricIntf
+	ActivemqDestinationProducers        MetricIntf
+	ActivemqDestinationSize             MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"activemq.broker.connections",
+		"activemq.broker.usage",
+		"activemq.destination.consumers",
+		"activemq.destination.messages.dequeued",
+		"activemq.destination.messages.enqueued",
+		"activemq.destination.messages.expired",
+		"activemq.destination.producers",
+		"activemq.destination.size",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"activemq.broker.connections":            Metrics.ActivemqBrokerConnections,
+	"activemq.broker.usage":                  Metrics.ActivemqBrokerUsage,
+	"activemq.destination.consumers":         Metrics.ActivemqDestinationConsumers,
+	"activemq.destination.messages.dequeued": Metrics.ActivemqDestinationMessagesDequeued,
+	"activemq.destination.messages.enqueued": Metrics.ActivemqDestinationMessagesEnqueued,
+	"activemq.destination.messages.expired":  Metrics.ActivemqDestinationMessagesExpired,
+	"activemq.destination.producers":         Metrics.ActivemqDestinationProducers,
+	"activemq.destination.size":              Metrics.ActivemqDestinationSize,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"activemq.broker.connections",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.broker.connections")
+			metric.SetDescription("Number of clients connected to the broker.")
+			metric.SetUnit("{connections}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"activemq.broker.usage",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.broker.usage")
+			metric.SetDescription("Percentage of the memory, store and temporary storage limits used by the broker.")
+			metric.SetUnit("%")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"activemq.destination.consumers",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.destination.consumers")
+			metric.SetDescription("Number of consumers of the destination.")
+			metric.SetUnit("{consumers}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"activemq.destination.messages.dequeued",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.destination.messages.dequeued")
+			metric.SetDescription("Number of messages acknowledged by the consumers of the destination since the broker started.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"activemq.destination.messages.enqueued",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.destination.messages.enqueued")
+			metric.SetDescription("Number of messages sent to the destination since the broker started.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"activemq.destination.messages.expired",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.destination.messages.expired")
+			metric.SetDescription("Number of messages of the destination that expired since the broker started.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"activemq.destination.producers",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.destination.producers")
+			metric.SetDescription("Number of producers of the destination.")
+			metric.SetUnit("{producers}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"activemq.destination.size",
+		func(metric pdata.Metric) {
+			metric.SetName("activemq.destination.size")
+			metric.SetDescription("Number of messages waiting in the destination.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Broker (The name of the broker.)
+	Broker string
+	// Destination (The name of the queue or topic.)
+	Destination string
+	// DestinationType (The type of the destination.)
+	DestinationType string
+	// UsageType (The resource whose limit is used.)
+	UsageType string
+}{
+	"activemq.broker",
+	"activemq.destination",
+	"activemq.destination.type",
+	"type",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelDestinationType are the possible values that the label "destination_type" can have.
+var LabelDestinationType = struct {
+	Queue string
+	Topic string
+}{
+	"queue",
+	"topic",
+}
+
+// LabelUsageType are the possible values that the label "usage_type" can have.
+var LabelUsageType = struct {
+	Memory string
+	Store  string
+	Temp   string
+}{
+	"memory",
+	"store",
+	"temp",
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activemqreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/activemqreceiver/internal/metadata"
+)
+
+const (
+	// destinationMetricsCount is the number of metrics recorded from the
+	// destinations.
+	destinationMetricsCount = 6
+	// brokerMetricsCount is the number of metrics recorded from the brokers.
+	brokerMetricsCount = 2
+
+	advisoryTopicPrefix = "ActiveMQ.Advisory."
+)
+
+var (
+	destinationAttributes = []string{"QueueSize", "ConsumerCount", "ProducerCount", "EnqueueCount", "DequeueCount", "ExpiredCount"}
+	brokerAttributes      = []string{"CurrentConnectionsCount", "MemoryPercentUsage", "StorePercentUsage", "TempPercentUsage"}
+
+	// destinationTypes maps the destinationType key property of the
+	// destinations to scrape to the value of their type attribute. Temporary
+	// destinations aren't scraped.
+	destinationTypes = map[string]string{
+		"Queue": metadata.LabelDestinationType.Queue,
+		"Topic": metadata.LabelDestinationType.Topic,
+	}
+)
+
+type activeMQScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	client *jolokiaClient
+}
+
+func newActiveMQScraper(logger *zap.Logger, cfg *Config) *activeMQScraper {
+	return &activeMQScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (s *activeMQScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = &jolokiaClient{
+		client:   httpClient,
+		endpoint: s.cfg.Endpoint,
+		username: s.cfg.Username,
+		password: s.cfg.Password,
+	}
+	return nil
+}
+
+func (s *activeMQScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	now := pdata.NewTimestampFromTime(time.Now())
+	metrics := pdata.NewMetricSlice()
+	var errs scrapererror.ScrapeErrors
+
+	if err := s.scrapeDestinations(ctx, metrics, now); err != nil {
+		errs.AddPartial(destinationMetricsCount, err)
+	}
+	if err := s.scrapeBrokers(ctx, metrics, now); err != nil {
+		errs.AddPartial(brokerMetricsCount, err)
+	}
+
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			return metric.Gauge().DataPoints().Len() == 0
+		case pdata.MetricDataTypeSum:
+			return metric.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+	return metrics, errs.Combine()
+}
+
+// scrapeDestinations records the statistics of the queues and topics of the
+// brokers.
+func (s *activeMQScraper) scrapeDestinations(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	pattern := fmt.Sprintf("org.apache.activemq:type=Broker,brokerName=%s,destinationType=*,destinationName=*", s.cfg.BrokerName)
+	mbeans, err := s.client.read(ctx, pattern, destinationAttributes)
+	if err != nil {
+		return fmt.Errorf("failed to read the destinations: %w", err)
+	}
+
+	size := newMetric(metrics, metadata.M.ActivemqDestinationSize).Sum().DataPoints()
+	consumers := newMetric(metrics, metadata.M.ActivemqDestinationConsumers).Sum().DataPoints()
+	producers := newMetric(metrics, metadata.M.ActivemqDestinationProducers).Sum().DataPoints()
+	enqueued := newMetric(metrics, metadata.M.ActivemqDestinationMessagesEnqueued).Sum().DataPoints()
+	dequeued := newMetric(metrics, metadata.M.ActivemqDestinationMessagesDequeued).Sum().DataPoints()
+	expired := newMetric(metrics, metadata.M.ActivemqDestinationMessagesExpired).Sum().DataPoints()
+	for mbean, attrs := range mbeans {
+		props := keyProperties(mbean)
+		destinationType, ok := destinationTypes[props["destinationType"]]
+		if !ok {
+			continue
+		}
+		destination := props["destinationName"]
+		if destinationType == metadata.LabelDestinationType.Topic && !s.cfg.IncludeAdvisoryTopics &&
+			strings.HasPrefix(destination, advisoryTopicPrefix) {
+			continue
+		}
+		labels := map[string]string{
+			metadata.L.Broker:          props["brokerName"],
+			metadata.L.Destination:     destination,
+			metadata.L.DestinationType: destinationType,
+		}
+		addAttributePoint(size, now, attrs, "QueueSize", labels)
+		addAttributePoint(consumers, now, attrs, "ConsumerCount", labels)
+		addAttributePoint(producers, now, attrs, "ProducerCount", labels)
+		addAttributePoint(enqueued, now, attrs, "EnqueueCount", labels)
+		addAttributePoint(dequeued, now, attrs, "DequeueCount", labels)
+		addAttributePoint(expired, now, attrs, "ExpiredCount", labels)
+	}
+	return nil
+}
+
+// scrapeBrokers records the connections and the resource usage of the
+// brokers.
+func (s *activeMQScraper) scrapeBrokers(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	pattern := fmt.Sprintf("org.apache.activemq:type=Broker,brokerName=%s", s.cfg.BrokerName)
+	mbeans, err := s.client.read(ctx, pattern, brokerAttributes)
+	if err != nil {
+		return fmt.Errorf("failed to read the brokers: %w", err)
+	}
+
+	connections := newMetric(metrics, metadata.M.ActivemqBrokerConnections).Sum().DataPoints()
+	usage := newMetric(metrics, metadata.M.ActivemqBrokerUsage).Gauge().DataPoints()
+	for mbean, attrs := range mbeans {
+		broker := keyProperties(mbean)["brokerName"]
+		addAttributePoint(connections, now, attrs, "CurrentConnectionsCount", map[string]string{metadata.L.Broker: broker})
+		for attribute, usageType := range map[string]string{
+			"MemoryPercentUsage": metadata.LabelUsageType.Memory,
+			"StorePercentUsage":  metadata.LabelUsageType.Store,
+			"TempPercentUsage":   metadata.LabelUsageType.Temp,
+		} {
+			addAttributePoint(usage, now, attrs, attribute, map[string]string{
+				metadata.L.Broker:    broker,
+				metadata.L.UsageType: usageType,
+			})
+		}
+	}
+	return nil
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+// addAttributePoint records the value of an MBean attribute, if it's a
+// number.
+func addAttributePoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, attrs map[string]interface{}, attribute string, labels map[string]string) {
+	value, ok := attrs[attribute].(float64)
+	if !ok {
+		return
+	}
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetIntVal(int64(value))
+	for k, v := range labels {
+		dp.Attributes().InsertString(k, v)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activemqreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
+)
+
+// newFakeJolokia starts a server responding to Jolokia read requests with the
+// response of the MBean pattern, either inline or the name of a file of
+// testdata/jolokia.
+func newFakeJolokia(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if user, password, ok := req.BasicAuth(); !ok || user != "admin" || password != "admin" {
+			http.Error(rw, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if req.Method != http.MethodPost || req.URL.Path != jolokiaPath {
+			http.NotFound(rw, req)
+			return
+		}
+		var rr readRequest
+		if !assert.NoError(t, json.NewDecoder(req.Body).Decode(&rr)) {
+			return
+		}
+		response, ok := responses[rr.MBean]
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+		if strings.HasSuffix(response, ".json") {
+			body, err := ioutil.ReadFile(filepath.Join("testdata", "jolokia", response))
+			if !assert.NoError(t, err) {
+				return
+			}
+			response = string(body)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var defaultResponses = map[string]string{
+	"org.apache.activemq:type=Broker,brokerName=*,destinationType=*,destinationName=*": "destinations.json",
+	"org.apache.activemq:type=Broker,brokerName=*":                                     "brokers.json",
+}
+
+func newTestScraper(t *testing.T, endpoint string) *activeMQScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Username = "admin"
+	cfg.Password = "admin"
+	s := newActiveMQScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func countPoints(metrics pdata.MetricSlice, name string) int {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i).Sum().DataPoints().Len()
+		}
+	}
+	return 0
+}
+
+func metricNames(metrics pdata.MetricSlice) []string {
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	return names
+}
+
+func TestScrape(t *testing.T) {
+	server := newFakeJolokia(t, defaultResponses)
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+
+	orders := map[string]string{"activemq.broker": "localhost", "activemq.destination": "orders", "activemq.destination.type": "queue"}
+	prices := map[string]string{"activemq.broker": "localhost", "activemq.destination": "prices", "activemq.destination.type": "topic"}
+	assert.EqualValues(t, 42, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.size", orders))
+	assert.EqualValues(t, 2, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.consumers", orders))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.producers", orders))
+	assert.EqualValues(t, 1530, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.messages.enqueued", orders))
+	assert.EqualValues(t, 1488, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.messages.dequeued", orders))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.messages.expired", orders))
+	assert.EqualValues(t, 5, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.consumers", prices))
+	assert.EqualValues(t, 3, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.messages.expired", prices))
+	// Advisory topics and temporary queues aren't scraped.
+	assert.Equal(t, 2, countPoints(metrics, "activemq.destination.size"))
+
+	broker := map[string]string{"activemq.broker": "localhost"}
+	assert.EqualValues(t, 7, metricstestutil.FindIntPoint(t, metrics, "activemq.broker.connections", broker))
+	assert.EqualValues(t, 12, metricstestutil.FindIntPoint(t, metrics, "activemq.broker.usage", map[string]string{"activemq.broker": "localhost", "type": "memory"}))
+	assert.EqualValues(t, 3, metricstestutil.FindIntPoint(t, metrics, "activemq.broker.usage", map[string]string{"activemq.broker": "localhost", "type": "store"}))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "activemq.broker.usage", map[string]string{"activemq.broker": "localhost", "type": "temp"}))
+}
+
+func TestScrapeAdvisoryTopics(t *testing.T) {
+	server := newFakeJolokia(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	s.cfg.IncludeAdvisoryTopics = true
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 12, metricstestutil.FindIntPoint(t, metrics, "activemq.destination.messages.enqueued", map[string]string{
+		"activemq.broker":           "localhost",
+		"activemq.destination":      "ActiveMQ.Advisory.Connection",
+		"activemq.destination.type": "topic",
+	}))
+	assert.Equal(t, 3, countPoints(metrics, "activemq.destination.size"))
+}
+
+func TestScrapeSingleBroker(t *testing.T) {
+	server := newFakeJolokia(t, map[string]string{
+		"org.apache.activemq:type=Broker,brokerName=broker-1,destinationType=*,destinationName=*": `{"status":404,"error_type":"javax.management.InstanceNotFoundException","error":"No MBean found"}`,
+		"org.apache.activemq:type=Broker,brokerName=broker-1":                                     `{"status":200,"value":{"CurrentConnectionsCount":3,"MemoryPercentUsage":1,"StorePercentUsage":0,"TempPercentUsage":0}}`,
+	})
+	s := newTestScraper(t, server.URL)
+	s.cfg.BrokerName = "broker-1"
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"activemq.broker.connections", "activemq.broker.usage"}, metricNames(metrics))
+	assert.EqualValues(t, 3, metricstestutil.FindIntPoint(t, metrics, "activemq.broker.connections", map[string]string{"activemq.broker": "broker-1"}))
+}
+
+func TestScrapeJolokiaError(t *testing.T) {
+	server := newFakeJolokia(t, map[string]string{
+		"org.apache.activemq:type=Broker,brokerName=*,destinationType=*,destinationName=*": `{"status":403,"error_type":"java.lang.Exception","error":"Reading attribute QueueSize is forbidden"}`,
+		"org.apache.activemq:type=Broker,brokerName=*":                                     "brokers.json",
+	})
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.EqualError(t, err, "failed to read the destinations: failed to read org.apache.activemq:type=Broker,brokerName=*,destinationType=*,destinationName=*: 403 Reading attribute QueueSize is forbidden")
+	assert.Equal(t, []string{"activemq.broker.connections", "activemq.broker.usage"}, metricNames(metrics))
+}
+
+func TestScrapeUnauthorized(t *testing.T) {
+	server := newFakeJolokia(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	s.client.password = "wrong"
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read the destinations: POST /api/jolokia/ returned 401: Unauthorized")
+	assert.Contains(t, err.Error(), "failed to read the brokers: POST /api/jolokia/ returned 401: Unauthorized")
+	assert.Equal(t, 0, metrics.Len())
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+// dagRun is a DAG run in the stable REST API of Airflow.
+type dagRun struct {
+	DAGID           string     `json:"dag_id"`
+	DAGRunID        string     `json:"dag_run_id"`
+	State           string     `json:"state"`
+	ExecutionDate   *time.Time `json:"execution_date"`
+	StartDate       *time.Time `json:"start_date"`
+	EndDate         *time.Time `json:"end_date"`
+	ExternalTrigger bool       `json:"external_trigger"`
+}
+
+type dagRunCollection struct {
+	DAGRuns      []dagRun `json:"dag_runs"`
+	TotalEntries int      `json:"total_entries"`
+}
+
+// dagRunsQuery is the body of the batch request listing the DAG runs of all
+// the DAGs.
+type dagRunsQuery struct {
+	PageOffset int        `json:"page_offset,omitempty"`
+	PageLimit  int        `json:"page_limit,omitempty"`
+	States     []string   `json:"states,omitempty"`
+	EndDateGTE *time.Time `json:"end_date_gte,omitempty"`
+	EndDateLTE *time.Time `json:"end_date_lte,omitempty"`
+	OrderBy    string     `json:"order_by,omitempty"`
+}
+
+// taskInstance is a task instance in the stable REST API of Airflow.
+type taskInstance struct {
+	TaskID    string     `json:"task_id"`
+	DAGID     string     `json:"dag_id"`
+	State     string     `json:"state"`
+	StartDate *time.Time `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+	TryNumber int64      `json:"try_number"`
+	MaxTries  int64      `json:"max_tries"`
+	Operator  string     `json:"operator"`
+	Pool      string     `json:"pool"`
+	Queue     string     `json:"queue"`
+	Hostname  string     `json:"hostname"`
+}
+
+type taskInstanceCollection struct {
+	TaskInstances []taskInstance `json:"task_instances"`
+	TotalEntries  int            `json:"total_entries"`
+}
+
+// taskInstancesQuery is the body of the batch request listing the task
+// instances of all the DAG runs.
+type taskInstancesQuery struct {
+	State      []string   `json:"state,omitempty"`
+	EndDateGTE *time.Time `json:"end_date_gte,omitempty"`
+	EndDateLTE *time.Time `json:"end_date_lte,omitempty"`
+}
+
+// airflowClient reads DAG runs and task instances from the stable REST API
+// of Airflow.
+type airflowClient struct {
+	client   *pollingsaas.Client
+	endpoint string
+	pageSize int
+}
+
+// dagRuns lists the DAG runs of all the DAGs matching the query, fetching
+// all the pages.
+func (c *airflowClient) dagRuns(ctx context.Context, query dagRunsQuery) ([]dagRun, error) {
+	query.PageLimit = c.pageSize
+	var runs []dagRun
+	for {
+		page, err := c.dagRunsPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, page.DAGRuns...)
+		query.PageOffset += len(page.DAGRuns)
+		if len(page.DAGRuns) == 0 || query.PageOffset >= page.TotalEntries {
+			return runs, nil
+		}
+	}
+}
+
+// dagRunsPage lists a single page of the DAG runs matching the query.
+func (c *airflowClient) dagRunsPage(ctx context.Context, query dagRunsQuery) (*dagRunCollection, error) {
+	page := &dagRunCollection{}
+	err := c.do(ctx, http.MethodPost, "/api/v1/dags/~/dagRuns/list", query, page)
+	return page, err
+}
+
+// taskInstances lists the task instances of all the DAG runs matching the
+// query.
+func (c *airflowClient) taskInstances(ctx context.Context, query taskInstancesQuery) ([]taskInstance, error) {
+	collection := &taskInstanceCollection{}
+	err := c.do(ctx, http.MethodPost, "/api/v1/dags/~/dagRuns/~/taskInstances/list", query, collection)
+	return collection.TaskInstances, err
+}
+
+// dagRunTaskInstances lists the task instances of a DAG run, fetching all
+// the pages.
+func (c *airflowClient) dagRunTaskInstances(ctx context.Context, run dagRun) ([]taskInstance, error) {
+	path := fmt.Sprintf("/api/v1/dags/%s/dagRuns/%s/taskInstances", url.PathEscape(run.DAGID), url.PathEscape(run.DAGRunID))
+	var tasks []taskInstance
+	for {
+		params := url.Values{
+			"limit":  {strconv.Itoa(c.pageSize)},
+			"offset": {strconv.Itoa(len(tasks))},
+		}
+		page := &taskInstanceCollection{}
+		if err := c.do(ctx, http.MethodGet, path+"?"+params.Encode(), nil, page); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.TaskInstances...)
+		if len(page.TaskInstances) == 0 || len(tasks) >= page.TotalEntries {
+			return tasks, nil
+		}
+	}
+}
+
+func (c *airflowClient) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.endpoint, "/")+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of %s: %w", path, err)
+	}
+	return nil
+}
+
+// basicAuth authenticates the requests with the username and password of an
+// Airflow user.
+type basicAuth struct {
+	username, password string
+}
+
+var _ pollingsaas.Authenticator = (*basicAuth)(nil)
+
+func (a *basicAuth) Authorize(_ context.Context, _ *http.Client, req *http.Request) error {
+	req.SetBasicAuth(a.username, a.password)
+	return nil
+}
+
+func (a *basicAuth) Invalidate() {}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const defaultEndpoint = "http://localhost:8080"
+
+// Config defines the configuration for the Airflow receiver.
+type Config struct {
+	// ScraperControllerSettings configure how often the metrics are scraped.
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+	// Settings configure how often the finished DAG runs are polled to be
+	// sent as traces, and where the position of the last one is persisted.
+	pollingsaas.Settings `mapstructure:",squash"`
+
+	// Username and Password are the credentials of the Airflow user the
+	// receiver authenticates as, with the basic_auth API authentication
+	// backend. The user only needs to read DAG runs and task instances.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// InitialLookback is how far back to look for finished DAG runs the
+	// first time the receiver starts.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+
+	// PageSize is the number of DAG runs fetched per request.
+	PageSize int `mapstructure:"page_size"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid endpoint %q: scheme must be http or https", cfg.Endpoint)
+	}
+	if (cfg.Username == "") != (cfg.Password == "") {
+		return errors.New("username and password must be specified together")
+	}
+	if cfg.InitialLookback <= 0 {
+		return errors.New("initial_lookback must be positive")
+	}
+	if cfg.PageSize <= 0 {
+		return errors.New("page_size must be positive")
+	}
+	return cfg.Settings.Validate()
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, "https://airflow.example.com", custom.Endpoint)
+	assert.Equal(t, "otel", custom.Username)
+	assert.Equal(t, "s3cr3t", custom.Password)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, 2*time.Minute, custom.PollInterval)
+	assert.Equal(t, "file_storage", custom.Storage)
+	assert.Equal(t, 2.0, custom.RequestsPerSecond)
+	assert.Equal(t, 5, custom.MaxRetries)
+	assert.Equal(t, 24*time.Hour, custom.InitialLookback)
+	assert.Equal(t, 50, custom.PageSize)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "endpoint must be specified",
+		},
+		{
+			name:   "invalid scheme",
+			modify: func(cfg *Config) { cfg.Endpoint = "localhost:8080" },
+			err:    `invalid endpoint "localhost:8080": scheme must be http or https`,
+		},
+		{
+			name:   "username without password",
+			modify: func(cfg *Config) { cfg.Username = "otel" },
+			err:    "username and password must be specified together",
+		},
+		{
+			name:   "no initial lookback",
+			modify: func(cfg *Config) { cfg.InitialLookback = 0 },
+			err:    "initial_lookback must be positive",
+		},
+		{
+			name:   "no page size",
+			modify: func(cfg *Config) { cfg.PageSize = 0 },
+			err:    "page_size must be positive",
+		},
+		{
+			name:   "invalid polling settings",
+			modify: func(cfg *Config) { cfg.PollInterval = 0 },
+			err:    "poll_interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
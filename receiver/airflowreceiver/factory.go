@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+//go:generate mdatagen metadata.yaml
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const (
+	typeStr = "airflow"
+
+	defaultTimeout         = 30 * time.Second
+	defaultPollInterval    = time.Minute
+	defaultInitialLookback = time.Hour
+	defaultPageSize        = 100
+)
+
+// NewFactory creates a factory for the Airflow receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	settings := pollingsaas.NewDefaultSettings()
+	settings.PollInterval = defaultPollInterval
+	return &Config{
+		ScraperControllerSettings: scraperhelper.DefaultScraperControllerSettings(typeStr),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: defaultEndpoint,
+			Timeout:  defaultTimeout,
+		},
+		Settings:        settings,
+		InitialLookback: defaultInitialLookback,
+		PageSize:        defaultPageSize,
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	rConf config.Receiver,
+	consumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	cfg := rConf.(*Config)
+
+	scraper := newAirflowScraper(params.Logger, cfg, newAuthenticator(cfg))
+
+	return scraperhelper.NewScraperControllerReceiver(
+		&cfg.ScraperControllerSettings, params.Logger, consumer,
+		scraperhelper.AddScraper(scraperhelper.NewMetricsScraper(typeStr, scraper.scrape, scraperhelper.WithStart(scraper.start))),
+	)
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	rConf config.Receiver,
+	consumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	cfg := rConf.(*Config)
+	source := newDAGRunSource(cfg)
+	return pollingsaas.NewTracesReceiver(cfg.ID(), typeStr, cfg.Settings, cfg.HTTPClientSettings, newAuthenticator(cfg), source, consumer, params), nil
+}
+
+// newAuthenticator returns the authenticator of the requests to Airflow, nil
+// if no credentials are configured.
+func newAuthenticator(cfg *Config) pollingsaas.Authenticator {
+	if cfg.Username == "" {
+		return nil
+	}
+	return &basicAuth{username: cfg.Username, password: cfg.Password}
+}
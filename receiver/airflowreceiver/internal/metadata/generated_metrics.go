@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "airflowreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	AirflowDagRunActive         MetricIntf
+	AirflowDagRunDuration       MetricIntf
+	AirflowDagRunFinished       MetricIntf
+	AirflowTaskInstanceActive   MetricIntf
+	AirflowTaskInstanceFinished MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"airflow.dag_run.active",
+		"airflow.dag_run.duration",
+		"airflow.dag_run.finished",
+		"airflow.task_instance.active",
+		"airflow.task_instance.finished",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"airflow.dag_run.active":         Metrics.AirflowDagRunActive,
+	"airflow.dag_run.duration":       Metrics.AirflowDagRunDuration,
+	"airflow.dag_run.finished":       Metrics.AirflowDagRunFinished,
+	"airflow.task_instance.active":   Metrics.AirflowTaskInstanceActive,
+	"airflow.task_instance.finished": Metrics.AirflowTaskInstanceFinished,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"airflow.dag_run.active",
+		func(metric pdata.Metric) {
+			metric.SetName("airflow.dag_run.active")
+			metric.SetDescription("Number of DAG runs queued or running.")
+			metric.SetUnit("{runs}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"airflow.dag_run.duration",
+		func(metric pdata.Metric) {
+			metric.SetName("airflow.dag_run.duration")
+			metric.SetDescription("Duration of the last DAG run that finished since the previous scrape.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"airflow.dag_run.finished",
+		func(metric pdata.Metric) {
+			metric.SetName("airflow.dag_run.finished")
+			metric.SetDescription("Number of DAG runs that finished since the receiver started.")
+			metric.SetUnit("{runs}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"airflow.task_instance.active",
+		func(metric pdata.Metric) {
+			metric.SetName("airflow.task_instance.active")
+			metric.SetDescription("Number of task instances scheduled, queued, running or waiting for a retry or a reschedule.")
+			metric.SetUnit("{tasks}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"airflow.task_instance.finished",
+		func(metric pdata.Metric) {
+			metric.SetName("airflow.task_instance.finished")
+			metric.SetDescription("Number of task instances that finished since the receiver started.")
+			metric.SetUnit("{tasks}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// DagID (The ID of the DAG.)
+	DagID string
+	// DagRunState (The state of the DAG runs.)
+	DagRunState string
+	// TaskState (The state of the task instances.)
+	TaskState string
+}{
+	"airflow.dag_id",
+	"state",
+	"state",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelDagRunState are the possible values that the label "dag_run_state" can have.
+var LabelDagRunState = struct {
+	Queued  string
+	Running string
+	Success string
+	Failed  string
+}{
+	"queued",
+	"running",
+	"success",
+	"failed",
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/airflowreceiver/internal/metadata"
+)
+
+const (
+	// dagRunMetricsCount is the number of metrics recorded from the DAG runs.
+	dagRunMetricsCount = 3
+	// taskInstanceMetricsCount is the number of metrics recorded from the
+	// task instances.
+	taskInstanceMetricsCount = 2
+)
+
+var (
+	activeDAGRunStates = []string{
+		metadata.LabelDagRunState.Queued,
+		metadata.LabelDagRunState.Running,
+	}
+	activeTaskStates = []string{
+		"scheduled",
+		"queued",
+		"running",
+		"up_for_retry",
+		"up_for_reschedule",
+		"deferred",
+	}
+)
+
+// stateKey identifies the DAG runs or task instances counted together.
+type stateKey struct {
+	dagID, state string
+}
+
+type airflowScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	auth   pollingsaas.Authenticator
+	client *airflowClient
+
+	startTime pdata.Timestamp
+	// runsSince and tasksSince are the end of the windows of the previous
+	// successful scrapes: DAG runs and task instances that finished after
+	// them are counted by the next scrape.
+	runsSince     time.Time
+	tasksSince    time.Time
+	finishedRuns  map[stateKey]int64
+	finishedTasks map[stateKey]int64
+}
+
+func newAirflowScraper(logger *zap.Logger, cfg *Config, auth pollingsaas.Authenticator) *airflowScraper {
+	return &airflowScraper{
+		logger:        logger,
+		cfg:           cfg,
+		auth:          auth,
+		finishedRuns:  make(map[stateKey]int64),
+		finishedTasks: make(map[stateKey]int64),
+	}
+}
+
+func (s *airflowScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = &airflowClient{
+		client:   pollingsaas.NewClient(httpClient, s.auth, s.cfg.Settings),
+		endpoint: s.cfg.Endpoint,
+		pageSize: s.cfg.PageSize,
+	}
+	now := time.Now()
+	s.startTime = pdata.NewTimestampFromTime(now)
+	s.runsSince = now
+	s.tasksSince = now
+	return nil
+}
+
+func (s *airflowScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	now := time.Now()
+	ts := pdata.NewTimestampFromTime(now)
+	metrics := pdata.NewMetricSlice()
+	var errs scrapererror.ScrapeErrors
+
+	if err := s.scrapeDAGRuns(ctx, metrics, ts, now); err != nil {
+		errs.AddPartial(dagRunMetricsCount, err)
+	}
+	if err := s.scrapeTaskInstances(ctx, metrics, ts, now); err != nil {
+		errs.AddPartial(taskInstanceMetricsCount, err)
+	}
+
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			return metric.Gauge().DataPoints().Len() == 0
+		case pdata.MetricDataTypeSum:
+			return metric.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+	return metrics, errs.Combine()
+}
+
+// scrapeDAGRuns counts the active DAG runs by state, and the DAG runs that
+// finished since the previous scrape.
+func (s *airflowScraper) scrapeDAGRuns(ctx context.Context, metrics pdata.MetricSlice, ts pdata.Timestamp, now time.Time) error {
+	active, err := s.client.dagRuns(ctx, dagRunsQuery{States: activeDAGRunStates})
+	if err != nil {
+		return fmt.Errorf("failed to list active DAG runs: %w", err)
+	}
+	finished, err := s.client.dagRuns(ctx, dagRunsQuery{EndDateGTE: &s.runsSince, EndDateLTE: &now})
+	if err != nil {
+		return fmt.Errorf("failed to list finished DAG runs: %w", err)
+	}
+
+	activeCounts := make(map[stateKey]int64)
+	for _, run := range active {
+		// Older versions of Airflow ignore the state filter.
+		if run.State == metadata.LabelDagRunState.Queued || run.State == metadata.LabelDagRunState.Running {
+			activeCounts[stateKey{run.DAGID, run.State}]++
+		}
+	}
+
+	// durations holds the duration of the last finished DAG run of each DAG
+	// and state, with the time it finished.
+	type lastRun struct {
+		end      time.Time
+		duration float64
+	}
+	durations := make(map[stateKey]lastRun)
+	for _, run := range finished {
+		if run.EndDate == nil || !run.EndDate.After(s.runsSince) {
+			continue
+		}
+		key := stateKey{run.DAGID, run.State}
+		s.finishedRuns[key]++
+		if run.StartDate == nil {
+			continue
+		}
+		if last, ok := durations[key]; !ok || run.EndDate.After(last.end) {
+			durations[key] = lastRun{*run.EndDate, run.EndDate.Sub(*run.StartDate).Seconds()}
+		}
+	}
+
+	activeDps := newMetric(metrics, metadata.M.AirflowDagRunActive).Sum().DataPoints()
+	for key, count := range activeCounts {
+		addStatePoint(activeDps, s.startTime, ts, key, metadata.L.DagRunState).SetIntVal(count)
+	}
+	finishedDps := newMetric(metrics, metadata.M.AirflowDagRunFinished).Sum().DataPoints()
+	for key, count := range s.finishedRuns {
+		addStatePoint(finishedDps, s.startTime, ts, key, metadata.L.DagRunState).SetIntVal(count)
+	}
+	durationDps := newMetric(metrics, metadata.M.AirflowDagRunDuration).Gauge().DataPoints()
+	for key, last := range durations {
+		addStatePoint(durationDps, 0, ts, key, metadata.L.DagRunState).SetDoubleVal(last.duration)
+	}
+	s.runsSince = now
+	return nil
+}
+
+// scrapeTaskInstances counts the active task instances by state, and the
+// task instances that finished since the previous scrape.
+func (s *airflowScraper) scrapeTaskInstances(ctx context.Context, metrics pdata.MetricSlice, ts pdata.Timestamp, now time.Time) error {
+	active, err := s.client.taskInstances(ctx, taskInstancesQuery{State: activeTaskStates})
+	if err != nil {
+		return fmt.Errorf("failed to list active task instances: %w", err)
+	}
+	finished, err := s.client.taskInstances(ctx, taskInstancesQuery{EndDateGTE: &s.tasksSince, EndDateLTE: &now})
+	if err != nil {
+		return fmt.Errorf("failed to list finished task instances: %w", err)
+	}
+
+	activeCounts := make(map[stateKey]int64)
+	for _, task := range active {
+		activeCounts[stateKey{task.DAGID, task.State}]++
+	}
+	for _, task := range finished {
+		if task.EndDate == nil || !task.EndDate.After(s.tasksSince) {
+			continue
+		}
+		s.finishedTasks[stateKey{task.DAGID, task.State}]++
+	}
+
+	activeDps := newMetric(metrics, metadata.M.AirflowTaskInstanceActive).Sum().DataPoints()
+	for key, count := range activeCounts {
+		addStatePoint(activeDps, s.startTime, ts, key, metadata.L.TaskState).SetIntVal(count)
+	}
+	finishedDps := newMetric(metrics, metadata.M.AirflowTaskInstanceFinished).Sum().DataPoints()
+	for key, count := range s.finishedTasks {
+		addStatePoint(finishedDps, s.startTime, ts, key, metadata.L.TaskState).SetIntVal(count)
+	}
+	s.tasksSince = now
+	return nil
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+func addStatePoint(dps pdata.NumberDataPointSlice, start, ts pdata.Timestamp, key stateKey, stateLabel string) pdata.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.Attributes().InsertString(metadata.L.DagID, key.dagID)
+	dp.Attributes().InsertString(stateLabel, key.state)
+	return dp
+}
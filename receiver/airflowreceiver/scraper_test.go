@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/airflowreceiver/internal/metadata"
+)
+
+// newTestServer serves the responses of testdata/api, paginating the DAG
+// runs as requested.
+func newTestServer(t *testing.T) *httptest.Server {
+	load := func(name string) map[string]interface{} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "api", name))
+		require.NoError(t, err)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &body))
+		return body
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "otel" || password != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]interface{}
+		switch r.URL.Path {
+		case "/api/v1/dags/~/dagRuns/list":
+			var query dagRunsQuery
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+			if len(query.States) > 0 {
+				body = load("dag_runs_active.json")
+			} else {
+				assert.NotNil(t, query.EndDateGTE)
+				body = load("dag_runs_finished.json")
+			}
+			runs := body["dag_runs"].([]interface{})
+			end := query.PageOffset + query.PageLimit
+			if end > len(runs) {
+				end = len(runs)
+			}
+			body["dag_runs"] = runs[query.PageOffset:end]
+		case "/api/v1/dags/~/dagRuns/~/taskInstances/list":
+			var query taskInstancesQuery
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+			if len(query.State) > 0 {
+				body = load("task_instances_active.json")
+			} else {
+				body = load("task_instances_finished.json")
+			}
+		case "/api/v1/dags/etl_orders/dagRuns/scheduled__2021-10-04T09:00:00+00:00/taskInstances":
+			body = load("dag_run_task_instances.json")
+		default:
+			body = map[string]interface{}{"task_instances": []interface{}{}, "total_entries": 0}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+}
+
+func newTestConfig(endpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Username = "otel"
+	cfg.Password = "s3cr3t"
+	cfg.RequestsPerSecond = 1000
+	cfg.PageSize = 2
+	return cfg
+}
+
+func TestScrape(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	cfg := newTestConfig(srv.URL)
+	scraper := newAirflowScraper(zap.NewNop(), cfg, newAuthenticator(cfg))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	since := time.Date(2021, 10, 4, 0, 0, 0, 0, time.UTC)
+	scraper.runsSince = since
+	scraper.tasksSince = since
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	points := pointsByName(metrics)
+	assert.Equal(t, map[string]float64{
+		"etl_orders/running": 1,
+		"etl_orders/queued":  1,
+		"reporting/running":  1,
+	}, points["airflow.dag_run.active"])
+	assert.Equal(t, map[string]float64{
+		"etl_orders/success": 2,
+		"reporting/failed":   1,
+	}, points["airflow.dag_run.finished"])
+	assert.Equal(t, map[string]float64{
+		"etl_orders/success": 330,
+		"reporting/failed":   60.5,
+	}, points["airflow.dag_run.duration"])
+	assert.Equal(t, map[string]float64{
+		"etl_orders/running":     1,
+		"etl_orders/scheduled":   2,
+		"reporting/up_for_retry": 1,
+	}, points["airflow.task_instance.active"])
+	assert.Equal(t, map[string]float64{
+		"etl_orders/success":        2,
+		"reporting/upstream_failed": 1,
+	}, points["airflow.task_instance.finished"])
+
+	// The DAG runs and task instances that finished before the previous
+	// scrape are not counted again, and no duration is reported.
+	metrics, err = scraper.scrape(context.Background())
+	require.NoError(t, err)
+	points = pointsByName(metrics)
+	assert.Equal(t, map[string]float64{
+		"etl_orders/success": 2,
+		"reporting/failed":   1,
+	}, points["airflow.dag_run.finished"])
+	assert.NotContains(t, points, "airflow.dag_run.duration")
+}
+
+func TestScrapeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(srv.URL)
+	scraper := newAirflowScraper(zap.NewNop(), cfg, newAuthenticator(cfg))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	since := scraper.runsSince
+
+	metrics, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list active DAG runs")
+	assert.Contains(t, err.Error(), "failed to list active task instances")
+	assert.Equal(t, 0, metrics.Len())
+	assert.Equal(t, since, scraper.runsSince)
+}
+
+// pointsByName returns the values of the data points of each metric, by
+// DAG ID and state.
+func pointsByName(metrics pdata.MetricSlice) map[string]map[string]float64 {
+	points := make(map[string]map[string]float64)
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		var dps pdata.NumberDataPointSlice
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			dps = metric.Gauge().DataPoints()
+		case pdata.MetricDataTypeSum:
+			dps = metric.Sum().DataPoints()
+		}
+		values := make(map[string]float64)
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			dagID, _ := dp.Attributes().Get(metadata.L.DagID)
+			state, _ := dp.Attributes().Get(metadata.L.DagRunState)
+			value := dp.DoubleVal()
+			if dp.Type() == pdata.MetricValueTypeInt {
+				value = float64(dp.IntVal())
+			}
+			values[dagID.StringVal()+"/"+state.StringVal()] = value
+		}
+		points[metric.Name()] = values
+	}
+	return points
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+const (
+	serviceName = "airflow"
+
+	attributeDAGID           = "airflow.dag_id"
+	attributeDAGRunID        = "airflow.dag_run.id"
+	attributeDAGRunState     = "airflow.dag_run.state"
+	attributeExecutionDate   = "airflow.dag_run.execution_date"
+	attributeExternalTrigger = "airflow.dag_run.external_trigger"
+	attributeTaskID          = "airflow.task_id"
+	attributeTaskState       = "airflow.task_instance.state"
+	attributeTryNumber       = "airflow.task_instance.try_number"
+	attributeOperator        = "airflow.operator"
+	attributePool            = "airflow.pool"
+	attributeQueue           = "airflow.queue"
+)
+
+// dagRunSource polls the DAG runs that finished since the cursor, which is
+// the end date of the last DAG run received, and returns a trace per DAG
+// run with a span per task instance.
+type dagRunSource struct {
+	cfg *Config
+	now func() time.Time
+}
+
+var _ pollingsaas.Source = (*dagRunSource)(nil)
+
+func newDAGRunSource(cfg *Config) *dagRunSource {
+	return &dagRunSource{cfg: cfg, now: time.Now}
+}
+
+func (s *dagRunSource) Poll(ctx context.Context, client *pollingsaas.Client, cursor string) (pollingsaas.Page, error) {
+	since := s.now().Add(-s.cfg.InitialLookback).UTC()
+	if cursor != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, cursor); err != nil {
+			return pollingsaas.Page{}, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+	}
+
+	c := &airflowClient{client: client, endpoint: s.cfg.Endpoint, pageSize: s.cfg.PageSize}
+	page, err := c.dagRunsPage(ctx, dagRunsQuery{
+		PageLimit:  s.cfg.PageSize,
+		EndDateGTE: &since,
+		OrderBy:    "end_date",
+	})
+	if err != nil {
+		return pollingsaas.Page{}, fmt.Errorf("failed to list finished DAG runs: %w", err)
+	}
+
+	traces := pdata.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, serviceName)
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	last := since
+	for _, run := range page.DAGRuns {
+		// The end date of the cursor is inclusive, the DAG runs that ended
+		// then were received by the previous poll.
+		if run.EndDate == nil || !run.EndDate.After(since) {
+			continue
+		}
+		tasks, err := c.dagRunTaskInstances(ctx, run)
+		if err != nil {
+			return pollingsaas.Page{}, fmt.Errorf("failed to list the task instances of DAG run %q of %q: %w", run.DAGRunID, run.DAGID, err)
+		}
+		appendDAGRunSpans(spans, run, tasks)
+		if run.EndDate.After(last) {
+			last = *run.EndDate
+		}
+	}
+
+	return pollingsaas.Page{
+		Traces:  traces,
+		Cursor:  last.Format(time.RFC3339Nano),
+		HasMore: len(page.DAGRuns) == s.cfg.PageSize && last.After(since),
+	}, nil
+}
+
+// appendDAGRunSpans appends the root span of the DAG run and a child span
+// for each task instance that started. The IDs are derived from the IDs of
+// the DAG, run and task, so that a DAG run received twice has the same IDs.
+func appendDAGRunSpans(spans pdata.SpanSlice, run dagRun, tasks []taskInstance) {
+	traceID := pdata.NewTraceID(traceIDFromParts(run.DAGID, run.DAGRunID))
+	rootID := pdata.NewSpanID(spanIDFromParts(run.DAGID, run.DAGRunID))
+
+	root := spans.AppendEmpty()
+	root.SetTraceID(traceID)
+	root.SetSpanID(rootID)
+	root.SetName(run.DAGID)
+	root.SetKind(pdata.SpanKindInternal)
+	start := run.EndDate
+	if run.StartDate != nil {
+		start = run.StartDate
+	}
+	root.SetStartTimestamp(pdata.NewTimestampFromTime(*start))
+	root.SetEndTimestamp(pdata.NewTimestampFromTime(*run.EndDate))
+	if run.State == "failed" {
+		root.Status().SetCode(pdata.StatusCodeError)
+	}
+	attrs := root.Attributes()
+	attrs.InsertString(attributeDAGID, run.DAGID)
+	attrs.InsertString(attributeDAGRunID, run.DAGRunID)
+	attrs.InsertString(attributeDAGRunState, run.State)
+	attrs.InsertBool(attributeExternalTrigger, run.ExternalTrigger)
+	if run.ExecutionDate != nil {
+		attrs.InsertString(attributeExecutionDate, run.ExecutionDate.Format(time.RFC3339Nano))
+	}
+
+	for _, task := range tasks {
+		if task.StartDate == nil {
+			// Skipped and upstream failed tasks never start.
+			continue
+		}
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(pdata.NewSpanID(spanIDFromParts(run.DAGID, run.DAGRunID, task.TaskID)))
+		span.SetParentSpanID(rootID)
+		span.SetName(task.TaskID)
+		span.SetKind(pdata.SpanKindInternal)
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(*task.StartDate))
+		end := task.StartDate
+		if task.EndDate != nil {
+			end = task.EndDate
+		}
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(*end))
+		if task.State == "failed" {
+			span.Status().SetCode(pdata.StatusCodeError)
+		}
+		attrs := span.Attributes()
+		attrs.InsertString(attributeDAGID, run.DAGID)
+		attrs.InsertString(attributeDAGRunID, run.DAGRunID)
+		attrs.InsertString(attributeTaskID, task.TaskID)
+		attrs.InsertString(attributeTaskState, task.State)
+		attrs.InsertInt(attributeTryNumber, task.TryNumber)
+		insertNonEmpty(attrs, attributeOperator, task.Operator)
+		insertNonEmpty(attrs, attributePool, task.Pool)
+		insertNonEmpty(attrs, attributeQueue, task.Queue)
+		insertNonEmpty(attrs, conventions.AttributeHostName, task.Hostname)
+	}
+}
+
+func insertNonEmpty(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+func traceIDFromParts(parts ...string) [16]byte {
+	var id [16]byte
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	copy(id[:], sum[:])
+	return id
+}
+
+func spanIDFromParts(parts ...string) [8]byte {
+	var id [8]byte
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{"span"}, parts...), "\x00")))
+	copy(id[:], sum[:])
+	return id
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airflowreceiver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+func TestPollDAGRuns(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	cfg := newTestConfig(srv.URL)
+	cfg.InitialLookback = 24 * time.Hour
+	source := newDAGRunSource(cfg)
+	source.now = func() time.Time { return time.Date(2021, 10, 4, 12, 0, 0, 0, time.UTC) }
+	client := pollingsaas.NewClient(http.DefaultClient, newAuthenticator(cfg), cfg.Settings)
+
+	page, err := source.Poll(context.Background(), client, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2021-10-04T10:05:30Z", page.Cursor)
+	assert.True(t, page.HasMore)
+
+	rs := page.Traces.ResourceSpans().At(0)
+	serviceName, _ := rs.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "airflow", serviceName.StringVal())
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	// The task that never started has no span.
+	require.Equal(t, 4, spans.Len())
+
+	root := spans.At(0)
+	assert.Equal(t, "etl_orders", root.Name())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, time.Date(2021, 10, 4, 10, 0, 0, 0, time.UTC), root.StartTimestamp().AsTime())
+	assert.Equal(t, time.Date(2021, 10, 4, 10, 5, 30, 0, time.UTC), root.EndTimestamp().AsTime())
+	assert.Equal(t, pdata.StatusCodeUnset, root.Status().Code())
+	assert.Equal(t, map[string]interface{}{
+		"airflow.dag_id":                   "etl_orders",
+		"airflow.dag_run.id":               "scheduled__2021-10-04T09:00:00+00:00",
+		"airflow.dag_run.state":            "success",
+		"airflow.dag_run.execution_date":   "2021-10-04T09:00:00Z",
+		"airflow.dag_run.external_trigger": false,
+	}, attributesAsMap(root.Attributes()))
+
+	transform := spans.At(2)
+	assert.Equal(t, "transform", transform.Name())
+	assert.Equal(t, root.TraceID(), transform.TraceID())
+	assert.Equal(t, root.SpanID(), transform.ParentSpanID())
+	assert.Equal(t, pdata.StatusCodeError, transform.Status().Code())
+	assert.Equal(t, 3*time.Minute-time.Second, transform.EndTimestamp().AsTime().Sub(transform.StartTimestamp().AsTime()))
+	assert.Equal(t, map[string]interface{}{
+		"airflow.dag_id":                   "etl_orders",
+		"airflow.dag_run.id":               "scheduled__2021-10-04T09:00:00+00:00",
+		"airflow.task_id":                  "transform",
+		"airflow.task_instance.state":      "failed",
+		"airflow.task_instance.try_number": int64(2),
+		"airflow.operator":                 "PythonOperator",
+		"airflow.pool":                     "default_pool",
+		"airflow.queue":                    "default",
+		"host.name":                        "worker-2",
+	}, attributesAsMap(transform.Attributes()))
+
+	other := spans.At(3)
+	assert.Equal(t, "etl_orders", other.Name())
+	assert.NotEqual(t, root.TraceID(), other.TraceID())
+
+	// Polling again returns the same IDs.
+	again, err := source.Poll(context.Background(), client, "")
+	require.NoError(t, err)
+	assert.Equal(t, root.TraceID(), again.Traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+	assert.Equal(t, transform.SpanID(), again.Traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(2).SpanID())
+
+	// The DAG runs that ended at the cursor were already received.
+	page, err = source.Poll(context.Background(), client, page.Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, "2021-10-04T10:05:30Z", page.Cursor)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, 0, page.Traces.SpanCount())
+}
+
+func TestPollInvalidCursor(t *testing.T) {
+	source := newDAGRunSource(newTestConfig("http://localhost"))
+	_, err := source.Poll(context.Background(), nil, "yesterday")
+	assert.EqualError(t, err, `invalid cursor "yesterday": parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`)
+}
+
+func attributesAsMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeBool:
+			m[k] = v.BoolVal()
+		case pdata.AttributeValueTypeInt:
+			m[k] = v.IntVal()
+		default:
+			m[k] = v.AsString()
+		}
+		return true
+	})
+	return m
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"time"
+
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+// cloudflareFormat maps the fields of the Cloudflare "http_requests" dataset.
+// See https://developers.cloudflare.com/logs/reference/log-fields/zone/http_requests.
+var cloudflareFormat = &logFormat{
+	provider:        "cloudflare",
+	timestampFields: []string{"EdgeStartTimestamp", "EdgeEndTimestamp"},
+	timeLayouts:     []string{time.RFC3339Nano},
+	fields: map[string]mappedField{
+		"ClientRequestMethod":    {attribute: conventions.AttributeHTTPMethod},
+		"ClientRequestHost":      {attribute: conventions.AttributeHTTPHost},
+		"ClientRequestURI":       {attribute: conventions.AttributeHTTPTarget},
+		"ClientRequestScheme":    {attribute: conventions.AttributeHTTPScheme},
+		"ClientRequestProtocol":  {attribute: conventions.AttributeHTTPFlavor, kind: flavorValue},
+		"ClientRequestUserAgent": {attribute: conventions.AttributeHTTPUserAgent},
+		"ClientIP":               {attribute: conventions.AttributeHTTPClientIP},
+		"ClientRequestBytes":     {attribute: conventions.AttributeHTTPRequestContentLength, kind: intValue},
+		"EdgeResponseStatus":     {attribute: conventions.AttributeHTTPStatusCode, kind: intValue},
+		"EdgeResponseBytes":      {attribute: conventions.AttributeHTTPResponseContentLength, kind: intValue},
+	},
+	ignore: isCloudflareValidation,
+}
+
+// isCloudflareValidation detects the test payload Logpush sends when a
+// destination is created, which looks like {"content":"tests"}.
+func isCloudflareValidation(fields map[string]interface{}) bool {
+	_, ok := fields["content"]
+	return ok && len(fields) == 1
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the CDN logs receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// AccessToken, if set, must be sent by the CDN in the Authorization
+	// header, either as is or prefixed with "Bearer ".
+	AccessToken string `mapstructure:"access_token"`
+
+	// Cloudflare configures the Cloudflare Logpush HTTP destination.
+	Cloudflare CloudflareConfig `mapstructure:"cloudflare"`
+
+	// Fastly configures the Fastly HTTPS real-time log streaming endpoint.
+	Fastly FastlyConfig `mapstructure:"fastly"`
+}
+
+// CloudflareConfig defines the settings of the Cloudflare Logpush endpoint.
+type CloudflareConfig struct {
+	// Path is the URL path Logpush jobs push to.
+	Path string `mapstructure:"path"`
+}
+
+// FastlyConfig defines the settings of the Fastly logging endpoint.
+type FastlyConfig struct {
+	// Path is the URL path Fastly HTTPS logging endpoints post to.
+	Path string `mapstructure:"path"`
+
+	// ServiceIDs are the Fastly service IDs allowed to stream logs to this
+	// receiver. They are used to answer Fastly's domain ownership challenge.
+	// If empty, any service is allowed.
+	ServiceIDs []string `mapstructure:"service_ids"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	for name, path := range map[string]string{
+		"cloudflare": cfg.Cloudflare.Path,
+		"fastly":     cfg.Fastly.Path,
+	} {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("%s.path must start with '/', got %q", name, path)
+		}
+	}
+	if cfg.Cloudflare.Path == cfg.Fastly.Path {
+		return errors.New("cloudflare.path and fastly.path must be different")
+	}
+	if cfg.Fastly.Path == fastlyChallengePath || cfg.Cloudflare.Path == fastlyChallengePath {
+		return fmt.Errorf("path %q is reserved for the Fastly challenge", fastlyChallengePath)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+// fastlyChallengePath is requested by Fastly to verify the endpoint accepts
+// logs for the service before it starts streaming.
+// See https://docs.fastly.com/en/guides/log-streaming-https.
+const fastlyChallengePath = "/.well-known/fastly/logging/challenge"
+
+// fastlyFormat maps the keys of the JSON log format recommended by Fastly.
+// See https://docs.fastly.com/en/guides/custom-log-formats.
+var fastlyFormat = &logFormat{
+	provider:        "fastly",
+	timestampFields: []string{"timestamp"},
+	// %Y-%m-%dT%H:%M:%S%z as produced by strftime in VCL.
+	timeLayouts: []string{"2006-01-02T15:04:05-0700", time.RFC3339Nano},
+	fields: map[string]mappedField{
+		"request_method":     {attribute: conventions.AttributeHTTPMethod},
+		"host":               {attribute: conventions.AttributeHTTPHost},
+		"url":                {attribute: conventions.AttributeHTTPTarget},
+		"request_protocol":   {attribute: conventions.AttributeHTTPFlavor, kind: flavorValue},
+		"request_user_agent": {attribute: conventions.AttributeHTTPUserAgent},
+		"client_ip":          {attribute: conventions.AttributeHTTPClientIP},
+		"response_status":    {attribute: conventions.AttributeHTTPStatusCode, kind: intValue},
+		"response_body_size": {attribute: conventions.AttributeHTTPResponseContentLength, kind: intValue},
+	},
+}
+
+// fastlyChallengeResponse returns the body expected by Fastly: the hex
+// encoded SHA-256 of each allowed service ID on its own line, or "*".
+func fastlyChallengeResponse(serviceIDs []string) string {
+	if len(serviceIDs) == 0 {
+		return "*\n"
+	}
+	var sb strings.Builder
+	for _, id := range serviceIDs {
+		sum := sha256.Sum256([]byte(id))
+		sb.WriteString(hex.EncodeToString(sum[:]))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
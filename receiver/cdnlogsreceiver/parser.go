@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	// cdnProviderAttribute is set on the resource of every log record to
+	// identify which CDN sent the log.
+	cdnProviderAttribute = "cdn.provider"
+
+	maxLineSize = 1024 * 1024
+)
+
+type valueKind int
+
+const (
+	stringValue valueKind = iota
+	intValue
+	flavorValue
+)
+
+type mappedField struct {
+	attribute string
+	kind      valueKind
+}
+
+// logFormat describes how the JSON fields of a CDN log line map onto log
+// record fields and standardized http.* attributes. Fields that are not
+// mapped are kept as attributes prefixed with the provider name.
+type logFormat struct {
+	provider        string
+	timestampFields []string
+	timeLayouts     []string
+	fields          map[string]mappedField
+	// ignore returns true for lines that are not access logs, e.g. the
+	// payloads sent when a destination is validated.
+	ignore func(map[string]interface{}) bool
+}
+
+// parseLogs converts newline delimited JSON log lines into pdata.Logs. Lines
+// that can't be parsed are skipped and counted in the returned value.
+func (f *logFormat) parseLogs(r io.Reader) (pdata.Logs, int, error) {
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(cdnProviderAttribute, f.provider)
+	records := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	invalid := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		fields, err := decodeLine(line)
+		if err != nil {
+			invalid++
+			continue
+		}
+		if f.ignore != nil && f.ignore(fields) {
+			continue
+		}
+		f.toLogRecord(fields, string(line), records.AppendEmpty())
+	}
+	return logs, invalid, scanner.Err()
+}
+
+func decodeLine(line []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	fields := map[string]interface{}{}
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func (f *logFormat) toLogRecord(fields map[string]interface{}, raw string, lr pdata.LogRecord) {
+	lr.Body().SetStringVal(raw)
+	attrs := lr.Attributes()
+
+	for _, tf := range f.timestampFields {
+		if ts, ok := f.parseTimestamp(fields[tf]); ok {
+			lr.SetTimestamp(pdata.NewTimestampFromTime(ts))
+			break
+		}
+	}
+
+	for k, v := range fields {
+		if v == nil {
+			continue
+		}
+		mf, ok := f.fields[k]
+		if !ok {
+			insertValue(attrs, f.provider+"."+k, v)
+			continue
+		}
+		switch mf.kind {
+		case intValue:
+			if n, ok := toInt(v); ok {
+				attrs.InsertInt(mf.attribute, n)
+			}
+		case flavorValue:
+			attrs.InsertString(mf.attribute, normalizeFlavor(fmt.Sprint(v)))
+		default:
+			attrs.InsertString(mf.attribute, fmt.Sprint(v))
+		}
+	}
+
+	if url, ok := buildURL(attrs); ok {
+		attrs.InsertString(conventions.AttributeHTTPURL, url)
+	}
+
+	if status, ok := attrs.Get(conventions.AttributeHTTPStatusCode); ok {
+		lr.SetSeverityNumber(severityFromStatus(status.IntVal()))
+		lr.SetSeverityText(lr.SeverityNumber().String())
+	}
+}
+
+func (f *logFormat) parseTimestamp(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		n, err := t.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		// Cloudflare can be configured to send unix seconds or nanoseconds.
+		if n > 1e15 {
+			return time.Unix(0, n), true
+		}
+		return time.Unix(n, 0), true
+	case string:
+		for _, layout := range f.timeLayouts {
+			if ts, err := time.Parse(layout, t); err == nil {
+				return ts, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func insertValue(attrs pdata.AttributeMap, key string, v interface{}) {
+	switch t := v.(type) {
+	case string:
+		attrs.InsertString(key, t)
+	case bool:
+		attrs.InsertBool(key, t)
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			attrs.InsertInt(key, n)
+		} else if f, err := t.Float64(); err == nil {
+			attrs.InsertDouble(key, f)
+		} else {
+			attrs.InsertString(key, t.String())
+		}
+	default:
+		// Arrays and nested objects are kept as their JSON representation.
+		b, err := json.Marshal(t)
+		if err == nil {
+			attrs.InsertString(key, string(b))
+		}
+	}
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		n, err := t.Int64()
+		return n, err == nil
+	case string:
+		n, err := json.Number(t).Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// normalizeFlavor converts protocol strings such as "HTTP/1.1" or "HTTP/2"
+// into the values defined by the http.flavor semantic convention.
+func normalizeFlavor(protocol string) string {
+	p := strings.TrimPrefix(strings.ToUpper(protocol), "HTTP/")
+	switch p {
+	case "1", "1.0":
+		return conventions.AttributeHTTPFlavorHTTP10
+	case "1.1":
+		return conventions.AttributeHTTPFlavorHTTP11
+	case "2", "2.0":
+		return conventions.AttributeHTTPFlavorHTTP20
+	case "3", "3.0", "QUIC":
+		return conventions.AttributeHTTPFlavorQUIC
+	case "SPDY":
+		return conventions.AttributeHTTPFlavorSPDY
+	}
+	return protocol
+}
+
+func buildURL(attrs pdata.AttributeMap) (string, bool) {
+	host, ok := attrs.Get(conventions.AttributeHTTPHost)
+	if !ok {
+		return "", false
+	}
+	target, ok := attrs.Get(conventions.AttributeHTTPTarget)
+	if !ok {
+		return "", false
+	}
+	scheme := "https"
+	if s, ok := attrs.Get(conventions.AttributeHTTPScheme); ok && s.StringVal() != "" {
+		scheme = s.StringVal()
+	}
+	return scheme + "://" + host.StringVal() + target.StringVal(), true
+}
+
+func severityFromStatus(status int64) pdata.SeverityNumber {
+	switch {
+	case status >= 500:
+		return pdata.SeverityNumberERROR
+	case status >= 400:
+		return pdata.SeverityNumberWARN
+	default:
+		return pdata.SeverityNumberINFO
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeFlavor(t *testing.T) {
+	for in, expected := range map[string]string{
+		"HTTP/1.0": "1.0",
+		"HTTP/1.1": "1.1",
+		"HTTP/2":   "2.0",
+		"HTTP/3":   "QUIC",
+		"http/2.0": "2.0",
+		"unknown":  "unknown",
+	} {
+		assert.Equal(t, expected, normalizeFlavor(in), in)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	ts, ok := cloudflareFormat.parseTimestamp(json.Number("1633359845"))
+	require.True(t, ok)
+	assert.Equal(t, int64(1633359845), ts.Unix())
+
+	ts, ok = cloudflareFormat.parseTimestamp(json.Number("1633359845123456789"))
+	require.True(t, ok)
+	assert.Equal(t, int64(1633359845123456789), ts.UnixNano())
+
+	ts, ok = fastlyFormat.parseTimestamp("2021-10-04T17:04:05+0200")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2021, 10, 4, 15, 4, 5, 0, time.UTC), ts.UTC())
+
+	_, ok = fastlyFormat.parseTimestamp("yesterday")
+	assert.False(t, ok)
+}
+
+func TestParseLogsNestedValues(t *testing.T) {
+	logs, invalid, err := fastlyFormat.parseLogs(strings.NewReader(`{"geo":{"country":"NL"},"tags":["a","b"],"ratio":0.5,"missing":null}` + "\n\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, invalid)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	attrs := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	assert.Equal(t, map[string]interface{}{
+		"fastly.geo":   `{"country":"NL"}`,
+		"fastly.tags":  `["a","b"]`,
+		"fastly.ratio": 0.5,
+	}, attributesToMap(attrs))
+}
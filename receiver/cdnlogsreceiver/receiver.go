@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const defaultServerTimeout = 20 * time.Second
+
+var errNilNextConsumer = errors.New("nil nextConsumer")
+
+type cdnLogsReceiver struct {
+	settings     component.TelemetrySettings
+	config       *Config
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+func newLogsReceiver(settings component.TelemetrySettings, config *Config, nextConsumer consumer.Logs) (*cdnLogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+
+	return &cdnLogsReceiver{
+		settings:     settings,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
+	}, nil
+}
+
+func (r *cdnLogsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(r.config.Cloudflare.Path, r.authenticated(r.logsHandler(cloudflareFormat)))
+	router.HandleFunc(r.config.Fastly.Path, r.authenticated(r.logsHandler(fastlyFormat)))
+	router.HandleFunc(fastlyChallengePath, r.handleFastlyChallenge)
+
+	r.server = r.config.HTTPServerSettings.ToServer(router, r.settings)
+	r.server.ReadHeaderTimeout = defaultServerTimeout
+	r.server.WriteTimeout = defaultServerTimeout
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (r *cdnLogsReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.wg.Wait()
+	return err
+}
+
+func (r *cdnLogsReceiver) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	if r.config.AccessToken == "" {
+		return next
+	}
+	expected := []byte(r.config.AccessToken)
+	return func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), expected) != 1 {
+			http.Error(w, "invalid access token", http.StatusUnauthorized)
+			return
+		}
+		next(w, req)
+	}
+}
+
+func (r *cdnLogsReceiver) logsHandler(format *logFormat) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			_, _ = io.Copy(io.Discard, req.Body)
+			_ = req.Body.Close()
+		}()
+
+		if req.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx := r.obsrecv.StartLogsOp(req.Context())
+		logs, invalid, err := format.parseLogs(req.Body)
+		count := logs.LogRecordCount()
+		if err != nil {
+			r.obsrecv.EndLogsOp(ctx, format.provider, count, err)
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if invalid > 0 {
+			r.settings.Logger.Debug("Skipped invalid log lines",
+				zap.String("provider", format.provider), zap.Int("count", invalid))
+			if count == 0 {
+				r.obsrecv.EndLogsOp(ctx, format.provider, 0, nil)
+				http.Error(w, "no valid log lines", http.StatusBadRequest)
+				return
+			}
+		}
+		if count == 0 {
+			r.obsrecv.EndLogsOp(ctx, format.provider, 0, nil)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		err = r.nextConsumer.ConsumeLogs(ctx, logs)
+		r.obsrecv.EndLogsOp(ctx, format.provider, count, err)
+		if err != nil {
+			if consumererror.IsPermanent(err) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			} else {
+				// Both Logpush and Fastly retry on 5xx responses.
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (r *cdnLogsReceiver) handleFastlyChallenge(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = io.WriteString(w, fastlyChallengeResponse(r.config.Fastly.ServiceIDs))
+}
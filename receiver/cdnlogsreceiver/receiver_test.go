@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdnlogsreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
+)
+
+func startReceiver(t *testing.T, cfg *Config, next consumer.Logs) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newLogsReceiver(componenttest.NewNopTelemetrySettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return "http://" + cfg.Endpoint
+}
+
+func post(t *testing.T, url string, body io.Reader, header http.Header) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func gzipFile(t *testing.T, path string) io.Reader {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return &buf
+}
+
+func TestCloudflareLogpush(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, createDefaultConfig().(*Config), sink)
+
+	resp := post(t, url+defaultCloudflarePath, gzipFile(t, "testdata/cloudflare.ndjson"), http.Header{
+		"Content-Encoding": []string{"gzip"},
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	logs := sink.AllLogs()[0]
+	rl := logs.ResourceLogs().At(0)
+	provider, _ := rl.Resource().Attributes().Get(cdnProviderAttribute)
+	assert.Equal(t, "cloudflare", provider.StringVal())
+
+	records := rl.InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 2, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, time.Date(2021, 10, 4, 15, 4, 5, 123456789, time.UTC), first.Timestamp().AsTime())
+	assert.Equal(t, pdata.SeverityNumberINFO, first.SeverityNumber())
+	assert.Equal(t, map[string]interface{}{
+		"http.client_ip":                "198.51.100.7",
+		"http.host":                     "www.example.com",
+		"http.method":                   "GET",
+		"http.target":                   "/index.html?q=1",
+		"http.scheme":                   "https",
+		"http.flavor":                   "2.0",
+		"http.user_agent":               "curl/7.79.1",
+		"http.request_content_length":   int64(1024),
+		"http.status_code":              int64(200),
+		"http.response_content_length":  int64(5120),
+		"http.url":                      "https://www.example.com/index.html?q=1",
+		"cloudflare.EdgeStartTimestamp": "2021-10-04T15:04:05.123456789Z",
+		"cloudflare.RayID":              "6991a56dd9b8e55c",
+		"cloudflare.CacheCacheStatus":   "hit",
+		"cloudflare.WAFFlags":           "0",
+	}, attributesToMap(first.Attributes()))
+
+	second := records.At(1)
+	assert.Equal(t, time.Unix(0, 1633359845000000000).UTC(), second.Timestamp().AsTime())
+	assert.Equal(t, pdata.SeverityNumberERROR, second.SeverityNumber())
+	status, _ := second.Attributes().Get("http.status_code")
+	assert.EqualValues(t, 502, status.IntVal())
+	origin, _ := second.Attributes().Get("cloudflare.OriginResponseStatus")
+	assert.EqualValues(t, 502, origin.IntVal())
+}
+
+func TestCloudflareValidationPayload(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, createDefaultConfig().(*Config), sink)
+
+	resp := post(t, url+defaultCloudflarePath, strings.NewReader(`{"content":"tests"}`), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, sink.AllLogs(), 0)
+}
+
+func TestFastlyLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, createDefaultConfig().(*Config), sink)
+
+	data, err := ioutil.ReadFile("testdata/fastly.ndjson")
+	require.NoError(t, err)
+	resp := post(t, url+defaultFastlyPath, bytes.NewReader(data), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	provider, _ := rl.Resource().Attributes().Get(cdnProviderAttribute)
+	assert.Equal(t, "fastly", provider.StringVal())
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, time.Date(2021, 10, 4, 15, 4, 5, 0, time.UTC), lr.Timestamp().AsTime().UTC())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, strings.TrimSpace(string(data)), lr.Body().StringVal())
+	assert.Equal(t, map[string]interface{}{
+		"http.client_ip":               "198.51.100.7",
+		"http.host":                    "www.example.com",
+		"http.method":                  "GET",
+		"http.target":                  "/assets/app.js",
+		"http.flavor":                  "1.1",
+		"http.user_agent":              "Mozilla/5.0",
+		"http.status_code":             int64(404),
+		"http.response_content_length": int64(162),
+		"http.url":                     "https://www.example.com/assets/app.js",
+		"fastly.timestamp":             "2021-10-04T15:04:05+0000",
+		"fastly.request_referer":       "https://www.example.com/",
+		"fastly.response_state":        "HIT",
+		"fastly.fastly_server":         "cache-ams21034-AMS",
+		"fastly.fastly_is_edge":        true,
+		"fastly.service_id":            "7i6HN3TK9wS159v2gPAZ8A",
+	}, attributesToMap(lr.Attributes()))
+}
+
+func TestFastlyChallenge(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fastly.ServiceIDs = []string{"7i6HN3TK9wS159v2gPAZ8A"}
+	url := startReceiver(t, cfg, consumertest.NewNop())
+
+	resp, err := http.Get(url + fastlyChallengePath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "6db2d1c383f7583dbeb21047407f2b049986b13e0ea48c2029505ca01aa7636e\n", string(body))
+}
+
+func TestAccessToken(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AccessToken = "secret"
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, cfg, sink)
+
+	line := `{"ClientRequestHost":"example.com","EdgeResponseStatus":200}`
+
+	resp := post(t, url+defaultCloudflarePath, strings.NewReader(line), nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = post(t, url+defaultCloudflarePath, strings.NewReader(line), http.Header{
+		"Authorization": []string{"wrong"},
+	})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = post(t, url+defaultCloudflarePath, strings.NewReader(line), http.Header{
+		"Authorization": []string{"Bearer secret"},
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestErrorResponses(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     consumer.Logs
+		method   string
+		body     string
+		expected int
+	}{
+		{
+			name:     "wrong method",
+			next:     consumertest.NewNop(),
+			method:   http.MethodGet,
+			expected: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "only invalid lines",
+			next:     consumertest.NewNop(),
+			method:   http.MethodPost,
+			body:     "not json\n{",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "some invalid lines",
+			next:     consumertest.NewNop(),
+			method:   http.MethodPost,
+			body:     "not json\n{\"EdgeResponseStatus\":200}",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "retryable consumer error",
+			next:     consumertest.NewErr(errors.New("busy")),
+			method:   http.MethodPost,
+			body:     `{"EdgeResponseStatus":200}`,
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "permanent consumer error",
+			next:     consumertest.NewErr(consumererror.NewPermanent(errors.New("bad data"))),
+			method:   http.MethodPost,
+			body:     `{"EdgeResponseStatus":200}`,
+			expected: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, createDefaultConfig().(*Config), tt.next)
+			req, err := http.NewRequest(tt.method, url+defaultCloudflarePath, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	out := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			out[k] = v.IntVal()
+		case pdata.AttributeValueTypeBool:
+			out[k] = v.BoolVal()
+		case pdata.AttributeValueTypeDouble:
+			out[k] = v.DoubleVal()
+		default:
+			out[k] = v.StringVal()
+		}
+		return true
+	})
+	return out
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// healthCheck is a health check in the health API of Consul.
+type healthCheck struct {
+	Node        string `json:"Node"`
+	CheckID     string `json:"CheckID"`
+	Status      string `json:"Status"`
+	ServiceName string `json:"ServiceName"`
+}
+
+// autopilotHealth is the health of the servers as seen by autopilot.
+type autopilotHealth struct {
+	Healthy          bool              `json:"Healthy"`
+	FailureTolerance int64             `json:"FailureTolerance"`
+	Servers          []autopilotServer `json:"Servers"`
+}
+
+type autopilotServer struct {
+	Name        string `json:"Name"`
+	Leader      bool   `json:"Leader"`
+	Voter       bool   `json:"Voter"`
+	Healthy     bool   `json:"Healthy"`
+	LastContact string `json:"LastContact"`
+	LastIndex   int64  `json:"LastIndex"`
+}
+
+// consulClient reads the state of the cluster from the HTTP API of a Consul
+// agent.
+type consulClient struct {
+	client     *http.Client
+	endpoint   string
+	token      string
+	datacenter string
+}
+
+func (c *consulClient) healthChecks(ctx context.Context) ([]healthCheck, error) {
+	var checks []healthCheck
+	err := c.get(ctx, "/v1/health/state/any", &checks)
+	return checks, err
+}
+
+func (c *consulClient) autopilotHealth(ctx context.Context) (*autopilotHealth, error) {
+	health := &autopilotHealth{}
+	// Autopilot responds 429 with the health of the servers when the cluster
+	// is unhealthy.
+	err := c.get(ctx, "/v1/operator/autopilot/health", health, http.StatusTooManyRequests)
+	return health, err
+}
+
+func (c *consulClient) get(ctx context.Context, path string, out interface{}, okStatuses ...int) error {
+	u := strings.TrimSuffix(c.endpoint, "/") + path
+	if c.datacenter != "" {
+		u += "?" + url.Values{"dc": {c.datacenter}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !isOK(resp.StatusCode, okStatuses) {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s returned %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of GET %s: %w", path, err)
+	}
+	return nil
+}
+
+func isOK(status int, okStatuses []int) bool {
+	if status == http.StatusOK {
+		return true
+	}
+	for _, s := range okStatuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
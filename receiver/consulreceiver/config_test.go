@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, "https://consul.example.com:8501", custom.Endpoint)
+	assert.Equal(t, "/etc/consul/ca.pem", custom.TLSSetting.CAFile)
+	assert.Equal(t, "6c1d2a4e-9b3f-4e1a-8d7c-2f5b0a9e3c41", custom.Token)
+	assert.Equal(t, "eu-west-1", custom.Datacenter)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		err      string
+	}{
+		{name: "valid", endpoint: "http://localhost:8500"},
+		{name: "https", endpoint: "https://consul.example.com:8501"},
+		{name: "missing endpoint", err: "endpoint must be specified"},
+		{
+			name:     "invalid scheme",
+			endpoint: "localhost:8500",
+			err:      `invalid endpoint "localhost:8500": scheme must be http or https`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tt.endpoint
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "consulreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	ConsulHealthChecks          MetricIntf
+	ConsulRaftFailureTolerance  MetricIntf
+	ConsulRaftHealthy           MetricIntf
+	ConsulRaftServerHealthy     MetricIntf
+	ConsulRaftServerLastContact MetricIntf
+	ConsulRaftServerLastIndex   MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"consul.health.checks",
+		"consul.raft.failure_tolerance",
+		"consul.raft.healthy",
+		"consul.raft.server.healthy",
+		"consul.raft.server.last_contact",
+		"consul.raft.server.last_index",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"consul.health.checks":            Metrics.ConsulHealthChecks,
+	"consul.raft.failure_tolerance":   Metrics.ConsulRaftFailureTolerance,
+	"consul.raft.healthy":             Metrics.ConsulRaftHealthy,
+	"consul.raft.server.healthy":      Metrics.ConsulRaftServerHealthy,
+	"consul.raft.server.last_contact": Metrics.ConsulRaftServerLastContact,
+	"consul.raft.server.last_index":   Metrics.ConsulRaftServerLastIndex,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"consul.health.checks",
+		func(metric pdata.Metric) {
+			metric.SetName("consul.health.checks")
+			metric.SetDescription("Number of health checks of the node and service, by status.")
+			metric.SetUnit("{checks}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"consul.raft.failure_tolerance",
+		func(metric pdata.Metric) {
+			metric.SetName("consul.raft.failure_tolerance")
+			metric.SetDescription("Number of voting servers the cluster can lose while keeping a quorum.")
+			metric.SetUnit("{servers}")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"consul.raft.healthy",
+		func(metric pdata.Metric) {
+			metric.SetName("consul.raft.healthy")
+			metric.SetDescription("1 if autopilot considers all the servers healthy, otherwise 0.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"consul.raft.server.healthy",
+		func(metric pdata.Metric) {
+			metric.SetName("consul.raft.server.healthy")
+			metric.SetDescription("1 if autopilot considers the server healthy, otherwise 0.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"consul.raft.server.last_contact",
+		func(metric pdata.Metric) {
+			metric.SetName("consul.raft.server.last_contact")
+			metric.SetDescription("Time elapsed since the server was last contacted by the leader, 0 for the leader.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"consul.raft.server.last_index",
+		func(metric pdata.Metric) {
+			metric.SetName("consul.raft.server.last_index")
+			metric.SetDescription("Index of the last Raft log entry of the server.")
+			metric.SetUnit("{index}")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// CheckStatus (The status of the health checks.)
+	CheckStatus string
+	// Node (The name of the node the health check runs on.)
+	Node string
+	// RaftRole (The role of the server in the Raft cluster.)
+	RaftRole string
+	// Server (The name of the Consul server.)
+	Server string
+	// Service (The name of the service the health check belongs to, absent for node health checks.)
+	Service string
+}{
+	"status",
+	"consul.node",
+	"consul.raft.role",
+	"consul.server",
+	"consul.service",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelCheckStatus are the possible values that the label "check_status" can have.
+var LabelCheckStatus = struct {
+	Passing  string
+	Warning  string
+	Critical string
+}{
+	"passing",
+	"warning",
+	"critical",
+}
+
+// LabelRaftRole are the possible values that the label "raft_role" can have.
+var LabelRaftRole = struct {
+	Leader   string
+	Follower string
+	Nonvoter string
+}{
+	"leader",
+	"follower",
+	"nonvoter",
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/consulreceiver/internal/metadata"
+)
+
+const (
+	// healthMetricsCount is the number of metrics recorded from the health
+	// checks.
+	healthMetricsCount = 1
+	// raftMetricsCount is the number of metrics recorded from the autopilot
+	// health of the servers.
+	raftMetricsCount = 5
+)
+
+type consulScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	client *consulClient
+}
+
+func newConsulScraper(logger *zap.Logger, cfg *Config) *consulScraper {
+	return &consulScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (s *consulScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = &consulClient{
+		client:     httpClient,
+		endpoint:   s.cfg.Endpoint,
+		token:      s.cfg.Token,
+		datacenter: s.cfg.Datacenter,
+	}
+	return nil
+}
+
+// checkKey identifies the health checks counted together.
+type checkKey struct {
+	node, service, status string
+}
+
+func (s *consulScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	now := pdata.NewTimestampFromTime(time.Now())
+	metrics := pdata.NewMetricSlice()
+	var errs scrapererror.ScrapeErrors
+
+	if err := s.scrapeHealthChecks(ctx, metrics, now); err != nil {
+		errs.AddPartial(healthMetricsCount, err)
+	}
+	if err := s.scrapeRaft(ctx, metrics, now); err != nil {
+		errs.AddPartial(raftMetricsCount, err)
+	}
+
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			return metric.Gauge().DataPoints().Len() == 0
+		case pdata.MetricDataTypeSum:
+			return metric.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+	return metrics, errs.Combine()
+}
+
+// scrapeHealthChecks counts the health checks of every node and service by
+// status.
+func (s *consulScraper) scrapeHealthChecks(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	checks, err := s.client.healthChecks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list health checks: %w", err)
+	}
+
+	counts := make(map[checkKey]int64)
+	for _, check := range checks {
+		counts[checkKey{check.Node, check.ServiceName, check.Status}]++
+	}
+
+	dps := newMetric(metrics, metadata.M.ConsulHealthChecks).Sum().DataPoints()
+	for key, count := range counts {
+		dp := addPoint(dps, now, count)
+		dp.Attributes().InsertString(metadata.L.Node, key.node)
+		if key.service != "" {
+			dp.Attributes().InsertString(metadata.L.Service, key.service)
+		}
+		dp.Attributes().InsertString(metadata.L.CheckStatus, key.status)
+	}
+	return nil
+}
+
+// scrapeRaft records the health of the servers of the Raft cluster, as seen
+// by autopilot.
+func (s *consulScraper) scrapeRaft(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	health, err := s.client.autopilotHealth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the autopilot health: %w", err)
+	}
+
+	addPoint(newMetric(metrics, metadata.M.ConsulRaftHealthy).Gauge().DataPoints(), now, boolToInt(health.Healthy))
+	addPoint(newMetric(metrics, metadata.M.ConsulRaftFailureTolerance).Gauge().DataPoints(), now, health.FailureTolerance)
+
+	healthy := newMetric(metrics, metadata.M.ConsulRaftServerHealthy).Gauge().DataPoints()
+	lastIndex := newMetric(metrics, metadata.M.ConsulRaftServerLastIndex).Gauge().DataPoints()
+	lastContact := newMetric(metrics, metadata.M.ConsulRaftServerLastContact).Gauge().DataPoints()
+	for _, server := range health.Servers {
+		addServerPoint(healthy, now, boolToInt(server.Healthy), server)
+		addServerPoint(lastIndex, now, server.LastIndex, server)
+
+		contact, err := time.ParseDuration(server.LastContact)
+		if err != nil {
+			s.logger.Debug("invalid last contact of Consul server",
+				zap.String("server", server.Name),
+				zap.String("last_contact", server.LastContact),
+				zap.Error(err))
+			continue
+		}
+		addServerPoint(lastContact, now, contact.Milliseconds(), server)
+	}
+	return nil
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+func addPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64) pdata.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetIntVal(value)
+	return dp
+}
+
+func addServerPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64, server autopilotServer) {
+	dp := addPoint(dps, now, value)
+	dp.Attributes().InsertString(metadata.L.Server, server.Name)
+	dp.Attributes().InsertString(metadata.L.RaftRole, raftRole(server))
+}
+
+func raftRole(server autopilotServer) string {
+	switch {
+	case server.Leader:
+		return metadata.LabelRaftRole.Leader
+	case server.Voter:
+		return metadata.LabelRaftRole.Follower
+	default:
+		return metadata.LabelRaftRole.Nonvoter
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
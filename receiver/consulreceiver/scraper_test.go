@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
+)
+
+const testToken = "6c1d2a4e-9b3f-4e1a-8d7c-2f5b0a9e3c41"
+
+// fakeConsul serves the files of testdata/api for the Consul API endpoints.
+type fakeConsul struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	datacenters map[string]bool
+}
+
+// newFakeConsul starts a fakeConsul responding with the autopilot status and
+// failing the requests of the paths in failing.
+func newFakeConsul(t *testing.T, autopilotStatus int, failing ...string) *fakeConsul {
+	f := &fakeConsul{datacenters: make(map[string]bool)}
+	files := map[string]string{
+		"/v1/health/state/any":          "health.json",
+		"/v1/operator/autopilot/health": "autopilot.json",
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		f.mu.Lock()
+		f.datacenters[req.URL.Query().Get("dc")] = true
+		f.mu.Unlock()
+
+		if req.Header.Get("X-Consul-Token") != testToken {
+			http.Error(rw, "ACL not found", http.StatusForbidden)
+			return
+		}
+		for _, path := range failing {
+			if req.URL.Path == path {
+				http.Error(rw, "Permission denied", http.StatusForbidden)
+				return
+			}
+		}
+		file, ok := files[req.URL.Path]
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "api", file))
+		if !assert.NoError(t, err) {
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/v1/operator/autopilot/health" {
+			rw.WriteHeader(autopilotStatus)
+		}
+		_, _ = rw.Write(body)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func newTestScraper(t *testing.T, endpoint string) *consulScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Token = testToken
+	s := newConsulScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func metricNames(metrics pdata.MetricSlice) []string {
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	return names
+}
+
+func TestScrape(t *testing.T) {
+	// Autopilot responds 429 as server-3 is unhealthy.
+	server := newFakeConsul(t, http.StatusTooManyRequests)
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "consul.health.checks",
+		map[string]string{"consul.node": "web-1", "status": "passing"}))
+	assert.EqualValues(t, 2, metricstestutil.FindIntPoint(t, metrics, "consul.health.checks",
+		map[string]string{"consul.node": "web-1", "consul.service": "web", "status": "passing"}))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "consul.health.checks",
+		map[string]string{"consul.node": "web-1", "consul.service": "web", "status": "critical"}))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "consul.health.checks",
+		map[string]string{"consul.node": "db-1", "consul.service": "postgres", "status": "warning"}))
+
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "consul.raft.healthy", nil))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "consul.raft.failure_tolerance", nil))
+
+	leader := map[string]string{"consul.server": "server-1", "consul.raft.role": "leader"}
+	follower := map[string]string{"consul.server": "server-2", "consul.raft.role": "follower"}
+	nonvoter := map[string]string{"consul.server": "server-3", "consul.raft.role": "nonvoter"}
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "consul.raft.server.healthy", leader))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "consul.raft.server.healthy", nonvoter))
+	assert.EqualValues(t, 4519, metricstestutil.FindIntPoint(t, metrics, "consul.raft.server.last_index", follower))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "consul.raft.server.last_contact", leader))
+	assert.EqualValues(t, 12, metricstestutil.FindIntPoint(t, metrics, "consul.raft.server.last_contact", follower))
+	assert.EqualValues(t, 72000, metricstestutil.FindIntPoint(t, metrics, "consul.raft.server.last_contact", nonvoter))
+
+	assert.Equal(t, map[string]bool{"": true}, server.datacenters)
+}
+
+func TestScrapeDatacenter(t *testing.T) {
+	server := newFakeConsul(t, http.StatusOK)
+	s := newConsulScraper(zap.NewNop(), &Config{})
+	s.cfg.Endpoint = server.URL
+	s.cfg.Token = testToken
+	s.cfg.Datacenter = "eu-west-1"
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"eu-west-1": true}, server.datacenters)
+}
+
+func TestScrapeRaftForbidden(t *testing.T) {
+	server := newFakeConsul(t, http.StatusOK, "/v1/operator/autopilot/health")
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.EqualError(t, err, "failed to get the autopilot health: GET /v1/operator/autopilot/health returned 403: Permission denied")
+	assert.Equal(t, []string{"consul.health.checks"}, metricNames(metrics))
+}
+
+func TestScrapeUnauthorized(t *testing.T) {
+	server := newFakeConsul(t, http.StatusOK)
+	s := newTestScraper(t, server.URL)
+	s.client.token = "wrong"
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list health checks: GET /v1/health/state/any returned 403: ACL not found")
+	assert.Contains(t, err.Error(), "failed to get the autopilot health: GET /v1/operator/autopilot/health returned 403: ACL not found")
+	assert.Equal(t, 0, metrics.Len())
+}
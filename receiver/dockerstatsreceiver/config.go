@@ -57,6 +57,9 @@ type Config struct {
 
 	// Docker client API version. Default is 1.22
 	DockerAPIVersion float64 `mapstructure:"api_version"`
+
+	// Whether the endpoint is the Docker-compatible API socket of Podman.  Default is false
+	PodmanCompatibility bool `mapstructure:"podman_compatibility"`
 }
 
 func (config Config) Validate() error {
@@ -53,6 +53,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Nil(t, dcfg.EnvVarsToMetricLabels)
 
 	assert.False(t, dcfg.ProvidePerCoreCPUMetrics)
+	assert.False(t, dcfg.PodmanCompatibility)
 
 	ascfg := cfg.Receivers[config.NewComponentIDWithName(typeStr, "allsettings")].(*Config)
 	assert.Equal(t, "docker_stats/allsettings", ascfg.ID().String())
@@ -77,4 +78,5 @@ func TestLoadConfig(t *testing.T) {
 	}, ascfg.EnvVarsToMetricLabels)
 
 	assert.True(t, ascfg.ProvidePerCoreCPUMetrics)
+	assert.True(t, ascfg.PodmanCompatibility)
 }
@@ -84,6 +84,7 @@ func (r *Receiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	dConfig.PodmanCompatibility = r.config.PodmanCompatibility
 
 	r.client, err = docker.NewDockerClient(dConfig, r.logger)
 	if err != nil {
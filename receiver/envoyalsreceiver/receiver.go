@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envoyalsreceiver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	alsv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+const (
+	transport = "grpc"
+	format    = "envoy_als"
+)
+
+// alsReceiver implements the Envoy gRPC access log service, converting the
+// access log entries streamed by the proxies to logs.
+type alsReceiver struct {
+	alsv3.UnimplementedAccessLogServiceServer
+
+	cfg          *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+
+	mu     sync.Mutex
+	server *grpc.Server
+}
+
+var _ component.LogsReceiver = (*alsReceiver)(nil)
+
+func newALSReceiver(cfg *Config, settings component.ReceiverCreateSettings, nextConsumer consumer.Logs) *alsReceiver {
+	return &alsReceiver{
+		cfg:          cfg,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID: cfg.ID(),
+			Transport:  transport,
+		}),
+	}
+}
+
+func (r *alsReceiver) Start(_ context.Context, host component.Host) error {
+	opts, err := r.cfg.GRPCServerSettings.ToServerOption(host, r.settings.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	ln, err := r.cfg.GRPCServerSettings.NetAddr.Listen()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.server = grpc.NewServer(opts...)
+	alsv3.RegisterAccessLogServiceServer(r.server, r)
+	go func(server *grpc.Server, ln net.Listener) {
+		if errServe := server.Serve(ln); errServe != nil && !errors.Is(errServe, grpc.ErrServerStopped) {
+			host.ReportFatalError(errServe)
+		}
+	}(r.server, ln)
+	return nil
+}
+
+// Shutdown stops the server without waiting for the streams to end: the
+// proxies keep their access log streams open for as long as they run.
+func (r *alsReceiver) Shutdown(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.server != nil {
+		r.server.Stop()
+	}
+	return nil
+}
+
+// StreamAccessLogs receives the access log entries of a proxy. Only the first
+// message of a stream identifies the proxy and the log.
+func (r *alsReceiver) StreamAccessLogs(stream alsv3.AccessLogService_StreamAccessLogsServer) error {
+	var identifier *alsv3.StreamAccessLogsMessage_Identifier
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&alsv3.StreamAccessLogsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		if identifier == nil {
+			identifier = msg.GetIdentifier()
+		}
+
+		logs := toLogs(identifier, msg)
+		count := logs.LogRecordCount()
+		if count == 0 {
+			continue
+		}
+		ctx := r.obsrecv.StartLogsOp(stream.Context())
+		err = r.nextConsumer.ConsumeLogs(ctx, logs)
+		r.obsrecv.EndLogsOp(ctx, format, count, err)
+		if err != nil {
+			// The access log service has no acknowledgements, the proxy doesn't
+			// resend the entries whatever the response.
+			r.settings.Logger.Error("Failed to consume the access log entries", zap.Error(err))
+		}
+	}
+}
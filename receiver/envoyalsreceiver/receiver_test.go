@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envoyalsreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	datav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	alsv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
+)
+
+func startReceiver(t *testing.T, nextConsumer consumer.Logs) alsv3.AccessLogServiceClient {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r := newALSReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), nextConsumer)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+
+	conn, err := grpc.Dial(cfg.NetAddr.Endpoint, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return alsv3.NewAccessLogServiceClient(conn)
+}
+
+func TestStreamAccessLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	client := startReceiver(t, sink)
+
+	stream, err := client.StreamAccessLogs(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&alsv3.StreamAccessLogsMessage{
+		Identifier: testIdentifier(t),
+		LogEntries: &alsv3.StreamAccessLogsMessage_HttpLogs{HttpLogs: &alsv3.StreamAccessLogsMessage_HTTPAccessLogEntries{
+			LogEntry: []*datav3.HTTPAccessLogEntry{testHTTPEntry(), testHTTPEntry()},
+		}},
+	}))
+	// The following messages of the stream don't repeat the identifier.
+	require.NoError(t, stream.Send(&alsv3.StreamAccessLogsMessage{
+		LogEntries: &alsv3.StreamAccessLogsMessage_TcpLogs{TcpLogs: &alsv3.StreamAccessLogsMessage_TCPAccessLogEntries{
+			LogEntry: []*datav3.TCPAccessLogEntry{testTCPEntry()},
+		}},
+	}))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 3, sink.LogRecordCount())
+	for _, logs := range sink.AllLogs() {
+		name, ok := logs.ResourceLogs().At(0).Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		assert.Equal(t, "productpage.bookinfo", name.StringVal())
+	}
+}
+
+func TestStreamAccessLogsConsumerError(t *testing.T) {
+	client := startReceiver(t, consumertest.NewErr(errors.New("pipeline full")))
+
+	stream, err := client.StreamAccessLogs(context.Background())
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, stream.Send(&alsv3.StreamAccessLogsMessage{
+			Identifier: testIdentifier(t),
+			LogEntries: &alsv3.StreamAccessLogsMessage_HttpLogs{HttpLogs: &alsv3.StreamAccessLogsMessage_HTTPAccessLogEntries{
+				LogEntry: []*datav3.HTTPAccessLogEntry{testHTTPEntry()},
+			}},
+		}))
+	}
+	// The stream stays open, the proxy has no way to resend the entries.
+	_, err = stream.CloseAndRecv()
+	assert.NoError(t, err)
+}
+
+func TestShutdownWithOpenStream(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r := newALSReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := grpc.Dial(cfg.NetAddr.Endpoint, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+	stream, err := alsv3.NewAccessLogServiceClient(conn).StreamAccessLogs(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&alsv3.StreamAccessLogsMessage{Identifier: testIdentifier(t)}))
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown waited for the open stream")
+	}
+}
+
+func TestStartInvalidEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:invalid"
+	r := newALSReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	assert.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+}
@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envoyalsreceiver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	datav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	alsv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	attributeEnvoyNodeID              = "envoy.node.id"
+	attributeEnvoyLogName             = "envoy.log_name"
+	attributeEnvoyUpstreamCluster     = "envoy.upstream.cluster"
+	attributeEnvoyUpstreamAddress     = "envoy.upstream.address"
+	attributeEnvoyUpstreamFailure     = "envoy.upstream.transport_failure_reason"
+	attributeEnvoyRouteName           = "envoy.route_name"
+	attributeEnvoyRequestID           = "envoy.request_id"
+	attributeEnvoyResponseFlags       = "envoy.response_flags"
+	attributeEnvoyResponseCodeDetails = "envoy.response_code_details"
+	attributeEnvoyDuration            = "envoy.duration_ms"
+	attributeEnvoyReceivedBytes       = "envoy.connection.received_bytes"
+	attributeEnvoySentBytes           = "envoy.connection.sent_bytes"
+
+	requestHeaderPrefix  = "http.request.header."
+	responseHeaderPrefix = "http.response.header."
+
+	// Istio sets the name and namespace of the pod in the node metadata.
+	istioMetadataPodName   = "NAME"
+	istioMetadataNamespace = "NAMESPACE"
+)
+
+// toLogs converts the access log entries of a message to logs, with the proxy
+// identified by the first message of the stream as resource.
+func toLogs(identifier *alsv3.StreamAccessLogsMessage_Identifier, msg *alsv3.StreamAccessLogsMessage) pdata.Logs {
+	logs := pdata.NewLogs()
+	httpEntries := msg.GetHttpLogs().GetLogEntry()
+	tcpEntries := msg.GetTcpLogs().GetLogEntry()
+	if len(httpEntries) == 0 && len(tcpEntries) == 0 {
+		return logs
+	}
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	fillResource(identifier, rl.Resource().Attributes())
+	records := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, entry := range httpEntries {
+		lr := records.AppendEmpty()
+		fillHTTPRecord(entry, lr)
+	}
+	for _, entry := range tcpEntries {
+		lr := records.AppendEmpty()
+		fillTCPRecord(entry, lr)
+	}
+	return logs
+}
+
+func fillResource(identifier *alsv3.StreamAccessLogsMessage_Identifier, attrs pdata.AttributeMap) {
+	node := identifier.GetNode()
+	if cluster := node.GetCluster(); cluster != "" {
+		attrs.InsertString(conventions.AttributeServiceName, cluster)
+	}
+	if id := node.GetId(); id != "" {
+		attrs.InsertString(attributeEnvoyNodeID, id)
+	}
+	if logName := identifier.GetLogName(); logName != "" {
+		attrs.InsertString(attributeEnvoyLogName, logName)
+	}
+	fields := node.GetMetadata().GetFields()
+	if name := fields[istioMetadataPodName].GetStringValue(); name != "" {
+		attrs.InsertString(conventions.AttributeK8SPodName, name)
+	}
+	if namespace := fields[istioMetadataNamespace].GetStringValue(); namespace != "" {
+		attrs.InsertString(conventions.AttributeK8SNamespaceName, namespace)
+	}
+}
+
+func fillHTTPRecord(entry *datav3.HTTPAccessLogEntry, lr pdata.LogRecord) {
+	common := entry.GetCommonProperties()
+	request := entry.GetRequest()
+	response := entry.GetResponse()
+	attrs := lr.Attributes()
+	fillCommon(common, lr)
+
+	if common.GetDownstreamDirectRemoteAddress() != nil {
+		// The downstream remote address is the original client when Envoy
+		// trusts the X-Forwarded-For header, the direct one is the peer.
+		insertAddress(attrs, common.GetDownstreamDirectRemoteAddress(), conventions.AttributeNetPeerIP, conventions.AttributeNetPeerPort)
+		if ip, _, ok := socketAddress(common.GetDownstreamRemoteAddress()); ok {
+			attrs.InsertString(conventions.AttributeHTTPClientIP, ip)
+		}
+	} else {
+		insertAddress(attrs, common.GetDownstreamRemoteAddress(), conventions.AttributeNetPeerIP, conventions.AttributeNetPeerPort)
+	}
+
+	method := ""
+	if request.GetRequestMethod() != corev3.RequestMethod_METHOD_UNSPECIFIED {
+		method = request.GetRequestMethod().String()
+		attrs.InsertString(conventions.AttributeHTTPMethod, method)
+	}
+	insertString(attrs, conventions.AttributeHTTPScheme, request.GetScheme())
+	insertString(attrs, conventions.AttributeHTTPHost, request.GetAuthority())
+	insertString(attrs, conventions.AttributeHTTPTarget, request.GetPath())
+	insertString(attrs, conventions.AttributeHTTPUserAgent, request.GetUserAgent())
+	insertString(attrs, attributeEnvoyRequestID, request.GetRequestId())
+	if flavor := httpFlavor(entry.GetProtocolVersion()); flavor != "" {
+		attrs.InsertString(conventions.AttributeHTTPFlavor, flavor)
+	}
+	if request.GetRequestBodyBytes() > 0 {
+		attrs.InsertInt(conventions.AttributeHTTPRequestContentLength, int64(request.GetRequestBodyBytes()))
+	}
+	insertHeaders(attrs, requestHeaderPrefix, request.GetRequestHeaders())
+
+	code := response.GetResponseCode().GetValue()
+	if code != 0 {
+		attrs.InsertInt(conventions.AttributeHTTPStatusCode, int64(code))
+	}
+	if response.GetResponseBodyBytes() > 0 {
+		attrs.InsertInt(conventions.AttributeHTTPResponseContentLength, int64(response.GetResponseBodyBytes()))
+	}
+	insertString(attrs, attributeEnvoyResponseCodeDetails, response.GetResponseCodeDetails())
+	insertHeaders(attrs, responseHeaderPrefix, response.GetResponseHeaders())
+
+	switch {
+	case code >= 500:
+		lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	case code >= 400 || code == 0:
+		// Envoy logs no response code when the request was reset before the
+		// response was sent.
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	default:
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	}
+	lr.SetSeverityText(severityText(lr.SeverityNumber()))
+
+	// The body follows the default format of the Envoy HTTP access logs.
+	path := request.GetOriginalPath()
+	if path == "" {
+		path = request.GetPath()
+	}
+	lr.Body().SetStringVal(fmt.Sprintf(`[%s] "%s %s %s" %d %s %d %d %d "%s" "%s" "%s" "%s" "%s"`,
+		formatStartTime(common),
+		orDash(method), orDash(path), protocol(entry.GetProtocolVersion()),
+		code, orDash(responseFlags(common.GetResponseFlags())),
+		request.GetRequestBodyBytes(), response.GetResponseBodyBytes(), duration(common).Milliseconds(),
+		orDash(request.GetForwardedFor()), orDash(request.GetUserAgent()), orDash(request.GetRequestId()),
+		orDash(request.GetAuthority()), orDash(addressString(common.GetUpstreamRemoteAddress()))))
+}
+
+func fillTCPRecord(entry *datav3.TCPAccessLogEntry, lr pdata.LogRecord) {
+	common := entry.GetCommonProperties()
+	connection := entry.GetConnectionProperties()
+	attrs := lr.Attributes()
+	fillCommon(common, lr)
+	attrs.InsertString(conventions.AttributeNetTransport, conventions.AttributeNetTransportTCP)
+	insertAddress(attrs, common.GetDownstreamRemoteAddress(), conventions.AttributeNetPeerIP, conventions.AttributeNetPeerPort)
+	attrs.InsertInt(attributeEnvoyReceivedBytes, int64(connection.GetReceivedBytes()))
+	attrs.InsertInt(attributeEnvoySentBytes, int64(connection.GetSentBytes()))
+
+	flags := responseFlags(common.GetResponseFlags())
+	if flags != "" {
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+	} else {
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	}
+	lr.SetSeverityText(severityText(lr.SeverityNumber()))
+
+	lr.Body().SetStringVal(fmt.Sprintf(`[%s] %s %d %d %d "%s" "%s"`,
+		formatStartTime(common), orDash(flags),
+		connection.GetReceivedBytes(), connection.GetSentBytes(), duration(common).Milliseconds(),
+		orDash(addressString(common.GetUpstreamRemoteAddress())), orDash(addressString(common.GetDownstreamRemoteAddress()))))
+}
+
+// fillCommon sets the timestamp of the record and the attributes shared by
+// the HTTP and TCP entries.
+func fillCommon(common *datav3.AccessLogCommon, lr pdata.LogRecord) {
+	if common.GetStartTime() != nil {
+		lr.SetTimestamp(pdata.NewTimestampFromTime(common.GetStartTime().AsTime()))
+	}
+	attrs := lr.Attributes()
+	insertAddress(attrs, common.GetDownstreamLocalAddress(), conventions.AttributeNetHostIP, conventions.AttributeNetHostPort)
+	insertString(attrs, attributeEnvoyUpstreamCluster, common.GetUpstreamCluster())
+	insertString(attrs, attributeEnvoyUpstreamAddress, addressString(common.GetUpstreamRemoteAddress()))
+	insertString(attrs, attributeEnvoyUpstreamFailure, common.GetUpstreamTransportFailureReason())
+	insertString(attrs, attributeEnvoyRouteName, common.GetRouteName())
+	insertString(attrs, attributeEnvoyResponseFlags, responseFlags(common.GetResponseFlags()))
+	if common.GetTimeToLastDownstreamTxByte() != nil || common.GetTimeToLastRxByte() != nil {
+		attrs.InsertInt(attributeEnvoyDuration, duration(common).Milliseconds())
+	}
+}
+
+// The short codes Envoy writes for %RESPONSE_FLAGS%, in the order of the
+// proto fields.
+var responseFlagCodes = []struct {
+	code string
+	set  func(*datav3.ResponseFlags) bool
+}{
+	{"LH", (*datav3.ResponseFlags).GetFailedLocalHealthcheck},
+	{"UH", (*datav3.ResponseFlags).GetNoHealthyUpstream},
+	{"UT", (*datav3.ResponseFlags).GetUpstreamRequestTimeout},
+	{"LR", (*datav3.ResponseFlags).GetLocalReset},
+	{"UR", (*datav3.ResponseFlags).GetUpstreamRemoteReset},
+	{"UF", (*datav3.ResponseFlags).GetUpstreamConnectionFailure},
+	{"UC", (*datav3.ResponseFlags).GetUpstreamConnectionTermination},
+	{"UO", (*datav3.ResponseFlags).GetUpstreamOverflow},
+	{"NR", (*datav3.ResponseFlags).GetNoRouteFound},
+	{"DI", (*datav3.ResponseFlags).GetDelayInjected},
+	{"FI", (*datav3.ResponseFlags).GetFaultInjected},
+	{"RL", (*datav3.ResponseFlags).GetRateLimited},
+	{"UAEX", func(f *datav3.ResponseFlags) bool { return f.GetUnauthorizedDetails() != nil }},
+	{"RLSE", (*datav3.ResponseFlags).GetRateLimitServiceError},
+	{"DC", (*datav3.ResponseFlags).GetDownstreamConnectionTermination},
+	{"URX", (*datav3.ResponseFlags).GetUpstreamRetryLimitExceeded},
+	{"SI", (*datav3.ResponseFlags).GetStreamIdleTimeout},
+	{"IH", (*datav3.ResponseFlags).GetInvalidEnvoyRequestHeaders},
+	{"DPE", (*datav3.ResponseFlags).GetDownstreamProtocolError},
+	{"UMSDR", (*datav3.ResponseFlags).GetUpstreamMaxStreamDurationReached},
+	{"RFCF", (*datav3.ResponseFlags).GetResponseFromCacheFilter},
+	{"NFCF", (*datav3.ResponseFlags).GetNoFilterConfigFound},
+	{"DT", (*datav3.ResponseFlags).GetDurationTimeout},
+	{"UPE", (*datav3.ResponseFlags).GetUpstreamProtocolError},
+	{"NC", (*datav3.ResponseFlags).GetNoClusterFound},
+	{"OM", (*datav3.ResponseFlags).GetOverloadManager},
+}
+
+// responseFlags returns the comma separated short codes of the set flags.
+func responseFlags(flags *datav3.ResponseFlags) string {
+	if flags == nil {
+		return ""
+	}
+	var codes []string
+	for _, c := range responseFlagCodes {
+		if c.set(flags) {
+			codes = append(codes, c.code)
+		}
+	}
+	return strings.Join(codes, ",")
+}
+
+func httpFlavor(version datav3.HTTPAccessLogEntry_HTTPVersion) string {
+	switch version {
+	case datav3.HTTPAccessLogEntry_HTTP10:
+		return conventions.AttributeHTTPFlavorHTTP10
+	case datav3.HTTPAccessLogEntry_HTTP11:
+		return conventions.AttributeHTTPFlavorHTTP11
+	case datav3.HTTPAccessLogEntry_HTTP2:
+		return conventions.AttributeHTTPFlavorHTTP20
+	case datav3.HTTPAccessLogEntry_HTTP3:
+		return conventions.AttributeHTTPFlavorQUIC
+	}
+	return ""
+}
+
+// protocol returns the protocol as written by Envoy for %PROTOCOL%.
+func protocol(version datav3.HTTPAccessLogEntry_HTTPVersion) string {
+	switch version {
+	case datav3.HTTPAccessLogEntry_HTTP10:
+		return "HTTP/1.0"
+	case datav3.HTTPAccessLogEntry_HTTP11:
+		return "HTTP/1.1"
+	case datav3.HTTPAccessLogEntry_HTTP2:
+		return "HTTP/2"
+	case datav3.HTTPAccessLogEntry_HTTP3:
+		return "HTTP/3"
+	}
+	return "-"
+}
+
+// duration returns the time from the start of the request, or connection,
+// until its last byte was sent downstream.
+func duration(common *datav3.AccessLogCommon) time.Duration {
+	if d := common.GetTimeToLastDownstreamTxByte(); d != nil {
+		return d.AsDuration()
+	}
+	return common.GetTimeToLastRxByte().AsDuration()
+}
+
+func formatStartTime(common *datav3.AccessLogCommon) string {
+	if common.GetStartTime() == nil {
+		return "-"
+	}
+	return common.GetStartTime().AsTime().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func socketAddress(address *corev3.Address) (string, int64, bool) {
+	sa := address.GetSocketAddress()
+	if sa.GetAddress() == "" {
+		return "", 0, false
+	}
+	return sa.GetAddress(), int64(sa.GetPortValue()), true
+}
+
+func addressString(address *corev3.Address) string {
+	if ip, port, ok := socketAddress(address); ok {
+		return fmt.Sprintf("%s:%d", ip, port)
+	}
+	return address.GetPipe().GetPath()
+}
+
+func insertAddress(attrs pdata.AttributeMap, address *corev3.Address, ipKey, portKey string) {
+	ip, port, ok := socketAddress(address)
+	if !ok {
+		return
+	}
+	attrs.InsertString(ipKey, ip)
+	if port != 0 {
+		attrs.InsertInt(portKey, port)
+	}
+}
+
+// insertHeaders inserts the logged headers as string array attributes, like
+// the semantic conventions for the HTTP headers captured by instrumentations.
+func insertHeaders(attrs pdata.AttributeMap, prefix string, headers map[string]string) {
+	for name, value := range headers {
+		av := pdata.NewAttributeValueArray()
+		av.ArrayVal().AppendEmpty().SetStringVal(value)
+		attrs.Insert(prefix+strings.ReplaceAll(strings.ToLower(name), "-", "_"), av)
+	}
+}
+
+func insertString(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func severityText(severity pdata.SeverityNumber) string {
+	switch severity {
+	case pdata.SeverityNumberERROR:
+		return "ERROR"
+	case pdata.SeverityNumberWARN:
+		return "WARN"
+	}
+	return "INFO"
+}
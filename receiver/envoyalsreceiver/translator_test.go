@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envoyalsreceiver
+
+import (
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	datav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	alsv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var startTime = time.Date(2021, 10, 4, 12, 30, 15, 123000000, time.UTC)
+
+func socket(ip string, port uint32) *corev3.Address {
+	return &corev3.Address{Address: &corev3.Address_SocketAddress{SocketAddress: &corev3.SocketAddress{
+		Address:       ip,
+		PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: port},
+	}}}
+}
+
+func testIdentifier(t *testing.T) *alsv3.StreamAccessLogsMessage_Identifier {
+	metadata, err := structpb.NewStruct(map[string]interface{}{
+		"NAME":      "productpage-v1-6b746f74dc-9stvs",
+		"NAMESPACE": "bookinfo",
+	})
+	require.NoError(t, err)
+	return &alsv3.StreamAccessLogsMessage_Identifier{
+		Node: &corev3.Node{
+			Id:       "sidecar~10.44.0.7~productpage-v1-6b746f74dc-9stvs.bookinfo~bookinfo.svc.cluster.local",
+			Cluster:  "productpage.bookinfo",
+			Metadata: metadata,
+		},
+		LogName: "http_envoy_accesslog",
+	}
+}
+
+func testHTTPEntry() *datav3.HTTPAccessLogEntry {
+	return &datav3.HTTPAccessLogEntry{
+		CommonProperties: &datav3.AccessLogCommon{
+			DownstreamRemoteAddress:       socket("83.164.160.102", 0),
+			DownstreamDirectRemoteAddress: socket("10.44.0.1", 41234),
+			DownstreamLocalAddress:        socket("10.44.0.7", 9080),
+			StartTime:                     timestamppb.New(startTime),
+			TimeToLastRxByte:              durationpb.New(time.Millisecond),
+			TimeToLastDownstreamTxByte:    durationpb.New(35 * time.Millisecond),
+			UpstreamRemoteAddress:         socket("10.44.0.9", 9080),
+			UpstreamCluster:               "outbound|9080||reviews.bookinfo.svc.cluster.local",
+			RouteName:                     "default",
+		},
+		ProtocolVersion: datav3.HTTPAccessLogEntry_HTTP11,
+		Request: &datav3.HTTPRequestProperties{
+			RequestMethod:    corev3.RequestMethod_GET,
+			Scheme:           "http",
+			Authority:        "reviews:9080",
+			Path:             "/reviews/0",
+			UserAgent:        "curl/7.68.0",
+			ForwardedFor:     "83.164.160.102",
+			RequestId:        "8d5b7c2e-4f2b-9a6c-b0d5-2f5cb6f0e1a3",
+			RequestBodyBytes: 12,
+			RequestHeaders:   map[string]string{"X-B3-TraceId": "463ac35c9f6413ad"},
+		},
+		Response: &datav3.HTTPResponseProperties{
+			ResponseCode:        wrapperspb.UInt32(503),
+			ResponseBodyBytes:   19,
+			ResponseCodeDetails: "upstream_reset_before_response_started{connection_failure}",
+		},
+	}
+}
+
+func testTCPEntry() *datav3.TCPAccessLogEntry {
+	return &datav3.TCPAccessLogEntry{
+		CommonProperties: &datav3.AccessLogCommon{
+			DownstreamRemoteAddress:    socket("10.44.0.1", 52100),
+			DownstreamLocalAddress:     socket("10.44.0.12", 27017),
+			StartTime:                  timestamppb.New(startTime),
+			TimeToLastDownstreamTxByte: durationpb.New(2 * time.Second),
+			UpstreamRemoteAddress:      socket("10.44.0.13", 27017),
+			UpstreamCluster:            "outbound|27017||mongodb.bookinfo.svc.cluster.local",
+		},
+		ConnectionProperties: &datav3.ConnectionProperties{ReceivedBytes: 1024, SentBytes: 4096},
+	}
+}
+
+func TestToLogsHTTP(t *testing.T) {
+	entry := testHTTPEntry()
+	entry.CommonProperties.ResponseFlags = &datav3.ResponseFlags{UpstreamConnectionFailure: true, UpstreamRetryLimitExceeded: true}
+	logs := toLogs(testIdentifier(t), &alsv3.StreamAccessLogsMessage{
+		LogEntries: &alsv3.StreamAccessLogsMessage_HttpLogs{HttpLogs: &alsv3.StreamAccessLogsMessage_HTTPAccessLogEntries{
+			LogEntry: []*datav3.HTTPAccessLogEntry{entry},
+		}},
+	})
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":       "productpage.bookinfo",
+		"envoy.node.id":      "sidecar~10.44.0.7~productpage-v1-6b746f74dc-9stvs.bookinfo~bookinfo.svc.cluster.local",
+		"envoy.log_name":     "http_envoy_accesslog",
+		"k8s.pod.name":       "productpage-v1-6b746f74dc-9stvs",
+		"k8s.namespace.name": "bookinfo",
+	}, attributesOf(rl.Resource().Attributes()))
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.NewTimestampFromTime(startTime), lr.Timestamp())
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	assert.Equal(t, "ERROR", lr.SeverityText())
+	assert.Equal(t, map[string]interface{}{
+		"net.peer.ip":                      "10.44.0.1",
+		"net.peer.port":                    int64(41234),
+		"net.host.ip":                      "10.44.0.7",
+		"net.host.port":                    int64(9080),
+		"http.client_ip":                   "83.164.160.102",
+		"http.method":                      "GET",
+		"http.scheme":                      "http",
+		"http.host":                        "reviews:9080",
+		"http.target":                      "/reviews/0",
+		"http.user_agent":                  "curl/7.68.0",
+		"http.flavor":                      "1.1",
+		"http.status_code":                 int64(503),
+		"http.request_content_length":      int64(12),
+		"http.response_content_length":     int64(19),
+		"http.request.header.x_b3_traceid": []interface{}{"463ac35c9f6413ad"},
+		"envoy.request_id":                 "8d5b7c2e-4f2b-9a6c-b0d5-2f5cb6f0e1a3",
+		"envoy.response_code_details":      "upstream_reset_before_response_started{connection_failure}",
+		"envoy.response_flags":             "UF,URX",
+		"envoy.upstream.cluster":           "outbound|9080||reviews.bookinfo.svc.cluster.local",
+		"envoy.upstream.address":           "10.44.0.9:9080",
+		"envoy.route_name":                 "default",
+		"envoy.duration_ms":                int64(35),
+	}, attributesOf(lr.Attributes()))
+	assert.Equal(t, `[2021-10-04T12:30:15.123Z] "GET /reviews/0 HTTP/1.1" 503 UF,URX 12 19 35 "83.164.160.102" "curl/7.68.0" "8d5b7c2e-4f2b-9a6c-b0d5-2f5cb6f0e1a3" "reviews:9080" "10.44.0.9:9080"`, lr.Body().StringVal())
+}
+
+func TestToLogsHTTPSeverity(t *testing.T) {
+	tests := []struct {
+		code     uint32
+		severity pdata.SeverityNumber
+	}{
+		{code: 200, severity: pdata.SeverityNumberINFO},
+		{code: 302, severity: pdata.SeverityNumberINFO},
+		{code: 404, severity: pdata.SeverityNumberWARN},
+		{code: 0, severity: pdata.SeverityNumberWARN},
+		{code: 500, severity: pdata.SeverityNumberERROR},
+	}
+	for _, tt := range tests {
+		entry := testHTTPEntry()
+		entry.Response.ResponseCode = wrapperspb.UInt32(tt.code)
+		logs := toLogs(nil, &alsv3.StreamAccessLogsMessage{
+			LogEntries: &alsv3.StreamAccessLogsMessage_HttpLogs{HttpLogs: &alsv3.StreamAccessLogsMessage_HTTPAccessLogEntries{
+				LogEntry: []*datav3.HTTPAccessLogEntry{entry},
+			}},
+		})
+		lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		assert.Equal(t, tt.severity, lr.SeverityNumber(), "code %d", tt.code)
+		_, ok := lr.Attributes().Get("http.status_code")
+		assert.Equal(t, tt.code != 0, ok)
+	}
+}
+
+func TestToLogsTCP(t *testing.T) {
+	logs := toLogs(testIdentifier(t), &alsv3.StreamAccessLogsMessage{
+		LogEntries: &alsv3.StreamAccessLogsMessage_TcpLogs{TcpLogs: &alsv3.StreamAccessLogsMessage_TCPAccessLogEntries{
+			LogEntry: []*datav3.TCPAccessLogEntry{testTCPEntry()},
+		}},
+	})
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.Equal(t, map[string]interface{}{
+		"net.transport":                   "ip_tcp",
+		"net.peer.ip":                     "10.44.0.1",
+		"net.peer.port":                   int64(52100),
+		"net.host.ip":                     "10.44.0.12",
+		"net.host.port":                   int64(27017),
+		"envoy.connection.received_bytes": int64(1024),
+		"envoy.connection.sent_bytes":     int64(4096),
+		"envoy.upstream.cluster":          "outbound|27017||mongodb.bookinfo.svc.cluster.local",
+		"envoy.upstream.address":          "10.44.0.13:27017",
+		"envoy.duration_ms":               int64(2000),
+	}, attributesOf(lr.Attributes()))
+	assert.Equal(t, `[2021-10-04T12:30:15.123Z] - 1024 4096 2000 "10.44.0.13:27017" "10.44.0.1:52100"`, lr.Body().StringVal())
+}
+
+func TestToLogsEmpty(t *testing.T) {
+	logs := toLogs(testIdentifier(t), &alsv3.StreamAccessLogsMessage{Identifier: testIdentifier(t)})
+	assert.Equal(t, 0, logs.ResourceLogs().Len())
+}
+
+func TestResponseFlags(t *testing.T) {
+	assert.Equal(t, "", responseFlags(nil))
+	assert.Equal(t, "", responseFlags(&datav3.ResponseFlags{}))
+	assert.Equal(t, "NR", responseFlags(&datav3.ResponseFlags{NoRouteFound: true}))
+	assert.Equal(t, "UAEX,DC", responseFlags(&datav3.ResponseFlags{
+		UnauthorizedDetails:             &datav3.ResponseFlags_Unauthorized{},
+		DownstreamConnectionTermination: true,
+	}))
+}
+
+func attributesOf(attrs pdata.AttributeMap) map[string]interface{} {
+	m := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			m[k] = v.IntVal()
+		case pdata.AttributeValueTypeArray:
+			var values []interface{}
+			for i := 0; i < v.ArrayVal().Len(); i++ {
+				values = append(values, v.ArrayVal().At(i).StringVal())
+			}
+			m[k] = values
+		default:
+			m[k] = v.StringVal()
+		}
+		return true
+	})
+	return m
+}
@@ -0,0 +1,340 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v3"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/input/file"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+)
+
+// knownFilesKey is the key under which the file input operator persists the
+// fingerprints and offsets of the files it reads.
+const knownFilesKey = "knownFiles"
+
+// AcquisitionMetricsConfig configures the metrics reported about how the
+// receiver keeps up with the files it tails.
+type AcquisitionMetricsConfig struct {
+	// Enabled turns the acquisition metrics on.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval at which the tailed files are inspected for read lag and rotations.
+	Interval time.Duration `mapstructure:"interval"`
+	// MaxFiles bounds the number of files reported with their own file tag.
+	// Additional files are reported under the "_other" tag.
+	MaxFiles int `mapstructure:"max_files"`
+}
+
+// persistedReader mirrors the state persisted by the file input operator for each file.
+type persistedReader struct {
+	Fingerprint *struct {
+		FirstBytes []byte
+	}
+	Offset int64
+}
+
+type trackedFile struct {
+	info   os.FileInfo
+	offset int64
+	known  bool
+}
+
+// acquisitionTracker reports acquisition metrics for the files tailed by a
+// receiver. Offsets are taken from the state persisted by the file input
+// operator and matched to the files on disk by their fingerprint.
+type acquisitionTracker struct {
+	logger          *zap.Logger
+	ctx             context.Context
+	interval        time.Duration
+	maxFiles        int
+	include         []string
+	exclude         []string
+	fingerprintSize int
+
+	mu         sync.Mutex
+	knownFiles []persistedReader
+	files      map[string]*trackedFile
+	fileTags   map[string]string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ stanza.Observer = (*acquisitionTracker)(nil)
+
+func newAcquisitionTracker(id config.ComponentID, logger *zap.Logger, cfg AcquisitionMetricsConfig, inputCfg *file.InputConfig) (*acquisitionTracker, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("acquisition_metrics interval must be a positive duration")
+	}
+	if cfg.MaxFiles < 0 {
+		return nil, errors.New("acquisition_metrics max_files can't be negative")
+	}
+
+	ctx, err := tag.New(context.Background(), tag.Upsert(tagReceiver, id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &acquisitionTracker{
+		logger:          logger,
+		ctx:             ctx,
+		interval:        cfg.Interval,
+		maxFiles:        cfg.MaxFiles,
+		include:         inputCfg.Include,
+		exclude:         inputCfg.Exclude,
+		fingerprintSize: int(inputCfg.FingerprintSize),
+		files:           map[string]*trackedFile{},
+		fileTags:        map[string]string{},
+	}, nil
+}
+
+func (t *acquisitionTracker) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				t.scan()
+			}
+		}
+	}()
+	return nil
+}
+
+func (t *acquisitionTracker) Shutdown(context.Context) error {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	t.wg.Wait()
+	return nil
+}
+
+// ObservePersisted keeps the latest offsets persisted by the file input operator.
+func (t *acquisitionTracker) ObservePersisted(key string, value []byte) {
+	if key != knownFilesKey && !strings.HasSuffix(key, "."+knownFilesKey) {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(value))
+	var count int
+	if err := dec.Decode(&count); err != nil {
+		t.logger.Debug("Failed to decode known files count", zap.Error(err))
+		return
+	}
+	knownFiles := make([]persistedReader, 0, count)
+	for i := 0; i < count; i++ {
+		var reader persistedReader
+		if err := dec.Decode(&reader); err != nil {
+			t.logger.Debug("Failed to decode known file", zap.Error(err))
+			return
+		}
+		knownFiles = append(knownFiles, reader)
+	}
+
+	t.mu.Lock()
+	t.knownFiles = knownFiles
+	t.mu.Unlock()
+}
+
+// ObserveLogs counts the emitted log records per file. Records are attributed
+// to their file.path attribute, or to file.name when the path isn't included.
+func (t *acquisitionTracker) ObserveLogs(ld pdata.Logs) {
+	lines := map[string]int64{}
+	var names []string
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				attrs := logs.At(k).Attributes()
+				name := ""
+				if v, ok := attrs.Get("file.path"); ok {
+					name = v.StringVal()
+				} else if v, ok := attrs.Get("file.name"); ok {
+					name = v.StringVal()
+				}
+				if _, ok := lines[name]; !ok {
+					names = append(names, name)
+				}
+				lines[name]++
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range names {
+		count := lines[name]
+		if name == "" {
+			stats.Record(t.ctx, statLinesEmitted.M(count))
+			continue
+		}
+		t.recordForFile(name, statLinesEmitted.M(count))
+	}
+}
+
+// scan inspects the tailed files and records their read lag, bytes read and rotations.
+func (t *acquisitionTracker) scan() {
+	paths := t.matchedPaths()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var totalLag int64
+	files := make(map[string]*trackedFile, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		fingerprint, err := readFingerprint(path, t.fingerprintSize)
+		if err != nil {
+			t.logger.Debug("Failed to read fingerprint", zap.String("path", path), zap.Error(err))
+			continue
+		}
+
+		current := &trackedFile{info: info}
+		current.offset, current.known = t.offsetOf(fingerprint)
+		files[path] = current
+
+		prev := t.files[path]
+		rotated := prev != nil && (!os.SameFile(prev.info, info) || info.Size() < prev.info.Size())
+		if rotated {
+			t.recordForFile(path, statRotations.M(1))
+		}
+
+		if !current.known {
+			continue
+		}
+
+		// The first time a file is seen only establishes the baseline.
+		switch {
+		case rotated:
+			t.recordForFile(path, statBytesRead.M(current.offset))
+		case prev != nil && prev.known && current.offset > prev.offset:
+			t.recordForFile(path, statBytesRead.M(current.offset-prev.offset))
+		}
+
+		lag := info.Size() - current.offset
+		if lag < 0 {
+			lag = 0
+		}
+		totalLag += lag
+		if fileTag := t.fileTag(path); fileTag != otherFiles {
+			_ = stats.RecordWithTags(t.ctx, []tag.Mutator{tag.Upsert(tagFile, fileTag)}, statFileLag.M(lag))
+		}
+	}
+	t.files = files
+
+	stats.Record(t.ctx, statReadLag.M(totalLag), statTrackedFiles.M(int64(len(files))))
+}
+
+func (t *acquisitionTracker) recordForFile(name string, measurement stats.Measurement) {
+	_ = stats.RecordWithTags(t.ctx, []tag.Mutator{tag.Upsert(tagFile, t.fileTag(name))}, measurement)
+}
+
+// fileTag returns the value of the file tag for the given file, keeping the
+// number of distinct values bounded by maxFiles.
+func (t *acquisitionTracker) fileTag(name string) string {
+	if fileTag, ok := t.fileTags[name]; ok {
+		return fileTag
+	}
+	if len(t.fileTags) >= t.maxFiles {
+		return otherFiles
+	}
+	t.fileTags[name] = name
+	return name
+}
+
+// offsetOf returns the offset of the known file with the longest fingerprint
+// that is a prefix of the given one.
+func (t *acquisitionTracker) offsetOf(fingerprint []byte) (int64, bool) {
+	var offset int64
+	matched := -1
+	for _, reader := range t.knownFiles {
+		if reader.Fingerprint == nil || len(reader.Fingerprint.FirstBytes) == 0 {
+			continue
+		}
+		if len(reader.Fingerprint.FirstBytes) >= matched && bytes.HasPrefix(fingerprint, reader.Fingerprint.FirstBytes) {
+			matched = len(reader.Fingerprint.FirstBytes)
+			offset = reader.Offset
+		}
+	}
+	return offset, matched >= 0
+}
+
+// matchedPaths returns the files matched by the receiver, the same way the file input operator finds them.
+func (t *acquisitionTracker) matchedPaths() []string {
+	seen := map[string]struct{}{}
+	var paths []string
+	for _, include := range t.include {
+		matches, _ := filepath.Glob(include)
+	MATCH:
+		for _, match := range matches {
+			for _, exclude := range t.exclude {
+				if excluded, _ := doublestar.PathMatch(exclude, match); excluded {
+					continue MATCH
+				}
+			}
+			if _, ok := seen[match]; ok {
+				continue
+			}
+			seen[match] = struct{}{}
+			paths = append(paths, match)
+		}
+	}
+	return paths
+}
+
+func readFingerprint(path string, size int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return buf[:n], nil
+}
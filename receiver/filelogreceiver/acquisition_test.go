@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/input/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func persistKnownFiles(t *testing.T, tracker *acquisitionTracker, readers ...persistedReader) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	require.NoError(t, enc.Encode(len(readers)))
+	for _, reader := range readers {
+		require.NoError(t, enc.Encode(reader))
+	}
+	tracker.ObservePersisted("$.file_input."+knownFilesKey, buf.Bytes())
+}
+
+func knownFile(firstBytes string, offset int64) persistedReader {
+	reader := persistedReader{Offset: offset}
+	reader.Fingerprint = &struct{ FirstBytes []byte }{FirstBytes: []byte(firstBytes)}
+	return reader
+}
+
+func viewValue(t *testing.T, name string, tags map[string]string) (float64, bool) {
+	rows, err := view.RetrieveData(name)
+	require.NoError(t, err)
+ROWS:
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if want, ok := tags[tg.Key.Name()]; ok && want != tg.Value {
+				continue ROWS
+			}
+		}
+		switch data := row.Data.(type) {
+		case *view.SumData:
+			return data.Value, true
+		case *view.LastValueData:
+			return data.Value, true
+		}
+	}
+	return 0, false
+}
+
+// newTestTracker creates a tracker with a unique receiver id, since the views
+// keep their data across tests.
+func newTestTracker(t *testing.T, include string, maxFiles int) (*acquisitionTracker, string) {
+	// The views may already be registered by NewFactory.
+	_ = view.Register(MetricViews()...)
+	for _, v := range MetricViews() {
+		require.NotNil(t, view.Find(v.Name))
+	}
+
+	inputCfg := file.NewInputConfig("file_input")
+	inputCfg.Include = []string{include}
+	id := config.NewComponentIDWithName(typeStr, fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano()))
+	tracker, err := newAcquisitionTracker(
+		id,
+		zap.NewNop(),
+		AcquisitionMetricsConfig{Enabled: true, Interval: time.Second, MaxFiles: maxFiles},
+		inputCfg,
+	)
+	require.NoError(t, err)
+	return tracker, id.String()
+}
+
+func TestAcquisitionTrackerLagAndBytesRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acquisition")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("line one\nline two\n"), 0600))
+
+	tracker, receiver := newTestTracker(t, filepath.Join(dir, "*.log"), 10)
+	receiverTags := map[string]string{"receiver": receiver}
+	fileTags := map[string]string{"receiver": receiver, "file": path}
+
+	persistKnownFiles(t, tracker, knownFile("line one\n", 9))
+	tracker.scan()
+
+	lag, ok := viewValue(t, "filelog_receiver_file_read_lag", fileTags)
+	require.True(t, ok)
+	assert.Equal(t, float64(9), lag)
+	total, ok := viewValue(t, "filelog_receiver_read_lag", receiverTags)
+	require.True(t, ok)
+	assert.Equal(t, float64(9), total)
+	files, ok := viewValue(t, "filelog_receiver_tracked_files", receiverTags)
+	require.True(t, ok)
+	assert.Equal(t, float64(1), files)
+	// The first scan only establishes the baseline.
+	_, ok = viewValue(t, "filelog_receiver_file_bytes_read", fileTags)
+	assert.False(t, ok)
+
+	persistKnownFiles(t, tracker, knownFile("line one\nline two\n", 18))
+	tracker.scan()
+
+	lag, _ = viewValue(t, "filelog_receiver_file_read_lag", fileTags)
+	assert.Equal(t, float64(0), lag)
+	read, ok := viewValue(t, "filelog_receiver_file_bytes_read", fileTags)
+	require.True(t, ok)
+	assert.Equal(t, float64(9), read)
+}
+
+func TestAcquisitionTrackerRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acquisition")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("first file content\n"), 0600))
+
+	tracker, receiver := newTestTracker(t, path, 10)
+	fileTags := map[string]string{"receiver": receiver, "file": path}
+
+	persistKnownFiles(t, tracker, knownFile("first file content\n", 19))
+	tracker.scan()
+
+	// Rotate by moving the file away and creating a new one at the same path.
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, ioutil.WriteFile(path, []byte("new\n"), 0600))
+	persistKnownFiles(t, tracker, knownFile("first file content\n", 19), knownFile("new\n", 4))
+	tracker.scan()
+
+	rotations, ok := viewValue(t, "filelog_receiver_rotations", fileTags)
+	require.True(t, ok)
+	assert.Equal(t, float64(1), rotations)
+	read, ok := viewValue(t, "filelog_receiver_file_bytes_read", fileTags)
+	require.True(t, ok)
+	assert.Equal(t, float64(4), read)
+}
+
+func TestAcquisitionTrackerLinesEmitted(t *testing.T) {
+	tracker, receiver := newTestTracker(t, "unused", 1)
+
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, name := range []string{"a.log", "a.log", "b.log"} {
+		logs.AppendEmpty().Attributes().InsertString("file.name", name)
+	}
+	logs.AppendEmpty()
+	tracker.ObserveLogs(ld)
+
+	total, ok := viewValue(t, "filelog_receiver_lines_emitted", map[string]string{"receiver": receiver})
+	require.True(t, ok)
+	assert.Equal(t, float64(4), total)
+
+	// Only one file gets its own tag, the others are reported as "_other".
+	perFile, ok := viewValue(t, "filelog_receiver_file_lines_emitted", map[string]string{"receiver": receiver, "file": "a.log"})
+	require.True(t, ok)
+	assert.Equal(t, float64(2), perFile)
+	other, ok := viewValue(t, "filelog_receiver_file_lines_emitted", map[string]string{"receiver": receiver, "file": otherFiles})
+	require.True(t, ok)
+	assert.Equal(t, float64(1), other)
+}
+
+func TestAcquisitionMetricsConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.Input = map[string]interface{}{"include": []string{"testdata/simple.log"}}
+
+	observer, err := ReceiverType{}.CreateObserver(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.Nil(t, observer)
+
+	cfg.AcquisitionMetrics.Enabled = true
+	observer, err = ReceiverType{}.CreateObserver(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, observer)
+
+	cfg.AcquisitionMetrics.Interval = 0
+	_, err = ReceiverType{}.CreateObserver(componenttest.NewNopReceiverCreateSettings(), cfg)
+	assert.EqualError(t, err, "acquisition_metrics interval must be a positive duration")
+}
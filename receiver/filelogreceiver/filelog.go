@@ -15,8 +15,11 @@
 package filelogreceiver
 
 import (
+	"time"
+
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/input/file"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"gopkg.in/yaml.v2"
@@ -28,6 +31,7 @@ const typeStr = "filelog"
 
 // NewFactory creates a factory for filelog receiver
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(MetricViews()...)
 	return stanza.NewFactory(ReceiverType{})
 }
 
@@ -55,6 +59,10 @@ func createDefaultConfig() *FileLogConfig {
 			},
 		},
 		Input: stanza.InputConfig{},
+		AcquisitionMetrics: AcquisitionMetricsConfig{
+			Interval: 10 * time.Second,
+			MaxFiles: 20,
+		},
 	}
 }
 
@@ -66,16 +74,38 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) stanza.BaseConfig {
 // FileLogConfig defines configuration for the filelog receiver
 type FileLogConfig struct {
 	stanza.BaseConfig `mapstructure:",squash"`
-	Input             stanza.InputConfig `mapstructure:",remain"`
+	// AcquisitionMetrics configures the metrics reported about the tailed files.
+	AcquisitionMetrics AcquisitionMetricsConfig `mapstructure:"acquisition_metrics"`
+	Input              stanza.InputConfig       `mapstructure:",remain"`
 }
 
 // DecodeInputConfig unmarshals the input operator
 func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
+	inputCfg, err := decodeFileInputConfig(cfg.(*FileLogConfig))
+	if err != nil {
+		return nil, err
+	}
+	return &operator.Config{Builder: inputCfg}, nil
+}
+
+// CreateObserver creates the tracker reporting acquisition metrics when they are enabled.
+func (f ReceiverType) CreateObserver(params component.ReceiverCreateSettings, cfg config.Receiver) (stanza.Observer, error) {
 	logConfig := cfg.(*FileLogConfig)
+	if !logConfig.AcquisitionMetrics.Enabled {
+		return nil, nil
+	}
+	inputCfg, err := decodeFileInputConfig(logConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newAcquisitionTracker(cfg.ID(), params.Logger, logConfig.AcquisitionMetrics, inputCfg)
+}
+
+func decodeFileInputConfig(logConfig *FileLogConfig) (*file.InputConfig, error) {
 	yamlBytes, _ := yaml.Marshal(logConfig.Input)
 	inputCfg := file.NewInputConfig("file_input")
 	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
 		return nil, err
 	}
-	return &operator.Config{Builder: inputCfg}, nil
+	return inputCfg, nil
 }
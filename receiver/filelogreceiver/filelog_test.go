@@ -294,6 +294,10 @@ func testdataConfigYamlAsMap() *FileLogConfig {
 			},
 			"start_at": "beginning",
 		},
+		AcquisitionMetrics: AcquisitionMetricsConfig{
+			Interval: 10 * time.Second,
+			MaxFiles: 20,
+		},
 	}
 }
 
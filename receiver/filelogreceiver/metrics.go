@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const otherFiles = "_other"
+
+var (
+	tagReceiver, _ = tag.NewKey("receiver")
+	tagFile, _     = tag.NewKey("file")
+
+	statBytesRead    = stats.Int64("filelog_receiver_bytes_read", "Number of bytes read from the tailed files", stats.UnitBytes)
+	statLinesEmitted = stats.Int64("filelog_receiver_lines_emitted", "Number of log records emitted from the tailed files", stats.UnitDimensionless)
+	statRotations    = stats.Int64("filelog_receiver_rotations", "Number of rotations or truncations detected on the tailed files", stats.UnitDimensionless)
+	statFileLag      = stats.Int64("filelog_receiver_file_read_lag", "Bytes of a tailed file that have not been read yet", stats.UnitBytes)
+	statReadLag      = stats.Int64("filelog_receiver_read_lag", "Bytes of all tailed files that have not been read yet", stats.UnitBytes)
+	statTrackedFiles = stats.Int64("filelog_receiver_tracked_files", "Number of files matched by the receiver", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metric views for the acquisition metrics of the filelog receiver.
+func MetricViews() []*view.View {
+	receiverTags := []tag.Key{tagReceiver}
+	fileTags := []tag.Key{tagReceiver, tagFile}
+
+	return []*view.View{
+		{
+			Name:        statBytesRead.Name(),
+			Measure:     statBytesRead,
+			Description: statBytesRead.Description(),
+			TagKeys:     receiverTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        "filelog_receiver_file_bytes_read",
+			Measure:     statBytesRead,
+			Description: "Number of bytes read per tailed file",
+			TagKeys:     fileTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statLinesEmitted.Name(),
+			Measure:     statLinesEmitted,
+			Description: statLinesEmitted.Description(),
+			TagKeys:     receiverTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        "filelog_receiver_file_lines_emitted",
+			Measure:     statLinesEmitted,
+			Description: "Number of log records emitted per tailed file",
+			TagKeys:     fileTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statRotations.Name(),
+			Measure:     statRotations,
+			Description: statRotations.Description(),
+			TagKeys:     fileTags,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        statFileLag.Name(),
+			Measure:     statFileLag,
+			Description: statFileLag.Description(),
+			TagKeys:     fileTags,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        statReadLag.Name(),
+			Measure:     statReadLag,
+			Description: statReadLag.Description(),
+			TagKeys:     receiverTags,
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        statTrackedFiles.Name(),
+			Measure:     statTrackedFiles,
+			Description: statTrackedFiles.Description(),
+			TagKeys:     receiverTags,
+			Aggregation: view.LastValue(),
+		},
+	}
+}
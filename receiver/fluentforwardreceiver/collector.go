@@ -59,7 +59,32 @@ func (c *Collector) processEvents(ctx context.Context) {
 			buffered = fillBufferUntilChanEmpty(c.eventCh, buffered)
 
 			logs := collectLogRecords(buffered)
-			c.nextConsumer.ConsumeLogs(ctx, logs)
+			err := c.nextConsumer.ConsumeLogs(ctx, logs)
+			notifyAckableEvents(buffered, err)
+		}
+	}
+}
+
+// ackableEvent wraps an Event carrying the `chunk` option. The server waits
+// on result before acknowledging the chunk to the client.
+type ackableEvent struct {
+	Event
+	result chan error
+}
+
+func newAckableEvent(e Event) *ackableEvent {
+	return &ackableEvent{
+		Event: e,
+		// Buffered so the collector never blocks on a connection that has
+		// already gone away.
+		result: make(chan error, 1),
+	}
+}
+
+func notifyAckableEvents(events []Event, err error) {
+	for _, e := range events {
+		if ae, ok := e.(*ackableEvent); ok {
+			ae.result <- err
 		}
 	}
 }
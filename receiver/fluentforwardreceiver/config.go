@@ -14,7 +14,13 @@
 
 package fluentforwardreceiver
 
-import "go.opentelemetry.io/collector/config"
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+)
 
 // Config defines configuration for the SignalFx receiver.
 type Config struct {
@@ -24,4 +30,65 @@ type Config struct {
 	// of the form `<ip addr>:<port>` (TCP) or `unix://<socket_path>` (Unix
 	// domain socket).
 	ListenAddress string `mapstructure:"endpoint"`
+
+	// TLSSetting configures TLS on the stream listener. If nil, connections
+	// are accepted in plaintext.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls"`
+
+	// CertReloader is the ID of the certreloader extension reloading the
+	// certificate, key and client CA files of TLSSetting when they change. The
+	// files are only loaded at start if empty.
+	CertReloader string `mapstructure:"cert_reloader"`
+
+	// Security enables the handshake phase of the Forward protocol. When set,
+	// clients must authenticate with the shared key (and optionally a
+	// username/password) before any events are accepted.
+	Security *SecurityConfig `mapstructure:"security"`
+}
+
+// SecurityConfig mirrors the `<security>` section of the fluentd
+// in_forward plugin.
+type SecurityConfig struct {
+	// SelfHostname is the hostname sent to clients in the PONG message. It
+	// defaults to the hostname of the machine running the collector.
+	SelfHostname string `mapstructure:"self_hostname"`
+
+	// SharedKey is the secret shared with the Fluent clients.
+	SharedKey string `mapstructure:"shared_key"`
+
+	// Users enables user authentication when non-empty. Clients must then
+	// provide one of the configured username/password pairs.
+	Users []UserConfig `mapstructure:"users"`
+}
+
+// UserConfig is a username/password pair accepted during the handshake.
+type UserConfig struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.CertReloader != "" {
+		if c.TLSSetting == nil {
+			return errors.New("cert_reloader requires tls to be configured")
+		}
+		if _, err := config.NewComponentIDFromString(c.CertReloader); err != nil {
+			return fmt.Errorf("invalid cert_reloader %q: %w", c.CertReloader, err)
+		}
+	}
+	if c.Security == nil {
+		return nil
+	}
+	if c.Security.SharedKey == "" {
+		return errors.New("security.shared_key must be specified when security is enabled")
+	}
+	for _, u := range c.Security.Users {
+		if u.Username == "" || u.Password == "" {
+			return errors.New("security.users entries must have both a username and a password")
+		}
+	}
+	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -36,9 +37,50 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, len(cfg.Receivers), 2)
 
 	r0 := cfg.Receivers[config.NewComponentID("fluentforward")]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
 
+	r1 := cfg.Receivers[config.NewComponentIDWithName("fluentforward", "secure")]
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName("fluentforward", "secure")),
+		ListenAddress:    "0.0.0.0:24224",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "/etc/otel/server.crt",
+				KeyFile:  "/etc/otel/server.key",
+			},
+		},
+		CertReloader: "certreloader",
+		Security: &SecurityConfig{
+			SelfHostname: "collector",
+			SharedKey:    "secret",
+			Users:        []UserConfig{{Username: "alice", Password: "hunter2"}},
+		},
+	}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Security = &SecurityConfig{}
+	assert.EqualError(t, cfg.Validate(), "security.shared_key must be specified when security is enabled")
+
+	cfg.Security.SharedKey = "secret"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Security.Users = []UserConfig{{Username: "alice"}}
+	assert.EqualError(t, cfg.Validate(), "security.users entries must have both a username and a password")
+	cfg.Security = nil
+
+	cfg.CertReloader = "certreloader"
+	assert.EqualError(t, cfg.Validate(), "cert_reloader requires tls to be configured")
+
+	cfg.TLSSetting = &configtls.TLSServerSetting{}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.CertReloader = "certreloader/"
+	assert.Error(t, cfg.Validate())
 }
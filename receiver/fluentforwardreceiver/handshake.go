@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// The handshake phase is described in
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#handshake-messages.
+// The server sends HELO, the client answers with PING and the server
+// concludes with PONG, after which regular events flow on the connection.
+
+const saltLength = 16
+
+type handshaker struct {
+	hostname  string
+	sharedKey string
+	users     map[string]string
+}
+
+func newHandshaker(cfg *SecurityConfig, hostname string) *handshaker {
+	users := make(map[string]string, len(cfg.Users))
+	for _, u := range cfg.Users {
+		users[u.Username] = u.Password
+	}
+	if cfg.SelfHostname != "" {
+		hostname = cfg.SelfHostname
+	}
+	return &handshaker{
+		hostname:  hostname,
+		sharedKey: cfg.SharedKey,
+		users:     users,
+	}
+}
+
+// PingMessage is the client's answer to the HELO message.
+type PingMessage struct {
+	ClientHostname     string
+	SharedKeySalt      string
+	SharedKeyHexDigest string
+	Username           string
+	PasswordHexDigest  string
+}
+
+// DecodeMsg reads a PING message, accepting either str or bin encoded fields
+// since clients differ in how they pack the salt and digests.
+func (p *PingMessage) DecodeMsg(dc *msgp.Reader) error {
+	size, err := dc.ReadArrayHeader()
+	if err != nil {
+		return msgp.WrapError(err, "PING")
+	}
+	if size != 6 {
+		return msgp.ArrayError{Wanted: 6, Got: size}
+	}
+
+	msgType, err := readStringOrBytes(dc)
+	if err != nil {
+		return msgp.WrapError(err, "Type")
+	}
+	if msgType != "PING" {
+		return fmt.Errorf("expected PING message, got %q", msgType)
+	}
+
+	for _, f := range []struct {
+		name string
+		dest *string
+	}{
+		{"ClientHostname", &p.ClientHostname},
+		{"SharedKeySalt", &p.SharedKeySalt},
+		{"SharedKeyHexDigest", &p.SharedKeyHexDigest},
+		{"Username", &p.Username},
+		{"PasswordHexDigest", &p.PasswordHexDigest},
+	} {
+		if *f.dest, err = readStringOrBytes(dc); err != nil {
+			return msgp.WrapError(err, f.name)
+		}
+	}
+	return nil
+}
+
+func readStringOrBytes(dc *msgp.Reader) (string, error) {
+	t, err := dc.NextType()
+	if err != nil {
+		return "", err
+	}
+	switch t {
+	case msgp.StrType:
+		return dc.ReadString()
+	case msgp.BinType:
+		b, err := dc.ReadBytes(nil)
+		return string(b), err
+	case msgp.NilType:
+		return "", dc.ReadNil()
+	default:
+		return "", msgp.TypeError{Method: msgp.StrType, Encoded: t}
+	}
+}
+
+// perform runs the server side of the handshake. A nil error means the client
+// is authenticated and events may be read from the connection.
+func (h *handshaker) perform(w io.Writer, dc *msgp.Reader) error {
+	nonce, err := newSalt()
+	if err != nil {
+		return err
+	}
+	var authSalt []byte
+	if len(h.users) > 0 {
+		if authSalt, err = newSalt(); err != nil {
+			return err
+		}
+	}
+
+	mw := msgp.NewWriter(w)
+	if err = writeHelo(mw, nonce, authSalt); err != nil {
+		return fmt.Errorf("failed to send HELO: %w", err)
+	}
+
+	var ping PingMessage
+	if err = ping.DecodeMsg(dc); err != nil {
+		return fmt.Errorf("failed to read PING: %w", err)
+	}
+
+	reason := h.verifyPing(&ping, nonce, authSalt)
+	var digest string
+	if reason == "" {
+		digest = h.digest(ping.SharedKeySalt, h.hostname, nonce)
+	}
+	if err = writePong(mw, reason == "", reason, h.hostname, digest); err != nil {
+		return fmt.Errorf("failed to send PONG: %w", err)
+	}
+
+	if reason != "" {
+		return fmt.Errorf("handshake with %q failed: %s", ping.ClientHostname, reason)
+	}
+	return nil
+}
+
+// verifyPing returns the reason the PING is rejected, or a blank string if
+// the client is authenticated.
+func (h *handshaker) verifyPing(ping *PingMessage, nonce, authSalt []byte) string {
+	if ping.ClientHostname == h.hostname {
+		return "same hostname between input and output: invalid configuration"
+	}
+	expected := h.digest(ping.SharedKeySalt, ping.ClientHostname, nonce)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(ping.SharedKeyHexDigest)) != 1 {
+		return "shared_key mismatch"
+	}
+
+	if len(h.users) == 0 {
+		return ""
+	}
+	password, ok := h.users[ping.Username]
+	if !ok {
+		return "username/password mismatch"
+	}
+	expected = hexDigest(string(authSalt), ping.Username, password)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(ping.PasswordHexDigest)) != 1 {
+		return "username/password mismatch"
+	}
+	return ""
+}
+
+func (h *handshaker) digest(salt, hostname string, nonce []byte) string {
+	return hexDigest(salt, hostname, string(nonce), h.sharedKey)
+}
+
+func hexDigest(parts ...string) string {
+	hash := sha512.New()
+	for _, p := range parts {
+		hash.Write([]byte(p))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func newSalt() ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return salt, nil
+}
+
+func writeHelo(mw *msgp.Writer, nonce, authSalt []byte) error {
+	if err := mw.WriteArrayHeader(2); err != nil {
+		return err
+	}
+	if err := mw.WriteString("HELO"); err != nil {
+		return err
+	}
+	if err := mw.WriteMapHeader(3); err != nil {
+		return err
+	}
+	if err := mw.WriteString("nonce"); err != nil {
+		return err
+	}
+	if err := mw.WriteBytes(nonce); err != nil {
+		return err
+	}
+	if err := mw.WriteString("auth"); err != nil {
+		return err
+	}
+	if err := mw.WriteBytes(authSalt); err != nil {
+		return err
+	}
+	if err := mw.WriteString("keepalive"); err != nil {
+		return err
+	}
+	if err := mw.WriteBool(true); err != nil {
+		return err
+	}
+	return mw.Flush()
+}
+
+func writePong(mw *msgp.Writer, authResult bool, reason, hostname, digest string) error {
+	if err := mw.WriteArrayHeader(5); err != nil {
+		return err
+	}
+	if err := mw.WriteString("PONG"); err != nil {
+		return err
+	}
+	if err := mw.WriteBool(authResult); err != nil {
+		return err
+	}
+	if err := mw.WriteString(reason); err != nil {
+		return err
+	}
+	if err := mw.WriteString(hostname); err != nil {
+		return err
+	}
+	if err := mw.WriteString(digest); err != nil {
+		return err
+	}
+	return mw.Flush()
+}
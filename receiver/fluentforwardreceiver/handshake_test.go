@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+const (
+	testSharedKey      = "secret"
+	testServerHostname = "collector"
+)
+
+// clientHandshake plays the client side of the handshake the way Fluent Bit
+// does and returns the decoded PONG message.
+func clientHandshake(t *testing.T, conn net.Conn, hostname, sharedKey, username, password string) []interface{} {
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	reader := msgp.NewReader(conn)
+
+	helo, err := reader.ReadIntf()
+	require.NoError(t, err)
+	heloArr := helo.([]interface{})
+	require.Equal(t, "HELO", heloArr[0])
+	opts := heloArr[1].(map[string]interface{})
+	nonce := opts["nonce"].([]byte)
+	authSalt := opts["auth"].([]byte)
+	require.Len(t, nonce, saltLength)
+	require.Equal(t, true, opts["keepalive"])
+
+	const salt = "0123456789abcdef"
+	var b []byte
+	b = msgp.AppendArrayHeader(b, 6)
+	b = msgp.AppendString(b, "PING")
+	b = msgp.AppendString(b, hostname)
+	b = msgp.AppendString(b, salt)
+	b = msgp.AppendString(b, hexDigest(salt, hostname, string(nonce), sharedKey))
+	b = msgp.AppendString(b, username)
+	if len(authSalt) > 0 {
+		b = msgp.AppendString(b, hexDigest(string(authSalt), username, password))
+	} else {
+		b = msgp.AppendString(b, "")
+	}
+	_, err = conn.Write(b)
+	require.NoError(t, err)
+
+	pong, err := reader.ReadIntf()
+	require.NoError(t, err)
+	pongArr := pong.([]interface{})
+	require.Len(t, pongArr, 5)
+	require.Equal(t, "PONG", pongArr[0])
+	if pongArr[1].(bool) {
+		require.Equal(t, testServerHostname, pongArr[3])
+		require.Equal(t, hexDigest(salt, testServerHostname, string(nonce), sharedKey), pongArr[4])
+	}
+	require.NoError(t, conn.SetDeadline(time.Time{}))
+	return pongArr
+}
+
+func TestHandshake(t *testing.T) {
+	next := new(consumertest.LogsSink)
+	connect, _, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		Security: &SecurityConfig{
+			SelfHostname: testServerHostname,
+			SharedKey:    testSharedKey,
+		},
+	}, next)
+	defer cancel()
+
+	conn := connect()
+	defer conn.Close()
+
+	pong := clientHandshake(t, conn, "fluent-bit", testSharedKey, "", "")
+	require.Equal(t, true, pong[1])
+	require.Equal(t, "", pong[2])
+
+	_, err := conn.Write(makeSampleEvent("my-tag"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return next.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHandshakeUserAuth(t *testing.T) {
+	next := new(consumertest.LogsSink)
+	connect, _, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		Security: &SecurityConfig{
+			SelfHostname: testServerHostname,
+			SharedKey:    testSharedKey,
+			Users:        []UserConfig{{Username: "alice", Password: "hunter2"}},
+		},
+	}, next)
+	defer cancel()
+
+	conn := connect()
+	defer conn.Close()
+
+	pong := clientHandshake(t, conn, "fluent-bit", testSharedKey, "alice", "hunter2")
+	require.Equal(t, true, pong[1])
+
+	_, err := conn.Write(makeSampleEvent("my-tag"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return next.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHandshakeRejected(t *testing.T) {
+	cases := []struct {
+		name     string
+		hostname string
+		key      string
+		username string
+		password string
+		reason   string
+	}{
+		{
+			name:     "shared key mismatch",
+			hostname: "fluent-bit",
+			key:      "wrong",
+			username: "alice",
+			password: "hunter2",
+			reason:   "shared_key mismatch",
+		},
+		{
+			name:     "unknown user",
+			hostname: "fluent-bit",
+			key:      testSharedKey,
+			username: "bob",
+			password: "hunter2",
+			reason:   "username/password mismatch",
+		},
+		{
+			name:     "wrong password",
+			hostname: "fluent-bit",
+			key:      testSharedKey,
+			username: "alice",
+			password: "hunter3",
+			reason:   "username/password mismatch",
+		},
+		{
+			name:     "same hostname",
+			hostname: testServerHostname,
+			key:      testSharedKey,
+			username: "alice",
+			password: "hunter2",
+			reason:   "same hostname between input and output: invalid configuration",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := new(consumertest.LogsSink)
+			connect, _, cancel := setupServerWithConfig(t, &Config{
+				ListenAddress: "127.0.0.1:0",
+				Security: &SecurityConfig{
+					SelfHostname: testServerHostname,
+					SharedKey:    testSharedKey,
+					Users:        []UserConfig{{Username: "alice", Password: "hunter2"}},
+				},
+			}, next)
+			defer cancel()
+
+			conn := connect()
+			defer conn.Close()
+
+			pong := clientHandshake(t, conn, tc.hostname, tc.key, tc.username, tc.password)
+			require.Equal(t, false, pong[1])
+			require.Equal(t, tc.reason, pong[2])
+			require.Equal(t, "", pong[4])
+
+			waitForConnectionClose(t, conn)
+			require.Equal(t, 0, next.LogRecordCount())
+		})
+	}
+}
+
+func TestPingMessageDecodeBinFields(t *testing.T) {
+	var b []byte
+	b = msgp.AppendArrayHeader(b, 6)
+	b = msgp.AppendString(b, "PING")
+	b = msgp.AppendString(b, "host")
+	b = msgp.AppendBytes(b, []byte("salt"))
+	b = msgp.AppendString(b, "digest")
+	b = msgp.AppendNil(b)
+	b = msgp.AppendBytes(b, nil)
+
+	var ping PingMessage
+	require.NoError(t, ping.DecodeMsg(msgp.NewReader(bytes.NewReader(b))))
+	require.Equal(t, PingMessage{
+		ClientHostname:     "host",
+		SharedKeySalt:      "salt",
+		SharedKeyHexDigest: "digest",
+	}, ping)
+
+	var bad []byte
+	bad = msgp.AppendArrayHeader(bad, 2)
+	bad = msgp.AppendString(bad, "PING")
+	bad = msgp.AppendString(bad, "host")
+	require.Error(t, ping.DecodeMsg(msgp.NewReader(bytes.NewReader(bad))))
+}
@@ -16,12 +16,18 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"net"
+	"os"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/certreloaderextension"
 )
 
 // Give the event channel a bit of buffer to help reduce backpressure on
@@ -42,7 +48,16 @@ func newFluentReceiver(logger *zap.Logger, conf *Config, next consumer.Logs) (co
 
 	collector := newCollector(eventCh, next, logger)
 
-	server := newServer(eventCh, logger)
+	var hs *handshaker
+	if conf.Security != nil {
+		hostname, err := os.Hostname()
+		if err != nil && conf.Security.SelfHostname == "" {
+			return nil, fmt.Errorf("failed to determine self_hostname: %w", err)
+		}
+		hs = newHandshaker(conf.Security, hostname)
+	}
+
+	server := newServer(eventCh, logger, hs)
 
 	return &fluentReceiver{
 		collector: collector,
@@ -52,7 +67,7 @@ func newFluentReceiver(logger *zap.Logger, conf *Config, next consumer.Logs) (co
 	}, nil
 }
 
-func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
+func (r *fluentReceiver) Start(ctx context.Context, host component.Host) error {
 	receiverCtx, cancel := context.WithCancel(ctx)
 	r.cancel = cancel
 
@@ -76,6 +91,18 @@ func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
+	if r.conf.TLSSetting != nil {
+		tlsCfg, err := r.loadTLSConfig(host)
+		if err != nil {
+			listener.Close()
+			if udpListener != nil {
+				udpListener.Close()
+			}
+			return err
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+
 	r.listener = listener
 
 	r.server.Start(receiverCtx, listener)
@@ -87,6 +114,20 @@ func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
 	return nil
 }
 
+// loadTLSConfig loads the TLS configuration of the listener, through the
+// certreloader extension if one is configured.
+func (r *fluentReceiver) loadTLSConfig(host component.Host) (*tls.Config, error) {
+	var reloader *config.ComponentID
+	if r.conf.CertReloader != "" {
+		id, err := config.NewComponentIDFromString(r.conf.CertReloader)
+		if err != nil {
+			return nil, err
+		}
+		reloader = &id
+	}
+	return certreloaderextension.LoadServerTLSConfig(host, reloader, *r.conf.TLSSetting)
+}
+
 func (r *fluentReceiver) Shutdown(context.Context) error {
 	r.cancel()
 	return nil
@@ -16,9 +16,18 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"net"
 	"os"
 	"path/filepath"
@@ -28,23 +37,33 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/certreloaderextension"
 )
 
 func setupServer(t *testing.T) (func() net.Conn, *consumertest.LogsSink, *observer.ObservedLogs, context.CancelFunc) {
+	next := new(consumertest.LogsSink)
+	connect, logObserver, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+	}, next)
+	return connect, next, logObserver, cancel
+}
+
+func setupServerWithConfig(t *testing.T, conf *Config, next consumer.Logs) (func() net.Conn, *observer.ObservedLogs, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	next := new(consumertest.LogsSink)
 	logCore, logObserver := observer.New(zap.DebugLevel)
 	logger := zap.New(logCore)
 
-	conf := &Config{
-		ListenAddress: "127.0.0.1:0",
-	}
-
 	receiver, err := newFluentReceiver(logger, conf, next)
 	require.NoError(t, err)
 	require.NoError(t, receiver.Start(ctx, nil))
@@ -60,7 +79,7 @@ func setupServer(t *testing.T) (func() net.Conn, *consumertest.LogsSink, *observ
 		require.NoError(t, receiver.Shutdown(ctx))
 	}()
 
-	return connect, next, logObserver, cancel
+	return connect, logObserver, cancel
 }
 
 func waitForConnectionClose(t *testing.T, conn net.Conn) {
@@ -210,6 +229,162 @@ func TestEventAcknowledgment(t *testing.T) {
 	require.Equal(t, chunkValue, resp["ack"])
 }
 
+func makeChunkedEvent(chunk string) []byte {
+	var b []byte
+
+	b = msgp.AppendArrayHeader(b, 4)
+	b = msgp.AppendString(b, "my-tag")
+	b = msgp.AppendInt(b, 5000)
+	b = msgp.AppendMapHeader(b, 1)
+	b = msgp.AppendString(b, "a")
+	b = msgp.AppendFloat64(b, 5.0)
+	b = msgp.AppendMapStrStr(b, map[string]string{"chunk": chunk})
+	return b
+}
+
+func TestEventAcknowledgmentConsumerError(t *testing.T) {
+	connect, logs, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+	}, consumertest.NewErr(errors.New("pipeline full")))
+	defer cancel()
+
+	b := makeChunkedEvent("abcdef01234576789")
+
+	conn := connect()
+	n, err := conn.Write(b)
+	require.NoError(t, err)
+	require.Equal(t, len(b), n)
+
+	require.Eventually(t, func() bool {
+		return logs.FilterMessageSnippet("Not acknowledging chunk").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// No ack must have been written back to the client.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, err = conn.Read(make([]byte, 1))
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func TestTLSEndpoint(t *testing.T) {
+	next := new(consumertest.LogsSink)
+	connect, _, cancel := setupServerWithConfig(t, &Config{
+		ListenAddress: "127.0.0.1:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "./testdata/server.crt",
+				KeyFile:  "./testdata/server.key",
+			},
+		},
+	}, next)
+	defer cancel()
+
+	caCert, err := ioutil.ReadFile("./testdata/ca.crt")
+	require.NoError(t, err)
+	caPool := x509.NewCertPool()
+	require.True(t, caPool.AppendCertsFromPEM(caCert))
+
+	conn := tls.Client(connect(), &tls.Config{
+		RootCAs:    caPool,
+		ServerName: "localhost",
+	})
+	defer conn.Close()
+
+	b := makeChunkedEvent("abcdef01234576789")
+	n, err := conn.Write(b)
+	require.NoError(t, err)
+	require.Equal(t, len(b), n)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	resp := map[string]interface{}{}
+	require.NoError(t, msgp.NewReader(conn).ReadMapStrIntf(resp))
+	require.Equal(t, "abcdef01234576789", resp["ack"])
+	require.Len(t, next.AllLogs(), 1)
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// writeSelfSignedCert writes a new self-signed certificate for localhost and
+// its key, and returns the certificate.
+func writeSelfSignedCert(t *testing.T, certFile, keyFile string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{"localhost"},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return cert
+}
+
+func TestTLSEndpointCertReloader(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	first := writeSelfSignedCert(t, certFile, keyFile)
+
+	factory := certreloaderextension.NewFactory()
+	extCfg := factory.CreateDefaultConfig().(*certreloaderextension.Config)
+	extCfg.CheckInterval = 10 * time.Millisecond
+	ext, err := factory.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), extCfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(ctx, componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(ctx)) }()
+	host := extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{config.NewComponentID("certreloader"): ext},
+	}
+
+	receiver, err := newFluentReceiver(zap.NewNop(), &Config{
+		ListenAddress: "127.0.0.1:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{CertFile: certFile, KeyFile: keyFile},
+		},
+		CertReloader: "certreloader",
+	}, new(consumertest.LogsSink))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(ctx, host))
+	defer func() { require.NoError(t, receiver.Shutdown(ctx)) }()
+	addr := receiver.(*fluentReceiver).listener.Addr().String()
+
+	// serverCert returns the certificate presented on a new connection.
+	serverCert := func() *x509.Certificate {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true}) // nolint:gosec
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0]
+	}
+	require.Equal(t, first.SerialNumber, serverCert().SerialNumber)
+
+	second := writeSelfSignedCert(t, certFile, keyFile)
+	require.Eventually(t, func() bool {
+		return serverCert().SerialNumber.Cmp(second.SerialNumber) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestForwardPackedEvent(t *testing.T) {
 	connect, next, _, cancel := setupServer(t)
 	defer cancel()
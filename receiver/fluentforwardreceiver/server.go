@@ -37,12 +37,15 @@ const readBufferSize = 10 * 1024
 type server struct {
 	outCh  chan<- Event
 	logger *zap.Logger
+	// handshaker is nil unless the security section is configured.
+	handshaker *handshaker
 }
 
-func newServer(outCh chan<- Event, logger *zap.Logger) *server {
+func newServer(outCh chan<- Event, logger *zap.Logger, hs *handshaker) *server {
 	return &server{
-		outCh:  outCh,
-		logger: logger,
+		outCh:      outCh,
+		logger:     logger,
+		handshaker: hs,
 	}
 }
 
@@ -91,6 +94,12 @@ func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 func (s *server) handleConn(ctx context.Context, conn net.Conn) error {
 	reader := msgp.NewReaderSize(conn, readBufferSize)
 
+	if s.handshaker != nil {
+		if err := s.handshaker.perform(conn, reader); err != nil {
+			return err
+		}
+	}
+
 	for {
 		mode, err := DetermineNextEventMode(reader.R)
 		if err != nil {
@@ -121,17 +130,32 @@ func (s *server) handleConn(ctx context.Context, conn net.Conn) error {
 
 		stats.Record(ctx, observ.EventsParsed.M(1))
 
-		s.outCh <- event
+		if event.Chunk() == "" {
+			s.outCh <- event
+			continue
+		}
 
-		// We must acknowledge the 'chunk' option if given. We could do this in
-		// another goroutine if it is too much of a bottleneck to reading
-		// messages -- this is the only thing that sends data back to the
-		// client.
-		if event.Chunk() != "" {
-			err := msgp.Encode(conn, AckResponse{Ack: event.Chunk()})
-			if err != nil {
-				return fmt.Errorf("failed to acknowledge chunk %s: %v", event.Chunk(), err)
-			}
+		// We must acknowledge the 'chunk' option if given, but only once the
+		// records have been accepted by the next consumer so that clients
+		// waiting for an ack get at-least-once delivery. Events of a single
+		// connection are thus handled one chunk at a time.
+		ackable := newAckableEvent(event)
+		s.outCh <- ackable
+		select {
+		case err = <-ackable.result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err != nil {
+			// Not acking makes the client resend the chunk after its ack
+			// timeout.
+			s.logger.Debug("Not acknowledging chunk that failed to be consumed", zap.String("chunk", event.Chunk()), zap.Error(err))
+			continue
+		}
+
+		err = msgp.Encode(conn, AckResponse{Ack: event.Chunk()})
+		if err != nil {
+			return fmt.Errorf("failed to acknowledge chunk %s: %v", event.Chunk(), err)
 		}
 	}
 }
@@ -74,11 +74,11 @@ var resourceMetrics = []string{
 }
 
 var systemSpecificMetrics = map[string][]string{
-	"linux":   {"system.disk.merged", "system.disk.weighted_io_time", "system.filesystem.inodes.usage", "system.paging.faults", "system.processes.created", "system.processes.count"},
-	"darwin":  {"system.filesystem.inodes.usage", "system.paging.faults", "system.processes.count"},
-	"freebsd": {"system.filesystem.inodes.usage", "system.paging.faults", "system.processes.count"},
-	"openbsd": {"system.filesystem.inodes.usage", "system.paging.faults", "system.processes.created", "system.processes.count"},
-	"solaris": {"system.filesystem.inodes.usage", "system.paging.faults"},
+	"linux":   {"system.disk.merged", "system.disk.weighted_io_time", "system.filesystem.inodes.usage", "system.filesystem.inodes.utilization", "system.paging.faults", "system.processes.created", "system.processes.count"},
+	"darwin":  {"system.filesystem.inodes.usage", "system.filesystem.inodes.utilization", "system.paging.faults", "system.processes.count"},
+	"freebsd": {"system.filesystem.inodes.usage", "system.filesystem.inodes.utilization", "system.paging.faults", "system.processes.count"},
+	"openbsd": {"system.filesystem.inodes.usage", "system.filesystem.inodes.utilization", "system.paging.faults", "system.processes.created", "system.processes.count"},
+	"solaris": {"system.filesystem.inodes.usage", "system.filesystem.inodes.utilization", "system.paging.faults"},
 }
 
 var factories = map[string]internal.ScraperFactory{
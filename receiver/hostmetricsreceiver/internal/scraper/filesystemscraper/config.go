@@ -40,6 +40,12 @@ type Config struct {
 	IncludeMountPoints MountPointMatchConfig `mapstructure:"include_mount_points"`
 	// ExcludeMountPoints specifies a filter on the mount points that should be excluded from the generated metrics.
 	ExcludeMountPoints MountPointMatchConfig `mapstructure:"exclude_mount_points"`
+
+	// ExcludePresets specifies named sets of filesystem types and mount points that should be excluded
+	// from the generated metrics, in addition to any explicit exclude filters. Supported values are
+	// "virtual" (pseudo filesystems such as proc, sysfs or cgroup) and "container" (per-container
+	// mounts created by docker, containerd, podman and the kubelet).
+	ExcludePresets []string `mapstructure:"exclude_presets"`
 }
 
 type DeviceMatchConfig struct {
@@ -67,6 +73,8 @@ type fsFilter struct {
 	excludeFSTypeFilter     filterset.FilterSet
 	includeMountPointFilter filterset.FilterSet
 	excludeMountPointFilter filterset.FilterSet
+	presetFSTypeFilter      filterset.FilterSet
+	presetMountPointFilter  filterset.FilterSet
 	filtersExist            bool
 }
 
@@ -104,6 +112,11 @@ func (cfg *Config) createFilter() (*fsFilter, error) {
 		return nil, err
 	}
 
+	filter.presetFSTypeFilter, filter.presetMountPointFilter, err = newPresetFilters(cfg.ExcludePresets)
+	if err != nil {
+		return nil, err
+	}
+
 	filter.setFiltersExist()
 	return &filter, nil
 }
@@ -111,7 +124,70 @@ func (cfg *Config) createFilter() (*fsFilter, error) {
 func (f *fsFilter) setFiltersExist() {
 	f.filtersExist = f.includeMountPointFilter != nil || f.excludeMountPointFilter != nil ||
 		f.includeFSTypeFilter != nil || f.excludeFSTypeFilter != nil ||
-		f.includeDeviceFilter != nil || f.excludeDeviceFilter != nil
+		f.includeDeviceFilter != nil || f.excludeDeviceFilter != nil ||
+		f.presetFSTypeFilter != nil || f.presetMountPointFilter != nil
+}
+
+const (
+	presetVirtual   = "virtual"
+	presetContainer = "container"
+)
+
+type excludePreset struct {
+	fsTypes     []string
+	mountPoints []string
+}
+
+// excludePresets holds the regular expressions that each named preset adds to the exclude filters.
+var excludePresets = map[string]excludePreset{
+	presetVirtual: {
+		fsTypes: []string{
+			"^(autofs|binfmt_misc|bpf|cgroup2?|configfs|debugfs|devpts|devtmpfs|fusectl|hugetlbfs|iso9660|mqueue|nsfs|proc|procfs|pstore|rpc_pipefs|securityfs|selinuxfs|squashfs|sysfs|tracefs)$",
+		},
+		mountPoints: []string{
+			"^/(dev|proc|sys|run/credentials/.+)($|/)",
+		},
+	},
+	presetContainer: {
+		fsTypes: []string{
+			"^(aufs|overlay)$",
+		},
+		mountPoints: []string{
+			"^/var/lib/docker/.+",
+			"^/var/lib/containers/storage/.+",
+			"^/var/lib/kubelet/pods/.+",
+			"^/var/lib/containerd/.+",
+			"^/run/containerd/.+",
+			"^/run/k3s/containerd/.+",
+		},
+	},
+}
+
+func newPresetFilters(presets []string) (filterset.FilterSet, filterset.FilterSet, error) {
+	if len(presets) == 0 {
+		return nil, nil, nil
+	}
+
+	var fsTypes, mountPoints []string
+	for _, name := range presets {
+		preset, ok := excludePresets[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown exclude preset %q, supported presets are %q and %q", name, presetVirtual, presetContainer)
+		}
+		fsTypes = append(fsTypes, preset.fsTypes...)
+		mountPoints = append(mountPoints, preset.mountPoints...)
+	}
+
+	regexpConfig := &filterset.Config{MatchType: filterset.Regexp}
+	fsTypeFilter, err := newExcludeFilterHelper(fsTypes, regexpConfig, metadata.Labels.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	mountPointFilter, err := newExcludeFilterHelper(mountPoints, regexpConfig, metadata.Labels.Mountpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fsTypeFilter, mountPointFilter, nil
 }
 
 const (
@@ -109,13 +109,17 @@ func initializeFileSystemUsageMetric(metric pdata.Metric, now pdata.Timestamp, d
 
 func initializeFileSystemUsageDataPoint(dataPoint pdata.NumberDataPoint, now pdata.Timestamp, partition disk.PartitionStat, stateLabel string, value int64) {
 	attributes := dataPoint.Attributes()
+	insertPartitionAttributes(attributes, partition)
+	attributes.InsertString(metadata.Labels.State, stateLabel)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetIntVal(value)
+}
+
+func insertPartitionAttributes(attributes pdata.AttributeMap, partition disk.PartitionStat) {
 	attributes.InsertString(metadata.Labels.Device, partition.Device)
 	attributes.InsertString(metadata.Labels.Type, partition.Fstype)
 	attributes.InsertString(metadata.Labels.Mode, getMountMode(partition.Opts))
 	attributes.InsertString(metadata.Labels.Mountpoint, partition.Mountpoint)
-	attributes.InsertString(metadata.Labels.State, stateLabel)
-	dataPoint.SetTimestamp(now)
-	dataPoint.SetIntVal(value)
 }
 
 func getMountMode(opts string) string {
@@ -154,10 +158,12 @@ func (f *fsFilter) includeDevice(deviceName string) bool {
 
 func (f *fsFilter) includeFSType(fsType string) bool {
 	return (f.includeFSTypeFilter == nil || f.includeFSTypeFilter.Matches(fsType)) &&
-		(f.excludeFSTypeFilter == nil || !f.excludeFSTypeFilter.Matches(fsType))
+		(f.excludeFSTypeFilter == nil || !f.excludeFSTypeFilter.Matches(fsType)) &&
+		(f.presetFSTypeFilter == nil || !f.presetFSTypeFilter.Matches(fsType))
 }
 
 func (f *fsFilter) includeMountPoint(mountPoint string) bool {
 	return (f.includeMountPointFilter == nil || f.includeMountPointFilter.Matches(mountPoint)) &&
-		(f.excludeMountPointFilter == nil || !f.excludeMountPointFilter.Matches(mountPoint))
+		(f.excludeMountPointFilter == nil || !f.excludeMountPointFilter.Matches(mountPoint)) &&
+		(f.presetMountPointFilter == nil || !f.presetMountPointFilter.Matches(mountPoint))
 }
@@ -164,6 +164,44 @@ func TestScrape(t *testing.T) {
 			config:      Config{ExcludeMountPoints: MountPointMatchConfig{MountPoints: []string{"test"}}},
 			newErrRegex: "^error creating mountpoint exclude filters:",
 		},
+		{
+			name:   "Exclude virtual and container presets",
+			config: Config{ExcludePresets: []string{"virtual", "container"}},
+			partitionsFunc: func(bool) ([]disk.PartitionStat, error) {
+				return []disk.PartitionStat{
+					{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4", Opts: "rw,relatime"},
+					{Device: "proc", Mountpoint: "/proc", Fstype: "proc", Opts: "rw"},
+					{Device: "cgroup2", Mountpoint: "/sys/fs/cgroup", Fstype: "cgroup2", Opts: "rw"},
+					{Device: "/dev/sda1", Mountpoint: "/var/lib/kubelet/pods/1234/volumes/kubernetes.io~empty-dir/data", Fstype: "ext4", Opts: "rw"},
+					{Device: "overlay", Mountpoint: "/var/lib/docker/overlay2/abcd/merged", Fstype: "overlay", Opts: "rw"},
+					{Device: "/dev/sdb1", Mountpoint: "/data", Fstype: "xfs", Opts: "ro"},
+				}, nil
+			},
+			usageFunc: func(string) (*disk.UsageStat, error) {
+				return &disk.UsageStat{}, nil
+			},
+			expectMetrics:            true,
+			expectedDeviceDataPoints: 2,
+			expectedDeviceAttributes: []map[string]pdata.AttributeValue{
+				{
+					"device":     pdata.NewAttributeValueString("/dev/sda1"),
+					"mountpoint": pdata.NewAttributeValueString("/"),
+					"type":       pdata.NewAttributeValueString("ext4"),
+					"mode":       pdata.NewAttributeValueString("rw"),
+				},
+				{
+					"device":     pdata.NewAttributeValueString("/dev/sdb1"),
+					"mountpoint": pdata.NewAttributeValueString("/data"),
+					"type":       pdata.NewAttributeValueString("xfs"),
+					"mode":       pdata.NewAttributeValueString("ro"),
+				},
+			},
+		},
+		{
+			name:        "Invalid Exclude Preset",
+			config:      Config{ExcludePresets: []string{"tmp"}},
+			newErrRegex: `^unknown exclude preset "tmp"`,
+		},
 		{
 			name:           "Partitions Error",
 			partitionsFunc: func(bool) ([]disk.PartitionStat, error) { return nil, errors.New("err1") },
@@ -238,6 +276,43 @@ func TestScrape(t *testing.T) {
 	}
 }
 
+func TestScrapeInodesUtilization(t *testing.T) {
+	if !isUnix() {
+		t.Skip("inode metrics are only reported on unix systems")
+	}
+
+	scraper, err := newFileSystemScraper(context.Background(), &Config{})
+	require.NoError(t, err)
+	scraper.partitions = func(bool) ([]disk.PartitionStat, error) {
+		return []disk.PartitionStat{
+			{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4", Opts: "rw"},
+			{Device: "/dev/sda2", Mountpoint: "/home", Fstype: "btrfs", Opts: "rw"},
+		}, nil
+	}
+	scraper.usage = func(mountPoint string) (*disk.UsageStat, error) {
+		if mountPoint == "/" {
+			return &disk.UsageStat{InodesTotal: 1000, InodesUsed: 250, InodesFree: 750}, nil
+		}
+		return &disk.UsageStat{}, nil
+	}
+
+	metrics, err := scraper.Scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, metricsLen, metrics.Len())
+
+	metric := metrics.At(2)
+	internal.AssertDescriptorEqual(t, metadata.Metrics.SystemFilesystemInodesUtilization.New(), metric)
+	// Filesystems without a fixed inode table are skipped.
+	require.Equal(t, 1, metric.Gauge().DataPoints().Len())
+	dataPoint := metric.Gauge().DataPoints().At(0)
+	assert.Equal(t, 0.25, dataPoint.DoubleVal())
+	mountPoint, ok := dataPoint.Attributes().Get(metadata.Labels.Mountpoint)
+	require.True(t, ok)
+	assert.Equal(t, "/", mountPoint.StringVal())
+	_, ok = dataPoint.Attributes().Get(metadata.Labels.State)
+	assert.False(t, ok)
+}
+
 func assertFileSystemUsageMetricValid(
 	t *testing.T,
 	metric pdata.Metric,
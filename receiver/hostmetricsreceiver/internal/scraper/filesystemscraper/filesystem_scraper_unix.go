@@ -31,7 +31,7 @@ func appendFileSystemUsageStateDataPoints(idps pdata.NumberDataPointSlice, now p
 	initializeFileSystemUsageDataPoint(idps.AppendEmpty(), now, deviceUsage.partition, metadata.LabelState.Reserved, int64(deviceUsage.usage.Total-deviceUsage.usage.Used-deviceUsage.usage.Free))
 }
 
-const systemSpecificMetricsLen = 1
+const systemSpecificMetricsLen = 2
 
 func appendSystemSpecificMetrics(metrics pdata.MetricSlice, now pdata.Timestamp, deviceUsages []*deviceUsage) {
 	metric := metrics.AppendEmpty()
@@ -43,4 +43,20 @@ func appendSystemSpecificMetrics(metrics pdata.MetricSlice, now pdata.Timestamp,
 		initializeFileSystemUsageDataPoint(idps.AppendEmpty(), now, deviceUsage.partition, metadata.LabelState.Used, int64(deviceUsage.usage.InodesUsed))
 		initializeFileSystemUsageDataPoint(idps.AppendEmpty(), now, deviceUsage.partition, metadata.LabelState.Free, int64(deviceUsage.usage.InodesFree))
 	}
+
+	metric = metrics.AppendEmpty()
+	metadata.Metrics.SystemFilesystemInodesUtilization.Init(metric)
+
+	ddps := metric.Gauge().DataPoints()
+	ddps.EnsureCapacity(len(deviceUsages))
+	for _, deviceUsage := range deviceUsages {
+		// Some filesystems (e.g. btrfs, vfat) allocate inodes dynamically and report no total.
+		if deviceUsage.usage.InodesTotal == 0 {
+			continue
+		}
+		dataPoint := ddps.AppendEmpty()
+		insertPartitionAttributes(dataPoint.Attributes(), deviceUsage.partition)
+		dataPoint.SetTimestamp(now)
+		dataPoint.SetDoubleVal(float64(deviceUsage.usage.InodesUsed) / float64(deviceUsage.usage.InodesTotal))
+	}
 }
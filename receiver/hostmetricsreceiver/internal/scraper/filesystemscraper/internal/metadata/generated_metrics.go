@@ -55,21 +55,24 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	SystemFilesystemInodesUsage MetricIntf
-	SystemFilesystemUsage       MetricIntf
+	SystemFilesystemInodesUsage       MetricIntf
+	SystemFilesystemInodesUtilization MetricIntf
+	SystemFilesystemUsage             MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
 		"system.filesystem.inodes.usage",
+		"system.filesystem.inodes.utilization",
 		"system.filesystem.usage",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"system.filesystem.inodes.usage": Metrics.SystemFilesystemInodesUsage,
-	"system.filesystem.usage":        Metrics.SystemFilesystemUsage,
+	"system.filesystem.inodes.usage":       Metrics.SystemFilesystemInodesUsage,
+	"system.filesystem.inodes.utilization": Metrics.SystemFilesystemInodesUtilization,
+	"system.filesystem.usage":              Metrics.SystemFilesystemUsage,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -90,6 +93,15 @@ var Metrics = &metricStruct{
 			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.filesystem.inodes.utilization",
+		func(metric pdata.Metric) {
+			metric.SetName("system.filesystem.inodes.utilization")
+			metric.SetDescription("Fraction of filesystem inodes used.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"system.filesystem.usage",
 		func(metric pdata.Metric) {
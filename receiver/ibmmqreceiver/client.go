@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmmqreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// csrfTokenHeader must be set on the POST requests to the REST API. Its
+	// value doesn't matter.
+	csrfTokenHeader = "ibm-mq-rest-csrf-token"
+
+	// Reason codes of commands matching no object, which aren't errors.
+	reasonUnknownObjectName   = 2085
+	reasonChannelStatusAbsent = 3065
+)
+
+// mqscCommand is a MQSC command in the JSON format of the REST API.
+type mqscCommand struct {
+	Type               string   `json:"type"`
+	Command            string   `json:"command"`
+	Qualifier          string   `json:"qualifier"`
+	Name               string   `json:"name"`
+	ResponseParameters []string `json:"responseParameters"`
+}
+
+type mqscResponse struct {
+	CommandResponse []struct {
+		CompletionCode int                    `json:"completionCode"`
+		ReasonCode     int                    `json:"reasonCode"`
+		Text           []string               `json:"text"`
+		Parameters     map[string]interface{} `json:"parameters"`
+	} `json:"commandResponse"`
+}
+
+// mqClient runs MQSC commands on a queue manager with the administrative
+// REST API of the mqweb server.
+type mqClient struct {
+	client       *http.Client
+	endpoint     string
+	queueManager string
+	username     string
+	password     string
+}
+
+// display runs a DISPLAY command and returns the parameters of the objects it
+// matched.
+func (c *mqClient) display(ctx context.Context, qualifier, name string, responseParameters ...string) ([]map[string]interface{}, error) {
+	body, err := json.Marshal(mqscCommand{
+		Type:               "runCommandJSON",
+		Command:            "display",
+		Qualifier:          qualifier,
+		Name:               name,
+		ResponseParameters: responseParameters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	path := "/ibmmq/rest/v2/admin/action/qmgr/" + url.PathEscape(c.queueManager) + "/mqsc"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.endpoint, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(csrfTokenHeader, "otelcol")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("POST %s returned %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var mr mqscResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of POST %s: %w", path, err)
+	}
+
+	var objects []map[string]interface{}
+	for _, cr := range mr.CommandResponse {
+		switch {
+		case cr.CompletionCode == 0:
+			objects = append(objects, cr.Parameters)
+		case cr.ReasonCode == reasonUnknownObjectName || cr.ReasonCode == reasonChannelStatusAbsent:
+		default:
+			return nil, fmt.Errorf("DISPLAY %s(%s) failed with reason %d: %s",
+				strings.ToUpper(qualifier), name, cr.ReasonCode, strings.Join(cr.Text, " "))
+		}
+	}
+	return objects, nil
+}
+
+// intParameter returns the value of an integer parameter. The REST API
+// returns blank strings for values which aren't available.
+func intParameter(params map[string]interface{}, name string) (int64, bool) {
+	switch v := params[name].(type) {
+	case float64:
+		return int64(v), true
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func stringParameter(params map[string]interface{}, name string) string {
+	s, _ := params[name].(string)
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmmqreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const (
+	defaultEndpoint = "https://localhost:9443"
+	defaultQueues   = "*"
+	defaultChannels = "*"
+)
+
+// Config defines the configuration for the IBM MQ receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+
+	// QueueManager is the name of the queue manager to scrape.
+	QueueManager string `mapstructure:"queue_manager"`
+	// Username and Password authenticate against the mqweb server. The user
+	// needs the MQWebAdmin role or the authority to display the queues and
+	// channels.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// Queues and Channels are the generic names, such as APP.*, of the queues
+	// and channels to scrape. All of them are scraped by default.
+	Queues   string `mapstructure:"queues"`
+	Channels string `mapstructure:"channels"`
+	// IncludeSystemObjects enables the scraping of the SYSTEM.* queues and
+	// channels.
+	IncludeSystemObjects bool `mapstructure:"include_system_objects"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid endpoint %q: scheme must be http or https", cfg.Endpoint)
+	}
+	if cfg.QueueManager == "" {
+		return errors.New("queue_manager must be specified")
+	}
+	if cfg.Queues == "" || cfg.Channels == "" {
+		return errors.New("queues and channels must be non-empty")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmmqreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.QueueManager = "QM1"
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, "https://mq.example.com:9443", custom.Endpoint)
+	assert.Equal(t, "/etc/mqweb/ca.pem", custom.TLSSetting.CAFile)
+	assert.Equal(t, "QM2", custom.QueueManager)
+	assert.Equal(t, "mqadmin", custom.Username)
+	assert.Equal(t, "secret", custom.Password)
+	assert.Equal(t, "APP.*", custom.Queues)
+	assert.Equal(t, "APP.*", custom.Channels)
+	assert.True(t, custom.IncludeSystemObjects)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "endpoint must be specified",
+		},
+		{
+			name:   "invalid scheme",
+			modify: func(cfg *Config) { cfg.Endpoint = "localhost:9443" },
+			err:    `invalid endpoint "localhost:9443": scheme must be http or https`,
+		},
+		{
+			name:   "missing queue manager",
+			modify: func(cfg *Config) { cfg.QueueManager = "" },
+			err:    "queue_manager must be specified",
+		},
+		{
+			name:   "empty channels",
+			modify: func(cfg *Config) { cfg.Channels = "" },
+			err:    "queues and channels must be non-empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.QueueManager = "QM1"
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "ibmmqreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	IbmmqChannelBytes             MetricIntf
+	IbmmqChannelInstances         MetricIntf
+	IbmmqChannelMessages          MetricIntf
+	IbmmqQueueDepth               MetricIntf
+	IbmmqQueueHandles             MetricIntf
+	IbmmqQueueMaxDepth            MetricIntf
+	IbmmqQueueOldestMessageAge    MetricIntf
+	IbmmqQueueUncommittedMessages MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"ibmmq.channel.bytes",
+		"ibmmq.channel.instances",
+		"ibmmq.channel.messages",
+		"ibmmq.queue.depth",
+		"ibmmq.queue.handles",
+		"ibmmq.queue.max_depth",
+		"ibmmq.queue.oldest_message.age",
+		"ibmmq.queue.uncommitted_messages",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"ibmmq.channel.bytes":              Metrics.IbmmqChannelBytes,
+	"ibmmq.channel.instances":          Metrics.IbmmqChannelInstances,
+	"ibmmq.channel.messages":           Metrics.IbmmqChannelMessages,
+	"ibmmq.queue.depth":                Metrics.IbmmqQueueDepth,
+	"ibmmq.queue.handles":              Metrics.IbmmqQueueHandles,
+	"ibmmq.queue.max_depth":            Metrics.IbmmqQueueMaxDepth,
+	"ibmmq.queue.oldest_message.age":   Metrics.IbmmqQueueOldestMessageAge,
+	"ibmmq.queue.uncommitted_messages": Metrics.IbmmqQueueUncommittedMessages,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"ibmmq.channel.bytes",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.channel.bytes")
+			metric.SetDescription("Number of bytes sent or received by the channel instances since they started.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"ibmmq.channel.instances",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.channel.instances")
+			metric.SetDescription("Number of instances of the channel, by status.")
+			metric.SetUnit("{instances}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"ibmmq.channel.messages",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.channel.messages")
+			metric.SetDescription("Number of messages sent or received by the channel instances since they started.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"ibmmq.queue.depth",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.queue.depth")
+			metric.SetDescription("Number of messages in the queue.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"ibmmq.queue.handles",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.queue.handles")
+			metric.SetDescription("Number of handles open on the queue, such as the consumers getting messages from it.")
+			metric.SetUnit("{handles}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"ibmmq.queue.max_depth",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.queue.max_depth")
+			metric.SetDescription("Maximum number of messages allowed in the queue.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"ibmmq.queue.oldest_message.age",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.queue.oldest_message.age")
+			metric.SetDescription("Age of the oldest message of the queue. Only reported when queue monitoring is enabled.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"ibmmq.queue.uncommitted_messages",
+		func(metric pdata.Metric) {
+			metric.SetName("ibmmq.queue.uncommitted_messages")
+			metric.SetDescription("Number of messages put to or got from the queue in uncommitted units of work.")
+			metric.SetUnit("{messages}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Channel (The name of the channel.)
+	Channel string
+	// ChannelStatus (The status of the channel instances, such as running or retrying.)
+	ChannelStatus string
+	// ChannelType (The type of the channel, such as svrconn or sdr.)
+	ChannelType string
+	// Connection (The connection name of the channel instance, usually the address of its partner.)
+	Connection string
+	// Direction (The direction of the transferred bytes.)
+	Direction string
+	// HandleType (Whether the handles are open for input or output.)
+	HandleType string
+	// Queue (The name of the queue.)
+	Queue string
+	// QueueManager (The name of the queue manager.)
+	QueueManager string
+}{
+	"ibmmq.channel",
+	"status",
+	"ibmmq.channel.type",
+	"ibmmq.channel.connection",
+	"direction",
+	"type",
+	"ibmmq.queue",
+	"ibmmq.queue_manager",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelDirection are the possible values that the label "direction" can have.
+var LabelDirection = struct {
+	Sent     string
+	Received string
+}{
+	"sent",
+	"received",
+}
+
+// LabelHandleType are the possible values that the label "handle_type" can have.
+var LabelHandleType = struct {
+	Input  string
+	Output string
+}{
+	"input",
+	"output",
+}
@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmmqreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ibmmqreceiver/internal/metadata"
+)
+
+const (
+	// queueStatusMetricsCount is the number of metrics recorded from the
+	// status of the queues.
+	queueStatusMetricsCount = 4
+	// queueMetricsCount is the number of metrics recorded from the definition
+	// of the queues.
+	queueMetricsCount = 1
+	// channelMetricsCount is the number of metrics recorded from the status
+	// of the channels.
+	channelMetricsCount = 3
+
+	systemObjectPrefix = "SYSTEM."
+)
+
+type ibmMQScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	client *mqClient
+}
+
+func newIBMMQScraper(logger *zap.Logger, cfg *Config) *ibmMQScraper {
+	return &ibmMQScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (s *ibmMQScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = &mqClient{
+		client:       httpClient,
+		endpoint:     s.cfg.Endpoint,
+		queueManager: s.cfg.QueueManager,
+		username:     s.cfg.Username,
+		password:     s.cfg.Password,
+	}
+	return nil
+}
+
+func (s *ibmMQScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	now := pdata.NewTimestampFromTime(time.Now())
+	metrics := pdata.NewMetricSlice()
+	var errs scrapererror.ScrapeErrors
+
+	if err := s.scrapeQueueStatus(ctx, metrics, now); err != nil {
+		errs.AddPartial(queueStatusMetricsCount, err)
+	}
+	if err := s.scrapeQueues(ctx, metrics, now); err != nil {
+		errs.AddPartial(queueMetricsCount, err)
+	}
+	if err := s.scrapeChannelStatus(ctx, metrics, now); err != nil {
+		errs.AddPartial(channelMetricsCount, err)
+	}
+
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			return metric.Gauge().DataPoints().Len() == 0
+		case pdata.MetricDataTypeSum:
+			return metric.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+	return metrics, errs.Combine()
+}
+
+// skip reports whether the object isn't scraped because it's a system object.
+func (s *ibmMQScraper) skip(name string) bool {
+	return name == "" || (!s.cfg.IncludeSystemObjects && strings.HasPrefix(name, systemObjectPrefix))
+}
+
+// scrapeQueueStatus records the depth and the handles of the queues.
+func (s *ibmMQScraper) scrapeQueueStatus(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	queues, err := s.client.display(ctx, "qstatus", s.cfg.Queues, "curdepth", "ipprocs", "opprocs", "msgage", "uncom")
+	if err != nil {
+		return fmt.Errorf("failed to display the queue status: %w", err)
+	}
+
+	depth := newMetric(metrics, metadata.M.IbmmqQueueDepth).Sum().DataPoints()
+	handles := newMetric(metrics, metadata.M.IbmmqQueueHandles).Sum().DataPoints()
+	age := newMetric(metrics, metadata.M.IbmmqQueueOldestMessageAge).Gauge().DataPoints()
+	uncommitted := newMetric(metrics, metadata.M.IbmmqQueueUncommittedMessages).Sum().DataPoints()
+	for _, params := range queues {
+		queue := stringParameter(params, "queue")
+		if s.skip(queue) {
+			continue
+		}
+		labels := map[string]string{metadata.L.QueueManager: s.cfg.QueueManager, metadata.L.Queue: queue}
+		addParameterPoint(depth, now, params, "curdepth", labels)
+		addParameterPoint(age, now, params, "msgage", labels)
+		addParameterPoint(uncommitted, now, params, "uncom", labels)
+		for param, handleType := range map[string]string{
+			"ipprocs": metadata.LabelHandleType.Input,
+			"opprocs": metadata.LabelHandleType.Output,
+		} {
+			addParameterPoint(handles, now, params, param, map[string]string{
+				metadata.L.QueueManager: s.cfg.QueueManager,
+				metadata.L.Queue:        queue,
+				metadata.L.HandleType:   handleType,
+			})
+		}
+	}
+	return nil
+}
+
+// scrapeQueues records the maximum depth of the local queues.
+func (s *ibmMQScraper) scrapeQueues(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	queues, err := s.client.display(ctx, "qlocal", s.cfg.Queues, "maxdepth")
+	if err != nil {
+		return fmt.Errorf("failed to display the local queues: %w", err)
+	}
+
+	maxDepth := newMetric(metrics, metadata.M.IbmmqQueueMaxDepth).Gauge().DataPoints()
+	for _, params := range queues {
+		queue := stringParameter(params, "queue")
+		if s.skip(queue) {
+			continue
+		}
+		addParameterPoint(maxDepth, now, params, "maxdepth", map[string]string{
+			metadata.L.QueueManager: s.cfg.QueueManager,
+			metadata.L.Queue:        queue,
+		})
+	}
+	return nil
+}
+
+// instanceKey identifies the channel instances counted together.
+type instanceKey struct {
+	channel, channelType, status string
+}
+
+// connectionKey identifies the channel instances whose traffic is summed.
+type connectionKey struct {
+	channel, connection string
+}
+
+type traffic struct {
+	messages, bytesSent, bytesReceived int64
+}
+
+// scrapeChannelStatus records the status and the traffic of the channel
+// instances.
+func (s *ibmMQScraper) scrapeChannelStatus(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	channels, err := s.client.display(ctx, "chstatus", s.cfg.Channels, "status", "chltype", "conname", "msgs", "bytssent", "bytsrcvd")
+	if err != nil {
+		return fmt.Errorf("failed to display the channel status: %w", err)
+	}
+
+	instances := map[instanceKey]int64{}
+	traffics := map[connectionKey]*traffic{}
+	for _, params := range channels {
+		channel := stringParameter(params, "channel")
+		if s.skip(channel) {
+			continue
+		}
+		instances[instanceKey{
+			channel:     channel,
+			channelType: strings.ToLower(stringParameter(params, "chltype")),
+			status:      strings.ToLower(stringParameter(params, "status")),
+		}]++
+
+		key := connectionKey{channel: channel, connection: stringParameter(params, "conname")}
+		t, ok := traffics[key]
+		if !ok {
+			t = &traffic{}
+			traffics[key] = t
+		}
+		// Channels which never started have no traffic.
+		msgs, _ := intParameter(params, "msgs")
+		sent, _ := intParameter(params, "bytssent")
+		received, _ := intParameter(params, "bytsrcvd")
+		t.messages += msgs
+		t.bytesSent += sent
+		t.bytesReceived += received
+	}
+
+	instanceDps := newMetric(metrics, metadata.M.IbmmqChannelInstances).Sum().DataPoints()
+	for key, count := range instances {
+		dp := addPoint(instanceDps, now, count)
+		dp.Attributes().InsertString(metadata.L.QueueManager, s.cfg.QueueManager)
+		dp.Attributes().InsertString(metadata.L.Channel, key.channel)
+		dp.Attributes().InsertString(metadata.L.ChannelType, key.channelType)
+		dp.Attributes().InsertString(metadata.L.ChannelStatus, key.status)
+	}
+
+	messages := newMetric(metrics, metadata.M.IbmmqChannelMessages).Sum().DataPoints()
+	bytes := newMetric(metrics, metadata.M.IbmmqChannelBytes).Sum().DataPoints()
+	for key, t := range traffics {
+		dp := addPoint(messages, now, t.messages)
+		insertConnection(dp, s.cfg.QueueManager, key)
+		dp = addPoint(bytes, now, t.bytesSent)
+		insertConnection(dp, s.cfg.QueueManager, key)
+		dp.Attributes().InsertString(metadata.L.Direction, metadata.LabelDirection.Sent)
+		dp = addPoint(bytes, now, t.bytesReceived)
+		insertConnection(dp, s.cfg.QueueManager, key)
+		dp.Attributes().InsertString(metadata.L.Direction, metadata.LabelDirection.Received)
+	}
+	return nil
+}
+
+func insertConnection(dp pdata.NumberDataPoint, queueManager string, key connectionKey) {
+	dp.Attributes().InsertString(metadata.L.QueueManager, queueManager)
+	dp.Attributes().InsertString(metadata.L.Channel, key.channel)
+	dp.Attributes().InsertString(metadata.L.Connection, key.connection)
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+func addPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64) pdata.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetIntVal(value)
+	return dp
+}
+
+// addParameterPoint records the value of an integer parameter, if it's
+// available.
+func addParameterPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, params map[string]interface{}, param string, labels map[string]string) {
+	value, ok := intParameter(params, param)
+	if !ok {
+		return
+	}
+	dp := addPoint(dps, now, value)
+	for k, v := range labels {
+		dp.Attributes().InsertString(k, v)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ibmmqreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
+)
+
+// newFakeMQWeb starts a server responding to the MQSC commands run on QM1
+// with the response of their qualifier, either inline or the name of a file of
+// testdata/mqsc.
+func newFakeMQWeb(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if user, password, ok := req.BasicAuth(); !ok || user != "mqadmin" || password != "passw0rd" {
+			http.Error(rw, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if req.Header.Get(csrfTokenHeader) == "" {
+			http.Error(rw, "Missing CSRF token", http.StatusForbidden)
+			return
+		}
+		if req.Method != http.MethodPost || req.URL.Path != "/ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc" {
+			http.NotFound(rw, req)
+			return
+		}
+		var cmd mqscCommand
+		if !assert.NoError(t, json.NewDecoder(req.Body).Decode(&cmd)) {
+			return
+		}
+		assert.Equal(t, "runCommandJSON", cmd.Type)
+		assert.Equal(t, "display", cmd.Command)
+		response, ok := responses[cmd.Qualifier]
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+		if strings.HasSuffix(response, ".json") {
+			body, err := ioutil.ReadFile(filepath.Join("testdata", "mqsc", response))
+			if !assert.NoError(t, err) {
+				return
+			}
+			response = string(body)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var defaultResponses = map[string]string{
+	"qstatus":  "qstatus.json",
+	"qlocal":   "qlocal.json",
+	"chstatus": "chstatus.json",
+}
+
+func newTestScraper(t *testing.T, endpoint string) *ibmMQScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.QueueManager = "QM1"
+	cfg.Username = "mqadmin"
+	cfg.Password = "passw0rd"
+	s := newIBMMQScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func countPoints(metrics pdata.MetricSlice, name string) int {
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		if m.DataType() == pdata.MetricDataTypeGauge {
+			return m.Gauge().DataPoints().Len()
+		}
+		return m.Sum().DataPoints().Len()
+	}
+	return 0
+}
+
+func metricNames(metrics pdata.MetricSlice) []string {
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	return names
+}
+
+func queueLabels(queue string) map[string]string {
+	return map[string]string{"ibmmq.queue_manager": "QM1", "ibmmq.queue": queue}
+}
+
+func TestScrape(t *testing.T) {
+	server := newFakeMQWeb(t, defaultResponses)
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+
+	orders := queueLabels("APP.ORDERS")
+	assert.EqualValues(t, 42, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.depth", orders))
+	assert.EqualValues(t, 5000, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.max_depth", orders))
+	assert.EqualValues(t, 37, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.oldest_message.age", orders))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.uncommitted_messages", orders))
+	assert.EqualValues(t, 2, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.handles", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.queue": "APP.ORDERS", "type": "input",
+	}))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.handles", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.queue": "APP.ORDERS", "type": "output",
+	}))
+	assert.EqualValues(t, 100000, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.max_depth", queueLabels("APP.PRICES")))
+	// The age of the oldest message isn't available without queue monitoring,
+	// and system queues aren't scraped.
+	assert.Equal(t, 1, countPoints(metrics, "ibmmq.queue.oldest_message.age"))
+	assert.Equal(t, 2, countPoints(metrics, "ibmmq.queue.depth"))
+	assert.Equal(t, 2, countPoints(metrics, "ibmmq.queue.max_depth"))
+
+	assert.EqualValues(t, 2, metricstestutil.FindIntPoint(t, metrics, "ibmmq.channel.instances", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.channel": "APP.SVRCONN", "ibmmq.channel.type": "svrconn", "status": "running",
+	}))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "ibmmq.channel.instances", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.channel": "QM1.TO.QM2", "ibmmq.channel.type": "sdr", "status": "retrying",
+	}))
+	svrconn := map[string]string{"ibmmq.queue_manager": "QM1", "ibmmq.channel": "APP.SVRCONN", "ibmmq.channel.connection": "10.0.0.5"}
+	assert.EqualValues(t, 150, metricstestutil.FindIntPoint(t, metrics, "ibmmq.channel.messages", svrconn))
+	assert.EqualValues(t, 41984, metricstestutil.FindIntPoint(t, metrics, "ibmmq.channel.bytes", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.channel": "APP.SVRCONN", "ibmmq.channel.connection": "10.0.0.5", "direction": "sent",
+	}))
+	assert.EqualValues(t, 10240, metricstestutil.FindIntPoint(t, metrics, "ibmmq.channel.bytes", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.channel": "APP.SVRCONN", "ibmmq.channel.connection": "10.0.0.5", "direction": "received",
+	}))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "ibmmq.channel.messages", map[string]string{
+		"ibmmq.queue_manager": "QM1", "ibmmq.channel": "QM1.TO.QM2", "ibmmq.channel.connection": "qm2.example.com(1414)",
+	}))
+}
+
+func TestScrapeSystemObjects(t *testing.T) {
+	server := newFakeMQWeb(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	s.cfg.IncludeSystemObjects = true
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 5000, metricstestutil.FindIntPoint(t, metrics, "ibmmq.queue.max_depth", queueLabels("SYSTEM.ADMIN.COMMAND.QUEUE")))
+	assert.Equal(t, 3, countPoints(metrics, "ibmmq.queue.depth"))
+}
+
+func TestScrapeNoChannelStatus(t *testing.T) {
+	server := newFakeMQWeb(t, map[string]string{
+		"qstatus": "qstatus.json",
+		"qlocal":  "qlocal.json",
+		"chstatus": `{"commandResponse":[{"completionCode":2,"reasonCode":3065,"text":["AMQ8420I: Channel Status not found."]}],
+			"overallCompletionCode":3,"overallReasonCode":3008}`,
+	})
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ibmmq.queue.depth",
+		"ibmmq.queue.handles",
+		"ibmmq.queue.oldest_message.age",
+		"ibmmq.queue.uncommitted_messages",
+		"ibmmq.queue.max_depth",
+	}, metricNames(metrics))
+}
+
+func TestScrapeCommandError(t *testing.T) {
+	server := newFakeMQWeb(t, map[string]string{
+		"qstatus": "qstatus.json",
+		"qlocal":  "qlocal.json",
+		"chstatus": `{"commandResponse":[{"completionCode":2,"reasonCode":2035,"text":["AMQ8135E: Not authorized."]}],
+			"overallCompletionCode":3,"overallReasonCode":3008}`,
+	})
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.EqualError(t, err, "failed to display the channel status: DISPLAY CHSTATUS(*) failed with reason 2035: AMQ8135E: Not authorized.")
+	assert.Equal(t, 5, metrics.Len())
+}
+
+func TestScrapeUnauthorized(t *testing.T) {
+	server := newFakeMQWeb(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	s.client.password = "wrong"
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to display the queue status: POST /ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc returned 401: Unauthorized")
+	assert.Contains(t, err.Error(), "failed to display the channel status: POST /ibmmq/rest/v2/admin/action/qmgr/QM1/mqsc returned 401: Unauthorized")
+	assert.Equal(t, 0, metrics.Len())
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	// ModeWebhook receives the events of the webhook audit backend of the
+	// API server.
+	ModeWebhook = "webhook"
+	// ModeFile tails the file of the log audit backend of the API server.
+	ModeFile = "file"
+)
+
+// Config defines configuration for the Kubernetes audit receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Mode is how audit events are received: webhook or file.
+	Mode string `mapstructure:"mode"`
+
+	// Webhook configures the endpoint of the webhook audit backend.
+	Webhook WebhookConfig `mapstructure:"webhook"`
+
+	// File configures the file of the log audit backend.
+	File FileConfig `mapstructure:"file"`
+
+	// ClusterName, if set, is added to the resource of events as
+	// k8s.cluster.name.
+	ClusterName string `mapstructure:"cluster_name"`
+
+	// MetricsInterval is the interval the request metrics are sent at, when
+	// the receiver is part of a metrics pipeline.
+	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
+}
+
+// WebhookConfig defines the endpoint the API server sends batches of events
+// to, configured with --audit-webhook-config-file.
+type WebhookConfig struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path is the URL path of the endpoint.
+	Path string `mapstructure:"path"`
+}
+
+// FileConfig defines the file the API server writes events to, configured
+// with --audit-log-path.
+type FileConfig struct {
+	// Path is the path of the file.
+	Path string `mapstructure:"path"`
+
+	// StartAt is where the file is first read from: beginning or end.
+	StartAt string `mapstructure:"start_at"`
+
+	// PollInterval is the interval the file is checked for new events at.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.Mode {
+	case ModeWebhook:
+		if cfg.Webhook.Endpoint == "" {
+			return errors.New("webhook.endpoint must be specified")
+		}
+		if !strings.HasPrefix(cfg.Webhook.Path, "/") {
+			return fmt.Errorf("webhook.path must start with '/', got %q", cfg.Webhook.Path)
+		}
+	case ModeFile:
+		if cfg.File.Path == "" {
+			return errors.New("file.path must be specified")
+		}
+		if cfg.File.StartAt != startAtBeginning && cfg.File.StartAt != startAtEnd {
+			return fmt.Errorf("invalid file.start_at %q, must be %q or %q", cfg.File.StartAt, startAtBeginning, startAtEnd)
+		}
+		if cfg.File.PollInterval <= 0 {
+			return errors.New("file.poll_interval must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid mode %q, must be %q or %q", cfg.Mode, ModeWebhook, ModeFile)
+	}
+	if cfg.MetricsInterval <= 0 {
+		return errors.New("metrics_interval must be positive")
+	}
+	return nil
+}
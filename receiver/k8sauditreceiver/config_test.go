@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	assert.Equal(t, r0, factory.CreateDefaultConfig())
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "file")]
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "file")),
+		Mode:             ModeFile,
+		Webhook: WebhookConfig{
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: defaultEndpoint,
+			},
+			Path: defaultWebhookPath,
+		},
+		File: FileConfig{
+			Path:         "/var/log/kubernetes/audit/audit.log",
+			StartAt:      startAtBeginning,
+			PollInterval: 5 * time.Second,
+		},
+		ClusterName:     "production",
+		MetricsInterval: time.Minute,
+	}, r1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "file",
+			modify: func(cfg *Config) {
+				cfg.Mode = ModeFile
+				cfg.File.Path = "/var/log/audit.log"
+			},
+		},
+		{
+			name:   "invalid mode",
+			modify: func(cfg *Config) { cfg.Mode = "syslog" },
+			err:    `invalid mode "syslog", must be "webhook" or "file"`,
+		},
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Webhook.Endpoint = "" },
+			err:    "webhook.endpoint must be specified",
+		},
+		{
+			name:   "relative path",
+			modify: func(cfg *Config) { cfg.Webhook.Path = "audit" },
+			err:    `webhook.path must start with '/', got "audit"`,
+		},
+		{
+			name:   "missing file path",
+			modify: func(cfg *Config) { cfg.Mode = ModeFile },
+			err:    "file.path must be specified",
+		},
+		{
+			name: "invalid start_at",
+			modify: func(cfg *Config) {
+				cfg.Mode = ModeFile
+				cfg.File.Path = "/var/log/audit.log"
+				cfg.File.StartAt = "middle"
+			},
+			err: `invalid file.start_at "middle", must be "beginning" or "end"`,
+		},
+		{
+			name: "invalid poll_interval",
+			modify: func(cfg *Config) {
+				cfg.Mode = ModeFile
+				cfg.File.Path = "/var/log/audit.log"
+				cfg.File.PollInterval = 0
+			},
+			err: "file.poll_interval must be positive",
+		},
+		{
+			name:   "invalid metrics_interval",
+			modify: func(cfg *Config) { cfg.MetricsInterval = 0 },
+			err:    "metrics_interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
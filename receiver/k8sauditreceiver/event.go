@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	attributeAuditID               = "k8s.audit.id"
+	attributeLevel                 = "k8s.audit.level"
+	attributeStage                 = "k8s.audit.stage"
+	attributeVerb                  = "k8s.audit.verb"
+	attributeRequestURI            = "k8s.audit.request_uri"
+	attributeUserName              = "k8s.audit.user.name"
+	attributeUserUID               = "k8s.audit.user.uid"
+	attributeUserGroups            = "k8s.audit.user.groups"
+	attributeImpersonatedUserName  = "k8s.audit.impersonated_user.name"
+	attributeImpersonatedUserGroup = "k8s.audit.impersonated_user.groups"
+	attributeSourceIPs             = "k8s.audit.source_ips"
+	attributeResource              = "k8s.audit.object.resource"
+	attributeSubresource           = "k8s.audit.object.subresource"
+	attributeAPIGroup              = "k8s.audit.object.api_group"
+	attributeAPIVersion            = "k8s.audit.object.api_version"
+	attributeObjectName            = "k8s.audit.object.name"
+	attributeResponseStatus        = "k8s.audit.response.status"
+	attributeResponseReason        = "k8s.audit.response.reason"
+	attributeResponseMessage       = "k8s.audit.response.message"
+	attributeAnnotationPrefix      = "k8s.audit.annotations."
+)
+
+const (
+	stageResponseComplete = "ResponseComplete"
+	stagePanic            = "Panic"
+)
+
+// eventList is a batch of events sent by the webhook backend.
+type eventList struct {
+	Items []json.RawMessage `json:"items"`
+}
+
+// event is an audit.k8s.io/v1 Event. Only the fields mapped to attributes are
+// decoded, the whole event is kept as the body of logs.
+type event struct {
+	Level                    string            `json:"level"`
+	AuditID                  string            `json:"auditID"`
+	Stage                    string            `json:"stage"`
+	RequestURI               string            `json:"requestURI"`
+	Verb                     string            `json:"verb"`
+	User                     userInfo          `json:"user"`
+	ImpersonatedUser         *userInfo         `json:"impersonatedUser"`
+	SourceIPs                []string          `json:"sourceIPs"`
+	UserAgent                string            `json:"userAgent"`
+	ObjectRef                *objectReference  `json:"objectRef"`
+	ResponseStatus           *status           `json:"responseStatus"`
+	RequestReceivedTimestamp time.Time         `json:"requestReceivedTimestamp"`
+	StageTimestamp           time.Time         `json:"stageTimestamp"`
+	Annotations              map[string]string `json:"annotations"`
+}
+
+type userInfo struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid"`
+	Groups   []string `json:"groups"`
+}
+
+type objectReference struct {
+	Resource    string `json:"resource"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	APIGroup    string `json:"apiGroup"`
+	APIVersion  string `json:"apiVersion"`
+	Subresource string `json:"subresource"`
+}
+
+type status struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Code    int    `json:"code"`
+}
+
+// completed reports whether the event is the last of its request, the one
+// request metrics count.
+func (e *event) completed() bool {
+	return e.Stage == stageResponseComplete || e.Stage == stagePanic
+}
+
+func (e *event) code() int {
+	if e.ResponseStatus == nil {
+		return 0
+	}
+	return e.ResponseStatus.Code
+}
+
+// appendLog appends the log of the event, whose JSON encoding is raw, to
+// logs.
+func appendLog(logs pdata.LogSlice, e *event, raw []byte) {
+	lr := logs.AppendEmpty()
+	ts := e.StageTimestamp
+	if ts.IsZero() {
+		ts = e.RequestReceivedTimestamp
+	}
+	lr.SetTimestamp(pdata.NewTimestampFromTime(ts))
+	lr.Body().SetStringVal(string(raw))
+
+	switch code := e.code(); {
+	case e.Stage == stagePanic || code >= 500:
+		lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+		lr.SetSeverityText("ERROR")
+	case code >= 400:
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+		lr.SetSeverityText("WARN")
+	default:
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+		lr.SetSeverityText("INFO")
+	}
+
+	attrs := lr.Attributes()
+	insertString(attrs, attributeAuditID, e.AuditID)
+	insertString(attrs, attributeLevel, e.Level)
+	insertString(attrs, attributeStage, e.Stage)
+	insertString(attrs, attributeVerb, e.Verb)
+	insertString(attrs, attributeRequestURI, e.RequestURI)
+	insertString(attrs, attributeUserName, e.User.Username)
+	insertString(attrs, attributeUserUID, e.User.UID)
+	insertStrings(attrs, attributeUserGroups, e.User.Groups)
+	if e.ImpersonatedUser != nil {
+		insertString(attrs, attributeImpersonatedUserName, e.ImpersonatedUser.Username)
+		insertStrings(attrs, attributeImpersonatedUserGroup, e.ImpersonatedUser.Groups)
+	}
+	insertStrings(attrs, attributeSourceIPs, e.SourceIPs)
+	insertString(attrs, conventions.AttributeHTTPUserAgent, e.UserAgent)
+	if ref := e.ObjectRef; ref != nil {
+		insertString(attrs, attributeResource, ref.Resource)
+		insertString(attrs, attributeSubresource, ref.Subresource)
+		insertString(attrs, attributeAPIGroup, ref.APIGroup)
+		insertString(attrs, attributeAPIVersion, ref.APIVersion)
+		insertString(attrs, attributeObjectName, ref.Name)
+		insertString(attrs, conventions.AttributeK8SNamespaceName, ref.Namespace)
+	}
+	if rs := e.ResponseStatus; rs != nil {
+		if rs.Code != 0 {
+			attrs.InsertInt(conventions.AttributeHTTPStatusCode, int64(rs.Code))
+		}
+		insertString(attrs, attributeResponseStatus, rs.Status)
+		insertString(attrs, attributeResponseReason, rs.Reason)
+		insertString(attrs, attributeResponseMessage, rs.Message)
+	}
+	for k, v := range e.Annotations {
+		attrs.InsertString(attributeAnnotationPrefix+k, v)
+	}
+}
+
+func insertString(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+func insertStrings(attrs pdata.AttributeMap, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	av := pdata.NewAttributeValueArray()
+	for _, value := range values {
+		av.ArrayVal().AppendEmpty().SetStringVal(value)
+	}
+	attrs.Insert(key, av)
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// loadEvents returns the JSON encoded events of testdata/events.json.
+func loadEvents(t *testing.T) [][]byte {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "events.json"))
+	require.NoError(t, err)
+	var list eventList
+	require.NoError(t, json.Unmarshal(body, &list))
+	raws := make([][]byte, len(list.Items))
+	for i, item := range list.Items {
+		raws[i] = item
+	}
+	return raws
+}
+
+func translate(t *testing.T, raw []byte) pdata.LogRecord {
+	var e event
+	require.NoError(t, json.Unmarshal(raw, &e))
+	logs := pdata.NewLogSlice()
+	appendLog(logs, &e, raw)
+	require.Equal(t, 1, logs.Len())
+	return logs.At(0)
+}
+
+func TestAppendLog(t *testing.T) {
+	raws := loadEvents(t)
+	lr := translate(t, raws[1])
+
+	assert.Equal(t, time.Date(2021, 10, 4, 12, 0, 0, 112000000, time.UTC), lr.Timestamp().AsTime())
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.Equal(t, "INFO", lr.SeverityText())
+	assert.JSONEq(t, string(raws[1]), lr.Body().StringVal())
+
+	assert.Equal(t, map[string]interface{}{
+		"k8s.audit.id":                 "a9a1c7e6-1c1f-4f8c-9d6c-2b1ef0f3a001",
+		"k8s.audit.level":              "Metadata",
+		"k8s.audit.stage":              "ResponseComplete",
+		"k8s.audit.verb":               "get",
+		"k8s.audit.request_uri":        "/api/v1/namespaces/default/pods/nginx",
+		"k8s.audit.user.name":          "alice",
+		"k8s.audit.user.uid":           "1001",
+		"k8s.audit.user.groups":        []interface{}{"developers", "system:authenticated"},
+		"k8s.audit.source_ips":         []interface{}{"10.0.0.12"},
+		"http.user_agent":              "kubectl/v1.22.2 (linux/amd64) kubernetes/8b5a191",
+		"k8s.audit.object.resource":    "pods",
+		"k8s.audit.object.api_version": "v1",
+		"k8s.audit.object.name":        "nginx",
+		"k8s.namespace.name":           "default",
+		"http.status_code":             int64(200),
+		"k8s.audit.annotations.authorization.k8s.io/decision": "allow",
+		"k8s.audit.annotations.authorization.k8s.io/reason":   `RBAC: allowed by RoleBinding "developers/default" of Role "developer" to Group "developers"`,
+	}, attributesToMap(lr.Attributes()))
+}
+
+func TestAppendLogImpersonatedForbidden(t *testing.T) {
+	lr := translate(t, loadEvents(t)[2])
+
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	attrs := attributesToMap(lr.Attributes())
+	assert.Equal(t, "system:serviceaccount:ci:deployer", attrs["k8s.audit.user.name"])
+	assert.Equal(t, "bob", attrs["k8s.audit.impersonated_user.name"])
+	assert.Equal(t, []interface{}{"admins"}, attrs["k8s.audit.impersonated_user.groups"])
+	assert.Equal(t, []interface{}{"10.0.1.7", "192.168.1.1"}, attrs["k8s.audit.source_ips"])
+	assert.Equal(t, "Failure", attrs["k8s.audit.response.status"])
+	assert.Equal(t, "Forbidden", attrs["k8s.audit.response.reason"])
+	assert.Contains(t, attrs["k8s.audit.response.message"], "cannot create resource")
+	assert.Equal(t, int64(403), attrs["http.status_code"])
+	assert.NotContains(t, attrs, "k8s.audit.object.name")
+}
+
+func TestAppendLogSeverity(t *testing.T) {
+	raws := loadEvents(t)
+	// Events of requests still in progress have no response status.
+	lr := translate(t, raws[0])
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.NotContains(t, attributesToMap(lr.Attributes()), "http.status_code")
+
+	lr = translate(t, raws[3])
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	assert.Equal(t, "scale", attributesToMap(lr.Attributes())["k8s.audit.object.subresource"])
+	assert.Equal(t, "apps", attributesToMap(lr.Attributes())["k8s.audit.object.api_group"])
+
+	lr = translate(t, []byte(`{"stage":"Panic","verb":"list","stageTimestamp":"2021-10-04T12:00:03Z"}`))
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			m[k] = v.IntVal()
+		case pdata.AttributeValueTypeArray:
+			var values []interface{}
+			for i := 0; i < v.ArrayVal().Len(); i++ {
+				values = append(values, v.ArrayVal().At(i).StringVal())
+			}
+			m[k] = values
+		default:
+			m[k] = v.StringVal()
+		}
+		return true
+	})
+	return m
+}
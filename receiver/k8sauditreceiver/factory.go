@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "k8saudit"
+
+	defaultEndpoint        = "0.0.0.0:8090"
+	defaultWebhookPath     = "/audit"
+	defaultPollInterval    = time.Second
+	defaultMetricsInterval = 30 * time.Second
+)
+
+// NewFactory creates a factory for the Kubernetes audit receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithLogs(createLogsReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Mode:             ModeWebhook,
+		Webhook: WebhookConfig{
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: defaultEndpoint,
+			},
+			Path: defaultWebhookPath,
+		},
+		File: FileConfig{
+			StartAt:      startAtEnd,
+			PollInterval: defaultPollInterval,
+		},
+		MetricsInterval: defaultMetricsInterval,
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	r := getReceiver(params, cfg.(*Config))
+	if err := r.registerLogsConsumer(consumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	r := getReceiver(params, cfg.(*Config))
+	if err := r.registerMetricsConsumer(consumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getReceiver returns the receiver of cfg, shared by the logs and metrics
+// pipelines it's part of.
+func getReceiver(params component.ReceiverCreateSettings, cfg *Config) *auditReceiver {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+	r := receivers[cfg]
+	if r == nil {
+		r = newAuditReceiver(params.TelemetrySettings, cfg)
+		receivers[cfg] = r
+	}
+	return r
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*auditReceiver{}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const metricRequests = "k8s.audit.requests"
+
+// requestKey identifies a series of the request metric.
+type requestKey struct {
+	userAgent string
+	verb      string
+	resource  string
+	code      int
+}
+
+type requestCount struct {
+	start pdata.Timestamp
+	count int64
+}
+
+// requestCounter counts the completed requests of the events by user agent,
+// verb, resource and response code.
+type requestCounter struct {
+	mu     sync.Mutex
+	counts map[requestKey]*requestCount
+}
+
+func newRequestCounter() *requestCounter {
+	return &requestCounter{counts: map[requestKey]*requestCount{}}
+}
+
+func (c *requestCounter) record(events []*event, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range events {
+		if !e.completed() {
+			continue
+		}
+		key := requestKey{userAgent: e.UserAgent, verb: e.Verb, code: e.code()}
+		if e.ObjectRef != nil {
+			key.resource = e.ObjectRef.Resource
+		}
+		rc, ok := c.counts[key]
+		if !ok {
+			rc = &requestCount{start: pdata.NewTimestampFromTime(now)}
+			c.counts[key] = rc
+		}
+		rc.count++
+	}
+}
+
+// appendMetrics appends the request counts, as a cumulative sum, to metrics.
+func (c *requestCounter) appendMetrics(metrics pdata.MetricSlice, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.counts) == 0 {
+		return
+	}
+
+	metric := metrics.AppendEmpty()
+	metric.SetName(metricRequests)
+	metric.SetDescription("Number of requests completed by the API server.")
+	metric.SetUnit("{requests}")
+	metric.SetDataType(pdata.MetricDataTypeSum)
+	metric.Sum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+
+	keys := make([]requestKey, 0, len(c.counts))
+	for key := range c.counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.userAgent != b.userAgent {
+			return a.userAgent < b.userAgent
+		}
+		if a.verb != b.verb {
+			return a.verb < b.verb
+		}
+		if a.resource != b.resource {
+			return a.resource < b.resource
+		}
+		return a.code < b.code
+	})
+	ts := pdata.NewTimestampFromTime(now)
+	for _, key := range keys {
+		rc := c.counts[key]
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(rc.start)
+		dp.SetTimestamp(ts)
+		dp.SetIntVal(rc.count)
+		attrs := dp.Attributes()
+		attrs.InsertString(conventions.AttributeHTTPUserAgent, key.userAgent)
+		attrs.InsertString(attributeVerb, key.verb)
+		attrs.InsertString(attributeResource, key.resource)
+		attrs.InsertInt(conventions.AttributeHTTPStatusCode, int64(key.code))
+	}
+}
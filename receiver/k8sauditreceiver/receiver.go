@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultServerTimeout = 20 * time.Second
+
+	format = "k8s_audit"
+)
+
+// auditReceiver receives audit events, sends them as logs and counts their
+// requests. It's shared by the logs and metrics pipelines of its
+// configuration.
+type auditReceiver struct {
+	settings component.TelemetrySettings
+	config   *Config
+	obsrecv  *obsreport.Receiver
+
+	mu              sync.Mutex
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+	requests        *requestCounter
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	server    *http.Server
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newAuditReceiver(settings component.TelemetrySettings, config *Config) *auditReceiver {
+	transport := "file"
+	if config.Mode == ModeWebhook {
+		transport = "http"
+		if config.Webhook.TLSSetting != nil {
+			transport = "https"
+		}
+	}
+	return &auditReceiver{
+		settings: settings,
+		config:   config,
+		obsrecv:  obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (r *auditReceiver) registerLogsConsumer(lc consumer.Logs) error {
+	if lc == nil {
+		return componenterror.ErrNilNextConsumer
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logsConsumer = lc
+	return nil
+}
+
+func (r *auditReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
+	if mc == nil {
+		return componenterror.ErrNilNextConsumer
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metricsConsumer = mc
+	r.requests = newRequestCounter()
+	return nil
+}
+
+// Start starts receiving events. It's called once per pipeline of the
+// receiver, but only starts it once.
+func (r *auditReceiver) Start(_ context.Context, host component.Host) error {
+	var err error
+	r.startOnce.Do(func() {
+		if r.config.Mode == ModeWebhook {
+			err = r.startWebhook(host)
+		} else {
+			r.startFile()
+		}
+		if err == nil && r.metricsConsumer != nil {
+			r.startMetrics()
+		}
+	})
+	return err
+}
+
+func (r *auditReceiver) Shutdown(context.Context) error {
+	var err error
+	r.stopOnce.Do(func() {
+		if r.server != nil {
+			err = r.server.Close()
+		}
+		close(r.done)
+		r.wg.Wait()
+	})
+	return err
+}
+
+func (r *auditReceiver) startWebhook(host component.Host) error {
+	ln, err := r.config.Webhook.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Webhook.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(r.config.Webhook.Path, r.handleEvents)
+
+	r.server = r.config.Webhook.ToServer(router, r.settings)
+	r.server.ReadHeaderTimeout = defaultServerTimeout
+	r.server.WriteTimeout = defaultServerTimeout
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (r *auditReceiver) startFile() {
+	t := newTailer(r.config.File.Path, r.config.File.StartAt)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer t.close()
+		ticker := time.NewTicker(r.config.File.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := t.poll(func(lines [][]byte) {
+					// The API server doesn't resend events, so events that
+					// fail to be consumed are dropped.
+					if err := r.consume(context.Background(), lines); err != nil {
+						r.settings.Logger.Error("Failed to consume audit events", zap.Error(err))
+					}
+				})
+				if err != nil {
+					r.settings.Logger.Error("Failed to read audit log file", zap.String("path", r.config.File.Path), zap.Error(err))
+				}
+			case <-r.done:
+				return
+			}
+		}
+	}()
+}
+
+func (r *auditReceiver) startMetrics() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.MetricsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.exportMetrics(context.Background())
+			case <-r.done:
+				return
+			}
+		}
+	}()
+}
+
+func (r *auditReceiver) handleEvents(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var list eventList
+	if err := json.NewDecoder(req.Body).Decode(&list); err != nil {
+		http.Error(w, "failed to decode the event list", http.StatusBadRequest)
+		return
+	}
+	items := make([][]byte, len(list.Items))
+	for i, item := range list.Items {
+		items[i] = item
+	}
+
+	if err := r.consume(req.Context(), items); err != nil {
+		if consumererror.IsPermanent(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			// The webhook backend retries batches on 5xx responses.
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// consume sends the events, each JSON encoded, as logs, and counts their
+// requests once the logs are consumed. Invalid events are skipped.
+func (r *auditReceiver) consume(ctx context.Context, raws [][]byte) error {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	if r.config.ClusterName != "" {
+		rl.Resource().Attributes().InsertString(conventions.AttributeK8SClusterName, r.config.ClusterName)
+	}
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+
+	events := make([]*event, 0, len(raws))
+	invalid := 0
+	for _, raw := range raws {
+		e := &event{}
+		if err := json.Unmarshal(raw, e); err != nil {
+			invalid++
+			continue
+		}
+		events = append(events, e)
+		appendLog(logs, e, raw)
+	}
+	if invalid > 0 {
+		r.settings.Logger.Debug("Skipped invalid audit events", zap.Int("count", invalid))
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	if r.logsConsumer != nil {
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err := r.logsConsumer.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, format, len(events), err)
+		if err != nil {
+			return err
+		}
+	}
+	if r.requests != nil {
+		r.requests.record(events, time.Now())
+	}
+	return nil
+}
+
+// exportMetrics sends the request counts to the metrics consumer.
+func (r *auditReceiver) exportMetrics(ctx context.Context) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	if r.config.ClusterName != "" {
+		rm.Resource().Attributes().InsertString(conventions.AttributeK8SClusterName, r.config.ClusterName)
+	}
+	r.requests.appendMetrics(rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics(), time.Now())
+	count := md.DataPointCount()
+	if count == 0 {
+		return
+	}
+
+	ctx = r.obsrecv.StartMetricsOp(ctx)
+	err := r.metricsConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, format, count, err)
+	if err != nil {
+		r.settings.Logger.Error("Failed to export audit request metrics", zap.Error(err))
+	}
+}
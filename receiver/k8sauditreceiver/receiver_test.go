@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
+)
+
+func newTestReceiver(t *testing.T, cfg *Config, logs consumer.Logs, metrics consumer.Metrics) *auditReceiver {
+	r := newAuditReceiver(componenttest.NewNopTelemetrySettings(), cfg)
+	if logs != nil {
+		require.NoError(t, r.registerLogsConsumer(logs))
+	}
+	if metrics != nil {
+		require.NoError(t, r.registerMetricsConsumer(metrics))
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return r
+}
+
+func newWebhookReceiver(t *testing.T, logs consumer.Logs, metrics consumer.Metrics) (*auditReceiver, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Webhook.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.ClusterName = "production"
+	return newTestReceiver(t, cfg, logs, metrics), "http://" + cfg.Webhook.Endpoint + cfg.Webhook.Path
+}
+
+func postEvents(t *testing.T, url string, body []byte) int {
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func loadEventList(t *testing.T) []byte {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "events.json"))
+	require.NoError(t, err)
+	return body
+}
+
+func TestWebhook(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	_, url := newWebhookReceiver(t, sink, nil)
+
+	assert.Equal(t, http.StatusOK, postEvents(t, url, loadEventList(t)))
+	require.Len(t, sink.AllLogs(), 1)
+	ld := sink.AllLogs()[0]
+	assert.Equal(t, 4, ld.LogRecordCount())
+	cluster, ok := ld.ResourceLogs().At(0).Resource().Attributes().Get("k8s.cluster.name")
+	require.True(t, ok)
+	assert.Equal(t, "production", cluster.StringVal())
+
+	// Invalid events are skipped.
+	assert.Equal(t, http.StatusOK, postEvents(t, url, []byte(`{"kind":"EventList","items":[{"verb":"get"},"invalid"]}`)))
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 1, sink.AllLogs()[1].LogRecordCount())
+}
+
+func TestWebhookInvalidRequests(t *testing.T) {
+	_, url := newWebhookReceiver(t, consumertest.NewNop(), nil)
+
+	assert.Equal(t, http.StatusBadRequest, postEvents(t, url, []byte("not json")))
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestWebhookConsumerErrors(t *testing.T) {
+	_, url := newWebhookReceiver(t, consumertest.NewErr(errors.New("queue is full")), nil)
+	assert.Equal(t, http.StatusServiceUnavailable, postEvents(t, url, loadEventList(t)))
+
+	_, url = newWebhookReceiver(t, consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid"))), nil)
+	assert.Equal(t, http.StatusBadRequest, postEvents(t, url, loadEventList(t)))
+}
+
+func TestRequestMetrics(t *testing.T) {
+	metrics := new(consumertest.MetricsSink)
+	r, url := newWebhookReceiver(t, consumertest.NewNop(), metrics)
+
+	// Nothing is sent before requests complete.
+	r.exportMetrics(context.Background())
+	assert.Len(t, metrics.AllMetrics(), 0)
+
+	require.Equal(t, http.StatusOK, postEvents(t, url, loadEventList(t)))
+	require.Equal(t, http.StatusOK, postEvents(t, url, loadEventList(t)))
+	r.exportMetrics(context.Background())
+	require.Len(t, metrics.AllMetrics(), 1)
+
+	rm := metrics.AllMetrics()[0].ResourceMetrics().At(0)
+	cluster, _ := rm.Resource().Attributes().Get("k8s.cluster.name")
+	assert.Equal(t, "production", cluster.StringVal())
+	metric := rm.InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "k8s.audit.requests", metric.Name())
+	assert.True(t, metric.Sum().IsMonotonic())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+
+	// The RequestReceived stage isn't counted.
+	var series []string
+	dps := metric.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		attrs := attributesToMap(dps.At(i).Attributes())
+		series = append(series, fmt.Sprintf("%s %s %s %d: %d", attrs["http.user_agent"], attrs["k8s.audit.verb"],
+			attrs["k8s.audit.object.resource"], attrs["http.status_code"], dps.At(i).IntVal()))
+	}
+	assert.Equal(t, []string{
+		"helm/v3.7.0 create secrets 403: 2",
+		"kubectl/v1.22.2 (linux/amd64) kubernetes/8b5a191 get pods 200: 2",
+		"kubectl/v1.22.2 (linux/amd64) kubernetes/8b5a191 update deployments 500: 2",
+	}, series)
+}
+
+func TestRequestMetricsWithoutLogs(t *testing.T) {
+	metrics := new(consumertest.MetricsSink)
+	r, url := newWebhookReceiver(t, nil, metrics)
+	// Start is called by each pipeline of the receiver.
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Equal(t, http.StatusOK, postEvents(t, url, loadEventList(t)))
+	r.exportMetrics(context.Background())
+	require.Len(t, metrics.AllMetrics(), 1)
+	assert.Equal(t, 3, metrics.AllMetrics()[0].DataPointCount())
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = ModeFile
+	cfg.File.Path = path
+	cfg.File.StartAt = startAtBeginning
+	cfg.File.PollInterval = 10 * time.Millisecond
+	require.NoError(t, cfg.Validate())
+
+	var lines []string
+	for _, raw := range loadEvents(t) {
+		var line bytes.Buffer
+		require.NoError(t, json.Compact(&line, raw))
+		lines = append(lines, line.String())
+	}
+	appendFile(t, path, strings.Join(lines[:2], "\n")+"\n")
+
+	sink := new(consumertest.LogsSink)
+	newTestReceiver(t, cfg, sink, nil)
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	appendFile(t, path, strings.Join(lines[2:], "\n")+"\n")
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+}
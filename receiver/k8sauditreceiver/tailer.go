@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+)
+
+const (
+	startAtBeginning = "beginning"
+	startAtEnd       = "end"
+
+	// maxBatchSize is the maximum number of events of a batch read from the
+	// file.
+	maxBatchSize = 1000
+)
+
+// tailer reads the lines appended to a file, following its rotations.
+type tailer struct {
+	path    string
+	startAt string
+
+	file   *os.File
+	info   os.FileInfo
+	offset int64
+	// opened reports whether a file was opened before, in which case new
+	// files are read from the beginning, as they were created by a rotation.
+	opened bool
+}
+
+func newTailer(path, startAt string) *tailer {
+	return &tailer{path: path, startAt: startAt}
+}
+
+// poll calls emit with batches of the complete lines appended to the file
+// since the previous poll. A line is only read once terminated, so that
+// events being written aren't split.
+func (t *tailer) poll(emit func(lines [][]byte)) error {
+	if t.file != nil {
+		info, err := os.Stat(t.path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// The file was rotated and the new one isn't created yet.
+			return t.read(emit)
+		case err != nil:
+			return err
+		case !os.SameFile(t.info, info):
+			// The file was rotated: the rest of the old file is read
+			// before switching to the new one.
+			if err := t.read(emit); err != nil {
+				return err
+			}
+			t.close()
+		case info.Size() < t.offset:
+			// The file was truncated.
+			t.offset = 0
+		}
+	}
+	if t.file == nil {
+		if err := t.open(); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+	}
+	return t.read(emit)
+}
+
+func (t *tailer) open() error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	t.file, t.info, t.offset = f, info, 0
+	if !t.opened && t.startAt == startAtEnd {
+		t.offset = info.Size()
+	}
+	t.opened = true
+	return nil
+}
+
+func (t *tailer) read(emit func(lines [][]byte)) error {
+	if _, err := t.file.Seek(t.offset, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(t.file)
+	var lines [][]byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// The incomplete line is read again at the next poll.
+			break
+		}
+		if err != nil {
+			return err
+		}
+		t.offset += int64(len(line))
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			lines = append(lines, line)
+		}
+		if len(lines) == maxBatchSize {
+			emit(lines)
+			lines = nil
+		}
+	}
+	if len(lines) > 0 {
+		emit(lines)
+	}
+	return nil
+}
+
+func (t *tailer) close() {
+	if t.file != nil {
+		_ = t.file.Close()
+		t.file = nil
+	}
+}
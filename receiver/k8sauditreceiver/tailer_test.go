@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sauditreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func pollLines(t *testing.T, tl *tailer) []string {
+	var lines []string
+	require.NoError(t, tl.poll(func(batch [][]byte) {
+		for _, line := range batch {
+			lines = append(lines, string(line))
+		}
+	}))
+	return lines
+}
+
+func TestTailerStartAtEnd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	appendFile(t, path, "{\"auditID\":\"1\"}\n")
+
+	tl := newTailer(path, startAtEnd)
+	defer tl.close()
+	assert.Empty(t, pollLines(t, tl))
+
+	// Lines are only read once complete.
+	appendFile(t, path, "{\"auditID\":\"2\"}\n\n{\"auditID\":")
+	assert.Equal(t, []string{`{"auditID":"2"}`}, pollLines(t, tl))
+	appendFile(t, path, "\"3\"}\n")
+	assert.Equal(t, []string{`{"auditID":"3"}`}, pollLines(t, tl))
+	assert.Empty(t, pollLines(t, tl))
+}
+
+func TestTailerStartAtBeginning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	tl := newTailer(path, startAtBeginning)
+	defer tl.close()
+
+	// The file doesn't exist yet.
+	assert.Empty(t, pollLines(t, tl))
+	appendFile(t, path, "{\"auditID\":\"1\"}\n")
+	assert.Equal(t, []string{`{"auditID":"1"}`}, pollLines(t, tl))
+}
+
+func TestTailerRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	appendFile(t, path, "{\"auditID\":\"1\"}\n")
+	tl := newTailer(path, startAtEnd)
+	defer tl.close()
+	assert.Empty(t, pollLines(t, tl))
+
+	// The lines written before the rotation are read from the old file.
+	appendFile(t, path, "{\"auditID\":\"2\"}\n")
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "audit-2021-10-04T12-00-00.000.log")))
+	assert.Equal(t, []string{`{"auditID":"2"}`}, pollLines(t, tl))
+
+	// The new file is read from the beginning.
+	appendFile(t, path, "{\"auditID\":\"3\"}\n")
+	assert.Equal(t, []string{`{"auditID":"3"}`}, pollLines(t, tl))
+}
+
+func TestTailerTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	appendFile(t, path, "{\"auditID\":\"1\"}\n")
+	tl := newTailer(path, startAtBeginning)
+	defer tl.close()
+	assert.Len(t, pollLines(t, tl), 1)
+
+	require.NoError(t, os.Truncate(path, 0))
+	appendFile(t, path, "{\"id\":2}\n")
+	assert.Equal(t, []string{`{"id":2}`}, pollLines(t, tl))
+}
+
+func TestTailerBatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	appendFile(t, path, strings.Repeat("{}\n", maxBatchSize+1))
+	tl := newTailer(path, startAtBeginning)
+	defer tl.close()
+
+	var sizes []int
+	require.NoError(t, tl.poll(func(lines [][]byte) {
+		sizes = append(sizes, len(lines))
+	}))
+	assert.Equal(t, []int{maxBatchSize, 1}, sizes)
+}
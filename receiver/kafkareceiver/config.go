@@ -15,6 +15,9 @@
 package kafkareceiver
 
 import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/config"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
@@ -41,11 +44,33 @@ type Config struct {
 	Metadata kafkaexporter.Metadata `mapstructure:"metadata"`
 
 	Authentication kafkaexporter.Authentication `mapstructure:"auth"`
+
+	// HeadersAsResourceAttributes is the list of Kafka record header keys
+	// inserted as resource attributes of the decoded payload. Attributes
+	// already present in the payload are not overwritten.
+	HeadersAsResourceAttributes []string `mapstructure:"headers_as_resource_attributes"`
+
+	// ExactlyOnce leaves the commit of the offsets of the messages consumed to
+	// the transactions of a Kafka exporter, and only reads the messages of
+	// committed transactions. It requires protocol_version 0.11.0 or later.
+	ExactlyOnce bool `mapstructure:"exactly_once"`
 }
 
 var _ config.Receiver = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.ExactlyOnce {
+		version := sarama.V1_0_0_0
+		if cfg.ProtocolVersion != "" {
+			var err error
+			if version, err = sarama.ParseKafkaVersion(cfg.ProtocolVersion); err != nil {
+				return err
+			}
+		}
+		if !version.IsAtLeast(sarama.V0_11_0_0) {
+			return fmt.Errorf("exactly_once requires protocol_version 0.11.0 or later, got %q", cfg.ProtocolVersion)
+		}
+	}
 	return nil
 }
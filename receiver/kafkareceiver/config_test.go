@@ -63,5 +63,16 @@ func TestLoadConfig(t *testing.T) {
 				Backoff: time.Second * 5,
 			},
 		},
+		HeadersAsResourceAttributes: []string{"tenant"},
+		ExactlyOnce:                 true,
 	}, r)
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ExactlyOnce = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ProtocolVersion = "0.10.2.0"
+	assert.EqualError(t, cfg.Validate(), `exactly_once requires protocol_version 0.11.0 or later, got "0.10.2.0"`)
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// insertHeaderAttributes inserts the values of the selected record headers
+// as resource attributes.
+func insertHeaderAttributes(keys []string, headers []*sarama.RecordHeader, resource pdata.Resource) {
+	attrs := resource.Attributes()
+	for _, key := range keys {
+		for _, header := range headers {
+			if header != nil && string(header.Key) == key {
+				attrs.InsertString(key, string(header.Value))
+				break
+			}
+		}
+	}
+}
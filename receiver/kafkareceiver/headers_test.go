@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestInsertHeaderAttributes(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "from-payload")
+	headers := []*sarama.RecordHeader{
+		{Key: []byte("service.name"), Value: []byte("from-header")},
+		{Key: []byte("tenant"), Value: []byte("acme")},
+		{Key: []byte("ignored"), Value: []byte("value")},
+		nil,
+	}
+
+	insertHeaderAttributes([]string{"service.name", "tenant", "missing"}, headers, resource)
+
+	assert.Equal(t, map[string]interface{}{
+		"service.name": "from-payload",
+		"tenant":       "acme",
+	}, resource.Attributes().AsRaw())
+}
@@ -45,6 +45,9 @@ type kafkaTracesConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
+	headerKeys        []string
+	groupID           string
+	exactlyOnce       bool
 
 	logger *zap.Logger
 }
@@ -57,6 +60,9 @@ type kafkaMetricsConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
+	headerKeys        []string
+	groupID           string
+	exactlyOnce       bool
 
 	logger *zap.Logger
 }
@@ -69,6 +75,9 @@ type kafkaLogsConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
+	headerKeys        []string
+	groupID           string
+	exactlyOnce       bool
 
 	logger *zap.Logger
 }
@@ -95,6 +104,9 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 		}
 		c.Version = version
 	}
+	if config.ExactlyOnce {
+		c.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
 	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
@@ -108,6 +120,9 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 		topics:        []string{config.Topic},
 		nextConsumer:  nextConsumer,
 		unmarshaler:   unmarshaler,
+		headerKeys:    config.HeadersAsResourceAttributes,
+		groupID:       config.GroupID,
+		exactlyOnce:   config.ExactlyOnce,
 		logger:        set.Logger,
 	}, nil
 }
@@ -119,6 +134,9 @@ func (c *kafkaTracesConsumer) Start(context.Context, component.Host) error {
 		id:           c.id,
 		logger:       c.logger,
 		unmarshaler:  c.unmarshaler,
+		headerKeys:   c.headerKeys,
+		groupID:      c.groupID,
+		exactlyOnce:  c.exactlyOnce,
 		nextConsumer: c.nextConsumer,
 		ready:        make(chan bool),
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: c.id, Transport: transport}),
@@ -167,6 +185,9 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 		}
 		c.Version = version
 	}
+	if config.ExactlyOnce {
+		c.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
 	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
@@ -180,6 +201,9 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 		topics:        []string{config.Topic},
 		nextConsumer:  nextConsumer,
 		unmarshaler:   unmarshaler,
+		headerKeys:    config.HeadersAsResourceAttributes,
+		groupID:       config.GroupID,
+		exactlyOnce:   config.ExactlyOnce,
 		logger:        set.Logger,
 	}, nil
 }
@@ -191,6 +215,9 @@ func (c *kafkaMetricsConsumer) Start(context.Context, component.Host) error {
 		id:           c.id,
 		logger:       c.logger,
 		unmarshaler:  c.unmarshaler,
+		headerKeys:   c.headerKeys,
+		groupID:      c.groupID,
+		exactlyOnce:  c.exactlyOnce,
 		nextConsumer: c.nextConsumer,
 		ready:        make(chan bool),
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: c.id, Transport: transport}),
@@ -238,6 +265,9 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 		}
 		c.Version = version
 	}
+	if config.ExactlyOnce {
+		c.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
 	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
@@ -251,6 +281,9 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 		topics:        []string{config.Topic},
 		nextConsumer:  nextConsumer,
 		unmarshaler:   unmarshaler,
+		headerKeys:    config.HeadersAsResourceAttributes,
+		groupID:       config.GroupID,
+		exactlyOnce:   config.ExactlyOnce,
 		logger:        set.Logger,
 	}, nil
 }
@@ -262,6 +295,9 @@ func (c *kafkaLogsConsumer) Start(context.Context, component.Host) error {
 		id:           c.id,
 		logger:       c.logger,
 		unmarshaler:  c.unmarshaler,
+		headerKeys:   c.headerKeys,
+		groupID:      c.groupID,
+		exactlyOnce:  c.exactlyOnce,
 		nextConsumer: c.nextConsumer,
 		ready:        make(chan bool),
 		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: c.id, Transport: transport}),
@@ -295,9 +331,14 @@ func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
 type tracesConsumerGroupHandler struct {
 	id           config.ComponentID
 	unmarshaler  TracesUnmarshaler
+	headerKeys   []string
+	groupID      string
+	exactlyOnce  bool
 	nextConsumer consumer.Traces
 	ready        chan bool
 	readyCloser  sync.Once
+	// uncommittedOnce warns once about offsets not committed in exactly_once mode.
+	uncommittedOnce sync.Once
 
 	logger *zap.Logger
 
@@ -307,9 +348,14 @@ type tracesConsumerGroupHandler struct {
 type metricsConsumerGroupHandler struct {
 	id           config.ComponentID
 	unmarshaler  MetricsUnmarshaler
+	headerKeys   []string
+	groupID      string
+	exactlyOnce  bool
 	nextConsumer consumer.Metrics
 	ready        chan bool
 	readyCloser  sync.Once
+	// uncommittedOnce warns once about offsets not committed in exactly_once mode.
+	uncommittedOnce sync.Once
 
 	logger *zap.Logger
 
@@ -319,9 +365,14 @@ type metricsConsumerGroupHandler struct {
 type logsConsumerGroupHandler struct {
 	id           config.ComponentID
 	unmarshaler  LogsUnmarshaler
+	headerKeys   []string
+	groupID      string
+	exactlyOnce  bool
 	nextConsumer consumer.Logs
 	ready        chan bool
 	readyCloser  sync.Once
+	// uncommittedOnce warns once about offsets not committed in exactly_once mode.
+	uncommittedOnce sync.Once
 
 	logger *zap.Logger
 
@@ -354,7 +405,7 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			zap.String("value", string(message.Value)),
 			zap.Time("timestamp", message.Timestamp),
 			zap.String("topic", message.Topic))
-		session.MarkMessage(message, "")
+		msgCtx, committed := messageContext(session, message, c.groupID, c.exactlyOnce)
 
 		ctx := c.obsrecv.StartTracesOp(session.Context())
 		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.String())}
@@ -368,13 +419,22 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
 			return err
 		}
+		if len(c.headerKeys) > 0 {
+			rs := traces.ResourceSpans()
+			for i := 0; i < rs.Len(); i++ {
+				insertHeaderAttributes(c.headerKeys, message.Headers, rs.At(i).Resource())
+			}
+		}
 
 		spanCount := traces.SpanCount()
-		err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
+		err = c.nextConsumer.ConsumeTraces(msgCtx, traces)
 		c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
 		if err != nil {
 			return err
 		}
+		if !committed() {
+			markUncommitted(session, message, c.logger, &c.uncommittedOnce)
+		}
 	}
 	return nil
 }
@@ -401,7 +461,7 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			zap.String("value", string(message.Value)),
 			zap.Time("timestamp", message.Timestamp),
 			zap.String("topic", message.Topic))
-		session.MarkMessage(message, "")
+		msgCtx, committed := messageContext(session, message, c.groupID, c.exactlyOnce)
 
 		ctx := c.obsrecv.StartMetricsOp(session.Context())
 		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.id.String())}
@@ -415,13 +475,22 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
 			return err
 		}
+		if len(c.headerKeys) > 0 {
+			rs := metrics.ResourceMetrics()
+			for i := 0; i < rs.Len(); i++ {
+				insertHeaderAttributes(c.headerKeys, message.Headers, rs.At(i).Resource())
+			}
+		}
 
 		dataPointCount := metrics.DataPointCount()
-		err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+		err = c.nextConsumer.ConsumeMetrics(msgCtx, metrics)
 		c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
 		if err != nil {
 			return err
 		}
+		if !committed() {
+			markUncommitted(session, message, c.logger, &c.uncommittedOnce)
+		}
 	}
 	return nil
 }
@@ -452,7 +521,7 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			zap.String("value", string(message.Value)),
 			zap.Time("timestamp", message.Timestamp),
 			zap.String("topic", message.Topic))
-		session.MarkMessage(message, "")
+		msgCtx, committed := messageContext(session, message, c.groupID, c.exactlyOnce)
 
 		ctx := c.obsrecv.StartTracesOp(session.Context())
 		_ = stats.RecordWithTags(
@@ -467,13 +536,51 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
 			return err
 		}
+		if len(c.headerKeys) > 0 {
+			rs := logs.ResourceLogs()
+			for i := 0; i < rs.Len(); i++ {
+				insertHeaderAttributes(c.headerKeys, message.Headers, rs.At(i).Resource())
+			}
+		}
 
-		err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+		err = c.nextConsumer.ConsumeLogs(msgCtx, logs)
 		// TODO
 		c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
 		if err != nil {
 			return err
 		}
+		if !committed() {
+			markUncommitted(session, message, c.logger, &c.uncommittedOnce)
+		}
 	}
 	return nil
 }
+
+// messageContext marks the message as consumed and returns the context of the
+// session, or in exactly_once mode, returns a context carrying the message to
+// mark as consumed in the transaction of the Kafka exporter producing the
+// data. The function returned reports whether the message was marked.
+func messageContext(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, groupID string, exactlyOnce bool) (context.Context, func() bool) {
+	if !exactlyOnce {
+		session.MarkMessage(message, "")
+		return session.Context(), func() bool { return true }
+	}
+	return kafkaexporter.ContextWithConsumedMessage(session.Context(), kafkaexporter.ConsumedMessage{
+		GroupID: groupID,
+		Message: message,
+	})
+}
+
+// markUncommitted marks a message consumed in exactly_once mode whose offset
+// no transaction committed, when the pipeline has no Kafka exporter with
+// transactions or a processor drops the offset from the context, so that the
+// offsets still move forward, and warns once that the data isn't forwarded
+// exactly once.
+func markUncommitted(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, logger *zap.Logger, once *sync.Once) {
+	once.Do(func() {
+		logger.Warn("exactly_once is enabled but the offset of a message consumed wasn't committed in a transaction, " +
+			"the messages are marked as consumed after being processed instead: check that the pipeline has a Kafka " +
+			"exporter with producer.transactional_id set, and no processor, like the batch processor, in between")
+	})
+	session.MarkMessage(message, "")
+}
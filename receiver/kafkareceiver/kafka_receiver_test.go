@@ -27,6 +27,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumerhelper"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/otlp"
 	"go.opentelemetry.io/collector/model/pdata"
@@ -224,6 +225,126 @@ func TestTracesConsumerGroupHandler_error_nextConsumer(t *testing.T) {
 	wg.Wait()
 }
 
+func TestTracesConsumerGroupHandler_exactlyOnce(t *testing.T) {
+	var offsets []int64
+	next, err := consumerhelper.NewTraces(func(ctx context.Context, _ pdata.Traces) error {
+		consumed, ok := kafkaexporter.ConsumedMessageFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "otel-collector", consumed.GroupID)
+		offsets = append(offsets, consumed.Message.Offset)
+		return nil
+	})
+	require.NoError(t, err)
+	core, logs := observer.New(zapcore.WarnLevel)
+	c := tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+		logger:       zap.New(core),
+		ready:        make(chan bool),
+		nextConsumer: next,
+		groupID:      "otel-collector",
+		exactlyOnce:  true,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+
+	var marked []int64
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testConsumerGroupSession{marked: &marked}, groupClaim))
+		wg.Done()
+	}()
+
+	bts, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: testTopic, Partition: testPartition, Offset: 41, Value: bts}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: testTopic, Partition: testPartition, Offset: 42, Value: bts}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	assert.Equal(t, []int64{41, 42}, offsets)
+	// Without a transaction committing the offsets, the messages are marked
+	// once consumed, with a single warning.
+	assert.Equal(t, []int64{41, 42}, marked)
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestTracesConsumerGroupHandler_exactlyOnceError(t *testing.T) {
+	next, err := consumerhelper.NewTraces(func(context.Context, pdata.Traces) error {
+		return errors.New("export failed")
+	})
+	require.NoError(t, err)
+	c := tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: next,
+		groupID:      "otel-collector",
+		exactlyOnce:  true,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+
+	var marked []int64
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.Error(t, c.ConsumeClaim(testConsumerGroupSession{marked: &marked}, groupClaim))
+		wg.Done()
+	}()
+
+	bts, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(testdata.GenerateTracesOneSpan())
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: testTopic, Partition: testPartition, Offset: 41, Value: bts}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	// The message isn't marked, so that it is consumed again.
+	assert.Empty(t, marked)
+}
+
+func TestTracesConsumerGroupHandler_headers(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(otlp.NewProtobufTracesUnmarshaler(), defaultEncoding),
+		headerKeys:   []string{"tenant"},
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+		obsrecv:      obsreport.NewReceiver(obsreport.ReceiverSettings{}),
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		require.NoError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+		wg.Done()
+	}()
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	bts, err := otlp.NewProtobufTracesMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Value:   bts,
+		Headers: []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	require.Len(t, sink.AllTraces(), 1)
+	tenant, ok := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.StringVal())
+}
+
 func TestNewMetricsReceiver_version_err(t *testing.T) {
 	c := Config{
 		Encoding:        defaultEncoding,
@@ -626,6 +747,8 @@ func (t testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 }
 
 type testConsumerGroupSession struct {
+	// marked records the offsets of the messages marked, when set.
+	marked *[]int64
 }
 
 func (t testConsumerGroupSession) Commit() {
@@ -654,7 +777,11 @@ func (t testConsumerGroupSession) ResetOffset(string, int32, int64, string) {
 	panic("implement me")
 }
 
-func (t testConsumerGroupSession) MarkMessage(*sarama.ConsumerMessage, string) {}
+func (t testConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	if t.marked != nil {
+		*t.marked = append(*t.marked, msg.Offset)
+	}
+}
 
 func (t testConsumerGroupSession) Context() context.Context {
 	return context.Background()
@@ -681,3 +808,19 @@ func (t *testConsumerGroup) Errors() <-chan error {
 func (t *testConsumerGroup) Close() error {
 	return nil
 }
+
+func (t *testConsumerGroup) Pause(map[string][]int32) {
+	panic("implement me")
+}
+
+func (t *testConsumerGroup) Resume(map[string][]int32) {
+	panic("implement me")
+}
+
+func (t *testConsumerGroup) PauseAll() {
+	panic("implement me")
+}
+
+func (t *testConsumerGroup) ResumeAll() {
+	panic("implement me")
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// The constants of the libvirt remote protocol, see
+// https://libvirt.org/kbase/internals/rpc.html.
+const (
+	remoteProgram         = 0x20008086
+	remoteProtocolVersion = 1
+
+	procConnectOpen              = 1
+	procConnectClose             = 2
+	procConnectGetAllDomainStats = 344
+
+	messageTypeCall  = 0
+	messageTypeReply = 1
+
+	messageStatusOK    = 0
+	messageStatusError = 1
+
+	// headerSize is the size of the length and header of a message.
+	headerSize = 28
+	// maxMessageSize is the maximum size of a message accepted by libvirt.
+	maxMessageSize = 32 * 1024 * 1024
+
+	// connectReadOnly is the VIR_CONNECT_RO flag.
+	connectReadOnly = 1
+	// statsActive is the VIR_CONNECT_GET_ALL_DOMAINS_STATS_ACTIVE flag, the
+	// domains which are not running have no statistics to collect.
+	statsActive = 1
+)
+
+// The groups of statistics requested, VIR_DOMAIN_STATS_*.
+const (
+	statsCPUTotal  = 1 << 1
+	statsBalloon   = 1 << 2
+	statsVCPU      = 1 << 3
+	statsInterface = 1 << 4
+	statsBlock     = 1 << 5
+)
+
+// The types of typed parameters, VIR_TYPED_PARAM_*.
+const (
+	typedParamInt     = 1
+	typedParamUint    = 2
+	typedParamLLong   = 3
+	typedParamULLong  = 4
+	typedParamDouble  = 5
+	typedParamBoolean = 6
+	typedParamString  = 7
+)
+
+// domainStats holds the statistics of a domain, by field name such as
+// "block.0.rd.bytes". Numbers are int64, uint64 or float64.
+type domainStats struct {
+	name   string
+	uuid   string
+	params map[string]interface{}
+}
+
+// libvirtClient is a minimal client of the libvirt remote protocol, only
+// reading the statistics of the domains.
+type libvirtClient struct {
+	conn   net.Conn
+	serial uint32
+}
+
+// dialLibvirt connects to the libvirt daemon and opens a read-only
+// connection to the hypervisor of the URI.
+func dialLibvirt(ctx context.Context, transport, endpoint, uri string, timeout time.Duration) (*libvirtClient, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, transport, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c := &libvirtClient{conn: conn}
+	args := &xdrEncoder{}
+	// The name is an optional string.
+	args.bool(true)
+	args.string(uri)
+	args.uint32(connectReadOnly)
+	if _, err = c.call(procConnectOpen, args.buf); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open %q: %w", uri, err)
+	}
+	return c, nil
+}
+
+// close closes the connection to the hypervisor and to the daemon.
+func (c *libvirtClient) close() error {
+	_, err := c.call(procConnectClose, nil)
+	if cerr := c.conn.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// allDomainStats returns the statistics of all the domains.
+func (c *libvirtClient) allDomainStats() ([]domainStats, error) {
+	args := &xdrEncoder{}
+	// No domains, to get the statistics of all of them.
+	args.uint32(0)
+	args.uint32(statsCPUTotal | statsBalloon | statsVCPU | statsInterface | statsBlock)
+	args.uint32(statsActive)
+	reply, err := c.call(procConnectGetAllDomainStats, args.buf)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &xdrDecoder{data: reply}
+	stats := make([]domainStats, d.length())
+	for i := range stats {
+		stats[i].name = d.string()
+		stats[i].uuid = formatUUID(d.fixedOpaque(16))
+		d.int32() // id
+
+		n := d.length()
+		stats[i].params = make(map[string]interface{}, n)
+		for j := 0; j < n && d.err == nil; j++ {
+			field := d.string()
+			switch typ := d.int32(); typ {
+			case typedParamInt, typedParamBoolean:
+				stats[i].params[field] = int64(d.int32())
+			case typedParamUint:
+				stats[i].params[field] = uint64(d.uint32())
+			case typedParamLLong:
+				stats[i].params[field] = int64(d.uint64())
+			case typedParamULLong:
+				stats[i].params[field] = d.uint64()
+			case typedParamDouble:
+				stats[i].params[field] = d.float64()
+			case typedParamString:
+				stats[i].params[field] = d.string()
+			default:
+				return nil, fmt.Errorf("unknown type %d of parameter %q", typ, field)
+			}
+		}
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to decode domain statistics: %w", d.err)
+	}
+	return stats, nil
+}
+
+// call sends a call of the procedure and returns the payload of its reply.
+func (c *libvirtClient) call(proc int32, args []byte) ([]byte, error) {
+	c.serial++
+	msg := &xdrEncoder{}
+	msg.uint32(uint32(headerSize + len(args)))
+	msg.uint32(remoteProgram)
+	msg.uint32(remoteProtocolVersion)
+	msg.int32(proc)
+	msg.int32(messageTypeCall)
+	msg.uint32(c.serial)
+	msg.int32(messageStatusOK)
+	msg.buf = append(msg.buf, args...)
+	if _, err := c.conn.Write(msg.buf); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header)
+	if size < headerSize || size > maxMessageSize {
+		return nil, fmt.Errorf("invalid message size %d", size)
+	}
+	payload := make([]byte, size-headerSize)
+	if _, err := io.ReadFull(c.conn, payload); err != nil {
+		return nil, err
+	}
+
+	h := &xdrDecoder{data: header[4:]}
+	program, version, replyProc := h.uint32(), h.uint32(), h.int32()
+	typ, serial, status := h.int32(), h.uint32(), h.int32()
+	if program != remoteProgram || version != remoteProtocolVersion || replyProc != proc ||
+		typ != messageTypeReply || serial != c.serial {
+		return nil, fmt.Errorf("unexpected message: program %#x, version %d, procedure %d, type %d, serial %d",
+			program, version, replyProc, typ, serial)
+	}
+	if status == messageStatusError {
+		return nil, decodeError(payload)
+	}
+	return payload, nil
+}
+
+// decodeError decodes the code and message of a remote_error.
+func decodeError(payload []byte) error {
+	d := &xdrDecoder{data: payload}
+	code := d.int32()
+	d.int32() // domain
+	message := "unknown error"
+	if d.bool() {
+		message = d.string()
+	}
+	if d.err != nil {
+		return fmt.Errorf("failed to decode libvirt error: %w", d.err)
+	}
+	return fmt.Errorf("libvirt error %d: %s", code, message)
+}
+
+func formatUUID(b []byte) string {
+	if len(b) != 16 {
+		return ""
+	}
+	s := hex.EncodeToString(b)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParam is a typed parameter of the statistics of a test domain.
+type testParam struct {
+	field string
+	value interface{}
+}
+
+type testDomain struct {
+	name   string
+	uuid   [16]byte
+	params []testParam
+}
+
+// fakeLibvirt is a libvirt daemon listening on a UNIX socket, serving the
+// statistics of its domains.
+type fakeLibvirt struct {
+	t        *testing.T
+	listener net.Listener
+	domains  []testDomain
+
+	mu       sync.Mutex
+	uri      string
+	flags    uint32
+	closed   bool
+	openErr  string
+	statsErr string
+}
+
+func newFakeLibvirt(t *testing.T, domains ...testDomain) *fakeLibvirt {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "libvirt-sock-ro"))
+	require.NoError(t, err)
+	f := &fakeLibvirt{t: t, listener: listener, domains: domains}
+	t.Cleanup(func() { listener.Close() })
+	go f.serve()
+	return f
+}
+
+func (f *fakeLibvirt) endpoint() string {
+	return f.listener.Addr().String()
+}
+
+func (f *fakeLibvirt) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeLibvirt) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		header := make([]byte, headerSize)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(header)-headerSize)
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			return
+		}
+		h := &xdrDecoder{data: header[4:]}
+		h.uint32()
+		h.uint32()
+		proc := h.int32()
+		h.int32()
+		serial := h.uint32()
+
+		reply, errMsg := f.dispatch(proc, payload)
+		msg := &xdrEncoder{}
+		if errMsg != "" {
+			reply = &xdrEncoder{}
+			reply.int32(38) // VIR_ERR_SYSTEM_ERROR
+			reply.int32(7)
+			reply.bool(true)
+			reply.string(errMsg)
+		}
+		msg.uint32(uint32(headerSize + len(reply.buf)))
+		msg.uint32(remoteProgram)
+		msg.uint32(remoteProtocolVersion)
+		msg.int32(proc)
+		msg.int32(messageTypeReply)
+		msg.uint32(serial)
+		if errMsg != "" {
+			msg.int32(messageStatusError)
+		} else {
+			msg.int32(messageStatusOK)
+		}
+		msg.buf = append(msg.buf, reply.buf...)
+		if _, err := conn.Write(msg.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeLibvirt) dispatch(proc int32, payload []byte) (*xdrEncoder, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reply := &xdrEncoder{}
+	d := &xdrDecoder{data: payload}
+	switch proc {
+	case procConnectOpen:
+		if d.bool() {
+			f.uri = d.string()
+		}
+		f.flags = d.uint32()
+		return reply, f.openErr
+	case procConnectClose:
+		f.closed = true
+		return reply, ""
+	case procConnectGetAllDomainStats:
+		if f.statsErr != "" {
+			return reply, f.statsErr
+		}
+		reply.uint32(uint32(len(f.domains)))
+		for _, dom := range f.domains {
+			reply.string(dom.name)
+			reply.fixedOpaque(dom.uuid[:])
+			reply.int32(1)
+			reply.uint32(uint32(len(dom.params)))
+			for _, p := range dom.params {
+				reply.string(p.field)
+				switch v := p.value.(type) {
+				case int32:
+					reply.int32(typedParamInt)
+					reply.int32(v)
+				case uint32:
+					reply.int32(typedParamUint)
+					reply.uint32(v)
+				case int64:
+					reply.int32(typedParamLLong)
+					reply.uint64(uint64(v))
+				case uint64:
+					reply.int32(typedParamULLong)
+					reply.uint64(v)
+				case float64:
+					reply.int32(typedParamDouble)
+					reply.float64(v)
+				case bool:
+					reply.int32(typedParamBoolean)
+					reply.bool(v)
+				case string:
+					reply.int32(typedParamString)
+					reply.string(v)
+				default:
+					f.t.Errorf("unsupported parameter %v", p)
+				}
+			}
+		}
+		return reply, ""
+	}
+	return reply, "unsupported procedure"
+}
+
+var testUUID = [16]byte{0x4d, 0xea, 0x22, 0xb3, 0x1d, 0x52, 0xd8, 0xf3, 0x2b, 0x63, 0x5c, 0x10, 0x82, 0xd6, 0x9c, 0x3b}
+
+func TestAllDomainStats(t *testing.T) {
+	f := newFakeLibvirt(t, testDomain{
+		name: "web-1",
+		uuid: testUUID,
+		params: []testParam{
+			{"state.state", int32(1)},
+			{"cpu.time", uint64(123456789)},
+			{"vcpu.current", uint32(2)},
+			{"balloon.current", uint64(2097152)},
+			{"block.0.name", "vda"},
+			{"block.0.rd.bytes", int64(-1)},
+			{"cpu.util", 0.5},
+			{"vcpu.0.halted", true},
+		},
+	})
+
+	c, err := dialLibvirt(context.Background(), "unix", f.endpoint(), "qemu:///system", time.Second)
+	require.NoError(t, err)
+	stats, err := c.allDomainStats()
+	require.NoError(t, err)
+	require.NoError(t, c.close())
+
+	assert.Equal(t, []domainStats{{
+		name: "web-1",
+		uuid: "4dea22b3-1d52-d8f3-2b63-5c1082d69c3b",
+		params: map[string]interface{}{
+			"state.state":      int64(1),
+			"cpu.time":         uint64(123456789),
+			"vcpu.current":     uint64(2),
+			"balloon.current":  uint64(2097152),
+			"block.0.name":     "vda",
+			"block.0.rd.bytes": int64(-1),
+			"cpu.util":         0.5,
+			"vcpu.0.halted":    int64(1),
+		},
+	}}, stats)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assert.Equal(t, "qemu:///system", f.uri)
+	assert.Equal(t, uint32(connectReadOnly), f.flags)
+	assert.True(t, f.closed)
+}
+
+func TestDialLibvirtError(t *testing.T) {
+	f := newFakeLibvirt(t)
+	f.openErr = "no connection driver available for xen:///"
+
+	_, err := dialLibvirt(context.Background(), "unix", f.endpoint(), "xen:///", time.Second)
+	assert.EqualError(t, err, `failed to open "xen:///": libvirt error 38: no connection driver available for xen:///`)
+}
+
+func TestDialLibvirtUnavailable(t *testing.T) {
+	_, err := dialLibvirt(context.Background(), "unix", filepath.Join(t.TempDir(), "missing"), "qemu:///system", time.Second)
+	assert.Error(t, err)
+}
+
+func TestCallUnexpectedReply(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	go func() {
+		buf := make([]byte, headerSize)
+		if _, err := io.ReadFull(server, buf); err != nil {
+			return
+		}
+		msg := &xdrEncoder{}
+		msg.uint32(headerSize)
+		msg.uint32(remoteProgram)
+		msg.uint32(remoteProtocolVersion)
+		msg.int32(procConnectClose)
+		msg.int32(messageTypeReply)
+		msg.uint32(99)
+		msg.int32(messageStatusOK)
+		_, _ = server.Write(msg.buf)
+	}()
+
+	c := &libvirtClient{conn: client}
+	_, err := c.call(procConnectClose, nil)
+	assert.EqualError(t, err, "unexpected message: program 0x20008086, version 1, procedure 2, type 1, serial 99")
+}
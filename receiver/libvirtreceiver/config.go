@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confignet"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const (
+	defaultEndpoint  = "/var/run/libvirt/libvirt-sock-ro"
+	defaultTransport = "unix"
+	defaultURI       = "qemu:///system"
+	defaultTimeout   = 10 * time.Second
+)
+
+// Config defines the configuration for the libvirt receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// NetAddr is the address of the libvirt daemon, its read-only UNIX socket
+	// by default, or host:port with the tcp transport.
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// URI is the connection URI of the hypervisor, qemu:///system by default.
+	URI string `mapstructure:"uri"`
+	// Timeout bounds the connection to the daemon and the collection of the
+	// statistics. Defaults to 10s.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if cfg.Transport != "unix" && cfg.Transport != "tcp" {
+		return fmt.Errorf("invalid transport %q: must be unix or tcp", cfg.Transport)
+	}
+	if cfg.URI == "" {
+		return errors.New("uri must be specified")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
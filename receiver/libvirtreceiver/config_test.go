@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, "kvm-1.example.com:16509", custom.Endpoint)
+	assert.Equal(t, "tcp", custom.Transport)
+	assert.Equal(t, "qemu+tcp://kvm-1.example.com/system", custom.URI)
+	assert.Equal(t, 5*time.Second, custom.Timeout)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{name: "default", modify: func(*Config) {}},
+		{
+			name: "tcp",
+			modify: func(cfg *Config) {
+				cfg.Endpoint = "localhost:16509"
+				cfg.Transport = "tcp"
+			},
+		},
+		{name: "missing endpoint", modify: func(cfg *Config) { cfg.Endpoint = "" }, err: "endpoint must be specified"},
+		{name: "invalid transport", modify: func(cfg *Config) { cfg.Transport = "udp" }, err: `invalid transport "udp": must be unix or tcp`},
+		{name: "missing uri", modify: func(cfg *Config) { cfg.URI = "" }, err: "uri must be specified"},
+		{name: "zero timeout", modify: func(cfg *Config) { cfg.Timeout = 0 }, err: "timeout must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "libvirtreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	LibvirtDomainCPUTime              MetricIntf
+	LibvirtDomainDiskAllocation       MetricIntf
+	LibvirtDomainDiskCapacity         MetricIntf
+	LibvirtDomainDiskIo               MetricIntf
+	LibvirtDomainDiskOperationTime    MetricIntf
+	LibvirtDomainDiskOperations       MetricIntf
+	LibvirtDomainMemoryBalloonCurrent MetricIntf
+	LibvirtDomainMemoryBalloonMaximum MetricIntf
+	LibvirtDomainMemoryRss            MetricIntf
+	LibvirtDomainMemoryUsable         MetricIntf
+	LibvirtDomainNetworkDropped       MetricIntf
+	LibvirtDomainNetworkErrors        MetricIntf
+	LibvirtDomainNetworkIo            MetricIntf
+	LibvirtDomainNetworkPackets       MetricIntf
+	LibvirtDomainVcpuTime             MetricIntf
+	LibvirtDomainVcpus                MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"libvirt.domain.cpu.time",
+		"libvirt.domain.disk.allocation",
+		"libvirt.domain.disk.capacity",
+		"libvirt.domain.disk.io",
+		"libvirt.domain.disk.operation_time",
+		"libvirt.domain.disk.operations",
+		"libvirt.domain.memory.balloon.current",
+		"libvirt.domain.memory.balloon.maximum",
+		"libvirt.domain.memory.rss",
+		"libvirt.domain.memory.usable",
+		"libvirt.domain.network.dropped",
+		"libvirt.domain.network.errors",
+		"libvirt.domain.network.io",
+		"libvirt.domain.network.packets",
+		"libvirt.domain.vcpu.time",
+		"libvirt.domain.vcpus",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"libvirt.domain.cpu.time":               Metrics.LibvirtDomainCPUTime,
+	"libvirt.domain.disk.allocation":        Metrics.LibvirtDomainDiskAllocation,
+	"libvirt.domain.disk.capacity":          Metrics.LibvirtDomainDiskCapacity,
+	"libvirt.domain.disk.io":                Metrics.LibvirtDomainDiskIo,
+	"libvirt.domain.disk.operation_time":    Metrics.LibvirtDomainDiskOperationTime,
+	"libvirt.domain.disk.operations":        Metrics.LibvirtDomainDiskOperations,
+	"libvirt.domain.memory.balloon.current": Metrics.LibvirtDomainMemoryBalloonCurrent,
+	"libvirt.domain.memory.balloon.maximum": Metrics.LibvirtDomainMemoryBalloonMaximum,
+	"libvirt.domain.memory.rss":             Metrics.LibvirtDomainMemoryRss,
+	"libvirt.domain.memory.usable":          Metrics.LibvirtDomainMemoryUsable,
+	"libvirt.domain.network.dropped":        Metrics.LibvirtDomainNetworkDropped,
+	"libvirt.domain.network.errors":         Metrics.LibvirtDomainNetworkErrors,
+	"libvirt.domain.network.io":             Metrics.LibvirtDomainNetworkIo,
+	"libvirt.domain.network.packets":        Metrics.LibvirtDomainNetworkPackets,
+	"libvirt.domain.vcpu.time":              Metrics.LibvirtDomainVcpuTime,
+	"libvirt.domain.vcpus":                  Metrics.LibvirtDomainVcpus,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"libvirt.domain.cpu.time",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.cpu.time")
+			metric.SetDescription("Total CPU time consumed by the domain.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.disk.allocation",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.disk.allocation")
+			metric.SetDescription("Space allocated to the block device on the host.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.disk.capacity",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.disk.capacity")
+			metric.SetDescription("Logical size of the block device.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.disk.io",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.disk.io")
+			metric.SetDescription("Bytes read from and written to the block device.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.disk.operation_time",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.disk.operation_time")
+			metric.SetDescription("Time spent in read and write requests of the block device.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.disk.operations",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.disk.operations")
+			metric.SetDescription("Read and write requests completed by the block device.")
+			metric.SetUnit("{operations}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.memory.balloon.current",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.memory.balloon.current")
+			metric.SetDescription("Memory currently assigned to the domain by the balloon driver.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.memory.balloon.maximum",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.memory.balloon.maximum")
+			metric.SetDescription("Maximum memory the balloon driver can assign to the domain.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.memory.rss",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.memory.rss")
+			metric.SetDescription("Resident set size of the process running the domain on the host.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.memory.usable",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.memory.usable")
+			metric.SetDescription("Memory the guest reports as usable without swapping, only available with a balloon driver in the guest.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.network.dropped",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.network.dropped")
+			metric.SetDescription("Packets dropped while receiving and transmitting on the network interface.")
+			metric.SetUnit("{packets}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.network.errors",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.network.errors")
+			metric.SetDescription("Errors while receiving and transmitting on the network interface.")
+			metric.SetUnit("{errors}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.network.io",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.network.io")
+			metric.SetDescription("Bytes received and transmitted by the network interface.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.network.packets",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.network.packets")
+			metric.SetDescription("Packets received and transmitted by the network interface.")
+			metric.SetUnit("{packets}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.vcpu.time",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.vcpu.time")
+			metric.SetDescription("CPU time consumed by the virtual CPU.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"libvirt.domain.vcpus",
+		func(metric pdata.Metric) {
+			metric.SetName("libvirt.domain.vcpus")
+			metric.SetDescription("Number of virtual CPUs currently online in the domain.")
+			metric.SetUnit("{vcpus}")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Disk (The name of the block device of the domain, such as vda.)
+	Disk string
+	// DiskDirection (The direction of the disk operations.)
+	DiskDirection string
+	// Interface (The name of the network interface on the host, such as vnet0.)
+	Interface string
+	// NetworkDirection (The direction of the network traffic.)
+	NetworkDirection string
+	// Vcpu (The number of the virtual CPU.)
+	Vcpu string
+}{
+	"device",
+	"direction",
+	"interface",
+	"direction",
+	"cpu",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelDiskDirection are the possible values that the label "disk_direction" can have.
+var LabelDiskDirection = struct {
+	Read  string
+	Write string
+}{
+	"read",
+	"write",
+}
+
+// LabelNetworkDirection are the possible values that the label "network_direction" can have.
+var LabelNetworkDirection = struct {
+	Receive  string
+	Transmit string
+}{
+	"receive",
+	"transmit",
+}
@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/libvirtreceiver/internal/metadata"
+)
+
+const (
+	attributeDomainName = "libvirt.domain.name"
+	attributeDomainUUID = "libvirt.domain.uuid"
+
+	kibibyte = 1024
+)
+
+type libvirtScraper struct {
+	logger    *zap.Logger
+	cfg       *Config
+	now       func() time.Time
+	startTime pdata.Timestamp
+}
+
+func newLibvirtScraper(logger *zap.Logger, cfg *Config) *libvirtScraper {
+	return &libvirtScraper{
+		logger: logger,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+func (s *libvirtScraper) start(context.Context, component.Host) error {
+	s.startTime = pdata.NewTimestampFromTime(s.now())
+	return nil
+}
+
+// scrape connects to the libvirt daemon on every collection, and records the
+// metrics of each domain in its own resource.
+func (s *libvirtScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	client, err := dialLibvirt(ctx, s.cfg.Transport, s.cfg.Endpoint, s.cfg.URI, s.cfg.Timeout)
+	if err != nil {
+		return pdata.ResourceMetricsSlice{}, fmt.Errorf("failed to connect to libvirt: %w", err)
+	}
+	defer func() {
+		if err := client.close(); err != nil {
+			s.logger.Debug("failed to close the libvirt connection", zap.Error(err))
+		}
+	}()
+
+	stats, err := client.allDomainStats()
+	if err != nil {
+		return pdata.ResourceMetricsSlice{}, fmt.Errorf("failed to get the domain statistics: %w", err)
+	}
+
+	now := pdata.NewTimestampFromTime(s.now())
+	rms := pdata.NewResourceMetricsSlice()
+	for _, domain := range stats {
+		rm := rms.AppendEmpty()
+		rm.Resource().Attributes().InsertString(attributeDomainName, domain.name)
+		rm.Resource().Attributes().InsertString(attributeDomainUUID, domain.uuid)
+		ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+		ilm.InstrumentationLibrary().SetName("otelcol/libvirt")
+
+		b := &metricsBuilder{metrics: ilm.Metrics(), params: domain.params, now: now, startTime: s.startTime}
+		b.recordCPU()
+		b.recordMemory()
+		b.recordBlock()
+		b.recordInterfaces()
+		removeEmpty(ilm.Metrics())
+	}
+	rms.RemoveIf(func(rm pdata.ResourceMetrics) bool {
+		return rm.InstrumentationLibraryMetrics().At(0).Metrics().Len() == 0
+	})
+	return rms, nil
+}
+
+// metricsBuilder records the metrics of a domain from its statistics, only
+// recording the metrics of the statistics returned by the hypervisor.
+type metricsBuilder struct {
+	metrics   pdata.MetricSlice
+	params    map[string]interface{}
+	now       pdata.Timestamp
+	startTime pdata.Timestamp
+}
+
+func (b *metricsBuilder) recordCPU() {
+	if v, ok := intParam(b.params, "cpu.time"); ok {
+		b.addSum(metadata.M.LibvirtDomainCPUTime).SetDoubleVal(nanosToSeconds(v))
+	}
+	if v, ok := intParam(b.params, "vcpu.current"); ok {
+		b.addGauge(metadata.M.LibvirtDomainVcpus).SetIntVal(v)
+	}
+	maximum, _ := intParam(b.params, "vcpu.maximum")
+	vcpuTime := newMetric(b.metrics, metadata.M.LibvirtDomainVcpuTime).Sum().DataPoints()
+	for i := int64(0); i < maximum; i++ {
+		v, ok := intParam(b.params, fmt.Sprintf("vcpu.%d.time", i))
+		if !ok {
+			continue
+		}
+		dp := b.addPoint(vcpuTime, true)
+		dp.SetDoubleVal(nanosToSeconds(v))
+		dp.Attributes().InsertString(metadata.L.Vcpu, fmt.Sprint(i))
+	}
+}
+
+func (b *metricsBuilder) recordMemory() {
+	for _, m := range []struct {
+		field  string
+		metric metadata.MetricIntf
+	}{
+		{"balloon.current", metadata.M.LibvirtDomainMemoryBalloonCurrent},
+		{"balloon.maximum", metadata.M.LibvirtDomainMemoryBalloonMaximum},
+		{"balloon.rss", metadata.M.LibvirtDomainMemoryRss},
+		{"balloon.usable", metadata.M.LibvirtDomainMemoryUsable},
+	} {
+		if v, ok := intParam(b.params, m.field); ok {
+			b.addGauge(m.metric).SetIntVal(v * kibibyte)
+		}
+	}
+}
+
+func (b *metricsBuilder) recordBlock() {
+	count, _ := intParam(b.params, "block.count")
+	if count == 0 {
+		return
+	}
+	io := newMetric(b.metrics, metadata.M.LibvirtDomainDiskIo).Sum().DataPoints()
+	operations := newMetric(b.metrics, metadata.M.LibvirtDomainDiskOperations).Sum().DataPoints()
+	operationTime := newMetric(b.metrics, metadata.M.LibvirtDomainDiskOperationTime).Sum().DataPoints()
+	capacity := newMetric(b.metrics, metadata.M.LibvirtDomainDiskCapacity).Gauge().DataPoints()
+	allocation := newMetric(b.metrics, metadata.M.LibvirtDomainDiskAllocation).Gauge().DataPoints()
+
+	for i := int64(0); i < count; i++ {
+		prefix := fmt.Sprintf("block.%d.", i)
+		disk, ok := b.params[prefix+"name"].(string)
+		if !ok {
+			continue
+		}
+		for _, dir := range []struct {
+			field, direction string
+		}{
+			{"rd", metadata.LabelDiskDirection.Read},
+			{"wr", metadata.LabelDiskDirection.Write},
+		} {
+			attrs := func(dp pdata.NumberDataPoint) {
+				dp.Attributes().InsertString(metadata.L.Disk, disk)
+				dp.Attributes().InsertString(metadata.L.DiskDirection, dir.direction)
+			}
+			if v, ok := intParam(b.params, prefix+dir.field+".bytes"); ok {
+				b.addIntPoint(io, true, v, attrs)
+			}
+			if v, ok := intParam(b.params, prefix+dir.field+".reqs"); ok {
+				b.addIntPoint(operations, true, v, attrs)
+			}
+			if v, ok := intParam(b.params, prefix+dir.field+".times"); ok {
+				dp := b.addPoint(operationTime, true)
+				dp.SetDoubleVal(nanosToSeconds(v))
+				attrs(dp)
+			}
+		}
+		attrs := func(dp pdata.NumberDataPoint) {
+			dp.Attributes().InsertString(metadata.L.Disk, disk)
+		}
+		if v, ok := intParam(b.params, prefix+"capacity"); ok {
+			b.addIntPoint(capacity, false, v, attrs)
+		}
+		if v, ok := intParam(b.params, prefix+"allocation"); ok {
+			b.addIntPoint(allocation, false, v, attrs)
+		}
+	}
+}
+
+func (b *metricsBuilder) recordInterfaces() {
+	count, _ := intParam(b.params, "net.count")
+	if count == 0 {
+		return
+	}
+	io := newMetric(b.metrics, metadata.M.LibvirtDomainNetworkIo).Sum().DataPoints()
+	packets := newMetric(b.metrics, metadata.M.LibvirtDomainNetworkPackets).Sum().DataPoints()
+	errs := newMetric(b.metrics, metadata.M.LibvirtDomainNetworkErrors).Sum().DataPoints()
+	dropped := newMetric(b.metrics, metadata.M.LibvirtDomainNetworkDropped).Sum().DataPoints()
+
+	for i := int64(0); i < count; i++ {
+		prefix := fmt.Sprintf("net.%d.", i)
+		iface, ok := b.params[prefix+"name"].(string)
+		if !ok {
+			continue
+		}
+		for _, dir := range []struct {
+			field, direction string
+		}{
+			{"rx", metadata.LabelNetworkDirection.Receive},
+			{"tx", metadata.LabelNetworkDirection.Transmit},
+		} {
+			attrs := func(dp pdata.NumberDataPoint) {
+				dp.Attributes().InsertString(metadata.L.Interface, iface)
+				dp.Attributes().InsertString(metadata.L.NetworkDirection, dir.direction)
+			}
+			for _, m := range []struct {
+				field string
+				dps   pdata.NumberDataPointSlice
+			}{
+				{"bytes", io},
+				{"pkts", packets},
+				{"errs", errs},
+				{"drop", dropped},
+			} {
+				if v, ok := intParam(b.params, prefix+dir.field+"."+m.field); ok {
+					b.addIntPoint(m.dps, true, v, attrs)
+				}
+			}
+		}
+	}
+}
+
+func (b *metricsBuilder) addGauge(m metadata.MetricIntf) pdata.NumberDataPoint {
+	return b.addPoint(newMetric(b.metrics, m).Gauge().DataPoints(), false)
+}
+
+func (b *metricsBuilder) addSum(m metadata.MetricIntf) pdata.NumberDataPoint {
+	return b.addPoint(newMetric(b.metrics, m).Sum().DataPoints(), true)
+}
+
+func (b *metricsBuilder) addPoint(dps pdata.NumberDataPointSlice, cumulative bool) pdata.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	if cumulative {
+		dp.SetStartTimestamp(b.startTime)
+	}
+	dp.SetTimestamp(b.now)
+	return dp
+}
+
+func (b *metricsBuilder) addIntPoint(dps pdata.NumberDataPointSlice, cumulative bool, value int64, attrs func(pdata.NumberDataPoint)) {
+	dp := b.addPoint(dps, cumulative)
+	dp.SetIntVal(value)
+	attrs(dp)
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+func removeEmpty(metrics pdata.MetricSlice) {
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			return metric.Gauge().DataPoints().Len() == 0
+		case pdata.MetricDataTypeSum:
+			return metric.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+}
+
+// intParam returns the value of an integer statistic.
+func intParam(params map[string]interface{}, field string) (int64, bool) {
+	switch v := params[field].(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func nanosToSeconds(ns int64) float64 {
+	return float64(ns) / float64(time.Second)
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func newTestScraper(t *testing.T, f *fakeLibvirt) *libvirtScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = f.endpoint()
+	s := newLibvirtScraper(zap.NewNop(), cfg)
+	s.now = func() time.Time { return time.Unix(1633000000, 0) }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// pointsByMetric returns the data points of the metrics by name.
+func pointsByMetric(metrics pdata.MetricSlice) map[string]pdata.NumberDataPointSlice {
+	points := make(map[string]pdata.NumberDataPointSlice)
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		switch m.DataType() {
+		case pdata.MetricDataTypeGauge:
+			points[m.Name()] = m.Gauge().DataPoints()
+		case pdata.MetricDataTypeSum:
+			points[m.Name()] = m.Sum().DataPoints()
+		}
+	}
+	return points
+}
+
+// pointAttributes returns the attributes of the data point as a string map.
+func pointAttributes(dp pdata.NumberDataPoint) map[string]string {
+	attrs := make(map[string]string)
+	dp.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		attrs[k] = v.StringVal()
+		return true
+	})
+	return attrs
+}
+
+func TestScrape(t *testing.T) {
+	f := newFakeLibvirt(t,
+		testDomain{
+			name: "web-1",
+			uuid: testUUID,
+			params: []testParam{
+				{"cpu.time", uint64(3500000000)},
+				{"vcpu.current", uint32(2)},
+				{"vcpu.maximum", uint32(4)},
+				{"vcpu.0.state", int32(1)},
+				{"vcpu.0.time", uint64(1500000000)},
+				{"vcpu.1.state", int32(1)},
+				{"vcpu.1.time", uint64(2000000000)},
+				{"balloon.current", uint64(2097152)},
+				{"balloon.maximum", uint64(4194304)},
+				{"balloon.rss", uint64(1048576)},
+				{"block.count", uint32(1)},
+				{"block.0.name", "vda"},
+				{"block.0.rd.reqs", uint64(120)},
+				{"block.0.rd.bytes", uint64(491520)},
+				{"block.0.rd.times", uint64(250000000)},
+				{"block.0.wr.reqs", uint64(30)},
+				{"block.0.wr.bytes", uint64(122880)},
+				{"block.0.wr.times", uint64(500000000)},
+				{"block.0.allocation", uint64(5368709120)},
+				{"block.0.capacity", uint64(21474836480)},
+				{"net.count", uint32(1)},
+				{"net.0.name", "vnet0"},
+				{"net.0.rx.bytes", uint64(9000)},
+				{"net.0.rx.pkts", uint64(60)},
+				{"net.0.rx.errs", uint64(0)},
+				{"net.0.rx.drop", uint64(2)},
+				{"net.0.tx.bytes", uint64(4500)},
+				{"net.0.tx.pkts", uint64(30)},
+				{"net.0.tx.errs", uint64(1)},
+				{"net.0.tx.drop", uint64(0)},
+			},
+		},
+		// A domain without statistics is not reported.
+		testDomain{
+			name:   "db-1",
+			uuid:   [16]byte{1},
+			params: []testParam{{"state.state", int32(5)}},
+		},
+	)
+	s := newTestScraper(t, f)
+	rms, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+
+	resource := rms.At(0).Resource().Attributes()
+	assert.Equal(t, map[string]interface{}{
+		"libvirt.domain.name": "web-1",
+		"libvirt.domain.uuid": "4dea22b3-1d52-d8f3-2b63-5c1082d69c3b",
+	}, resource.AsRaw())
+	assert.Equal(t, "otelcol/libvirt", rms.At(0).InstrumentationLibraryMetrics().At(0).InstrumentationLibrary().Name())
+
+	points := pointsByMetric(rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics())
+	assert.Len(t, points, 15)
+
+	cpu := points["libvirt.domain.cpu.time"].At(0)
+	assert.Equal(t, 3.5, cpu.DoubleVal())
+	assert.Equal(t, s.startTime, cpu.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(time.Unix(1633000000, 0)), cpu.Timestamp())
+	assert.EqualValues(t, 2, points["libvirt.domain.vcpus"].At(0).IntVal())
+	vcpuTime := points["libvirt.domain.vcpu.time"]
+	require.Equal(t, 2, vcpuTime.Len())
+	assert.Equal(t, map[string]string{"cpu": "1"}, pointAttributes(vcpuTime.At(1)))
+	assert.Equal(t, 2.0, vcpuTime.At(1).DoubleVal())
+
+	assert.EqualValues(t, 2*1024*1024*1024, points["libvirt.domain.memory.balloon.current"].At(0).IntVal())
+	assert.EqualValues(t, 4*1024*1024*1024, points["libvirt.domain.memory.balloon.maximum"].At(0).IntVal())
+	assert.EqualValues(t, 1024*1024*1024, points["libvirt.domain.memory.rss"].At(0).IntVal())
+	assert.NotContains(t, points, "libvirt.domain.memory.usable")
+
+	diskIO := points["libvirt.domain.disk.io"]
+	require.Equal(t, 2, diskIO.Len())
+	assert.Equal(t, map[string]string{"device": "vda", "direction": "read"}, pointAttributes(diskIO.At(0)))
+	assert.EqualValues(t, 491520, diskIO.At(0).IntVal())
+	assert.Equal(t, map[string]string{"device": "vda", "direction": "write"}, pointAttributes(diskIO.At(1)))
+	assert.EqualValues(t, 122880, diskIO.At(1).IntVal())
+	assert.EqualValues(t, 30, points["libvirt.domain.disk.operations"].At(1).IntVal())
+	assert.Equal(t, 0.5, points["libvirt.domain.disk.operation_time"].At(1).DoubleVal())
+	assert.EqualValues(t, 21474836480, points["libvirt.domain.disk.capacity"].At(0).IntVal())
+	assert.Equal(t, map[string]string{"device": "vda"}, pointAttributes(points["libvirt.domain.disk.allocation"].At(0)))
+
+	netIO := points["libvirt.domain.network.io"]
+	require.Equal(t, 2, netIO.Len())
+	assert.Equal(t, map[string]string{"interface": "vnet0", "direction": "receive"}, pointAttributes(netIO.At(0)))
+	assert.EqualValues(t, 9000, netIO.At(0).IntVal())
+	assert.EqualValues(t, 30, points["libvirt.domain.network.packets"].At(1).IntVal())
+	assert.EqualValues(t, 1, points["libvirt.domain.network.errors"].At(1).IntVal())
+	assert.EqualValues(t, 2, points["libvirt.domain.network.dropped"].At(0).IntVal())
+}
+
+func TestScrapeStatsError(t *testing.T) {
+	f := newFakeLibvirt(t)
+	f.statsErr = "Requested operation is not valid: domain is not running"
+	_, err := newTestScraper(t, f).scrape(context.Background())
+	assert.EqualError(t, err, "failed to get the domain statistics: libvirt error 38: Requested operation is not valid: domain is not running")
+}
+
+func TestScrapeUnavailable(t *testing.T) {
+	f := newFakeLibvirt(t)
+	s := newTestScraper(t, f)
+	require.NoError(t, f.listener.Close())
+	_, err := s.scrape(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errShortBuffer = errors.New("unexpected end of XDR data")
+
+// xdrEncoder encodes the XDR (RFC 4506) values of the libvirt RPC protocol.
+type xdrEncoder struct {
+	buf []byte
+}
+
+func (e *xdrEncoder) uint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *xdrEncoder) int32(v int32) {
+	e.uint32(uint32(v))
+}
+
+func (e *xdrEncoder) uint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *xdrEncoder) float64(v float64) {
+	e.uint64(math.Float64bits(v))
+}
+
+func (e *xdrEncoder) bool(v bool) {
+	if v {
+		e.uint32(1)
+	} else {
+		e.uint32(0)
+	}
+}
+
+// fixedOpaque encodes data of a length known by both sides, padded to a
+// multiple of four bytes.
+func (e *xdrEncoder) fixedOpaque(data []byte) {
+	e.buf = append(e.buf, data...)
+	e.buf = append(e.buf, make([]byte, pad(len(data)))...)
+}
+
+func (e *xdrEncoder) string(s string) {
+	e.uint32(uint32(len(s)))
+	e.fixedOpaque([]byte(s))
+}
+
+func pad(n int) int {
+	return (4 - n%4) % 4
+}
+
+// xdrDecoder decodes XDR values. The first error is kept and returned by
+// err, the values decoded after it are zero.
+type xdrDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *xdrDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.data) {
+		d.err = errShortBuffer
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *xdrDecoder) uint32() uint32 {
+	b := d.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (d *xdrDecoder) int32() int32 {
+	return int32(d.uint32())
+}
+
+func (d *xdrDecoder) uint64() uint64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (d *xdrDecoder) float64() float64 {
+	return math.Float64frombits(d.uint64())
+}
+
+func (d *xdrDecoder) bool() bool {
+	return d.uint32() != 0
+}
+
+func (d *xdrDecoder) fixedOpaque(n int) []byte {
+	b := d.next(n)
+	d.next(pad(n))
+	return b
+}
+
+func (d *xdrDecoder) string() string {
+	return string(d.fixedOpaque(d.length()))
+}
+
+// length decodes the length of a variable length array, which cannot
+// exceed the remaining data as every element is at least four bytes.
+func (d *xdrDecoder) length() int {
+	n := d.uint32()
+	if d.err == nil && int64(n) > int64(len(d.data)) {
+		d.err = errShortBuffer
+		return 0
+	}
+	return int(n)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libvirtreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXDRRoundTrip(t *testing.T) {
+	e := &xdrEncoder{}
+	e.uint32(42)
+	e.int32(-7)
+	e.uint64(1 << 40)
+	e.float64(0.25)
+	e.bool(true)
+	e.string("vda")
+	e.fixedOpaque([]byte{1, 2, 3, 4, 5})
+	// Every value is aligned on four bytes.
+	assert.Len(t, e.buf, 4+4+8+8+4+8+8)
+
+	d := &xdrDecoder{data: e.buf}
+	assert.Equal(t, uint32(42), d.uint32())
+	assert.Equal(t, int32(-7), d.int32())
+	assert.Equal(t, uint64(1<<40), d.uint64())
+	assert.Equal(t, 0.25, d.float64())
+	assert.True(t, d.bool())
+	assert.Equal(t, "vda", d.string())
+	assert.Equal(t, []byte{1, 2, 3, 4, 5}, d.fixedOpaque(5))
+	assert.NoError(t, d.err)
+	assert.Empty(t, d.data)
+}
+
+func TestXDRShortBuffer(t *testing.T) {
+	e := &xdrEncoder{}
+	e.uint32(100)
+	e.fixedOpaque([]byte("short"))
+
+	d := &xdrDecoder{data: e.buf}
+	assert.Equal(t, "", d.string())
+	assert.Equal(t, errShortBuffer, d.err)
+	// The values after the error are zero.
+	assert.Equal(t, uint32(0), d.uint32())
+}
+
+func TestXDRLength(t *testing.T) {
+	e := &xdrEncoder{}
+	e.uint32(1 << 30)
+
+	d := &xdrDecoder{data: e.buf}
+	assert.Equal(t, 0, d.length())
+	assert.Equal(t, errShortBuffer, d.err)
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomadreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// node is a client node in the node list of the Nomad API.
+type node struct {
+	ID         string `json:"ID"`
+	Name       string `json:"Name"`
+	Datacenter string `json:"Datacenter"`
+	Status     string `json:"Status"`
+}
+
+// nodeDetail holds the resources of a client node.
+type nodeDetail struct {
+	NodeResources     *resources `json:"NodeResources"`
+	ReservedResources *resources `json:"ReservedResources"`
+}
+
+type resources struct {
+	CPU struct {
+		CPUShares int64 `json:"CpuShares"`
+	} `json:"Cpu"`
+	Memory struct {
+		MemoryMB int64 `json:"MemoryMB"`
+	} `json:"Memory"`
+	Disk struct {
+		DiskMB int64 `json:"DiskMB"`
+	} `json:"Disk"`
+}
+
+// allocation is an allocation placed on a client node.
+type allocation struct {
+	Namespace          string              `json:"Namespace"`
+	JobID              string              `json:"JobID"`
+	TaskGroup          string              `json:"TaskGroup"`
+	ClientStatus       string              `json:"ClientStatus"`
+	AllocatedResources *allocatedResources `json:"AllocatedResources"`
+}
+
+type allocatedResources struct {
+	Tasks map[string]struct {
+		CPU struct {
+			CPUShares int64 `json:"CpuShares"`
+		} `json:"Cpu"`
+		Memory struct {
+			MemoryMB int64 `json:"MemoryMB"`
+		} `json:"Memory"`
+	} `json:"Tasks"`
+	Shared struct {
+		DiskMB int64 `json:"DiskMB"`
+	} `json:"Shared"`
+}
+
+// job is a job in the job list of the Nomad API.
+type job struct {
+	ID         string `json:"ID"`
+	Namespace  string `json:"Namespace"`
+	Type       string `json:"Type"`
+	Status     string `json:"Status"`
+	JobSummary *struct {
+		Summary map[string]struct {
+			Queued int64 `json:"Queued"`
+		} `json:"Summary"`
+	} `json:"JobSummary"`
+}
+
+// nomadClient reads the state of the cluster from the HTTP API of a Nomad
+// agent.
+type nomadClient struct {
+	client   *http.Client
+	endpoint string
+	token    string
+	region   string
+}
+
+func (c *nomadClient) nodes(ctx context.Context) ([]node, error) {
+	var nodes []node
+	err := c.get(ctx, "/v1/nodes", nil, &nodes)
+	return nodes, err
+}
+
+func (c *nomadClient) node(ctx context.Context, id string) (*nodeDetail, error) {
+	detail := &nodeDetail{}
+	err := c.get(ctx, "/v1/node/"+url.PathEscape(id), nil, detail)
+	return detail, err
+}
+
+func (c *nomadClient) nodeAllocations(ctx context.Context, id string) ([]allocation, error) {
+	var allocs []allocation
+	err := c.get(ctx, "/v1/node/"+url.PathEscape(id)+"/allocations", nil, &allocs)
+	return allocs, err
+}
+
+func (c *nomadClient) jobs(ctx context.Context, namespace string) ([]job, error) {
+	var jobs []job
+	err := c.get(ctx, "/v1/jobs", url.Values{"namespace": {namespace}}, &jobs)
+	return jobs, err
+}
+
+func (c *nomadClient) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	if c.region != "" {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("region", c.region)
+	}
+	u := strings.TrimSuffix(c.endpoint, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Nomad-Token", c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s returned %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of GET %s: %w", path, err)
+	}
+	return nil
+}
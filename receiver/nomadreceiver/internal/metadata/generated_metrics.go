@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "nomadreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	NomadAllocations          MetricIntf
+	NomadJobQueuedAllocations MetricIntf
+	NomadJobStatus            MetricIntf
+	NomadNodeCPU              MetricIntf
+	NomadNodeDisk             MetricIntf
+	NomadNodeMemory           MetricIntf
+	NomadNodeStatus           MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"nomad.allocations",
+		"nomad.job.queued_allocations",
+		"nomad.job.status",
+		"nomad.node.cpu",
+		"nomad.node.disk",
+		"nomad.node.memory",
+		"nomad.node.status",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"nomad.allocations":            Metrics.NomadAllocations,
+	"nomad.job.queued_allocations": Metrics.NomadJobQueuedAllocations,
+	"nomad.job.status":             Metrics.NomadJobStatus,
+	"nomad.node.cpu":               Metrics.NomadNodeCPU,
+	"nomad.node.disk":              Metrics.NomadNodeDisk,
+	"nomad.node.memory":            Metrics.NomadNodeMemory,
+	"nomad.node.status":            Metrics.NomadNodeStatus,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"nomad.allocations",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.allocations")
+			metric.SetDescription("Number of allocations placed on client nodes, by client status.")
+			metric.SetUnit("{allocations}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nomad.job.queued_allocations",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.job.queued_allocations")
+			metric.SetDescription("Number of allocations of the task group waiting to be placed.")
+			metric.SetUnit("{allocations}")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nomad.job.status",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.job.status")
+			metric.SetDescription("1 for the current status of the job.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nomad.node.cpu",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.node.cpu")
+			metric.SetDescription("CPU capacity of the client node, reserved and allocated to tasks.")
+			metric.SetUnit("MHz")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nomad.node.disk",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.node.disk")
+			metric.SetDescription("Disk capacity of the client node, reserved and allocated to tasks.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nomad.node.memory",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.node.memory")
+			metric.SetDescription("Memory capacity of the client node, reserved and allocated to tasks.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"nomad.node.status",
+		func(metric pdata.Metric) {
+			metric.SetName("nomad.node.status")
+			metric.SetDescription("1 for the current status of the client node.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// AllocationStatus (The client status of the allocation.)
+	AllocationStatus string
+	// Datacenter (The datacenter of the Nomad client node.)
+	Datacenter string
+	// Job (The ID of the job.)
+	Job string
+	// JobStatus (The status of the job.)
+	JobStatus string
+	// JobType (The type of the job.)
+	JobType string
+	// Namespace (The namespace of the job.)
+	Namespace string
+	// Node (The name of the Nomad client node.)
+	Node string
+	// NodeStatus (The status of the Nomad client node.)
+	NodeStatus string
+	// ResourceState (Whether the resources are the capacity of the node, reserved for processes outside of Nomad or allocated to tasks.)
+	ResourceState string
+	// TaskGroup (The name of the task group.)
+	TaskGroup string
+}{
+	"status",
+	"nomad.datacenter",
+	"nomad.job.id",
+	"status",
+	"nomad.job.type",
+	"nomad.namespace",
+	"nomad.node.name",
+	"status",
+	"state",
+	"nomad.task_group",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelAllocationStatus are the possible values that the label "allocation_status" can have.
+var LabelAllocationStatus = struct {
+	Pending  string
+	Running  string
+	Complete string
+	Failed   string
+	Lost     string
+}{
+	"pending",
+	"running",
+	"complete",
+	"failed",
+	"lost",
+}
+
+// LabelJobStatus are the possible values that the label "job_status" can have.
+var LabelJobStatus = struct {
+	Pending string
+	Running string
+	Dead    string
+}{
+	"pending",
+	"running",
+	"dead",
+}
+
+// LabelJobType are the possible values that the label "job_type" can have.
+var LabelJobType = struct {
+	Service  string
+	Batch    string
+	System   string
+	Sysbatch string
+}{
+	"service",
+	"batch",
+	"system",
+	"sysbatch",
+}
+
+// LabelNodeStatus are the possible values that the label "node_status" can have.
+var LabelNodeStatus = struct {
+	Initializing string
+	Ready        string
+	Down         string
+	Disconnected string
+}{
+	"initializing",
+	"ready",
+	"down",
+	"disconnected",
+}
+
+// LabelResourceState are the possible values that the label "resource_state" can have.
+var LabelResourceState = struct {
+	Capacity  string
+	Reserved  string
+	Allocated string
+}{
+	"capacity",
+	"reserved",
+	"allocated",
+}
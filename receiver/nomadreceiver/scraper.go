@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomadreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nomadreceiver/internal/metadata"
+)
+
+const (
+	// nodeMetricsCount is the number of metrics recorded from the nodes.
+	nodeMetricsCount = 5
+	// jobMetricsCount is the number of metrics recorded from the jobs.
+	jobMetricsCount = 2
+)
+
+const mebibyte = 1024 * 1024
+
+type nomadScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	client *nomadClient
+}
+
+func newNomadScraper(logger *zap.Logger, cfg *Config) *nomadScraper {
+	return &nomadScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (s *nomadScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = &nomadClient{
+		client:   httpClient,
+		endpoint: s.cfg.Endpoint,
+		token:    s.cfg.Token,
+		region:   s.cfg.Region,
+	}
+	return nil
+}
+
+// allocationKey identifies the allocations counted together.
+type allocationKey struct {
+	namespace, job, taskGroup, status string
+}
+
+func (s *nomadScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	now := pdata.NewTimestampFromTime(time.Now())
+	metrics := pdata.NewMetricSlice()
+	var errs scrapererror.ScrapeErrors
+
+	if err := s.scrapeNodes(ctx, metrics, now); err != nil {
+		errs.AddPartial(nodeMetricsCount, err)
+	}
+	if err := s.scrapeJobs(ctx, metrics, now); err != nil {
+		errs.AddPartial(jobMetricsCount, err)
+	}
+
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			return metric.Gauge().DataPoints().Len() == 0
+		case pdata.MetricDataTypeSum:
+			return metric.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+	return metrics, errs.Combine()
+}
+
+// scrapeNodes records the status and resources of the client nodes, and
+// counts the allocations placed on them.
+func (s *nomadScraper) scrapeNodes(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	nodes, err := s.client.nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	status := newMetric(metrics, metadata.M.NomadNodeStatus).Gauge().DataPoints()
+	cpu := newMetric(metrics, metadata.M.NomadNodeCPU).Gauge().DataPoints()
+	memory := newMetric(metrics, metadata.M.NomadNodeMemory).Gauge().DataPoints()
+	disk := newMetric(metrics, metadata.M.NomadNodeDisk).Gauge().DataPoints()
+	allocations := newMetric(metrics, metadata.M.NomadAllocations).Sum()
+
+	counts := make(map[allocationKey]int64)
+	var failed error
+	for _, n := range nodes {
+		dp := addPoint(status, now, 1)
+		addNodeLabels(dp, n)
+		dp.Attributes().InsertString(metadata.L.NodeStatus, n.Status)
+
+		detail, err := s.client.node(ctx, n.ID)
+		if err != nil {
+			failed = fmt.Errorf("failed to get node %q: %w", n.Name, err)
+			continue
+		}
+		allocs, err := s.client.nodeAllocations(ctx, n.ID)
+		if err != nil {
+			failed = fmt.Errorf("failed to list the allocations of node %q: %w", n.Name, err)
+			continue
+		}
+
+		var allocated resources
+		for _, alloc := range allocs {
+			if s.cfg.Namespace == defaultNamespace || alloc.Namespace == s.cfg.Namespace {
+				counts[allocationKey{alloc.Namespace, alloc.JobID, alloc.TaskGroup, alloc.ClientStatus}]++
+			}
+			// Only the allocations which are not terminal hold resources,
+			// whatever their namespace.
+			if alloc.AllocatedResources == nil ||
+				(alloc.ClientStatus != metadata.LabelAllocationStatus.Pending && alloc.ClientStatus != metadata.LabelAllocationStatus.Running) {
+				continue
+			}
+			for _, task := range alloc.AllocatedResources.Tasks {
+				allocated.CPU.CPUShares += task.CPU.CPUShares
+				allocated.Memory.MemoryMB += task.Memory.MemoryMB
+			}
+			allocated.Disk.DiskMB += alloc.AllocatedResources.Shared.DiskMB
+		}
+
+		for _, r := range []struct {
+			state string
+			res   *resources
+		}{
+			{metadata.LabelResourceState.Capacity, detail.NodeResources},
+			{metadata.LabelResourceState.Reserved, detail.ReservedResources},
+			{metadata.LabelResourceState.Allocated, &allocated},
+		} {
+			if r.res == nil {
+				continue
+			}
+			addResourcePoint(cpu, now, r.res.CPU.CPUShares, n, r.state)
+			addResourcePoint(memory, now, r.res.Memory.MemoryMB*mebibyte, n, r.state)
+			addResourcePoint(disk, now, r.res.Disk.DiskMB*mebibyte, n, r.state)
+		}
+	}
+
+	for key, count := range counts {
+		dp := addPoint(allocations.DataPoints(), now, count)
+		dp.Attributes().InsertString(metadata.L.Namespace, key.namespace)
+		dp.Attributes().InsertString(metadata.L.Job, key.job)
+		dp.Attributes().InsertString(metadata.L.TaskGroup, key.taskGroup)
+		dp.Attributes().InsertString(metadata.L.AllocationStatus, key.status)
+	}
+	return failed
+}
+
+// scrapeJobs records the status of the jobs and their allocations waiting
+// to be placed.
+func (s *nomadScraper) scrapeJobs(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	jobs, err := s.client.jobs(ctx, s.cfg.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	status := newMetric(metrics, metadata.M.NomadJobStatus).Gauge().DataPoints()
+	queued := newMetric(metrics, metadata.M.NomadJobQueuedAllocations).Gauge().DataPoints()
+	for _, j := range jobs {
+		dp := addPoint(status, now, 1)
+		dp.Attributes().InsertString(metadata.L.Namespace, j.Namespace)
+		dp.Attributes().InsertString(metadata.L.Job, j.ID)
+		dp.Attributes().InsertString(metadata.L.JobType, j.Type)
+		dp.Attributes().InsertString(metadata.L.JobStatus, j.Status)
+
+		if j.JobSummary == nil {
+			continue
+		}
+		for taskGroup, summary := range j.JobSummary.Summary {
+			dp := addPoint(queued, now, summary.Queued)
+			dp.Attributes().InsertString(metadata.L.Namespace, j.Namespace)
+			dp.Attributes().InsertString(metadata.L.Job, j.ID)
+			dp.Attributes().InsertString(metadata.L.TaskGroup, taskGroup)
+		}
+	}
+	return nil
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+func addPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64) pdata.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetIntVal(value)
+	return dp
+}
+
+func addNodeLabels(dp pdata.NumberDataPoint, n node) {
+	dp.Attributes().InsertString(metadata.L.Node, n.Name)
+	dp.Attributes().InsertString(metadata.L.Datacenter, n.Datacenter)
+}
+
+func addResourcePoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64, n node, state string) {
+	dp := addPoint(dps, now, value)
+	addNodeLabels(dp, n)
+	dp.Attributes().InsertString(metadata.L.ResourceState, state)
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomadreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
+)
+
+const testToken = "8f2b4c1e-6d1a-4b43-9a6f-3c5d9e0f7a21"
+
+// fakeNomad serves the files of testdata/api for the Nomad API endpoints.
+type fakeNomad struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	regions map[string]bool
+}
+
+// newFakeNomad starts a fakeNomad failing the requests of the paths in
+// failing.
+func newFakeNomad(t *testing.T, failing ...string) *fakeNomad {
+	f := &fakeNomad{regions: make(map[string]bool)}
+	files := map[string]string{
+		"/v1/nodes": "nodes.json",
+		"/v1/node/f7476465-4d6e-c0de-26d0-e383c49be941":             "node-client-1.json",
+		"/v1/node/3f9a8e44-0c1f-7f1b-2e0e-8d1f3b6b5c2a":             "node-client-2.json",
+		"/v1/node/f7476465-4d6e-c0de-26d0-e383c49be941/allocations": "allocations-client-1.json",
+		"/v1/node/3f9a8e44-0c1f-7f1b-2e0e-8d1f3b6b5c2a/allocations": "allocations-client-2.json",
+		"/v1/jobs": "jobs.json",
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		f.mu.Lock()
+		f.regions[req.URL.Query().Get("region")] = true
+		f.mu.Unlock()
+
+		if req.Header.Get("X-Nomad-Token") != testToken {
+			http.Error(rw, "Permission denied", http.StatusForbidden)
+			return
+		}
+		if req.URL.Path == "/v1/jobs" && req.URL.Query().Get("namespace") == "" {
+			http.Error(rw, "missing namespace", http.StatusBadRequest)
+			return
+		}
+		for _, path := range failing {
+			if req.URL.Path == path {
+				http.Error(rw, "No cluster leader", http.StatusInternalServerError)
+				return
+			}
+		}
+		file, ok := files[req.URL.Path]
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+		http.ServeFile(rw, req, filepath.Join("testdata", "api", file))
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func newTestScraper(t *testing.T, endpoint string) *nomadScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Token = testToken
+	s := newNomadScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func metricNames(metrics pdata.MetricSlice) []string {
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	return names
+}
+
+func TestScrape(t *testing.T) {
+	server := newFakeNomad(t)
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+
+	client1 := map[string]string{"nomad.node.name": "client-1", "nomad.datacenter": "dc1"}
+	with := func(attrs map[string]string, k, v string) map[string]string {
+		res := map[string]string{k: v}
+		for k, v := range attrs {
+			res[k] = v
+		}
+		return res
+	}
+
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.node.status", with(client1, "status", "ready")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.node.status",
+		map[string]string{"nomad.node.name": "client-2", "nomad.datacenter": "dc2", "status": "down"}))
+
+	assert.EqualValues(t, 4000, metricstestutil.FindIntPoint(t, metrics, "nomad.node.cpu", with(client1, "state", "capacity")))
+	assert.EqualValues(t, 200, metricstestutil.FindIntPoint(t, metrics, "nomad.node.cpu", with(client1, "state", "reserved")))
+	// The running and pending allocations, not the complete one.
+	assert.EqualValues(t, 1100, metricstestutil.FindIntPoint(t, metrics, "nomad.node.cpu", with(client1, "state", "allocated")))
+	assert.EqualValues(t, 8192*mebibyte, metricstestutil.FindIntPoint(t, metrics, "nomad.node.memory", with(client1, "state", "capacity")))
+	assert.EqualValues(t, 640*mebibyte, metricstestutil.FindIntPoint(t, metrics, "nomad.node.memory", with(client1, "state", "allocated")))
+	assert.EqualValues(t, 1024*mebibyte, metricstestutil.FindIntPoint(t, metrics, "nomad.node.disk", with(client1, "state", "reserved")))
+	assert.EqualValues(t, 600*mebibyte, metricstestutil.FindIntPoint(t, metrics, "nomad.node.disk", with(client1, "state", "allocated")))
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "nomad.node.cpu",
+		map[string]string{"nomad.node.name": "client-2", "nomad.datacenter": "dc2", "state": "allocated"}))
+
+	web := map[string]string{"nomad.namespace": "default", "nomad.job.id": "web", "nomad.task_group": "frontend"}
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.allocations", with(web, "status", "running")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.allocations", with(web, "status", "pending")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.allocations", with(web, "status", "lost")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.allocations",
+		map[string]string{"nomad.namespace": "payments", "nomad.job.id": "reconcile", "nomad.task_group": "worker", "status": "complete"}))
+
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.job.status",
+		map[string]string{"nomad.namespace": "default", "nomad.job.id": "web", "nomad.job.type": "service", "status": "running"}))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.job.status",
+		map[string]string{"nomad.namespace": "payments", "nomad.job.id": "reconcile", "nomad.job.type": "batch", "status": "dead"}))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.job.queued_allocations", web))
+}
+
+func TestScrapeNamespace(t *testing.T) {
+	server := newFakeNomad(t)
+	s := newTestScraper(t, server.URL)
+	s.cfg.Namespace = "payments"
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.allocations",
+		map[string]string{"nomad.namespace": "payments", "nomad.job.id": "reconcile", "nomad.task_group": "worker", "status": "complete"}))
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == "nomad.allocations" {
+			assert.Equal(t, 1, metrics.At(i).Sum().DataPoints().Len())
+		}
+	}
+	// Resources allocated in all the namespaces are still accounted for.
+	assert.EqualValues(t, 1100, metricstestutil.FindIntPoint(t, metrics, "nomad.node.cpu",
+		map[string]string{"nomad.node.name": "client-1", "nomad.datacenter": "dc1", "state": "allocated"}))
+}
+
+func TestScrapeRegion(t *testing.T) {
+	server := newFakeNomad(t)
+	s := newNomadScraper(zap.NewNop(), &Config{})
+	s.cfg.Endpoint = server.URL
+	s.cfg.Token = testToken
+	s.cfg.Namespace = defaultNamespace
+	s.cfg.Region = "eu-west"
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"eu-west": true}, server.regions)
+}
+
+func TestScrapePartialFailure(t *testing.T) {
+	server := newFakeNomad(t, "/v1/node/3f9a8e44-0c1f-7f1b-2e0e-8d1f3b6b5c2a/allocations")
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), `failed to list the allocations of node "client-2": GET /v1/node/3f9a8e44-0c1f-7f1b-2e0e-8d1f3b6b5c2a/allocations returned 500: No cluster leader`)
+
+	// The status of the failed node and the other nodes are still recorded.
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "nomad.node.status",
+		map[string]string{"nomad.node.name": "client-2", "nomad.datacenter": "dc2", "status": "down"}))
+	assert.EqualValues(t, 4000, metricstestutil.FindIntPoint(t, metrics, "nomad.node.cpu",
+		map[string]string{"nomad.node.name": "client-1", "nomad.datacenter": "dc1", "state": "capacity"}))
+	assert.Contains(t, metricNames(metrics), "nomad.job.status")
+}
+
+func TestScrapeJobsFailure(t *testing.T) {
+	server := newFakeNomad(t, "/v1/jobs")
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Equal(t, []string{"nomad.node.status", "nomad.node.cpu", "nomad.node.memory", "nomad.node.disk", "nomad.allocations"}, metricNames(metrics))
+}
+
+func TestScrapeUnauthorized(t *testing.T) {
+	server := newFakeNomad(t)
+	s := newTestScraper(t, server.URL)
+	s.client.token = "wrong"
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list nodes: GET /v1/nodes returned 403: Permission denied")
+	assert.Contains(t, err.Error(), "failed to list jobs: GET /v1/jobs returned 403: Permission denied")
+	assert.Equal(t, 0, metrics.Len())
+}
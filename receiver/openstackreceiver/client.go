@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstackreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Service types of the catalog.
+	serviceCompute      = "compute"
+	serviceBlockStorage = "volumev3"
+	serviceNetwork      = "network"
+	serviceIdentity     = "identity"
+
+	// tokenRenewalMargin renews the tokens before they expire during a
+	// scrape.
+	tokenRenewalMargin = 5 * time.Minute
+)
+
+// serviceAliases are the other types the services can have in the catalog.
+var serviceAliases = map[string][]string{
+	serviceBlockStorage: {"block-storage"},
+}
+
+// errServiceNotFound is returned for the services missing from the catalog,
+// which aren't deployed in the cloud.
+var errServiceNotFound = errors.New("service not found in the catalog")
+
+type project struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	DomainID string `json:"domain_id"`
+	Domain   struct {
+		ID string `json:"id"`
+	} `json:"domain"`
+}
+
+type catalogEntry struct {
+	Type      string `json:"type"`
+	Endpoints []struct {
+		Interface string `json:"interface"`
+		Region    string `json:"region"`
+		RegionID  string `json:"region_id"`
+		URL       string `json:"url"`
+	} `json:"endpoints"`
+}
+
+type tokenResponse struct {
+	Token struct {
+		ExpiresAt time.Time      `json:"expires_at"`
+		Project   project        `json:"project"`
+		Catalog   []catalogEntry `json:"catalog"`
+	} `json:"token"`
+}
+
+// openStackClient calls the OpenStack APIs with a token issued by Keystone,
+// which is renewed when it expires.
+type openStackClient struct {
+	client *http.Client
+	cfg    *Config
+	now    func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	project   project
+	catalog   []catalogEntry
+}
+
+func newOpenStackClient(client *http.Client, cfg *Config) *openStackClient {
+	return &openStackClient{client: client, cfg: cfg, now: time.Now}
+}
+
+// tokenProject returns the project the token is scoped to.
+func (c *openStackClient) tokenProject(ctx context.Context) (project, error) {
+	if _, err := c.currentToken(ctx); err != nil {
+		return project{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.project, nil
+}
+
+// get decodes the response of the path of a service of the catalog.
+func (c *openStackClient) get(ctx context.Context, service, path string, v interface{}) error {
+	endpoint, err := c.endpoint(ctx, service)
+	if err != nil {
+		return err
+	}
+	return c.getURL(ctx, strings.TrimSuffix(endpoint, "/")+path, v)
+}
+
+// getURL decodes the response of a URL, such as the next page of a list. The
+// token is renewed once when it was revoked.
+func (c *openStackClient) getURL(ctx context.Context, url string, v interface{}) error {
+	token, err := c.currentToken(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, url, token)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		c.invalidateToken(token)
+		if token, err = c.currentToken(ctx); err != nil {
+			return err
+		}
+		resp, err = c.do(ctx, url, token)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(http.MethodGet, url, resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode the response of GET %s: %w", url, err)
+	}
+	return nil
+}
+
+func (c *openStackClient) do(ctx context.Context, url, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", token)
+	return c.client.Do(req)
+}
+
+// endpoint returns the URL of a service from the catalog of the token.
+func (c *openStackClient) endpoint(ctx context.Context, service string) (string, error) {
+	if service == serviceIdentity {
+		return c.cfg.Endpoint, nil
+	}
+	if _, err := c.currentToken(ctx); err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	types := append([]string{service}, serviceAliases[service]...)
+	for _, t := range types {
+		for _, entry := range c.catalog {
+			if entry.Type != t {
+				continue
+			}
+			for _, e := range entry.Endpoints {
+				if e.Interface != c.cfg.Interface {
+					continue
+				}
+				if c.cfg.Region == "" || e.RegionID == c.cfg.Region || e.Region == c.cfg.Region {
+					return e.URL, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %s", errServiceNotFound, service)
+}
+
+// currentToken returns the token, issuing a new one when it's about to
+// expire.
+func (c *openStackClient) currentToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && c.now().Add(tokenRenewalMargin).Before(c.expiresAt) {
+		return c.token, nil
+	}
+
+	body, err := json.Marshal(c.authRequest())
+	if err != nil {
+		return "", err
+	}
+	url := strings.TrimSuffix(c.cfg.Endpoint, "/") + "/auth/tokens"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to authenticate: %w", statusError(http.MethodPost, url, resp))
+	}
+	var tr tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return "", fmt.Errorf("failed to decode the token: %w", err)
+	}
+	c.token = resp.Header.Get("X-Subject-Token")
+	c.expiresAt = tr.Token.ExpiresAt
+	c.project = tr.Token.Project
+	c.project.DomainID = c.project.Domain.ID
+	c.catalog = tr.Token.Catalog
+	return c.token, nil
+}
+
+// invalidateToken forgets the token, unless it was already renewed.
+func (c *openStackClient) invalidateToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+func (c *openStackClient) authRequest() map[string]interface{} {
+	if c.cfg.ApplicationCredentialID != "" {
+		return map[string]interface{}{"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"application_credential"},
+				"application_credential": map[string]interface{}{
+					"id":     c.cfg.ApplicationCredentialID,
+					"secret": c.cfg.ApplicationCredentialSecret,
+				},
+			},
+		}}
+	}
+	return map[string]interface{}{"auth": map[string]interface{}{
+		"identity": map[string]interface{}{
+			"methods": []string{"password"},
+			"password": map[string]interface{}{
+				"user": map[string]interface{}{
+					"name":     c.cfg.Username,
+					"password": c.cfg.Password,
+					"domain":   map[string]interface{}{"name": c.cfg.UserDomainName},
+				},
+			},
+		},
+		"scope": map[string]interface{}{
+			"project": map[string]interface{}{
+				"name":   c.cfg.ProjectName,
+				"domain": map[string]interface{}{"name": c.cfg.ProjectDomainName},
+			},
+		},
+	}}
+}
+
+func statusError(method, url string, resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s %s returned %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstackreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		region  string
+		iface   string
+		want    string
+		err     error
+	}{
+		{name: "first region", service: serviceCompute, iface: "public", want: "/compute/v2.1"},
+		{name: "region", service: serviceCompute, region: "RegionTwo", iface: "public", want: "/compute-two/v2.1"},
+		{name: "interface", service: serviceCompute, iface: "internal", want: "/compute-internal/v2.1"},
+		{name: "alias", service: serviceBlockStorage, iface: "public", want: "/volume/v3/admin-project-id"},
+		{name: "identity", service: serviceIdentity, iface: "admin", want: "/identity/v3"},
+		{name: "missing interface", service: serviceNetwork, iface: "admin", err: errServiceNotFound},
+		{name: "missing region", service: serviceNetwork, region: "RegionTwo", iface: "public", err: errServiceNotFound},
+	}
+	server := newFakeOpenStack(t, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL + "/identity/v3"
+			cfg.Username = "otel"
+			cfg.Password = "secret"
+			cfg.Region = tt.region
+			cfg.Interface = tt.iface
+			endpoint, err := newOpenStackClient(http.DefaultClient, cfg).endpoint(context.Background(), tt.service)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, server.URL+tt.want, endpoint)
+		})
+	}
+}
+
+func TestTokenRenewal(t *testing.T) {
+	server := newFakeOpenStack(t, nil)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/identity/v3"
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+	c := newOpenStackClient(http.DefaultClient, cfg)
+
+	token, err := c.currentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	token, err = c.currentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The token expires within the renewal margin.
+	c.now = func() time.Time { return time.Date(2098, 12, 31, 23, 57, 0, 0, time.UTC) }
+	token, err = c.currentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
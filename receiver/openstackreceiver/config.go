@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstackreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const (
+	defaultEndpoint   = "http://localhost:5000/v3"
+	defaultDomainName = "Default"
+	defaultInterface  = "public"
+)
+
+var validInterfaces = map[string]bool{"public": true, "internal": true, "admin": true}
+
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// Endpoint is the URL of the Keystone identity API, the other APIs are
+	// found in the service catalog of the token.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// Username and Password authenticate a user of the UserDomainName domain,
+	// and scope the token to the ProjectName project of the ProjectDomainName
+	// domain.
+	Username          string `mapstructure:"username"`
+	Password          string `mapstructure:"password"`
+	UserDomainName    string `mapstructure:"user_domain_name"`
+	ProjectName       string `mapstructure:"project_name"`
+	ProjectDomainName string `mapstructure:"project_domain_name"`
+	// ApplicationCredentialID and ApplicationCredentialSecret authenticate
+	// with an application credential instead, which is scoped to the project
+	// it was created in.
+	ApplicationCredentialID     string `mapstructure:"application_credential_id"`
+	ApplicationCredentialSecret string `mapstructure:"application_credential_secret"`
+
+	// Region selects the endpoints of the catalog, the first region is used
+	// by default.
+	Region string `mapstructure:"region"`
+	// Interface is the interface of the endpoints of the catalog: public,
+	// internal or admin.
+	Interface string `mapstructure:"interface"`
+	// AllProjects reports the instances and quotas of all the projects, which
+	// requires the admin role, instead of the project of the token only.
+	AllProjects bool `mapstructure:"all_projects"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid endpoint %q: scheme must be http or https", cfg.Endpoint)
+	}
+
+	password := cfg.Username != "" || cfg.Password != ""
+	applicationCredential := cfg.ApplicationCredentialID != "" || cfg.ApplicationCredentialSecret != ""
+	switch {
+	case password && applicationCredential:
+		return errors.New("username and application_credential_id can't both be specified")
+	case applicationCredential:
+		if cfg.ApplicationCredentialID == "" || cfg.ApplicationCredentialSecret == "" {
+			return errors.New("application_credential_id and application_credential_secret must both be specified")
+		}
+	default:
+		if cfg.Username == "" || cfg.Password == "" {
+			return errors.New("username and password, or application_credential_id and application_credential_secret, must be specified")
+		}
+		if cfg.ProjectName == "" {
+			return errors.New("project_name must be specified with username")
+		}
+	}
+
+	if !validInterfaces[cfg.Interface] {
+		return fmt.Errorf("invalid interface %q: must be public, internal or admin", cfg.Interface)
+	}
+	return nil
+}
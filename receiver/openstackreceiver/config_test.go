@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstackreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Username = "otel"
+	expected.Password = "secret"
+	expected.ProjectName = "admin"
+	assert.Equal(t, expected, cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 10*time.Minute, custom.CollectionInterval)
+	assert.Equal(t, "https://keystone.example.com:5000/v3", custom.Endpoint)
+	assert.Equal(t, "/etc/openstack/ca.pem", custom.TLSSetting.CAFile)
+	assert.Equal(t, "4f2b9a6c", custom.ApplicationCredentialID)
+	assert.Equal(t, "s3cr3t", custom.ApplicationCredentialSecret)
+	assert.Equal(t, "RegionTwo", custom.Region)
+	assert.Equal(t, "internal", custom.Interface)
+	assert.True(t, custom.AllProjects)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{name: "password", modify: func(*Config) {}},
+		{
+			name: "application credential",
+			modify: func(cfg *Config) {
+				cfg.Username, cfg.Password, cfg.ProjectName = "", "", ""
+				cfg.ApplicationCredentialID, cfg.ApplicationCredentialSecret = "app-id", "app-secret"
+			},
+		},
+		{
+			name:   "missing endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "endpoint must be specified",
+		},
+		{
+			name:   "invalid scheme",
+			modify: func(cfg *Config) { cfg.Endpoint = "localhost:5000" },
+			err:    `invalid endpoint "localhost:5000": scheme must be http or https`,
+		},
+		{
+			name:   "missing credentials",
+			modify: func(cfg *Config) { cfg.Username, cfg.Password = "", "" },
+			err:    "username and password, or application_credential_id and application_credential_secret, must be specified",
+		},
+		{
+			name:   "missing password",
+			modify: func(cfg *Config) { cfg.Password = "" },
+			err:    "username and password, or application_credential_id and application_credential_secret, must be specified",
+		},
+		{
+			name:   "missing project",
+			modify: func(cfg *Config) { cfg.ProjectName = "" },
+			err:    "project_name must be specified with username",
+		},
+		{
+			name:   "both credentials",
+			modify: func(cfg *Config) { cfg.ApplicationCredentialID = "app-id" },
+			err:    "username and application_credential_id can't both be specified",
+		},
+		{
+			name: "missing application credential secret",
+			modify: func(cfg *Config) {
+				cfg.Username, cfg.Password = "", ""
+				cfg.ApplicationCredentialID = "app-id"
+			},
+			err: "application_credential_id and application_credential_secret must both be specified",
+		},
+		{
+			name:   "invalid interface",
+			modify: func(cfg *Config) { cfg.Interface = "private" },
+			err:    `invalid interface "private": must be public, internal or admin`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Username = "otel"
+			cfg.Password = "secret"
+			cfg.ProjectName = "admin"
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "openstackreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	OpenstackHypervisorDisk      MetricIntf
+	OpenstackHypervisorInstances MetricIntf
+	OpenstackHypervisorMemory    MetricIntf
+	OpenstackHypervisorVcpus     MetricIntf
+	OpenstackInstances           MetricIntf
+	OpenstackNetworkQuotaLimit   MetricIntf
+	OpenstackNetworkQuotaUsage   MetricIntf
+	OpenstackVolumeQuotaLimit    MetricIntf
+	OpenstackVolumeQuotaUsage    MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"openstack.hypervisor.disk",
+		"openstack.hypervisor.instances",
+		"openstack.hypervisor.memory",
+		"openstack.hypervisor.vcpus",
+		"openstack.instances",
+		"openstack.network.quota.limit",
+		"openstack.network.quota.usage",
+		"openstack.volume.quota.limit",
+		"openstack.volume.quota.usage",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"openstack.hypervisor.disk":      Metrics.OpenstackHypervisorDisk,
+	"openstack.hypervisor.instances": Metrics.OpenstackHypervisorInstances,
+	"openstack.hypervisor.memory":    Metrics.OpenstackHypervisorMemory,
+	"openstack.hypervisor.vcpus":     Metrics.OpenstackHypervisorVcpus,
+	"openstack.instances":            Metrics.OpenstackInstances,
+	"openstack.network.quota.limit":  Metrics.OpenstackNetworkQuotaLimit,
+	"openstack.network.quota.usage":  Metrics.OpenstackNetworkQuotaUsage,
+	"openstack.volume.quota.limit":   Metrics.OpenstackVolumeQuotaLimit,
+	"openstack.volume.quota.usage":   Metrics.OpenstackVolumeQuotaUsage,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"openstack.hypervisor.disk",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.hypervisor.disk")
+			metric.SetDescription("Local disk space of the hypervisor.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.hypervisor.instances",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.hypervisor.instances")
+			metric.SetDescription("Number of instances running on the hypervisor.")
+			metric.SetUnit("{instances}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.hypervisor.memory",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.hypervisor.memory")
+			metric.SetDescription("Memory of the hypervisor.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.hypervisor.vcpus",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.hypervisor.vcpus")
+			metric.SetDescription("Number of virtual CPUs of the hypervisor.")
+			metric.SetUnit("{vcpus}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.instances",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.instances")
+			metric.SetDescription("Number of instances of the project by status.")
+			metric.SetUnit("{instances}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.network.quota.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.network.quota.limit")
+			metric.SetDescription("Limit of the networking quotas of the project. Unlimited quotas aren't reported.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.network.quota.usage",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.network.quota.usage")
+			metric.SetDescription("Usage of the networking quotas of the project.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.volume.quota.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.volume.quota.limit")
+			metric.SetDescription("Limit of the block storage quotas of the project. Unlimited quotas aren't reported.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"openstack.volume.quota.usage",
+		func(metric pdata.Metric) {
+			metric.SetName("openstack.volume.quota.usage")
+			metric.SetDescription("Usage of the block storage quotas of the project. The gigabytes resources are in GiB.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Domain (The ID of the domain of the project, when Keystone lists it.)
+	Domain string
+	// Hypervisor (The host name of the hypervisor.)
+	Hypervisor string
+	// InstanceState (The status of the instances, such as active, shutoff or error.)
+	InstanceState string
+	// Project (The ID of the project.)
+	Project string
+	// ProjectName (The name of the project, when Keystone lists it.)
+	ProjectName string
+	// QuotaResource (The resource limited by the quota, such as volumes or floatingip.)
+	QuotaResource string
+	// UsageState (Whether the resource is used or free.)
+	UsageState string
+}{
+	"openstack.domain.id",
+	"openstack.hypervisor",
+	"state",
+	"openstack.project.id",
+	"openstack.project.name",
+	"resource",
+	"state",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
+
+// LabelUsageState are the possible values that the label "usage_state" can have.
+var LabelUsageState = struct {
+	Used string
+	Free string
+}{
+	"used",
+	"free",
+}
@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstackreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/openstackreceiver/internal/metadata"
+)
+
+const (
+	// hypervisorMetricsCount is the number of metrics recorded from the
+	// hypervisors.
+	hypervisorMetricsCount = 4
+	// quotaMetricsCount is the number of metrics recorded from the quotas of
+	// each service.
+	quotaMetricsCount = 2
+
+	mib = 1024 * 1024
+	gib = 1024 * mib
+)
+
+// volumeQuotaResources are the block storage quotas to report, the quotas of
+// the volume types are left out.
+var volumeQuotaResources = []string{"volumes", "gigabytes", "snapshots", "backups", "backup_gigabytes"}
+
+type hypervisorsResponse struct {
+	Hypervisors []struct {
+		HypervisorHostname string `json:"hypervisor_hostname"`
+		VCPUs              int64  `json:"vcpus"`
+		VCPUsUsed          int64  `json:"vcpus_used"`
+		MemoryMB           int64  `json:"memory_mb"`
+		MemoryMBUsed       int64  `json:"memory_mb_used"`
+		LocalGB            int64  `json:"local_gb"`
+		LocalGBUsed        int64  `json:"local_gb_used"`
+		RunningVMs         int64  `json:"running_vms"`
+	} `json:"hypervisors"`
+}
+
+type link struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+type serversResponse struct {
+	Servers []struct {
+		Status   string `json:"status"`
+		TenantID string `json:"tenant_id"`
+	} `json:"servers"`
+	Links []link `json:"servers_links"`
+}
+
+type projectsResponse struct {
+	Projects []project `json:"projects"`
+	Links    struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+type volumeQuotasResponse struct {
+	QuotaSet map[string]json.RawMessage `json:"quota_set"`
+}
+
+type volumeQuota struct {
+	InUse int64 `json:"in_use"`
+	Limit int64 `json:"limit"`
+}
+
+type networkQuotasResponse struct {
+	Quota map[string]struct {
+		Used  int64 `json:"used"`
+		Limit int64 `json:"limit"`
+	} `json:"quota"`
+}
+
+type openStackScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	client *openStackClient
+}
+
+func newOpenStackScraper(logger *zap.Logger, cfg *Config) *openStackScraper {
+	return &openStackScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (s *openStackScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = newOpenStackClient(httpClient, s.cfg)
+	return nil
+}
+
+func (s *openStackScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	now := pdata.NewTimestampFromTime(time.Now())
+	metrics := pdata.NewMetricSlice()
+
+	tokenProject, err := s.client.tokenProject(ctx)
+	if err != nil {
+		return metrics, err
+	}
+	projects := map[string]project{tokenProject.ID: tokenProject}
+	if s.cfg.AllProjects {
+		if err = s.listProjects(ctx, projects); err != nil {
+			// The metrics of the projects are still reported by ID.
+			s.logger.Warn("Failed to list the projects", zap.Error(err))
+		}
+	}
+
+	var errs scrapererror.ScrapeErrors
+	if err = s.scrapeHypervisors(ctx, metrics, now); err != nil && !errors.Is(err, errServiceNotFound) {
+		errs.AddPartial(hypervisorMetricsCount, err)
+	}
+	if err = s.scrapeInstances(ctx, metrics, now, projects); err != nil && !errors.Is(err, errServiceNotFound) {
+		errs.AddPartial(1, err)
+	}
+	volumeUsage := newMetric(metrics, metadata.M.OpenstackVolumeQuotaUsage).Sum().DataPoints()
+	volumeLimit := newMetric(metrics, metadata.M.OpenstackVolumeQuotaLimit).Sum().DataPoints()
+	networkUsage := newMetric(metrics, metadata.M.OpenstackNetworkQuotaUsage).Sum().DataPoints()
+	networkLimit := newMetric(metrics, metadata.M.OpenstackNetworkQuotaLimit).Sum().DataPoints()
+	for _, p := range projects {
+		if err = s.scrapeVolumeQuotas(ctx, volumeUsage, volumeLimit, now, p); err != nil && !errors.Is(err, errServiceNotFound) {
+			errs.AddPartial(quotaMetricsCount, err)
+		}
+		if err = s.scrapeNetworkQuotas(ctx, networkUsage, networkLimit, now, p); err != nil && !errors.Is(err, errServiceNotFound) {
+			errs.AddPartial(quotaMetricsCount, err)
+		}
+	}
+
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		return metric.Sum().DataPoints().Len() == 0
+	})
+	return metrics, errs.Combine()
+}
+
+// listProjects adds the projects listed by Keystone.
+func (s *openStackScraper) listProjects(ctx context.Context, projects map[string]project) error {
+	var resp projectsResponse
+	if err := s.client.get(ctx, serviceIdentity, "/projects", &resp); err != nil {
+		return err
+	}
+	for {
+		for _, p := range resp.Projects {
+			projects[p.ID] = p
+		}
+		if resp.Links.Next == "" {
+			return nil
+		}
+		next := resp.Links.Next
+		resp = projectsResponse{}
+		if err := s.client.getURL(ctx, next, &resp); err != nil {
+			return err
+		}
+	}
+}
+
+// scrapeHypervisors records the utilization of the hypervisors, which
+// requires the admin role.
+func (s *openStackScraper) scrapeHypervisors(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp) error {
+	var resp hypervisorsResponse
+	if err := s.client.get(ctx, serviceCompute, "/os-hypervisors/detail", &resp); err != nil {
+		return fmt.Errorf("failed to read the hypervisors: %w", err)
+	}
+
+	vcpus := newMetric(metrics, metadata.M.OpenstackHypervisorVcpus).Sum().DataPoints()
+	memory := newMetric(metrics, metadata.M.OpenstackHypervisorMemory).Sum().DataPoints()
+	disk := newMetric(metrics, metadata.M.OpenstackHypervisorDisk).Sum().DataPoints()
+	instances := newMetric(metrics, metadata.M.OpenstackHypervisorInstances).Sum().DataPoints()
+	for _, h := range resp.Hypervisors {
+		labels := map[string]string{metadata.L.Hypervisor: h.HypervisorHostname}
+		addUsagePoints(vcpus, now, labels, h.VCPUsUsed, h.VCPUs)
+		addUsagePoints(memory, now, labels, h.MemoryMBUsed*mib, h.MemoryMB*mib)
+		addUsagePoints(disk, now, labels, h.LocalGBUsed*gib, h.LocalGB*gib)
+		addPoint(instances, now, h.RunningVMs, labels)
+	}
+	return nil
+}
+
+// scrapeInstances records the number of instances of the projects by status.
+func (s *openStackScraper) scrapeInstances(ctx context.Context, metrics pdata.MetricSlice, now pdata.Timestamp, projects map[string]project) error {
+	path := "/servers/detail"
+	if s.cfg.AllProjects {
+		path += "?all_tenants=true"
+	}
+	var resp serversResponse
+	if err := s.client.get(ctx, serviceCompute, path, &resp); err != nil {
+		return fmt.Errorf("failed to read the instances: %w", err)
+	}
+
+	type key struct{ project, state string }
+	counts := map[key]int64{}
+	for {
+		for _, server := range resp.Servers {
+			counts[key{server.TenantID, strings.ToLower(server.Status)}]++
+		}
+		next := nextLink(resp.Links)
+		if next == "" {
+			break
+		}
+		resp = serversResponse{}
+		if err := s.client.getURL(ctx, next, &resp); err != nil {
+			return fmt.Errorf("failed to read the instances: %w", err)
+		}
+	}
+
+	dps := newMetric(metrics, metadata.M.OpenstackInstances).Sum().DataPoints()
+	for k, count := range counts {
+		p, ok := projects[k.project]
+		if !ok {
+			// The quotas of the projects having instances are reported too.
+			p = project{ID: k.project}
+			projects[k.project] = p
+		}
+		labels := projectLabels(p)
+		labels[metadata.L.InstanceState] = k.state
+		addPoint(dps, now, count, labels)
+	}
+	return nil
+}
+
+// scrapeVolumeQuotas records the block storage quota usage of a project.
+func (s *openStackScraper) scrapeVolumeQuotas(ctx context.Context, usage, limit pdata.NumberDataPointSlice, now pdata.Timestamp, p project) error {
+	var resp volumeQuotasResponse
+	if err := s.client.get(ctx, serviceBlockStorage, "/os-quota-sets/"+url.PathEscape(p.ID)+"?usage=true", &resp); err != nil {
+		return fmt.Errorf("failed to read the volume quotas of project %s: %w", p.ID, err)
+	}
+	for _, resource := range volumeQuotaResources {
+		raw, ok := resp.QuotaSet[resource]
+		if !ok {
+			continue
+		}
+		var quota volumeQuota
+		if err := json.Unmarshal(raw, &quota); err != nil {
+			return fmt.Errorf("failed to decode the %s volume quota of project %s: %w", resource, p.ID, err)
+		}
+		addQuotaPoints(usage, limit, now, p, resource, quota.InUse, quota.Limit)
+	}
+	return nil
+}
+
+// scrapeNetworkQuotas records the networking quota usage of a project.
+func (s *openStackScraper) scrapeNetworkQuotas(ctx context.Context, usage, limit pdata.NumberDataPointSlice, now pdata.Timestamp, p project) error {
+	var resp networkQuotasResponse
+	if err := s.client.get(ctx, serviceNetwork, "/v2.0/quotas/"+url.PathEscape(p.ID)+"/details.json", &resp); err != nil {
+		return fmt.Errorf("failed to read the network quotas of project %s: %w", p.ID, err)
+	}
+	for resource, quota := range resp.Quota {
+		addQuotaPoints(usage, limit, now, p, resource, quota.Used, quota.Limit)
+	}
+	return nil
+}
+
+func nextLink(links []link) string {
+	for _, l := range links {
+		if l.Rel == "next" {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+func projectLabels(p project) map[string]string {
+	labels := map[string]string{metadata.L.Project: p.ID}
+	if p.Name != "" {
+		labels[metadata.L.ProjectName] = p.Name
+	}
+	if p.DomainID != "" {
+		labels[metadata.L.Domain] = p.DomainID
+	}
+	return labels
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+// addUsagePoints records the used and free amounts of a resource. The free
+// amount is 0 when the resource is overcommitted.
+func addUsagePoints(dps pdata.NumberDataPointSlice, now pdata.Timestamp, labels map[string]string, used, total int64) {
+	free := total - used
+	if free < 0 {
+		free = 0
+	}
+	addPoint(dps, now, used, withLabel(labels, metadata.L.UsageState, metadata.LabelUsageState.Used))
+	addPoint(dps, now, free, withLabel(labels, metadata.L.UsageState, metadata.LabelUsageState.Free))
+}
+
+// addQuotaPoints records the usage and the limit of a quota, -1 meaning
+// unlimited.
+func addQuotaPoints(usage, limit pdata.NumberDataPointSlice, now pdata.Timestamp, p project, resource string, used, max int64) {
+	labels := projectLabels(p)
+	labels[metadata.L.QuotaResource] = resource
+	addPoint(usage, now, used, labels)
+	if max >= 0 {
+		addPoint(limit, now, max, labels)
+	}
+}
+
+func addPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64, labels map[string]string) {
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetIntVal(value)
+	for k, v := range labels {
+		dp.Attributes().InsertString(k, v)
+	}
+}
+
+func withLabel(labels map[string]string, key, value string) map[string]string {
+	l := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		l[k] = v
+	}
+	l[key] = value
+	return l
+}
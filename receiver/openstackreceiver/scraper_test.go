@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openstackreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
+)
+
+// fakeOpenStack serves the Keystone tokens, and the responses of the other
+// APIs by request URI, either inline or the name of a file of
+// testdata/openstack. The ENDPOINT placeholder of the responses is replaced
+// by the URL of the server.
+type fakeOpenStack struct {
+	*httptest.Server
+	t         *testing.T
+	responses map[string]string
+	tokenFile string
+
+	mu         sync.Mutex
+	authCount  int
+	validToken string
+}
+
+var defaultResponses = map[string]string{
+	"/identity/v3/projects":                                                 "projects.json",
+	"/compute/v2.1/os-hypervisors/detail":                                   "hypervisors.json",
+	"/compute/v2.1/servers/detail?all_tenants=true":                         "servers.json",
+	"/compute/v2.1/servers/detail?all_tenants=true&marker=b4":               "servers_page2.json",
+	"/volume/v3/admin-project-id/os-quota-sets/admin-project-id?usage=true": "volume_quotas.json",
+	"/volume/v3/admin-project-id/os-quota-sets/demo-project-id?usage=true":  `{"quota_set":{"id":"demo-project-id","volumes":{"in_use":1,"limit":5}}}`,
+	"/network/v2.0/quotas/admin-project-id/details.json":                    "network_quotas.json",
+	"/network/v2.0/quotas/demo-project-id/details.json":                     `{"quota":{"network":{"used":1,"limit":10}}}`,
+}
+
+func newFakeOpenStack(t *testing.T, responses map[string]string) *fakeOpenStack {
+	f := &fakeOpenStack{t: t, responses: responses, tokenFile: "token.json"}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeOpenStack) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodPost && req.URL.Path == "/identity/v3/auth/tokens" {
+		f.authenticate(rw, req)
+		return
+	}
+	f.mu.Lock()
+	validToken := f.validToken
+	f.mu.Unlock()
+	if req.Header.Get("X-Auth-Token") != validToken {
+		http.Error(rw, `{"error":{"code":401,"title":"Unauthorized"}}`, http.StatusUnauthorized)
+		return
+	}
+	response, ok := f.responses[req.URL.RequestURI()]
+	if !ok {
+		http.NotFound(rw, req)
+		return
+	}
+	f.write(rw, http.StatusOK, response)
+}
+
+func (f *fakeOpenStack) authenticate(rw http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Auth struct {
+			Identity struct {
+				Password struct {
+					User struct {
+						Name     string `json:"name"`
+						Password string `json:"password"`
+					} `json:"user"`
+				} `json:"password"`
+				ApplicationCredential struct {
+					ID     string `json:"id"`
+					Secret string `json:"secret"`
+				} `json:"application_credential"`
+			} `json:"identity"`
+		} `json:"auth"`
+	}
+	if !assert.NoError(f.t, json.NewDecoder(req.Body).Decode(&body)) {
+		return
+	}
+	identity := body.Auth.Identity
+	if (identity.Password.User.Name != "otel" || identity.Password.User.Password != "secret") &&
+		(identity.ApplicationCredential.ID != "app-id" || identity.ApplicationCredential.Secret != "app-secret") {
+		http.Error(rw, `{"error":{"code":401,"message":"The request you have made requires authentication."}}`, http.StatusUnauthorized)
+		return
+	}
+	f.mu.Lock()
+	f.authCount++
+	f.validToken = "token-" + strconv.Itoa(f.authCount)
+	rw.Header().Set("X-Subject-Token", f.validToken)
+	f.mu.Unlock()
+	f.write(rw, http.StatusCreated, f.tokenFile)
+}
+
+func (f *fakeOpenStack) write(rw http.ResponseWriter, status int, response string) {
+	if strings.HasSuffix(response, ".json") {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "openstack", response))
+		if !assert.NoError(f.t, err) {
+			return
+		}
+		response = string(body)
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write([]byte(strings.ReplaceAll(response, "ENDPOINT", f.URL)))
+}
+
+func (f *fakeOpenStack) revokeToken() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validToken = ""
+}
+
+func newTestScraper(t *testing.T, endpoint string) *openStackScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint + "/identity/v3"
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+	cfg.ProjectName = "admin"
+	cfg.AllProjects = true
+	s := newOpenStackScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func countPoints(metrics pdata.MetricSlice, name string) int {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i).Sum().DataPoints().Len()
+		}
+	}
+	return 0
+}
+
+func metricNames(metrics pdata.MetricSlice) []string {
+	var names []string
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	return names
+}
+
+func quotaLabels(project, name, domain, resource string) map[string]string {
+	return map[string]string{
+		"openstack.project.id":   project,
+		"openstack.project.name": name,
+		"openstack.domain.id":    domain,
+		"resource":               resource,
+	}
+}
+
+func TestScrape(t *testing.T) {
+	server := newFakeOpenStack(t, defaultResponses)
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+
+	compute1 := func(state string) map[string]string {
+		return map[string]string{"openstack.hypervisor": "compute-1", "state": state}
+	}
+	assert.EqualValues(t, 12, metricstestutil.FindIntPoint(t, metrics, "openstack.hypervisor.vcpus", compute1("used")))
+	assert.EqualValues(t, 20, metricstestutil.FindIntPoint(t, metrics, "openstack.hypervisor.vcpus", compute1("free")))
+	assert.EqualValues(t, int64(49152)*mib, metricstestutil.FindIntPoint(t, metrics, "openstack.hypervisor.memory", compute1("used")))
+	assert.EqualValues(t, int64(660)*gib, metricstestutil.FindIntPoint(t, metrics, "openstack.hypervisor.disk", compute1("free")))
+	assert.EqualValues(t, 5, metricstestutil.FindIntPoint(t, metrics, "openstack.hypervisor.instances", map[string]string{"openstack.hypervisor": "compute-1"}))
+	// The vCPUs of compute-2 are overcommitted.
+	assert.EqualValues(t, 0, metricstestutil.FindIntPoint(t, metrics, "openstack.hypervisor.vcpus", map[string]string{"openstack.hypervisor": "compute-2", "state": "free"}))
+
+	instances := func(project, name, domain, state string) map[string]string {
+		return map[string]string{
+			"openstack.project.id":   project,
+			"openstack.project.name": name,
+			"openstack.domain.id":    domain,
+			"state":                  state,
+		}
+	}
+	assert.EqualValues(t, 2, metricstestutil.FindIntPoint(t, metrics, "openstack.instances", instances("admin-project-id", "admin", "default", "active")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "openstack.instances", instances("admin-project-id", "admin", "default", "shutoff")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "openstack.instances", instances("demo-project-id", "demo", "research", "active")))
+	// The second page of the instances.
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "openstack.instances", instances("demo-project-id", "demo", "research", "error")))
+
+	assert.EqualValues(t, 350, metricstestutil.FindIntPoint(t, metrics, "openstack.volume.quota.usage", quotaLabels("admin-project-id", "admin", "default", "gigabytes")))
+	assert.EqualValues(t, 1000, metricstestutil.FindIntPoint(t, metrics, "openstack.volume.quota.limit", quotaLabels("admin-project-id", "admin", "default", "gigabytes")))
+	assert.EqualValues(t, 2, metricstestutil.FindIntPoint(t, metrics, "openstack.volume.quota.usage", quotaLabels("admin-project-id", "admin", "default", "snapshots")))
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "openstack.volume.quota.usage", quotaLabels("demo-project-id", "demo", "research", "volumes")))
+	// The quotas of the volume types and the unlimited quotas aren't reported.
+	assert.Equal(t, 6, countPoints(metrics, "openstack.volume.quota.usage"))
+	assert.Equal(t, 5, countPoints(metrics, "openstack.volume.quota.limit"))
+
+	assert.EqualValues(t, 5, metricstestutil.FindIntPoint(t, metrics, "openstack.network.quota.usage", quotaLabels("admin-project-id", "admin", "default", "floatingip")))
+	assert.EqualValues(t, 50, metricstestutil.FindIntPoint(t, metrics, "openstack.network.quota.limit", quotaLabels("admin-project-id", "admin", "default", "floatingip")))
+	assert.EqualValues(t, 10, metricstestutil.FindIntPoint(t, metrics, "openstack.network.quota.limit", quotaLabels("demo-project-id", "demo", "research", "network")))
+	assert.Equal(t, 4, countPoints(metrics, "openstack.network.quota.usage"))
+	assert.Equal(t, 3, countPoints(metrics, "openstack.network.quota.limit"))
+}
+
+func TestScrapeTokenProject(t *testing.T) {
+	server := newFakeOpenStack(t, map[string]string{
+		"/compute/v2.1/os-hypervisors/detail":                                   `{"forbiddenResource":{"code":403,"message":"Policy doesn't allow os_compute_api:os-hypervisors:list-detail to be performed."}}`,
+		"/compute/v2.1/servers/detail":                                          `{"servers":[{"id":"b1","status":"ACTIVE","tenant_id":"admin-project-id"}]}`,
+		"/volume/v3/admin-project-id/os-quota-sets/admin-project-id?usage=true": "volume_quotas.json",
+		"/network/v2.0/quotas/admin-project-id/details.json":                    "network_quotas.json",
+	})
+	server.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/compute/v2.1/os-hypervisors/detail" {
+			http.Error(rw, `{"forbiddenResource":{"code":403,"message":"Policy doesn't allow os_compute_api:os-hypervisors:list-detail to be performed."}}`, http.StatusForbidden)
+			return
+		}
+		server.serveHTTP(rw, req)
+	})
+	s := newTestScraper(t, server.URL)
+	s.cfg.AllProjects = false
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), "failed to read the hypervisors: GET "+server.URL+"/compute/v2.1/os-hypervisors/detail returned 403")
+
+	assert.Equal(t, []string{
+		"openstack.instances",
+		"openstack.volume.quota.usage",
+		"openstack.volume.quota.limit",
+		"openstack.network.quota.usage",
+		"openstack.network.quota.limit",
+	}, metricNames(metrics))
+	// The project of the token is named after its scope.
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "openstack.instances", map[string]string{
+		"openstack.project.id":   "admin-project-id",
+		"openstack.project.name": "admin",
+		"openstack.domain.id":    "default",
+		"state":                  "active",
+	}))
+}
+
+func TestScrapeMissingServices(t *testing.T) {
+	server := newFakeOpenStack(t, defaultResponses)
+	server.tokenFile = `{"token":{"expires_at":"2099-01-01T00:00:00Z","project":{"id":"admin-project-id","name":"admin","domain":{"id":"default"}},` +
+		`"catalog":[{"type":"compute","endpoints":[{"interface":"public","region_id":"RegionOne","url":"ENDPOINT/compute/v2.1"}]}]}}`
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"openstack.hypervisor.vcpus",
+		"openstack.hypervisor.memory",
+		"openstack.hypervisor.disk",
+		"openstack.hypervisor.instances",
+		"openstack.instances",
+	}, metricNames(metrics))
+}
+
+func TestScrapeProjectsForbidden(t *testing.T) {
+	responses := map[string]string{}
+	for k, v := range defaultResponses {
+		responses[k] = v
+	}
+	delete(responses, "/identity/v3/projects")
+	server := newFakeOpenStack(t, responses)
+	metrics, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+
+	// The projects having instances are still reported, by ID.
+	assert.EqualValues(t, 1, metricstestutil.FindIntPoint(t, metrics, "openstack.instances", map[string]string{
+		"openstack.project.id": "demo-project-id",
+		"state":                "error",
+	}))
+	assert.EqualValues(t, 10, metricstestutil.FindIntPoint(t, metrics, "openstack.network.quota.limit", map[string]string{
+		"openstack.project.id": "demo-project-id",
+		"resource":             "network",
+	}))
+}
+
+func TestScrapeTokenRevoked(t *testing.T) {
+	server := newFakeOpenStack(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	_, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	server.revokeToken()
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 9, metrics.Len())
+	assert.Equal(t, 2, server.authCount)
+}
+
+func TestScrapeApplicationCredential(t *testing.T) {
+	server := newFakeOpenStack(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	s.cfg.Username = ""
+	s.cfg.Password = ""
+	s.cfg.ApplicationCredentialID = "app-id"
+	s.cfg.ApplicationCredentialSecret = "app-secret"
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 9, metrics.Len())
+}
+
+func TestScrapeAuthenticationFailure(t *testing.T) {
+	server := newFakeOpenStack(t, defaultResponses)
+	s := newTestScraper(t, server.URL)
+	s.cfg.Password = "wrong"
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.False(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), "failed to authenticate: POST "+server.URL+"/identity/v3/auth/tokens returned 401")
+	assert.Equal(t, 0, metrics.Len())
+}
@@ -19,6 +19,8 @@ package podmanreceiver
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/model/pdata"
@@ -50,26 +52,96 @@ func translateStatsToMetrics(stats *containerStats, ts time.Time) pdata.Metrics
 	return md
 }
 
+// healthStatuses are the statuses of the health checks of containers.
+var healthStatuses = []string{"healthy", "unhealthy", "starting"}
+
+// containerState is the state of a container, combined from the list of
+// containers and its inspection.
+type containerState struct {
+	ID           string
+	Name         string
+	Status       string
+	Health       string
+	RestartCount int32
+	ExitCode     int32
+	PodID        string
+	PodName      string
+}
+
+func newContainerState(c *container, inspect *containerInspect) *containerState {
+	name := strings.TrimPrefix(inspect.Name, "/")
+	if name == "" && len(c.Names) > 0 {
+		name = c.Names[0]
+	}
+	return &containerState{
+		ID:           c.ID,
+		Name:         name,
+		Status:       c.State,
+		Health:       inspect.health(),
+		RestartCount: inspect.RestartCount,
+		ExitCode:     inspect.State.ExitCode,
+		PodID:        c.Pod,
+		PodName:      c.PodName,
+	}
+}
+
+// translateStateToMetrics returns the metrics of the state of a container
+// without stats, which is not running.
+func translateStateToMetrics(state *containerState, ts time.Time) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+
+	resource := rm.Resource()
+	resource.Attributes().InsertString("container.name", state.Name)
+	resource.Attributes().InsertString("container.id", state.ID)
+
+	ms := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	appendStateMetrics(ms, state, pdata.NewTimestampFromTime(ts))
+	return md
+}
+
+func appendStateMetrics(ms pdata.MetricSlice, state *containerState, ts pdata.Timestamp) {
+	sum(ms, "container.restarts", "1", []point{{
+		intVal: uint64(state.RestartCount),
+		attributes: map[string]string{
+			"exit_code": strconv.Itoa(int(state.ExitCode)),
+		},
+	}}, ts)
+
+	// Containers without health check have no health status.
+	if state.Health == "" || state.Health == "none" {
+		return
+	}
+	points := make([]point, len(healthStatuses))
+	for i, status := range healthStatuses {
+		points[i] = point{attributes: map[string]string{"status": status}}
+		if status == state.Health {
+			points[i].intVal = 1
+		}
+	}
+	gaugeI(ms, "container.health.status", "1", points, ts)
+}
+
 func appendMemoryMetrics(ms pdata.MetricSlice, stats *containerStats, ts pdata.Timestamp) {
-	gaugeI(ms, "memory.usage.limit", "By", []point{{intVal: stats.MemLimit}}, ts)
-	gaugeI(ms, "memory.usage.total", "By", []point{{intVal: stats.MemUsage}}, ts)
-	gaugeF(ms, "memory.percent", "1", []point{{doubleVal: stats.MemPerc}}, ts)
+	gaugeI(ms, "container.memory.usage.limit", "By", []point{{intVal: stats.MemLimit}}, ts)
+	gaugeI(ms, "container.memory.usage.total", "By", []point{{intVal: stats.MemUsage}}, ts)
+	gaugeF(ms, "container.memory.percent", "1", []point{{doubleVal: stats.MemPerc}}, ts)
 }
 
 func appendNetworkMetrics(ms pdata.MetricSlice, stats *containerStats, ts pdata.Timestamp) {
-	sum(ms, "network.io.usage.tx_bytes", "By", []point{{intVal: stats.NetInput}}, ts)
-	sum(ms, "network.io.usage.rx_bytes", "By", []point{{intVal: stats.NetOutput}}, ts)
+	sum(ms, "container.network.io.usage.tx_bytes", "By", []point{{intVal: stats.NetInput}}, ts)
+	sum(ms, "container.network.io.usage.rx_bytes", "By", []point{{intVal: stats.NetOutput}}, ts)
 }
 
 func appendIOMetrics(ms pdata.MetricSlice, stats *containerStats, ts pdata.Timestamp) {
-	sum(ms, "blockio.io_service_bytes_recursive.write", "By", []point{{intVal: stats.BlockOutput}}, ts)
-	sum(ms, "blockio.io_service_bytes_recursive.read", "By", []point{{intVal: stats.BlockInput}}, ts)
+	sum(ms, "container.blockio.io_service_bytes_recursive.write", "By", []point{{intVal: stats.BlockOutput}}, ts)
+	sum(ms, "container.blockio.io_service_bytes_recursive.read", "By", []point{{intVal: stats.BlockInput}}, ts)
 }
 
 func appendCPUMetrics(ms pdata.MetricSlice, stats *containerStats, ts pdata.Timestamp) {
-	sum(ms, "cpu.usage.system", "ns", []point{{intVal: stats.CPUSystemNano}}, ts)
-	sum(ms, "cpu.usage.total", "ns", []point{{intVal: stats.CPUNano}}, ts)
-	gaugeF(ms, "cpu.percent", "1", []point{{doubleVal: stats.CPU}}, ts)
+	sum(ms, "container.cpu.usage.system", "ns", []point{{intVal: stats.CPUSystemNano}}, ts)
+	sum(ms, "container.cpu.usage.total", "ns", []point{{intVal: stats.CPUNano}}, ts)
+	gaugeF(ms, "container.cpu.percent", "1", []point{{doubleVal: stats.CPU}}, ts)
 
 	points := make([]point, len(stats.PerCPU))
 	for i, cpu := range stats.PerCPU {
@@ -80,12 +152,12 @@ func appendCPUMetrics(ms pdata.MetricSlice, stats *containerStats, ts pdata.Time
 			},
 		}
 	}
-	sum(ms, "cpu.usage.percpu", "ns", points, ts)
+	sum(ms, "container.cpu.usage.percpu", "ns", points, ts)
 }
 
 func initMetric(ms pdata.MetricSlice, name, unit string) pdata.Metric {
 	m := ms.AppendEmpty()
-	m.SetName(fmt.Sprintf("container.%s", name))
+	m.SetName(name)
 	m.SetUnit(unit)
 	return m
 }
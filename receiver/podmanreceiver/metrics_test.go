@@ -118,6 +118,62 @@ func assertPoints(t *testing.T, dpts pdata.NumberDataPointSlice, pts []point) {
 	}
 }
 
+func TestTranslateStateToMetrics(t *testing.T) {
+	state := &containerState{
+		ID:           "abcd1234",
+		Name:         "cntrA",
+		Health:       "starting",
+		RestartCount: 4,
+		ExitCode:     1,
+	}
+	md := translateStateToMetrics(state, time.Now())
+
+	rsm := md.ResourceMetrics().At(0)
+	id, _ := rsm.Resource().Attributes().Get("container.id")
+	assert.Equal(t, "abcd1234", id.StringVal())
+
+	metrics := rsm.InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "container.restarts", metrics.At(0).Name())
+	assertMetricEqual(t, metrics.At(0), pdata.MetricDataTypeSum, []point{
+		{intVal: 4, attributes: map[string]string{"exit_code": "1"}},
+	})
+	assert.Equal(t, "container.health.status", metrics.At(1).Name())
+	assertMetricEqual(t, metrics.At(1), pdata.MetricDataTypeGauge, []point{
+		{intVal: 0, attributes: map[string]string{"status": "healthy"}},
+		{intVal: 0, attributes: map[string]string{"status": "unhealthy"}},
+		{intVal: 1, attributes: map[string]string{"status": "starting"}},
+	})
+}
+
+func TestTranslateStateWithoutHealthCheck(t *testing.T) {
+	for _, health := range []string{"", "none"} {
+		md := translateStateToMetrics(&containerState{ID: "abcd1234", Health: health}, time.Now())
+		metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		assert.Equal(t, 1, metrics.Len())
+		assert.Equal(t, "container.restarts", metrics.At(0).Name())
+	}
+}
+
+func TestNewContainerState(t *testing.T) {
+	inspect := containerInspect{Name: "/cntrA", RestartCount: 2}
+	inspect.State.ExitCode = 143
+	inspect.State.Healthcheck = &containerHealth{Status: "healthy"}
+
+	state := newContainerState(&container{ID: "abcd1234", Names: []string{"other"}}, &inspect)
+	assert.Equal(t, &containerState{
+		ID:           "abcd1234",
+		Name:         "cntrA",
+		Health:       "healthy",
+		RestartCount: 2,
+		ExitCode:     143,
+	}, state)
+
+	state = newContainerState(&container{ID: "abcd1234", Names: []string{"other"}}, &containerInspect{})
+	assert.Equal(t, "other", state.Name)
+	assert.Equal(t, "", state.Health)
+}
+
 func genContainerStats() *containerStats {
 	return &containerStats{
 		ContainerID:   "abcd1234",
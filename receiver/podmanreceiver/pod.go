@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package podmanreceiver
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// podStatuses are the statuses of the containers always counted for pods.
+var podStatuses = []string{"created", "running", "paused", "stopped", "exited"}
+
+// podStats aggregates the stats and states of the containers of a pod.
+type podStats struct {
+	ID   string
+	Name string
+
+	CPUNano  uint64
+	CPU      float64
+	MemUsage uint64
+	// Containers counts the containers of the pod by status.
+	Containers map[string]int64
+}
+
+// aggregatePods aggregates the stats and states of the containers by pod,
+// sorted by pod ID. The containers not in a pod are ignored.
+func aggregatePods(stats []containerStats, states map[string]*containerState) []*podStats {
+	pods := map[string]*podStats{}
+	for _, state := range states {
+		if state.PodID == "" {
+			continue
+		}
+		pod, ok := pods[state.PodID]
+		if !ok {
+			pod = &podStats{
+				ID:         state.PodID,
+				Name:       state.PodName,
+				Containers: map[string]int64{},
+			}
+			pods[state.PodID] = pod
+		}
+		pod.Containers[state.Status]++
+	}
+
+	for i := range stats {
+		state, ok := states[stats[i].ContainerID]
+		if !ok || state.PodID == "" {
+			continue
+		}
+		pod := pods[state.PodID]
+		pod.CPUNano += stats[i].CPUNano
+		pod.CPU += stats[i].CPU
+		pod.MemUsage += stats[i].MemUsage
+	}
+
+	sorted := make([]*podStats, 0, len(pods))
+	for _, pod := range pods {
+		sorted = append(sorted, pod)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
+
+func translatePodToMetrics(pod *podStats, ts time.Time) pdata.Metrics {
+	pbts := pdata.NewTimestampFromTime(ts)
+
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+
+	resource := rm.Resource()
+	resource.Attributes().InsertString("podman.pod.name", pod.Name)
+	resource.Attributes().InsertString("podman.pod.id", pod.ID)
+
+	ms := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	sum(ms, "pod.cpu.usage.total", "ns", []point{{intVal: pod.CPUNano}}, pbts)
+	gaugeF(ms, "pod.cpu.percent", "1", []point{{doubleVal: pod.CPU}}, pbts)
+	gaugeI(ms, "pod.memory.usage.total", "By", []point{{intVal: pod.MemUsage}}, pbts)
+
+	statuses := append([]string{}, podStatuses...)
+	for status := range pod.Containers {
+		if !containsString(podStatuses, status) {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses[len(podStatuses):])
+	points := make([]point, len(statuses))
+	for i, status := range statuses {
+		points[i] = point{
+			intVal:     uint64(pod.Containers[status]),
+			attributes: map[string]string{"state": status},
+		}
+	}
+	gaugeI(ms, "pod.containers", "1", points, pbts)
+
+	return md
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
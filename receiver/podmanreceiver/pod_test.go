@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package podmanreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestAggregatePods(t *testing.T) {
+	states := map[string]*containerState{
+		"c1": {ID: "c1", Status: "running", PodID: "p1", PodName: "web"},
+		"c2": {ID: "c2", Status: "running", PodID: "p1", PodName: "web"},
+		"c3": {ID: "c3", Status: "exited", PodID: "p1", PodName: "web"},
+		"c4": {ID: "c4", Status: "running", PodID: "p0", PodName: "db"},
+		"c5": {ID: "c5", Status: "running"},
+	}
+	stats := []containerStats{
+		{ContainerID: "c1", CPUNano: 100, CPU: 1.5, MemUsage: 1000},
+		{ContainerID: "c2", CPUNano: 200, CPU: 2.5, MemUsage: 2000},
+		{ContainerID: "c4", CPUNano: 50, CPU: 0.5, MemUsage: 500},
+		{ContainerID: "c5", CPUNano: 1000, CPU: 10, MemUsage: 10000},
+		{ContainerID: "c6", CPUNano: 1000, CPU: 10, MemUsage: 10000},
+	}
+
+	pods := aggregatePods(stats, states)
+	assert.Equal(t, []*podStats{
+		{ID: "p0", Name: "db", CPUNano: 50, CPU: 0.5, MemUsage: 500, Containers: map[string]int64{"running": 1}},
+		{ID: "p1", Name: "web", CPUNano: 300, CPU: 4, MemUsage: 3000, Containers: map[string]int64{"running": 2, "exited": 1}},
+	}, pods)
+}
+
+func TestTranslatePodToMetrics(t *testing.T) {
+	pod := &podStats{
+		ID:         "p1",
+		Name:       "web",
+		CPUNano:    300,
+		CPU:        4,
+		MemUsage:   3000,
+		Containers: map[string]int64{"running": 2, "exited": 1, "removing": 1},
+	}
+	md := translatePodToMetrics(pod, time.Now())
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	name, _ := rm.Resource().Attributes().Get("podman.pod.name")
+	assert.Equal(t, "web", name.StringVal())
+	id, _ := rm.Resource().Attributes().Get("podman.pod.id")
+	assert.Equal(t, "p1", id.StringVal())
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 4, metrics.Len())
+	assert.Equal(t, "pod.cpu.usage.total", metrics.At(0).Name())
+	assertMetricEqual(t, metrics.At(0), pdata.MetricDataTypeSum, []point{{intVal: 300}})
+	assert.Equal(t, "pod.cpu.percent", metrics.At(1).Name())
+	assertMetricEqual(t, metrics.At(1), pdata.MetricDataTypeGauge, []point{{doubleVal: 4}})
+	assert.Equal(t, "pod.memory.usage.total", metrics.At(2).Name())
+	assertMetricEqual(t, metrics.At(2), pdata.MetricDataTypeGauge, []point{{intVal: 3000}})
+	assert.Equal(t, "pod.containers", metrics.At(3).Name())
+	assertMetricEqual(t, metrics.At(3), pdata.MetricDataTypeGauge, []point{
+		{intVal: 0, attributes: map[string]string{"state": "created"}},
+		{intVal: 2, attributes: map[string]string{"state": "running"}},
+		{intVal: 0, attributes: map[string]string{"state": "paused"}},
+		{intVal: 0, attributes: map[string]string{"state": "stopped"}},
+		{intVal: 1, attributes: map[string]string{"state": "exited"}},
+		{intVal: 1, attributes: map[string]string{"state": "removing"}},
+	})
+}
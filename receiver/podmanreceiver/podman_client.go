@@ -57,10 +57,56 @@ type containerStatsReport struct {
 	Stats []containerStats
 }
 
+// container is a container as listed by /containers/json.
+type container struct {
+	ID       string `json:"Id"`
+	Names    []string
+	State    string
+	ExitCode int32
+	Pod      string
+	PodName  string
+}
+
+// containerHealth is the result of the health check of a container.
+type containerHealth struct {
+	Status        string
+	FailingStreak int
+}
+
+// containerInspect is the subset of the inspection of a container used to
+// report its health and restarts.
+type containerInspect struct {
+	ID           string `json:"Id"`
+	Name         string
+	RestartCount int32
+	State        struct {
+		Status   string
+		ExitCode int32
+		// Health is set from Podman 4, Healthcheck before.
+		Health      *containerHealth
+		Healthcheck *containerHealth
+	}
+}
+
+// health returns the status of the health check of the container, empty when
+// the container has no health check.
+func (c *containerInspect) health() string {
+	h := c.State.Health
+	if h == nil {
+		h = c.State.Healthcheck
+	}
+	if h == nil {
+		return ""
+	}
+	return h.Status
+}
+
 type clientFactory func(logger *zap.Logger, cfg *Config) (client, error)
 
 type client interface {
 	stats() ([]containerStats, error)
+	list() ([]container, error)
+	inspect(id string) (containerInspect, error)
 }
 
 type podmanClient struct {
@@ -122,6 +168,41 @@ func (c *podmanClient) stats() ([]containerStats, error) {
 	return report.Stats, nil
 }
 
+func (c *podmanClient) list() ([]container, error) {
+	params := url.Values{}
+	params.Add("all", "true")
+
+	var containers []container
+	if err := c.get("/containers/json", params, &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+func (c *podmanClient) inspect(id string) (containerInspect, error) {
+	var inspect containerInspect
+	err := c.get("/containers/"+url.PathEscape(id)+"/json", nil, &inspect)
+	return inspect, err
+}
+
+// get requests the path and decodes the JSON response in v.
+func (c *podmanClient) get(path string, params url.Values, v interface{}) error {
+	resp, err := c.request(context.Background(), path, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s returned %d: %s", path, resp.StatusCode, bytes)
+	}
+	return json.Unmarshal(bytes, v)
+}
+
 func (c *podmanClient) ping() error {
 	resp, err := c.request(context.Background(), "/_ping", nil)
 	if err != nil {
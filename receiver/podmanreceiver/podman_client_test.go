@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package podmanreceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *podmanClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &podmanClient{
+		conn:     server.Client(),
+		endpoint: server.URL + "/v3.3.1/libpod",
+	}
+}
+
+func TestList(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3.3.1/libpod/containers/json", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("all"))
+		_, _ = w.Write([]byte(`[{"Id":"c1","Names":["web"],"State":"running","ExitCode":0},{"Id":"c2","Names":["worker"],"State":"exited","ExitCode":137}]`))
+	})
+
+	containers, err := c.list()
+	require.NoError(t, err)
+	assert.Equal(t, []container{
+		{ID: "c1", Names: []string{"web"}, State: "running"},
+		{ID: "c2", Names: []string{"worker"}, State: "exited", ExitCode: 137},
+	}, containers)
+}
+
+func TestInspect(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3.3.1/libpod/containers/c1/json":
+			_, _ = w.Write([]byte(`{"Id":"c1","Name":"web","RestartCount":5,"State":{"Status":"running","ExitCode":1,"Healthcheck":{"Status":"unhealthy","FailingStreak":3}}}`))
+		case "/v3.3.1/libpod/containers/c2/json":
+			_, _ = w.Write([]byte(`{"Id":"c2","Name":"worker","State":{"Status":"running","Health":{"Status":"healthy"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"cause":"no such container"}`))
+		}
+	})
+
+	inspect, err := c.inspect("c1")
+	require.NoError(t, err)
+	assert.Equal(t, "web", inspect.Name)
+	assert.EqualValues(t, 5, inspect.RestartCount)
+	assert.EqualValues(t, 1, inspect.State.ExitCode)
+	assert.Equal(t, "unhealthy", inspect.health())
+
+	inspect, err = c.inspect("c2")
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", inspect.health())
+
+	_, err = c.inspect("c3")
+	assert.EqualError(t, err, `request to /containers/c3/json returned 404: {"cause":"no such container"}`)
+}
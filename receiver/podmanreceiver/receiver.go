@@ -25,6 +25,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
 
@@ -123,22 +124,64 @@ func (r *receiver) Run() error {
 		return nil
 	}
 
-	numPoints, err = r.consumeStats(ctx, stats)
+	states, err := r.containerStates()
+	if err != nil {
+		// The stats are still reported without the states of the containers.
+		r.logger.Error("error fetching containers", zap.Error(err))
+	}
+
+	numPoints, err = r.consumeStats(ctx, stats, states)
 	return nil
 }
 
-func (r *receiver) consumeStats(ctx context.Context, stats []containerStats) (int, error) {
+// containerStates returns the states of all the containers, including the
+// ones not running, by ID.
+func (r *receiver) containerStates() (map[string]*containerState, error) {
+	containers, err := r.client.list()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]*containerState, len(containers))
+	for _, c := range containers {
+		inspect, err := r.client.inspect(c.ID)
+		if err != nil {
+			// The container may have been removed since it was listed.
+			r.logger.Debug("error inspecting container", zap.String("id", c.ID), zap.Error(err))
+			continue
+		}
+		states[c.ID] = newContainerState(&c, &inspect)
+	}
+	return states, nil
+}
+
+func (r *receiver) consumeStats(ctx context.Context, stats []containerStats, states map[string]*containerState) (int, error) {
 	numPoints := 0
 	var lastErr error
 
-	for i := range stats {
-		md := translateStatsToMetrics(&stats[i], time.Now())
+	consume := func(md pdata.Metrics) {
 		numPoints += md.DataPointCount()
 		err := r.nextConsumer.ConsumeMetrics(ctx, md)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to consume stats: %w", err)
-			continue
 		}
 	}
+
+	now := time.Now()
+	for _, pod := range aggregatePods(stats, states) {
+		consume(translatePodToMetrics(pod, now))
+	}
+	for i := range stats {
+		md := translateStatsToMetrics(&stats[i], now)
+		if state, ok := states[stats[i].ContainerID]; ok {
+			appendStateMetrics(md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics(), state, pdata.NewTimestampFromTime(now))
+			delete(states, stats[i].ContainerID)
+		}
+		consume(md)
+	}
+	// The containers without stats, which are not running.
+	for _, state := range states {
+		consume(translateStateToMetrics(state, now))
+	}
 	return numPoints, lastErr
 }
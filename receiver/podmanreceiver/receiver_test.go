@@ -112,6 +112,88 @@ func (c mockClient) stats() ([]containerStats, error) {
 	return report.Stats, nil
 }
 
+func (c mockClient) list() ([]container, error) {
+	return nil, nil
+}
+
+func (c mockClient) inspect(id string) (containerInspect, error) {
+	return containerInspect{}, errors.New("not found")
+}
+
+type stateClient struct {
+	mockClient
+	containers []container
+	inspects   map[string]containerInspect
+}
+
+func (c *stateClient) list() ([]container, error) {
+	return c.containers, nil
+}
+
+func (c *stateClient) inspect(id string) (containerInspect, error) {
+	inspect, ok := c.inspects[id]
+	if !ok {
+		return containerInspect{}, errors.New("no such container")
+	}
+	return inspect, nil
+}
+
+func TestConsumeStatesOfContainers(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	running := containerInspect{ID: "c1", Name: "web", RestartCount: 3}
+	running.State.Health = &containerHealth{Status: "unhealthy"}
+	exited := containerInspect{ID: "c2", Name: "worker", RestartCount: 7}
+	exited.State.ExitCode = 137
+	client := &stateClient{
+		containers: []container{{ID: "c1"}, {ID: "c2"}, {ID: "c3"}},
+		inspects:   map[string]containerInspect{"c1": running, "c2": exited},
+	}
+
+	r := &receiver{logger: zap.NewNop(), nextConsumer: sink, client: client}
+	states, err := r.containerStates()
+	require.NoError(t, err)
+	// c3 was removed before being inspected.
+	assert.Len(t, states, 2)
+
+	numPoints, err := r.consumeStats(context.Background(), []containerStats{{ContainerID: "c1", Name: "web"}}, states)
+	require.NoError(t, err)
+
+	all := sink.AllMetrics()
+	require.Len(t, all, 2)
+	// The states of running containers are added to their stats.
+	assert.Equal(t, 11+2, all[0].MetricCount())
+	// The containers not running only have their state.
+	assert.Equal(t, 1, all[1].MetricCount())
+	name, _ := all[1].ResourceMetrics().At(0).Resource().Attributes().Get("container.name")
+	assert.Equal(t, "worker", name.StringVal())
+	assert.Equal(t, all[0].DataPointCount()+all[1].DataPointCount(), numPoints)
+}
+
+func TestConsumeStatsOfPods(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	client := &stateClient{
+		containers: []container{
+			{ID: "c1", State: "running", Pod: "p1", PodName: "web"},
+			{ID: "c2", State: "exited", Pod: "p1", PodName: "web"},
+		},
+		inspects: map[string]containerInspect{"c1": {ID: "c1"}, "c2": {ID: "c2"}},
+	}
+
+	r := &receiver{logger: zap.NewNop(), nextConsumer: sink, client: client}
+	states, err := r.containerStates()
+	require.NoError(t, err)
+
+	_, err = r.consumeStats(context.Background(), []containerStats{{ContainerID: "c1", CPUNano: 10}}, states)
+	require.NoError(t, err)
+
+	all := sink.AllMetrics()
+	// The pod, the running container and the exited one.
+	require.Len(t, all, 3)
+	pod, ok := all[0].ResourceMetrics().At(0).Resource().Attributes().Get("podman.pod.id")
+	require.True(t, ok)
+	assert.Equal(t, "p1", pod.StringVal())
+}
+
 type mockConsumer chan pdata.Metrics
 
 func (m mockConsumer) Capabilities() consumer.Capabilities {
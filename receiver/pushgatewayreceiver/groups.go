@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushgatewayreceiver
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	jobLabel      = "job"
+	base64Suffix  = "@base64"
+	metricsPrefix = "/metrics/"
+)
+
+// label is a label of the grouping key of a group.
+type label struct {
+	name  string
+	value string
+}
+
+// parseGroupingKey parses the grouping key of the escaped path of a request,
+// /metrics/job/<job>{/<label>/<value>}. Values of labels whose name have the
+// @base64 suffix are decoded from URL-safe base64. The labels are sorted by
+// name.
+func parseGroupingKey(escapedPath string) ([]label, error) {
+	if !strings.HasPrefix(escapedPath, metricsPrefix) {
+		return nil, errors.New("path must start with /metrics/")
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(escapedPath, metricsPrefix), "/"), "/")
+	if len(segments)%2 != 0 {
+		return nil, errors.New("grouping key must be made of label name and value pairs")
+	}
+
+	labels := make([]label, 0, len(segments)/2)
+	seen := make(map[string]bool, len(segments)/2)
+	for i := 0; i < len(segments); i += 2 {
+		name, err := url.PathUnescape(segments[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid label name %q: %w", segments[i], err)
+		}
+		value, err := url.PathUnescape(segments[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of label %q: %w", name, err)
+		}
+		if strings.HasSuffix(name, base64Suffix) {
+			name = strings.TrimSuffix(name, base64Suffix)
+			if value, err = decodeBase64(value); err != nil {
+				return nil, fmt.Errorf("invalid base64 value of label %q: %w", name, err)
+			}
+		}
+		if i == 0 && name != jobLabel {
+			return nil, errors.New("grouping key must start with the job label")
+		}
+		if !model.LabelName(name).IsValid() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+			return nil, fmt.Errorf("invalid label name %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate label %q", name)
+		}
+		seen[name] = true
+		labels = append(labels, label{name: name, value: value})
+	}
+	if labels[0].value == "" {
+		return nil, errors.New("job must not be empty")
+	}
+
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+	return labels, nil
+}
+
+func decodeBase64(s string) (string, error) {
+	// An empty value is encoded as "=", which is also accepted without
+	// padding.
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	return string(b), err
+}
+
+// groupID returns the identifier of the group with the labels.
+func groupID(labels []label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.name)
+		b.WriteByte(model.SeparatorByte)
+		b.WriteString(l.value)
+		b.WriteByte(model.SeparatorByte)
+	}
+	return b.String()
+}
+
+// family is a metric family of a group, with the time it was pushed.
+type family struct {
+	family   *dto.MetricFamily
+	pushTime time.Time
+}
+
+// group is the set of metric families pushed with the same grouping key.
+type group struct {
+	labels   []label
+	families map[string]family
+	pushTime time.Time
+}
+
+// groupStore stores the metric families of groups, the way a Pushgateway
+// does.
+type groupStore struct {
+	mu     sync.Mutex
+	groups map[string]*group
+}
+
+func newGroupStore() *groupStore {
+	return &groupStore{groups: make(map[string]*group)}
+}
+
+// push stores the families in the group with the labels. If replace is true,
+// families of the group that aren't pushed are deleted, otherwise only the
+// families with the same names are replaced.
+func (s *groupStore) push(labels []label, families []*dto.MetricFamily, replace bool, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := groupID(labels)
+	g, ok := s.groups[id]
+	if !ok || replace {
+		g = &group{labels: labels, families: make(map[string]family, len(families))}
+		s.groups[id] = g
+	}
+	for _, mf := range families {
+		g.families[mf.GetName()] = family{family: mf, pushTime: now}
+	}
+	g.pushTime = now
+}
+
+// delete deletes the group with the labels.
+func (s *groupStore) delete(labels []label) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.groups, groupID(labels))
+}
+
+// expire deletes the groups that haven't been pushed to since before.
+func (s *groupStore) expire(before time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, g := range s.groups {
+		if g.pushTime.Before(before) {
+			delete(s.groups, id)
+		}
+	}
+}
+
+// snapshot returns the groups, sorted by grouping key. Groups and families
+// are replaced rather than modified, so they can be read after the lock is
+// released.
+func (s *groupStore) snapshot() []group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.groups))
+	for id := range s.groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	groups := make([]group, 0, len(ids))
+	for _, id := range ids {
+		g := s.groups[id]
+		families := make(map[string]family, len(g.families))
+		for name, f := range g.families {
+			families[name] = f
+		}
+		groups = append(groups, group{labels: g.labels, families: families, pushTime: g.pushTime})
+	}
+	return groups
+}
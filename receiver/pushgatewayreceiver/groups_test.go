@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushgatewayreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupingKey(t *testing.T) {
+	tests := []struct {
+		path   string
+		labels []label
+		err    string
+	}{
+		{
+			path:   "/metrics/job/backup",
+			labels: []label{{"job", "backup"}},
+		},
+		{
+			path:   "/metrics/job/backup/instance/db-1/",
+			labels: []label{{"instance", "db-1"}, {"job", "backup"}},
+		},
+		{
+			path:   "/metrics/job@base64/YmFja3VwL2RhaWx5/path@base64/L3Zhci90bXA",
+			labels: []label{{"job", "backup/daily"}, {"path", "/var/tmp"}},
+		},
+		{
+			path:   "/metrics/job/backup/empty@base64/=",
+			labels: []label{{"empty", ""}, {"job", "backup"}},
+		},
+		{
+			path:   "/metrics/job/backup/zone/eu%20west",
+			labels: []label{{"job", "backup"}, {"zone", "eu west"}},
+		},
+		{
+			path: "/metrics/job",
+			err:  "grouping key must be made of label name and value pairs",
+		},
+		{
+			path: "/metrics/instance/db-1",
+			err:  "grouping key must start with the job label",
+		},
+		{
+			path: "/metrics/job@base64/=",
+			err:  "job must not be empty",
+		},
+		{
+			path: "/metrics/job/backup/job/restore",
+			err:  `duplicate label "job"`,
+		},
+		{
+			path: "/metrics/job/backup/1zone/eu",
+			err:  `invalid label name "1zone"`,
+		},
+		{
+			path: "/metrics/job/backup/__name__/up",
+			err:  `invalid label name "__name__"`,
+		},
+		{
+			path: "/metrics/job/backup/zone@base64/!!",
+			err:  `invalid base64 value of label "zone": illegal base64 data at input byte 0`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			labels, err := parseGroupingKey(test.path)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.labels, labels)
+		})
+	}
+}
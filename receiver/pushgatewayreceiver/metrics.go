@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushgatewayreceiver
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prommetrics"
+)
+
+const (
+	instanceLabel = "instance"
+
+	// pushTimeMetric is the name of the gauge set to the time of the last
+	// push to a group, as exposed by the Pushgateway.
+	pushTimeMetric = "push_time_seconds"
+)
+
+// groupsToMetrics converts the metric families of the groups. Every group is
+// a resource with the labels of the grouping key as attributes, the job also
+// being the service name. Labels of the grouping key take precedence over the
+// labels of the metrics, and cumulative metrics start when their family was
+// pushed.
+func groupsToMetrics(groups []group, now time.Time) pdata.Metrics {
+	md := pdata.NewMetrics()
+	ts := pdata.NewTimestampFromTime(now)
+	for _, g := range groups {
+		rm := md.ResourceMetrics().AppendEmpty()
+		attrs := rm.Resource().Attributes()
+		groupLabels := make(map[string]bool, len(g.labels))
+		for _, l := range g.labels {
+			attrs.UpsertString(l.name, l.value)
+			groupLabels[l.name] = true
+			switch l.name {
+			case jobLabel:
+				attrs.UpsertString(conventions.AttributeServiceName, l.value)
+			case instanceLabel:
+				attrs.UpsertString(conventions.AttributeServiceInstanceID, l.value)
+			}
+		}
+
+		metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+		names := make([]string, 0, len(g.families))
+		for name := range g.families {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			f := g.families[name]
+			prommetrics.AppendFamily(metrics, f.family, pdata.NewTimestampFromTime(f.pushTime), ts, groupLabels)
+		}
+
+		pushTime := metrics.AppendEmpty()
+		pushTime.SetName(pushTimeMetric)
+		pushTime.SetDescription("Last Unix time when this group was changed in the Pushgateway.")
+		pushTime.SetDataType(pdata.MetricDataTypeGauge)
+		dp := pushTime.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetDoubleVal(float64(g.pushTime.UnixNano()) / 1e9)
+	}
+	return md
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushgatewayreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prommetrics"
+)
+
+type pushgatewayReceiver struct {
+	cfg          *Config
+	settings     component.TelemetrySettings
+	nextConsumer consumer.Metrics
+	groups       *groupStore
+	now          func() time.Time
+
+	server *http.Server
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPushgatewayReceiver(cfg *Config, settings component.TelemetrySettings, nextConsumer consumer.Metrics) *pushgatewayReceiver {
+	return &pushgatewayReceiver{
+		cfg:          cfg,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		groups:       newGroupStore(),
+		now:          time.Now,
+	}
+}
+
+func (r *pushgatewayReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.cfg.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.cfg.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(metricsPrefix, r.handleMetrics)
+	r.server = r.cfg.HTTPServerSettings.ToServer(router, r.settings)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(2)
+	go func() {
+		defer r.wg.Done()
+		if err := r.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			host.ReportFatalError(err)
+		}
+	}()
+	go func() {
+		defer r.wg.Done()
+		r.collectLoop(ctx)
+	}()
+	return nil
+}
+
+func (r *pushgatewayReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	var err error
+	if r.server != nil {
+		err = r.server.Close()
+	}
+	r.wg.Wait()
+	return err
+}
+
+func (r *pushgatewayReceiver) collectLoop(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.CollectionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.collect(ctx)
+		}
+	}
+}
+
+// collect emits the metrics of all the groups, after deleting the expired
+// ones.
+func (r *pushgatewayReceiver) collect(ctx context.Context) {
+	now := r.now()
+	if r.cfg.GroupExpiration > 0 {
+		r.groups.expire(now.Add(-r.cfg.GroupExpiration))
+	}
+	groups := r.groups.snapshot()
+	if len(groups) == 0 {
+		return
+	}
+	if err := r.nextConsumer.ConsumeMetrics(ctx, groupsToMetrics(groups, now)); err != nil {
+		r.settings.Logger.Error("Failed to pass pushed metrics to next consumer", zap.Error(err))
+	}
+}
+
+// handleMetrics implements the Pushgateway API: PUT replaces all the metrics
+// of a group, POST replaces the metrics with the same names, and DELETE
+// deletes a group.
+func (r *pushgatewayReceiver) handleMetrics(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPut && req.Method != http.MethodPost && req.Method != http.MethodDelete {
+		w.Header().Set("Allow", "PUT, POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	labels, err := parseGroupingKey(req.URL.EscapedPath())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Method == http.MethodDelete {
+		r.groups.delete(labels)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	families, err := decodeFamilies(req)
+	if err != nil {
+		r.settings.Logger.Debug("Rejected pushed metrics", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.groups.push(labels, families, req.Method == http.MethodPut, r.now())
+	w.WriteHeader(http.StatusOK)
+}
+
+// decodeFamilies decodes the metric families of a request, in the text or
+// protobuf format.
+func decodeFamilies(req *http.Request) ([]*dto.MetricFamily, error) {
+	decoder := expfmt.NewDecoder(req.Body, expfmt.ResponseFormat(req.Header))
+	var families []*dto.MetricFamily
+	names := make(map[string]bool)
+	for {
+		mf := &dto.MetricFamily{}
+		if err := decoder.Decode(mf); err != nil {
+			if errors.Is(err, io.EOF) {
+				return families, nil
+			}
+			return nil, fmt.Errorf("failed to parse metrics: %w", err)
+		}
+		if names[mf.GetName()] {
+			return nil, fmt.Errorf("metric family %q is pushed more than once", mf.GetName())
+		}
+		names[mf.GetName()] = true
+		if err := prommetrics.Validate(mf); err != nil {
+			return nil, err
+		}
+		families = append(families, mf)
+	}
+}
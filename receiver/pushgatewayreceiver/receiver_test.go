@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pushgatewayreceiver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"google.golang.org/protobuf/proto"
+)
+
+const backupMetrics = `# HELP backup_duration_seconds Duration of the backup.
+# TYPE backup_duration_seconds gauge
+backup_duration_seconds{job="ignored",db="orders"} 42.5
+# HELP backup_files_total Files backed up.
+# TYPE backup_files_total counter
+backup_files_total{db="orders"} 1200
+# TYPE backup_size_bytes histogram
+backup_size_bytes_bucket{le="1000"} 3
+backup_size_bytes_bucket{le="10000"} 5
+backup_size_bytes_bucket{le="+Inf"} 6
+backup_size_bytes_sum 25000
+backup_size_bytes_count 6
+# TYPE backup_latency_seconds summary
+backup_latency_seconds{quantile="0.5"} 0.2
+backup_latency_seconds{quantile="0.99"} 1.5
+backup_latency_seconds_sum 12
+backup_latency_seconds_count 30
+`
+
+func newTestReceiver(t *testing.T) (*pushgatewayReceiver, *consumertest.MetricsSink, *time.Time) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	r := newPushgatewayReceiver(cfg, componenttest.NewNopTelemetrySettings(), sink)
+	now := time.Date(2021, 10, 4, 15, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	return r, sink, &now
+}
+
+func push(t *testing.T, r *pushgatewayReceiver, method, path, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	r.handleMetrics(rec, req)
+	return rec
+}
+
+func collectMetrics(t *testing.T, r *pushgatewayReceiver, sink *consumertest.MetricsSink) map[string]pdata.Metric {
+	sink.Reset()
+	r.collect(context.Background())
+	metrics := make(map[string]pdata.Metric)
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			ms := rms.At(i).InstrumentationLibraryMetrics().At(0).Metrics()
+			for j := 0; j < ms.Len(); j++ {
+				metrics[ms.At(j).Name()] = ms.At(j)
+			}
+		}
+	}
+	return metrics
+}
+
+func TestPushText(t *testing.T) {
+	r, sink, now := newTestReceiver(t)
+	pushTime := *now
+	rec := push(t, r, http.MethodPut, "/metrics/job/backup/instance/db-1", "", []byte(backupMetrics))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	*now = now.Add(time.Minute)
+	metrics := collectMetrics(t, r, sink)
+	require.Len(t, sink.AllMetrics(), 1)
+	require.Len(t, metrics, 5)
+
+	resource := sink.AllMetrics()[0].ResourceMetrics().At(0).Resource()
+	assert.Equal(t, map[string]interface{}{
+		"job":                 "backup",
+		"instance":            "db-1",
+		"service.name":        "backup",
+		"service.instance.id": "db-1",
+	}, resource.Attributes().AsRaw())
+
+	gauge := metrics["backup_duration_seconds"]
+	assert.Equal(t, "Duration of the backup.", gauge.Description())
+	require.Equal(t, pdata.MetricDataTypeGauge, gauge.DataType())
+	dp := gauge.Gauge().DataPoints().At(0)
+	assert.Equal(t, 42.5, dp.DoubleVal())
+	assert.Equal(t, pdata.NewTimestampFromTime(*now), dp.Timestamp())
+	assert.Equal(t, map[string]interface{}{"db": "orders"}, dp.Attributes().AsRaw())
+
+	counter := metrics["backup_files_total"]
+	require.Equal(t, pdata.MetricDataTypeSum, counter.DataType())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, counter.Sum().AggregationTemporality())
+	dp = counter.Sum().DataPoints().At(0)
+	assert.Equal(t, 1200.0, dp.DoubleVal())
+	assert.Equal(t, pdata.NewTimestampFromTime(pushTime), dp.StartTimestamp())
+
+	histogram := metrics["backup_size_bytes"]
+	require.Equal(t, pdata.MetricDataTypeHistogram, histogram.DataType())
+	hdp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(6), hdp.Count())
+	assert.Equal(t, 25000.0, hdp.Sum())
+	assert.Equal(t, []float64{1000, 10000}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{3, 2, 1}, hdp.BucketCounts())
+
+	summary := metrics["backup_latency_seconds"]
+	require.Equal(t, pdata.MetricDataTypeSummary, summary.DataType())
+	sdp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(30), sdp.Count())
+	assert.Equal(t, 12.0, sdp.Sum())
+	require.Equal(t, 2, sdp.QuantileValues().Len())
+	assert.Equal(t, 0.99, sdp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 1.5, sdp.QuantileValues().At(1).Value())
+
+	pushTimeSeconds := metrics[pushTimeMetric]
+	assert.Equal(t, float64(pushTime.Unix()), pushTimeSeconds.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestPushProtobuf(t *testing.T) {
+	r, sink, _ := newTestReceiver(t)
+	mf := &dto.MetricFamily{
+		Name: proto.String("jobs_processed_total"),
+		Type: dto.MetricType_COUNTER.Enum(),
+		Metric: []*dto.Metric{{
+			Counter: &dto.Counter{Value: proto.Float64(7)},
+		}},
+	}
+	var body bytes.Buffer
+	format := expfmt.FmtProtoDelim
+	require.NoError(t, expfmt.NewEncoder(&body, format).Encode(mf))
+
+	rec := push(t, r, http.MethodPost, "/metrics/job/worker", string(format), body.Bytes())
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	metrics := collectMetrics(t, r, sink)
+	assert.Equal(t, 7.0, metrics["jobs_processed_total"].Sum().DataPoints().At(0).DoubleVal())
+}
+
+func TestPushReplaceAndDelete(t *testing.T) {
+	r, sink, _ := newTestReceiver(t)
+	require.Equal(t, http.StatusOK, push(t, r, http.MethodPut, "/metrics/job/backup", "", []byte("a 1\nb 2\n")).Code)
+
+	// POST only replaces the metrics with the same names.
+	require.Equal(t, http.StatusOK, push(t, r, http.MethodPost, "/metrics/job/backup", "", []byte("b 3\nc 4\n")).Code)
+	metrics := collectMetrics(t, r, sink)
+	require.Len(t, metrics, 4)
+	assert.Equal(t, 1.0, metrics["a"].Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 3.0, metrics["b"].Gauge().DataPoints().At(0).DoubleVal())
+	assert.Equal(t, 4.0, metrics["c"].Gauge().DataPoints().At(0).DoubleVal())
+
+	// PUT replaces all the metrics of the group.
+	require.Equal(t, http.StatusOK, push(t, r, http.MethodPut, "/metrics/job/backup", "", []byte("d 5\n")).Code)
+	metrics = collectMetrics(t, r, sink)
+	require.Len(t, metrics, 2)
+	assert.Contains(t, metrics, "d")
+
+	// Other groups are left untouched.
+	require.Equal(t, http.StatusOK, push(t, r, http.MethodPut, "/metrics/job/backup/instance/db-2", "", []byte("e 6\n")).Code)
+	r.collect(context.Background())
+	assert.Equal(t, 2, sink.AllMetrics()[len(sink.AllMetrics())-1].ResourceMetrics().Len())
+
+	require.Equal(t, http.StatusAccepted, push(t, r, http.MethodDelete, "/metrics/job/backup", "", nil).Code)
+	metrics = collectMetrics(t, r, sink)
+	assert.Contains(t, metrics, "e")
+	assert.NotContains(t, metrics, "d")
+
+	require.Equal(t, http.StatusAccepted, push(t, r, http.MethodDelete, "/metrics/job/backup/instance/db-2", "", nil).Code)
+	collectMetrics(t, r, sink)
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestGroupExpiration(t *testing.T) {
+	r, sink, now := newTestReceiver(t)
+	r.cfg.GroupExpiration = time.Hour
+	require.Equal(t, http.StatusOK, push(t, r, http.MethodPut, "/metrics/job/old", "", []byte("a 1\n")).Code)
+	*now = now.Add(30 * time.Minute)
+	require.Equal(t, http.StatusOK, push(t, r, http.MethodPut, "/metrics/job/new", "", []byte("b 1\n")).Code)
+
+	*now = now.Add(45 * time.Minute)
+	metrics := collectMetrics(t, r, sink)
+	assert.NotContains(t, metrics, "a")
+	assert.Contains(t, metrics, "b")
+}
+
+func TestPushInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+		err    string
+	}{
+		{
+			name:   "method",
+			method: http.MethodGet,
+			path:   "/metrics/job/backup",
+			code:   http.StatusMethodNotAllowed,
+			err:    "method not allowed",
+		},
+		{
+			name:   "grouping key",
+			method: http.MethodPut,
+			path:   "/metrics/instance/db-1",
+			code:   http.StatusBadRequest,
+			err:    "grouping key must start with the job label",
+		},
+		{
+			name:   "syntax",
+			method: http.MethodPut,
+			path:   "/metrics/job/backup",
+			body:   "a{ 1\n",
+			code:   http.StatusBadRequest,
+			err:    "failed to parse metrics",
+		},
+		{
+			name:   "timestamp",
+			method: http.MethodPut,
+			path:   "/metrics/job/backup",
+			body:   "a 1 1633359600000\n",
+			code:   http.StatusBadRequest,
+			err:    `metric family "a" must not have timestamps`,
+		},
+		{
+			name:   "histogram",
+			method: http.MethodPost,
+			path:   "/metrics/job/backup",
+			body:   "# TYPE h histogram\nh_bucket{le=\"1\"} 5\nh_bucket{le=\"2\"} 3\nh_bucket{le=\"+Inf\"} 5\nh_count 5\nh_sum 1\n",
+			code:   http.StatusBadRequest,
+			err:    `buckets of histogram "h" are not cumulative`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, sink, _ := newTestReceiver(t)
+			rec := push(t, r, test.method, test.path, "", []byte(test.body))
+			assert.Equal(t, test.code, rec.Code)
+			assert.True(t, strings.Contains(rec.Body.String(), test.err), rec.Body.String())
+			collectMetrics(t, r, sink)
+			assert.Empty(t, sink.AllMetrics())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package salesforcereceiver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+const (
+	intervalHourly = "Hourly"
+	intervalDaily  = "Daily"
+
+	// maxPageSize is the maximum number of records a SOQL query returns in
+	// a single response.
+	maxPageSize = 2000
+)
+
+var (
+	apiVersionRegexp = regexp.MustCompile(`^\d+\.\d+$`)
+	eventTypeRegexp  = regexp.MustCompile(`^[A-Za-z]+$`)
+)
+
+// Config defines configuration for the Salesforce receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	// HTTPClientSettings configures the client for the Salesforce APIs. Its
+	// endpoint is the login URL used to authenticate.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	pollingsaas.Settings          `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// ClientID and ClientSecret are the consumer key and secret of the
+	// connected app used to authenticate.
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// Username and Password are the credentials of the user the receiver
+	// authenticates as, with the OAuth 2.0 username-password flow. The
+	// password must be followed by the security token of the user, unless
+	// the IP address of the Collector is trusted.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// APIVersion is the version of the REST API.
+	APIVersion string `mapstructure:"api_version"`
+
+	// EventTypes restricts the event log files to receive to these event
+	// types. All event types are received if empty.
+	EventTypes []string `mapstructure:"event_types"`
+
+	// LogInterval selects the event log files to receive: Hourly or Daily.
+	LogInterval string `mapstructure:"log_interval"`
+
+	// InitialLookback is how far back to look for event log files the first
+	// time the receiver starts.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+
+	// PageSize is the number of event log files fetched per page.
+	PageSize int `mapstructure:"page_size"`
+
+	// MaxAPIUsage is the ratio of the daily API request limit of the
+	// organization above which the receiver stops paging until the next
+	// poll, to leave room for other API clients.
+	MaxAPIUsage float64 `mapstructure:"max_api_usage"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be non-empty")
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return errors.New("client_id and client_secret must be non-empty")
+	}
+	if cfg.Username == "" || cfg.Password == "" {
+		return errors.New("username and password must be non-empty")
+	}
+	if !apiVersionRegexp.MatchString(cfg.APIVersion) {
+		return fmt.Errorf("invalid api_version %q", cfg.APIVersion)
+	}
+	for _, eventType := range cfg.EventTypes {
+		if !eventTypeRegexp.MatchString(eventType) {
+			return fmt.Errorf("invalid event type %q", eventType)
+		}
+	}
+	if cfg.LogInterval != intervalHourly && cfg.LogInterval != intervalDaily {
+		return fmt.Errorf("log_interval must be %q or %q", intervalHourly, intervalDaily)
+	}
+	if cfg.InitialLookback <= 0 {
+		return errors.New("initial_lookback must be positive")
+	}
+	if cfg.PageSize <= 0 || cfg.PageSize > maxPageSize {
+		return fmt.Errorf("page_size must be between 1 and %d", maxPageSize)
+	}
+	if cfg.MaxAPIUsage <= 0 || cfg.MaxAPIUsage > 1 {
+		return errors.New("max_api_usage must be in (0, 1]")
+	}
+	return cfg.Settings.Validate()
+}
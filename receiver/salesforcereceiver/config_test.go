@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package salesforcereceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.ClientID = "consumer-key"
+	defaultCfg.ClientSecret = "consumer-secret"
+	defaultCfg.Username = "audit@example.com"
+	defaultCfg.Password = "passwordTOKEN"
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewComponentID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "customized")),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "https://test.salesforce.com",
+			Timeout:  10 * time.Second,
+		},
+		Settings: pollingsaas.Settings{
+			PollInterval:      15 * time.Minute,
+			Storage:           "file_storage",
+			RequestsPerSecond: 1,
+			MaxRetries:        5,
+		},
+		ClientID:        "consumer-key",
+		ClientSecret:    "consumer-secret",
+		Username:        "audit@example.com",
+		Password:        "passwordTOKEN",
+		APIVersion:      "52.0",
+		EventTypes:      []string{"Login", "API", "Report"},
+		LogInterval:     intervalDaily,
+		InitialLookback: 72 * time.Hour,
+		PageSize:        5,
+		MaxAPIUsage:     0.5,
+	}, cfg.Receivers[config.NewComponentIDWithName(typeStr, "customized")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "endpoint must be non-empty",
+		},
+		{
+			name:   "no client secret",
+			modify: func(cfg *Config) { cfg.ClientSecret = "" },
+			err:    "client_id and client_secret must be non-empty",
+		},
+		{
+			name:   "no password",
+			modify: func(cfg *Config) { cfg.Password = "" },
+			err:    "username and password must be non-empty",
+		},
+		{
+			name:   "invalid api version",
+			modify: func(cfg *Config) { cfg.APIVersion = "v53" },
+			err:    `invalid api_version "v53"`,
+		},
+		{
+			name:   "invalid event type",
+			modify: func(cfg *Config) { cfg.EventTypes = []string{"Login') OR ('1'='1"} },
+			err:    `invalid event type "Login') OR ('1'='1"`,
+		},
+		{
+			name:   "invalid log interval",
+			modify: func(cfg *Config) { cfg.LogInterval = "Weekly" },
+			err:    `log_interval must be "Hourly" or "Daily"`,
+		},
+		{
+			name:   "no initial lookback",
+			modify: func(cfg *Config) { cfg.InitialLookback = 0 },
+			err:    "initial_lookback must be positive",
+		},
+		{
+			name:   "page size too large",
+			modify: func(cfg *Config) { cfg.PageSize = 5000 },
+			err:    "page_size must be between 1 and 2000",
+		},
+		{
+			name:   "invalid max api usage",
+			modify: func(cfg *Config) { cfg.MaxAPIUsage = 1.5 },
+			err:    "max_api_usage must be in (0, 1]",
+		},
+		{
+			name:   "invalid polling settings",
+			modify: func(cfg *Config) { cfg.PollInterval = 0 },
+			err:    "poll_interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig("https://login.salesforce.com")
+			require.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func testConfig(endpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.ClientID = "consumer-key"
+	cfg.ClientSecret = "consumer-secret"
+	cfg.Username = "audit@example.com"
+	cfg.Password = "passwordTOKEN"
+	return cfg
+}
@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package salesforcereceiver
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+const (
+	// Layout of the datetime fields of the REST API.
+	apiTimeLayout = "2006-01-02T15:04:05.000-0700"
+	// Layout of datetime literals in SOQL queries.
+	soqlTimeLayout = "2006-01-02T15:04:05.000Z"
+	// Layout of the TIMESTAMP column of event log files.
+	logTimestampLayout = "20060102150405.000"
+
+	attributePrefix         = "salesforce."
+	attributeInstanceURL    = "salesforce.instance_url"
+	attributeEventLogFileID = "salesforce.event_log_file.id"
+	attributeEventType      = "salesforce.event_type"
+)
+
+// eventLogFileSource polls the EventLogFile objects of an organization and
+// turns each row of their CSV content into a log record.
+type eventLogFileSource struct {
+	cfg     *Config
+	session *session
+	logger  *zap.Logger
+	now     func() time.Time
+}
+
+var _ pollingsaas.Source = (*eventLogFileSource)(nil)
+
+func newEventLogFileSource(cfg *Config, session *session, logger *zap.Logger) *eventLogFileSource {
+	return &eventLogFileSource{
+		cfg:     cfg,
+		session: session,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// cursor is the position of the last event log file received. Files are
+// ordered by creation date then ID, since several files can be created at
+// the same time.
+type cursor struct {
+	createdDate time.Time
+	id          string
+}
+
+func parseCursor(s string) (cursor, error) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) != 2 {
+		return cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	t, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return cursor{}, fmt.Errorf("invalid cursor %q: %w", s, err)
+	}
+	return cursor{createdDate: t, id: parts[1]}, nil
+}
+
+func (c cursor) String() string {
+	return c.createdDate.UTC().Format(time.RFC3339Nano) + "," + c.id
+}
+
+type queryResponse struct {
+	Records []eventLogFile `json:"records"`
+}
+
+type eventLogFile struct {
+	ID          string `json:"Id"`
+	EventType   string `json:"EventType"`
+	LogFile     string `json:"LogFile"`
+	CreatedDate string `json:"CreatedDate"`
+}
+
+// query returns the SOQL query selecting the page of event log files
+// following the cursor.
+func (s *eventLogFileSource) query(c cursor) string {
+	date := c.createdDate.UTC().Format(soqlTimeLayout)
+	conditions := []string{fmt.Sprintf("Interval = '%s'", s.cfg.LogInterval)}
+	if c.id == "" {
+		conditions = append(conditions, "CreatedDate >= "+date)
+	} else {
+		conditions = append(conditions, fmt.Sprintf("(CreatedDate > %s OR (CreatedDate = %s AND Id > '%s'))", date, date, c.id))
+	}
+	if len(s.cfg.EventTypes) > 0 {
+		conditions = append(conditions, fmt.Sprintf("EventType IN ('%s')", strings.Join(s.cfg.EventTypes, "','")))
+	}
+	return fmt.Sprintf("SELECT Id, EventType, LogFile, CreatedDate FROM EventLogFile WHERE %s ORDER BY CreatedDate, Id LIMIT %d",
+		strings.Join(conditions, " AND "), s.cfg.PageSize)
+}
+
+// Poll implements pollingsaas.Source.
+func (s *eventLogFileSource) Poll(ctx context.Context, client *pollingsaas.Client, cursorValue string) (pollingsaas.Page, error) {
+	c := cursor{createdDate: s.now().Add(-s.cfg.InitialLookback)}
+	if cursorValue != "" {
+		var err error
+		if c, err = parseCursor(cursorValue); err != nil {
+			return pollingsaas.Page{}, err
+		}
+	}
+
+	_, instanceURL, err := s.session.login(ctx, client.HTTPClient())
+	if err != nil {
+		return pollingsaas.Page{}, err
+	}
+
+	queryURL := fmt.Sprintf("%s/services/data/v%s/query?q=%s", instanceURL, s.cfg.APIVersion, url.QueryEscape(s.query(c)))
+	resp, err := s.get(ctx, client, queryURL)
+	if err != nil {
+		return pollingsaas.Page{}, fmt.Errorf("failed to query event log files: %w", err)
+	}
+	var qr queryResponse
+	err = json.NewDecoder(resp.Body).Decode(&qr)
+	resp.Body.Close()
+	if err != nil {
+		return pollingsaas.Page{}, fmt.Errorf("failed to decode query response: %w", err)
+	}
+	overLimit := s.overAPILimit(resp.Header)
+
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(attributeInstanceURL, instanceURL)
+	records := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, file := range qr.Records {
+		createdDate, err := time.Parse(apiTimeLayout, file.CreatedDate)
+		if err != nil {
+			return pollingsaas.Page{}, fmt.Errorf("invalid creation date of event log file %s: %w", file.ID, err)
+		}
+		resp, err := s.get(ctx, client, instanceURL+file.LogFile)
+		if err != nil {
+			return pollingsaas.Page{}, fmt.Errorf("failed to download event log file %s: %w", file.ID, err)
+		}
+		if resp.Header.Get("Sforce-Limit-Info") != "" {
+			overLimit = s.overAPILimit(resp.Header) || overLimit
+		}
+		err = appendLogRecords(records, file, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return pollingsaas.Page{}, fmt.Errorf("failed to parse event log file %s: %w", file.ID, err)
+		}
+		c = cursor{createdDate: createdDate, id: file.ID}
+	}
+
+	if overLimit {
+		s.logger.Warn("API usage is over max_api_usage, waiting for the next poll")
+	}
+	page := pollingsaas.Page{
+		Logs:    logs,
+		HasMore: len(qr.Records) == s.cfg.PageSize && !overLimit,
+	}
+	if c.id != "" {
+		page.Cursor = c.String()
+	} else {
+		page.Cursor = cursorValue
+	}
+	return page, nil
+}
+
+func (s *eventLogFileSource) get(ctx context.Context, client *pollingsaas.Client, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// overAPILimit reports whether the API usage returned in the
+// Sforce-Limit-Info header, formatted as "api-usage=used/limit", is over
+// max_api_usage.
+func (s *eventLogFileSource) overAPILimit(header http.Header) bool {
+	for _, part := range strings.Split(header.Get("Sforce-Limit-Info"), ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 || kv[0] != "api-usage" {
+			continue
+		}
+		usage := strings.SplitN(kv[1], "/", 2)
+		if len(usage) != 2 {
+			return false
+		}
+		used, err1 := strconv.ParseFloat(usage[0], 64)
+		limit, err2 := strconv.ParseFloat(usage[1], 64)
+		if err1 != nil || err2 != nil || limit <= 0 {
+			return false
+		}
+		return used/limit >= s.cfg.MaxAPIUsage
+	}
+	return false
+}
+
+// appendLogRecords parses the CSV content of an event log file and appends
+// a log record per row. Columns are added as attributes, named after the
+// lower case column name with the salesforce. prefix.
+func appendLogRecords(records pdata.LogSlice, file eventLogFile, content io.Reader) error {
+	r := csv.NewReader(content)
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		lr := records.AppendEmpty()
+		lr.Body().SetStringVal(file.EventType)
+		attrs := lr.Attributes()
+		attrs.InsertString(attributeEventLogFileID, file.ID)
+		attrs.InsertString(attributeEventType, file.EventType)
+		for i, column := range header {
+			if i >= len(row) || row[i] == "" {
+				continue
+			}
+			switch column {
+			case "EVENT_TYPE":
+				continue
+			case "TIMESTAMP_DERIVED":
+				if t, err := time.Parse(time.RFC3339Nano, row[i]); err == nil {
+					lr.SetTimestamp(pdata.NewTimestampFromTime(t))
+				}
+			case "TIMESTAMP":
+				if lr.Timestamp() == 0 {
+					if t, err := time.Parse(logTimestampLayout, row[i]); err == nil {
+						lr.SetTimestamp(pdata.NewTimestampFromTime(t))
+					}
+				}
+			}
+			attrs.InsertString(attributePrefix+strings.ToLower(column), row[i])
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package salesforcereceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+// fakeSalesforce serves the token, query and event log file endpoints.
+type fakeSalesforce struct {
+	t   *testing.T
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	queries  []string
+	logins   int
+	apiUsage string
+	records  string
+}
+
+func newFakeSalesforce(t *testing.T) *fakeSalesforce {
+	f := &fakeSalesforce{t: t, apiUsage: "api-usage=10/1000"}
+	content, err := ioutil.ReadFile("testdata/login.csv")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("password") != "passwordTOKEN" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"authentication failure"}`))
+			return
+		}
+		assert.Equal(t, "password", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "consumer-key", r.PostForm.Get("client_id"))
+		f.mu.Lock()
+		f.logins++
+		f.mu.Unlock()
+		_, _ = w.Write([]byte(`{"access_token":"token","instance_url":"` + f.srv.URL + `","token_type":"Bearer"}`))
+	})
+	mux.HandleFunc("/services/data/v53.0/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.queries = append(f.queries, r.URL.Query().Get("q"))
+		w.Header().Set("Sforce-Limit-Info", f.apiUsage)
+		_, _ = w.Write([]byte(f.records))
+	})
+	mux.HandleFunc("/services/data/v53.0/sobjects/EventLogFile/0AT1/LogFile", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_, _ = w.Write(content)
+	})
+	f.srv = httptest.NewServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+const oneFile = `{"totalSize":1,"done":true,"records":[{"attributes":{"type":"EventLogFile"},"Id":"0AT1","EventType":"Login",
+"LogFile":"/services/data/v53.0/sobjects/EventLogFile/0AT1/LogFile","CreatedDate":"2021-10-05T11:02:13.000+0000"}]}`
+
+func newTestSource(t *testing.T, f *fakeSalesforce, modify func(*Config)) (*eventLogFileSource, *pollingsaas.Client) {
+	cfg := testConfig(f.srv.URL)
+	cfg.RequestsPerSecond = 1000
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	s := newSession(cfg)
+	source := newEventLogFileSource(cfg, s, zap.NewNop())
+	source.now = func() time.Time { return time.Date(2021, 10, 5, 12, 0, 0, 0, time.UTC) }
+	return source, pollingsaas.NewClient(http.DefaultClient, s, cfg.Settings)
+}
+
+func TestPollEventLogFiles(t *testing.T) {
+	f := newFakeSalesforce(t)
+	f.records = oneFile
+	source, client := newTestSource(t, f, func(cfg *Config) {
+		cfg.PageSize = 1
+		cfg.EventTypes = []string{"Login", "Logout"}
+	})
+
+	page, err := source.Poll(context.Background(), client, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2021-10-05T11:02:13Z,0AT1", page.Cursor)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, []string{"SELECT Id, EventType, LogFile, CreatedDate FROM EventLogFile WHERE Interval = 'Hourly' AND " +
+		"CreatedDate >= 2021-10-04T12:00:00.000Z AND EventType IN ('Login','Logout') ORDER BY CreatedDate, Id LIMIT 1"}, f.queries)
+
+	require.Equal(t, 2, page.Logs.LogRecordCount())
+	rl := page.Logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{attributeInstanceURL: f.srv.URL}, rl.Resource().Attributes().AsRaw())
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "Login", lr.Body().StringVal())
+	assert.Equal(t, time.Date(2021, 10, 5, 10, 15, 2, 123000000, time.UTC), lr.Timestamp().AsTime())
+	assert.Equal(t, map[string]interface{}{
+		"salesforce.event_log_file.id": "0AT1",
+		"salesforce.event_type":        "Login",
+		"salesforce.timestamp":         "20211005101502.123",
+		"salesforce.request_id":        "4exLFFQZ1234",
+		"salesforce.organization_id":   "00D5e000000abcd",
+		"salesforce.user_id":           "0055e000001AbCd",
+		"salesforce.uri":               "/index.jsp",
+		"salesforce.login_status":      "LOGIN_NO_ERROR",
+		"salesforce.client_ip":         "203.0.113.10",
+		"salesforce.timestamp_derived": "2021-10-05T10:15:02.123Z",
+		"salesforce.user_name":         "alice@example.com",
+	}, lr.Attributes().AsRaw())
+	// Empty columns are omitted.
+	_, ok := rl.InstrumentationLibraryLogs().At(0).Logs().At(1).Attributes().Get("salesforce.user_name")
+	assert.False(t, ok)
+
+	f.records = `{"totalSize":0,"done":true,"records":[]}`
+	page, err = source.Poll(context.Background(), client, page.Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, "2021-10-05T11:02:13Z,0AT1", page.Cursor)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, 0, page.Logs.LogRecordCount())
+	assert.Equal(t, "SELECT Id, EventType, LogFile, CreatedDate FROM EventLogFile WHERE Interval = 'Hourly' AND "+
+		"(CreatedDate > 2021-10-05T11:02:13.000Z OR (CreatedDate = 2021-10-05T11:02:13.000Z AND Id > '0AT1')) "+
+		"AND EventType IN ('Login','Logout') ORDER BY CreatedDate, Id LIMIT 1", f.queries[1])
+	assert.Equal(t, 1, f.logins)
+}
+
+func TestPollStopsPagingOverAPIUsage(t *testing.T) {
+	f := newFakeSalesforce(t)
+	f.records = oneFile
+	f.apiUsage = "api-usage=900/1000"
+	source, client := newTestSource(t, f, func(cfg *Config) { cfg.PageSize = 1 })
+
+	page, err := source.Poll(context.Background(), client, "")
+	require.NoError(t, err)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, 2, page.Logs.LogRecordCount())
+}
+
+func TestPollErrors(t *testing.T) {
+	f := newFakeSalesforce(t)
+	source, client := newTestSource(t, f, nil)
+
+	_, err := source.Poll(context.Background(), client, "not a cursor")
+	assert.EqualError(t, err, `invalid cursor "not a cursor"`)
+
+	f.records = `{"records":[{"Id":"0AT2","EventType":"Login","LogFile":"/missing","CreatedDate":"2021-10-05T11:02:13.000+0000"}]}`
+	_, err = source.Poll(context.Background(), client, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to download event log file 0AT2")
+
+	f.records = `not json`
+	_, err = source.Poll(context.Background(), client, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode query response")
+
+	source, client = newTestSource(t, f, func(cfg *Config) { cfg.Password = "wrong" })
+	_, err = source.Poll(context.Background(), client, "")
+	assert.EqualError(t, err, "login failed: invalid_grant: authentication failure")
+}
+
+func TestReceiver(t *testing.T) {
+	f := newFakeSalesforce(t)
+	f.records = oneFile
+	cfg := testConfig(f.srv.URL)
+	sink := new(consumertest.LogsSink)
+
+	r, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// Only one page is fetched since it isn't full.
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assert.Len(t, f.queries, 1)
+}
+
+func TestAppendLogRecordsWithoutDerivedTimestamp(t *testing.T) {
+	records := pdata.NewLogSlice()
+	content := "\"EVENT_TYPE\",\"TIMESTAMP\"\n\"API\",\"20211005101502.123\"\n"
+	require.NoError(t, appendLogRecords(records, eventLogFile{ID: "0AT3", EventType: "API"}, strings.NewReader(content)))
+	require.Equal(t, 1, records.Len())
+	assert.Equal(t, time.Date(2021, 10, 5, 10, 15, 2, 123000000, time.UTC), records.At(0).Timestamp().AsTime())
+
+	records = pdata.NewLogSlice()
+	assert.NoError(t, appendLogRecords(records, eventLogFile{}, strings.NewReader("")))
+	assert.Equal(t, 0, records.Len())
+}
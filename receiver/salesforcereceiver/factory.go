@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package salesforcereceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "salesforce"
+
+	defaultEndpoint        = "https://login.salesforce.com"
+	defaultTimeout         = 30 * time.Second
+	defaultAPIVersion      = "53.0"
+	defaultInitialLookback = 24 * time.Hour
+	defaultPageSize        = 20
+	defaultMaxAPIUsage     = 0.8
+)
+
+// NewFactory creates a factory for the Salesforce receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: defaultEndpoint,
+			Timeout:  defaultTimeout,
+		},
+		Settings:        pollingsaas.NewDefaultSettings(),
+		APIVersion:      defaultAPIVersion,
+		LogInterval:     intervalHourly,
+		InitialLookback: defaultInitialLookback,
+		PageSize:        defaultPageSize,
+		MaxAPIUsage:     defaultMaxAPIUsage,
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	rCfg := cfg.(*Config)
+	auth := newSession(rCfg)
+	source := newEventLogFileSource(rCfg, auth, params.Logger)
+	return pollingsaas.NewReceiver(rCfg.ID(), typeStr, rCfg.Settings, rCfg.HTTPClientSettings, auth, source, consumer, params), nil
+}
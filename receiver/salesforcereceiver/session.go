@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package salesforcereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// session authenticates with the OAuth 2.0 username-password flow and
+// caches the access token until the API rejects it.
+type session struct {
+	cfg *Config
+
+	mu          sync.Mutex
+	accessToken string
+	instanceURL string
+}
+
+func newSession(cfg *Config) *session {
+	return &session{cfg: cfg}
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	InstanceURL string `json:"instance_url"`
+}
+
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Authorize implements pollingsaas.Authenticator.
+func (s *session) Authorize(ctx context.Context, client *http.Client, req *http.Request) error {
+	token, _, err := s.login(ctx, client)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Invalidate implements pollingsaas.Authenticator.
+func (s *session) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessToken = ""
+}
+
+// login returns the access token and the URL of the instance of the
+// organization, requesting a new token if none is cached.
+func (s *session) login(ctx context.Context, client *http.Client) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.accessToken != "" {
+		return s.accessToken, s.instanceURL, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"password"},
+		"client_id":     {s.cfg.ClientID},
+		"client_secret": {s.cfg.ClientSecret},
+		"username":      {s.cfg.Username},
+		"password":      {s.cfg.Password},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(s.cfg.Endpoint, "/")+"/services/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp tokenErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+			return "", "", fmt.Errorf("login failed: %s: %s", errResp.Error, errResp.ErrorDescription)
+		}
+		return "", "", fmt.Errorf("login failed with status %d", resp.StatusCode)
+	}
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" || tokenResp.InstanceURL == "" {
+		return "", "", fmt.Errorf("token response is missing the access token or instance URL")
+	}
+	s.accessToken = tokenResp.AccessToken
+	s.instanceURL = strings.TrimSuffix(tokenResp.InstanceURL, "/")
+	return s.accessToken, s.instanceURL, nil
+}
@@ -16,6 +16,9 @@ package scraperhelper
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenthelper"
@@ -23,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/multierr"
 )
 
 // ScrapeMetrics scrapes metrics.
@@ -31,6 +35,9 @@ type ScrapeMetrics func(context.Context) (pdata.MetricSlice, error)
 // ScrapeResourceMetrics scrapes resource metrics.
 type ScrapeResourceMetrics func(context.Context) (pdata.ResourceMetricsSlice, error)
 
+// ScrapeTarget scrapes the resource metrics of one target.
+type ScrapeTarget func(ctx context.Context, target string) (pdata.ResourceMetricsSlice, error)
+
 type baseSettings struct {
 	componentOptions []componenthelper.Option
 }
@@ -47,6 +54,20 @@ type Scraper interface {
 	Scrape(context.Context, config.ComponentID) (pdata.Metrics, error)
 }
 
+// TargetsScraper is a Scraper collecting several targets, e.g. the endpoints
+// of a receiver configured with many of them. With a collection splay or a
+// scrape timeout, the scraper controller spreads the targets of the scraper
+// across the splay like other scrapers, and bounds the scrape of each target
+// with the timeout, so that a slow target only loses its own data.
+type TargetsScraper interface {
+	Scraper
+
+	// Targets returns the targets of the scraper.
+	Targets() []string
+	// ScrapeTarget scrapes one of the targets.
+	ScrapeTarget(ctx context.Context, receiverID config.ComponentID, target string) (pdata.Metrics, error)
+}
+
 type baseScraper struct {
 	component.Component
 	id config.ComponentID
@@ -163,3 +184,104 @@ func (rms resourceMetricsScraper) Scrape(ctx context.Context, receiverID config.
 	scrp.EndMetricsOp(ctx, count, err)
 	return md, err
 }
+
+type targetsScraper struct {
+	baseScraper
+	targets []string
+	scrape  ScrapeTarget
+}
+
+var _ TargetsScraper = (*targetsScraper)(nil)
+
+// NewTargetsScraper creates a TargetsScraper calling scrape for each of the
+// targets, which must be unique. Without collection splay nor scrape timeout,
+// the targets are scraped concurrently, so scrape must be safe for concurrent
+// use in any case.
+func NewTargetsScraper(
+	id config.ComponentID,
+	targets []string,
+	scrape ScrapeTarget,
+	options ...ScraperOption,
+) TargetsScraper {
+	set := &baseSettings{}
+	for _, op := range options {
+		op(set)
+	}
+
+	return &targetsScraper{
+		baseScraper: baseScraper{
+			Component: componenthelper.New(set.componentOptions...),
+			id:        id,
+		},
+		targets: targets,
+		scrape:  scrape,
+	}
+}
+
+func (ts *targetsScraper) Targets() []string {
+	return ts.targets
+}
+
+// Scrape scrapes all the targets concurrently, and returns a partial scrape
+// error when only some of them fail.
+func (ts *targetsScraper) Scrape(ctx context.Context, receiverID config.ComponentID) (pdata.Metrics, error) {
+	scrp := obsreport.NewScraper(obsreport.ScraperSettings{ReceiverID: receiverID, Scraper: ts.ID()})
+	ctx = scrp.StartMetricsOp(ctx)
+
+	results := make([]pdata.ResourceMetricsSlice, len(ts.targets))
+	errs := make([]error, len(ts.targets))
+	var wg sync.WaitGroup
+	for i, target := range ts.targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			results[i], errs[i] = ts.scrape(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	md := pdata.NewMetrics()
+	var err error
+	failedTargets, failedMetrics := 0, 0
+	for i, target := range ts.targets {
+		if errs[i] != nil {
+			err = multierr.Append(err, fmt.Errorf("target %q: %w", target, errs[i]))
+			var partialErr scrapererror.PartialScrapeError
+			if !errors.As(errs[i], &partialErr) {
+				failedTargets++
+				continue
+			}
+			failedMetrics += partialErr.Failed
+		}
+		results[i].MoveAndAppendTo(md.ResourceMetrics())
+	}
+
+	count := md.MetricCount()
+	switch {
+	case err == nil:
+	case failedTargets == len(ts.targets):
+		md, count = pdata.Metrics{}, 0
+	default:
+		err = scrapererror.NewPartialScrapeError(err, failedMetrics)
+	}
+
+	scrp.EndMetricsOp(ctx, count, err)
+	return md, err
+}
+
+func (ts *targetsScraper) ScrapeTarget(ctx context.Context, receiverID config.ComponentID, target string) (pdata.Metrics, error) {
+	scrp := obsreport.NewScraper(obsreport.ScraperSettings{ReceiverID: receiverID, Scraper: ts.ID()})
+	ctx = scrp.StartMetricsOp(ctx)
+	resourceMetrics, err := ts.scrape(ctx, target)
+
+	count := 0
+	md := pdata.Metrics{}
+	if err == nil || scrapererror.IsPartialScrapeError(err) {
+		md = pdata.NewMetrics()
+		resourceMetrics.MoveAndAppendTo(md.ResourceMetrics())
+		count = md.MetricCount()
+	}
+
+	scrp.EndMetricsOp(ctx, count, err)
+	return md, err
+}
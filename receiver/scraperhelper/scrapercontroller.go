@@ -17,6 +17,8 @@ package scraperhelper
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -36,6 +38,16 @@ import (
 type ScraperControllerSettings struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	CollectionInterval      time.Duration            `mapstructure:"collection_interval"`
+	// CollectionSplay spreads the start of the scrapers added to the receiver,
+	// and of each target of TargetsScrapers, evenly across this duration, so
+	// targets are not all contacted at the same instant. It must be less than
+	// the collection interval. Zero disables it.
+	CollectionSplay time.Duration `mapstructure:"collection_splay"`
+	// ScrapeTimeout bounds each individual scrape of a scraper, or of a target
+	// of a TargetsScraper. When it is set, or when a splay is configured,
+	// scrapers and targets run independently so a slow target only loses its
+	// own data for that interval. Zero disables it.
+	ScrapeTimeout time.Duration `mapstructure:"scrape_timeout"`
 }
 
 // DefaultScraperControllerSettings returns default scraper controller
@@ -74,9 +86,14 @@ type controller struct {
 	id                 config.ComponentID
 	logger             *zap.Logger
 	collectionInterval time.Duration
+	collectionSplay    time.Duration
+	scrapeTimeout      time.Duration
 	nextConsumer       consumer.Metrics
 
 	scrapers []Scraper
+	// jobs are the scrapers, with a job per target of TargetsScrapers, that
+	// are scraped independently with a collection splay or a scrape timeout.
+	jobs []*scrapeJob
 
 	tickerCh <-chan time.Time
 
@@ -102,10 +119,20 @@ func NewScraperControllerReceiver(
 		return nil, errors.New("collection_interval must be a positive duration")
 	}
 
+	if cfg.CollectionSplay < 0 || cfg.CollectionSplay >= cfg.CollectionInterval {
+		return nil, errors.New("collection_splay must be a non-negative duration less than collection_interval")
+	}
+
+	if cfg.ScrapeTimeout < 0 {
+		return nil, errors.New("scrape_timeout must be a non-negative duration")
+	}
+
 	sc := &controller{
 		id:                 cfg.ID(),
 		logger:             logger,
 		collectionInterval: cfg.CollectionInterval,
+		collectionSplay:    cfg.CollectionSplay,
+		scrapeTimeout:      cfg.ScrapeTimeout,
 		nextConsumer:       nextConsumer,
 		done:               make(chan struct{}),
 		terminated:         make(chan struct{}),
@@ -127,6 +154,19 @@ func (sc *controller) Start(ctx context.Context, host component.Host) error {
 		}
 	}
 
+	// Targets are only known once the scrapers are started.
+	sc.jobs = nil
+	for _, scraper := range sc.scrapers {
+		ts, ok := scraper.(TargetsScraper)
+		if !ok {
+			sc.jobs = append(sc.jobs, &scrapeJob{scraper: scraper})
+			continue
+		}
+		for _, target := range ts.Targets() {
+			sc.jobs = append(sc.jobs, &scrapeJob{scraper: scraper, targets: ts, target: target})
+		}
+	}
+
 	sc.initialized = true
 	sc.startScraping()
 	return nil
@@ -178,16 +218,17 @@ func (sc *controller) startScraping() {
 func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	metrics := pdata.NewMetrics()
 
-	for _, scraper := range sc.scrapers {
-		md, err := scraper.Scrape(ctx, sc.id)
-		if err != nil {
-			sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
-
-			if !scrapererror.IsPartialScrapeError(err) {
-				continue
+	if sc.collectionSplay == 0 && sc.scrapeTimeout == 0 {
+		for _, scraper := range sc.scrapers {
+			md, err := scraper.Scrape(ctx, sc.id)
+			if sc.keepScraped(err, zap.Stringer("scraper", scraper.ID())) {
+				md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
 			}
 		}
-		md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+	} else {
+		for _, md := range sc.scrapeIndependently(ctx) {
+			md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+		}
 	}
 
 	dataPointCount := metrics.DataPointCount()
@@ -196,6 +237,124 @@ func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
 }
 
+// keepScraped logs scrape errors and reports whether the scraped metrics
+// should be forwarded.
+func (sc *controller) keepScraped(err error, fields ...zap.Field) bool {
+	if err == nil {
+		return true
+	}
+	sc.logger.Error("Error scraping metrics", append(fields, zap.Error(err))...)
+	return scrapererror.IsPartialScrapeError(err)
+}
+
+// scrapeJob is a scraper, or one of the targets of a TargetsScraper.
+type scrapeJob struct {
+	scraper Scraper
+	// targets is the scraper of the target, nil if the job is a scraper.
+	targets TargetsScraper
+	target  string
+	// inFlight is set while a scrape abandoned after its timeout is still
+	// running, so that the job is never scraped concurrently with itself.
+	inFlight int32
+}
+
+func (j *scrapeJob) scrape(ctx context.Context, receiverID config.ComponentID) (pdata.Metrics, error) {
+	if j.targets != nil {
+		return j.targets.ScrapeTarget(ctx, receiverID, j.target)
+	}
+	return j.scraper.Scrape(ctx, receiverID)
+}
+
+// logFields identifies the job in logs.
+func (j *scrapeJob) logFields() []zap.Field {
+	fields := []zap.Field{zap.Stringer("scraper", j.scraper.ID())}
+	if j.targets != nil {
+		fields = append(fields, zap.String("target", j.target))
+	}
+	return fields
+}
+
+// scrapeIndependently runs every job in its own goroutine, delayed by its
+// share of the collection splay and bounded by the scrape timeout. The
+// returned slice preserves the order of the jobs and only contains the
+// results that should be forwarded.
+func (sc *controller) scrapeIndependently(ctx context.Context) []pdata.Metrics {
+	results := make([]pdata.Metrics, len(sc.jobs))
+	keep := make([]bool, len(sc.jobs))
+
+	var wg sync.WaitGroup
+	for i := range sc.jobs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], keep[i] = sc.scrapeOne(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	kept := make([]pdata.Metrics, 0, len(results))
+	for i, md := range results {
+		if keep[i] {
+			kept = append(kept, md)
+		}
+	}
+	return kept
+}
+
+func (sc *controller) scrapeOne(ctx context.Context, idx int) (pdata.Metrics, bool) {
+	job := sc.jobs[idx]
+
+	if offset := sc.splayOffset(idx); offset > 0 {
+		timer := time.NewTimer(offset)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-sc.done:
+			return pdata.Metrics{}, false
+		}
+	}
+
+	if !atomic.CompareAndSwapInt32(&job.inFlight, 0, 1) {
+		sc.logger.Warn("Skipping scrape, previous scrape has not completed yet", job.logFields()...)
+		return pdata.Metrics{}, false
+	}
+
+	if sc.scrapeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sc.scrapeTimeout)
+		defer cancel()
+	}
+
+	type result struct {
+		md  pdata.Metrics
+		err error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		defer atomic.StoreInt32(&job.inFlight, 0)
+		md, err := job.scrape(ctx, sc.id)
+		resultCh <- result{md: md, err: err}
+	}()
+
+	select {
+	case res := <-resultCh:
+		return res.md, sc.keepScraped(res.err, job.logFields()...)
+	case <-ctx.Done():
+		// The scraper did not honor the deadline; drop whatever it returns.
+		sc.keepScraped(ctx.Err(), job.logFields()...)
+		return pdata.Metrics{}, false
+	}
+}
+
+// splayOffset returns the delay of the job at idx within the collection
+// splay, spreading the scrapers and targets evenly across it.
+func (sc *controller) splayOffset(idx int) time.Duration {
+	if sc.collectionSplay == 0 || len(sc.jobs) < 2 {
+		return 0
+	}
+	return sc.collectionSplay * time.Duration(idx) / time.Duration(len(sc.jobs))
+}
+
 // stopScraping stops the ticker
 func (sc *controller) stopScraping() {
 	close(sc.done)
@@ -17,6 +17,7 @@ package scraperhelper
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -132,6 +133,35 @@ func TestScrapeController(t *testing.T) {
 			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: -time.Millisecond},
 			expectedNewErr:            "collection_interval must be a positive duration",
 		},
+		{
+			name:     "AddMetricsScrapers_InvalidCollectionSplayError",
+			scrapers: 2,
+			scraperControllerSettings: &ScraperControllerSettings{
+				CollectionInterval: time.Second,
+				CollectionSplay:    time.Second,
+			},
+			expectedNewErr: "collection_splay must be a non-negative duration less than collection_interval",
+		},
+		{
+			name:     "AddMetricsScrapers_InvalidScrapeTimeoutError",
+			scrapers: 2,
+			scraperControllerSettings: &ScraperControllerSettings{
+				CollectionInterval: time.Second,
+				ScrapeTimeout:      -time.Second,
+			},
+			expectedNewErr: "scrape_timeout must be a non-negative duration",
+		},
+		{
+			name:     "AddMetricsScrapersWithSplayAndTimeout",
+			scrapers: 2,
+			scraperControllerSettings: &ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID("receiver")),
+				CollectionInterval: time.Minute,
+				CollectionSplay:    10 * time.Millisecond,
+				ScrapeTimeout:      time.Second,
+			},
+			expectScraped: true,
+		},
 		{
 			name:      "AddMetricsScrapers_ScrapeError",
 			scrapers:  2,
@@ -452,3 +482,206 @@ func TestSingleScrapePerTick(t *testing.T) {
 		return
 	}
 }
+
+func TestScrapeTimeoutDropsSlowScraper(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	// The slow scraper ignores its context, so the controller must stop waiting on its own.
+	slow := func(context.Context) (pdata.MetricSlice, error) {
+		<-release
+		return singleMetric(), nil
+	}
+	fast := func(context.Context) (pdata.MetricSlice, error) {
+		return singleMetric(), nil
+	}
+
+	cfg := DefaultScraperControllerSettings("receiver")
+	cfg.ScrapeTimeout = 20 * time.Millisecond
+
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddScraper(NewMetricsScraper("slow", slow)),
+		AddScraper(NewMetricsScraper("fast", fast)),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 1, sink.DataPointCount())
+
+	// The abandoned scrape is still running, so the slow scraper is skipped rather than called again.
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 2, sink.DataPointCount())
+
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+func TestCollectionSplay(t *testing.T) {
+	var mu sync.Mutex
+	started := map[string]time.Time{}
+	scrape := func(name string) ScrapeMetrics {
+		return func(context.Context) (pdata.MetricSlice, error) {
+			mu.Lock()
+			started[name] = time.Now()
+			mu.Unlock()
+			return singleMetric(), nil
+		}
+	}
+
+	cfg := DefaultScraperControllerSettings("receiver")
+	cfg.CollectionSplay = 200 * time.Millisecond
+
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddScraper(NewMetricsScraper("first", scrape("first"))),
+		AddScraper(NewMetricsScraper("second", scrape("second"))),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() == 2
+	}, 2*time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(t, started["second"].Sub(started["first"]), 90*time.Millisecond)
+
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+func TestScrapeTimeoutDropsSlowTarget(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var mu sync.Mutex
+	calls := map[string]int{}
+	// The slow target ignores its context, so the controller must stop waiting on its own.
+	scrape := func(_ context.Context, target string) (pdata.ResourceMetricsSlice, error) {
+		mu.Lock()
+		calls[target]++
+		mu.Unlock()
+		if target == "slow" {
+			<-release
+		}
+		return singleResourceMetric(), nil
+	}
+
+	cfg := DefaultScraperControllerSettings("receiver")
+	cfg.ScrapeTimeout = 20 * time.Millisecond
+
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddScraper(NewTargetsScraper(config.NewComponentID("scraper"), []string{"fast", "slow", "other"}, scrape)),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 2, sink.DataPointCount())
+
+	// The abandoned scrape of the slow target is still running, so only this target is skipped.
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 4, sink.DataPointCount())
+
+	require.NoError(t, receiver.Shutdown(context.Background()))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"fast": 2, "slow": 1, "other": 2}, calls)
+}
+
+func TestCollectionSplayTargets(t *testing.T) {
+	var mu sync.Mutex
+	started := map[string]time.Time{}
+	scrape := func(_ context.Context, target string) (pdata.ResourceMetricsSlice, error) {
+		mu.Lock()
+		started[target] = time.Now()
+		mu.Unlock()
+		return singleResourceMetric(), nil
+	}
+
+	cfg := DefaultScraperControllerSettings("receiver")
+	cfg.CollectionSplay = 200 * time.Millisecond
+
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddScraper(NewTargetsScraper(config.NewComponentID("scraper"), []string{"first", "second"}, scrape)),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() == 2
+	}, 2*time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(t, started["second"].Sub(started["first"]), 90*time.Millisecond)
+
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+func TestTargetsScraperScrape(t *testing.T) {
+	failed := map[string]error{}
+	scrape := func(_ context.Context, target string) (pdata.ResourceMetricsSlice, error) {
+		if err := failed[target]; err != nil {
+			return pdata.NewResourceMetricsSlice(), err
+		}
+		return singleResourceMetric(), nil
+	}
+	scraper := NewTargetsScraper(config.NewComponentID("scraper"), []string{"first", "second"}, scrape)
+	assert.Equal(t, []string{"first", "second"}, scraper.Targets())
+
+	md, err := scraper.Scrape(context.Background(), config.NewComponentID("receiver"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, md.DataPointCount())
+
+	md, err = scraper.ScrapeTarget(context.Background(), config.NewComponentID("receiver"), "second")
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.DataPointCount())
+
+	// The metrics of the other targets are kept when a target fails.
+	failed["second"] = errors.New("unreachable")
+	md, err = scraper.Scrape(context.Background(), config.NewComponentID("receiver"))
+	assert.EqualError(t, err, `target "second": unreachable`)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Equal(t, 1, md.DataPointCount())
+
+	failed["first"] = errors.New("unreachable")
+	_, err = scraper.Scrape(context.Background(), config.NewComponentID("receiver"))
+	assert.EqualError(t, err, `target "first": unreachable; target "second": unreachable`)
+	assert.False(t, scrapererror.IsPartialScrapeError(err))
+}
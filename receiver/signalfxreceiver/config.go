@@ -15,10 +15,13 @@
 package signalfxreceiver
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 // Config defines configuration for the SignalFx receiver.
@@ -27,4 +30,27 @@ type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
+
+	// DimensionSanitization defines how the keys of the received dimensions
+	// are sanitized. They are kept as is when it is not set.
+	DimensionSanitization *signalfx.SanitizationRules `mapstructure:"dimension_sanitization"`
+
+	// MetricSanitization defines how the received metric names are sanitized.
+	// They are kept as is when it is not set.
+	MetricSanitization *signalfx.SanitizationRules `mapstructure:"metric_sanitization"`
+}
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.DimensionSanitization != nil {
+		if err := cfg.DimensionSanitization.Validate(); err != nil {
+			return fmt.Errorf("invalid dimension_sanitization: %w", err)
+		}
+	}
+	if cfg.MetricSanitization != nil {
+		if err := cfg.MetricSanitization.Validate(); err != nil {
+			return fmt.Errorf("invalid metric_sanitization: %w", err)
+		}
+	}
+	return nil
 }
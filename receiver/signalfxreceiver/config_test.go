@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -55,6 +56,13 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: true,
 			},
+			DimensionSanitization: &signalfx.SanitizationRules{
+				AllowedChars: "_-",
+			},
+			MetricSanitization: &signalfx.SanitizationRules{
+				AllowedChars: "_-",
+				PreserveDots: true,
+			},
 		})
 
 	r2 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "tls")].(*Config)
@@ -75,3 +83,17 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DimensionSanitization = &signalfx.SanitizationRules{Replacement: "."}
+	assert.EqualError(t, cfg.Validate(),
+		`invalid dimension_sanitization: replacement "." must be a letter, a digit or one of the allowed characters`)
+
+	cfg.DimensionSanitization = nil
+	cfg.MetricSanitization = &signalfx.SanitizationRules{Replacement: "--"}
+	assert.EqualError(t, cfg.Validate(),
+		`invalid metric_sanitization: replacement "--" must be a single character`)
+}
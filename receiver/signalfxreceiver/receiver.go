@@ -236,6 +236,7 @@ func (r *sfxReceiver) handleDatapointReq(resp http.ResponseWriter, req *http.Req
 		return
 	}
 
+	sanitizeDataPoints(msg.Datapoints, r.config.MetricSanitization, r.config.DimensionSanitization)
 	md, _ := signalFxV2ToMetrics(r.settings.Logger, msg.Datapoints)
 
 	if r.config.AccessTokenPassthrough {
@@ -21,6 +21,8 @@ import (
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 var (
@@ -140,3 +142,31 @@ func fillInAttributes(
 		attributes.InsertString(dim.Key, dim.Value)
 	}
 }
+
+// sanitizeDataPoints applies the sanitization rules, when set, to the metric
+// names and dimension keys of the data points, in place.
+func sanitizeDataPoints(
+	sfxDataPoints []*sfxpb.DataPoint,
+	metricRules *signalfx.SanitizationRules,
+	dimensionRules *signalfx.SanitizationRules,
+) {
+	if metricRules == nil && dimensionRules == nil {
+		return
+	}
+	for _, sfxDataPoint := range sfxDataPoints {
+		if sfxDataPoint == nil {
+			continue
+		}
+		if metricRules != nil {
+			sfxDataPoint.Metric = metricRules.Sanitize(sfxDataPoint.Metric)
+		}
+		if dimensionRules == nil {
+			continue
+		}
+		for _, dim := range sfxDataPoint.Dimensions {
+			if dim != nil {
+				dim.Key = dimensionRules.Sanitize(dim.Key)
+			}
+		}
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signalfx"
 )
 
 func Test_signalFxV2ToMetricsData(t *testing.T) {
@@ -248,3 +250,26 @@ func buildNDimensions(n uint) []*sfxpb.Dimension {
 	}
 	return d
 }
+
+func Test_sanitizeDataPoints(t *testing.T) {
+	newDataPoints := func() []*sfxpb.DataPoint {
+		return []*sfxpb.DataPoint{
+			{
+				Metric: "k8s.pod/cpu",
+				Dimensions: []*sfxpb.Dimension{
+					{Key: "k8s.pod.name", Value: "a.b"},
+					nil,
+				},
+			},
+			nil,
+		}
+	}
+
+	dps := newDataPoints()
+	sanitizeDataPoints(dps, nil, nil)
+	assert.Equal(t, newDataPoints(), dps)
+
+	sanitizeDataPoints(dps, &signalfx.SanitizationRules{PreserveDots: true}, &signalfx.SanitizationRules{AllowedChars: "_-"})
+	assert.Equal(t, "k8s.pod_cpu", dps[0].Metric)
+	assert.Equal(t, &sfxpb.Dimension{Key: "k8s_pod_name", Value: "a.b"}, dps[0].Dimensions[0])
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Config defines the configuration for the sshcheck receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// Targets are the SSH endpoints to check on every collection interval.
+	Targets []TargetConfig `mapstructure:"targets"`
+}
+
+// TargetConfig defines an SSH endpoint to check.
+type TargetConfig struct {
+	// Endpoint is the host:port of the SSH server.
+	Endpoint string `mapstructure:"endpoint"`
+	// Username is the user to authenticate as.
+	Username string `mapstructure:"username"`
+	// Password is used for password authentication.
+	Password string `mapstructure:"password"`
+	// KeyFile is the path of a PEM encoded private key used for public key
+	// authentication.
+	KeyFile string `mapstructure:"key_file"`
+	// KnownHosts is the path of a known_hosts file the host key of the
+	// endpoint is verified against. Host keys are not verified when empty,
+	// changes of the host key fingerprint are still reported.
+	KnownHosts string `mapstructure:"known_hosts"`
+	// Timeout bounds the connection, authentication and SFTP check of the
+	// endpoint. Defaults to 10s.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// CheckSFTP additionally opens an SFTP session once connected.
+	CheckSFTP bool `mapstructure:"check_sftp"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Targets) == 0 {
+		return errors.New("at least one target must be specified")
+	}
+	endpoints := make(map[string]bool, len(cfg.Targets))
+	for i, target := range cfg.Targets {
+		if err := target.validate(); err != nil {
+			return fmt.Errorf("target %d: %w", i, err)
+		}
+		if endpoints[target.Endpoint] {
+			return fmt.Errorf("target %d: duplicate endpoint %q", i, target.Endpoint)
+		}
+		endpoints[target.Endpoint] = true
+	}
+	return nil
+}
+
+func (t *TargetConfig) validate() error {
+	if t.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if _, _, err := net.SplitHostPort(t.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", t.Endpoint, err)
+	}
+	if t.Username == "" {
+		return errors.New("username must be specified")
+	}
+	if t.Password == "" && t.KeyFile == "" {
+		return errors.New("either password or key_file must be specified")
+	}
+	if t.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Targets = []TargetConfig{{Endpoint: "localhost:22", Username: "otel", Password: "secret"}}
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, []TargetConfig{{
+		Endpoint:   "bastion.example.com:22",
+		Username:   "monitor",
+		KeyFile:    "/etc/otel/id_ed25519",
+		KnownHosts: "/etc/otel/known_hosts",
+		Timeout:    5 * time.Second,
+		CheckSFTP:  true,
+	}}, custom.Targets)
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := TargetConfig{Endpoint: "localhost:22", Username: "otel", Password: "secret"}
+	tests := []struct {
+		name    string
+		targets []TargetConfig
+		err     string
+	}{
+		{name: "valid", targets: []TargetConfig{valid}},
+		{name: "no targets", err: "at least one target must be specified"},
+		{
+			name:    "missing endpoint",
+			targets: []TargetConfig{{Username: "otel", Password: "secret"}},
+			err:     "target 0: endpoint must be specified",
+		},
+		{
+			name:    "endpoint without port",
+			targets: []TargetConfig{{Endpoint: "localhost", Username: "otel", Password: "secret"}},
+			err:     `target 0: invalid endpoint "localhost": address localhost: missing port in address`,
+		},
+		{
+			name:    "missing username",
+			targets: []TargetConfig{valid, {Endpoint: "localhost:22", Password: "secret"}},
+			err:     "target 1: username must be specified",
+		},
+		{
+			name:    "missing credentials",
+			targets: []TargetConfig{{Endpoint: "localhost:22", Username: "otel"}},
+			err:     "target 0: either password or key_file must be specified",
+		},
+		{
+			name:    "duplicate endpoint",
+			targets: []TargetConfig{valid, valid},
+			err:     `target 1: duplicate endpoint "localhost:22"`,
+		},
+		{
+			name:    "negative timeout",
+			targets: []TargetConfig{{Endpoint: "localhost:22", Username: "otel", Password: "secret", Timeout: -time.Second}},
+			err:     "target 0: timeout must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Targets = tt.targets
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
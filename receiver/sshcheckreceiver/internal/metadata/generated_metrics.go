@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Type is the component type name.
+const Type config.Type = "sshcheckreceiver"
+
+// MetricIntf is an interface to generically interact with generated metric.
+type MetricIntf interface {
+	Name() string
+	New() pdata.Metric
+	Init(metric pdata.Metric)
+}
+
+// Intentionally not exposing this so that it is opaque and can change freely.
+type metricImpl struct {
+	name     string
+	initFunc func(pdata.Metric)
+}
+
+// Name returns the metric name.
+func (m *metricImpl) Name() string {
+	return m.name
+}
+
+// New creates a metric object preinitialized.
+func (m *metricImpl) New() pdata.Metric {
+	metric := pdata.NewMetric()
+	m.Init(metric)
+	return metric
+}
+
+// Init initializes the provided metric object.
+func (m *metricImpl) Init(metric pdata.Metric) {
+	m.initFunc(metric)
+}
+
+type metricStruct struct {
+	SshcheckDuration       MetricIntf
+	SshcheckError          MetricIntf
+	SshcheckHostKeyChanges MetricIntf
+	SshcheckSftpDuration   MetricIntf
+	SshcheckSftpError      MetricIntf
+	SshcheckSftpStatus     MetricIntf
+	SshcheckStatus         MetricIntf
+}
+
+// Names returns a list of all the metric name strings.
+func (m *metricStruct) Names() []string {
+	return []string{
+		"sshcheck.duration",
+		"sshcheck.error",
+		"sshcheck.host_key.changes",
+		"sshcheck.sftp_duration",
+		"sshcheck.sftp_error",
+		"sshcheck.sftp_status",
+		"sshcheck.status",
+	}
+}
+
+var metricsByName = map[string]MetricIntf{
+	"sshcheck.duration":         Metrics.SshcheckDuration,
+	"sshcheck.error":            Metrics.SshcheckError,
+	"sshcheck.host_key.changes": Metrics.SshcheckHostKeyChanges,
+	"sshcheck.sftp_duration":    Metrics.SshcheckSftpDuration,
+	"sshcheck.sftp_error":       Metrics.SshcheckSftpError,
+	"sshcheck.sftp_status":      Metrics.SshcheckSftpStatus,
+	"sshcheck.status":           Metrics.SshcheckStatus,
+}
+
+func (m *metricStruct) ByName(n string) MetricIntf {
+	return metricsByName[n]
+}
+
+// Metrics contains a set of methods for each metric that help with
+// manipulating those metrics.
+var Metrics = &metricStruct{
+	&metricImpl{
+		"sshcheck.duration",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.duration")
+			metric.SetDescription("Time taken to connect and authenticate to the SSH endpoint.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"sshcheck.error",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.error")
+			metric.SetDescription("Records errors occurring while connecting or authenticating to the SSH endpoint.")
+			metric.SetUnit("{error}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"sshcheck.host_key.changes",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.host_key.changes")
+			metric.SetDescription("Number of times the host key fingerprint presented by the SSH endpoint changed.")
+			metric.SetUnit("{change}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"sshcheck.sftp_duration",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.sftp_duration")
+			metric.SetDescription("Time taken to open an SFTP session once connected to the SSH endpoint.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"sshcheck.sftp_error",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.sftp_error")
+			metric.SetDescription("Records errors occurring while opening an SFTP session.")
+			metric.SetUnit("{error}")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(false)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"sshcheck.sftp_status",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.sftp_status")
+			metric.SetDescription("1 if the SFTP session was opened successfully, otherwise 0.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"sshcheck.status",
+		func(metric pdata.Metric) {
+			metric.SetName("sshcheck.status")
+			metric.SetDescription("1 if the SSH connection and authentication succeeded, otherwise 0.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+}
+
+// M contains a set of methods for each metric that help with
+// manipulating those metrics. M is an alias for Metrics
+var M = Metrics
+
+// Labels contains the possible metric labels that can be used.
+var Labels = struct {
+	// Endpoint (The SSH endpoint checked.)
+	Endpoint string
+	// ErrorMessage (The error message of the failed check.)
+	ErrorMessage string
+}{
+	"ssh.endpoint",
+	"error.message",
+}
+
+// L contains the possible metric labels that can be used. L is an alias for
+// Labels.
+var L = Labels
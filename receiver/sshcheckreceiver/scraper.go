@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sshcheckreceiver/internal/metadata"
+)
+
+// target is a configured endpoint with its prepared client configuration.
+type target struct {
+	cfg          TargetConfig
+	clientConfig *ssh.ClientConfig
+}
+
+// checkResult is the outcome of checking a single target.
+type checkResult struct {
+	duration    time.Duration
+	err         error
+	fingerprint string
+
+	sftpChecked  bool
+	sftpDuration time.Duration
+	sftpErr      error
+}
+
+type sshcheckScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	now    func() time.Time
+
+	// targets are the targets by endpoint.
+	targets   map[string]*target
+	startTime pdata.Timestamp
+
+	// mu guards the state of the targets, which are scraped concurrently.
+	mu sync.Mutex
+	// fingerprints holds the last host key fingerprint seen per endpoint.
+	fingerprints map[string]string
+	// changes counts the host key fingerprint changes per endpoint.
+	changes map[string]int64
+}
+
+func newSSHCheckScraper(logger *zap.Logger, cfg *Config) *sshcheckScraper {
+	return &sshcheckScraper{
+		logger:       logger,
+		cfg:          cfg,
+		now:          time.Now,
+		targets:      make(map[string]*target),
+		fingerprints: make(map[string]string),
+		changes:      make(map[string]int64),
+	}
+}
+
+// start prepares the client configuration of every target, reading the key
+// and known hosts files once.
+func (s *sshcheckScraper) start(context.Context, component.Host) error {
+	s.startTime = pdata.NewTimestampFromTime(s.now())
+	for _, cfg := range s.cfg.Targets {
+		clientConfig, err := newClientConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to configure target %q: %w", cfg.Endpoint, err)
+		}
+		s.targets[cfg.Endpoint] = &target{cfg: cfg, clientConfig: clientConfig}
+	}
+	return nil
+}
+
+func newClientConfig(cfg TargetConfig) (*ssh.ClientConfig, error) {
+	var auth []ssh.AuthMethod
+	if cfg.KeyFile != "" {
+		key, err := ioutil.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key file: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auth = append(auth, ssh.Password(cfg.Password))
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if cfg.KnownHosts != "" {
+		var err error
+		if hostKeyCallback, err = knownhosts.New(cfg.KnownHosts); err != nil {
+			return nil, fmt.Errorf("failed to read known hosts: %w", err)
+		}
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	return &ssh.ClientConfig{
+		User:            cfg.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+	}, nil
+}
+
+// endpoints returns the endpoints of the targets, scraped as the targets of
+// a scraperhelper.TargetsScraper.
+func (s *sshcheckScraper) endpoints() []string {
+	endpoints := make([]string, len(s.cfg.Targets))
+	for i, target := range s.cfg.Targets {
+		endpoints[i] = target.Endpoint
+	}
+	return endpoints
+}
+
+// scrapeTarget checks the target of the endpoint. A failed check is reported
+// through the status and error metrics, not as a scrape error.
+func (s *sshcheckScraper) scrapeTarget(ctx context.Context, endpoint string) (pdata.ResourceMetricsSlice, error) {
+	t, ok := s.targets[endpoint]
+	if !ok {
+		return pdata.NewResourceMetricsSlice(), fmt.Errorf("unknown target %q", endpoint)
+	}
+	res := check(ctx, t)
+
+	now := pdata.NewTimestampFromTime(s.now())
+	rms := pdata.NewResourceMetricsSlice()
+	metrics := rms.AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	addPoint(newMetric(metrics, metadata.M.SshcheckDuration).Gauge().DataPoints(), now, res.duration.Milliseconds(), endpoint)
+	if res.err != nil {
+		addPoint(newMetric(metrics, metadata.M.SshcheckStatus).Gauge().DataPoints(), now, 0, endpoint)
+		addPoint(newMetric(metrics, metadata.M.SshcheckError).Sum().DataPoints(), now, 1, endpoint).Attributes().
+			InsertString(metadata.L.ErrorMessage, res.err.Error())
+	} else {
+		addPoint(newMetric(metrics, metadata.M.SshcheckStatus).Gauge().DataPoints(), now, 1, endpoint)
+	}
+
+	if res.sftpChecked {
+		addPoint(newMetric(metrics, metadata.M.SshcheckSftpDuration).Gauge().DataPoints(), now, res.sftpDuration.Milliseconds(), endpoint)
+		if res.sftpErr != nil {
+			addPoint(newMetric(metrics, metadata.M.SshcheckSftpStatus).Gauge().DataPoints(), now, 0, endpoint)
+			addPoint(newMetric(metrics, metadata.M.SshcheckSftpError).Sum().DataPoints(), now, 1, endpoint).Attributes().
+				InsertString(metadata.L.ErrorMessage, res.sftpErr.Error())
+		} else {
+			addPoint(newMetric(metrics, metadata.M.SshcheckSftpStatus).Gauge().DataPoints(), now, 1, endpoint)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if res.fingerprint != "" {
+		s.recordFingerprint(endpoint, res.fingerprint)
+	}
+	if _, ok := s.fingerprints[endpoint]; ok {
+		addPoint(newMetric(metrics, metadata.M.SshcheckHostKeyChanges).Sum().DataPoints(), now, s.changes[endpoint], endpoint).
+			SetStartTimestamp(s.startTime)
+	}
+	return rms, nil
+}
+
+// recordFingerprint remembers the host key fingerprint of the endpoint and
+// counts a change when it differs from the previous one. It's called with mu
+// held.
+func (s *sshcheckScraper) recordFingerprint(endpoint, fingerprint string) {
+	previous, ok := s.fingerprints[endpoint]
+	s.fingerprints[endpoint] = fingerprint
+	if !ok || previous == fingerprint {
+		return
+	}
+	s.changes[endpoint]++
+	s.logger.Warn("SSH host key fingerprint changed",
+		zap.String("endpoint", endpoint),
+		zap.String("previous", previous),
+		zap.String("current", fingerprint))
+}
+
+// check connects and authenticates to the target, then optionally opens an
+// SFTP session. The whole check is bounded by the target timeout.
+func check(ctx context.Context, t *target) (res checkResult) {
+	var (
+		mu          sync.Mutex
+		fingerprint string
+	)
+	// The host key callback runs on the handshake goroutine, also on re-keys.
+	clientConfig := *t.clientConfig
+	clientConfig.HostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		mu.Lock()
+		fingerprint = ssh.FingerprintSHA256(key)
+		mu.Unlock()
+		return t.clientConfig.HostKeyCallback(hostname, remote, key)
+	}
+	defer func() {
+		mu.Lock()
+		res.fingerprint = fingerprint
+		mu.Unlock()
+	}()
+
+	begin := time.Now()
+	deadline := begin.Add(clientConfig.Timeout)
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", t.cfg.Endpoint)
+	if err != nil {
+		res.duration = time.Since(begin)
+		res.err = err
+		return res
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(deadline); err != nil {
+		res.duration = time.Since(begin)
+		res.err = err
+		return res
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, t.cfg.Endpoint, &clientConfig)
+	res.duration = time.Since(begin)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	client := ssh.NewClient(c, chans, reqs)
+	defer client.Close()
+
+	if t.cfg.CheckSFTP {
+		res.sftpChecked = true
+		sftpBegin := time.Now()
+		res.sftpErr = checkSFTP(client)
+		res.sftpDuration = time.Since(sftpBegin)
+	}
+	return res
+}
+
+func newMetric(metrics pdata.MetricSlice, m metadata.MetricIntf) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	m.Init(metric)
+	return metric
+}
+
+func addPoint(dps pdata.NumberDataPointSlice, now pdata.Timestamp, value int64, endpoint string) pdata.NumberDataPoint {
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(now)
+	dp.SetIntVal(value)
+	dp.Attributes().InsertString(metadata.L.Endpoint, endpoint)
+	return dp
+}
@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testServer is an SSH server accepting the otel/secret password and an
+// optional public key, with an optional minimal SFTP subsystem.
+type testServer struct {
+	t        *testing.T
+	listener net.Listener
+	sftp     bool
+
+	mu        sync.Mutex
+	hostKey   ssh.Signer
+	clientKey ssh.PublicKey
+}
+
+func newTestServer(t *testing.T, sftp bool) *testServer {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	s := &testServer{t: t, listener: listener, sftp: sftp, hostKey: newHostKey(t)}
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+func newHostKey(t *testing.T) ssh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+func (s *testServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testServer) setHostKey(key ssh.Signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hostKey = key
+}
+
+func (s *testServer) setClientKey(key ssh.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientKey = key
+}
+
+func (s *testServer) serverConfig() *ssh.ServerConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clientKey := s.clientKey
+	cfg := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "otel" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("invalid password")
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if clientKey != nil && string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown key")
+		},
+	}
+	cfg.AddHostKey(s.hostKey)
+	return cfg
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.serverConfig())
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleSession(channel, requests)
+	}
+}
+
+func (s *testServer) handleSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		if req.Type != "subsystem" || !s.sftp || len(req.Payload) < 4 || string(req.Payload[4:]) != "sftp" {
+			_ = req.Reply(false, nil)
+			continue
+		}
+		_ = req.Reply(true, nil)
+
+		init := make([]byte, 9)
+		if _, err := io.ReadFull(channel, init); err != nil || init[4] != sftpPacketInit {
+			return
+		}
+		version := make([]byte, 9)
+		binary.BigEndian.PutUint32(version[0:4], 5)
+		version[4] = sftpPacketVersion
+		binary.BigEndian.PutUint32(version[5:9], sftpVersion)
+		_, _ = channel.Write(version)
+	}
+}
+
+func newTestScraper(t *testing.T, targets ...TargetConfig) *sshcheckScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = targets
+	require.NoError(t, cfg.Validate())
+	s := newSSHCheckScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func scrape(t *testing.T, s *sshcheckScraper) map[string]pdata.NumberDataPointSlice {
+	rms, err := s.scrapeTarget(context.Background(), s.cfg.Targets[0].Endpoint)
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	points := make(map[string]pdata.NumberDataPointSlice)
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			points[metric.Name()] = metric.Gauge().DataPoints()
+		case pdata.MetricDataTypeSum:
+			points[metric.Name()] = metric.Sum().DataPoints()
+		}
+	}
+	return points
+}
+
+func assertPoint(t *testing.T, points pdata.NumberDataPointSlice, endpoint string, value int64) {
+	require.Equal(t, 1, points.Len())
+	assert.Equal(t, value, points.At(0).IntVal())
+	ep, ok := points.At(0).Attributes().Get("ssh.endpoint")
+	require.True(t, ok)
+	assert.Equal(t, endpoint, ep.StringVal())
+}
+
+func TestScrapePassword(t *testing.T) {
+	server := newTestServer(t, true)
+	s := newTestScraper(t, TargetConfig{Endpoint: server.addr(), Username: "otel", Password: "secret", CheckSFTP: true})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 1)
+	assertPoint(t, points["sshcheck.sftp_status"], server.addr(), 1)
+	assertPoint(t, points["sshcheck.host_key.changes"], server.addr(), 0)
+	assert.Equal(t, 1, points["sshcheck.duration"].Len())
+	assert.Equal(t, 1, points["sshcheck.sftp_duration"].Len())
+	assert.NotContains(t, points, "sshcheck.error")
+	assert.NotContains(t, points, "sshcheck.sftp_error")
+}
+
+func TestScrapeKeyFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "id_ecdsa")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	server := newTestServer(t, false)
+	server.setClientKey(publicKey)
+	s := newTestScraper(t, TargetConfig{Endpoint: server.addr(), Username: "otel", KeyFile: keyFile})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 1)
+	assert.NotContains(t, points, "sshcheck.sftp_status")
+}
+
+func TestScrapeAuthenticationFailure(t *testing.T) {
+	server := newTestServer(t, true)
+	s := newTestScraper(t, TargetConfig{Endpoint: server.addr(), Username: "otel", Password: "wrong", CheckSFTP: true})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 0)
+	assertPoint(t, points["sshcheck.error"], server.addr(), 1)
+	msg, ok := points["sshcheck.error"].At(0).Attributes().Get("error.message")
+	require.True(t, ok)
+	assert.Contains(t, msg.StringVal(), "unable to authenticate")
+	assert.NotContains(t, points, "sshcheck.sftp_status")
+}
+
+func TestScrapeConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	s := newTestScraper(t, TargetConfig{Endpoint: endpoint, Username: "otel", Password: "secret", Timeout: time.Second})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], endpoint, 0)
+	assertPoint(t, points["sshcheck.error"], endpoint, 1)
+	assert.NotContains(t, points, "sshcheck.host_key.changes")
+}
+
+func TestScrapeSFTPUnavailable(t *testing.T) {
+	server := newTestServer(t, false)
+	s := newTestScraper(t, TargetConfig{Endpoint: server.addr(), Username: "otel", Password: "secret", CheckSFTP: true})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 1)
+	assertPoint(t, points["sshcheck.sftp_status"], server.addr(), 0)
+	assertPoint(t, points["sshcheck.sftp_error"], server.addr(), 1)
+}
+
+func TestScrapeKnownHosts(t *testing.T) {
+	server := newTestServer(t, false)
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(server.addr())}, server.hostKey.PublicKey())
+	require.NoError(t, ioutil.WriteFile(knownHostsFile, []byte(line+"\n"), 0600))
+	s := newTestScraper(t, TargetConfig{Endpoint: server.addr(), Username: "otel", Password: "secret", KnownHosts: knownHostsFile})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 1)
+
+	server.setHostKey(newHostKey(t))
+	points = scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 0)
+	assertPoint(t, points["sshcheck.host_key.changes"], server.addr(), 1)
+}
+
+func TestScrapeHostKeyChanges(t *testing.T) {
+	server := newTestServer(t, false)
+	s := newTestScraper(t, TargetConfig{Endpoint: server.addr(), Username: "otel", Password: "secret"})
+
+	points := scrape(t, s)
+	assertPoint(t, points["sshcheck.host_key.changes"], server.addr(), 0)
+	points = scrape(t, s)
+	assertPoint(t, points["sshcheck.host_key.changes"], server.addr(), 0)
+
+	server.setHostKey(newHostKey(t))
+	points = scrape(t, s)
+	assertPoint(t, points["sshcheck.status"], server.addr(), 1)
+	assertPoint(t, points["sshcheck.host_key.changes"], server.addr(), 1)
+	assert.Equal(t, s.startTime, points["sshcheck.host_key.changes"].At(0).StartTimestamp())
+}
+
+func TestScrapeTimeoutSlowTarget(t *testing.T) {
+	server := newTestServer(t, false)
+	// The slow endpoint accepts connections but never starts the SSH handshake.
+	slow, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer slow.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 50 * time.Millisecond
+	cfg.ScrapeTimeout = 20 * time.Millisecond
+	cfg.Targets = []TargetConfig{
+		{Endpoint: slow.Addr().String(), Username: "otel", Password: "secret"},
+		{Endpoint: server.addr(), Username: "otel", Password: "secret"},
+	}
+	sink := new(consumertest.MetricsSink)
+	r, err := NewFactory().CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	// The slow target only loses its own metrics.
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == "sshcheck.status" {
+			assertPoint(t, metrics.At(i).Gauge().DataPoints(), server.addr(), 1)
+			return
+		}
+	}
+	assert.Fail(t, "sshcheck.status not reported")
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTP packet types and protocol version, see
+// https://datatracker.ietf.org/doc/html/draft-ietf-secsh-filexfer-02.
+const (
+	sftpPacketInit    = 1
+	sftpPacketVersion = 2
+	sftpVersion       = 3
+	// sftpMaxPacket bounds the size of the version packet read from the
+	// server, which only carries the version and extension names.
+	sftpMaxPacket = 64 * 1024
+)
+
+// checkSFTP opens an SFTP session on the client and performs the protocol
+// version negotiation, which is enough to know the SFTP server is available.
+func checkSFTP(client *ssh.Client) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = session.RequestSubsystem("sftp"); err != nil {
+		return fmt.Errorf("failed to start sftp subsystem: %w", err)
+	}
+
+	init := make([]byte, 9)
+	binary.BigEndian.PutUint32(init[0:4], 5)
+	init[4] = sftpPacketInit
+	binary.BigEndian.PutUint32(init[5:9], sftpVersion)
+	if _, err = stdin.Write(init); err != nil {
+		return fmt.Errorf("failed to send sftp init: %w", err)
+	}
+
+	header := make([]byte, 5)
+	if _, err = io.ReadFull(stdout, header); err != nil {
+		return fmt.Errorf("failed to read sftp version: %w", err)
+	}
+	length := binary.BigEndian.Uint32(header[0:4])
+	if header[4] != sftpPacketVersion || length < 5 || length > sftpMaxPacket {
+		return fmt.Errorf("unexpected sftp packet type %d with length %d", header[4], length)
+	}
+	if _, err = io.CopyN(io.Discard, stdout, int64(length-1)); err != nil {
+		return fmt.Errorf("failed to read sftp version: %w", err)
+	}
+	return nil
+}
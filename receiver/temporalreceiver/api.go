@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+// The types below decode the JSON encoding of the Temporal API messages
+// returned by the HTTP API, only keeping the fields used by the receiver.
+
+type listWorkflowExecutionsResponse struct {
+	Executions    []workflowExecutionInfo `json:"executions"`
+	NextPageToken string                  `json:"nextPageToken"`
+}
+
+type workflowExecution struct {
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+}
+
+type named struct {
+	Name string `json:"name"`
+}
+
+type workflowExecutionInfo struct {
+	Execution       workflowExecution  `json:"execution"`
+	Type            named              `json:"type"`
+	StartTime       time.Time          `json:"startTime"`
+	CloseTime       time.Time          `json:"closeTime"`
+	Status          string             `json:"status"`
+	HistoryLength   int64Value         `json:"historyLength"`
+	TaskQueue       string             `json:"taskQueue"`
+	ParentExecution *workflowExecution `json:"parentExecution"`
+}
+
+type getWorkflowExecutionHistoryResponse struct {
+	History struct {
+		Events []historyEvent `json:"events"`
+	} `json:"history"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+type failure struct {
+	Message string `json:"message"`
+}
+
+type historyEvent struct {
+	EventID   int64Value `json:"eventId"`
+	EventTime time.Time  `json:"eventTime"`
+	EventType string     `json:"eventType"`
+
+	WorkflowExecutionFailedEventAttributes *struct {
+		Failure *failure `json:"failure"`
+	} `json:"workflowExecutionFailedEventAttributes"`
+	WorkflowExecutionSignaledEventAttributes *struct {
+		SignalName string `json:"signalName"`
+	} `json:"workflowExecutionSignaledEventAttributes"`
+
+	ActivityTaskScheduledEventAttributes *struct {
+		ActivityID   string `json:"activityId"`
+		ActivityType named  `json:"activityType"`
+		TaskQueue    named  `json:"taskQueue"`
+	} `json:"activityTaskScheduledEventAttributes"`
+	ActivityTaskStartedEventAttributes *struct {
+		ScheduledEventID int64Value `json:"scheduledEventId"`
+		Attempt          int64Value `json:"attempt"`
+		Identity         string     `json:"identity"`
+	} `json:"activityTaskStartedEventAttributes"`
+	ActivityTaskCompletedEventAttributes *activityTaskClosedAttributes `json:"activityTaskCompletedEventAttributes"`
+	ActivityTaskFailedEventAttributes    *activityTaskClosedAttributes `json:"activityTaskFailedEventAttributes"`
+	ActivityTaskTimedOutEventAttributes  *activityTaskClosedAttributes `json:"activityTaskTimedOutEventAttributes"`
+	ActivityTaskCanceledEventAttributes  *activityTaskClosedAttributes `json:"activityTaskCanceledEventAttributes"`
+
+	TimerStartedEventAttributes *struct {
+		TimerID string `json:"timerId"`
+	} `json:"timerStartedEventAttributes"`
+	TimerFiredEventAttributes    *timerClosedAttributes `json:"timerFiredEventAttributes"`
+	TimerCanceledEventAttributes *timerClosedAttributes `json:"timerCanceledEventAttributes"`
+
+	StartChildWorkflowExecutionInitiatedEventAttributes *struct {
+		Namespace    string `json:"namespace"`
+		WorkflowID   string `json:"workflowId"`
+		WorkflowType named  `json:"workflowType"`
+	} `json:"startChildWorkflowExecutionInitiatedEventAttributes"`
+	StartChildWorkflowExecutionFailedEventAttributes *childWorkflowClosedAttributes `json:"startChildWorkflowExecutionFailedEventAttributes"`
+	ChildWorkflowExecutionStartedEventAttributes     *childWorkflowClosedAttributes `json:"childWorkflowExecutionStartedEventAttributes"`
+	ChildWorkflowExecutionCompletedEventAttributes   *childWorkflowClosedAttributes `json:"childWorkflowExecutionCompletedEventAttributes"`
+	ChildWorkflowExecutionFailedEventAttributes      *childWorkflowClosedAttributes `json:"childWorkflowExecutionFailedEventAttributes"`
+	ChildWorkflowExecutionCanceledEventAttributes    *childWorkflowClosedAttributes `json:"childWorkflowExecutionCanceledEventAttributes"`
+	ChildWorkflowExecutionTimedOutEventAttributes    *childWorkflowClosedAttributes `json:"childWorkflowExecutionTimedOutEventAttributes"`
+	ChildWorkflowExecutionTerminatedEventAttributes  *childWorkflowClosedAttributes `json:"childWorkflowExecutionTerminatedEventAttributes"`
+}
+
+type activityTaskClosedAttributes struct {
+	ScheduledEventID int64Value `json:"scheduledEventId"`
+	Failure          *failure   `json:"failure"`
+}
+
+type timerClosedAttributes struct {
+	StartedEventID int64Value `json:"startedEventId"`
+}
+
+type childWorkflowClosedAttributes struct {
+	InitiatedEventID  int64Value         `json:"initiatedEventId"`
+	WorkflowExecution *workflowExecution `json:"workflowExecution"`
+	Failure           *failure           `json:"failure"`
+	Cause             string             `json:"cause"`
+}
+
+// int64Value decodes 64-bit integers, encoded as strings by the JSON mapping
+// of protocol buffers, or as numbers.
+type int64Value int64
+
+func (v *int64Value) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		*v = 0
+		return nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+	*v = int64Value(i)
+	return nil
+}
+
+// normalizeEnum returns the lower case name of an enum value without its
+// prefix, so that both "EVENT_TYPE_TIMER_FIRED" and "TimerFired" become
+// "timerfired".
+func normalizeEnum(value, prefix string) string {
+	value = strings.TrimPrefix(value, prefix)
+	return strings.ToLower(strings.ReplaceAll(value, "_", ""))
+}
+
+// apiClient sends requests to the Temporal HTTP API.
+type apiClient struct {
+	endpoint string
+	client   *pollingsaas.Client
+}
+
+func (c *apiClient) listClosedWorkflowExecutions(ctx context.Context, namespace, query string, pageSize int, pageToken string) (*listWorkflowExecutionsResponse, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	if pageToken != "" {
+		params.Set("nextPageToken", pageToken)
+	}
+	u := fmt.Sprintf("%s/api/v1/namespaces/%s/workflows?%s",
+		strings.TrimSuffix(c.endpoint, "/"), url.PathEscape(namespace), params.Encode())
+
+	var resp listWorkflowExecutionsResponse
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list workflow executions of namespace %s: %w", namespace, err)
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getWorkflowExecutionHistory(ctx context.Context, namespace string, execution workflowExecution) ([]historyEvent, error) {
+	var events []historyEvent
+	pageToken := ""
+	for {
+		params := url.Values{}
+		params.Set("execution.runId", execution.RunID)
+		if pageToken != "" {
+			params.Set("nextPageToken", pageToken)
+		}
+		u := fmt.Sprintf("%s/api/v1/namespaces/%s/workflows/%s/history?%s",
+			strings.TrimSuffix(c.endpoint, "/"), url.PathEscape(namespace), url.PathEscape(execution.WorkflowID), params.Encode())
+
+		var resp getWorkflowExecutionHistoryResponse
+		if err := c.get(ctx, u, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get history of workflow %s run %s: %w", execution.WorkflowID, execution.RunID, err)
+		}
+		events = append(events, resp.History.Events...)
+		if resp.NextPageToken == "" {
+			return events, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (c *apiClient) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+// Config defines configuration for the Temporal receiver.
+type Config struct {
+	// ScraperControllerSettings configures how often the metrics are scraped.
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// Metrics configures the scraping of the Prometheus endpoint of the
+	// Temporal server, for metrics pipelines.
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// Workflows configures the conversion of workflow execution histories to
+	// traces, for traces pipelines.
+	Workflows WorkflowsConfig `mapstructure:"workflows"`
+}
+
+// MetricsConfig configures the scraping of Temporal server metrics.
+type MetricsConfig struct {
+	// HTTPClientSettings configures the client scraping the metrics. Its
+	// endpoint is the URL of the Prometheus endpoint of the server.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// WorkflowsConfig configures the conversion of workflow execution histories to
+// traces.
+type WorkflowsConfig struct {
+	// HTTPClientSettings configures the client of the Temporal HTTP API. Its
+	// endpoint is the base URL of the API.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	pollingsaas.Settings          `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Namespaces are the namespaces whose closed workflow executions are
+	// converted. Defaults to the default namespace.
+	Namespaces []string `mapstructure:"namespaces"`
+
+	// Query is a visibility query further restricting the workflow
+	// executions converted, for example "WorkflowType = 'Checkout'".
+	Query string `mapstructure:"query"`
+
+	// InitialLookback is how far back to look for closed workflow executions
+	// the first time the receiver starts.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+
+	// PageSize is the number of workflow executions listed per request.
+	PageSize int `mapstructure:"page_size"`
+
+	// MaxWorkflowsPerPoll limits the number of workflow execution histories
+	// fetched per namespace before the traces are sent.
+	MaxWorkflowsPerPoll int `mapstructure:"max_workflows_per_poll"`
+}
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if err := validateEndpoint(cfg.Metrics.Endpoint); err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	if err := cfg.Workflows.validate(); err != nil {
+		return fmt.Errorf("workflows: %w", err)
+	}
+	return nil
+}
+
+func (cfg *WorkflowsConfig) validate() error {
+	if err := validateEndpoint(cfg.Endpoint); err != nil {
+		return err
+	}
+	for _, namespace := range cfg.Namespaces {
+		if namespace == "" {
+			return errors.New("namespaces must not be empty")
+		}
+	}
+	if cfg.InitialLookback < 0 {
+		return errors.New("initial_lookback must not be negative")
+	}
+	if cfg.PageSize <= 0 {
+		return errors.New("page_size must be positive")
+	}
+	if cfg.MaxWorkflowsPerPoll <= 0 {
+		return errors.New("max_workflows_per_poll must be positive")
+	}
+	return cfg.Settings.Validate()
+}
+
+func validateEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return errors.New("endpoint must be non-empty")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid endpoint %q: scheme must be http or https", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewComponentID(typeStr)])
+
+	custom := cfg.Receivers[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, 30*time.Second, custom.CollectionInterval)
+	assert.Equal(t, "http://temporal-frontend:9090/metrics", custom.Metrics.Endpoint)
+	assert.Equal(t, "https://temporal.example.com:7243", custom.Workflows.Endpoint)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, custom.Workflows.Headers)
+	assert.Equal(t, []string{"orders", "payments"}, custom.Workflows.Namespaces)
+	assert.Equal(t, "WorkflowType = 'Checkout'", custom.Workflows.Query)
+	assert.Equal(t, 2*time.Minute, custom.Workflows.PollInterval)
+	assert.Equal(t, 15*time.Minute, custom.Workflows.InitialLookback)
+	assert.Equal(t, 50, custom.Workflows.PageSize)
+	assert.Equal(t, 200, custom.Workflows.MaxWorkflowsPerPoll)
+	assert.Equal(t, "file_storage", custom.Workflows.Storage)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{name: "default", modify: func(cfg *Config) {}},
+		{
+			name:   "missing metrics endpoint",
+			modify: func(cfg *Config) { cfg.Metrics.Endpoint = "" },
+			err:    "metrics: endpoint must be non-empty",
+		},
+		{
+			name:   "invalid workflows endpoint scheme",
+			modify: func(cfg *Config) { cfg.Workflows.Endpoint = "grpc://localhost:7233" },
+			err:    `workflows: invalid endpoint "grpc://localhost:7233": scheme must be http or https`,
+		},
+		{
+			name:   "empty namespace",
+			modify: func(cfg *Config) { cfg.Workflows.Namespaces = []string{"default", ""} },
+			err:    "workflows: namespaces must not be empty",
+		},
+		{
+			name:   "negative lookback",
+			modify: func(cfg *Config) { cfg.Workflows.InitialLookback = -time.Minute },
+			err:    "workflows: initial_lookback must not be negative",
+		},
+		{
+			name:   "invalid page size",
+			modify: func(cfg *Config) { cfg.Workflows.PageSize = 0 },
+			err:    "workflows: page_size must be positive",
+		},
+		{
+			name:   "invalid max workflows",
+			modify: func(cfg *Config) { cfg.Workflows.MaxWorkflowsPerPoll = 0 },
+			err:    "workflows: max_workflows_per_poll must be positive",
+		},
+		{
+			name:   "invalid poll interval",
+			modify: func(cfg *Config) { cfg.Workflows.PollInterval = 0 },
+			err:    "workflows: poll_interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
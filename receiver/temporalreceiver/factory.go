@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "temporal"
+
+	defaultMetricsEndpoint     = "http://localhost:8000/metrics"
+	defaultMetricsTimeout      = 10 * time.Second
+	defaultAPIEndpoint         = "http://localhost:7243"
+	defaultAPITimeout          = 30 * time.Second
+	defaultPollInterval        = time.Minute
+	defaultInitialLookback     = time.Hour
+	defaultPageSize            = 100
+	defaultMaxWorkflowsPerPoll = 500
+	defaultNamespace           = "default"
+)
+
+// NewFactory creates a factory for the Temporal receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	settings := pollingsaas.NewDefaultSettings()
+	settings.PollInterval = defaultPollInterval
+	return &Config{
+		ScraperControllerSettings: scraperhelper.DefaultScraperControllerSettings(typeStr),
+		Metrics: MetricsConfig{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: defaultMetricsEndpoint,
+				Timeout:  defaultMetricsTimeout,
+			},
+		},
+		Workflows: WorkflowsConfig{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: defaultAPIEndpoint,
+				Timeout:  defaultAPITimeout,
+			},
+			Settings:            settings,
+			InitialLookback:     defaultInitialLookback,
+			PageSize:            defaultPageSize,
+			MaxWorkflowsPerPoll: defaultMaxWorkflowsPerPoll,
+		},
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	scraper := newMetricsScraper(rCfg, params.Logger)
+	return scraperhelper.NewScraperControllerReceiver(
+		&rCfg.ScraperControllerSettings, params.Logger, consumer,
+		scraperhelper.AddScraper(scraperhelper.NewMetricsScraper(typeStr, scraper.scrape, scraperhelper.WithStart(scraper.start))),
+	)
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	rCfg := cfg.(*Config)
+	source := newWorkflowSource(&rCfg.Workflows, params.Logger)
+	return pollingsaas.NewTracesReceiver(rCfg.ID(), typeStr, rCfg.Workflows.Settings, rCfg.Workflows.HTTPClientSettings, nil, source, consumer, params), nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prommetrics"
+)
+
+// metricsScraper scrapes the Prometheus endpoint of a Temporal server.
+type metricsScraper struct {
+	cfg    *MetricsConfig
+	logger *zap.Logger
+	now    func() time.Time
+
+	client    *http.Client
+	startTime pdata.Timestamp
+}
+
+func newMetricsScraper(cfg *Config, logger *zap.Logger) *metricsScraper {
+	return &metricsScraper{
+		cfg:    &cfg.Metrics,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *metricsScraper) start(_ context.Context, host component.Host) error {
+	client, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = client
+	s.startTime = pdata.NewTimestampFromTime(s.now())
+	return nil
+}
+
+// scrape converts the metric families exposed by the server. Counters,
+// histograms and summaries are cumulative from the start of the receiver.
+// Families that can't be converted are skipped.
+func (s *metricsScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.Endpoint, nil)
+	if err != nil {
+		return pdata.MetricSlice{}, err
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return pdata.MetricSlice{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return pdata.MetricSlice{}, fmt.Errorf("failed to scrape %s: %s", s.cfg.Endpoint, resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return pdata.MetricSlice{}, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ts := pdata.NewTimestampFromTime(s.now())
+	metrics := pdata.NewMetricSlice()
+	for _, name := range names {
+		mf := families[name]
+		if err := prommetrics.Validate(mf); err != nil {
+			s.logger.Debug("Skipping metric family", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		prommetrics.AppendFamily(metrics, mf, s.startTime, ts, nil)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func newTestMetricsScraper(t *testing.T, endpoint string) *metricsScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.Endpoint = endpoint
+	s := newMetricsScraper(cfg, zap.NewNop())
+	s.now = func() time.Time { return time.Unix(1633428000, 0) }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func TestScrapeMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/metrics.txt")
+	}))
+	defer server.Close()
+	s := newTestMetricsScraper(t, server.URL)
+
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, metrics.Len())
+
+	goroutines := metrics.At(0)
+	assert.Equal(t, "num_goroutines", goroutines.Name())
+	assert.Equal(t, pdata.MetricDataTypeGauge, goroutines.DataType())
+
+	latency := metrics.At(1)
+	assert.Equal(t, "service_latency", latency.Name())
+	require.Equal(t, pdata.MetricDataTypeHistogram, latency.DataType())
+	dp := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(42), dp.Count())
+	assert.Equal(t, []float64{0.005, 0.05}, dp.ExplicitBounds())
+	assert.Equal(t, s.startTime, dp.StartTimestamp())
+
+	counter := metrics.At(2)
+	assert.Equal(t, "service_requests", counter.Name())
+	require.Equal(t, pdata.MetricDataTypeSum, counter.DataType())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, 2, counter.Sum().DataPoints().Len())
+}
+
+func TestScrapeMetricsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid" {
+			_, _ = w.Write([]byte("not a metric{"))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := newTestMetricsScraper(t, server.URL+"/metrics").scrape(context.Background())
+	assert.EqualError(t, err, "failed to scrape "+server.URL+"/metrics: 503 Service Unavailable")
+
+	_, err = newTestMetricsScraper(t, server.URL+"/invalid").scrape(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	instrumentationLibraryName = "otelcol/temporal"
+	defaultServiceName         = "temporal"
+
+	attributeNamespace         = "temporal.namespace"
+	attributeWorkflowID        = "temporal.workflow.id"
+	attributeRunID             = "temporal.workflow.run_id"
+	attributeWorkflowType      = "temporal.workflow.type"
+	attributeWorkflowStatus    = "temporal.workflow.status"
+	attributeHistoryLength     = "temporal.workflow.history_length"
+	attributeTaskQueue         = "temporal.task_queue"
+	attributeActivityID        = "temporal.activity.id"
+	attributeActivityType      = "temporal.activity.type"
+	attributeActivityAttempt   = "temporal.activity.attempt"
+	attributeScheduleToStartMs = "temporal.activity.schedule_to_start_ms"
+	attributeWorkerIdentity    = "temporal.worker.identity"
+	attributeTimerID           = "temporal.timer.id"
+	attributeSignalName        = "temporal.signal.name"
+	attributeOutcome           = "temporal.outcome"
+)
+
+// appendWorkflowTrace converts the history of a closed workflow execution to
+// a trace. The workflow execution is the root span, with a child span per
+// activity, timer and child workflow. Trace and span IDs are derived from
+// the run ID and event IDs, so converting a history twice produces the same
+// spans. The trace ID is the run ID itself when it is a UUID.
+func appendWorkflowTrace(traces pdata.Traces, namespace string, info workflowExecutionInfo, events []historyEvent) {
+	rs := traces.ResourceSpans().AppendEmpty()
+	serviceName := info.TaskQueue
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, serviceName)
+	rs.Resource().Attributes().InsertString(attributeNamespace, namespace)
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	ils.InstrumentationLibrary().SetName(instrumentationLibraryName)
+	spans := ils.Spans()
+
+	runID := info.Execution.RunID
+	traceID := traceIDFromRunID(runID)
+	root := spans.AppendEmpty()
+	root.SetTraceID(traceID)
+	root.SetSpanID(spanIDFromEvent(runID, 0))
+	root.SetName("RunWorkflow:" + info.Type.Name)
+	root.SetKind(pdata.SpanKindServer)
+	root.SetStartTimestamp(pdata.NewTimestampFromTime(info.StartTime))
+	root.SetEndTimestamp(pdata.NewTimestampFromTime(info.CloseTime))
+	attrs := root.Attributes()
+	attrs.InsertString(attributeWorkflowID, info.Execution.WorkflowID)
+	attrs.InsertString(attributeRunID, runID)
+	attrs.InsertString(attributeWorkflowType, info.Type.Name)
+	if info.TaskQueue != "" {
+		attrs.InsertString(attributeTaskQueue, info.TaskQueue)
+	}
+	status := normalizeEnum(info.Status, "WORKFLOW_EXECUTION_STATUS_")
+	attrs.InsertString(attributeWorkflowStatus, status)
+	attrs.InsertInt(attributeHistoryLength, int64(info.HistoryLength))
+	switch status {
+	case "failed", "timedout", "terminated":
+		root.Status().SetCode(pdata.StatusCodeError)
+	case "completed":
+		root.Status().SetCode(pdata.StatusCodeOk)
+	}
+	if parent := info.ParentExecution; parent != nil && parent.RunID != "" {
+		link := root.Links().AppendEmpty()
+		link.SetTraceID(traceIDFromRunID(parent.RunID))
+		link.SetSpanID(spanIDFromEvent(parent.RunID, 0))
+		link.Attributes().InsertString(attributeWorkflowID, parent.WorkflowID)
+	}
+
+	c := &historyConverter{runID: runID, traceID: traceID, root: root, spans: spans, pending: make(map[int64]pdata.Span)}
+	for _, event := range events {
+		c.convert(event)
+	}
+	// Activities and timers still pending when the workflow closed end with it.
+	for _, span := range c.pending {
+		span.SetEndTimestamp(root.EndTimestamp())
+		span.Attributes().UpsertString(attributeOutcome, "unfinished")
+	}
+}
+
+type historyConverter struct {
+	runID   string
+	traceID pdata.TraceID
+	root    pdata.Span
+	spans   pdata.SpanSlice
+	// pending holds the spans not closed yet, by the ID of the event that
+	// opened them.
+	pending map[int64]pdata.Span
+}
+
+func (c *historyConverter) convert(event historyEvent) {
+	ts := pdata.NewTimestampFromTime(event.EventTime)
+	switch normalizeEnum(event.EventType, "EVENT_TYPE_") {
+	case "workflowexecutionsignaled":
+		if a := event.WorkflowExecutionSignaledEventAttributes; a != nil {
+			e := c.root.Events().AppendEmpty()
+			e.SetName("WorkflowExecutionSignaled")
+			e.SetTimestamp(ts)
+			e.Attributes().InsertString(attributeSignalName, a.SignalName)
+		}
+	case "workflowexecutionfailed":
+		if a := event.WorkflowExecutionFailedEventAttributes; a != nil && a.Failure != nil {
+			c.root.Status().SetMessage(a.Failure.Message)
+		}
+
+	case "activitytaskscheduled":
+		if a := event.ActivityTaskScheduledEventAttributes; a != nil {
+			span := c.open(event, "RunActivity:"+a.ActivityType.Name)
+			span.Attributes().InsertString(attributeActivityID, a.ActivityID)
+			span.Attributes().InsertString(attributeActivityType, a.ActivityType.Name)
+			if a.TaskQueue.Name != "" {
+				span.Attributes().InsertString(attributeTaskQueue, a.TaskQueue.Name)
+			}
+		}
+	case "activitytaskstarted":
+		if a := event.ActivityTaskStartedEventAttributes; a != nil {
+			if span, ok := c.pending[int64(a.ScheduledEventID)]; ok {
+				span.Attributes().UpsertInt(attributeActivityAttempt, int64(a.Attempt))
+				span.Attributes().UpsertInt(attributeScheduleToStartMs, int64(ts-span.StartTimestamp())/1e6)
+				if a.Identity != "" {
+					span.Attributes().UpsertString(attributeWorkerIdentity, a.Identity)
+				}
+				e := span.Events().AppendEmpty()
+				e.SetName("ActivityTaskStarted")
+				e.SetTimestamp(ts)
+			}
+		}
+	case "activitytaskcompleted":
+		c.closeActivity(event.ActivityTaskCompletedEventAttributes, ts, "completed", false)
+	case "activitytaskfailed":
+		c.closeActivity(event.ActivityTaskFailedEventAttributes, ts, "failed", true)
+	case "activitytasktimedout":
+		c.closeActivity(event.ActivityTaskTimedOutEventAttributes, ts, "timedout", true)
+	case "activitytaskcanceled":
+		c.closeActivity(event.ActivityTaskCanceledEventAttributes, ts, "canceled", false)
+
+	case "timerstarted":
+		if a := event.TimerStartedEventAttributes; a != nil {
+			span := c.open(event, "Timer:"+a.TimerID)
+			span.Attributes().InsertString(attributeTimerID, a.TimerID)
+		}
+	case "timerfired":
+		if a := event.TimerFiredEventAttributes; a != nil {
+			c.close(int64(a.StartedEventID), ts, "fired", false, nil)
+		}
+	case "timercanceled":
+		if a := event.TimerCanceledEventAttributes; a != nil {
+			c.close(int64(a.StartedEventID), ts, "canceled", false, nil)
+		}
+
+	case "startchildworkflowexecutioninitiated":
+		if a := event.StartChildWorkflowExecutionInitiatedEventAttributes; a != nil {
+			span := c.open(event, "StartChildWorkflow:"+a.WorkflowType.Name)
+			span.SetKind(pdata.SpanKindClient)
+			span.Attributes().InsertString(attributeWorkflowID, a.WorkflowID)
+			span.Attributes().InsertString(attributeWorkflowType, a.WorkflowType.Name)
+			if a.Namespace != "" {
+				span.Attributes().InsertString(attributeNamespace, a.Namespace)
+			}
+		}
+	case "childworkflowexecutionstarted":
+		if a := event.ChildWorkflowExecutionStartedEventAttributes; a != nil && a.WorkflowExecution != nil {
+			if span, ok := c.pending[int64(a.InitiatedEventID)]; ok && a.WorkflowExecution.RunID != "" {
+				span.Attributes().UpsertString(attributeRunID, a.WorkflowExecution.RunID)
+				link := span.Links().AppendEmpty()
+				link.SetTraceID(traceIDFromRunID(a.WorkflowExecution.RunID))
+				link.SetSpanID(spanIDFromEvent(a.WorkflowExecution.RunID, 0))
+			}
+		}
+	case "startchildworkflowexecutionfailed":
+		if a := event.StartChildWorkflowExecutionFailedEventAttributes; a != nil {
+			c.close(int64(a.InitiatedEventID), ts, "failed", true, &failure{Message: a.Cause})
+		}
+	case "childworkflowexecutioncompleted":
+		c.closeChild(event.ChildWorkflowExecutionCompletedEventAttributes, ts, "completed", false)
+	case "childworkflowexecutionfailed":
+		c.closeChild(event.ChildWorkflowExecutionFailedEventAttributes, ts, "failed", true)
+	case "childworkflowexecutiontimedout":
+		c.closeChild(event.ChildWorkflowExecutionTimedOutEventAttributes, ts, "timedout", true)
+	case "childworkflowexecutioncanceled":
+		c.closeChild(event.ChildWorkflowExecutionCanceledEventAttributes, ts, "canceled", false)
+	case "childworkflowexecutionterminated":
+		c.closeChild(event.ChildWorkflowExecutionTerminatedEventAttributes, ts, "terminated", true)
+	}
+}
+
+// open starts a child span of the workflow at the time of the event.
+func (c *historyConverter) open(event historyEvent, name string) pdata.Span {
+	span := c.spans.AppendEmpty()
+	span.SetTraceID(c.traceID)
+	span.SetSpanID(spanIDFromEvent(c.runID, int64(event.EventID)))
+	span.SetParentSpanID(c.root.SpanID())
+	span.SetName(name)
+	span.SetKind(pdata.SpanKindInternal)
+	span.SetStartTimestamp(pdata.NewTimestampFromTime(event.EventTime))
+	c.pending[int64(event.EventID)] = span
+	return span
+}
+
+func (c *historyConverter) closeActivity(a *activityTaskClosedAttributes, ts pdata.Timestamp, outcome string, isError bool) {
+	if a != nil {
+		c.close(int64(a.ScheduledEventID), ts, outcome, isError, a.Failure)
+	}
+}
+
+func (c *historyConverter) closeChild(a *childWorkflowClosedAttributes, ts pdata.Timestamp, outcome string, isError bool) {
+	if a != nil {
+		c.close(int64(a.InitiatedEventID), ts, outcome, isError, a.Failure)
+	}
+}
+
+func (c *historyConverter) close(openEventID int64, ts pdata.Timestamp, outcome string, isError bool, f *failure) {
+	span, ok := c.pending[openEventID]
+	if !ok {
+		return
+	}
+	delete(c.pending, openEventID)
+	span.SetEndTimestamp(ts)
+	span.Attributes().UpsertString(attributeOutcome, outcome)
+	if isError {
+		span.Status().SetCode(pdata.StatusCodeError)
+		if f != nil {
+			span.Status().SetMessage(f.Message)
+		}
+	}
+}
+
+// traceIDFromRunID returns the run ID as a trace ID when it is a UUID, and
+// a hash of the run ID otherwise.
+func traceIDFromRunID(runID string) pdata.TraceID {
+	var id [16]byte
+	if b, err := hex.DecodeString(strings.ReplaceAll(runID, "-", "")); err == nil && len(b) == len(id) {
+		copy(id[:], b)
+	} else {
+		sum := sha256.Sum256([]byte(runID))
+		copy(id[:], sum[:])
+	}
+	return pdata.NewTraceID(id)
+}
+
+// spanIDFromEvent returns the span ID of the span opened by an event of the
+// run, the event ID 0 being the workflow execution itself.
+func spanIDFromEvent(runID string, eventID int64) pdata.SpanID {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(runID))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write([]byte(strconv.FormatInt(eventID, 10)))
+	var id [8]byte
+	copy(id[:], h.Sum(nil))
+	if id == [8]byte{} {
+		id[7] = 1
+	}
+	return pdata.NewSpanID(id)
+}
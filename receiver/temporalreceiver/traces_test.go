@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const testRunID = "2c1f7d1e-8a5b-4c3d-9e0f-1a2b3c4d5e6f"
+
+func loadHistory(t *testing.T) []historyEvent {
+	data, err := ioutil.ReadFile("testdata/history.json")
+	require.NoError(t, err)
+	var resp getWorkflowExecutionHistoryResponse
+	require.NoError(t, json.Unmarshal(data, &resp))
+	return resp.History.Events
+}
+
+func testExecutionInfo() workflowExecutionInfo {
+	return workflowExecutionInfo{
+		Execution:     workflowExecution{WorkflowID: "checkout-1", RunID: testRunID},
+		Type:          named{Name: "Checkout"},
+		StartTime:     time.Date(2021, 10, 5, 10, 0, 0, 0, time.UTC),
+		CloseTime:     time.Date(2021, 10, 5, 10, 0, 9, 0, time.UTC),
+		Status:        "WORKFLOW_EXECUTION_STATUS_FAILED",
+		HistoryLength: 15,
+		TaskQueue:     "orders",
+	}
+}
+
+func spansByName(traces pdata.Traces) map[string]pdata.Span {
+	spans := make(map[string]pdata.Span)
+	ss := traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	for i := 0; i < ss.Len(); i++ {
+		spans[ss.At(i).Name()] = ss.At(i)
+	}
+	return spans
+}
+
+func TestAppendWorkflowTrace(t *testing.T) {
+	traces := pdata.NewTraces()
+	appendWorkflowTrace(traces, "orders", testExecutionInfo(), loadHistory(t))
+
+	require.Equal(t, 1, traces.ResourceSpans().Len())
+	resource := traces.ResourceSpans().At(0).Resource().Attributes()
+	assert.Equal(t, map[string]interface{}{"service.name": "orders", "temporal.namespace": "orders"}, attributesToMap(resource))
+
+	spans := spansByName(traces)
+	require.Len(t, spans, 5)
+
+	root := spans["RunWorkflow:Checkout"]
+	assert.Equal(t, "2c1f7d1e8a5b4c3d9e0f1a2b3c4d5e6f", root.TraceID().HexString())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, pdata.SpanKindServer, root.Kind())
+	assert.Equal(t, pdata.StatusCodeError, root.Status().Code())
+	assert.Equal(t, "shipping failed", root.Status().Message())
+	assert.Equal(t, 9*time.Second, time.Duration(root.EndTimestamp()-root.StartTimestamp()))
+	assert.Equal(t, map[string]interface{}{
+		"temporal.workflow.id":             "checkout-1",
+		"temporal.workflow.run_id":         testRunID,
+		"temporal.workflow.type":           "Checkout",
+		"temporal.workflow.status":         "failed",
+		"temporal.workflow.history_length": int64(15),
+		"temporal.task_queue":              "orders",
+	}, attributesToMap(root.Attributes()))
+	require.Equal(t, 1, root.Events().Len())
+	assert.Equal(t, "WorkflowExecutionSignaled", root.Events().At(0).Name())
+
+	activity := spans["RunActivity:ChargeCard"]
+	assert.Equal(t, root.TraceID(), activity.TraceID())
+	assert.Equal(t, root.SpanID(), activity.ParentSpanID())
+	assert.Equal(t, time.Second, time.Duration(activity.EndTimestamp()-activity.StartTimestamp()))
+	assert.Equal(t, pdata.StatusCodeUnset, activity.Status().Code())
+	assert.Equal(t, map[string]interface{}{
+		"temporal.activity.id":                   "5",
+		"temporal.activity.type":                 "ChargeCard",
+		"temporal.task_queue":                    "payments",
+		"temporal.activity.attempt":              int64(2),
+		"temporal.activity.schedule_to_start_ms": int64(100),
+		"temporal.worker.identity":               "worker-1@host",
+		"temporal.outcome":                       "completed",
+	}, attributesToMap(activity.Attributes()))
+
+	timer := spans["Timer:backoff"]
+	assert.Equal(t, 5*time.Second, time.Duration(timer.EndTimestamp()-timer.StartTimestamp()))
+	outcome, _ := timer.Attributes().Get(attributeOutcome)
+	assert.Equal(t, "fired", outcome.StringVal())
+
+	child := spans["StartChildWorkflow:Ship"]
+	assert.Equal(t, pdata.SpanKindClient, child.Kind())
+	assert.Equal(t, pdata.StatusCodeError, child.Status().Code())
+	assert.Equal(t, "carrier unavailable", child.Status().Message())
+	require.Equal(t, 1, child.Links().Len())
+	assert.Equal(t, "6f1c9a523b1e4d2a9c550d3f6e1a2b3c", child.Links().At(0).TraceID().HexString())
+
+	unfinished := spans["RunActivity:Refund"]
+	assert.Equal(t, root.EndTimestamp(), unfinished.EndTimestamp())
+	outcome, _ = unfinished.Attributes().Get(attributeOutcome)
+	assert.Equal(t, "unfinished", outcome.StringVal())
+}
+
+func TestAppendWorkflowTraceIsDeterministic(t *testing.T) {
+	first := pdata.NewTraces()
+	appendWorkflowTrace(first, "orders", testExecutionInfo(), loadHistory(t))
+	second := pdata.NewTraces()
+	appendWorkflowTrace(second, "orders", testExecutionInfo(), loadHistory(t))
+
+	firstSpans, secondSpans := spansByName(first), spansByName(second)
+	for name, span := range firstSpans {
+		assert.Equal(t, span.SpanID(), secondSpans[name].SpanID(), name)
+	}
+}
+
+func TestAppendWorkflowTraceChild(t *testing.T) {
+	info := testExecutionInfo()
+	info.Status = "Completed"
+	info.TaskQueue = ""
+	info.ParentExecution = &workflowExecution{WorkflowID: "parent", RunID: "not-a-uuid"}
+
+	traces := pdata.NewTraces()
+	appendWorkflowTrace(traces, "orders", info, nil)
+
+	name, _ := traces.ResourceSpans().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, defaultServiceName, name.StringVal())
+	root := traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.StatusCodeOk, root.Status().Code())
+	require.Equal(t, 1, root.Links().Len())
+	assert.Equal(t, traceIDFromRunID("not-a-uuid"), root.Links().At(0).TraceID())
+	assert.Equal(t, spanIDFromEvent("not-a-uuid", 0), root.Links().At(0).SpanID())
+}
+
+func TestNormalizeEnum(t *testing.T) {
+	assert.Equal(t, "timerfired", normalizeEnum("EVENT_TYPE_TIMER_FIRED", "EVENT_TYPE_"))
+	assert.Equal(t, "timerfired", normalizeEnum("TimerFired", "EVENT_TYPE_"))
+	assert.Equal(t, "timedout", normalizeEnum("WORKFLOW_EXECUTION_STATUS_TIMED_OUT", "WORKFLOW_EXECUTION_STATUS_"))
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{})
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		switch v.Type() {
+		case pdata.AttributeValueTypeInt:
+			m[k] = v.IntVal()
+		default:
+			m[k] = v.StringVal()
+		}
+		return true
+	})
+	return m
+}
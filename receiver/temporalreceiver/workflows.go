@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+// workflowSource polls the visibility API for workflow executions closed
+// since the last poll, and converts their histories to traces.
+type workflowSource struct {
+	cfg        *WorkflowsConfig
+	namespaces []string
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+var _ pollingsaas.Source = (*workflowSource)(nil)
+
+func newWorkflowSource(cfg *WorkflowsConfig, logger *zap.Logger) *workflowSource {
+	namespaces := cfg.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{defaultNamespace}
+	}
+	return &workflowSource{
+		cfg:        cfg,
+		namespaces: namespaces,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// namespaceCursor is the position reached in the closed workflow executions
+// of a namespace: the latest close time converted, and the runs closed at
+// that time, since several runs can close at the same time.
+type namespaceCursor struct {
+	CloseTime time.Time `json:"close_time"`
+	RunIDs    []string  `json:"run_ids,omitempty"`
+}
+
+// Poll implements pollingsaas.Source. The cursor maps namespaces to their
+// position, encoded as JSON.
+func (s *workflowSource) Poll(ctx context.Context, client *pollingsaas.Client, cursorValue string) (pollingsaas.Page, error) {
+	cursors := make(map[string]*namespaceCursor)
+	if cursorValue != "" {
+		if err := json.Unmarshal([]byte(cursorValue), &cursors); err != nil {
+			return pollingsaas.Page{}, fmt.Errorf("invalid cursor %q: %w", cursorValue, err)
+		}
+	}
+
+	api := &apiClient{endpoint: s.cfg.Endpoint, client: client}
+	traces := pdata.NewTraces()
+	hasMore := false
+	for _, namespace := range s.namespaces {
+		c, ok := cursors[namespace]
+		if !ok {
+			c = &namespaceCursor{CloseTime: s.now().Add(-s.cfg.InitialLookback).UTC()}
+			cursors[namespace] = c
+		}
+
+		executions, err := s.listClosed(ctx, api, namespace, c)
+		if err != nil {
+			return pollingsaas.Page{}, err
+		}
+		if len(executions) > s.cfg.MaxWorkflowsPerPoll {
+			executions = executions[:s.cfg.MaxWorkflowsPerPoll]
+			hasMore = true
+		}
+		for _, info := range executions {
+			events, err := api.getWorkflowExecutionHistory(ctx, namespace, info.Execution)
+			if err != nil {
+				return pollingsaas.Page{}, err
+			}
+			appendWorkflowTrace(traces, namespace, info, events)
+		}
+		c.advance(executions)
+	}
+
+	cursor, err := json.Marshal(cursors)
+	if err != nil {
+		return pollingsaas.Page{}, err
+	}
+	return pollingsaas.Page{Traces: traces, Cursor: string(cursor), HasMore: hasMore}, nil
+}
+
+// listClosed returns the workflow executions of the namespace closed since
+// the cursor, ordered by close time.
+func (s *workflowSource) listClosed(ctx context.Context, api *apiClient, namespace string, c *namespaceCursor) ([]workflowExecutionInfo, error) {
+	query := fmt.Sprintf(`CloseTime >= "%s" AND ExecutionStatus != "Running"`, c.CloseTime.UTC().Format(time.RFC3339Nano))
+	if s.cfg.Query != "" {
+		query += " AND (" + s.cfg.Query + ")"
+	}
+	seen := make(map[string]bool, len(c.RunIDs))
+	for _, runID := range c.RunIDs {
+		seen[runID] = true
+	}
+
+	var executions []workflowExecutionInfo
+	pageToken := ""
+	for {
+		resp, err := api.listClosedWorkflowExecutions(ctx, namespace, query, s.cfg.PageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range resp.Executions {
+			if info.CloseTime.IsZero() || info.CloseTime.Before(c.CloseTime) {
+				continue
+			}
+			if info.CloseTime.Equal(c.CloseTime) && seen[info.Execution.RunID] {
+				continue
+			}
+			executions = append(executions, info)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	sort.SliceStable(executions, func(i, j int) bool {
+		if !executions[i].CloseTime.Equal(executions[j].CloseTime) {
+			return executions[i].CloseTime.Before(executions[j].CloseTime)
+		}
+		return executions[i].Execution.RunID < executions[j].Execution.RunID
+	})
+	return executions, nil
+}
+
+// advance moves the cursor after the converted executions, ordered by close
+// time.
+func (c *namespaceCursor) advance(executions []workflowExecutionInfo) {
+	for _, info := range executions {
+		if info.CloseTime.After(c.CloseTime) {
+			c.CloseTime = info.CloseTime.UTC()
+			c.RunIDs = nil
+		}
+		c.RunIDs = append(c.RunIDs, info.Execution.RunID)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporalreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pollingsaas"
+)
+
+// fakeTemporal serves the closed executions of the "orders" namespace over
+// two pages, and the same history for every execution.
+type fakeTemporal struct {
+	t          *testing.T
+	executions []workflowExecutionInfo
+	history    []byte
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func (f *fakeTemporal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api/v1/namespaces/orders/workflows":
+		f.mu.Lock()
+		f.queries = append(f.queries, r.URL.Query().Get("query"))
+		f.mu.Unlock()
+		resp := listWorkflowExecutionsResponse{}
+		if r.URL.Query().Get("nextPageToken") == "" {
+			resp.Executions = f.executions[:len(f.executions)/2]
+			resp.NextPageToken = "page-2"
+		} else {
+			resp.Executions = f.executions[len(f.executions)/2:]
+		}
+		assert.NoError(f.t, json.NewEncoder(w).Encode(resp))
+	case strings.HasPrefix(r.URL.Path, "/api/v1/namespaces/orders/workflows/") && strings.HasSuffix(r.URL.Path, "/history"):
+		assert.NotEmpty(f.t, r.URL.Query().Get("execution.runId"))
+		_, _ = w.Write(f.history)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestSource(t *testing.T, executions []workflowExecutionInfo) (*workflowSource, *pollingsaas.Client, *fakeTemporal) {
+	history, err := ioutil.ReadFile("testdata/history.json")
+	require.NoError(t, err)
+	fake := &fakeTemporal{t: t, executions: executions, history: history}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Workflows.Endpoint = srv.URL
+	cfg.Workflows.Namespaces = []string{"orders"}
+	cfg.Workflows.Query = `WorkflowType = "Checkout"`
+	cfg.Workflows.RequestsPerSecond = 1000
+	source := newWorkflowSource(&cfg.Workflows, zap.NewNop())
+	source.now = func() time.Time { return time.Date(2021, 10, 5, 11, 0, 0, 0, time.UTC) }
+	return source, pollingsaas.NewClient(srv.Client(), nil, cfg.Workflows.Settings), fake
+}
+
+func testExecutions() []workflowExecutionInfo {
+	base := time.Date(2021, 10, 5, 10, 30, 0, 0, time.UTC)
+	closeTimes := []time.Time{base.Add(2 * time.Minute), base, base.Add(time.Minute), base.Add(time.Minute)}
+	runIDs := []string{
+		"00000000-0000-0000-0000-000000000004",
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000003",
+		"00000000-0000-0000-0000-000000000002",
+	}
+	executions := make([]workflowExecutionInfo, len(runIDs))
+	for i, runID := range runIDs {
+		executions[i] = workflowExecutionInfo{
+			Execution: workflowExecution{WorkflowID: "checkout", RunID: runID},
+			Type:      named{Name: "Checkout"},
+			StartTime: closeTimes[i].Add(-time.Minute),
+			CloseTime: closeTimes[i],
+			Status:    "WORKFLOW_EXECUTION_STATUS_COMPLETED",
+		}
+	}
+	return executions
+}
+
+func TestWorkflowSourcePoll(t *testing.T) {
+	source, client, fake := newTestSource(t, testExecutions())
+
+	page, err := source.Poll(context.Background(), client, "")
+	require.NoError(t, err)
+	assert.False(t, page.HasMore)
+	require.Equal(t, 4, page.Traces.ResourceSpans().Len())
+	for i, runID := range []string{"1", "2", "3", "4"} {
+		span := page.Traces.ResourceSpans().At(i).InstrumentationLibrarySpans().At(0).Spans().At(0)
+		assert.Equal(t, "0000000000000000000000000000000"+runID, span.TraceID().HexString())
+	}
+	assert.JSONEq(t, `{"orders": {"close_time": "2021-10-05T10:32:00Z", "run_ids": ["00000000-0000-0000-0000-000000000004"]}}`, page.Cursor)
+
+	require.Len(t, fake.queries, 2)
+	assert.Equal(t, `CloseTime >= "2021-10-05T10:00:00Z" AND ExecutionStatus != "Running" AND (WorkflowType = "Checkout")`, fake.queries[0])
+
+	// Executions already converted at the cursor close time are skipped.
+	page, err = source.Poll(context.Background(), client, page.Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 0, page.Traces.ResourceSpans().Len())
+	assert.Equal(t, `CloseTime >= "2021-10-05T10:32:00Z" AND ExecutionStatus != "Running" AND (WorkflowType = "Checkout")`, fake.queries[2])
+}
+
+func TestWorkflowSourcePollMaxWorkflows(t *testing.T) {
+	source, client, _ := newTestSource(t, testExecutions())
+	source.cfg.MaxWorkflowsPerPoll = 2
+
+	page, err := source.Poll(context.Background(), client, "")
+	require.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, 2, page.Traces.ResourceSpans().Len())
+	assert.JSONEq(t, `{"orders": {"close_time": "2021-10-05T10:31:00Z", "run_ids": ["00000000-0000-0000-0000-000000000002"]}}`, page.Cursor)
+
+	// The run closed at the same time as the last converted one is not
+	// skipped.
+	page, err = source.Poll(context.Background(), client, page.Cursor)
+	require.NoError(t, err)
+	assert.False(t, page.HasMore)
+	require.Equal(t, 2, page.Traces.ResourceSpans().Len())
+	span := page.Traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, "00000000000000000000000000000003", span.TraceID().HexString())
+}
+
+func TestWorkflowSourcePollErrors(t *testing.T) {
+	source, client, _ := newTestSource(t, nil)
+
+	_, err := source.Poll(context.Background(), client, "not json")
+	assert.Error(t, err)
+
+	source.namespaces = []string{"unknown"}
+	_, err = source.Poll(context.Background(), client, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list workflow executions of namespace unknown")
+}
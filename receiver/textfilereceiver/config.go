@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textfilereceiver
+
+import (
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/scraperhelper"
+)
+
+// Config defines configuration for the textfile receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// Directory is the directory the *.prom files are read from.
+	Directory string `mapstructure:"directory"`
+}
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("directory must be specified")
+	}
+	return nil
+}
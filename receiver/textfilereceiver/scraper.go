@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textfilereceiver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/prommetrics"
+)
+
+const (
+	fileExtension = ".prom"
+
+	mtimeMetric       = "textfile_mtime_seconds"
+	scrapeErrorMetric = "textfile_scrape_error"
+	fileAttribute     = "file"
+)
+
+// fileState is what is remembered about a file between scrapes.
+type fileState struct {
+	// start is the time the file was first read, at which its cumulative
+	// metrics start.
+	start time.Time
+	// metrics are the metrics last read from the file, used to mark them
+	// stale when the file is removed.
+	metrics pdata.MetricSlice
+}
+
+type textfileScraper struct {
+	logger *zap.Logger
+	cfg    *Config
+	now    func() time.Time
+
+	files map[string]*fileState
+}
+
+func newTextfileScraper(logger *zap.Logger, cfg *Config) *textfileScraper {
+	return &textfileScraper{
+		logger: logger,
+		cfg:    cfg,
+		now:    time.Now,
+		files:  make(map[string]*fileState),
+	}
+}
+
+// scrape reads the metrics of the *.prom files of the directory. Files are
+// expected to be replaced atomically, by writing a temporary file and renaming
+// it, and are read as a whole from a single open file. A file that can't be
+// read or parsed is skipped, and the metrics of a removed file are marked
+// stale once.
+func (s *textfileScraper) scrape(context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+	now := s.now()
+	ts := pdata.NewTimestampFromTime(now)
+
+	names, err := s.listFiles()
+	if err != nil {
+		return metrics, err
+	}
+
+	var errs error
+	failed := 0
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+		families, mtime, err := readFile(filepath.Join(s.cfg.Directory, name))
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			failed++
+			continue
+		}
+
+		state, ok := s.files[name]
+		if !ok {
+			state = &fileState{start: now}
+			s.files[name] = state
+		}
+		state.metrics = pdata.NewMetricSlice()
+		start := pdata.NewTimestampFromTime(state.start)
+		for _, mf := range families {
+			prommetrics.AppendFamily(state.metrics, mf, start, ts, nil)
+		}
+		for i := 0; i < state.metrics.Len(); i++ {
+			state.metrics.At(i).CopyTo(metrics.AppendEmpty())
+		}
+		appendGauge(metrics, mtimeMetric, "Unix time of the last modification of the textfile.", ts, float64(mtime.UnixNano())/1e9, name)
+	}
+
+	for name, state := range s.files {
+		if !seen[name] {
+			appendStale(metrics, state.metrics, ts)
+			delete(s.files, name)
+		}
+	}
+
+	scrapeError := 0.0
+	if errs != nil {
+		scrapeError = 1
+	}
+	appendGauge(metrics, scrapeErrorMetric, "1 if there was an error reading a textfile, 0 otherwise.", ts, scrapeError, "")
+	if errs != nil {
+		return metrics, scrapererror.NewPartialScrapeError(errs, failed)
+	}
+	return metrics, nil
+}
+
+// listFiles returns the names of the *.prom files of the directory, sorted.
+func (s *textfileScraper) listFiles() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.cfg.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %q: %w", s.cfg.Directory, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), fileExtension) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readFile reads the metric families of a file, sorted by name, and its
+// modification time.
+func readFile(path string) ([]*dto.MetricFamily, time.Time, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	// The modification time is the one of the file that is read, even if
+	// it has been replaced since it was listed.
+	info, err := f.Stat()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to stat %q: %w", path, err)
+	}
+	parsed, err := new(expfmt.TextParser).TextToMetricFamilies(f)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+
+	families := make([]*dto.MetricFamily, 0, len(parsed))
+	for _, mf := range parsed {
+		if err := prommetrics.Validate(mf); err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid metrics in %q: %w", path, err)
+		}
+		families = append(families, mf)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].GetName() < families[j].GetName() })
+	return families, info.ModTime(), nil
+}
+
+// appendGauge appends a gauge with a single data point, with the file
+// attribute if file isn't empty.
+func appendGauge(metrics pdata.MetricSlice, name, description string, ts pdata.Timestamp, value float64, file string) {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetDataType(pdata.MetricDataTypeGauge)
+	dp := metric.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleVal(value)
+	if file != "" {
+		dp.Attributes().UpsertString(fileAttribute, file)
+	}
+}
+
+// appendStale appends the metrics of a removed file, with their data points
+// flagged as having no recorded value, so that backends mark the series as
+// stale rather than waiting for them to time out.
+func appendStale(metrics pdata.MetricSlice, removed pdata.MetricSlice, ts pdata.Timestamp) {
+	flags := pdata.NewMetricDataPointFlags(pdata.MetricDataPointFlagNoRecordedValue)
+	for i := 0; i < removed.Len(); i++ {
+		metric := metrics.AppendEmpty()
+		removed.At(i).CopyTo(metric)
+		switch metric.DataType() {
+		case pdata.MetricDataTypeGauge:
+			markStaleNumbers(metric.Gauge().DataPoints(), flags, ts)
+		case pdata.MetricDataTypeSum:
+			markStaleNumbers(metric.Sum().DataPoints(), flags, ts)
+		case pdata.MetricDataTypeHistogram:
+			dps := metric.Histogram().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetFlags(flags)
+				dps.At(j).SetTimestamp(ts)
+			}
+		case pdata.MetricDataTypeSummary:
+			dps := metric.Summary().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetFlags(flags)
+				dps.At(j).SetTimestamp(ts)
+			}
+		}
+	}
+}
+
+func markStaleNumbers(dps pdata.NumberDataPointSlice, flags pdata.MetricDataPointFlags, ts pdata.Timestamp) {
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).SetFlags(flags)
+		dps.At(i).SetTimestamp(ts)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textfilereceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+)
+
+func newTestScraper(t *testing.T) (*textfileScraper, string, *time.Time) {
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = dir
+	s := newTextfileScraper(zap.NewNop(), cfg)
+	now := time.Date(2021, 10, 4, 15, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, dir, &now
+}
+
+// writeFile writes a file the way it is expected to be done, to a temporary
+// file renamed once complete.
+func writeFile(t *testing.T, dir, name, content string) {
+	tmp := filepath.Join(dir, name+".tmp")
+	require.NoError(t, ioutil.WriteFile(tmp, []byte(content), 0600))
+	require.NoError(t, os.Rename(tmp, filepath.Join(dir, name)))
+}
+
+func metricsByName(metrics pdata.MetricSlice) map[string][]pdata.Metric {
+	byName := make(map[string][]pdata.Metric)
+	for i := 0; i < metrics.Len(); i++ {
+		byName[metrics.At(i).Name()] = append(byName[metrics.At(i).Name()], metrics.At(i))
+	}
+	return byName
+}
+
+func TestScrape(t *testing.T) {
+	s, dir, now := newTestScraper(t)
+	start := *now
+	writeFile(t, dir, "backup.prom", "# HELP backup_last_success_seconds Last successful backup.\n# TYPE backup_last_success_seconds gauge\nbackup_last_success_seconds{db=\"orders\"} 1633359600\n")
+	writeFile(t, dir, "apt.prom", "# TYPE apt_upgrades_total counter\napt_upgrades_total 12\n")
+	writeFile(t, dir, "ignored.txt", "ignored 1\n")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dir.prom"), 0700))
+
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	byName := metricsByName(metrics)
+	require.Len(t, byName, 4)
+	assert.NotContains(t, byName, "ignored")
+
+	gauge := byName["backup_last_success_seconds"][0]
+	assert.Equal(t, "Last successful backup.", gauge.Description())
+	dp := gauge.Gauge().DataPoints().At(0)
+	assert.Equal(t, 1633359600.0, dp.DoubleVal())
+	assert.Equal(t, map[string]interface{}{"db": "orders"}, dp.Attributes().AsRaw())
+
+	mtimes := byName[mtimeMetric]
+	require.Len(t, mtimes, 2)
+	file, _ := mtimes[0].Gauge().DataPoints().At(0).Attributes().Get(fileAttribute)
+	assert.Equal(t, "apt.prom", file.StringVal())
+	info, err := os.Stat(filepath.Join(dir, "apt.prom"))
+	require.NoError(t, err)
+	assert.Equal(t, float64(info.ModTime().UnixNano())/1e9, mtimes[0].Gauge().DataPoints().At(0).DoubleVal())
+
+	assert.Equal(t, 0.0, byName[scrapeErrorMetric][0].Gauge().DataPoints().At(0).DoubleVal())
+
+	// Cumulative metrics start when the file was first read.
+	*now = now.Add(time.Minute)
+	writeFile(t, dir, "apt.prom", "# TYPE apt_upgrades_total counter\napt_upgrades_total 15\n")
+	metrics, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	sum := metricsByName(metrics)["apt_upgrades_total"][0].Sum().DataPoints().At(0)
+	assert.Equal(t, 15.0, sum.DoubleVal())
+	assert.Equal(t, pdata.NewTimestampFromTime(start), sum.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(*now), sum.Timestamp())
+}
+
+func TestScrapeRemovedFile(t *testing.T) {
+	s, dir, now := newTestScraper(t)
+	writeFile(t, dir, "backup.prom", "backup_last_success_seconds 1633359600\n")
+	_, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "backup.prom")))
+	*now = now.Add(time.Minute)
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	stale := metricsByName(metrics)["backup_last_success_seconds"]
+	require.Len(t, stale, 1)
+	dp := stale[0].Gauge().DataPoints().At(0)
+	assert.True(t, dp.Flags().HasFlag(pdata.MetricDataPointFlagNoRecordedValue))
+	assert.Equal(t, pdata.NewTimestampFromTime(*now), dp.Timestamp())
+	assert.NotContains(t, metricsByName(metrics), mtimeMetric)
+
+	// Series are only marked stale once.
+	metrics, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, metricsByName(metrics), "backup_last_success_seconds")
+}
+
+func TestScrapeInvalidFile(t *testing.T) {
+	s, dir, _ := newTestScraper(t)
+	writeFile(t, dir, "good.prom", "good 1\n")
+	writeFile(t, dir, "syntax.prom", "bad{ 1\n")
+	writeFile(t, dir, "timestamp.prom", "timestamped 1 1633359600000\n")
+
+	metrics, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), "failed to parse")
+	assert.Contains(t, err.Error(), `metric family "timestamped" must not have timestamps`)
+
+	byName := metricsByName(metrics)
+	assert.Contains(t, byName, "good")
+	assert.NotContains(t, byName, "timestamped")
+	assert.Len(t, byName[mtimeMetric], 1)
+	assert.Equal(t, 1.0, byName[scrapeErrorMetric][0].Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestScrapeMissingDirectory(t *testing.T) {
+	s, dir, _ := newTestScraper(t)
+	s.cfg.Directory = filepath.Join(dir, "missing")
+	_, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.False(t, scrapererror.IsPartialScrapeError(err))
+}
@@ -17,6 +17,8 @@ package zipkinreceiver
 import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/baggage"
 )
 
 // Config defines configuration for Zipkin receiver.
@@ -27,6 +29,8 @@ type Config struct {
 	// If enabled the zipkin receiver will attempt to parse string tags/binary annotations into int/bool/float.
 	// Disabled by default
 	ParseStringTags bool `mapstructure:"parse_string_tags"`
+	// Baggage configures the W3C baggage members of requests promoted to span attributes.
+	Baggage baggage.Config `mapstructure:"baggage"`
 }
 
 var _ config.Receiver = (*Config)(nil)
@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/baggage"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -37,7 +39,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 3)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
@@ -60,4 +62,17 @@ func TestLoadConfig(t *testing.T) {
 			},
 			ParseStringTags: true,
 		})
+
+	r3 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "baggage")].(*Config)
+	assert.Equal(t, r3,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "baggage")),
+			HTTPServerSettings: confighttp.HTTPServerSettings{
+				Endpoint: "0.0.0.0:9411",
+			},
+			Baggage: baggage.Config{
+				Keys:   []string{"tenant", "feature_flag"},
+				Prefix: "baggage.",
+			},
+		})
 }
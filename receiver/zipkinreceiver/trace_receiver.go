@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/baggage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv1"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv2"
 )
@@ -64,6 +65,8 @@ type zipkinReceiver struct {
 	protobufUnmarshaler      pdata.TracesUnmarshaler
 	protobufDebugUnmarshaler pdata.TracesUnmarshaler
 
+	baggagePromoter *baggage.Promoter
+
 	settings component.TelemetrySettings
 }
 
@@ -84,6 +87,7 @@ func newReceiver(config *Config, nextConsumer consumer.Traces, settings componen
 		jsonUnmarshaler:          zipkinv2.NewJSONTracesUnmarshaler(config.ParseStringTags),
 		protobufUnmarshaler:      zipkinv2.NewProtobufTracesUnmarshaler(false, config.ParseStringTags),
 		protobufDebugUnmarshaler: zipkinv2.NewProtobufTracesUnmarshaler(true, config.ParseStringTags),
+		baggagePromoter:          baggage.NewPromoter(config.Baggage),
 		settings:                 settings,
 	}
 	return zr, nil
@@ -228,6 +232,7 @@ func (zr *zipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	zr.baggagePromoter.PromoteTraces(r.Header, td)
 
 	consumerErr := zr.nextConsumer.ConsumeTraces(ctx, td)
 
@@ -41,6 +41,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/pdata"
 	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/baggage"
 )
 
 const (
@@ -421,6 +423,35 @@ func TestReceiverConvertsStringsToTypes(t *testing.T) {
 	assert.EqualValues(t, expected, actual)
 }
 
+func TestReceiverPromotesBaggage(t *testing.T) {
+	body, err := ioutil.ReadFile(zipkinV2Single)
+	require.NoError(t, err, "Failed to read sample JSON file: %v", err)
+
+	r := httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBuffer(body))
+	r.Header.Add("content-type", "application/json")
+	r.Header.Add("baggage", "tenant=acme,user=alice")
+
+	next := new(consumertest.TracesSink)
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(zipkinReceiverID),
+		Baggage:          baggage.Config{Keys: []string{"tenant"}, Prefix: "baggage."},
+	}
+	zr, err := newReceiver(cfg, next, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	req := httptest.NewRecorder()
+	zr.ServeHTTP(req, r)
+	require.Equal(t, 202, req.Code)
+
+	require.Len(t, next.AllTraces(), 1)
+	span := next.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	tenant, ok := span.Attributes().Get("baggage.tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.StringVal())
+	_, ok = span.Attributes().Get("baggage.user")
+	assert.False(t, ok)
+}
+
 func TestFromBytesWithNoTimestamp(t *testing.T) {
 	noTimestampBytes, err := ioutil.ReadFile(zipkinV2NoTimestamp)
 	require.NoError(t, err, "Failed to read sample JSON file: %v", err)